@@ -0,0 +1,89 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ChangeFreezeKind = "ChangeFreeze"
+)
+
+// ChangeFreezeSpec defines periods during which the Terraform objects of the selected namespaces
+// keep planning, but do not apply their plans.
+type ChangeFreezeSpec struct {
+	// Windows are the time ranges of the freeze.
+	// +required
+	Windows []FreezeWindow `json:"windows"`
+
+	// NamespaceSelector selects the namespaces of the frozen Terraform objects, by their labels.
+	// All namespaces are frozen when empty.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Reason of the freeze, shown in the status of the frozen Terraform objects.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// FreezeWindow is a time range, from Start included to End excluded.
+type FreezeWindow struct {
+	// +required
+	Start metav1.Time `json:"start"`
+
+	// +required
+	End metav1.Time `json:"end"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".spec.reason",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// ChangeFreeze is the Schema for the changefreezes API
+type ChangeFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ChangeFreezeSpec `json:"spec,omitempty"`
+}
+
+// ActiveWindow returns the window of the freeze containing now, if any.
+func (in ChangeFreeze) ActiveWindow(now time.Time) (FreezeWindow, bool) {
+	for _, w := range in.Spec.Windows {
+		if !now.Before(w.Start.Time) && now.Before(w.End.Time) {
+			return w, true
+		}
+	}
+	return FreezeWindow{}, false
+}
+
+//+kubebuilder:object:root=true
+
+// ChangeFreezeList contains a list of ChangeFreeze
+type ChangeFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChangeFreeze `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChangeFreeze{}, &ChangeFreezeList{})
+}
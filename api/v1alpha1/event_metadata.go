@@ -0,0 +1,38 @@
+package v1alpha1
+
+// EventMetadataSchemaVersion is the version of the schema of the metadata of the events of the Terraform objects,
+// which notification-controller passes to the providers and the alert templates. Keys are only added to a version.
+// Renaming or removing a key, or changing the format of its value, makes a new version.
+const EventMetadataSchemaVersion = "v1"
+
+// The keys of the metadata of the events, in the v1 schema. The keys are prefixed with the API group,
+// and the values are strings.
+const (
+	// EventMetadataSchemaKey is the version of the schema, set on every event.
+	EventMetadataSchemaKey = "infra.contrib.fluxcd.io/metadata-schema"
+
+	// EventMetadataRevisionKey is the revision of the source, e.g. main/b8e362c206e3d0cbb7ed22ced771a0056455a2fb.
+	EventMetadataRevisionKey = "infra.contrib.fluxcd.io/revision"
+
+	// EventMetadataWorkspaceKey is the Terraform workspace of the object, set on every event.
+	EventMetadataWorkspaceKey = "infra.contrib.fluxcd.io/workspace"
+
+	// EventMetadataPlanIDKey is the plan pending, or being applied, e.g. plan-main-b8e362c206.
+	EventMetadataPlanIDKey = "infra.contrib.fluxcd.io/plan-id"
+
+	// EventMetadataChangeCountKey is the number of the changes of the plan, on the events of the new plans.
+	EventMetadataChangeCountKey = "infra.contrib.fluxcd.io/change-count"
+
+	// EventMetadataDurationKey is the duration of the operation reported by the event, in seconds, e.g. 12.5.
+	EventMetadataDurationKey = "infra.contrib.fluxcd.io/duration-seconds"
+
+	// EventMetadataInventoryAddedKey and EventMetadataInventoryRemovedKey are the numbers of the resources
+	// added to and removed from the inventory, on the events of the applies with the inventory enabled.
+	EventMetadataInventoryAddedKey   = "infra.contrib.fluxcd.io/inventory-added"
+	EventMetadataInventoryRemovedKey = "infra.contrib.fluxcd.io/inventory-removed"
+
+	// EventMetadataBreakGlassTokenKey and EventMetadataBreakGlassUserKey are the identifier and the user
+	// of the break-glass token of the event.
+	EventMetadataBreakGlassTokenKey = "infra.contrib.fluxcd.io/break-glass-token"
+	EventMetadataBreakGlassUserKey  = "infra.contrib.fluxcd.io/break-glass-user"
+)
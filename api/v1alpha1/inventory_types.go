@@ -16,6 +16,12 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // ResourceInventory contains a list of Kubernetes resource object references that have been applied by a Kustomization.
 type ResourceInventory struct {
 	// Entries of Kubernetes resource object references.
@@ -32,4 +38,149 @@ type ResourceRef struct {
 
 	// ID is the resource identifier. This is cloud-specific. For example, ARN is an ID on AWS.
 	Identifier string `json:"id"`
+
+	// Object is the Kubernetes object managed by the resource, for the resources of the kubernetes provider.
+	// +optional
+	Object *ObjectRef `json:"obj,omitempty"`
+}
+
+// ObjectRef locates a Kubernetes object of the cluster of the controller.
+type ObjectRef struct {
+	// APIVersion of the object.
+	APIVersion string `json:"v"`
+
+	// Kind of the object.
+	Kind string `json:"k"`
+
+	// Namespace of the object, empty for the cluster-scoped objects.
+	// +optional
+	Namespace string `json:"ns,omitempty"`
+
+	// Name of the object.
+	Name string `json:"n"`
+}
+
+// String returns the object as "<apiVersion> <kind> <namespace>/<name>".
+func (in ObjectRef) String() string {
+	name := in.Name
+	if in.Namespace != "" {
+		name = in.Namespace + "/" + in.Name
+	}
+	return in.APIVersion + " " + in.Kind + " " + name
+}
+
+// Find returns the entry of the resource with the identifier, e.g. an ARN.
+func (in *ResourceInventory) Find(identifier string) (ResourceRef, bool) {
+	if in == nil || identifier == "" {
+		return ResourceRef{}, false
+	}
+
+	for _, entry := range in.Entries {
+		if entry.Identifier == identifier {
+			return entry, true
+		}
+	}
+	return ResourceRef{}, false
+}
+
+// Identifiers returns the identifiers of the entries, skipping the resources without one.
+func (in *ResourceInventory) Identifiers() []string {
+	if in == nil {
+		return nil
+	}
+
+	var ids []string
+	for _, entry := range in.Entries {
+		if entry.Identifier != "" {
+			ids = append(ids, entry.Identifier)
+		}
+	}
+	return ids
+}
+
+// Objects returns the Kubernetes objects of the entries.
+func (in *ResourceInventory) Objects() []ObjectRef {
+	if in == nil {
+		return nil
+	}
+
+	var objects []ObjectRef
+	for _, entry := range in.Entries {
+		if entry.Object != nil {
+			objects = append(objects, *entry.Object)
+		}
+	}
+	return objects
+}
+
+// InventoryDiff is the change of the inventory made by an apply.
+type InventoryDiff struct {
+	// FromRevision is the revision of the previous apply, which the inventory is compared with.
+	// +optional
+	FromRevision string `json:"fromRevision,omitempty"`
+
+	// Revision of the apply.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Added are the resources created by the apply. A replaced resource is both removed and added, with a new identifier.
+	// +optional
+	Added []ResourceRef `json:"added,omitempty"`
+
+	// Removed are the resources destroyed by the apply.
+	// +optional
+	Removed []ResourceRef `json:"removed,omitempty"`
+}
+
+// DiffInventory returns the resources of the entries missing from the inventory, and the resources of the inventory
+// missing from the entries. The resources are told apart by their type, name and identifier.
+func DiffInventory(inventory *ResourceInventory, entries []ResourceRef) (added []ResourceRef, removed []ResourceRef) {
+	key := func(entry ResourceRef) string {
+		return entry.Type + "." + entry.Name + "#" + entry.Identifier
+	}
+
+	previous := map[string]bool{}
+	if inventory != nil {
+		for _, entry := range inventory.Entries {
+			previous[key(entry)] = true
+		}
+	}
+	current := map[string]bool{}
+	for _, entry := range entries {
+		current[key(entry)] = true
+		if !previous[key(entry)] {
+			added = append(added, entry)
+		}
+	}
+	if inventory != nil {
+		for _, entry := range inventory.Entries {
+			if !current[key(entry)] {
+				removed = append(removed, entry)
+			}
+		}
+	}
+	return added, removed
+}
+
+// Summary counts the added and the removed resources by type, e.g. "3 aws_s3_bucket added, 1 aws_iam_role removed",
+// or returns an empty string when the inventory is unchanged.
+func (in InventoryDiff) Summary() string {
+	count := func(entries []ResourceRef, verb string) []string {
+		counts := map[string]int{}
+		for _, entry := range entries {
+			counts[entry.Type]++
+		}
+		types := make([]string, 0, len(counts))
+		for t := range counts {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		var parts []string
+		for _, t := range types {
+			parts = append(parts, fmt.Sprintf("%d %s %s", counts[t], t, verb))
+		}
+		return parts
+	}
+	return strings.Join(append(count(in.Added, "added"), count(in.Removed, "removed")...), ", ")
 }
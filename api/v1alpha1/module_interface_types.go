@@ -0,0 +1,58 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ModuleInterface contains the input variables and the outputs declared by the Terraform module of an object.
+type ModuleInterface struct {
+	// Variables declared by the module, sorted by name.
+	// +optional
+	Variables []ModuleVariable `json:"variables,omitempty"`
+
+	// Outputs declared by the module, sorted by name.
+	// +optional
+	Outputs []ModuleOutput `json:"outputs,omitempty"`
+}
+
+// ModuleVariable describes an input variable of a Terraform module.
+type ModuleVariable struct {
+	Name string `json:"name"`
+
+	// Type is the type constraint of the variable as written in the module, e.g. list(string).
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Required is true when the variable has no default value, and must be provided.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// ModuleOutput describes an output of a Terraform module.
+type ModuleOutput struct {
+	Name string `json:"name"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/fluxcd/pkg/apis/meta"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProviderConfigKind     = "ProviderConfig"
+	ProviderConfigIndexKey = ".metadata.providerConfig"
+)
+
+// ProviderConfigSpec defines the configuration of a Terraform provider,
+// shared by the Terraform objects referring to it.
+type ProviderConfigSpec struct {
+	// Provider is the local name of the Terraform provider to configure.
+	// +kubebuilder:validation:Enum=aws;google;azurerm;kubernetes
+	// +required
+	Provider string `json:"provider"`
+
+	// Alias of the provider configuration, for modules using more than one
+	// configuration of the same provider.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// Settings are the arguments of the provider block, e.g. the region of the aws provider.
+	// +optional
+	Settings *apiextensionsv1.JSON `json:"settings,omitempty"`
+
+	// CredentialsSecretRef refers to a Secret whose keys are set as environment
+	// variables of Terraform, e.g. AWS_ACCESS_KEY_ID or ARM_CLIENT_SECRET.
+	// +optional
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialFiles are files created from Secrets for the providers reading
+	// their credentials from a file, e.g. a kubeconfig or a service account key.
+	// +optional
+	CredentialFiles []FileMapping `json:"credentialFiles,omitempty"`
+
+	// WorkloadIdentity federates the service account of the runner pod with the
+	// cloud provider, instead of using static credentials. It cannot be used
+	// together with CredentialsSecretRef.
+	// +optional
+	WorkloadIdentity *WorkloadIdentitySpec `json:"workloadIdentity,omitempty"`
+}
+
+// WorkloadIdentitySpec defines how a projected service account token of the runner
+// pod is exchanged for the credentials of a cloud provider.
+type WorkloadIdentitySpec struct {
+	// Audience of the projected service account token. Defaults to sts.amazonaws.com for aws,
+	// to api://AzureADTokenExchange for azurerm, and to the workload identity provider for google.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds of the projected service account token. Defaults to 3600.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+
+	// RoleARN is the IAM role assumed through AWS STS. Required for aws.
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// WorkloadIdentityProvider is the full resource name of the workload identity pool provider,
+	// e.g. projects/123/locations/global/workloadIdentityPools/my-pool/providers/my-provider.
+	// Required for google.
+	// +optional
+	WorkloadIdentityProvider string `json:"workloadIdentityProvider,omitempty"`
+
+	// ServiceAccountEmail is the Google service account to impersonate with the federated token.
+	// +optional
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+
+	// ClientID of the Azure AD application. Required for azurerm.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// TenantID of the Azure AD application. Required for azurerm.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.provider",description=""
+// +kubebuilder:printcolumn:name="Alias",type="string",JSONPath=".spec.alias",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// ProviderConfig is the Schema for the providerconfigs API
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProviderConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
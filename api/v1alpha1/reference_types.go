@@ -38,6 +38,22 @@ func (s *CrossNamespaceSourceReference) String() string {
 	return fmt.Sprintf("%s/%s", s.Kind, s.Name)
 }
 
+// DependsOnReference refers to a Terraform object that must be ready before this one is reconciled.
+type DependsOnReference struct {
+	// Name of the referent.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the referent, defaults to the namespace of the Kubernetes resource object that contains the reference.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// RequiredOutputs are the keys that must be present, with a non-empty value,
+	// in the output Secret of the referent.
+	// +optional
+	RequiredOutputs []string `json:"requiredOutputs,omitempty"`
+}
+
 type FileMapping struct {
 	// Reference to a Secret that contains the file content
 	SecretRef meta.SecretKeyReference `json:"secretRef"`
@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// Revision is the revision of the artifact of a source, parsed from any of the formats of the source-controller versions:
+// <ref>/<digest> or <digest> for the older ones, e.g. main/5394cb7f48332b2de7c17dd8b8384bbc84b7e738,
+// and <ref>@<algorithm>:<digest> or <algorithm>:<digest> for the newer ones, e.g. main@sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738.
+type Revision struct {
+	// Ref is the branch or the tag of the revision, empty when the revision is only a digest.
+	Ref string
+	// Algorithm of the digest, e.g. sha1 or sha256. It is guessed from the length of the digests of the older formats,
+	// and left empty if it can't be.
+	Algorithm string
+	// Digest is the hex encoded digest.
+	Digest string
+}
+
+// ParseRevision parses the revision of an artifact, in any format.
+func ParseRevision(revision string) Revision {
+	var r Revision
+	if i := strings.LastIndex(revision, "@"); i >= 0 {
+		r.Ref, revision = revision[:i], revision[i+1:]
+		r.Algorithm, r.Digest = splitDigest(revision)
+		return r
+	}
+
+	if algorithm, digest := splitDigest(revision); algorithm != "" {
+		r.Algorithm, r.Digest = algorithm, digest
+		return r
+	}
+
+	// a ref of the older formats may have slashes, the digest never has one
+	if i := strings.LastIndex(revision, "/"); i >= 0 {
+		r.Ref, revision = revision[:i], revision[i+1:]
+	}
+	r.Digest = revision
+	switch len(r.Digest) {
+	case 40:
+		r.Algorithm = "sha1"
+	case 64:
+		r.Algorithm = "sha256"
+	}
+	return r
+}
+
+// digestAlgorithms are the algorithms of the digests of the revisions of the newer formats.
+var digestAlgorithms = map[string]bool{"sha1": true, "sha256": true, "sha384": true, "sha512": true, "blake3": true}
+
+func splitDigest(s string) (algorithm string, digest string) {
+	if i := strings.Index(s, ":"); i >= 0 && digestAlgorithms[s[:i]] {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// String returns the revision in the format of the newer source-controller versions.
+func (r Revision) String() string {
+	s := r.Digest
+	if r.Algorithm != "" {
+		s = r.Algorithm + ":" + s
+	}
+	if r.Ref != "" {
+		s = r.Ref + "@" + s
+	}
+	return s
+}
+
+// ShortDigest returns the first 10 characters of the digest.
+func (r Revision) ShortDigest() string {
+	if len(r.Digest) > 10 {
+		return r.Digest[:10]
+	}
+	return r.Digest
+}
+
+// status returns the revision as reported in the status, or nil for an empty revision.
+func (r Revision) status() *RevisionStatus {
+	if r.Digest == "" && r.Ref == "" {
+		return nil
+	}
+	digest := r.Digest
+	if r.Algorithm != "" {
+		digest = r.Algorithm + ":" + digest
+	}
+	return &RevisionStatus{Revision: r.String(), Ref: r.Ref, Digest: digest}
+}
+
+// RevisionStatus is a revision of the source, normalized to the format of the newer source-controller versions.
+type RevisionStatus struct {
+	// Revision is the normalized revision, <ref>@<algorithm>:<digest>.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Ref is the branch or the tag of the revision.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Digest of the revision, <algorithm>:<digest>.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// RevisionsStatus holds the revisions of the status, normalized.
+type RevisionsStatus struct {
+	// LastApplied is the normalized LastAppliedRevision.
+	// +optional
+	LastApplied *RevisionStatus `json:"lastApplied,omitempty"`
+
+	// LastAttempted is the normalized LastAttemptedRevision.
+	// +optional
+	LastAttempted *RevisionStatus `json:"lastAttempted,omitempty"`
+
+	// LastPlanned is the normalized LastPlannedRevision.
+	// +optional
+	LastPlanned *RevisionStatus `json:"lastPlanned,omitempty"`
+}
+
+// NormalizeRevisions sets the normalized revisions of the status from its revisions.
+func (in *TerraformStatus) NormalizeRevisions() {
+	revisions := RevisionsStatus{
+		LastApplied:   ParseRevision(in.LastAppliedRevision).status(),
+		LastAttempted: ParseRevision(in.LastAttemptedRevision).status(),
+		LastPlanned:   ParseRevision(in.LastPlannedRevision).status(),
+	}
+	if revisions == (RevisionsStatus{}) {
+		in.Revisions = nil
+		return
+	}
+	in.Revisions = &revisions
+}
@@ -17,12 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 
@@ -36,11 +41,39 @@ const (
 	CACertSecretName = "tf-controller.tls"
 	// RunnerTLSSecretName is the name of the secret containing a TLS cert that will be written to
 	// the namespace in which a terraform runner is created
-	RunnerTLSSecretName   = "terraform-runner.tls"
-	RunnerLabel           = "infra.contrib.fluxcd.io/terraform"
-	GitRepositoryIndexKey = ".metadata.gitRepository"
-	BucketIndexKey        = ".metadata.bucket"
-	OCIRepositoryIndexKey = ".metadata.ociRepository"
+	RunnerTLSSecretName      = "terraform-runner.tls"
+	RunnerLabel              = "infra.contrib.fluxcd.io/terraform"
+	GitRepositoryIndexKey    = ".metadata.gitRepository"
+	BucketIndexKey           = ".metadata.bucket"
+	OCIRepositoryIndexKey    = ".metadata.ociRepository"
+	BackendIndexKey          = ".metadata.backend"
+	InventoryIndexKey        = ".status.inventory.id"
+	ClusterInventoryIndexKey = ".status.inventory.obj"
+	OutputsSourceIndexKey    = ".spec.readOutputsFromTF"
+	// AcknowledgeFailuresAnnotation resumes a stalled object when set to a new value, e.g. a timestamp.
+	AcknowledgeFailuresAnnotation = "infra.contrib.fluxcd.io/acknowledge-failures"
+	// RegenerateOutputsAnnotation writes the outputs again from the state, without a plan, when set to a new value, e.g. a timestamp.
+	RegenerateOutputsAnnotation = "infra.contrib.fluxcd.io/regenerate-outputs"
+	// ReplaceResourcesAnnotation lists the addresses of the resources to replace, separated by commas, e.g. aws_instance.web.
+	// They are replaced by the next plan and apply, with the -replace flag of Terraform, once per value of the annotation.
+	ReplaceResourcesAnnotation = "infra.contrib.fluxcd.io/replace-resources"
+	// KeepRunnerPodUntilAnnotation is set on a runner Pod kept after a failed reconciliation, to the RFC 3339 time
+	// it is kept until. The reconciliations of its object wait for it to be deleted.
+	KeepRunnerPodUntilAnnotation = "infra.contrib.fluxcd.io/keep-until"
+	// BreakGlassTokenAnnotation holds a break-glass token minted by the approver API, which authorizes one apply
+	// without approval, bypassing the change freezes, the suspension of the apply, the post-planning webhooks and the resource limits.
+	BreakGlassTokenAnnotation = "infra.contrib.fluxcd.io/break-glass-token"
+	// BackendTemplateSecretLabel allows the templates of the customConfiguration of the backends to read a Secret, when set to "true".
+	BackendTemplateSecretLabel = "infra.contrib.fluxcd.io/backend-template"
+	// BranchPlannerLabel is set on the preview objects of the branch planner, to the name of the object they preview.
+	BranchPlannerLabel = "infra.contrib.fluxcd.io/branch-planner"
+	// BranchPlannerPullRequestLabel is set on the preview objects of the branch planner, to the number of their pull request.
+	BranchPlannerPullRequestLabel = "infra.contrib.fluxcd.io/pull-request"
+	// BranchPlannerCommentedAnnotation records the last plan of a preview object commented on its pull request.
+	BranchPlannerCommentedAnnotation = "infra.contrib.fluxcd.io/branch-planner-commented"
+	// BranchPlannerTokenKey is the key of the token of the git provider API in the Secrets of the branch planner
+	// and of the pull request comments.
+	BranchPlannerTokenKey = "token"
 )
 
 type ReadInputsFromSecretSpec struct {
@@ -51,16 +84,182 @@ type ReadInputsFromSecretSpec struct {
 	As string `json:"as"`
 }
 
+// ReadOutputsFromTFSpec refers to another Terraform object of the namespace whose outputs are input variables.
+type ReadOutputsFromTFSpec struct {
+	// Name of the Terraform object.
+	// +required
+	Name string `json:"name"`
+
+	// Outputs are the outputs read as the input variables of the same names, or output:variable
+	// to read an output as another variable. All the outputs are read when empty.
+	// +optional
+	Outputs []string `json:"outputs,omitempty"`
+}
+
 // WriteOutputsToSecretSpec defines where to store outputs, and which outputs to be stored.
 type WriteOutputsToSecretSpec struct {
 	// Name is the name of the Secret to be written
 	// +required
 	Name string `json:"name"`
 
+	// Namespace of the Secret. Defaults to the namespace of the Terraform object.
+	// Writing the Secret to another namespace must be allowed with the --allow-cross-namespace-outputs flag of the controller,
+	// and the namespace listed in its --allowed-outputs-namespaces flag.
+	// Such a Secret can't be owned by the Terraform object, it is deleted when the object is finalized instead.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CreateRBAC creates the Role and the RoleBinding allowing the runner to write the Secret of another namespace,
+	// in that namespace. Otherwise the permissions of the runner are verified before the outputs are written.
+	// +optional
+	CreateRBAC bool `json:"createRBAC,omitempty"`
+
+	// DeletionPolicy of the Secret when the Terraform object is deleted. The Secret is deleted with delete, the default,
+	// and kept without the owner reference to the object with retain, e.g. for the workloads still reading it.
+	// +kubebuilder:validation:Enum=delete;retain
+	// +kubebuilder:default:=delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
 	// Outputs contain the selected names of outputs to be written
 	// to the secret. Empty array means writing all outputs, which is default.
 	// +optional
 	Outputs []string `json:"outputs,omitempty"`
+
+	// OutputMappings write the selected outputs to the secret in addition to Outputs, each under its key,
+	// and reduced by its JSONPath. Only the outputs of Outputs and OutputMappings are written when it is set.
+	// +optional
+	OutputMappings []OutputMapping `json:"outputMappings,omitempty"`
+
+	// ExcludeSensitive leaves the outputs marked sensitive by Terraform out of the secret, e.g. for a secret
+	// readable by many workloads. Selecting a sensitive output in Outputs or OutputMappings is then an error.
+	// +optional
+	ExcludeSensitive bool `json:"excludeSensitive,omitempty"`
+
+	// Schema is an OpenAPI v3 schema, in the dialect used by CustomResourceDefinitions,
+	// of an object holding the outputs to be written, keyed by their names in the Secret.
+	// The outputs are validated against it before the Secret gets written.
+	// +optional
+	Schema *apiextensionsv1.JSON `json:"schema,omitempty"`
+
+	// SchemaFrom selects a key of a ConfigMap, in the namespace of the Terraform object,
+	// containing the schema in JSON or YAML. It is used when Schema is not set.
+	// +optional
+	SchemaFrom *corev1.ConfigMapKeySelector `json:"schemaFrom,omitempty"`
+
+	// PushTo pushes the outputs written to the Secret to external secret stores, e.g. AWS Secrets Manager or Vault,
+	// for the consumers outside of Kubernetes. It requires the External Secrets Operator.
+	// +optional
+	PushTo *PushOutputsSpec `json:"pushTo,omitempty"`
+}
+
+// OutputMapping selects an output to be written to a key of the outputs Secret.
+type OutputMapping struct {
+	// Output is the name of the output.
+	// +required
+	Output string `json:"output"`
+
+	// Key of the Secret the output is written to. Defaults to the name of the output.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// JSONPath reduces the value of the output before it is written, e.g. {.endpoint.host} to pick
+	// a field of an object. A path selecting several values writes them as a list.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+
+	// Decode decodes the value, a string, before it is written: base64 writes the bytes it encodes,
+	// e.g. the base64 encoded certificate authority of a cluster, as a PEM document.
+	// +kubebuilder:validation:Enum=base64
+	// +optional
+	Decode string `json:"decode,omitempty"`
+
+	// TrailingNewline ends the value with a newline if it does not already, e.g. for the PEM documents
+	// and the kubeconfigs mounted as files and read by tools expecting one.
+	// +optional
+	TrailingNewline bool `json:"trailingNewline,omitempty"`
+}
+
+const (
+	// OutputDecodeBase64 decodes an output encoded in base64.
+	OutputDecodeBase64 = "base64"
+)
+
+// WriteOutputsToConfigMapSpec defines the ConfigMap to store the non-sensitive outputs, and which outputs to be stored.
+type WriteOutputsToConfigMapSpec struct {
+	// Name is the name of the ConfigMap to be written.
+	// +required
+	Name string `json:"name"`
+
+	// Outputs contain the selected names of outputs to be written to the ConfigMap, or output:key
+	// to write an output to another key. Empty array means writing all the non-sensitive outputs.
+	// Selecting a sensitive output fails the writing of the outputs.
+	// +optional
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// PushOutputsSpec defines the external secret stores to push the outputs to, with a PushSecret of the External Secrets Operator.
+type PushOutputsSpec struct {
+	// SecretStoreRefs are the stores to push the outputs to.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	SecretStoreRefs []SecretStoreRef `json:"secretStoreRefs"`
+
+	// RemoteKey is the name of the secret in the stores, holding the outputs as its properties.
+	// +required
+	RemoteKey string `json:"remoteKey"`
+
+	// RefreshInterval is the interval of the External Secrets Operator between the pushes of the outputs.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// SecretStoreRef references a SecretStore, or a ClusterSecretStore, of the External Secrets Operator.
+type SecretStoreRef struct {
+	// Name of the store.
+	// +required
+	Name string `json:"name"`
+
+	// Kind of the store.
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +kubebuilder:default:=SecretStore
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// WriteOutputsToObjectSpec defines the object to write outputs to, and the fields to write them to.
+type WriteOutputsToObjectSpec struct {
+	// APIVersion of the object.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	// +required
+	Name string `json:"name"`
+
+	// FieldMappings map the outputs to the fields of the object.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	FieldMappings []OutputFieldMapping `json:"fieldMappings"`
+}
+
+// OutputFieldMapping maps an output to a field of an object.
+type OutputFieldMapping struct {
+	// Output is the name of the output. Sensitive outputs are not written to objects.
+	// +required
+	Output string `json:"output"`
+
+	// Field is the path of the field, with its names separated by dots, e.g. spec.dnsName.
+	// The value of the output, of any type, replaces the value of the field.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`
+	// +required
+	Field string `json:"field"`
 }
 
 type Variable struct {
@@ -97,6 +296,14 @@ type TerraformSpec struct {
 	// +kubebuilder:default:=default
 	Workspace string `json:"workspace,omitempty"`
 
+	// ActiveWorkspace manages two workspaces, blue and green, under this object, named after the workspace
+	// with a -blue or -green suffix. Only the active one is planned and applied, and its outputs
+	// are written. Switching the active workspace swaps the outputs only once the plan of the newly
+	// active workspace has been applied, so that the consumers of the outputs cut over to a ready stack.
+	// +kubebuilder:validation:Enum=blue;green
+	// +optional
+	ActiveWorkspace string `json:"activeWorkspace,omitempty"`
+
 	// List of input variables to set for the Terraform program.
 	// +optional
 	Vars []Variable `json:"vars,omitempty"`
@@ -110,28 +317,104 @@ type TerraformSpec struct {
 	// Values map to the Terraform variable "values", which is an object of arbitrary values.
 	// It is a convenient way to pass values to Terraform resources without having to define
 	// a variable for each value. To use this feature, your Terraform file must define the variable "values".
+	// Values are validated against the type constraint of the variable before planning.
 	// +optional
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
 
+	// VarsPolicy controls what happens to the variables given by vars, varsFrom and values
+	// that the module does not declare. Terraform only warns about them, which hides typos.
+	// With `strict`, such variables fail the reconciliation before planning.
+	// Defaults to `lenient`, which lets Terraform ignore them.
+	// +kubebuilder:validation:Enum:=lenient;strict
+	// +kubebuilder:default:string=lenient
+	// +optional
+	VarsPolicy string `json:"varsPolicy,omitempty"`
+
 	// List of all configuration files to be created in initialization.
 	// +optional
 	FileMappings []FileMapping `json:"fileMappings,omitempty"`
 
 	// The interval at which to reconcile the Terraform.
+	// An interval of 0s disables the periodic reconciliation, like ManualReconciliation.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
 	// +required
 	Interval metav1.Duration `json:"interval"`
 
+	// ManualReconciliation disables the periodic reconciliation, and the retries of failed reconciliations.
+	// The object is then only reconciled when its spec or its source changes,
+	// or when a reconciliation is requested with the reconcile.fluxcd.io/requestedAt annotation.
+	// The interval must then be 0s.
+	// +optional
+	ManualReconciliation bool `json:"manualReconciliation,omitempty"`
+
+	// SuspendApply stops applying plans, while the object keeps planning and detecting drifts.
+	// Pending plans, approved or not, are kept until the apply is resumed.
+	// Unlike Suspend, the changes to apply remain visible, e.g. during a change freeze.
+	// +optional
+	SuspendApply bool `json:"suspendApply,omitempty"`
+
+	// PlanOnly plans the configuration without ever applying the plans, nor writing the state or the outputs.
+	// The previews of the branch planner are plan-only objects.
+	// +optional
+	PlanOnly bool `json:"planOnly,omitempty"`
+
+	// BranchPlanner previews the pull requests of the GitRepository of the object, with a plan-only copy of the object
+	// per pull request, and comments their plans on the pull requests.
+	// +optional
+	BranchPlanner *BranchPlannerSpec `json:"branchPlanner,omitempty"`
+
+	// PullRequestComments comments the plans with changes on the open pull requests, or merge requests,
+	// of their revision, for the objects whose source is a GitRepository.
+	// +optional
+	PullRequestComments *PullRequestCommentsSpec `json:"pullRequestComments,omitempty"`
+
 	// The interval at which to retry a previously failed reconciliation.
 	// When not specified, the controller uses the TerraformSpec.Interval
 	// value to retry failures.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
 	// +optional
 	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
 
+	// MaxConsecutiveFailures stalls the object once its reconciliation failed this number of times
+	// in a row with the same reason. A stalled object is not retried until its failures are acknowledged
+	// by setting the infra.contrib.fluxcd.io/acknowledge-failures annotation. Zero never stalls the object.
+	// When not specified, the controller default applies.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConsecutiveFailures *int32 `json:"maxConsecutiveFailures,omitempty"`
+
+	// SourceNotFound configures the retries of the reconciliation while the source of .spec.sourceRef is not found.
+	// +optional
+	SourceNotFound *SourceNotFoundSpec `json:"sourceNotFound,omitempty"`
+
 	// Path to the directory containing Terraform (.tf) files.
 	// Defaults to 'None', which translates to the root path of the SourceRef.
+	// The path must not traverse outside the SourceRef with '..'.
+	// +kubebuilder:validation:Pattern=`^(/?(\.|\.\.[^/]+|\.[^./][^/]*|[^./][^/]*))?(/(\.|\.\.[^/]+|\.[^./][^/]*|[^./][^/]*))*/?$`
 	// +optional
 	Path string `json:"path,omitempty"`
 
+	// CDKTF synthesizes the Terraform configuration of a CDK for Terraform application at the Path,
+	// and plans and applies the synthesized stack.
+	// +optional
+	CDKTF *CDKTFSpec `json:"cdktf,omitempty"`
+
+	// Engine is the binary running the init, plan, apply and output commands of the object,
+	// terraform or tofu for OpenTofu. The runner image must provide it. Defaults to terraform.
+	// +kubebuilder:validation:Enum=terraform;tofu
+	// +kubebuilder:default:=terraform
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// TerraformVersion pins the exact version of the terraform binary of the object, e.g. 1.3.9.
+	// The runner downloads and verifies the release from releases.hashicorp.com, and caches it in $TF_BINARY_CACHE_DIR.
+	// Defaults to the terraform binary of the runner image. Only valid with the terraform engine.
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.]+)?$`
+	// +optional
+	TerraformVersion string `json:"terraformVersion,omitempty"`
+
 	// SourceRef is the reference of the source where the Terraform files are stored.
 	// +required
 	SourceRef CrossNamespaceSourceReference `json:"sourceRef"`
@@ -150,10 +433,30 @@ type TerraformSpec struct {
 	// +optional
 	ReadInputsFromSecrets []ReadInputsFromSecretSpec `json:"readInputsFromSecrets,omitempty"`
 
+	// ReadOutputsFromTF reads the outputs of other Terraform objects of the namespace as input variables,
+	// from their state in the cluster, or from their outputs Secret. The object is reconciled again
+	// when their outputs change. Spec.Vars and Spec.VarsFrom override them.
+	// +optional
+	ReadOutputsFromTF []ReadOutputsFromTFSpec `json:"readOutputsFromTF,omitempty"`
+
 	// A list of target secrets for the outputs to be written as.
 	// +optional
 	WriteOutputsToSecret *WriteOutputsToSecretSpec `json:"writeOutputsToSecret,omitempty"`
 
+	// WriteOutputsToConfigMap writes the non-sensitive outputs to a ConfigMap in the namespace of the Terraform object,
+	// for the workloads mounting ConfigMaps. The ConfigMap is owned by the Terraform object.
+	// +optional
+	WriteOutputsToConfigMap *WriteOutputsToConfigMapSpec `json:"writeOutputsToConfigMap,omitempty"`
+
+	// WriteOutputsTo writes outputs to the fields of an object in the namespace of the Terraform object,
+	// e.g. a custom resource of another controller. The object is created if it does not exist.
+	// +optional
+	WriteOutputsTo *WriteOutputsToObjectSpec `json:"writeOutputsTo,omitempty"`
+
+	// Outputs configures when the outputs are written, in addition to the applies.
+	// +optional
+	Outputs *OutputsSpec `json:"outputs,omitempty"`
+
 	// Disable automatic drift detection. Drift detection may be resource intensive in
 	// the context of a large cluster or complex Terraform statefile. Defaults to false.
 	// +kubebuilder:default:=false
@@ -172,16 +475,21 @@ type TerraformSpec struct {
 
 	// Create destroy plan and apply it to destroy terraform resources
 	// upon deletion of this object. Defaults to false.
+	// Unless ApprovePlan is auto, the destroy plan must be approved before it is applied.
 	// +kubebuilder:default:=false
 	// +optional
 	DestroyResourcesOnDeletion bool `json:"destroyResourcesOnDeletion,omitempty"`
 
 	// Name of a ServiceAccount for the runner Pod to provision Terraform resources.
-	// Default to tf-runner.
+	// Default to tf-runner. Ignored when runner.createServiceAccount is set.
 	// +kubebuilder:default:=tf-runner
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
+	// Runner configures the identity of the runner Pod, for the objects sharing a namespace, and keeping it after failures.
+	// +optional
+	Runner *RunnerSpec `json:"runner,omitempty"`
+
 	// Clean the runner pod up after each reconciliation cycle
 	// +kubebuilder:default:=true
 	// +optional
@@ -194,11 +502,61 @@ type TerraformSpec struct {
 	// +optional
 	RunnerTerminationGracePeriodSeconds *int64 `json:"runnerTerminationGracePeriodSeconds,omitempty"`
 
+	// WorkingDirStorage keeps the working directory of the runner, with the extracted source
+	// and the .terraform directory, across the reconciliations.
+	// +optional
+	WorkingDirStorage *WorkingDirStorage `json:"workingDirStorage,omitempty"`
+
 	// RefreshBeforeApply forces refreshing of the state before the apply step.
 	// +kubebuilder:default:=false
 	// +optional
 	RefreshBeforeApply bool `json:"refreshBeforeApply,omitempty"`
 
+	// Parallelism limits the number of the concurrent operations of the plans, the applies and the destroys,
+	// with the -parallelism flag of Terraform. Defaults to 10, the default of Terraform.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// ExtraArgs are the additional flags of the Terraform commands, by stage.
+	// +optional
+	ExtraArgs *ExtraArgs `json:"extraArgs,omitempty"`
+
+	// Imports are the existing resources to import into the state, with terraform import, before planning.
+	// The resources already in the state are not imported again, so that the plan adopts them instead of creating them.
+	// +optional
+	Imports []Import `json:"imports,omitempty"`
+
+	// StateBackup snapshots the state before every apply, to the Secrets of the namespace of the object,
+	// a bucket of S3 or an OCI repository, keeping the most recent snapshots.
+	// +optional
+	StateBackup *StateBackup `json:"stateBackup,omitempty"`
+
+	// RestoreStateFrom is the ID of a snapshot of stateBackup, e.g. 20230201-100530. The state is replaced
+	// by the snapshot before the next plan, once per value, and the object takes over the lineage of the snapshot.
+	// +optional
+	RestoreStateFrom string `json:"restoreStateFrom,omitempty"`
+
+	// WorkingDirSnapshot stores the working directory of the failed plans and applies, without the providers
+	// installed by init, so that a failure can be reproduced locally with the same generated variables and backend files.
+	// +optional
+	WorkingDirSnapshot *WorkingDirSnapshot `json:"workingDirSnapshot,omitempty"`
+
+	// ApplyStrictness controls whether an approved plan is checked against the live state before it is applied.
+	// With `state`, the serial and the lineage of the state the plan was created against are compared
+	// with the current state in the backend. If the state has changed since planning,
+	// the plan is discarded as stale and a new plan is created instead of applying it.
+	// Defaults to `none`, which applies the plan as approved.
+	// +kubebuilder:validation:Enum:=none;state
+	// +kubebuilder:default:string=none
+	// +optional
+	ApplyStrictness string `json:"applyStrictness,omitempty"`
+
+	// ApplyRetry re-applies the saved plan after a failed apply, instead of creating a new plan,
+	// as long as the state has not changed since planning.
+	// +optional
+	ApplyRetry *ApplyRetry `json:"applyRetry,omitempty"`
+
 	// +optional
 	RunnerPodTemplate RunnerPodTemplate `json:"runnerPodTemplate,omitempty"`
 
@@ -206,26 +564,174 @@ type TerraformSpec struct {
 	// +optional
 	EnableInventory bool `json:"enableInventory,omitempty"`
 
+	// WatchClusterInventory watches the Kubernetes objects of the inventory, provisioned with the kubernetes provider,
+	// and reports the objects deleted out-of-band as a drift right away, rather than at the next plan.
+	// It requires EnableInventory, and the controller to be allowed to list and watch the objects.
+	// +optional
+	WatchClusterInventory bool `json:"watchClusterInventory,omitempty"`
+
 	// +optional
 	TFState *TFStateSpec `json:"tfstate,omitempty"`
 
 	// Targets specify the resource, module or collection of resources to target.
 	// +optional
-	Targets []string `json:"targets,omitempty"`
+	Targets []ResourceAddress `json:"targets,omitempty"`
 
 	// StoreReadablePlan enables storing the plan in a readable format.
-	// +kubebuilder:validation:Enum=none;json;human
+	// Values that Terraform marks as sensitive are masked in the stored plan.
+	// The json plan is stored in a Secret, the human, markdown and diff ones in a ConfigMap.
+	// +kubebuilder:validation:Enum=none;json;human;markdown;diff
 	// +kubebuilder:default:=none
 	// +optional
 	StoreReadablePlan string `json:"storeReadablePlan,omitempty"`
 
+	// StoreGraph enables storing the dependency graph of the resources, in the DOT format,
+	// in the tfgraph-<workspace>-<name> ConfigMap every time a plan is made.
+	// +optional
+	StoreGraph bool `json:"storeGraph,omitempty"`
+
 	// +optional
 	Webhooks []Webhook `json:"webhooks,omitempty"`
 
 	// +optional
-	DependsOn []meta.NamespacedObjectReference `json:"dependsOn,omitempty"`
+	DependsOn []DependsOnReference `json:"dependsOn,omitempty"`
+
+	// DeleteDependants deletes the objects of the same namespace depending on this one, directly or not,
+	// when this object is deleted. The dependants are deleted first, in reverse dependency order,
+	// and this object waits for all of them to be gone.
+	// +optional
+	DeleteDependants bool `json:"deleteDependants,omitempty"`
+
+	// ResourceLimits restrict what the plans of this object may contain.
+	// A plan exceeding them is not saved, so it can never be applied.
+	// +optional
+	ResourceLimits *ResourceLimits `json:"resourceLimits,omitempty"`
+
+	// PolicyChecks refer to ConfigMaps of Rego policies, in the namespace of this object,
+	// evaluated against the JSON of the plans. A plan denied by a policy is not saved, so it can never be applied.
+	// +optional
+	PolicyChecks []PolicyCheck `json:"policyChecks,omitempty"`
+
+	// Checks are the gates of the plans and of the applies, run in their order within each stage,
+	// the first failing check stopping its stage. When set, they replace webhooks, resourceLimits,
+	// policyChecks and healthChecks, which are otherwise run as the checks: the webhooks,
+	// the resource limits and the policies after planning, in this order, and the health checks after applying.
+	// A plan waits for its approval once all its post-planning checks pass.
+	// +optional
+	Checks []Check `json:"checks,omitempty"`
+
+	// ProviderConfigRefs refer to ProviderConfig objects, in the namespace of this object,
+	// rendered into provider override files of the Terraform program.
+	// +optional
+	ProviderConfigRefs []meta.LocalObjectReference `json:"providerConfigRefs,omitempty"`
+
+	// ProviderRefs refer to cluster-scoped TerraformProvider objects selecting the namespace of this object,
+	// rendered into provider override files of the Terraform program. The ProviderConfig objects
+	// of ProviderConfigRefs take precedence over them.
+	// +optional
+	ProviderRefs []meta.LocalObjectReference `json:"providerRefs,omitempty"`
+
+	// Logging controls what the controller surfaces in the events and the
+	// condition messages of this object.
+	// +optional
+	Logging *LoggingSpec `json:"logging,omitempty"`
+}
+
+// Check is a gate of the checks pipeline. The field of its type holds its configuration.
+type Check struct {
+	// Name of the check, unique within the checks, reported in the status.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Name string `json:"name"`
+
+	// Stage is post-planning, to check the plans before they are saved, or post-apply,
+	// to check the resources once applied. Only the healthCheck checks run post-apply.
+	// +kubebuilder:validation:Enum=post-planning;post-apply
+	// +kubebuilder:default:=post-planning
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// +kubebuilder:validation:Enum=webhook;resourceLimits;policy;healthCheck
+	// +required
+	Type string `json:"type"`
+
+	// +optional
+	Webhook *Webhook `json:"webhook,omitempty"`
+
+	// +optional
+	ResourceLimits *ResourceLimits `json:"resourceLimits,omitempty"`
+
+	// +optional
+	Policy *PolicyCheck `json:"policy,omitempty"`
+
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// The stages and the types of the checks.
+const (
+	CheckStagePostPlanning = "post-planning"
+	CheckStagePostApply    = "post-apply"
+
+	CheckTypeWebhook        = "webhook"
+	CheckTypeResourceLimits = "resourceLimits"
+	CheckTypePolicy         = "policy"
+	CheckTypeHealthCheck    = "healthCheck"
+)
+
+// The results of the checks.
+const (
+	CheckResultPassed = "Passed"
+	CheckResultFailed = "Failed"
+)
+
+// CheckStatus is the result of a check of the last run of its stage.
+type CheckStatus struct {
+	Name  string `json:"name"`
+	Stage string `json:"stage"`
+	Type  string `json:"type"`
+
+	// +kubebuilder:validation:Enum=Passed;Failed
+	Result string `json:"result"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceLimits restrict the resources that a plan may manage.
+type ResourceLimits struct {
+	// MaxManagedResources is the maximum number of managed resources once the plan is applied.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxManagedResources *int `json:"maxManagedResources,omitempty"`
+
+	// ForbiddenResourceTypes are resource types, or glob patterns such as aws_iam_*,
+	// that a plan must not create or update.
+	// +optional
+	ForbiddenResourceTypes []string `json:"forbiddenResourceTypes,omitempty"`
 }
 
+// LoggingSpec controls how much of the Terraform output is echoed to events and conditions.
+type LoggingSpec struct {
+	// Level is 'full' to keep the messages as they are, or 'summary' to keep only
+	// their first line, leaving out the complete Terraform output. Defaults to 'full'.
+	// +kubebuilder:validation:Enum=full;summary
+	// +kubebuilder:default:=full
+	// +optional
+	Level string `json:"level,omitempty"`
+
+	// RedactPatterns are regular expressions, every match of which is masked
+	// in event and condition messages.
+	// +optional
+	RedactPatterns []string `json:"redactPatterns,omitempty"`
+}
+
+// ResourceAddress is the address of a resource or module in the configuration,
+// e.g. aws_instance.example, module.network or module.network["eu"].aws_vpc.main[0].
+// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_-]*(\[[^\]]+\])?(\.[a-zA-Z_][a-zA-Z0-9_-]*(\[[^\]]+\])?)*$`
+type ResourceAddress string
+
 type Webhook struct {
 	// +kubebuilder:validation:Enum=post-planning
 	// +kubebuilder:default:=post-planning
@@ -236,6 +742,9 @@ type Webhook struct {
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// URL of the webhook. Only https is accepted, except for plain http
+	// to a server listening on the loopback interface.
+	// +kubebuilder:validation:Pattern=`^(https://|http://(localhost|127\.0\.0\.1)(:[0-9]+)?(/|$))`
 	// +required
 	URL string `json:"url"`
 
@@ -255,124 +764,925 @@ func (w Webhook) IsEnabled() bool {
 	return w.Enabled == nil || *w.Enabled
 }
 
-type PlanStatus struct {
+// FailureStatus counts the failed reconciliations of an object in a row, with the same reason.
+type FailureStatus struct {
+	// Reason of the Ready condition of the last failed reconciliation,
+	// or SourceNotFound while the source is not found.
 	// +optional
-	LastApplied string `json:"lastApplied,omitempty"`
+	Reason string `json:"reason,omitempty"`
 
 	// +optional
-	Pending string `json:"pending,omitempty"`
+	Count int32 `json:"count,omitempty"`
 
+	// LastAcknowledged is the value of the acknowledge-failures annotation which last resumed the object.
 	// +optional
-	IsDestroyPlan bool `json:"isDestroyPlan,omitempty"`
+	LastAcknowledged string `json:"lastAcknowledged,omitempty"`
+}
 
+// RunStatus describes the outcome of the last run of Terraform.
+type RunStatus struct {
+	// Diagnostics reported by Terraform when the run failed, at most MaxDiagnostics of them.
 	// +optional
-	IsDriftDetectionPlan bool `json:"isDriftDetectionPlan,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
+	// Progress of the last apply, updated while it is running.
+	// +optional
+	Progress *ApplyProgress `json:"progress,omitempty"`
 }
 
-// TerraformStatus defines the observed state of Terraform
-type TerraformStatus struct {
-	meta.ReconcileRequestStatus `json:",inline"`
+// RemoteRunStatus is the last run of the workspace of the remote backend.
+type RemoteRunStatus struct {
+	// ID of the run, e.g. run-CZcmD7eagjhyX0vN.
+	ID string `json:"id"`
 
-	// ObservedGeneration is the last reconciled generation.
+	// Status of the run in Terraform Cloud, e.g. planning, planned, applied or errored.
 	// +optional
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	Status string `json:"status,omitempty"`
 
+	// Revision of the source uploaded for the run.
 	// +optional
-	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	Revision string `json:"revision,omitempty"`
 
-	// The last successfully applied revision.
-	// The revision format for Git sources is <branch|tag>/<commit-sha>.
+	// URL of the run in the user interface of Terraform Cloud.
 	// +optional
-	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+	URL string `json:"url,omitempty"`
 
-	// LastAttemptedRevision is the revision of the last reconciliation attempt.
+	// IsDestroy is true for the runs destroying the resources.
 	// +optional
-	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+	IsDestroy bool `json:"isDestroy,omitempty"`
 
-	// LastPlannedRevision is the revision used by the last planning process.
-	// The result could be either no plan change or a new plan generated.
+	// FinishedAt is when the run reached a final status, unset while it is running.
 	// +optional
-	LastPlannedRevision string `json:"lastPlannedRevision,omitempty"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
 
-	// LastDriftDetectedAt is the time when the last drift was detected
+// ApplyProgress counts the resources whose changes have been applied.
+type ApplyProgress struct {
+	// Completed is the number of resources whose changes are complete.
 	// +optional
-	LastDriftDetectedAt *metav1.Time `json:"lastDriftDetectedAt,omitempty"`
+	Completed int32 `json:"completed"`
 
-	// LastAppliedByDriftDetectionAt is the time when the last drift was detected and
-	// terraform apply was performed as a result
+	// Total is the number of resource changes of the plan, 0 if unknown.
 	// +optional
-	LastAppliedByDriftDetectionAt *metav1.Time `json:"lastAppliedByDriftDetectionAt,omitempty"`
+	Total int32 `json:"total,omitempty"`
 
+	// LastUpdateTime is when the progress was last reported by the runner.
 	// +optional
-	AvailableOutputs []string `json:"availableOutputs,omitempty"`
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// Diagnostic is an error or a warning reported by Terraform.
+type Diagnostic struct {
+	// +kubebuilder:validation:Enum=error;warning
+	// +required
+	Severity string `json:"severity"`
+
+	// +required
+	Summary string `json:"summary"`
 
 	// +optional
-	Plan PlanStatus `json:"plan,omitempty"`
+	Detail string `json:"detail,omitempty"`
 
-	// Inventory contains the list of Terraform resource object references that have been successfully applied.
+	// Range locates the configuration the diagnostic is about.
 	// +optional
-	Inventory *ResourceInventory `json:"inventory,omitempty"`
+	Range *DiagnosticRange `json:"range,omitempty"`
+}
+
+// DiagnosticRange is a location in the configuration of a Terraform module.
+type DiagnosticRange struct {
+	// +required
+	Filename string `json:"filename"`
 
 	// +optional
-	Lock LockStatus `json:"lock,omitempty"`
+	Line int32 `json:"line,omitempty"`
 }
 
-// LockStatus defines the observed state of a Terraform State Lock
-type LockStatus struct {
+type PlanStatus struct {
 	// +optional
 	LastApplied string `json:"lastApplied,omitempty"`
 
-	// Pending holds the identifier of the Lock Holder to be used with Force Unlock
 	// +optional
 	Pending string `json:"pending,omitempty"`
-}
 
-// +kubebuilder:object:root=true
-// +kubebuilder:resource:shortName=tf
-// +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
-// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
-// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+	// CreatedAt is the time when the pending plan was created.
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 
-// Terraform is the Schema for the terraforms API
-type Terraform struct {
-	metav1.TypeMeta   `json:",inline"`
-	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +optional
+	IsDestroyPlan bool `json:"isDestroyPlan,omitempty"`
 
-	Spec   TerraformSpec   `json:"spec,omitempty"`
-	Status TerraformStatus `json:"status,omitempty"`
-}
+	// +optional
+	IsDriftDetectionPlan bool `json:"isDriftDetectionPlan,omitempty"`
 
-//+kubebuilder:object:root=true
+	// Replaces is the value of the replace-resources annotation the pending plan replaces the resources of.
+	// +optional
+	Replaces string `json:"replaces,omitempty"`
 
-// TerraformList contains a list of Terraform
-type TerraformList struct {
-	metav1.TypeMeta `json:",inline"`
-	metav1.ListMeta `json:"metadata,omitempty"`
-	Items           []Terraform `json:"items"`
-}
+	// Diff summarizes what changed since the pending plan that this plan superseded.
+	// +optional
+	Diff *PlanDiff `json:"diff,omitempty"`
 
-// BackendConfigSpec is for specifying configuration for Terraform's Kubernetes backend
-type BackendConfigSpec struct {
+	// ApplyRetries counts the failed applies of the pending plan that have been retried.
+	// +optional
+	ApplyRetries int32 `json:"applyRetries,omitempty"`
+
+	// ApprovedBy records the approval of the pending plan outside of spec.approvePlan,
+	// e.g. by the approvers of a merge request.
+	// +optional
+	ApprovedBy string `json:"approvedBy,omitempty"`
+}
+
+// BranchPlannerSpec configures the branch planner of an object.
+type BranchPlannerSpec struct {
+	// Provider hosting the repository of the GitRepository.
+	// +kubebuilder:validation:Enum=github;gitlab
+	// +kubebuilder:default:=github
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// SecretRef is the Secret holding the token of the API of the provider, in its token key.
+	// The token must be allowed to read the pull requests and to comment them.
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// APIURL of the provider, e.g. of a GitHub Enterprise or a self-managed GitLab server. Defaults to the public API of the provider.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// Interval at which the pull requests are listed. Defaults to 5m.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// PullRequestCommentsSpec configures the comments of the plans of an object on its pull requests.
+type PullRequestCommentsSpec struct {
+	// Provider hosting the repository of the GitRepository.
+	// +kubebuilder:validation:Enum=github;gitlab
+	// +kubebuilder:default:=github
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// SecretRef is the Secret holding the token of the API of the provider, in its token key.
+	// The token must be allowed to read the pull requests and to comment them.
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// APIURL of the provider, e.g. of a GitHub Enterprise or a self-managed GitLab server. Defaults to the public API of the provider.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// ApprovePlanOnApproval approves the pending plan once an open merge request of its revision is approved,
+	// as if approvePlan was set to its id. Only supported with the gitlab provider.
+	// +optional
+	ApprovePlanOnApproval bool `json:"approvePlanOnApproval,omitempty"`
+}
+
+// ProviderVersion is a provider of the configuration of an object, and its version.
+type ProviderVersion struct {
+	// Source address of the provider, e.g. registry.terraform.io/hashicorp/aws.
+	Source string `json:"source"`
+	// Version of the provider.
+	Version string `json:"version"`
+}
+
+// CDKTFSpec is the CDK for Terraform application of an object.
+type CDKTFSpec struct {
+	// Language of the application, which must match the language of its cdktf.json.
+	// The runner image must provide the cdktf CLI and the toolchain of the language.
+	// +kubebuilder:validation:Enum=typescript;python;go;java;csharp
+	// +required
+	Language string `json:"language"`
+
+	// Stack to plan and apply. It may be omitted when the application has a single stack.
+	// +optional
+	Stack string `json:"stack,omitempty"`
+}
+
+// SourceNotFoundSpec configures the retries of the reconciliation while the source is not found.
+type SourceNotFoundSpec struct {
+	// DisableBackoff retries at the retry interval, instead of doubling the interval at each attempt
+	// up to .spec.interval.
+	// +optional
+	DisableBackoff bool `json:"disableBackoff,omitempty"`
+
+	// MaxAttempts stalls the object once the source has not been found this number of times in a row.
+	// Zero retries forever.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// ResumeWhenFound resumes the object stalled by MaxAttempts as soon as the source appears,
+	// instead of waiting for its failures to be acknowledged.
+	// +optional
+	ResumeWhenFound bool `json:"resumeWhenFound,omitempty"`
+}
+
+// BreakGlassStatus is the record of a break-glass token used to apply a plan.
+type BreakGlassStatus struct {
+	// TokenID is the identifier of the token.
+	TokenID string `json:"tokenID"`
+
+	// User who minted the token.
+	User string `json:"user"`
+
+	// Reason given when minting the token.
+	Reason string `json:"reason"`
+
+	// Plan applied with the token.
+	// +optional
+	Plan string `json:"plan,omitempty"`
+
+	// UsedAt is the time when the token was used.
+	UsedAt metav1.Time `json:"usedAt"`
+}
+
+// UsedBreakGlassToken is a break-glass token used to apply a plan, kept until it expires so that it is not used again.
+type UsedBreakGlassToken struct {
+	// ID is the identifier of the token.
+	ID string `json:"id"`
+
+	// ExpiresAt is the expiration time of the token.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// RunnerSpec configures the identity of the runner Pod.
+type RunnerSpec struct {
+	// CreateServiceAccount creates a ServiceAccount for the runner Pod of this object, named <name>-tf-runner
+	// and owned by the object, instead of using ServiceAccountName. The ServiceAccount is bound to the RoleBindings.
+	// +optional
+	CreateServiceAccount bool `json:"createServiceAccount,omitempty"`
+
+	// RoleBindings are the templates of the RoleBindings of the created ServiceAccount, in the namespace of the object.
+	// Defaults to a RoleBinding to the tf-runner-role ClusterRole.
+	// +optional
+	RoleBindings []RunnerRoleBinding `json:"roleBindings,omitempty"`
+
+	// ScopedToken replaces the service account token mounted in the runner Pod with short-lived tokens bound to the Pod,
+	// and adds a token whose audience is unique to this object. The objects sharing a ServiceAccount
+	// can then be told apart by the systems accepting the tokens, e.g. Vault.
+	// +optional
+	ScopedToken *RunnerScopedToken `json:"scopedToken,omitempty"`
+
+	// KeepFailedPodsFor keeps the runner Pod of a failed reconciliation, with its working directory, for this long
+	// when alwaysCleanupRunnerPod is true, so that its logs and its files can be inspected. The reconciliations
+	// of the object wait for the kept Pod, which is deleted at the end of the period, or can be deleted by hand before.
+	// +optional
+	KeepFailedPodsFor *metav1.Duration `json:"keepFailedPodsFor,omitempty"`
+}
+
+// RunnerRoleBinding is the template of a RoleBinding of the ServiceAccount created for the runner Pod.
+type RunnerRoleBinding struct {
+	// Kind of the role, Role or ClusterRole.
+	// +kubebuilder:validation:Enum=Role;ClusterRole
+	// +kubebuilder:default:=ClusterRole
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the role.
+	// +required
+	Name string `json:"name"`
+}
+
+// RunnerScopedToken configures the tokens of the runner Pod.
+type RunnerScopedToken struct {
+	// Audience of the token of this object. Defaults to tf-runner/<namespace>/<name>.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds of the tokens, refreshed by the kubelet before they expire. Defaults to 3600.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// OutputsSpec configures when the outputs are written.
+type OutputsSpec struct {
+	// RefreshInterval re-reads the outputs from the state, and writes them again to their Secret, ConfigMap
+	// and object, at this interval, even when no plan is applied, e.g. for the credentials rotated by the providers
+	// or changed in a shared state. The outputs are only written by the applies when it is not set.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// ExtraArgs are the additional flags of the init, plan, apply and destroy commands, e.g. -lock-timeout=5m.
+// Only the flags tuning the commands are supported: -lock, -lock-timeout, -parallelism, -refresh, -replace and -target,
+// among those of each command, and -upgrade and -reconfigure for init. They override the flags set by the spec.
+type ExtraArgs struct {
+	// +optional
+	Init []string `json:"init,omitempty"`
+
+	// +optional
+	Plan []string `json:"plan,omitempty"`
+
+	// Apply are the flags of the applies of the saved plans, which only support -lock, -lock-timeout and -parallelism.
+	// +optional
+	Apply []string `json:"apply,omitempty"`
+
+	// +optional
+	Destroy []string `json:"destroy,omitempty"`
+}
+
+// Import is an existing resource to import into the state.
+type Import struct {
+	// Address is the address of the resource in the configuration, e.g. aws_s3_bucket.logs.
+	// +required
+	Address string `json:"address"`
+
+	// ID is the identifier of the resource for its provider, e.g. the name of the bucket.
+	// +required
+	ID string `json:"id"`
+}
+
+// The results of the imports.
+const (
+	ImportResultImported = "Imported"
+	ImportResultInState  = "InState"
+	ImportResultFailed   = "Failed"
+)
+
+// ImportStatus is the result of an import of spec.imports.
+type ImportStatus struct {
+	Address string `json:"address"`
+	ID      string `json:"id"`
+
+	// Result is Imported when the resource has been imported, InState when it already was in the state,
+	// and Failed when terraform import failed.
+	// +kubebuilder:validation:Enum=Imported;InState;Failed
+	Result string `json:"result"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ImportedAt is the time of the import of the resource, kept while it stays in the state.
+	// +optional
+	ImportedAt *metav1.Time `json:"importedAt,omitempty"`
+}
+
+// The types of the storages of the snapshots.
+const (
+	SnapshotStorageTypeSecret = "secret"
+	SnapshotStorageTypeS3     = "s3"
+	SnapshotStorageTypeOCI    = "oci"
+)
+
+// SnapshotStorage is where the runner stores snapshots: in Secrets of the namespace of the object,
+// in a bucket of S3, or in an OCI repository, with the credentials of the runner pod by default.
+type SnapshotStorage struct {
+	// Type is secret, s3 or oci. The field of the type holds the configuration of the storage.
+	// +kubebuilder:validation:Enum=secret;s3;oci
+	// +required
+	Type string `json:"type"`
+
+	// +optional
+	S3 *S3SnapshotStorage `json:"s3,omitempty"`
+
+	// +optional
+	OCI *OCISnapshotStorage `json:"oci,omitempty"`
+}
+
+// S3SnapshotStorage is the bucket of Amazon S3, or of a storage compatible with S3, holding the snapshots.
+type S3SnapshotStorage struct {
+	// +required
+	Bucket string `json:"bucket"`
+
+	// Prefix of the keys of the snapshots in the bucket. Defaults to <namespace>/<name>.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// +required
+	Region string `json:"region"`
+
+	// Endpoint of a storage compatible with S3, replacing the one of Amazon S3.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// CredentialsSecretRef refers to a Secret of the namespace of the object with the access_key,
+	// secret_key and token keys.
+	// +optional
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// OCISnapshotStorage is the repository of an OCI registry holding the snapshots, each of them tagged with its ID.
+type OCISnapshotStorage struct {
+	// Repository of the snapshots, e.g. ghcr.io/org/tfstate/helloworld.
+	// +required
+	Repository string `json:"repository"`
+
+	// SecretRef refers to a Secret of the namespace of the object of the kubernetes.io/dockerconfigjson type,
+	// with the credentials of the registry. The registry is accessed anonymously without it.
+	// +optional
+	SecretRef *meta.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Insecure accesses the registry over plain HTTP.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// StateBackup configures the snapshots of the state taken before the applies.
+type StateBackup struct {
+	SnapshotStorage `json:",inline"`
+
+	// Keep is the number of snapshots kept, the oldest being deleted.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=10
+	// +optional
+	Keep int `json:"keep,omitempty"`
+}
+
+// DefaultStateSnapshotsKept is the number of snapshots kept when spec.stateBackup.keep is not set.
+const DefaultStateSnapshotsKept = 10
+
+// GetKeep returns the number of snapshots kept, with the default applied.
+func (in StateBackup) GetKeep() int {
+	if in.Keep <= 0 {
+		return DefaultStateSnapshotsKept
+	}
+	return in.Keep
+}
+
+// StateSnapshot is a snapshot of the state taken before an apply.
+type StateSnapshot struct {
+	// ID of the snapshot, the UTC time it was taken at, e.g. 20230201-100530.
+	ID string `json:"id"`
+
+	// Location of the snapshot: its Secret, its S3 URL, or its OCI reference.
+	Location string `json:"location"`
+
+	// +optional
+	Lineage string `json:"lineage,omitempty"`
+
+	// +optional
+	Serial uint64 `json:"serial,omitempty"`
+
+	// Revision is the revision whose apply followed the snapshot.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	CreatedAt metav1.Time `json:"createdAt"`
+}
+
+// WorkingDirSnapshot configures the snapshots of the working directory taken when a plan or an apply fails.
+type WorkingDirSnapshot struct {
+	SnapshotStorage `json:",inline"`
+
+	// Keep is the number of snapshots kept, the oldest being deleted.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=3
+	// +optional
+	Keep int `json:"keep,omitempty"`
+}
+
+// DefaultWorkingDirSnapshotsKept is the number of snapshots kept when spec.workingDirSnapshot.keep is not set.
+const DefaultWorkingDirSnapshotsKept = 3
+
+// GetKeep returns the number of snapshots kept, with the default applied.
+func (in WorkingDirSnapshot) GetKeep() int {
+	if in.Keep <= 0 {
+		return DefaultWorkingDirSnapshotsKept
+	}
+	return in.Keep
+}
+
+// WorkingDirSnapshotStatus is a snapshot of the working directory of a failed plan or apply.
+type WorkingDirSnapshotStatus struct {
+	// ID of the snapshot, the UTC time it was taken at, e.g. 20230201-100530.
+	ID string `json:"id"`
+
+	// Location of the snapshot: its Secret, its S3 URL, or its OCI reference.
+	Location string `json:"location"`
+
+	// Stage that failed, plan or apply.
+	Stage string `json:"stage"`
+
+	// Path of the working directory in the snapshot, which holds the whole source.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Revision is the revision whose run failed.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Message is the error of the failed run.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	CreatedAt metav1.Time `json:"createdAt"`
+}
+
+// WorkingDirStorage is the storage of the working directory of the runner.
+type WorkingDirStorage struct {
+	// PVC stores the working directory in a PersistentVolumeClaim, created for the object.
+	// +required
+	PVC *WorkingDirPVC `json:"pvc"`
+}
+
+// WorkingDirPVC is the PersistentVolumeClaim of a working directory.
+type WorkingDirPVC struct {
+	// Size of the volume.
+	// +required
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName of the volume. The default storage class is used when empty.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// ApplyRetry configures the retries of failed applies with the same plan.
+type ApplyRetry struct {
+	// MaxRetries is the number of times a plan is applied again after a failed apply,
+	// before it is discarded and a new plan is created.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=3
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+}
+
+// DefaultApplyRetries is the number of retries of a failed apply when spec.applyRetry.maxRetries is not set.
+const DefaultApplyRetries = 3
+
+// GetMaxRetries returns the number of retries, with the default applied.
+func (in ApplyRetry) GetMaxRetries() int32 {
+	if in.MaxRetries <= 0 {
+		return DefaultApplyRetries
+	}
+	return in.MaxRetries
+}
+
+// PlanDiff summarizes the resource changes of a plan against the pending plan it superseded.
+// Changes are in the "<action> <address>" format, e.g. "create aws_s3_bucket.logs".
+type PlanDiff struct {
+	// Superseded is the identifier of the superseded plan.
+	// +required
+	Superseded string `json:"superseded"`
+
+	// Added are the changes that the superseded plan did not have.
+	// +optional
+	Added []string `json:"added,omitempty"`
+
+	// Removed are the changes of the superseded plan that this plan no longer has.
+	// +optional
+	Removed []string `json:"removed,omitempty"`
+}
+
+// TerraformStatus defines the observed state of Terraform
+type TerraformStatus struct {
+	meta.ReconcileRequestStatus `json:",inline"`
+
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last successfully applied revision.
+	// The revision format for Git sources is <branch|tag>/<commit-sha>, or <branch|tag>@sha1:<commit-sha>
+	// with the newer source-controller versions.
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// LastAttemptedRevision is the revision of the last reconciliation attempt.
+	// +optional
+	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+
+	// LastPlannedRevision is the revision used by the last planning process.
+	// The result could be either no plan change or a new plan generated.
+	// +optional
+	LastPlannedRevision string `json:"lastPlannedRevision,omitempty"`
+
+	// Revisions are the revisions of the status normalized to <ref>@<algorithm>:<digest>,
+	// whatever the format of the source-controller reporting them.
+	// +optional
+	Revisions *RevisionsStatus `json:"revisions,omitempty"`
+
+	// LastDriftDetectedAt is the time when the last drift was detected
+	// +optional
+	LastDriftDetectedAt *metav1.Time `json:"lastDriftDetectedAt,omitempty"`
+
+	// DriftedResources are the addresses of the resources of the last drift detected.
+	// +optional
+	DriftedResources []string `json:"driftedResources,omitempty"`
+
+	// LastRegeneratedOutputs is the value of the regenerate-outputs annotation which last wrote the outputs again.
+	// +optional
+	LastRegeneratedOutputs string `json:"lastRegeneratedOutputs,omitempty"`
+
+	// LastReplacedResources is the value of the replace-resources annotation of the last applied plan replacing resources.
+	// +optional
+	LastReplacedResources string `json:"lastReplacedResources,omitempty"`
+
+	// Imports are the results of the last imports of spec.imports.
+	// +optional
+	Imports []ImportStatus `json:"imports,omitempty"`
+
+	// Checks are the results of the checks of the last run of each stage, in their order,
+	// up to the first failing check.
+	// +optional
+	Checks []CheckStatus `json:"checks,omitempty"`
+
+	// StateSnapshots are the snapshots of the state kept by spec.stateBackup, the most recent last.
+	// +optional
+	StateSnapshots []StateSnapshot `json:"stateSnapshots,omitempty"`
+
+	// LastRestoredStateFrom is the spec.restoreStateFrom which last restored the state.
+	// +optional
+	LastRestoredStateFrom string `json:"lastRestoredStateFrom,omitempty"`
+
+	// WorkingDirSnapshots are the snapshots of the working directory kept by spec.workingDirSnapshot, the most recent last.
+	// +optional
+	WorkingDirSnapshots []WorkingDirSnapshotStatus `json:"workingDirSnapshots,omitempty"`
+
+	// LastAppliedByDriftDetectionAt is the time when the last drift was detected and
+	// terraform apply was performed as a result
+	// +optional
+	LastAppliedByDriftDetectionAt *metav1.Time `json:"lastAppliedByDriftDetectionAt,omitempty"`
+
+	// LastApplyAt is the time when the last plan was applied successfully.
+	// +optional
+	LastApplyAt *metav1.Time `json:"lastApplyAt,omitempty"`
+
+	// LastOutputsRefreshAt is the time when the outputs were last written again by spec.outputs.refreshInterval.
+	// +optional
+	LastOutputsRefreshAt *metav1.Time `json:"lastOutputsRefreshAt,omitempty"`
+
+	// +optional
+	AvailableOutputs []string `json:"availableOutputs,omitempty"`
+
+	// +optional
+	Plan PlanStatus `json:"plan,omitempty"`
+
+	// Failures counts the consecutive failed reconciliations with the same reason.
+	// +optional
+	Failures FailureStatus `json:"failures,omitempty"`
+
+	// LastRun describes the last run of Terraform, with the progress of the apply and the diagnostics of a failed run.
+	// +optional
+	LastRun *RunStatus `json:"lastRun,omitempty"`
+
+	// RemoteRun is the last run of the remote backend, with backendConfig.type remote.
+	// +optional
+	RemoteRun *RemoteRunStatus `json:"remoteRun,omitempty"`
+
+	// BreakGlass records the last break-glass token used to apply a plan. A token is used only once.
+	// +optional
+	BreakGlass *BreakGlassStatus `json:"breakGlass,omitempty"`
+
+	// UsedBreakGlassTokens are the break-glass tokens used to apply a plan which have not expired yet.
+	// +optional
+	UsedBreakGlassTokens []UsedBreakGlassToken `json:"usedBreakGlassTokens,omitempty"`
+
+	// Inventory contains the list of Terraform resource object references that have been successfully applied.
+	// +optional
+	Inventory *ResourceInventory `json:"inventory,omitempty"`
+
+	// InventoryDiff is the change of the inventory made by the last apply, with the inventory enabled.
+	// +optional
+	InventoryDiff *InventoryDiff `json:"inventoryDiff,omitempty"`
+
+	// ModuleInterface contains the variables and the outputs declared by the module,
+	// as parsed from its source during the initialization.
+	// +optional
+	ModuleInterface *ModuleInterface `json:"moduleInterface,omitempty"`
+
+	// +optional
+	Lock LockStatus `json:"lock,omitempty"`
+
+	// Lineage is the lineage of the Terraform state managed by this object.
+	// The state is checked against it before every reconciliation.
+	// +optional
+	Lineage string `json:"lineage,omitempty"`
+
+	// WorkspaceLineages are the lineages of the states of the blue and green workspaces,
+	// in place of Lineage when ActiveWorkspace is set.
+	// +optional
+	WorkspaceLineages map[string]string `json:"workspaceLineages,omitempty"`
+
+	// ActiveWorkspace is the blue or green workspace whose outputs are written.
+	// +optional
+	ActiveWorkspace string `json:"activeWorkspace,omitempty"`
+
+	// ManagedResources is the number of managed resources of the last plan
+	// checked against resource limits.
+	// +optional
+	ManagedResources int `json:"managedResources,omitempty"`
+
+	// Providers are the provider versions locked by the last plan, recorded with the provider schema cache of the controller.
+	// +optional
+	Providers []ProviderVersion `json:"providers,omitempty"`
+
+	// MessagesConfigMap is the name of the ConfigMap storing the full messages of the conditions too long
+	// to be kept in the status, keyed by the type of their condition.
+	// +optional
+	MessagesConfigMap string `json:"messagesConfigMap,omitempty"`
+}
+
+// PolicyCheck refers to a ConfigMap whose keys ending with .rego are the modules of Rego policies.
+type PolicyCheck struct {
+	// Name of the ConfigMap.
+	// +required
+	Name string `json:"name"`
+
+	// Query evaluated against the plan, given as input. Each message it returns denies the plan.
+	// +kubebuilder:default:=data.terraform.deny
+	// +optional
+	Query string `json:"query,omitempty"`
+}
+
+// LockStatus defines the observed state of a Terraform State Lock
+type LockStatus struct {
+	// +optional
+	LastApplied string `json:"lastApplied,omitempty"`
+
+	// Pending holds the identifier of the Lock Holder to be used with Force Unlock
+	// +optional
+	Pending string `json:"pending,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=tf
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// Terraform is the Schema for the terraforms API
+type Terraform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TerraformSpec   `json:"spec,omitempty"`
+	Status TerraformStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformList contains a list of Terraform
+type TerraformList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Terraform `json:"items"`
+}
+
+// BackendConfigSpec is for specifying configuration for Terraform's Kubernetes backend
+type BackendConfigSpec struct {
+
+	// Disable is to completely disable the backend configuration.
+	// +optional
+	Disable bool `json:"disable"`
+
+	// Type is the kind of the backend. The kubernetes backend, the default, stores the state in a Secret
+	// and runs Terraform in the runner pods. The remote backend runs the plans and the applies in a workspace
+	// of Terraform Cloud or Terraform Enterprise, configured with Remote, while the controller tracks the runs.
+	// The s3, gcs and azurerm backends store the state in the bucket configured with S3, GCS or AzureRM,
+	// whose backend block is generated by the runner.
+	// +kubebuilder:validation:Enum=kubernetes;remote;s3;gcs;azurerm
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Remote is the workspace of Terraform Cloud or Terraform Enterprise of the remote backend.
+	// +optional
+	Remote *RemoteBackendSpec `json:"remote,omitempty"`
+
+	// S3 is the bucket of Amazon S3 of the s3 backend.
+	// +optional
+	S3 *S3BackendSpec `json:"s3,omitempty"`
+
+	// GCS is the bucket of Google Cloud Storage of the gcs backend.
+	// +optional
+	GCS *GCSBackendSpec `json:"gcs,omitempty"`
+
+	// AzureRM is the container of Azure Blob Storage of the azurerm backend.
+	// +optional
+	AzureRM *AzureRMBackendSpec `json:"azurerm,omitempty"`
+
+	// +optional
+	SecretSuffix string `json:"secretSuffix,omitempty"`
+
+	// +optional
+	InClusterConfig bool `json:"inClusterConfig,omitempty"`
+
+	// CustomConfiguration is the backend block of the Terraform configuration, replacing the kubernetes backend.
+	// It is rendered as a Go template with the ${{ and }} delimiters, with .Name, .Namespace, .Workspace and .Labels
+	// of the object, and the secret function reading a key of a Secret of its namespace
+	// labelled infra.contrib.fluxcd.io/backend-template=true.
+	// +optional
+	CustomConfiguration string `json:"customConfiguration,omitempty"`
+
+	// +optional
+	ConfigPath string `json:"configPath,omitempty"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// KeyPrefixPolicy namespaced puts the state of the object under the <namespace>/<name> prefix in the buckets
+	// of the backends of CustomConfiguration shared by many objects, the key of s3, azurerm and oss, the prefix
+	// of gcs and cos, or the path of consul. The key is generated when the backend does not set it, and prefixed
+	// otherwise. Two objects can't declare the same backend with this policy.
+	// +kubebuilder:validation:Enum=none;namespaced
+	// +optional
+	KeyPrefixPolicy string `json:"keyPrefixPolicy,omitempty"`
+
+	// AdoptExistingState allows a newly created object to take over a state
+	// found in the kubernetes backend with the same secretSuffix and workspace,
+	// for example when the object has been re-created under a new name.
+	// Without it, such a state blocks the reconciliation.
+	// +optional
+	AdoptExistingState bool `json:"adoptExistingState,omitempty"`
+}
+
+// RemoteBackendSpec is the workspace of Terraform Cloud or Terraform Enterprise running the plans and the applies.
+type RemoteBackendSpec struct {
+	// Hostname of Terraform Cloud, or of the Terraform Enterprise installation.
+	// +kubebuilder:default:=app.terraform.io
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Organization owning the workspace.
+	// +required
+	Organization string `json:"organization"`
+
+	// Workspace is the name of the workspace, created when it does not exist.
+	// Defaults to <namespace>-<name>, suffixed with -<workspace> for the workspaces other than default.
+	// +optional
+	Workspace string `json:"workspace,omitempty"`
+
+	// TokenSecretRef is the key of a Secret of the namespace of the object holding the API token.
+	// +required
+	TokenSecretRef meta.SecretKeyReference `json:"tokenSecretRef"`
+}
+
+// S3BackendSpec is the bucket of Amazon S3, or of a storage compatible with S3, holding the state.
+type S3BackendSpec struct {
+	// +required
+	Bucket string `json:"bucket"`
+
+	// Key of the state in the bucket. Defaults to <namespace>/<name>/terraform.tfstate.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// +required
+	Region string `json:"region"`
+
+	// Endpoint of a storage compatible with S3, replacing the one of Amazon S3.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// DynamoDBTable is the table of Amazon DynamoDB locking the state.
+	// +optional
+	DynamoDBTable string `json:"dynamodbTable,omitempty"`
+
+	// +optional
+	Encrypt bool `json:"encrypt,omitempty"`
 
-	// Disable is to completely disable the backend configuration.
 	// +optional
-	Disable bool `json:"disable"`
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
 
+	// CredentialsSecretRef refers to a Secret of the namespace of the object with the access_key,
+	// secret_key and token keys. The runner uses the credentials of its pod without it.
 	// +optional
-	SecretSuffix string `json:"secretSuffix,omitempty"`
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// GCSBackendSpec is the bucket of Google Cloud Storage holding the state.
+type GCSBackendSpec struct {
+	// +required
+	Bucket string `json:"bucket"`
 
+	// Prefix of the state in the bucket. Defaults to <namespace>/<name>.
 	// +optional
-	InClusterConfig bool `json:"inClusterConfig,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
 
+	// CredentialsSecretRef refers to a Secret of the namespace of the object with the credentials key,
+	// holding the JSON key of a service account. The runner uses the credentials of its pod without it.
 	// +optional
-	CustomConfiguration string `json:"customConfiguration,omitempty"`
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// AzureRMBackendSpec is the container of Azure Blob Storage holding the state.
+type AzureRMBackendSpec struct {
+	// +required
+	ResourceGroupName string `json:"resourceGroupName"`
+
+	// +required
+	StorageAccountName string `json:"storageAccountName"`
+
+	// +required
+	ContainerName string `json:"containerName"`
 
+	// Key of the state in the container. Defaults to <namespace>/<name>/terraform.tfstate.
 	// +optional
-	ConfigPath string `json:"configPath,omitempty"`
+	Key string `json:"key,omitempty"`
 
+	// CredentialsSecretRef refers to a Secret of the namespace of the object with the access_key or sas_token keys,
+	// or the client_id, client_secret, tenant_id and subscription_id keys of a service principal.
+	// The runner uses the credentials of its pod without it.
 	// +optional
-	Labels map[string]string `json:"labels,omitempty"`
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // TFStateSpec allows the user to set ForceUnlock
@@ -424,6 +1734,25 @@ const (
 	ApprovePlanAutoValue      = "auto"
 	ApprovePlanDisableValue   = "disable"
 	DefaultWorkspaceName      = "default"
+	DestroyPlanIdPrefix       = "destroy"
+	// MaxDiagnostics bounds the diagnostics kept in the status, and MaxDiagnosticDetailLength the length of their detail.
+	MaxDiagnostics            = 10
+	MaxDiagnosticDetailLength = 1024
+	// EngineTerraform and EngineOpenTofu are the engines of spec.engine.
+	EngineTerraform = "terraform"
+	EngineOpenTofu  = "tofu"
+	// KeyPrefixPolicyNone and KeyPrefixPolicyNamespaced are the policies of spec.backendConfig.keyPrefixPolicy.
+	KeyPrefixPolicyNone       = "none"
+	KeyPrefixPolicyNamespaced = "namespaced"
+	// BackendTypeKubernetes, BackendTypeRemote, BackendTypeS3, BackendTypeGCS and BackendTypeAzureRM are the types of spec.backendConfig.type.
+	BackendTypeKubernetes = "kubernetes"
+	BackendTypeRemote     = "remote"
+	BackendTypeS3         = "s3"
+	BackendTypeGCS        = "gcs"
+	BackendTypeAzureRM    = "azurerm"
+	// OutputsDeletionPolicyDelete and OutputsDeletionPolicyRetain are the policies of spec.writeOutputsToSecret.deletionPolicy.
+	OutputsDeletionPolicyDelete = "delete"
+	OutputsDeletionPolicyRetain = "retain"
 )
 
 // The potential reasons that are associated with condition types
@@ -448,6 +1777,49 @@ const (
 	TFExecApplySucceedReason        = "TerraformAppliedSucceed"
 	TFExecLockHeldReason            = "LockHeld"
 	TFExecForceUnlockReason         = "ForceUnlock"
+	OutputsModifiedReason           = "OutputsModified"
+	OutputsMissingReason            = "OutputsMissing"
+	PlanSecretModifiedReason        = "PlanSecretModified"
+	StateAdoptionRequiredReason     = "StateAdoptionRequired"
+	StateLineageMismatchReason      = "StateLineageMismatch"
+	OutputsSchemaInvalidReason      = "OutputsSchemaInvalid"
+	ResourceLimitsExceededReason    = "ResourceLimitsExceeded"
+	PolicyFailedReason              = "PolicyFailed"
+	PolicyPassedReason              = "PolicyPassed"
+	PlanStaleReason                 = "PlanStale"
+	BackendConflictReason           = "BackendConflict"
+	MissingVariablesReason          = "MissingVariables"
+	UndeclaredVariablesReason       = "UndeclaredVariables"
+	InvalidValuesReason             = "InvalidValues"
+	ApplySuspendedReason            = "ApplySuspended"
+	ChangeFreezeReason              = "ChangeFreeze"
+	DestroyPlanPendingReason        = "DestroyPlanPending"
+	RunnerLostReason                = "RunnerLost"
+	ApplyInterruptedReason          = "ApplyInterrupted"
+	PlanTooLargeReason              = "PlanTooLarge"
+	CDKTFSynthFailedReason          = "CDKTFSynthFailed"
+	SourceNotFoundReason            = "SourceNotFound"
+	OutputsWritingForbiddenReason   = "OutputsWritingForbidden"
+	OutputsConflictReason           = "OutputsConflict"
+	NamespaceConfigInvalidReason    = "NamespaceConfigInvalid"
+	RemoteRunFailedReason           = "RemoteRunFailed"
+	StateKeyInvalidReason           = "StateKeyInvalid"
+	BackendCredentialsFailedReason  = "BackendCredentialsFailed"
+	UpstreamOutputsMissingReason    = "UpstreamOutputsMissing"
+	TFExecImportFailedReason        = "TFExecImportFailed"
+	StateBackupFailedReason         = "StateBackupFailed"
+	StateRestoreFailedReason        = "StateRestoreFailed"
+)
+
+// The classes of the errors of the failed reconciliations, reported as the reasons of the Failure condition
+const (
+	AuthErrorReason         = "AuthError"
+	QuotaExceededReason     = "QuotaExceeded"
+	SyntaxErrorReason       = "SyntaxError"
+	ProviderCrashReason     = "ProviderCrash"
+	StateLockedReason       = "StateLocked"
+	NetworkErrorReason      = "NetworkError"
+	UnclassifiedErrorReason = "Unclassified"
 )
 
 // These constants are the Condition Types that the Terraform Resource works with
@@ -457,6 +1829,13 @@ const (
 	ConditionTypeOutput      = "Output"
 	ConditionTypePlan        = "Plan"
 	ConditionTypeStateLocked = "StateLocked"
+	ConditionTypePolicyCheck = "PolicyCheck"
+	// ConditionTypeFailure classifies the error of the last failed reconciliation,
+	// from the diagnostics of Terraform, in its reason.
+	ConditionTypeFailure = "Failure"
+	// ConditionTypeOutputsOutOfSync is set when the output secret no longer
+	// matches what the controller last wrote to it.
+	ConditionTypeOutputsOutOfSync = "OutputsOutOfSync"
 )
 
 // Webhook stages
@@ -464,6 +1843,24 @@ const (
 	PostPlanningWebhook = "post-planning"
 )
 
+// Apply strictness levels
+const (
+	ApplyStrictnessNone  = "none"
+	ApplyStrictnessState = "state"
+)
+
+// Vars policies
+const (
+	VarsPolicyLenient = "lenient"
+	VarsPolicyStrict  = "strict"
+)
+
+// Logging levels
+const (
+	LoggingLevelFull    = "full"
+	LoggingLevelSummary = "summary"
+)
+
 const (
 	TFDependencyOfPrefix = "tf.dependency.of."
 )
@@ -516,11 +1913,39 @@ func TerraformOutputsWritten(terraform Terraform, revision string, message strin
 		Message: trimString(message, MaxConditionMessageLength),
 	}
 	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypeOutputsOutOfSync)
 
 	SetTerraformReadiness(&terraform, metav1.ConditionTrue, "TerraformOutputsWritten", message+": "+revision, revision)
 	return terraform
 }
 
+// TerraformOutputsInvalid marks the outputs as not satisfying the schema
+// of .spec.writeOutputsToSecret, so that they are not written.
+func TerraformOutputsInvalid(terraform Terraform, revision string, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeOutput,
+		Status:  metav1.ConditionFalse,
+		Reason:  OutputsSchemaInvalidReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+
+	return TerraformNotReady(terraform, revision, OutputsSchemaInvalidReason, message)
+}
+
+// TerraformOutputsOutOfSync marks the output secret as deleted or modified
+// out-of-band, so that the outputs will be re-written.
+func TerraformOutputsOutOfSync(terraform Terraform, reason, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeOutputsOutOfSync,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
 func TerraformApplied(terraform Terraform, revision string, message string, isDestroyApply bool, entries []ResourceRef) Terraform {
 	newCondition := metav1.Condition{
 		Type:    ConditionTypeApply,
@@ -534,16 +1959,29 @@ func TerraformApplied(terraform Terraform, revision string, message string, isDe
 		(&terraform).Status.LastAppliedByDriftDetectionAt = &metav1.Time{Time: time.Now()}
 	}
 
+	if terraform.Status.Plan.Replaces != "" {
+		(&terraform).Status.LastReplacedResources = terraform.Status.Plan.Replaces
+	}
+
 	(&terraform).Status.Plan = PlanStatus{
 		LastApplied:   terraform.Status.Plan.Pending,
 		Pending:       "",
 		IsDestroyPlan: isDestroyApply,
 	}
+	(&terraform).Status.LastApplyAt = &metav1.Time{Time: time.Now()}
+	fromRevision := terraform.Status.LastAppliedRevision
 	if revision != "" {
 		(&terraform).Status.LastAppliedRevision = revision
 	}
 
 	if len(entries) > 0 {
+		added, removed := DiffInventory(terraform.Status.Inventory, entries)
+		(&terraform).Status.InventoryDiff = &InventoryDiff{
+			FromRevision: fromRevision,
+			Revision:     terraform.Status.LastAppliedRevision,
+			Added:        added,
+			Removed:      removed,
+		}
 		(&terraform).Status.Inventory = &ResourceInventory{Entries: entries}
 	}
 
@@ -552,19 +1990,88 @@ func TerraformApplied(terraform Terraform, revision string, message string, isDe
 }
 
 func GetPlanIdAndApproveMessage(revision string, message string) (string, string) {
-	planRev := strings.Replace(revision, "/", "-", 1)
-	planId := fmt.Sprintf("plan-%s", planRev)
-	shortPlanId := planId
-	parts := strings.SplitN(revision, "/", 2)
-	if len(parts) == 2 {
-		if len(parts[1]) >= 10 {
-			shortPlanId = fmt.Sprintf("plan-%s-%s", parts[0], parts[1][0:10])
+	return getPlanIdAndApproveMessage("plan", revision, message)
+}
+
+// GetDestroyPlanIdAndApproveMessage returns the id of the plan destroying the resources of an object being deleted,
+// destroy-<ref>-<digest>-<hash>, which an approval of the plan-<ref>-<digest>-<hash> plan does not match.
+func GetDestroyPlanIdAndApproveMessage(revision string, message string) (string, string) {
+	return getPlanIdAndApproveMessage(DestroyPlanIdPrefix, revision, message)
+}
+
+// IsDestroyPlanId returns true if the plan id is the one of a plan destroying the resources of an object being deleted.
+func IsDestroyPlanId(planId string) bool {
+	return strings.HasPrefix(planId, DestroyPlanIdPrefix+"-")
+}
+
+// maxPlanIdRefLength keeps the plan ids within the 63 characters of a label value.
+const maxPlanIdRefLength = 24
+
+var planIdUnsafeRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// getPlanIdAndApproveMessage returns the id of the plan of a revision, <prefix>-<ref>-<digest>-<hash>.
+// The ref, truncated, and the first 10 characters of the digest are for the humans, and the hash of the whole revision
+// tells apart the revisions which would otherwise get the same id. The approve message gives the id without its hash,
+// which approves the plan as a prefix of its id.
+func getPlanIdAndApproveMessage(prefix string, revision string, message string) (string, string) {
+	rev := ParseRevision(revision)
+	shortPlanId := prefix
+	if rev.Ref != "" {
+		ref := planIdUnsafeRegexp.ReplaceAllString(rev.Ref, "-")
+		if len(ref) > maxPlanIdRefLength {
+			ref = ref[:maxPlanIdRefLength]
 		}
+		shortPlanId += "-" + ref
+	}
+	if digest := planIdUnsafeRegexp.ReplaceAllString(rev.ShortDigest(), "-"); digest != "" {
+		shortPlanId += "-" + digest
 	}
+	sum := sha256.Sum256([]byte(rev.String()))
+	planId := shortPlanId + "-" + hex.EncodeToString(sum[:])[:10]
+
 	approveMessage := fmt.Sprintf("%s: set approvePlan: \"%s\" to approve this plan.", message, shortPlanId)
 	return planId, approveMessage
 }
 
+// legacyPlanIds returns the ids given to the plan of a revision by the previous versions.
+func legacyPlanIds(prefix string, revision string) []string {
+	rev := ParseRevision(revision)
+	ids := []string{prefix + "-" + strings.Replace(revision, "/", "-", 1)}
+	if rev.Ref != "" {
+		ids = append(ids, prefix+"-"+strings.ReplaceAll(rev.Ref, "/", "-")+"-"+rev.Digest)
+	}
+	return ids
+}
+
+// PlanIdMatches returns true if id designates the plan planId of the revision, being its id or a prefix of it.
+// The ids given to the plan by the previous versions, and their prefixes, are accepted too.
+func PlanIdMatches(id string, planId string, revision string) bool {
+	if id == "" || planId == "" {
+		return false
+	}
+	if strings.HasPrefix(planId, id) {
+		return true
+	}
+	if revision == "" {
+		return false
+	}
+
+	prefix := "plan"
+	if IsDestroyPlanId(planId) {
+		prefix = DestroyPlanIdPrefix
+	}
+	if generated, _ := getPlanIdAndApproveMessage(prefix, revision, ""); generated != planId {
+		// the plan is not the one of the revision
+		return false
+	}
+	for _, legacyId := range legacyPlanIds(prefix, revision) {
+		if strings.HasPrefix(legacyId, id) {
+			return true
+		}
+	}
+	return false
+}
+
 func TerraformPostPlanningWebhookFailed(terraform Terraform, revision string, message string) Terraform {
 	newCondition := metav1.Condition{
 		Type:    ConditionTypePlan,
@@ -598,6 +2105,7 @@ func TerraformPlannedWithChanges(terraform Terraform, revision string, forceOrAu
 	(&terraform).Status.Plan = PlanStatus{
 		LastApplied:          terraform.Status.Plan.LastApplied,
 		Pending:              planId,
+		CreatedAt:            &metav1.Time{Time: time.Now()},
 		IsDestroyPlan:        terraform.Spec.Destroy,
 		IsDriftDetectionPlan: terraform.HasDrift(),
 	}
@@ -615,6 +2123,32 @@ func TerraformPlannedWithChanges(terraform Terraform, revision string, forceOrAu
 	return terraform
 }
 
+// TerraformDestroyPlanned records the plan destroying the resources of an object being deleted,
+// pending until it is approved.
+func TerraformDestroyPlanned(terraform Terraform, revision string, message string) Terraform {
+	planId, approveMessage := GetDestroyPlanIdAndApproveMessage(revision, message)
+	newCondition := metav1.Condition{
+		Type:    ConditionTypePlan,
+		Status:  metav1.ConditionTrue,
+		Reason:  DestroyPlanPendingReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	(&terraform).Status.Plan = PlanStatus{
+		LastApplied:   terraform.Status.Plan.LastApplied,
+		Pending:       planId,
+		CreatedAt:     &metav1.Time{Time: time.Now()},
+		IsDestroyPlan: true,
+	}
+	if revision != "" {
+		(&terraform).Status.LastAttemptedRevision = revision
+		(&terraform).Status.LastPlannedRevision = revision
+	}
+
+	SetTerraformReadiness(&terraform, metav1.ConditionUnknown, DestroyPlanPendingReason, approveMessage, revision)
+	return terraform
+}
+
 func TerraformPlannedNoChanges(terraform Terraform, revision string, message string) Terraform {
 	newCondition := metav1.Condition{
 		Type:    ConditionTypePlan,
@@ -650,15 +2184,122 @@ func TerraformProgressing(terraform Terraform, message string) Terraform {
 	return terraform
 }
 
+// TerraformApplyBlocked registers that the pending plan of the given Terraform is not applied for the given reason,
+// while planning goes on.
+func TerraformApplyBlocked(terraform Terraform, revision, reason, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeApply,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	SetTerraformReadiness(&terraform, metav1.ConditionUnknown, reason, trimString(message, MaxConditionMessageLength), revision)
+	return terraform
+}
+
 // TerraformNotReady registers a failed apply attempt of the given Terraform.
+// A message too long keeps its first line and its end, where Terraform reports its errors.
 func TerraformNotReady(terraform Terraform, revision, reason, message string) Terraform {
-	SetTerraformReadiness(&terraform, metav1.ConditionFalse, reason, trimString(message, MaxConditionMessageLength), revision)
+	SetTerraformReadiness(&terraform, metav1.ConditionFalse, reason, trimStringTail(message, MaxConditionMessageLength), revision)
 	if revision != "" {
 		terraform.Status.LastAttemptedRevision = revision
 	}
 	return terraform
 }
 
+// TerraformFailed counts the failed reconciliation, with the reason of the Ready condition,
+// and returns the number of consecutive failures with this reason.
+func TerraformFailed(terraform Terraform) (Terraform, int32) {
+	reason := ""
+	if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil {
+		reason = ready.Reason
+	}
+
+	if terraform.Status.Failures.Reason == reason {
+		terraform.Status.Failures.Count++
+	} else {
+		terraform.Status.Failures.Reason = reason
+		terraform.Status.Failures.Count = 1
+	}
+	return terraform, terraform.Status.Failures.Count
+}
+
+// TerraformFailureClassified records the class of the error of a failed reconciliation.
+func TerraformFailureClassified(terraform Terraform, class string, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeFailure,
+		Status:  metav1.ConditionTrue,
+		Reason:  class,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformRunFailed records the diagnostics of a failed run of Terraform.
+func TerraformRunFailed(terraform Terraform, diagnostics []Diagnostic) Terraform {
+	if len(diagnostics) > MaxDiagnostics {
+		diagnostics = diagnostics[:MaxDiagnostics]
+	}
+	for i := range diagnostics {
+		diagnostics[i].Detail = trimString(diagnostics[i].Detail, MaxDiagnosticDetailLength)
+	}
+	if terraform.Status.LastRun == nil {
+		terraform.Status.LastRun = &RunStatus{}
+	}
+	terraform.Status.LastRun.Diagnostics = diagnostics
+	return terraform
+}
+
+// TerraformApplyProgressed records the progress of the running apply.
+func TerraformApplyProgressed(terraform Terraform, completed, total int32) Terraform {
+	if terraform.Status.LastRun == nil {
+		terraform.Status.LastRun = &RunStatus{}
+	}
+	terraform.Status.LastRun.Progress = &ApplyProgress{
+		Completed:      completed,
+		Total:          total,
+		LastUpdateTime: metav1.Now(),
+	}
+	return terraform
+}
+
+// TerraformStalled stops retrying an object failing repeatedly for the same reason, until its failures are acknowledged.
+func TerraformStalled(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    meta.StalledCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  terraform.Status.Failures.Reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
+// TerraformSourceNotFound counts the reconciliations which did not find the source,
+// and returns the number of them in a row.
+func TerraformSourceNotFound(terraform Terraform, message string) (Terraform, int32) {
+	terraform = TerraformNotReady(terraform, "", ArtifactFailedReason, message)
+	if terraform.Status.Failures.Reason == SourceNotFoundReason {
+		terraform.Status.Failures.Count++
+	} else {
+		terraform.Status.Failures.Reason = SourceNotFoundReason
+		terraform.Status.Failures.Count = 1
+	}
+	return terraform, terraform.Status.Failures.Count
+}
+
+// TerraformFailuresReset clears the failures of an object, with their class and diagnostics, and resumes it if stalled.
+func TerraformFailuresReset(terraform Terraform) Terraform {
+	terraform.Status.Failures.Reason = ""
+	terraform.Status.Failures.Count = 0
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), meta.StalledCondition)
+	apimeta.RemoveStatusCondition(terraform.GetStatusConditions(), ConditionTypeFailure)
+	terraform.Status.LastRun = nil
+	return terraform
+}
+
 func TerraformAppliedFailResetPlanAndNotReady(terraform Terraform, revision, reason, message string) Terraform {
 	newCondition := metav1.Condition{
 		Type:    ConditionTypeApply,
@@ -672,14 +2313,68 @@ func TerraformAppliedFailResetPlanAndNotReady(terraform Terraform, revision, rea
 	return terraform
 }
 
-func TerraformDriftDetected(terraform Terraform, revision, reason, message string) Terraform {
+// TerraformApplyRetryScheduled keeps the pending plan after a failed apply, for it to be applied again.
+func TerraformApplyRetryScheduled(terraform Terraform, revision, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeApply,
+		Status:  metav1.ConditionFalse,
+		Reason:  "TerraformAppliedFail",
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	terraform = TerraformNotReady(terraform, revision, TFExecApplyFailedReason, message)
+	terraform.Status.Plan.ApplyRetries++
+	return terraform
+}
+
+// TerraformRunnerLost records an apply interrupted by the loss of its runner. The pending plan is dropped,
+// as some of its changes may have been applied.
+func TerraformRunnerLost(terraform Terraform, revision, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeApply,
+		Status:  metav1.ConditionFalse,
+		Reason:  RunnerLostReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	terraform = TerraformNotReady(terraform, revision, RunnerLostReason, message)
+	terraform.Status.Plan.Pending = ""
+	return terraform
+}
+
+// TerraformApplyInterrupted records an apply stopped gracefully by its runner, whose pod is being terminated.
+// The pending plan is dropped, as the changes applied until then have advanced the state.
+func TerraformApplyInterrupted(terraform Terraform, revision, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypeApply,
+		Status:  metav1.ConditionFalse,
+		Reason:  ApplyInterruptedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	terraform = TerraformNotReady(terraform, revision, ApplyInterruptedReason, message)
+	terraform.Status.Plan.Pending = ""
+	return terraform
+}
+
+// TerraformPlanStale drops the pending plan, so that a new plan is created instead of applying the stale one.
+func TerraformPlanStale(terraform Terraform, revision, message string) Terraform {
+	terraform = TerraformNotReady(terraform, revision, PlanStaleReason, message)
+	terraform.Status.Plan.Pending = ""
+	return terraform
+}
+
+// TerraformDriftDetected records the drift of the resources, and sets the ReadyCondition to false.
+func TerraformDriftDetected(terraform Terraform, revision, reason, message string, resources []string) Terraform {
 	(&terraform).Status.LastDriftDetectedAt = &metav1.Time{Time: time.Now()}
+	(&terraform).Status.DriftedResources = resources
 
 	SetTerraformReadiness(&terraform, metav1.ConditionFalse, reason, trimString(message, MaxConditionMessageLength), revision)
 	return terraform
 }
 
 func TerraformNoDrift(terraform Terraform, revision, reason, message string) Terraform {
+	(&terraform).Status.DriftedResources = nil
 	SetTerraformReadiness(&terraform, metav1.ConditionTrue, reason, message+": "+revision, revision)
 	return terraform
 }
@@ -745,6 +2440,30 @@ func TerraformStateLocked(terraform Terraform, lockID, message string) Terraform
 	return terraform
 }
 
+// TerraformPolicyFailed registers the plan denied by the policy checks, with the messages of the policies.
+func TerraformPolicyFailed(terraform Terraform, revision, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypePolicyCheck,
+		Status:  metav1.ConditionFalse,
+		Reason:  PolicyFailedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return TerraformNotReady(terraform, revision, PolicyFailedReason, message)
+}
+
+// TerraformPolicyPassed registers the plan allowed by the policy checks.
+func TerraformPolicyPassed(terraform Terraform, message string) Terraform {
+	newCondition := metav1.Condition{
+		Type:    ConditionTypePolicyCheck,
+		Status:  metav1.ConditionTrue,
+		Reason:  PolicyPassedReason,
+		Message: trimString(message, MaxConditionMessageLength),
+	}
+	apimeta.SetStatusCondition(terraform.GetStatusConditions(), newCondition)
+	return terraform
+}
+
 // HasDrift returns true if drift has been detected since the last successful apply
 func (in Terraform) HasDrift() bool {
 	for _, condition := range in.Status.Conditions {
@@ -760,7 +2479,29 @@ func (in Terraform) HasDrift() bool {
 
 // GetDependsOn returns the list of dependencies, namespace scoped.
 func (in Terraform) GetDependsOn() []meta.NamespacedObjectReference {
-	return in.Spec.DependsOn
+	deps := make([]meta.NamespacedObjectReference, 0, len(in.Spec.DependsOn))
+	for _, d := range in.Spec.DependsOn {
+		deps = append(deps, meta.NamespacedObjectReference{Name: d.Name, Namespace: d.Namespace})
+	}
+	return deps
+}
+
+// GetTargets returns the addresses of the targeted resources.
+func (in Terraform) GetTargets() []string {
+	if len(in.Spec.Targets) == 0 {
+		return nil
+	}
+	targets := make([]string, 0, len(in.Spec.Targets))
+	for _, target := range in.Spec.Targets {
+		targets = append(targets, string(target))
+	}
+	return targets
+}
+
+// IsManualReconciliation returns true if the object is only reconciled on explicit requests and changes,
+// and never on a timer.
+func (in Terraform) IsManualReconciliation() bool {
+	return in.Spec.ManualReconciliation || in.Spec.Interval.Duration == 0
 }
 
 // GetRetryInterval returns the retry interval
@@ -777,10 +2518,49 @@ func (in *Terraform) GetStatusConditions() *[]metav1.Condition {
 }
 
 func (in *Terraform) WorkspaceName() string {
+	workspace := DefaultWorkspaceName
 	if in.Spec.Workspace != "" {
-		return in.Spec.Workspace
+		workspace = in.Spec.Workspace
+	}
+	if in.Spec.ActiveWorkspace != "" {
+		return workspace + "-" + in.Spec.ActiveWorkspace
+	}
+	return workspace
+}
+
+// WorkspaceNames returns the names of all the workspaces managed by the object,
+// both the blue and the green one when ActiveWorkspace is set.
+func (in *Terraform) WorkspaceNames() []string {
+	if in.Spec.ActiveWorkspace == "" {
+		return []string{in.WorkspaceName()}
+	}
+	workspace := strings.TrimSuffix(in.WorkspaceName(), "-"+in.Spec.ActiveWorkspace)
+	return []string{workspace + "-blue", workspace + "-green"}
+}
+
+// IsSwitchingWorkspace returns true if the outputs are still those of the previously active blue or green workspace.
+func (in *Terraform) IsSwitchingWorkspace() bool {
+	return in.Spec.ActiveWorkspace != "" && in.Status.ActiveWorkspace != "" && in.Status.ActiveWorkspace != in.Spec.ActiveWorkspace
+}
+
+// StateLineage returns the recorded lineage of the state of the workspace.
+func (in *Terraform) StateLineage() string {
+	if in.Spec.ActiveWorkspace != "" {
+		return in.Status.WorkspaceLineages[in.Spec.ActiveWorkspace]
 	}
-	return DefaultWorkspaceName
+	return in.Status.Lineage
+}
+
+// SetStateLineage records the lineage of the state of the workspace.
+func (in *Terraform) SetStateLineage(lineage string) {
+	if in.Spec.ActiveWorkspace != "" {
+		if in.Status.WorkspaceLineages == nil {
+			in.Status.WorkspaceLineages = map[string]string{}
+		}
+		in.Status.WorkspaceLineages[in.Spec.ActiveWorkspace] = lineage
+		return
+	}
+	in.Status.Lineage = lineage
 }
 
 func (in Terraform) ToBytes(scheme *runtime.Scheme) ([]byte, error) {
@@ -806,6 +2586,104 @@ func (in *Terraform) GetRunnerHostname(ip string) string {
 	return fmt.Sprintf("%s.%s.pod.cluster.local", prefix, in.Namespace)
 }
 
+// WorkingDirPVCName returns the name of the PersistentVolumeClaim storing the working directory of the runner.
+func (in Terraform) WorkingDirPVCName() string {
+	return fmt.Sprintf("%s-tf-runner-workdir", in.Name)
+}
+
+// MessagesConfigMapName returns the name of the ConfigMap storing the long messages of the conditions.
+func (in Terraform) MessagesConfigMapName() string {
+	return fmt.Sprintf("%s-tf-messages", in.Name)
+}
+
+// OutputsSecretNamespace returns the namespace of the Secret of the outputs.
+func (in Terraform) OutputsSecretNamespace() string {
+	if in.Spec.WriteOutputsToSecret != nil && in.Spec.WriteOutputsToSecret.Namespace != "" {
+		return in.Spec.WriteOutputsToSecret.Namespace
+	}
+	return in.Namespace
+}
+
+// RetainsOutputsSecret returns true if the Secret of the outputs is kept when the object is deleted.
+func (in Terraform) RetainsOutputsSecret() bool {
+	return in.Spec.WriteOutputsToSecret != nil && in.Spec.WriteOutputsToSecret.DeletionPolicy == OutputsDeletionPolicyRetain
+}
+
+// HasNamespacedStateKey returns true if the state key of the custom backend is prefixed with the namespace and the name.
+func (in Terraform) HasNamespacedStateKey() bool {
+	return in.Spec.BackendConfig != nil && in.Spec.BackendConfig.KeyPrefixPolicy == KeyPrefixPolicyNamespaced
+}
+
+// StateKeyPrefix returns the prefix of the state key of the object with keyPrefixPolicy namespaced.
+func (in Terraform) StateKeyPrefix() string {
+	return in.Namespace + "/" + in.Name
+}
+
+// IsRemote returns true if the plans and the applies run in Terraform Cloud, with backendConfig.type remote.
+func (in Terraform) IsRemote() bool {
+	return in.Spec.BackendConfig != nil && in.Spec.BackendConfig.Type == BackendTypeRemote && in.Spec.BackendConfig.Remote != nil
+}
+
+// HasTypedBackend returns true if the backend block of the state is generated from backendConfig.s3, gcs or azurerm.
+func (in Terraform) HasTypedBackend() bool {
+	if in.Spec.BackendConfig == nil {
+		return false
+	}
+	switch backend := in.Spec.BackendConfig; backend.Type {
+	case BackendTypeS3:
+		return backend.S3 != nil
+	case BackendTypeGCS:
+		return backend.GCS != nil
+	case BackendTypeAzureRM:
+		return backend.AzureRM != nil
+	}
+	return false
+}
+
+// RemoteWorkspaceName returns the name of the workspace of Terraform Cloud of the remote backend.
+func (in Terraform) RemoteWorkspaceName() string {
+	if in.Spec.BackendConfig != nil && in.Spec.BackendConfig.Remote != nil && in.Spec.BackendConfig.Remote.Workspace != "" {
+		return in.Spec.BackendConfig.Remote.Workspace
+	}
+	name := in.Namespace + "-" + in.Name
+	if workspace := in.WorkspaceName(); workspace != DefaultWorkspaceName {
+		name += "-" + workspace
+	}
+	return name
+}
+
+// RunnerServiceAccountName returns the name of the ServiceAccount of the runner Pod.
+func (in Terraform) RunnerServiceAccountName() string {
+	if in.Spec.Runner != nil && in.Spec.Runner.CreateServiceAccount {
+		return fmt.Sprintf("%s-tf-runner", in.Name)
+	}
+	if in.Spec.ServiceAccountName == "" {
+		return "tf-runner"
+	}
+	return in.Spec.ServiceAccountName
+}
+
+// EngineBinary returns the name of the binary of the engine of this object, looked up in the PATH of the runner.
+func (in Terraform) EngineBinary() string {
+	if in.Spec.Engine == "" {
+		return EngineTerraform
+	}
+	return in.Spec.Engine
+}
+
+// RunnerTokenAudience returns the audience of the token of the runner Pod scoped to this object.
+func (in Terraform) RunnerTokenAudience() string {
+	if in.Spec.Runner != nil && in.Spec.Runner.ScopedToken != nil && in.Spec.Runner.ScopedToken.Audience != "" {
+		return in.Spec.Runner.ScopedToken.Audience
+	}
+	return fmt.Sprintf("tf-runner/%s/%s", in.Namespace, in.Name)
+}
+
+// HasPersistentWorkingDir reports whether the working directory of the runner is kept across the reconciliations.
+func (in TerraformSpec) HasPersistentWorkingDir() bool {
+	return in.WorkingDirStorage != nil && in.WorkingDirStorage.PVC != nil
+}
+
 func (in *TerraformSpec) GetAlwaysCleanupRunnerPod() bool {
 	if in.AlwaysCleanupRunnerPod == nil {
 		return true
@@ -822,6 +2700,23 @@ func trimString(str string, limit int) string {
 	return str[0:limit] + "..."
 }
 
+// trimStringTail trims str to the limit, keeping its first line and its end.
+func trimStringTail(str string, limit int) string {
+	if len(str) <= limit {
+		return str
+	}
+
+	head := ""
+	if i := strings.IndexByte(str, '\n'); i >= 0 && i < limit/4 {
+		head = str[:i+1]
+	}
+	tail := str[len(str)-(limit-len(head)-len("...")):]
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return head + "..." + tail
+}
+
 func init() {
 	SchemeBuilder.Register(&Terraform{}, &TerraformList{})
 }
@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformDriftReportKind = "TerraformDriftReport"
+)
+
+// TerraformDriftReportStatus summarizes the Terraform objects of the cluster with a drift.
+type TerraformDriftReportStatus struct {
+	// GeneratedAt is the time when the report was last generated.
+	// +optional
+	GeneratedAt *metav1.Time `json:"generatedAt,omitempty"`
+
+	// TotalObjects is the number of the Terraform objects of the cluster.
+	// +optional
+	TotalObjects int `json:"totalObjects"`
+
+	// DriftedObjects is the number of the Terraform objects with a drift.
+	// +optional
+	DriftedObjects int `json:"driftedObjects"`
+
+	// Drifts are the Terraform objects with a drift, sorted by namespace and name.
+	// +optional
+	Drifts []ObjectDrift `json:"drifts,omitempty"`
+}
+
+// ObjectDrift is the drift of a Terraform object.
+type ObjectDrift struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// LastAppliedRevision is the revision applied before the drift.
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// FirstDetectedAt is the time when the drift was first reported.
+	FirstDetectedAt metav1.Time `json:"firstDetectedAt"`
+
+	// LastDetectedAt is the time when the drift was last detected.
+	LastDetectedAt metav1.Time `json:"lastDetectedAt"`
+
+	// Resources are the addresses of the drifted resources, or the objects of the inventory deleted out-of-band.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Drifted",type="integer",JSONPath=".status.driftedObjects",description=""
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.totalObjects",description=""
+// +kubebuilder:printcolumn:name="Generated",type="date",JSONPath=".status.generatedAt",description=""
+
+// TerraformDriftReport is the Schema for the terraformdriftreports API, generated by the controller.
+type TerraformDriftReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status TerraformDriftReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformDriftReportList contains a list of TerraformDriftReport
+type TerraformDriftReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformDriftReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformDriftReport{}, &TerraformDriftReportList{})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformNamespaceConfigKind = "TerraformNamespaceConfig"
+)
+
+// TerraformNamespaceConfigSpec defines the defaults of the Terraform objects of a namespace.
+// A field left empty by a Terraform object inherits the default.
+type TerraformNamespaceConfigSpec struct {
+	// ServiceAccountName is the default .spec.serviceAccountName.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// RunnerImage is the default .spec.runnerPodTemplate.spec.image.
+	// +optional
+	RunnerImage string `json:"runnerImage,omitempty"`
+
+	// ApprovePlan is the default .spec.approvePlan, "auto" to approve every plan,
+	// or "disable" to only detect the drifts.
+	// +kubebuilder:validation:Enum=auto;disable
+	// +optional
+	ApprovePlan string `json:"approvePlan,omitempty"`
+
+	// DisableDriftDetection disables the drift detection of all the Terraform objects of the namespace.
+	// +optional
+	DisableDriftDetection bool `json:"disableDriftDetection,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Approve Plan",type="string",JSONPath=".spec.approvePlan",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformNamespaceConfig is the Schema for the terraformnamespaceconfigs API
+type TerraformNamespaceConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformNamespaceConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformNamespaceConfigList contains a list of TerraformNamespaceConfig
+type TerraformNamespaceConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformNamespaceConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformNamespaceConfig{}, &TerraformNamespaceConfigList{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/fluxcd/pkg/apis/meta"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformProviderKind     = "TerraformProvider"
+	TerraformProviderIndexKey = ".metadata.terraformProvider"
+)
+
+// TerraformProviderSpec defines provider blocks, and their credentials, shared by the
+// Terraform objects of the namespaces it selects.
+type TerraformProviderSpec struct {
+	// Providers are the provider blocks rendered into the Terraform programs.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Providers []ProviderBlock `json:"providers"`
+
+	// NamespaceSelector selects the namespaces whose Terraform objects may refer to this object,
+	// by their labels. An empty selector selects all the namespaces.
+	// +required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// SecretNamespace is the namespace of the Secrets of CredentialsSecretRef and CredentialFiles.
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+
+	// CredentialsSecretRef refers to a Secret whose keys are set as environment
+	// variables of Terraform, e.g. AWS_ACCESS_KEY_ID or ARM_CLIENT_SECRET.
+	// +optional
+	CredentialsSecretRef *meta.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialFiles are files created from Secrets for the providers reading
+	// their credentials from a file, e.g. a kubeconfig or a service account key.
+	// +optional
+	CredentialFiles []FileMapping `json:"credentialFiles,omitempty"`
+}
+
+// ProviderBlock is a provider block of a Terraform program.
+type ProviderBlock struct {
+	// Provider is the local name of the Terraform provider to configure, e.g. aws.
+	// +kubebuilder:validation:Pattern="^[a-z][a-z0-9-]*$"
+	// +required
+	Provider string `json:"provider"`
+
+	// Alias of the provider configuration, for modules using more than one
+	// configuration of the same provider.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// Settings are the arguments of the provider block, e.g. the region of the aws provider.
+	// +optional
+	Settings *apiextensionsv1.JSON `json:"settings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformProvider is the Schema for the terraformproviders API
+type TerraformProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformProviderSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformProviderList contains a list of TerraformProvider
+type TerraformProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformProvider{}, &TerraformProviderList{})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/fluxcd/pkg/apis/meta"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TerraformReceiverKind = "TerraformReceiver"
+
+	// ReceiverTokenKey is the key of the Secret referred by a TerraformReceiver holding its token.
+	ReceiverTokenKey = "token"
+)
+
+// Types of TerraformReceiver
+const (
+	GenericReceiver = "generic"
+	GitHubReceiver  = "github"
+	GitLabReceiver  = "gitlab"
+	HarborReceiver  = "harbor"
+
+	// change feed receivers reconcile the objects managing the changed cloud resources
+	AWSEventBridgeReceiver = "aws-eventbridge"
+	GCPAssetFeedReceiver   = "gcp-asset-feed"
+)
+
+// Reasons of the Ready condition of a TerraformReceiver
+const (
+	ReceiverReadyReason         = "ReceiverReady"
+	ReceiverTokenNotFoundReason = "TokenNotFound"
+)
+
+// TerraformReceiverSpec defines an endpoint for external systems to trigger the
+// reconciliation of Terraform objects, instead of waiting for their interval.
+type TerraformReceiverSpec struct {
+	// Type of the sender, which determines how requests are verified and how the event is read:
+	// `generic` relies on the secrecy of the webhook path, `github` verifies the X-Hub-Signature-256 header,
+	// `gitlab` the X-Gitlab-Token header, and `harbor` the Authorization header.
+	// The change feed types, `aws-eventbridge` verifying the Authorization header, and `gcp-asset-feed`
+	// relying on the secrecy of the path, reconcile the objects whose inventory contains a changed cloud resource.
+	// +kubebuilder:validation:Enum=generic;github;gitlab;harbor;aws-eventbridge;gcp-asset-feed
+	// +required
+	Type string `json:"type"`
+
+	// Events to react to, e.g. `push` for GitHub, `Push Hook` for GitLab, `PUSH_ARTIFACT` for Harbor,
+	// the detail-type for AWS EventBridge, or the asset type for GCP asset feeds.
+	// All events are accepted when empty.
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// SecretRef refers to a Secret holding the token under the `token` key.
+	// The token is part of the webhook path, and verifies the requests of the typed receivers.
+	// +required
+	SecretRef meta.LocalObjectReference `json:"secretRef"`
+
+	// Resources are the Terraform objects, in the namespace of the receiver, to reconcile on an accepted event.
+	// For the change feed types, they restrict the objects whose inventory is looked up, all the objects
+	// of the namespace are when empty.
+	// +optional
+	Resources []ReceiverResource `json:"resources,omitempty"`
+
+	// Suspend makes the receiver ignore all requests.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ReceiverResource selects the Terraform objects reconciled by a TerraformReceiver.
+type ReceiverResource struct {
+	// Name of the Terraform object. Use `*` to select the objects by MatchLabels.
+	// +required
+	Name string `json:"name"`
+
+	// MatchLabels restricts the objects selected by the `*` name.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// TerraformReceiverStatus defines the observed state of a TerraformReceiver.
+type TerraformReceiverStatus struct {
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// WebhookPath is the path, derived from the token, where the receiver accepts requests.
+	// +optional
+	WebhookPath string `json:"webhookPath,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description=""
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Path",type="string",JSONPath=".status.webhookPath",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// TerraformReceiver is the Schema for the terraformreceivers API
+type TerraformReceiver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TerraformReceiverSpec `json:"spec,omitempty"`
+	// +kubebuilder:default:={"observedGeneration":-1}
+	Status TerraformReceiverStatus `json:"status,omitempty"`
+}
+
+// TerraformReceiverReady records the webhook path of the receiver.
+func TerraformReceiverReady(receiver TerraformReceiver, webhookPath string) TerraformReceiver {
+	receiver.Status.WebhookPath = webhookPath
+	apimeta.SetStatusCondition(&receiver.Status.Conditions, metav1.Condition{
+		Type:    meta.ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReceiverReadyReason,
+		Message: "Receiver is accepting requests at " + webhookPath,
+	})
+	receiver.Status.ObservedGeneration = receiver.Generation
+	return receiver
+}
+
+// TerraformReceiverNotReady removes the webhook path of the receiver, so that it stops accepting requests.
+func TerraformReceiverNotReady(receiver TerraformReceiver, reason, message string) TerraformReceiver {
+	receiver.Status.WebhookPath = ""
+	apimeta.SetStatusCondition(&receiver.Status.Conditions, metav1.Condition{
+		Type:    meta.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: trimString(message, MaxConditionMessageLength),
+	})
+	receiver.Status.ObservedGeneration = receiver.Generation
+	return receiver
+}
+
+//+kubebuilder:object:root=true
+
+// TerraformReceiverList contains a list of TerraformReceiver
+type TerraformReceiverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TerraformReceiver `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TerraformReceiver{}, &TerraformReceiverList{})
+}
@@ -29,9 +29,80 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyProgress) DeepCopyInto(out *ApplyProgress) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyProgress.
+func (in *ApplyProgress) DeepCopy() *ApplyProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyRetry) DeepCopyInto(out *ApplyRetry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyRetry.
+func (in *ApplyRetry) DeepCopy() *ApplyRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureRMBackendSpec) DeepCopyInto(out *AzureRMBackendSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureRMBackendSpec.
+func (in *AzureRMBackendSpec) DeepCopy() *AzureRMBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureRMBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 	*out = *in
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteBackendSpec)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackendSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSBackendSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureRM != nil {
+		in, out := &in.AzureRM, &out.AzureRM
+		*out = new(AzureRMBackendSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -72,268 +143,1755 @@ func (in *BackendConfigsReference) DeepCopy() *BackendConfigsReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CrossNamespaceSourceReference) DeepCopyInto(out *CrossNamespaceSourceReference) {
+func (in *BranchPlannerSpec) DeepCopyInto(out *BranchPlannerSpec) {
 	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossNamespaceSourceReference.
-func (in *CrossNamespaceSourceReference) DeepCopy() *CrossNamespaceSourceReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchPlannerSpec.
+func (in *BranchPlannerSpec) DeepCopy() *BranchPlannerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CrossNamespaceSourceReference)
+	out := new(BranchPlannerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileMapping) DeepCopyInto(out *FileMapping) {
+func (in *BreakGlassStatus) DeepCopyInto(out *BreakGlassStatus) {
 	*out = *in
-	out.SecretRef = in.SecretRef
+	in.UsedAt.DeepCopyInto(&out.UsedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileMapping.
-func (in *FileMapping) DeepCopy() *FileMapping {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassStatus.
+func (in *BreakGlassStatus) DeepCopy() *BreakGlassStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FileMapping)
+	out := new(BreakGlassStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+func (in *CDKTFSpec) DeepCopyInto(out *CDKTFSpec) {
 	*out = *in
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
-func (in *HealthCheck) DeepCopy() *HealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDKTFSpec.
+func (in *CDKTFSpec) DeepCopy() *CDKTFSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheck)
+	out := new(CDKTFSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LockStatus) DeepCopyInto(out *LockStatus) {
+func (in *ChangeFreeze) DeepCopyInto(out *ChangeFreeze) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockStatus.
-func (in *LockStatus) DeepCopy() *LockStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreeze.
+func (in *ChangeFreeze) DeepCopy() *ChangeFreeze {
 	if in == nil {
 		return nil
 	}
-	out := new(LockStatus)
+	out := new(ChangeFreeze)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChangeFreeze) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+func (in *ChangeFreezeList) DeepCopyInto(out *ChangeFreezeList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChangeFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
-func (in *PlanStatus) DeepCopy() *PlanStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeList.
+func (in *ChangeFreezeList) DeepCopy() *ChangeFreezeList {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanStatus)
+	out := new(ChangeFreezeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChangeFreezeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+func (in *ChangeFreezeSpec) DeepCopyInto(out *ChangeFreezeSpec) {
 	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]FreezeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
-func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChangeFreezeSpec.
+func (in *ChangeFreezeSpec) DeepCopy() *ChangeFreezeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadInputsFromSecretSpec)
+	out := new(ChangeFreezeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+func (in *Check) DeepCopyInto(out *Check) {
 	*out = *in
-	if in.Entries != nil {
-		in, out := &in.Entries, &out.Entries
-		*out = make([]ResourceRef, len(*in))
-		copy(*out, *in)
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Webhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = new(ResourceLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PolicyCheck)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
-func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Check.
+func (in *Check) DeepCopy() *Check {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceInventory)
+	out := new(Check)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
-func (in *ResourceRef) DeepCopy() *ResourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckStatus.
+func (in *CheckStatus) DeepCopy() *CheckStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRef)
+	out := new(CheckStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+func (in *CrossNamespaceSourceReference) DeepCopyInto(out *CrossNamespaceSourceReference) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossNamespaceSourceReference.
+func (in *CrossNamespaceSourceReference) DeepCopy() *CrossNamespaceSourceReference {
+	if in == nil {
+		return nil
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(CrossNamespaceSourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependsOnReference) DeepCopyInto(out *DependsOnReference) {
+	*out = *in
+	if in.RequiredOutputs != nil {
+		in, out := &in.RequiredOutputs, &out.RequiredOutputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
-func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependsOnReference.
+func (in *DependsOnReference) DeepCopy() *DependsOnReference {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodMetadata)
+	out := new(DependsOnReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+func (in *Diagnostic) DeepCopyInto(out *Diagnostic) {
 	*out = *in
-	if in.EnvFrom != nil {
-		in, out := &in.EnvFrom, &out.EnvFrom
-		*out = make([]corev1.EnvFromSource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Range != nil {
+		in, out := &in.Range, &out.Range
+		*out = new(DiagnosticRange)
+		**out = **in
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Diagnostic.
+func (in *Diagnostic) DeepCopy() *Diagnostic {
+	if in == nil {
+		return nil
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
+	out := new(Diagnostic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticRange) DeepCopyInto(out *DiagnosticRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticRange.
+func (in *DiagnosticRange) DeepCopy() *DiagnosticRange {
+	if in == nil {
+		return nil
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(DiagnosticRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraArgs) DeepCopyInto(out *ExtraArgs) {
+	*out = *in
+	if in.Init != nil {
+		in, out := &in.Init, &out.Init
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]corev1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]corev1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Destroy != nil {
+		in, out := &in.Destroy, &out.Destroy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
-func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
+func (in *ExtraArgs) DeepCopy() *ExtraArgs {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodSpec)
+	out := new(ExtraArgs)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+func (in *FailureStatus) DeepCopyInto(out *FailureStatus) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
-func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureStatus.
+func (in *FailureStatus) DeepCopy() *FailureStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RunnerPodTemplate)
+	out := new(FailureStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+func (in *FileMapping) DeepCopyInto(out *FileMapping) {
 	*out = *in
+	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
-func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileMapping.
+func (in *FileMapping) DeepCopy() *FileMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(TFStateSpec)
+	out := new(FileMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeWindow) DeepCopyInto(out *FreezeWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeWindow.
+func (in *FreezeWindow) DeepCopy() *FreezeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSBackendSpec) DeepCopyInto(out *GCSBackendSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSBackendSpec.
+func (in *GCSBackendSpec) DeepCopy() *GCSBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Import) DeepCopyInto(out *Import) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Import.
+func (in *Import) DeepCopy() *Import {
+	if in == nil {
+		return nil
+	}
+	out := new(Import)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
+	*out = *in
+	if in.ImportedAt != nil {
+		in, out := &in.ImportedAt, &out.ImportedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportStatus.
+func (in *ImportStatus) DeepCopy() *ImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryDiff) DeepCopyInto(out *InventoryDiff) {
+	*out = *in
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]ResourceRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]ResourceRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryDiff.
+func (in *InventoryDiff) DeepCopy() *InventoryDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LockStatus) DeepCopyInto(out *LockStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockStatus.
+func (in *LockStatus) DeepCopy() *LockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
+	*out = *in
+	if in.RedactPatterns != nil {
+		in, out := &in.RedactPatterns, &out.RedactPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingSpec.
+func (in *LoggingSpec) DeepCopy() *LoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleInterface) DeepCopyInto(out *ModuleInterface) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]ModuleVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]ModuleOutput, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleInterface.
+func (in *ModuleInterface) DeepCopy() *ModuleInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleOutput) DeepCopyInto(out *ModuleOutput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleOutput.
+func (in *ModuleOutput) DeepCopy() *ModuleOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModuleVariable) DeepCopyInto(out *ModuleVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModuleVariable.
+func (in *ModuleVariable) DeepCopy() *ModuleVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(ModuleVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCISnapshotStorage) DeepCopyInto(out *OCISnapshotStorage) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCISnapshotStorage.
+func (in *OCISnapshotStorage) DeepCopy() *OCISnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(OCISnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectDrift) DeepCopyInto(out *ObjectDrift) {
+	*out = *in
+	in.FirstDetectedAt.DeepCopyInto(&out.FirstDetectedAt)
+	in.LastDetectedAt.DeepCopyInto(&out.LastDetectedAt)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDrift.
+func (in *ObjectDrift) DeepCopy() *ObjectDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
+func (in *ObjectRef) DeepCopy() *ObjectRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputFieldMapping) DeepCopyInto(out *OutputFieldMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputFieldMapping.
+func (in *OutputFieldMapping) DeepCopy() *OutputFieldMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputFieldMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputMapping) DeepCopyInto(out *OutputMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputMapping.
+func (in *OutputMapping) DeepCopy() *OutputMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputsSpec) DeepCopyInto(out *OutputsSpec) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputsSpec.
+func (in *OutputsSpec) DeepCopy() *OutputsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanDiff) DeepCopyInto(out *PlanDiff) {
+	*out = *in
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanDiff.
+func (in *PlanDiff) DeepCopy() *PlanDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(PlanDiff)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
+func (in *PlanStatus) DeepCopy() *PlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyCheck) DeepCopyInto(out *PolicyCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyCheck.
+func (in *PolicyCheck) DeepCopy() *PolicyCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderBlock) DeepCopyInto(out *ProviderBlock) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderBlock.
+func (in *ProviderBlock) DeepCopy() *ProviderBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
+func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigList.
+func (in *ProviderConfigList) DeepCopy() *ProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialFiles != nil {
+		in, out := &in.CredentialFiles, &out.CredentialFiles
+		*out = make([]FileMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
+func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderVersion) DeepCopyInto(out *ProviderVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderVersion.
+func (in *ProviderVersion) DeepCopy() *ProviderVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestCommentsSpec) DeepCopyInto(out *PullRequestCommentsSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestCommentsSpec.
+func (in *PullRequestCommentsSpec) DeepCopy() *PullRequestCommentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestCommentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushOutputsSpec) DeepCopyInto(out *PushOutputsSpec) {
+	*out = *in
+	if in.SecretStoreRefs != nil {
+		in, out := &in.SecretStoreRefs, &out.SecretStoreRefs
+		*out = make([]SecretStoreRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushOutputsSpec.
+func (in *PushOutputsSpec) DeepCopy() *PushOutputsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PushOutputsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadInputsFromSecretSpec) DeepCopyInto(out *ReadInputsFromSecretSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadInputsFromSecretSpec.
+func (in *ReadInputsFromSecretSpec) DeepCopy() *ReadInputsFromSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadInputsFromSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadOutputsFromTFSpec) DeepCopyInto(out *ReadOutputsFromTFSpec) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadOutputsFromTFSpec.
+func (in *ReadOutputsFromTFSpec) DeepCopy() *ReadOutputsFromTFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadOutputsFromTFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReceiverResource) DeepCopyInto(out *ReceiverResource) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReceiverResource.
+func (in *ReceiverResource) DeepCopy() *ReceiverResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ReceiverResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteBackendSpec) DeepCopyInto(out *RemoteBackendSpec) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteBackendSpec.
+func (in *RemoteBackendSpec) DeepCopy() *RemoteBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteRunStatus) DeepCopyInto(out *RemoteRunStatus) {
+	*out = *in
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteRunStatus.
+func (in *RemoteRunStatus) DeepCopy() *RemoteRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceInventory) DeepCopyInto(out *ResourceInventory) {
+	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]ResourceRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInventory.
+func (in *ResourceInventory) DeepCopy() *ResourceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceLimits) DeepCopyInto(out *ResourceLimits) {
+	*out = *in
+	if in.MaxManagedResources != nil {
+		in, out := &in.MaxManagedResources, &out.MaxManagedResources
+		*out = new(int)
+		**out = **in
+	}
+	if in.ForbiddenResourceTypes != nil {
+		in, out := &in.ForbiddenResourceTypes, &out.ForbiddenResourceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceLimits.
+func (in *ResourceLimits) DeepCopy() *ResourceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Revision) DeepCopyInto(out *Revision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Revision.
+func (in *Revision) DeepCopy() *Revision {
+	if in == nil {
+		return nil
+	}
+	out := new(Revision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionStatus.
+func (in *RevisionStatus) DeepCopy() *RevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionsStatus) DeepCopyInto(out *RevisionsStatus) {
+	*out = *in
+	if in.LastApplied != nil {
+		in, out := &in.LastApplied, &out.LastApplied
+		*out = new(RevisionStatus)
+		**out = **in
+	}
+	if in.LastAttempted != nil {
+		in, out := &in.LastAttempted, &out.LastAttempted
+		*out = new(RevisionStatus)
+		**out = **in
+	}
+	if in.LastPlanned != nil {
+		in, out := &in.LastPlanned, &out.LastPlanned
+		*out = new(RevisionStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionsStatus.
+func (in *RevisionsStatus) DeepCopy() *RevisionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunStatus) DeepCopyInto(out *RunStatus) {
+	*out = *in
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = make([]Diagnostic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ApplyProgress)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatus.
+func (in *RunStatus) DeepCopy() *RunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodMetadata) DeepCopyInto(out *RunnerPodMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodMetadata.
+func (in *RunnerPodMetadata) DeepCopy() *RunnerPodMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodSpec) DeepCopyInto(out *RunnerPodSpec) {
+	*out = *in
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodSpec.
+func (in *RunnerPodSpec) DeepCopy() *RunnerPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPodTemplate) DeepCopyInto(out *RunnerPodTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPodTemplate.
+func (in *RunnerPodTemplate) DeepCopy() *RunnerPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerRoleBinding) DeepCopyInto(out *RunnerRoleBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerRoleBinding.
+func (in *RunnerRoleBinding) DeepCopy() *RunnerRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerScopedToken) DeepCopyInto(out *RunnerScopedToken) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerScopedToken.
+func (in *RunnerScopedToken) DeepCopy() *RunnerScopedToken {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerScopedToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerSpec) DeepCopyInto(out *RunnerSpec) {
+	*out = *in
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]RunnerRoleBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScopedToken != nil {
+		in, out := &in.ScopedToken, &out.ScopedToken
+		*out = new(RunnerScopedToken)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeepFailedPodsFor != nil {
+		in, out := &in.KeepFailedPodsFor, &out.KeepFailedPodsFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerSpec.
+func (in *RunnerSpec) DeepCopy() *RunnerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackendSpec) DeepCopyInto(out *S3BackendSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackendSpec.
+func (in *S3BackendSpec) DeepCopy() *S3BackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SnapshotStorage) DeepCopyInto(out *S3SnapshotStorage) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SnapshotStorage.
+func (in *S3SnapshotStorage) DeepCopy() *S3SnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreRef) DeepCopyInto(out *SecretStoreRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreRef.
+func (in *SecretStoreRef) DeepCopy() *SecretStoreRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotStorage) DeepCopyInto(out *SnapshotStorage) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3SnapshotStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCISnapshotStorage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotStorage.
+func (in *SnapshotStorage) DeepCopy() *SnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceNotFoundSpec) DeepCopyInto(out *SourceNotFoundSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceNotFoundSpec.
+func (in *SourceNotFoundSpec) DeepCopy() *SourceNotFoundSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceNotFoundSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateBackup) DeepCopyInto(out *StateBackup) {
+	*out = *in
+	in.SnapshotStorage.DeepCopyInto(&out.SnapshotStorage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateBackup.
+func (in *StateBackup) DeepCopy() *StateBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(StateBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateSnapshot) DeepCopyInto(out *StateSnapshot) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateSnapshot.
+func (in *StateSnapshot) DeepCopy() *StateSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(StateSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TFStateSpec) DeepCopyInto(out *TFStateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFStateSpec.
+func (in *TFStateSpec) DeepCopy() *TFStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TFStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Terraform) DeepCopyInto(out *Terraform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
+func (in *Terraform) DeepCopy() *Terraform {
+	if in == nil {
+		return nil
+	}
+	out := new(Terraform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Terraform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformDriftReport) DeepCopyInto(out *TerraformDriftReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformDriftReport.
+func (in *TerraformDriftReport) DeepCopy() *TerraformDriftReport {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformDriftReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformDriftReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformDriftReportList) DeepCopyInto(out *TerraformDriftReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformDriftReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformDriftReportList.
+func (in *TerraformDriftReportList) DeepCopy() *TerraformDriftReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformDriftReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformDriftReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformDriftReportStatus) DeepCopyInto(out *TerraformDriftReportStatus) {
+	*out = *in
+	if in.GeneratedAt != nil {
+		in, out := &in.GeneratedAt, &out.GeneratedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Drifts != nil {
+		in, out := &in.Drifts, &out.Drifts
+		*out = make([]ObjectDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformDriftReportStatus.
+func (in *TerraformDriftReportStatus) DeepCopy() *TerraformDriftReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformDriftReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformList) DeepCopyInto(out *TerraformList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Terraform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
+func (in *TerraformList) DeepCopy() *TerraformList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformNamespaceConfig) DeepCopyInto(out *TerraformNamespaceConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespaceConfig.
+func (in *TerraformNamespaceConfig) DeepCopy() *TerraformNamespaceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformNamespaceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformNamespaceConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformNamespaceConfigList) DeepCopyInto(out *TerraformNamespaceConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformNamespaceConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespaceConfigList.
+func (in *TerraformNamespaceConfigList) DeepCopy() *TerraformNamespaceConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformNamespaceConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformNamespaceConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformNamespaceConfigSpec) DeepCopyInto(out *TerraformNamespaceConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformNamespaceConfigSpec.
+func (in *TerraformNamespaceConfigSpec) DeepCopy() *TerraformNamespaceConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformNamespaceConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformProvider) DeepCopyInto(out *TerraformProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformProvider.
+func (in *TerraformProvider) DeepCopy() *TerraformProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformProviderList) DeepCopyInto(out *TerraformProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TerraformProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformProviderList.
+func (in *TerraformProviderList) DeepCopy() *TerraformProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TerraformProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformProviderSpec) DeepCopyInto(out *TerraformProviderSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialFiles != nil {
+		in, out := &in.CredentialFiles, &out.CredentialFiles
+		*out = make([]FileMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformProviderSpec.
+func (in *TerraformProviderSpec) DeepCopy() *TerraformProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Terraform) DeepCopyInto(out *Terraform) {
+func (in *TerraformReceiver) DeepCopyInto(out *TerraformReceiver) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -341,18 +1899,18 @@ func (in *Terraform) DeepCopyInto(out *Terraform) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Terraform.
-func (in *Terraform) DeepCopy() *Terraform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformReceiver.
+func (in *TerraformReceiver) DeepCopy() *TerraformReceiver {
 	if in == nil {
 		return nil
 	}
-	out := new(Terraform)
+	out := new(TerraformReceiver)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Terraform) DeepCopyObject() runtime.Object {
+func (in *TerraformReceiver) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -360,37 +1918,87 @@ func (in *Terraform) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TerraformList) DeepCopyInto(out *TerraformList) {
+func (in *TerraformReceiverList) DeepCopyInto(out *TerraformReceiverList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Terraform, len(*in))
+		*out = make([]TerraformReceiver, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformList.
-func (in *TerraformList) DeepCopy() *TerraformList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformReceiverList.
+func (in *TerraformReceiverList) DeepCopy() *TerraformReceiverList {
 	if in == nil {
 		return nil
 	}
-	out := new(TerraformList)
+	out := new(TerraformReceiverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TerraformList) DeepCopyObject() runtime.Object {
+func (in *TerraformReceiverList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformReceiverSpec) DeepCopyInto(out *TerraformReceiverSpec) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.SecretRef = in.SecretRef
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ReceiverResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformReceiverSpec.
+func (in *TerraformReceiverSpec) DeepCopy() *TerraformReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformReceiverStatus) DeepCopyInto(out *TerraformReceiverStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformReceiverStatus.
+func (in *TerraformReceiverStatus) DeepCopy() *TerraformReceiverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformReceiverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	*out = *in
@@ -431,22 +2039,69 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		copy(*out, *in)
 	}
 	out.Interval = in.Interval
+	if in.BranchPlanner != nil {
+		in, out := &in.BranchPlanner, &out.BranchPlanner
+		*out = new(BranchPlannerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequestComments != nil {
+		in, out := &in.PullRequestComments, &out.PullRequestComments
+		*out = new(PullRequestCommentsSpec)
+		**out = **in
+	}
 	if in.RetryInterval != nil {
 		in, out := &in.RetryInterval, &out.RetryInterval
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.MaxConsecutiveFailures != nil {
+		in, out := &in.MaxConsecutiveFailures, &out.MaxConsecutiveFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SourceNotFound != nil {
+		in, out := &in.SourceNotFound, &out.SourceNotFound
+		*out = new(SourceNotFoundSpec)
+		**out = **in
+	}
+	if in.CDKTF != nil {
+		in, out := &in.CDKTF, &out.CDKTF
+		*out = new(CDKTFSpec)
+		**out = **in
+	}
 	out.SourceRef = in.SourceRef
 	if in.ReadInputsFromSecrets != nil {
 		in, out := &in.ReadInputsFromSecrets, &out.ReadInputsFromSecrets
 		*out = make([]ReadInputsFromSecretSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReadOutputsFromTF != nil {
+		in, out := &in.ReadOutputsFromTF, &out.ReadOutputsFromTF
+		*out = make([]ReadOutputsFromTFSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WriteOutputsToSecret != nil {
 		in, out := &in.WriteOutputsToSecret, &out.WriteOutputsToSecret
 		*out = new(WriteOutputsToSecretSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WriteOutputsToConfigMap != nil {
+		in, out := &in.WriteOutputsToConfigMap, &out.WriteOutputsToConfigMap
+		*out = new(WriteOutputsToConfigMapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteOutputsTo != nil {
+		in, out := &in.WriteOutputsTo, &out.WriteOutputsTo
+		*out = new(WriteOutputsToObjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = new(OutputsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CliConfigSecretRef != nil {
 		in, out := &in.CliConfigSecretRef, &out.CliConfigSecretRef
 		*out = new(corev1.SecretReference)
@@ -459,6 +2114,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Runner != nil {
+		in, out := &in.Runner, &out.Runner
+		*out = new(RunnerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AlwaysCleanupRunnerPod != nil {
 		in, out := &in.AlwaysCleanupRunnerPod, &out.AlwaysCleanupRunnerPod
 		*out = new(bool)
@@ -469,6 +2129,36 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.WorkingDirStorage != nil {
+		in, out := &in.WorkingDirStorage, &out.WorkingDirStorage
+		*out = new(WorkingDirStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = new(ExtraArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]Import, len(*in))
+		copy(*out, *in)
+	}
+	if in.StateBackup != nil {
+		in, out := &in.StateBackup, &out.StateBackup
+		*out = new(StateBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkingDirSnapshot != nil {
+		in, out := &in.WorkingDirSnapshot, &out.WorkingDirSnapshot
+		*out = new(WorkingDirSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyRetry != nil {
+		in, out := &in.ApplyRetry, &out.ApplyRetry
+		*out = new(ApplyRetry)
+		**out = **in
+	}
 	in.RunnerPodTemplate.DeepCopyInto(&out.RunnerPodTemplate)
 	if in.TFState != nil {
 		in, out := &in.TFState, &out.TFState
@@ -477,7 +2167,7 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	}
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
-		*out = make([]string, len(*in))
+		*out = make([]ResourceAddress, len(*in))
 		copy(*out, *in)
 	}
 	if in.Webhooks != nil {
@@ -489,9 +2179,43 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	}
 	if in.DependsOn != nil {
 		in, out := &in.DependsOn, &out.DependsOn
-		*out = make([]meta.NamespacedObjectReference, len(*in))
+		*out = make([]DependsOnReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = new(ResourceLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyChecks != nil {
+		in, out := &in.PolicyChecks, &out.PolicyChecks
+		*out = make([]PolicyCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]Check, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProviderConfigRefs != nil {
+		in, out := &in.ProviderConfigRefs, &out.ProviderConfigRefs
+		*out = make([]meta.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProviderRefs != nil {
+		in, out := &in.ProviderRefs, &out.ProviderRefs
+		*out = make([]meta.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LoggingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformSpec.
@@ -515,26 +2239,115 @@ func (in *TerraformStatus) DeepCopyInto(out *TerraformStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = new(RevisionsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LastDriftDetectedAt != nil {
 		in, out := &in.LastDriftDetectedAt, &out.LastDriftDetectedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Imports != nil {
+		in, out := &in.Imports, &out.Imports
+		*out = make([]ImportStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]CheckStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StateSnapshots != nil {
+		in, out := &in.StateSnapshots, &out.StateSnapshots
+		*out = make([]StateSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkingDirSnapshots != nil {
+		in, out := &in.WorkingDirSnapshots, &out.WorkingDirSnapshots
+		*out = make([]WorkingDirSnapshotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.LastAppliedByDriftDetectionAt != nil {
 		in, out := &in.LastAppliedByDriftDetectionAt, &out.LastAppliedByDriftDetectionAt
 		*out = (*in).DeepCopy()
 	}
+	if in.LastApplyAt != nil {
+		in, out := &in.LastApplyAt, &out.LastApplyAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastOutputsRefreshAt != nil {
+		in, out := &in.LastOutputsRefreshAt, &out.LastOutputsRefreshAt
+		*out = (*in).DeepCopy()
+	}
 	if in.AvailableOutputs != nil {
 		in, out := &in.AvailableOutputs, &out.AvailableOutputs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.Plan = in.Plan
+	in.Plan.DeepCopyInto(&out.Plan)
+	out.Failures = in.Failures
+	if in.LastRun != nil {
+		in, out := &in.LastRun, &out.LastRun
+		*out = new(RunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteRun != nil {
+		in, out := &in.RemoteRun, &out.RemoteRun
+		*out = new(RemoteRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BreakGlass != nil {
+		in, out := &in.BreakGlass, &out.BreakGlass
+		*out = new(BreakGlassStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsedBreakGlassTokens != nil {
+		in, out := &in.UsedBreakGlassTokens, &out.UsedBreakGlassTokens
+		*out = make([]UsedBreakGlassToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Inventory != nil {
 		in, out := &in.Inventory, &out.Inventory
 		*out = new(ResourceInventory)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InventoryDiff != nil {
+		in, out := &in.InventoryDiff, &out.InventoryDiff
+		*out = new(InventoryDiff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModuleInterface != nil {
+		in, out := &in.ModuleInterface, &out.ModuleInterface
+		*out = new(ModuleInterface)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Lock = in.Lock
+	if in.WorkspaceLineages != nil {
+		in, out := &in.WorkspaceLineages, &out.WorkspaceLineages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderVersion, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformStatus.
@@ -547,6 +2360,22 @@ func (in *TerraformStatus) DeepCopy() *TerraformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsedBreakGlassToken) DeepCopyInto(out *UsedBreakGlassToken) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsedBreakGlassToken.
+func (in *UsedBreakGlassToken) DeepCopy() *UsedBreakGlassToken {
+	if in == nil {
+		return nil
+	}
+	out := new(UsedBreakGlassToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Variable) DeepCopyInto(out *Variable) {
 	*out = *in
@@ -612,6 +2441,139 @@ func (in *Webhook) DeepCopy() *Webhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkingDirPVC) DeepCopyInto(out *WorkingDirPVC) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkingDirPVC.
+func (in *WorkingDirPVC) DeepCopy() *WorkingDirPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkingDirPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkingDirSnapshot) DeepCopyInto(out *WorkingDirSnapshot) {
+	*out = *in
+	in.SnapshotStorage.DeepCopyInto(&out.SnapshotStorage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkingDirSnapshot.
+func (in *WorkingDirSnapshot) DeepCopy() *WorkingDirSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkingDirSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkingDirSnapshotStatus) DeepCopyInto(out *WorkingDirSnapshotStatus) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkingDirSnapshotStatus.
+func (in *WorkingDirSnapshotStatus) DeepCopy() *WorkingDirSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkingDirSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkingDirStorage) DeepCopyInto(out *WorkingDirStorage) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(WorkingDirPVC)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkingDirStorage.
+func (in *WorkingDirStorage) DeepCopy() *WorkingDirStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkingDirStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentitySpec) DeepCopyInto(out *WorkloadIdentitySpec) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentitySpec.
+func (in *WorkloadIdentitySpec) DeepCopy() *WorkloadIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WriteOutputsToConfigMapSpec) DeepCopyInto(out *WriteOutputsToConfigMapSpec) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WriteOutputsToConfigMapSpec.
+func (in *WriteOutputsToConfigMapSpec) DeepCopy() *WriteOutputsToConfigMapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WriteOutputsToConfigMapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WriteOutputsToObjectSpec) DeepCopyInto(out *WriteOutputsToObjectSpec) {
+	*out = *in
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]OutputFieldMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WriteOutputsToObjectSpec.
+func (in *WriteOutputsToObjectSpec) DeepCopy() *WriteOutputsToObjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WriteOutputsToObjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WriteOutputsToSecretSpec) DeepCopyInto(out *WriteOutputsToSecretSpec) {
 	*out = *in
@@ -620,6 +2582,26 @@ func (in *WriteOutputsToSecretSpec) DeepCopyInto(out *WriteOutputsToSecretSpec)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OutputMappings != nil {
+		in, out := &in.OutputMappings, &out.OutputMappings
+		*out = make([]OutputMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchemaFrom != nil {
+		in, out := &in.SchemaFrom, &out.SchemaFrom
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PushTo != nil {
+		in, out := &in.PushTo, &out.PushTo
+		*out = new(PushOutputsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WriteOutputsToSecretSpec.
@@ -0,0 +1,138 @@
+// kubectl-tf is the kubectl plugin of tf-controller, run as kubectl tf once installed in the PATH.
+// It uses the namespace of the current context of the kubeconfig, as kubectl does.
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/weaveworks/tf-controller/tfctl"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+var (
+	// BuildSHA is the kubectl-tf version
+	BuildSHA string
+
+	// BuildVersion is the kubectl-tf build version
+	BuildVersion string
+)
+
+var kubeconfigArgs = genericclioptions.NewConfigFlags(false)
+
+func main() {
+	cmd := newRootCommand()
+	cobra.CheckErr(cmd.Execute())
+}
+
+func newRootCommand() *cobra.Command {
+	app := tfctl.New(BuildSHA, BuildVersion)
+
+	config := viper.New()
+
+	rootCmd := &cobra.Command{
+		Use:           "kubectl tf",
+		Short:         "Inspect and drive the Terraform resources of tf-controller",
+		SilenceErrors: false,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			k8sConfig, err := kubeconfigArgs.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			namespace, _, err := kubeconfigArgs.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			config.Set("namespace", namespace)
+			return app.Init(k8sConfig, config)
+		},
+	}
+
+	// flags
+	rootCmd.PersistentFlags().String("terraform", "terraform", "The location of the terraform binary, which renders the plans not stored in a readable format.")
+	kubeconfigArgs.AddFlags(rootCmd.PersistentFlags())
+
+	// bind flags to config
+	config.BindPFlag("terraform", rootCmd.PersistentFlags().Lookup("terraform"))
+
+	rootCmd.AddCommand(buildPlanCmd(app))
+	rootCmd.AddCommand(buildLogsCmd(app))
+	rootCmd.AddCommand(buildReconcileCmd(app))
+
+	return rootCmd
+}
+
+var planExamples = `
+  # Show the pending plan of a Terraform resource as a colored diff
+  kubectl tf plan my-resource -n default
+
+  # Show the pending plan without colors, e.g. to save it to a file
+  kubectl tf plan my-resource --no-color > plan.diff
+`
+
+func buildPlanCmd(app *tfctl.CLI) *cobra.Command {
+	plan := &cobra.Command{
+		Use:     "plan NAME",
+		Short:   "Show pending Terraform plan as a diff",
+		Example: strings.Trim(planExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noColor, _ := cmd.Flags().GetBool("no-color")
+			return app.ShowPlanDiff(os.Stdout, args[0], noColor)
+		},
+	}
+	plan.Flags().Bool("no-color", false, "Print the diff without colors")
+	return plan
+}
+
+var logsExamples = `
+  # Print the logs of the runner of a Terraform resource
+  kubectl tf logs my-resource
+
+  # Follow the logs of the runner while it plans or applies
+  kubectl tf logs my-resource -f
+`
+
+func buildLogsCmd(app *tfctl.CLI) *cobra.Command {
+	logs := &cobra.Command{
+		Use:     "logs NAME",
+		Short:   "Print the logs of the runner pod of a Terraform resource",
+		Example: strings.Trim(logsExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			follow, _ := cmd.Flags().GetBool("follow")
+			return app.RunnerLogs(os.Stdout, args[0], follow)
+		},
+	}
+	logs.Flags().BoolP("follow", "f", false, "Follow the logs")
+	return logs
+}
+
+var reconcileExamples = `
+  # Reconcile a Terraform resource now, without waiting for its interval
+  kubectl tf reconcile my-resource
+
+  # Reconcile a Terraform resource, and fail if it is not ready after the reconciliation
+  kubectl tf reconcile my-resource --wait --timeout=10m
+`
+
+func buildReconcileCmd(app *tfctl.CLI) *cobra.Command {
+	reconcile := &cobra.Command{
+		Use:     "reconcile NAME",
+		Short:   "Trigger a reconcile of the provided resource",
+		Example: strings.Trim(reconcileExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			return app.Reconcile(os.Stdout, args[0], wait, timeout)
+		},
+	}
+	reconcile.Flags().Bool("wait", false, "Wait for the reconciliation to complete, and fail if the resource is not ready")
+	reconcile.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the reconciliation with --wait")
+	return reconcile
+}
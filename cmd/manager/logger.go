@@ -0,0 +1,51 @@
+package main
+
+import (
+	"github.com/fluxcd/pkg/runtime/logger"
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// logLevels mirrors the levels accepted by --log-level.
+var logLevels = map[string]zapcore.Level{
+	"trace": zapcore.DebugLevel - 1,
+	"debug": zapcore.DebugLevel,
+	"info":  zapcore.InfoLevel,
+	"error": zapcore.ErrorLevel,
+}
+
+// newLogger builds the same logger as logger.NewLogger, except that its level
+// can be changed at runtime through the returned zap.AtomicLevel.
+func newLogger(opts logger.Options) (logr.Logger, zap.AtomicLevel) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	if l, ok := logLevels[opts.LogLevel]; ok {
+		level.SetLevel(l)
+	}
+
+	zapOpts := crzap.Options{
+		EncoderConfigOptions: []crzap.EncoderConfigOption{
+			func(config *zapcore.EncoderConfig) {
+				config.EncodeTime = zapcore.ISO8601TimeEncoder
+			},
+		},
+		Level: level,
+	}
+
+	switch opts.LogEncoding {
+	case "console":
+		crzap.ConsoleEncoder(zapOpts.EncoderConfigOptions...)(&zapOpts)
+	case "json":
+		crzap.JSONEncoder(zapOpts.EncoderConfigOptions...)(&zapOpts)
+	}
+
+	switch opts.LogLevel {
+	case "trace", "debug":
+		zapOpts.StacktraceLevel = zapcore.ErrorLevel
+	default:
+		zapOpts.StacktraceLevel = zapcore.PanicLevel
+	}
+
+	return crzap.New(crzap.UseFlagOptions(&zapOpts)), level
+}
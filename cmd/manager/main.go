@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"time"
 
@@ -79,12 +81,39 @@ func main() {
 		runnerGRPCPort           int
 		runnerCreationTimeout    time.Duration
 		runnerGRPCMaxMessageSize int
+		runnerHeartbeatInterval  time.Duration
+		orphanedStateInterval    time.Duration
+		dependencySweepInterval  time.Duration
+		driftReportInterval      time.Duration
+		complianceBucket         string
+		compliancePrefix         string
+		complianceEndpoint       string
+		complianceKeyFile        string
+		complianceInterval       time.Duration
+		allowCrossNsOutputs      bool
+		allowedRunnerRoles       []string
+		allowedOutputsNamespaces []string
+		allowedOutputsKinds      []string
+		breakGlassKeyFile        string
+		providerSchemaCacheSize  int
+		approvalWorkers          int
+		enableBranchPlanner      bool
+		configFile               string
+		approverAPIAddr          string
+		approverAPICertFile      string
+		approverAPIKeyFile       string
+		receiverAddr             string
+		artifactFetchMode        string
+		enableAdmissionWebhook   bool
+		webhookCertDir           string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&eventsAddr, "events-addr", "", "The address of the events receiver.")
 	flag.StringVar(&healthAddr, "health-addr", ":9440", "The address the health endpoint binds to.")
 	flag.IntVar(&concurrent, "concurrent", 4, "The number of concurrent terraform reconciles.")
+	flag.IntVar(&approvalWorkers, "approval-workers", 0,
+		"Enables the approval fast path: the number of workers applying the approved plans ahead of the other reconciles. Disabled when 0.")
 	flag.DurationVar(&requeueDependency, "requeue-dependency", 30*time.Second, "The interval at which failing dependencies are reevaluated.")
 	flag.BoolVar(&watchAllNamespaces, "watch-all-namespaces", true,
 		"Watch for custom resources in all namespaces, if set to false it will only watch the runtime namespace.")
@@ -98,13 +127,66 @@ func main() {
 	flag.IntVar(&runnerGRPCPort, "runner-grpc-port", 30000, "The port which will be exposed on the runner pod for gRPC connections.")
 	flag.DurationVar(&runnerCreationTimeout, "runner-creation-timeout", 120*time.Second, "Timeout for creating a runner pod.")
 	flag.IntVar(&runnerGRPCMaxMessageSize, "runner-grpc-max-message-size", 4, "The maximum message size for gRPC connections in MiB.")
+	flag.DurationVar(&runnerHeartbeatInterval, "runner-heartbeat-interval", 30*time.Second,
+		"The interval at which the runner pods are checked to be alive while applying. Set to 0 to disable.")
+	flag.DurationVar(&orphanedStateInterval, "orphaned-state-check-interval", time.Hour,
+		"The interval at which state Secrets of the kubernetes backend are checked for not being claimed by any Terraform object. Set to 0 to disable.")
+	flag.DurationVar(&dependencySweepInterval, "dependency-finalizer-sweep-interval", 10*time.Minute,
+		"The interval at which the dependency finalizers naming dependants which no longer exist are removed. Set to 0 to disable.")
+	flag.DurationVar(&driftReportInterval, "drift-report-interval", 5*time.Minute,
+		"The interval at which the TerraformDriftReport summarizing the Terraform objects with a drift is generated. Set to 0 to disable.")
+	flag.StringVar(&complianceBucket, "compliance-export-bucket", "",
+		"The S3 bucket the signed compliance snapshots of the Terraform objects are exported to. Disabled when empty.")
+	flag.StringVar(&compliancePrefix, "compliance-export-prefix", "tf-controller",
+		"The prefix of the keys of the compliance snapshots in the bucket.")
+	flag.StringVar(&complianceEndpoint, "compliance-export-endpoint", "",
+		"The endpoint of a storage compatible with S3, replacing Amazon S3 for the compliance export.")
+	flag.StringVar(&complianceKeyFile, "compliance-export-signing-key-file", "",
+		"The file of the PEM encoded ed25519 private key signing the compliance snapshots.")
+	flag.DurationVar(&complianceInterval, "compliance-export-interval", 24*time.Hour,
+		"The interval at which the compliance snapshots are exported.")
+	flag.BoolVar(&allowCrossNsOutputs, "allow-cross-namespace-outputs", false,
+		"Allow the Terraform objects to write their outputs Secret to other namespaces, with writeOutputsToSecret.namespace.")
+	flag.StringSliceVar(&allowedOutputsNamespaces, "allowed-outputs-namespaces", nil,
+		"The namespaces the outputs Secrets may be written to with --allow-cross-namespace-outputs, besides the namespace of their object.")
+	flag.StringSliceVar(&allowedOutputsKinds, "allowed-outputs-kinds", []string{"ConfigMap"},
+		"The kinds of the objects the outputs may be written to with writeOutputsTo, as Kind.group, e.g. DNSEndpoint.externaldns.k8s.io, or Kind for the core group.")
+	flag.StringSliceVar(&allowedRunnerRoles, "allowed-runner-roles", []string{"tf-runner-role"},
+		"The names of the Roles and ClusterRoles the ServiceAccounts created with runner.createServiceAccount may be bound to. "+
+			"The controller must be allowed to bind them.")
+	flag.StringVar(&breakGlassKeyFile, "break-glass-key-file", "",
+		"The file of the key signing the break-glass tokens minted by the approver API. Break-glass tokens are rejected without it.")
+	flag.IntVar(&providerSchemaCacheSize, "provider-schema-cache-size", 0,
+		"The number of provider versions whose schemas are cached to describe the resource types of the plans. Set to 0 to disable.")
+	flag.BoolVar(&enableBranchPlanner, "enable-branch-planner", false,
+		"Preview the plans of the pull requests of the GitRepository of the Terraform objects with a branchPlanner.")
+	flag.StringVar(&configFile, "config-file", "",
+		"The path of the controller config file, reloaded when it changes.")
+
+	flag.StringVar(&approverAPIAddr, "approver-api-addr", "",
+		"The address the REST API to review and approve plans binds to. Disabled when empty.")
+	flag.StringVar(&approverAPICertFile, "approver-api-cert-file", "",
+		"The TLS certificate of the approver API. The API is served over plain HTTP without it.")
+	flag.StringVar(&approverAPIKeyFile, "approver-api-key-file", "", "The TLS key of the approver API.")
+
+	flag.StringVar(&receiverAddr, "receiver-addr", "",
+		"The address the webhook receiver of the TerraformReceiver objects binds to. Disabled when empty.")
+
+	flag.StringVar(&artifactFetchMode, "artifact-fetch-mode", controllers.ArtifactFetchModeController,
+		"How the runners get the source artifacts: 'controller' streams them through the controller, 'runner' has the runners download them from the source-controller.")
+
+	flag.BoolVar(&enableAdmissionWebhook, "enable-admission-webhook", false,
+		"Enable the validating admission webhook of the Terraform objects, served on port 9443.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "",
+		"The directory of the tls.crt and tls.key of the admission webhook server. Defaults to /tmp/k8s-webhook-server/serving-certs.")
 
 	clientOptions.BindFlags(flag.CommandLine)
 	logOptions.BindFlags(flag.CommandLine)
 	leaderElectionOptions.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(logger.NewLogger(logOptions))
+	log, logLevel := newLogger(logOptions)
+	ctrl.SetLogger(log)
 	// ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	metricsRecorder := metrics.NewRecorder()
@@ -123,6 +205,7 @@ func main() {
 		MetricsBindAddress:            metricsAddr,
 		HealthProbeBindAddress:        healthAddr,
 		Port:                          9443,
+		CertDir:                       webhookCertDir,
 		LeaderElection:                leaderElectionOptions.Enable,
 		LeaderElectionReleaseOnCancel: leaderElectionOptions.ReleaseOnCancel,
 		LeaseDuration:                 &leaderElectionOptions.LeaseDuration,
@@ -172,6 +255,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	var controllerConfig *controllers.ControllerConfigWatcher
+	if configFile != "" {
+		if controllerConfig, err = controllers.NewControllerConfigWatcher(configFile, 10*time.Second); err != nil {
+			setupLog.Error(err, "unable to load controller config")
+			os.Exit(1)
+		}
+		// the log level from the config file takes precedence over --log-level
+		setLogLevel := func(config controllers.ControllerConfig) {
+			level := logOptions.LogLevel
+			if config.LogLevel != "" {
+				level = config.LogLevel
+			}
+			if l, ok := logLevels[level]; ok && l != logLevel.Level() {
+				setupLog.Info("setting log level", "level", level)
+				logLevel.SetLevel(l)
+			}
+		}
+		setLogLevel(controllerConfig.Get())
+		controllerConfig.OnChange = setLogLevel
+
+		if err := mgr.Add(controllerConfig); err != nil {
+			setupLog.Error(err, "unable to set up controller config reload")
+			os.Exit(1)
+		}
+	}
+
+	if artifactFetchMode != controllers.ArtifactFetchModeController && artifactFetchMode != controllers.ArtifactFetchModeRunner {
+		setupLog.Error(fmt.Errorf("unknown artifact fetch mode %q", artifactFetchMode), "invalid --artifact-fetch-mode")
+		os.Exit(1)
+	}
+
+	var breakGlassKey []byte
+	if breakGlassKeyFile != "" {
+		breakGlassKey, err = os.ReadFile(breakGlassKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read the break-glass key")
+			os.Exit(1)
+		}
+		breakGlassKey = bytes.TrimSpace(breakGlassKey)
+	}
+
+	var providerSchemas *controllers.ProviderSchemaCache
+	if providerSchemaCacheSize > 0 {
+		providerSchemas = controllers.NewProviderSchemaCache(providerSchemaCacheSize)
+	}
+
 	reconciler := &controllers.TerraformReconciler{
 		Client:                   mgr.GetClient(),
 		Scheme:                   mgr.GetScheme(),
@@ -182,14 +311,133 @@ func main() {
 		RunnerGRPCPort:           runnerGRPCPort,
 		RunnerCreationTimeout:    runnerCreationTimeout,
 		RunnerGRPCMaxMessageSize: runnerGRPCMaxMessageSize,
+		RunnerHeartbeatInterval:  runnerHeartbeatInterval,
+		Config:                   controllerConfig,
+
+		AllowCrossNamespaceOutputs: allowCrossNsOutputs,
+		AllowedOutputsNamespaces:   allowedOutputsNamespaces,
+		AllowedOutputsKinds:        allowedOutputsKinds,
+		AllowedRunnerRoles:         allowedRunnerRoles,
+		BreakGlassKey:              breakGlassKey,
+		ProviderSchemas:            providerSchemas,
+		ApprovalWorkers:            approvalWorkers,
+		ArtifactFetchMode:          artifactFetchMode,
 	}
 
 	if err = reconciler.SetupWithManager(mgr, concurrent, httpRetry); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Terraform")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.TerraformReceiverReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TerraformReceiver")
+		os.Exit(1)
+	}
+
+	if enableBranchPlanner {
+		if err = (&controllers.BranchPlannerReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "BranchPlanner")
+			os.Exit(1)
+		}
+	}
+	if enableAdmissionWebhook {
+		if err = (&controllers.TerraformValidator{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Terraform")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if orphanedStateInterval > 0 {
+		if err := mgr.Add(&controllers.OrphanedStateDetector{
+			Client:        mgr.GetClient(),
+			EventRecorder: mgr.GetEventRecorderFor(controllerName),
+			Interval:      orphanedStateInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up orphaned state detection")
+			os.Exit(1)
+		}
+	}
+
+	if dependencySweepInterval > 0 {
+		if err := mgr.Add(&controllers.DependencyFinalizerSweeper{
+			Client:        mgr.GetClient(),
+			EventRecorder: mgr.GetEventRecorderFor(controllerName),
+			Interval:      dependencySweepInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the dependency finalizer sweep")
+			os.Exit(1)
+		}
+	}
+
+	if driftReportInterval > 0 {
+		if err := mgr.Add(&controllers.DriftReportGenerator{
+			Client:   mgr.GetClient(),
+			Interval: driftReportInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the drift report")
+			os.Exit(1)
+		}
+	}
+
+	if complianceBucket != "" {
+		keyPEM, err := os.ReadFile(complianceKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read the compliance signing key")
+			os.Exit(1)
+		}
+		signingKey, err := controllers.ParseComplianceSigningKey(keyPEM)
+		if err != nil {
+			setupLog.Error(err, "unable to parse the compliance signing key")
+			os.Exit(1)
+		}
+		store, err := controllers.NewS3ComplianceStore(signalHandlerContext, complianceBucket, complianceEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the compliance store")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controllers.ComplianceExporter{
+			Client:     mgr.GetClient(),
+			Store:      store,
+			Prefix:     compliancePrefix,
+			SigningKey: signingKey,
+			Interval:   complianceInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the compliance export")
+			os.Exit(1)
+		}
+	}
+
+	if approverAPIAddr != "" {
+		if err := mgr.Add(&controllers.ApproverAPIServer{
+			Client:   mgr.GetClient(),
+			Addr:     approverAPIAddr,
+			CertFile: approverAPICertFile,
+			KeyFile:  approverAPIKeyFile,
+
+			BreakGlassKey:   breakGlassKey,
+			ProviderSchemas: providerSchemas,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the approver API")
+			os.Exit(1)
+		}
+	}
+
+	if receiverAddr != "" {
+		if err := mgr.Add(&controllers.ReceiverServer{
+			Client: mgr.GetClient(),
+			Addr:   receiverAddr,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the webhook receiver")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("INSECURE_LOCAL_RUNNER") == "1" {
 		runnerServer := &runner.TerraformRunnerServer{
 			Client: mgr.GetClient(),
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -55,15 +57,20 @@ func newRootCommand() *cobra.Command {
 
 	rootCmd.AddCommand(buildVersionCmd(app))
 	rootCmd.AddCommand(buildPlanGroup(app))
+	rootCmd.AddCommand(buildShowGroup(app))
+	rootCmd.AddCommand(buildApproveCmd(app))
+	rootCmd.AddCommand(buildReplanCmd(app))
 	rootCmd.AddCommand(buildInstallCmd(app))
 	rootCmd.AddCommand(buildUninstallCmd(app))
 	rootCmd.AddCommand(buildReconcileCmd(app))
 	rootCmd.AddCommand(buildSuspendCmd(app))
 	rootCmd.AddCommand(buildResumeCmd(app))
+	rootCmd.AddCommand(buildRegenerateOutputsCmd(app))
 	rootCmd.AddCommand(buildGetGroup(app))
 	rootCmd.AddCommand(buildDeleteCmd(app))
 	rootCmd.AddCommand(buildCreateCmd(app))
 	rootCmd.AddCommand(buildForceUnlockCmd(app))
+	rootCmd.AddCommand(buildWhichManagesCmd(app))
 
 	return rootCmd
 }
@@ -124,18 +131,26 @@ func buildUninstallCmd(app *tfctl.CLI) *cobra.Command {
 var reconcileExamples = `
   # Reconcile a Terraform resource
   tfctl reconcile --namespace=default my-resource
+
+  # Reconcile a Terraform resource, and fail if it is not ready after the reconciliation
+  tfctl reconcile --namespace=default my-resource --wait --timeout=10m
 `
 
 func buildReconcileCmd(app *tfctl.CLI) *cobra.Command {
-	return &cobra.Command{
+	reconcile := &cobra.Command{
 		Use:     "reconcile NAME",
 		Short:   "Trigger a reconcile of the provided resource",
 		Example: strings.Trim(reconcileExamples, "\n"),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return app.Reconcile(os.Stdout, args[0])
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			return app.Reconcile(os.Stdout, args[0], wait, timeout)
 		},
 	}
+	reconcile.Flags().Bool("wait", false, "Wait for the reconciliation to complete, and fail if the resource is not ready")
+	reconcile.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the reconciliation with --wait")
+	return reconcile
 }
 
 var suspendExamples = `
@@ -172,6 +187,23 @@ func buildResumeCmd(app *tfctl.CLI) *cobra.Command {
 	}
 }
 
+var regenerateOutputsExamples = `
+  # Write the outputs Secret of a Terraform resource again from its state
+  tfctl regenerate-outputs my-resource
+`
+
+func buildRegenerateOutputsCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "regenerate-outputs NAME",
+		Short:   "Write the outputs Secret of the provided resource again from its state, without a plan or an apply",
+		Args:    cobra.ExactArgs(1),
+		Example: strings.Trim(regenerateOutputsExamples, "\n"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.RegenerateOutputs(os.Stdout, args[0])
+		},
+	}
+}
+
 func buildPlanGroup(app *tfctl.CLI) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "plan",
@@ -179,6 +211,7 @@ func buildPlanGroup(app *tfctl.CLI) *cobra.Command {
 	}
 	cmd.AddCommand(buildPlanShowCmd(app))
 	cmd.AddCommand(buildPlanApproveCmd(app))
+	cmd.AddCommand(buildPlanGraphCmd(app))
 	return cmd
 }
 
@@ -199,6 +232,23 @@ func buildPlanShowCmd(app *tfctl.CLI) *cobra.Command {
 	}
 }
 
+var planGraphExamples = `
+  # Render the dependency graph of a Terraform resource with Graphviz
+  tfctl plan graph my-resource | dot -Tsvg > graph.svg
+`
+
+func buildPlanGraphCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "graph NAME",
+		Short:   "Show the dependency graph of a Terraform resource, in the DOT format",
+		Example: strings.Trim(planGraphExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.ShowGraph(os.Stdout, args[0])
+		},
+	}
+}
+
 var planApproveExamples = `
   # Approve the plan for a Terraform resource
   tfctl plan approve my-resource
@@ -216,9 +266,63 @@ func buildPlanApproveCmd(app *tfctl.CLI) *cobra.Command {
 	}
 }
 
+func buildShowGroup(app *tfctl.CLI) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the plans of Terraform resources",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:     "plan NAME",
+		Short:   "Show pending Terraform plan",
+		Example: "  tfctl show plan my-resource",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.ShowPlan(os.Stdout, args[0])
+		},
+	})
+	return cmd
+}
+
+var approveExamples = `
+  # Approve the plan for a Terraform resource, as tfctl plan approve
+  tfctl approve my-resource
+`
+
+func buildApproveCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "approve NAME",
+		Short:   "Approve pending Terraform plan",
+		Example: strings.Trim(approveExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.ApprovePlan(os.Stdout, args[0])
+		},
+	}
+}
+
+var replanExamples = `
+  # Discard the pending plan of a Terraform resource, and plan it again
+  tfctl replan my-resource
+`
+
+func buildReplanCmd(app *tfctl.CLI) *cobra.Command {
+	return &cobra.Command{
+		Use:     "replan NAME",
+		Short:   "Discard pending Terraform plan, and plan again",
+		Example: strings.Trim(replanExamples, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.Replan(os.Stdout, args[0])
+		},
+	}
+}
+
 var getExamples = `
   # List all Terraform resources in the given namespace
   tfctl get --namespace=default
+
+  # List all Terraform resources in all namespaces
+  tfctl get terraforms --all-namespaces
 `
 
 func buildGetGroup(app *tfctl.CLI) *cobra.Command {
@@ -226,10 +330,16 @@ func buildGetGroup(app *tfctl.CLI) *cobra.Command {
 		Use:     "get",
 		Short:   "Get Terraform resources",
 		Example: strings.Trim(getExamples, "\n"),
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return app.Get(os.Stdout)
+			if len(args) == 1 && args[0] != "terraforms" && args[0] != "terraform" && args[0] != "tf" {
+				return fmt.Errorf("unknown resource type %s, use terraforms", args[0])
+			}
+			allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+			return app.Get(os.Stdout, allNamespaces)
 		},
 	}
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List the Terraform resources of all namespaces")
 	cmd.AddCommand(buildGetTerraformCmd(app))
 	return cmd
 }
@@ -333,3 +443,23 @@ func buildForceUnlockCmd(app *tfctl.CLI) *cobra.Command {
 	viper.BindPFlags(forceUnlock.Flags())
 	return forceUnlock
 }
+
+var whichManagesExample = `
+	# Find the Terraform resources managing an S3 bucket in all namespaces
+	tfctl which-manages arn:aws:s3:::my-bucket --all-namespaces
+`
+
+func buildWhichManagesCmd(app *tfctl.CLI) *cobra.Command {
+	whichManages := &cobra.Command{
+		Use:     "which-manages ID",
+		Short:   "Find the Terraform resources managing a cloud resource, by its ARN or ID",
+		Example: strings.Trim(whichManagesExample, "\n"),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.WhichManages(os.Stdout, args[0], viper.GetBool("all-namespaces"))
+		},
+	}
+	whichManages.Flags().BoolP("all-namespaces", "A", false, "Look up the Terraform resources in all namespaces")
+	viper.BindPFlags(whichManages.Flags())
+	return whichManages
+}
@@ -0,0 +1,577 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const approverAPIPrefix = "/api/v1/terraforms/"
+
+// ApproverAPIServer serves a small REST API to review and approve the plans of Terraform objects,
+// for portals that cannot easily talk to the Kubernetes API:
+//
+//	GET  /api/v1/terraforms/{namespace}/{name}/plan     the pending plan
+//	POST /api/v1/terraforms/{namespace}/{name}/approve  approve the pending plan
+//	POST /api/v1/terraforms/{namespace}/{name}/break-glass  mint a break-glass token
+//	GET  /api/v1/terraforms/{namespace}/{name}/runs     the outcome of the last plan, apply and outputs
+//	GET  /api/v1/terraforms/{namespace}/{name}/inventory-diff  the resources added and removed by the last apply
+//	GET  /api/v1/resources?id={id}                      the objects managing a cloud resource, e.g. an ARN
+//
+// Requests carry a Kubernetes bearer token, authenticated with a TokenReview.
+// The caller must be allowed to get the Terraform object, or to patch it for approving, as checked with a SubjectAccessReview.
+// Minting a break-glass token requires the create verb on the terraforms/breakglass subresource, which only cluster admins have by default.
+type ApproverAPIServer struct {
+	client.Client
+	Addr     string
+	CertFile string
+	KeyFile  string
+
+	// BreakGlassKey signs the break-glass tokens, none is minted when it is empty.
+	BreakGlassKey []byte
+
+	// ProviderSchemas describes the resource types of the plans, when set.
+	ProviderSchemas *ProviderSchemaCache
+
+	// authorize is replaced in tests, it defaults to reviewAccess.
+	authorize func(ctx context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error)
+}
+
+// approverAPIError is an error with the HTTP status code it is answered with.
+type approverAPIError struct {
+	code int
+	msg  string
+}
+
+func (e *approverAPIError) Error() string {
+	return e.msg
+}
+
+// PlanResponse is the body answered to GET .../plan.
+type PlanResponse struct {
+	Pending  string             `json:"pending"`
+	Status   infrav1.PlanStatus `json:"status"`
+	Changes  []string           `json:"changes,omitempty"`
+	Readable string             `json:"readable,omitempty"`
+	// ResourceTypes describes the resource types of the changes, with the provider schema cache enabled.
+	ResourceTypes map[string]ResourceTypeInfo `json:"resourceTypes,omitempty"`
+}
+
+// ApproveRequest is the optional body of POST .../approve.
+// When Plan is set, the approval fails if it is not the pending plan anymore.
+type ApproveRequest struct {
+	Plan string `json:"plan,omitempty"`
+}
+
+// ApproveResponse is the body answered to POST .../approve.
+type ApproveResponse struct {
+	Approved string `json:"approved"`
+}
+
+// BreakGlassRequest is the body of POST .../break-glass.
+type BreakGlassRequest struct {
+	// Reason of the bypass, recorded in the audit event.
+	Reason string `json:"reason"`
+	// TTL of the token, 15m by default and 1h at most.
+	TTL string `json:"ttl,omitempty"`
+	// Plan restricts the token to a pending plan.
+	Plan string `json:"plan,omitempty"`
+}
+
+// BreakGlassResponse is the body answered to POST .../break-glass.
+type BreakGlassResponse struct {
+	Token      string      `json:"token"`
+	Annotation string      `json:"annotation"`
+	ExpiresAt  metav1.Time `json:"expiresAt"`
+}
+
+// ManagedResource is a cloud resource recorded in the inventory of a Terraform object.
+type ManagedResource struct {
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Resource  infrav1.ResourceRef `json:"resource"`
+}
+
+// ManagedResourcesResponse is the body answered to GET /api/v1/resources.
+type ManagedResourcesResponse struct {
+	Items []ManagedResource `json:"items"`
+}
+
+// Run is the outcome of one step of the last reconciliation.
+type Run struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason"`
+	Message            string      `json:"message"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// RunsResponse is the body answered to GET .../runs.
+type RunsResponse struct {
+	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+	LastPlannedRevision   string `json:"lastPlannedRevision,omitempty"`
+	LastAppliedRevision   string `json:"lastAppliedRevision,omitempty"`
+	Runs                  []Run  `json:"runs"`
+}
+
+// InventoryDiffResponse is the body answered to GET .../inventory-diff.
+type InventoryDiffResponse struct {
+	infrav1.InventoryDiff `json:",inline"`
+	Summary               string `json:"summary"`
+}
+
+// Start implements manager.Runnable.
+func (s *ApproverAPIServer) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("approver-api")
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("starting approver API", "addr", s.Addr)
+		var err error
+		if s.CertFile != "" && s.KeyFile != "" {
+			err = srv.ListenAndServeTLS(s.CertFile, s.KeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Every replica can serve the API, as it only reads and patches objects.
+func (s *ApproverAPIServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the http.Handler serving the API.
+func (s *ApproverAPIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(approverAPIPrefix, s.serveTerraform)
+	mux.HandleFunc("/api/v1/resources", s.serveResources)
+	return mux
+}
+
+func (s *ApproverAPIServer) serveTerraform(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, approverAPIPrefix), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		writeAPIError(w, &approverAPIError{http.StatusNotFound, "not found"})
+		return
+	}
+	key := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+
+	var (
+		method      string
+		verb        string
+		subresource string
+		handle      func(ctx context.Context, key types.NamespacedName, user string, r *http.Request) (interface{}, error)
+	)
+	switch parts[2] {
+	case "plan":
+		method, verb, handle = http.MethodGet, "get", s.getPlan
+	case "approve":
+		method, verb, handle = http.MethodPost, "patch", s.approve
+	case "runs":
+		method, verb, handle = http.MethodGet, "get", s.getRuns
+	case "inventory-diff":
+		method, verb, handle = http.MethodGet, "get", s.getInventoryDiff
+	case "break-glass":
+		method, verb, subresource, handle = http.MethodPost, "create", "breakglass", s.breakGlass
+	default:
+		writeAPIError(w, &approverAPIError{http.StatusNotFound, "not found"})
+		return
+	}
+
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeAPIError(w, &approverAPIError{http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method)})
+		return
+	}
+
+	token, err := bearerToken(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.authorizer()(ctx, token, authorizationv1.ResourceAttributes{
+		Namespace:   key.Namespace,
+		Verb:        verb,
+		Group:       infrav1.GroupVersion.Group,
+		Resource:    "terraforms",
+		Subresource: subresource,
+		Name:        key.Name,
+	})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithName("approver-api")
+	log.Info("serving request", "user", user, "action", parts[2], "namespace", key.Namespace, "name", key.Name)
+
+	body, err := handle(ctx, key, user, r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// serveResources answers which Terraform objects manage the cloud resource with the identifier given by the id parameter.
+// Only the objects of the namespaces where the caller is allowed to list Terraform objects are returned.
+func (s *ApproverAPIServer) serveResources(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeAPIError(w, &approverAPIError{http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method)})
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeAPIError(w, &approverAPIError{http.StatusBadRequest, "the id parameter is required"})
+		return
+	}
+
+	token, err := bearerToken(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	terraforms, err := listTerraformsManaging(ctx, s.Client, "", id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	reply := ManagedResourcesResponse{Items: []ManagedResource{}}
+	allowed := map[string]bool{}
+	for _, terraform := range terraforms {
+		ok, checked := allowed[terraform.Namespace]
+		if !checked {
+			_, err := s.authorizer()(ctx, token, authorizationv1.ResourceAttributes{
+				Namespace: terraform.Namespace,
+				Verb:      "list",
+				Group:     infrav1.GroupVersion.Group,
+				Resource:  "terraforms",
+			})
+			var apiErr *approverAPIError
+			if err != nil && !(errors.As(err, &apiErr) && apiErr.code == http.StatusForbidden) {
+				writeAPIError(w, err)
+				return
+			}
+			ok = err == nil
+			allowed[terraform.Namespace] = ok
+		}
+		if !ok {
+			continue
+		}
+
+		entry, _ := terraform.Status.Inventory.Find(id)
+		reply.Items = append(reply.Items, ManagedResource{
+			Namespace: terraform.Namespace,
+			Name:      terraform.Name,
+			Resource:  entry,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reply)
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == "" || token == header {
+		return "", &approverAPIError{http.StatusUnauthorized, "a bearer token is required"}
+	}
+	return token, nil
+}
+
+func (s *ApproverAPIServer) authorizer() func(ctx context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+	if s.authorize != nil {
+		return s.authorize
+	}
+	return s.reviewAccess
+}
+
+// reviewAccess authenticates the token with a TokenReview, and checks with a SubjectAccessReview
+// that its user is allowed to act on the object. It returns the name of the user.
+func (s *ApproverAPIServer) reviewAccess(ctx context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := s.Create(ctx, tokenReview); err != nil {
+		return "", fmt.Errorf("unable to review the token: %w", err)
+	}
+
+	if !tokenReview.Status.Authenticated {
+		return "", &approverAPIError{http.StatusUnauthorized, "invalid token"}
+	}
+
+	userInfo := tokenReview.Status.User
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               userInfo.Username,
+			Groups:             userInfo.Groups,
+			UID:                userInfo.UID,
+			Extra:              extra,
+		},
+	}
+	if err := s.Create(ctx, accessReview); err != nil {
+		return "", fmt.Errorf("unable to review the access: %w", err)
+	}
+
+	if !accessReview.Status.Allowed {
+		return "", &approverAPIError{http.StatusForbidden,
+			fmt.Sprintf("%s is not allowed to %s terraforms %s/%s", userInfo.Username, attributes.Verb, attributes.Namespace, attributes.Name)}
+	}
+
+	return userInfo.Username, nil
+}
+
+func (s *ApproverAPIServer) getTerraform(ctx context.Context, key types.NamespacedName) (*infrav1.Terraform, error) {
+	terraform := &infrav1.Terraform{}
+	if err := s.Get(ctx, key, terraform); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &approverAPIError{http.StatusNotFound, fmt.Sprintf("terraform %s not found", key)}
+		}
+		return nil, err
+	}
+	return terraform, nil
+}
+
+func (s *ApproverAPIServer) getPlan(ctx context.Context, key types.NamespacedName, _ string, _ *http.Request) (interface{}, error) {
+	terraform, err := s.getTerraform(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := PlanResponse{
+		Pending: terraform.Status.Plan.Pending,
+		Status:  terraform.Status.Plan,
+	}
+	if reply.Pending == "" {
+		return reply, nil
+	}
+
+	planName := "tfplan-" + terraform.WorkspaceName() + "-" + terraform.Name
+
+	var planSecret corev1.Secret
+	if err := s.Get(ctx, types.NamespacedName{Namespace: key.Namespace, Name: planName}, &planSecret); err == nil {
+		if data, ok := planSecret.Data[runner.TFPlanChangesKey]; ok {
+			if err := json.Unmarshal(data, &reply.Changes); err != nil {
+				return nil, fmt.Errorf("unable to read the changes of the plan: %w", err)
+			}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// only present with .spec.storeReadablePlan set to human, markdown or diff
+	var readablePlan corev1.ConfigMap
+	if err := s.Get(ctx, types.NamespacedName{Namespace: key.Namespace, Name: planName}, &readablePlan); err == nil {
+		reply.Readable = readablePlan.Data[runner.TFPlanName]
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if s.ProviderSchemas != nil && len(reply.Changes) > 0 {
+		reply.ResourceTypes = s.ProviderSchemas.ResourceTypes(terraform.Status.Providers, reply.Changes)
+	}
+
+	return reply, nil
+}
+
+func (s *ApproverAPIServer) approve(ctx context.Context, key types.NamespacedName, _ string, r *http.Request) (interface{}, error) {
+	var req ApproveRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, &approverAPIError{http.StatusBadRequest, fmt.Sprintf("invalid request: %s", err)}
+		}
+	}
+
+	var approved string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform, err := s.getTerraform(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		pending := terraform.Status.Plan.Pending
+		if pending == "" {
+			return &approverAPIError{http.StatusConflict, "no plan pending"}
+		}
+		if req.Plan != "" && !infrav1.PlanIdMatches(req.Plan, pending, terraform.Status.LastPlannedRevision) {
+			return &approverAPIError{http.StatusConflict, fmt.Sprintf("plan %s is not pending anymore, %s is", req.Plan, pending)}
+		}
+
+		patch := client.MergeFrom(terraform.DeepCopy())
+		terraform.Spec.ApprovePlan = pending
+		// reconcile right away instead of waiting for the next interval
+		if terraform.Annotations == nil {
+			terraform.Annotations = map[string]string{}
+		}
+		terraform.Annotations[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
+		if err := s.Patch(ctx, terraform, patch); err != nil {
+			return err
+		}
+
+		approved = pending
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ApproveResponse{Approved: approved}, nil
+}
+
+// breakGlass mints a break-glass token for the object, to be set as its break-glass-token annotation.
+// The minting is logged. The token itself is only answered, not stored, as it is verified with its signature.
+func (s *ApproverAPIServer) breakGlass(ctx context.Context, key types.NamespacedName, user string, r *http.Request) (interface{}, error) {
+	if len(s.BreakGlassKey) == 0 {
+		return nil, &approverAPIError{http.StatusNotFound, "break-glass tokens are not enabled"}
+	}
+
+	var req BreakGlassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, &approverAPIError{http.StatusBadRequest, fmt.Sprintf("invalid request: %s", err)}
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, &approverAPIError{http.StatusBadRequest, "a reason is required"}
+	}
+	ttl := defaultBreakGlassTokenTTL
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 || ttl > MaxBreakGlassTokenTTL {
+			return nil, &approverAPIError{http.StatusBadRequest, fmt.Sprintf("the ttl must be a duration up to %s", MaxBreakGlassTokenTTL)}
+		}
+	}
+
+	if _, err := s.getTerraform(ctx, key); err != nil {
+		return nil, err
+	}
+
+	token, err := newBreakGlassToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	token.Namespace, token.Name = key.Namespace, key.Name
+	token.Plan = req.Plan
+	token.User = user
+	token.Reason = req.Reason
+	token.ExpiresAt = expiresAt.Unix()
+	signed, err := signBreakGlassToken(s.BreakGlassKey, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.LoggerFrom(ctx).WithName("approver-api").Info("BREAK-GLASS: token minted", "audit", true,
+		"user", user, "token", token.ID, "namespace", key.Namespace, "name", key.Name, "reason", req.Reason, "expiresAt", expiresAt)
+	return BreakGlassResponse{
+		Token:      signed,
+		Annotation: infrav1.BreakGlassTokenAnnotation,
+		ExpiresAt:  metav1.NewTime(time.Unix(token.ExpiresAt, 0)),
+	}, nil
+}
+
+func (s *ApproverAPIServer) getRuns(ctx context.Context, key types.NamespacedName, _ string, _ *http.Request) (interface{}, error) {
+	terraform, err := s.getTerraform(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := RunsResponse{
+		LastAttemptedRevision: terraform.Status.LastAttemptedRevision,
+		LastPlannedRevision:   terraform.Status.LastPlannedRevision,
+		LastAppliedRevision:   terraform.Status.LastAppliedRevision,
+		Runs:                  []Run{},
+	}
+	for _, c := range terraform.Status.Conditions {
+		reply.Runs = append(reply.Runs, Run{
+			Type:               c.Type,
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		})
+	}
+
+	return reply, nil
+}
+
+func (s *ApproverAPIServer) getInventoryDiff(ctx context.Context, key types.NamespacedName, _ string, _ *http.Request) (interface{}, error) {
+	terraform, err := s.getTerraform(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if terraform.Status.InventoryDiff == nil {
+		if !terraform.Spec.EnableInventory {
+			return nil, &approverAPIError{http.StatusNotFound, fmt.Sprintf("terraform %s has no inventory enabled", key)}
+		}
+		return nil, &approverAPIError{http.StatusNotFound, fmt.Sprintf("terraform %s has not been applied with the inventory yet", key)}
+	}
+
+	diff := *terraform.Status.InventoryDiff
+	return InventoryDiffResponse{InventoryDiff: diff, Summary: diff.Summary()}, nil
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var apiErr *approverAPIError
+	if errors.As(err, &apiErr) {
+		code = apiErr.code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApproverAPIServer(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+			Status: infrav1.TerraformStatus{
+				LastPlannedRevision: "main/b8e362c206",
+				Plan:                infrav1.PlanStatus{Pending: "plan-main-b8e362c206"},
+				Conditions: []metav1.Condition{{
+					Type:   "Plan",
+					Status: metav1.ConditionTrue,
+					Reason: "TerraformPlannedWithChanges",
+				}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-helloworld", Namespace: "flux-system"},
+			Data:       map[string][]byte{"tfplan.changes": []byte(`["create null_resource.a"]`)},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-helloworld", Namespace: "flux-system"},
+			Data:       map[string]string{"tfplan": "Plan: 1 to add, 0 to change, 0 to destroy."},
+		},
+	).Build()
+
+	var lastVerb string
+	server := &ApproverAPIServer{
+		Client: cli,
+		authorize: func(_ context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+			lastVerb = attributes.Verb
+			switch token {
+			case "approver":
+				return "approver", nil
+			case "viewer":
+				if attributes.Verb == "get" {
+					return "viewer", nil
+				}
+				return "", &approverAPIError{http.StatusForbidden, "forbidden"}
+			}
+			return "", &approverAPIError{http.StatusUnauthorized, "invalid token"}
+		},
+	}
+	handler := server.Handler()
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	const base = "/api/v1/terraforms/flux-system/helloworld"
+
+	g.Expect(do(http.MethodGet, base+"/plan", "", "").Code).To(Equal(http.StatusUnauthorized))
+	g.Expect(do(http.MethodGet, base+"/plan", "unknown", "").Code).To(Equal(http.StatusUnauthorized))
+	g.Expect(do(http.MethodGet, base+"/unknown", "viewer", "").Code).To(Equal(http.StatusNotFound))
+	g.Expect(do(http.MethodGet, "/api/v1/terraforms/flux-system/missing/plan", "viewer", "").Code).To(Equal(http.StatusNotFound))
+	g.Expect(do(http.MethodGet, base+"/approve", "approver", "").Code).To(Equal(http.StatusMethodNotAllowed))
+
+	rec := do(http.MethodGet, base+"/plan", "viewer", "")
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(lastVerb).To(Equal("get"))
+	var plan PlanResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &plan)).To(Succeed())
+	g.Expect(plan.Pending).To(Equal("plan-main-b8e362c206"))
+	g.Expect(plan.Changes).To(Equal([]string{"create null_resource.a"}))
+	g.Expect(plan.Readable).To(ContainSubstring("1 to add"))
+
+	rec = do(http.MethodGet, base+"/runs", "viewer", "")
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	var runs RunsResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &runs)).To(Succeed())
+	g.Expect(runs.LastPlannedRevision).To(Equal("main/b8e362c206"))
+	g.Expect(runs.Runs).To(HaveLen(1))
+	g.Expect(runs.Runs[0].Reason).To(Equal("TerraformPlannedWithChanges"))
+
+	g.Expect(do(http.MethodPost, base+"/approve", "viewer", "").Code).To(Equal(http.StatusForbidden))
+	g.Expect(lastVerb).To(Equal("patch"))
+	g.Expect(do(http.MethodPost, base+"/approve", "approver", `{"plan": "plan-main-a1b2c3d4"}`).Code).To(Equal(http.StatusConflict))
+
+	rec = do(http.MethodPost, base+"/approve", "approver", `{"plan": "plan-main-b8e362c206"}`)
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	var approved ApproveResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &approved)).To(Succeed())
+	g.Expect(approved.Approved).To(Equal("plan-main-b8e362c206"))
+
+	var terraform infrav1.Terraform
+	g.Expect(cli.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &terraform)).To(Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(Equal("plan-main-b8e362c206"))
+	g.Expect(terraform.Annotations).To(HaveKey(meta.ReconcileRequestAnnotation))
+}
@@ -0,0 +1,421 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	defaultBranchPlannerInterval = 5 * time.Minute
+	// maxPullRequestCommentLength keeps the comments under the limit of 65536 characters of GitHub.
+	maxPullRequestCommentLength = 60000
+)
+
+// PullRequest is an open pull request of the repository of a branch planner.
+type PullRequest struct {
+	Number int
+	Branch string
+	SHA    string
+	// Fork is true when the branch is in another repository, whose code is never planned,
+	// as it would run with the credentials of the object.
+	Fork bool
+}
+
+// pullRequestProvider is the API of the provider hosting the repository of a branch planner.
+type pullRequestProvider interface {
+	ListPullRequests(ctx context.Context) ([]PullRequest, error)
+	// PullRequestsOf returns the numbers of the open pull requests whose head is the commit sha.
+	PullRequestsOf(ctx context.Context, sha string) ([]int, error)
+	Comment(ctx context.Context, number int, body string) error
+}
+
+// pullRequestApprovals is implemented by the providers whose approvals of the pull requests can approve the plans.
+type pullRequestApprovals interface {
+	// ApprovedBy returns the approvers of the pull request, or none while it is not approved.
+	ApprovedBy(ctx context.Context, number int) ([]string, error)
+}
+
+// BranchPlannerReconciler previews the pull requests of the GitRepository of the objects with a branch planner.
+// Each open pull request gets a GitRepository following its branch and a plan-only copy of the object, named
+// <name>-pr-<number>, whose plans are commented on the pull request. The previews of the closed pull requests are deleted.
+type BranchPlannerReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	HTTPClient *http.Client
+}
+
+//+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=gitrepositories,verbs=create;update;delete
+
+func (r *BranchPlannerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var terraform infrav1.Terraform
+	if err := r.Get(ctx, req.NamespacedName, &terraform); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if _, ok := terraform.Labels[infrav1.BranchPlannerLabel]; ok {
+		return ctrl.Result{}, r.commentPlan(ctx, terraform)
+	}
+
+	if terraform.Spec.BranchPlanner == nil || !terraform.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.deletePreviews(ctx, terraform, nil)
+	}
+
+	if terraform.Spec.SourceRef.Kind != sourcev1.GitRepositoryKind ||
+		(terraform.Spec.SourceRef.Namespace != "" && terraform.Spec.SourceRef.Namespace != terraform.Namespace) {
+		log.Info("the branch planner only previews the GitRepository of the namespace of the object", "sourceRef", terraform.Spec.SourceRef.String())
+		return ctrl.Result{}, nil
+	}
+
+	var repository sourcev1.GitRepository
+	if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.SourceRef.Name}, &repository); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	provider, err := r.pullRequestProvider(ctx, terraform, repository.Spec.URL)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	pullRequests, err := provider.ListPullRequests(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list the pull requests: %w", err)
+	}
+
+	open := map[int]bool{}
+	for _, pr := range pullRequests {
+		if pr.Fork {
+			log.V(1).Info("skipping the pull request of a fork", "pullRequest", pr.Number)
+			continue
+		}
+		open[pr.Number] = true
+		if err := r.reconcilePreview(ctx, terraform, repository, pr); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to reconcile the preview of pull request %d: %w", pr.Number, err)
+		}
+	}
+
+	if err := r.deletePreviews(ctx, terraform, open); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	interval := defaultBranchPlannerInterval
+	if terraform.Spec.BranchPlanner.Interval != nil {
+		interval = terraform.Spec.BranchPlanner.Interval.Duration
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// pullRequestProvider returns the API client of the provider of the branch planner of the object.
+func (r *BranchPlannerReconciler) pullRequestProvider(ctx context.Context, terraform infrav1.Terraform, repositoryURL string) (pullRequestProvider, error) {
+	spec := terraform.Spec.BranchPlanner
+	return newPullRequestProvider(ctx, r.Client, r.HTTPClient, terraform.Namespace, spec.Provider, spec.SecretRef, spec.APIURL, repositoryURL)
+}
+
+// newPullRequestProvider returns the API client of provider for the repository at repositoryURL,
+// authenticated with the token of the Secret secretRef of the namespace.
+func newPullRequestProvider(ctx context.Context, c client.Client, httpClient *http.Client, namespace string, provider string, secretRef meta.LocalObjectReference, apiURL string, repositoryURL string) (pullRequestProvider, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretRef.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the secret of the token of %s: %w", provider, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[infrav1.BranchPlannerTokenKey]))
+	if token == "" {
+		return nil, fmt.Errorf("secret %s has no %s key", secretRef.Name, infrav1.BranchPlannerTokenKey)
+	}
+
+	path, err := repositoryPath(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	switch provider {
+	case "", "github":
+		if apiURL == "" {
+			apiURL = defaultGitHubAPIURL
+		}
+		return &gitHubProvider{httpClient: httpClient, apiURL: strings.TrimSuffix(apiURL, "/"), token: token, repository: path}, nil
+	case "gitlab":
+		if apiURL == "" {
+			apiURL = defaultGitLabAPIURL
+		}
+		return &gitLabProvider{httpClient: httpClient, apiURL: strings.TrimSuffix(apiURL, "/"), token: token, repository: path}, nil
+	}
+	return nil, fmt.Errorf("unsupported pull request provider %q", provider)
+}
+
+// repositoryPath returns the path of a repository on its provider, e.g. owner/name of
+// https://github.com/owner/name.git or ssh://git@github.com/owner/name.
+func repositoryPath(repositoryURL string) (string, error) {
+	var path string
+	if strings.Contains(repositoryURL, "://") {
+		u, err := url.Parse(repositoryURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid repository URL %q: %w", repositoryURL, err)
+		}
+		path = u.Path
+	} else if _, p, ok := strings.Cut(repositoryURL, ":"); ok {
+		// scp-like URLs, e.g. git@github.com:owner/name.git
+		path = p
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return "", fmt.Errorf("unable to find the repository of the URL %q", repositoryURL)
+	}
+	return path, nil
+}
+
+func previewName(terraform infrav1.Terraform, number int) string {
+	return fmt.Sprintf("%s-pr-%d", terraform.Name, number)
+}
+
+func previewLabels(terraform infrav1.Terraform, number int) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/created-by":        "tf-controller",
+		infrav1.BranchPlannerLabel:            terraform.Name,
+		infrav1.BranchPlannerPullRequestLabel: strconv.Itoa(number),
+	}
+}
+
+// reconcilePreview creates or updates the GitRepository of the branch of the pull request, and the plan-only copy of the object planning it.
+func (r *BranchPlannerReconciler) reconcilePreview(ctx context.Context, terraform infrav1.Terraform, repository sourcev1.GitRepository, pr PullRequest) error {
+	name := previewName(terraform, pr.Number)
+
+	previewRepository := sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Namespace: terraform.Namespace, Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &previewRepository, func() error {
+		previewRepository.Labels = previewLabels(terraform, pr.Number)
+		previewRepository.Spec = *repository.Spec.DeepCopy()
+		previewRepository.Spec.Reference = &sourcev1.GitRepositoryRef{Branch: pr.Branch}
+		return controllerutil.SetOwnerReference(&terraform, &previewRepository, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	preview := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: terraform.Namespace, Name: name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, &preview, func() error {
+		preview.Labels = previewLabels(terraform, pr.Number)
+		preview.Spec = previewSpec(terraform, name)
+		return controllerutil.SetOwnerReference(&terraform, &preview, r.Scheme)
+	})
+	return err
+}
+
+// previewSpec returns the spec of the plan-only copy of the object, planning the GitRepository of a pull request
+// against the state of the object. Everything writing outside of the plan, or acting on other objects, is left out.
+func previewSpec(terraform infrav1.Terraform, name string) infrav1.TerraformSpec {
+	spec := terraform.Spec.DeepCopy()
+	spec.PlanOnly = true
+	spec.BranchPlanner = nil
+	spec.PullRequestComments = nil
+	spec.ApprovePlan = ""
+	spec.Force = false
+	spec.ManualReconciliation = false
+	spec.DestroyResourcesOnDeletion = false
+	spec.DeleteDependants = false
+	spec.DependsOn = nil
+	spec.WriteOutputsToSecret = nil
+	spec.WriteOutputsToConfigMap = nil
+	spec.WriteOutputsTo = nil
+	spec.HealthChecks = nil
+	spec.TFState = nil
+	spec.WorkingDirStorage = nil
+	spec.SourceNotFound = nil
+	spec.EnableInventory = false
+	spec.WatchClusterInventory = false
+	spec.StoreReadablePlan = "markdown"
+	spec.SourceRef = infrav1.CrossNamespaceSourceReference{
+		Kind:      sourcev1.GitRepositoryKind,
+		Name:      name,
+		Namespace: terraform.Namespace,
+	}
+
+	// the default kubernetes backend stores the state under the name of the object
+	if spec.BackendConfig == nil && os.Getenv("DISABLE_TF_K8S_BACKEND") != "1" {
+		spec.BackendConfig = &infrav1.BackendConfigSpec{
+			SecretSuffix:    terraform.Name,
+			InClusterConfig: true,
+		}
+	}
+	return *spec
+}
+
+// deletePreviews deletes the previews of the object, except the ones of the open pull requests.
+func (r *BranchPlannerReconciler) deletePreviews(ctx context.Context, terraform infrav1.Terraform, open map[int]bool) error {
+	selector := client.MatchingLabels{infrav1.BranchPlannerLabel: terraform.Name}
+
+	var previews infrav1.TerraformList
+	if err := r.List(ctx, &previews, client.InNamespace(terraform.Namespace), selector); err != nil {
+		return err
+	}
+	for i := range previews.Items {
+		if keepPreview(previews.Items[i].Labels, open) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info("deleting the preview of a closed pull request", "preview", previews.Items[i].Name)
+		if err := r.Delete(ctx, &previews.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	var repositories sourcev1.GitRepositoryList
+	if err := r.List(ctx, &repositories, client.InNamespace(terraform.Namespace), selector); err != nil {
+		return err
+	}
+	for i := range repositories.Items {
+		if keepPreview(repositories.Items[i].Labels, open) {
+			continue
+		}
+		if err := r.Delete(ctx, &repositories.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func keepPreview(labels map[string]string, open map[int]bool) bool {
+	number, err := strconv.Atoi(labels[infrav1.BranchPlannerPullRequestLabel])
+	return err == nil && open[number]
+}
+
+// commentPlan comments the outcome of the last plan of a preview on its pull request, once per revision.
+func (r *BranchPlannerReconciler) commentPlan(ctx context.Context, preview infrav1.Terraform) error {
+	key, body, err := r.previewComment(ctx, preview)
+	if err != nil || key == "" || preview.Annotations[infrav1.BranchPlannerCommentedAnnotation] == key {
+		return err
+	}
+
+	var terraform infrav1.Terraform
+	if err := r.Get(ctx, types.NamespacedName{Namespace: preview.Namespace, Name: preview.Labels[infrav1.BranchPlannerLabel]}, &terraform); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if terraform.Spec.BranchPlanner == nil {
+		return nil
+	}
+	number, err := strconv.Atoi(preview.Labels[infrav1.BranchPlannerPullRequestLabel])
+	if err != nil {
+		return nil
+	}
+
+	var repository sourcev1.GitRepository
+	if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.SourceRef.Name}, &repository); err != nil {
+		return err
+	}
+	provider, err := r.pullRequestProvider(ctx, terraform, repository.Spec.URL)
+	if err != nil {
+		return err
+	}
+
+	if len(body) > maxPullRequestCommentLength {
+		body = body[:maxPullRequestCommentLength] + "\n\n... Truncated"
+	}
+	if err := provider.Comment(ctx, number, body); err != nil {
+		return fmt.Errorf("unable to comment the plan on pull request %d: %w", number, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("commented the plan on the pull request", "pullRequest", number, "plan", key)
+
+	patch := client.MergeFrom(preview.DeepCopy())
+	if preview.Annotations == nil {
+		preview.Annotations = map[string]string{}
+	}
+	preview.Annotations[infrav1.BranchPlannerCommentedAnnotation] = key
+	return r.Patch(ctx, &preview, patch)
+}
+
+// previewComment returns the comment of the last plan of the preview, and the key identifying it.
+// The key is empty while the preview has not planned yet.
+func (r *BranchPlannerReconciler) previewComment(ctx context.Context, preview infrav1.Terraform) (string, string, error) {
+	title := fmt.Sprintf("**Terraform plan** of `%s/%s`", preview.Namespace, preview.Labels[infrav1.BranchPlannerLabel])
+
+	ready := apimeta.FindStatusCondition(preview.Status.Conditions, meta.ReadyCondition)
+	attempted := preview.Status.LastAttemptedRevision
+	if ready != nil && ready.Status == metav1.ConditionFalse && attempted != "" && attempted != preview.Status.LastPlannedRevision {
+		message, err := conditionMessage(ctx, r.Client, preview, meta.ReadyCondition)
+		if err != nil {
+			return "", "", err
+		}
+		return "failed/" + attempted, fmt.Sprintf("%s failed at `%s`:\n\n```\n%s\n```", title, attempted, message), nil
+	}
+
+	revision := preview.Status.LastPlannedRevision
+	if revision == "" {
+		return "", "", nil
+	}
+	if preview.Status.Plan.Pending == "" {
+		return revision, fmt.Sprintf("%s at `%s`: no changes.", title, revision), nil
+	}
+
+	var readablePlan corev1.ConfigMap
+	planName := "tfplan-" + preview.WorkspaceName() + "-" + preview.Name
+	if err := r.Get(ctx, types.NamespacedName{Namespace: preview.Namespace, Name: planName}, &readablePlan); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the readable plan is stored before the status, it is read on the next event
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	return revision, fmt.Sprintf("%s at `%s`:\n\n%s", title, revision, readablePlan.Data[runner.TFPlanName]), nil
+}
+
+func (r *BranchPlannerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("branch-planner").
+		For(&infrav1.Terraform{}, builder.WithPredicates(BranchPlannerPredicate{})).
+		Complete(r)
+}
+
+// BranchPlannerPredicate triggers for the changes of the objects with a branch planner, and for the status updates
+// of their previews, whose plans are commented.
+type BranchPlannerPredicate struct {
+	predicate.Funcs
+}
+
+func (BranchPlannerPredicate) Create(e event.CreateEvent) bool {
+	return hasBranchPlanner(e.Object)
+}
+
+func (BranchPlannerPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	if _, ok := e.ObjectNew.GetLabels()[infrav1.BranchPlannerLabel]; ok {
+		return true
+	}
+	return (hasBranchPlanner(e.ObjectOld) || hasBranchPlanner(e.ObjectNew)) &&
+		e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration()
+}
+
+func (BranchPlannerPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
+
+func hasBranchPlanner(o client.Object) bool {
+	terraform, ok := o.(*infrav1.Terraform)
+	return ok && terraform.Spec.BranchPlanner != nil
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+	gitHubPageSize      = 100
+)
+
+// gitHubProvider lists and comments the pull requests of a GitHub repository.
+type gitHubProvider struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+	// repository is the owner/name path of the repository.
+	repository string
+}
+
+type gitHubPullRequest struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Head   struct {
+		Ref  string `json:"ref"`
+		SHA  string `json:"sha"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Repo struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"base"`
+}
+
+func (p *gitHubProvider) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	var pullRequests []PullRequest
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/pulls?state=open&per_page=%d&page=%d", p.apiURL, p.repository, gitHubPageSize, page)
+		var prs []gitHubPullRequest
+		if err := p.do(ctx, http.MethodGet, url, nil, &prs); err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			// the repository of a deleted fork is null
+			fork := pr.Head.Repo == nil || pr.Head.Repo.FullName != pr.Base.Repo.FullName
+			pullRequests = append(pullRequests, PullRequest{
+				Number: pr.Number,
+				Branch: pr.Head.Ref,
+				SHA:    pr.Head.SHA,
+				Fork:   fork,
+			})
+		}
+		if len(prs) < gitHubPageSize {
+			return pullRequests, nil
+		}
+	}
+}
+
+func (p *gitHubProvider) PullRequestsOf(ctx context.Context, sha string) ([]int, error) {
+	url := fmt.Sprintf("%s/repos/%s/commits/%s/pulls", p.apiURL, p.repository, sha)
+	var prs []gitHubPullRequest
+	if err := p.do(ctx, http.MethodGet, url, nil, &prs); err != nil {
+		return nil, err
+	}
+	// the merged pull requests and the ones the commit is no longer the head of are left out
+	var numbers []int
+	for _, pr := range prs {
+		if pr.State == "open" && pr.Head.SHA == sha {
+			numbers = append(numbers, pr.Number)
+		}
+	}
+	return numbers, nil
+}
+
+func (p *gitHubProvider) Comment(ctx context.Context, number int, body string) error {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d/comments", p.apiURL, p.repository, number)
+	return p.do(ctx, http.MethodPost, url, map[string]string{"body": body}, nil)
+}
+
+func (p *gitHubProvider) do(ctx context.Context, method string, url string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GitHub API %s %s: %s: %s", method, strings.TrimPrefix(url, p.apiURL), resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
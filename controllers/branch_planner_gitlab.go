@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultGitLabAPIURL = "https://gitlab.com/api/v4"
+	gitLabPageSize      = 100
+)
+
+// gitLabProvider lists, comments and reads the approvals of the merge requests of a GitLab project.
+// The merge requests are identified by their iid, the number shown in the project.
+type gitLabProvider struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+	// repository is the path of the project, with its groups.
+	repository string
+}
+
+type gitLabMergeRequest struct {
+	IID             int    `json:"iid"`
+	State           string `json:"state"`
+	SourceBranch    string `json:"source_branch"`
+	SHA             string `json:"sha"`
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+}
+
+func (p *gitLabProvider) project() string {
+	return fmt.Sprintf("%s/projects/%s", p.apiURL, url.PathEscape(p.repository))
+}
+
+func (p *gitLabProvider) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	var pullRequests []PullRequest
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/merge_requests?state=opened&per_page=%d&page=%d", p.project(), gitLabPageSize, page)
+		var mrs []gitLabMergeRequest
+		if err := p.do(ctx, http.MethodGet, url, nil, &mrs); err != nil {
+			return nil, err
+		}
+		for _, mr := range mrs {
+			pullRequests = append(pullRequests, PullRequest{
+				Number: mr.IID,
+				Branch: mr.SourceBranch,
+				SHA:    mr.SHA,
+				Fork:   mr.SourceProjectID != mr.TargetProjectID,
+			})
+		}
+		if len(mrs) < gitLabPageSize {
+			return pullRequests, nil
+		}
+	}
+}
+
+func (p *gitLabProvider) PullRequestsOf(ctx context.Context, sha string) ([]int, error) {
+	url := fmt.Sprintf("%s/repository/commits/%s/merge_requests", p.project(), sha)
+	var mrs []gitLabMergeRequest
+	if err := p.do(ctx, http.MethodGet, url, nil, &mrs); err != nil {
+		return nil, err
+	}
+	var numbers []int
+	for _, mr := range mrs {
+		if mr.State == "opened" && mr.SHA == sha {
+			numbers = append(numbers, mr.IID)
+		}
+	}
+	return numbers, nil
+}
+
+func (p *gitLabProvider) Comment(ctx context.Context, number int, body string) error {
+	url := fmt.Sprintf("%s/merge_requests/%d/notes", p.project(), number)
+	return p.do(ctx, http.MethodPost, url, map[string]string{"body": body}, nil)
+}
+
+// ApprovedBy returns the usernames of the approvers of the merge request, when it has all its required approvals.
+func (p *gitLabProvider) ApprovedBy(ctx context.Context, number int) ([]string, error) {
+	url := fmt.Sprintf("%s/merge_requests/%d/approvals", p.project(), number)
+	var approvals struct {
+		Approved   bool `json:"approved"`
+		ApprovedBy []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"approved_by"`
+	}
+	if err := p.do(ctx, http.MethodGet, url, nil, &approvals); err != nil {
+		return nil, err
+	}
+	if !approvals.Approved {
+		return nil, nil
+	}
+	var users []string
+	for _, a := range approvals.ApprovedBy {
+		users = append(users, a.User.Username)
+	}
+	return users, nil
+}
+
+func (p *gitLabProvider) do(ctx context.Context, method string, url string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", p.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GitLab API %s %s: %s: %s", method, strings.TrimPrefix(url, p.apiURL), resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRepositoryPath(t *testing.T) {
+	g := NewWithT(t)
+
+	for url, path := range map[string]string{
+		"https://github.com/weaveworks/tf-controller":        "weaveworks/tf-controller",
+		"https://github.com/weaveworks/tf-controller.git":    "weaveworks/tf-controller",
+		"ssh://git@github.com/weaveworks/tf-controller.git":  "weaveworks/tf-controller",
+		"git@github.com:weaveworks/tf-controller.git":        "weaveworks/tf-controller",
+		"https://github.example.com/infra/team/network.git/": "infra/team/network",
+	} {
+		g.Expect(repositoryPath(url)).To(Equal(path), url)
+	}
+
+	_, err := repositoryPath("https://github.com/weaveworks")
+	g.Expect(err).To(HaveOccurred())
+}
+
+type fakeGitHub struct {
+	pullRequests []map[string]interface{}
+	comments     map[string][]string
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/weaveworks/infra/pulls":
+		json.NewEncoder(w).Encode(f.pullRequests)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/weaveworks/infra/commits/"):
+		json.NewEncoder(w).Encode(f.pullRequests)
+	case r.Method == http.MethodPost:
+		var body struct {
+			Body string `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.comments[r.URL.Path] = append(f.comments[r.URL.Path], body.Body)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func gitHubPullRequestJSON(number int, branch string, headRepo string) map[string]interface{} {
+	head := map[string]interface{}{"ref": branch, "sha": "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"}
+	if headRepo != "" {
+		head["repo"] = map[string]interface{}{"full_name": headRepo}
+	}
+	return map[string]interface{}{
+		"number": number,
+		"state":  "open",
+		"head":   head,
+		"base":   map[string]interface{}{"repo": map[string]interface{}{"full_name": "weaveworks/infra"}},
+	}
+}
+
+func TestBranchPlannerReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	gitHub := &fakeGitHub{
+		pullRequests: []map[string]interface{}{
+			gitHubPullRequestJSON(7, "feature", "weaveworks/infra"),
+			gitHubPullRequestJSON(8, "evil", "someone/infra"),
+		},
+		comments: map[string][]string{},
+	}
+	server := httptest.NewServer(gitHub)
+	defer server.Close()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "infra", UID: "4b2b7dfc"},
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan:                "auto",
+			DestroyResourcesOnDeletion: true,
+			SourceRef:                  infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "infra"},
+			WriteOutputsToSecret:       &infrav1.WriteOutputsToSecretSpec{Name: "infra-outputs"},
+			BranchPlanner: &infrav1.BranchPlannerSpec{
+				SecretRef: meta.LocalObjectReference{Name: "github-token"},
+				APIURL:    server.URL,
+			},
+		},
+	}
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "infra"},
+		Spec: sourcev1.GitRepositorySpec{
+			URL:       "https://github.com/weaveworks/infra",
+			Reference: &sourcev1.GitRepositoryRef{Branch: "main"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "github-token"},
+		Data:       map[string][]byte{infrav1.BranchPlannerTokenKey: []byte("s3cr3t\n")},
+	}
+	// the preview of a closed pull request
+	closed := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "flux-system",
+		Name:      "infra-pr-3",
+		Labels:    map[string]string{infrav1.BranchPlannerLabel: "infra", infrav1.BranchPlannerPullRequestLabel: "3"},
+	}}
+
+	r := &BranchPlannerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform, repository, secret, closed).Build(),
+		Scheme: testScheme,
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "flux-system", Name: "infra"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(defaultBranchPlannerInterval))
+
+	var previewRepository sourcev1.GitRepository
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "infra-pr-7"}, &previewRepository)).To(Succeed())
+	g.Expect(previewRepository.Spec.URL).To(Equal(repository.Spec.URL))
+	g.Expect(previewRepository.Spec.Reference.Branch).To(Equal("feature"))
+	g.Expect(previewRepository.OwnerReferences).To(HaveLen(1))
+
+	var preview infrav1.Terraform
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "infra-pr-7"}, &preview)).To(Succeed())
+	g.Expect(preview.Labels).To(HaveKeyWithValue(infrav1.BranchPlannerPullRequestLabel, "7"))
+	g.Expect(preview.Spec.PlanOnly).To(BeTrue())
+	g.Expect(preview.Spec.BranchPlanner).To(BeNil())
+	g.Expect(preview.Spec.ApprovePlan).To(BeEmpty())
+	g.Expect(preview.Spec.DestroyResourcesOnDeletion).To(BeFalse())
+	g.Expect(preview.Spec.WriteOutputsToSecret).To(BeNil())
+	g.Expect(preview.Spec.SourceRef.Name).To(Equal("infra-pr-7"))
+	g.Expect(preview.Spec.BackendConfig.SecretSuffix).To(Equal("infra"))
+
+	// the pull request of a fork is not previewed
+	err = r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "infra-pr-8"}, &infrav1.Terraform{})
+	g.Expect(err).To(HaveOccurred())
+
+	err = r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "infra-pr-3"}, &infrav1.Terraform{})
+	g.Expect(err).To(HaveOccurred())
+
+	// the plan of the preview is commented once
+	patch := client.MergeFrom(preview.DeepCopy())
+	preview.Status.LastPlannedRevision = "feature/b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+	preview.Status.Conditions = []metav1.Condition{{Type: meta.ReadyCondition, Status: metav1.ConditionTrue, Reason: "NoChanges"}}
+	g.Expect(r.Status().Patch(ctx, &preview, patch)).To(Succeed())
+
+	for i := 0; i < 2; i++ {
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "flux-system", Name: "infra-pr-7"}})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	comments := gitHub.comments["/repos/weaveworks/infra/issues/7/comments"]
+	g.Expect(comments).To(HaveLen(1))
+	g.Expect(comments[0]).To(ContainSubstring("no changes"))
+
+	// removing the branch planner deletes the previews
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "infra"}, terraform)).To(Succeed())
+	terraform.Spec.BranchPlanner = nil
+	g.Expect(r.Update(ctx, terraform)).To(Succeed())
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "flux-system", Name: "infra"}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var previews infrav1.TerraformList
+	g.Expect(r.List(ctx, &previews, client.MatchingLabels{infrav1.BranchPlannerLabel: "infra"})).To(Succeed())
+	g.Expect(previews.Items).To(BeEmpty())
+}
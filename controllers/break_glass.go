@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	BreakGlassEventReason = "BreakGlass"
+
+	breakGlassTokenPrefix = "tfbg"
+	// MaxBreakGlassTokenTTL bounds the lifetime of the break-glass tokens.
+	MaxBreakGlassTokenTTL     = time.Hour
+	defaultBreakGlassTokenTTL = 15 * time.Minute
+)
+
+// BreakGlassToken is the payload of a break-glass token, signed with the break-glass key of the controller.
+type BreakGlassToken struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Plan restricts the token to a pending plan, any plan is applied when empty.
+	Plan      string `json:"plan,omitempty"`
+	User      string `json:"user"`
+	Reason    string `json:"reason"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// newBreakGlassToken returns a token with a new identifier.
+func newBreakGlassToken() (BreakGlassToken, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return BreakGlassToken{}, err
+	}
+	return BreakGlassToken{ID: hex.EncodeToString(b)}, nil
+}
+
+// signBreakGlassToken encodes the token as tfbg.<payload>.<signature>, with an HMAC-SHA256 signature.
+func signBreakGlassToken(key []byte, token BreakGlassToken) (string, error) {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	signed := breakGlassTokenPrefix + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(breakGlassMAC(key, signed)), nil
+}
+
+func breakGlassMAC(key []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+// parseBreakGlassToken verifies the signature and the expiration of a token.
+func parseBreakGlassToken(key []byte, s string, now time.Time) (*BreakGlassToken, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || parts[0] != breakGlassTokenPrefix {
+		return nil, errors.New("malformed token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token")
+	}
+	if !hmac.Equal(signature, breakGlassMAC(key, parts[0]+"."+parts[1])) {
+		return nil, errors.New("invalid signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token")
+	}
+	var token BreakGlassToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, errors.New("malformed token")
+	}
+	if now.Unix() > token.ExpiresAt {
+		return nil, fmt.Errorf("token expired at %s", time.Unix(token.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return &token, nil
+}
+
+// breakGlassToken returns the break-glass token of the object, if it is valid, issued for the object and not used yet.
+func (r *TerraformReconciler) breakGlassToken(terraform infrav1.Terraform) (*BreakGlassToken, error) {
+	s := terraform.GetAnnotations()[infrav1.BreakGlassTokenAnnotation]
+	if s == "" {
+		return nil, nil
+	}
+	if len(r.BreakGlassKey) == 0 {
+		return nil, errors.New("break-glass tokens are not enabled, the controller has no --break-glass-key-file")
+	}
+
+	token, err := parseBreakGlassToken(r.BreakGlassKey, s, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if token.Namespace != terraform.Namespace || token.Name != terraform.Name {
+		return nil, fmt.Errorf("token issued for %s/%s", token.Namespace, token.Name)
+	}
+	if breakGlassTokenUsed(terraform, token.ID) {
+		return nil, fmt.Errorf("token %s already used", token.ID)
+	}
+	return token, nil
+}
+
+// breakGlassTokenUsed reports whether the token has already applied a plan of the object.
+func breakGlassTokenUsed(terraform infrav1.Terraform, id string) bool {
+	if terraform.Status.BreakGlass != nil && terraform.Status.BreakGlass.TokenID == id {
+		return true
+	}
+	for _, used := range terraform.Status.UsedBreakGlassTokens {
+		if used.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneUsedBreakGlassTokens drops the used tokens which have expired, and can't be used again anyway.
+func pruneUsedBreakGlassTokens(tokens []infrav1.UsedBreakGlassToken, now time.Time) []infrav1.UsedBreakGlassToken {
+	var pruned []infrav1.UsedBreakGlassToken
+	for _, used := range tokens {
+		if !now.After(used.ExpiresAt.Time) {
+			pruned = append(pruned, used)
+		}
+	}
+	return pruned
+}
+
+// breakGlassActive reports whether the object has a break-glass token allowed to bypass the gates of the apply.
+// A rejected token is logged, and the gates apply as usual.
+func (r *TerraformReconciler) breakGlassActive(ctx context.Context, terraform infrav1.Terraform) bool {
+	token, err := r.breakGlassToken(terraform)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "break-glass token rejected")
+		return false
+	}
+	return token != nil
+}
+
+// breakGlassApproves reports whether the object has a break-glass token approving its pending plan.
+func (r *TerraformReconciler) breakGlassApproves(terraform infrav1.Terraform) bool {
+	token, err := r.breakGlassToken(terraform)
+	if err != nil || token == nil || terraform.Status.Plan.Pending == "" {
+		return false
+	}
+	return token.Plan == "" || infrav1.PlanIdMatches(token.Plan, terraform.Status.Plan.Pending, terraform.Status.LastPlannedRevision)
+}
+
+// useBreakGlassToken records the break-glass token applying the pending plan in the status, so that it is not used again until it expires,
+// and emits the audit event of the bypass. It returns false when the object has no usable token.
+func (r *TerraformReconciler) useBreakGlassToken(ctx context.Context, terraform *infrav1.Terraform, revision string) bool {
+	if !r.breakGlassApproves(*terraform) {
+		return false
+	}
+	token, _ := r.breakGlassToken(*terraform)
+
+	now := metav1.Now()
+	terraform.Status.BreakGlass = &infrav1.BreakGlassStatus{
+		TokenID: token.ID,
+		User:    token.User,
+		Reason:  token.Reason,
+		Plan:    terraform.Status.Plan.Pending,
+		UsedAt:  now,
+	}
+	terraform.Status.UsedBreakGlassTokens = append(pruneUsedBreakGlassTokens(terraform.Status.UsedBreakGlassTokens, now.Time),
+		infrav1.UsedBreakGlassToken{ID: token.ID, ExpiresAt: metav1.NewTime(time.Unix(token.ExpiresAt, 0))})
+
+	msg := fmt.Sprintf("BREAK-GLASS: plan %s is applied with the token %s of %s, bypassing the approval and the gates of the apply: %s",
+		terraform.Status.Plan.Pending, token.ID, token.User, token.Reason)
+	ctrl.LoggerFrom(ctx).Info(msg, "audit", true, "user", token.User, "token", token.ID)
+	metadata := eventMetadata(*terraform, revision, map[string]string{
+		infrav1.EventMetadataBreakGlassTokenKey: token.ID,
+		infrav1.EventMetadataBreakGlassUserKey:  token.User,
+	})
+	if r.EventRecorder != nil {
+		r.EventRecorder.AnnotatedEventf(terraform, metadata, "Warning", BreakGlassEventReason, "%s", msg)
+	}
+	return true
+}
+
+// BreakGlassTokenPredicate triggers when the break-glass token annotation changes, so that the token is used right away.
+type BreakGlassTokenPredicate struct {
+	predicate.Funcs
+}
+
+func (BreakGlassTokenPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[infrav1.BreakGlassTokenAnnotation] != e.ObjectOld.GetAnnotations()[infrav1.BreakGlassTokenAnnotation]
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBreakGlassToken(t *testing.T) {
+	g := NewWithT(t)
+	key := []byte("break-glass-key")
+	now := time.Now()
+
+	token := BreakGlassToken{ID: "1234", Namespace: "flux-system", Name: "helloworld", User: "admin", Reason: "incident", ExpiresAt: now.Add(time.Minute).Unix()}
+	signed, err := signBreakGlassToken(key, token)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	parsed, err := parseBreakGlassToken(key, signed, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*parsed).To(Equal(token))
+
+	_, err = parseBreakGlassToken([]byte("another-key"), signed, now)
+	g.Expect(err).To(MatchError("invalid signature"))
+	_, err = parseBreakGlassToken(key, signed, now.Add(2*time.Minute))
+	g.Expect(err).To(MatchError(ContainSubstring("expired")))
+
+	// the payload can't be changed without the key
+	token.Name = "other"
+	forged, err := signBreakGlassToken([]byte("another-key"), token)
+	g.Expect(err).ToNot(HaveOccurred())
+	forged = forged[:strings.LastIndex(forged, ".")] + signed[strings.LastIndex(signed, "."):]
+	_, err = parseBreakGlassToken(key, forged, now)
+	g.Expect(err).To(MatchError("invalid signature"))
+}
+
+func TestBreakGlassApply(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	key := []byte("break-glass-key")
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	signed, err := signBreakGlassToken(key, BreakGlassToken{
+		ID: "1234", Namespace: "flux-system", Name: "helloworld", User: "admin", Reason: "incident", ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "helloworld",
+			Namespace:   "flux-system",
+			Annotations: map[string]string{infrav1.BreakGlassTokenAnnotation: signed},
+		},
+		Spec: infrav1.TerraformSpec{SuspendApply: true},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).Build(),
+		EventRecorder: recorder,
+	}
+
+	// the token is rejected without the key of the controller
+	g.Expect(r.shouldApply(terraform)).To(BeFalse())
+
+	r.BreakGlassKey = key
+	g.Expect(r.shouldApply(terraform)).To(BeTrue())
+	reason, _, err := r.applyBlocked(ctx, terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(BeEmpty())
+
+	g.Expect(r.useBreakGlassToken(ctx, &terraform, "main/b8e362c206")).To(BeTrue())
+	g.Expect(terraform.Status.BreakGlass.TokenID).To(Equal("1234"))
+	g.Expect(terraform.Status.BreakGlass.User).To(Equal("admin"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("BREAK-GLASS")))
+
+	// the token is used only once
+	g.Expect(r.shouldApply(terraform)).To(BeFalse())
+	reason, _, err = r.applyBlocked(ctx, terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(Equal(infrav1.ApplySuspendedReason))
+}
+
+func TestBreakGlassTokenReused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	key := []byte("break-glass-key")
+
+	sign := func(id string, expiresAt time.Time) string {
+		signed, err := signBreakGlassToken(key, BreakGlassToken{
+			ID: id, Namespace: "flux-system", Name: "helloworld", User: "admin", Reason: "incident", ExpiresAt: expiresAt.Unix(),
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		return signed
+	}
+	tokenA := sign("a", time.Now().Add(10*time.Minute))
+	tokenB := sign("b", time.Now().Add(10*time.Minute))
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec:       infrav1.TerraformSpec{SuspendApply: true},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"},
+			UsedBreakGlassTokens: []infrav1.UsedBreakGlassToken{
+				{ID: "expired", ExpiresAt: metav1.NewTime(time.Now().Add(-time.Minute))},
+			},
+		},
+	}
+	r := &TerraformReconciler{BreakGlassKey: key, EventRecorder: record.NewFakeRecorder(10)}
+
+	terraform.SetAnnotations(map[string]string{infrav1.BreakGlassTokenAnnotation: tokenA})
+	g.Expect(r.useBreakGlassToken(ctx, &terraform, "main/b8e362c206")).To(BeTrue())
+	terraform.Status.Plan.Pending = "plan-main-c9f473d317"
+	terraform.SetAnnotations(map[string]string{infrav1.BreakGlassTokenAnnotation: tokenB})
+	g.Expect(r.useBreakGlassToken(ctx, &terraform, "main/c9f473d317")).To(BeTrue())
+	g.Expect(terraform.Status.BreakGlass.TokenID).To(Equal("b"))
+
+	// the earlier token, put back in the annotation, does not authorize another apply
+	terraform.Status.Plan.Pending = "plan-main-d0a584e428"
+	terraform.SetAnnotations(map[string]string{infrav1.BreakGlassTokenAnnotation: tokenA})
+	_, err := r.breakGlassToken(terraform)
+	g.Expect(err).To(MatchError("token a already used"))
+	g.Expect(r.useBreakGlassToken(ctx, &terraform, "main/d0a584e428")).To(BeFalse())
+	g.Expect(r.shouldApply(terraform)).To(BeFalse())
+
+	// the used tokens are kept until they expire
+	g.Expect(terraform.Status.UsedBreakGlassTokens).To(HaveLen(2))
+	g.Expect(terraform.Status.UsedBreakGlassTokens[0].ID).To(Equal("a"))
+	g.Expect(terraform.Status.UsedBreakGlassTokens[1].ID).To(Equal("b"))
+	g.Expect(pruneUsedBreakGlassTokens(terraform.Status.UsedBreakGlassTokens, time.Now().Add(time.Hour))).To(BeEmpty())
+}
+
+func TestApproverAPIBreakGlass(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}},
+	).Build()
+
+	var lastAttributes authorizationv1.ResourceAttributes
+	server := &ApproverAPIServer{
+		Client:        cli,
+		BreakGlassKey: []byte("break-glass-key"),
+		authorize: func(_ context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+			lastAttributes = attributes
+			return "admin", nil
+		},
+	}
+	handler := server.Handler()
+	do := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/terraforms/flux-system/helloworld/break-glass", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(`{"reason":"incident 42","ttl":"5m"}`)
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(lastAttributes.Verb).To(Equal("create"))
+	g.Expect(lastAttributes.Subresource).To(Equal("breakglass"))
+
+	var reply BreakGlassResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &reply)).To(Succeed())
+	g.Expect(reply.Annotation).To(Equal(infrav1.BreakGlassTokenAnnotation))
+	token, err := parseBreakGlassToken(server.BreakGlassKey, reply.Token, time.Now())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(token.User).To(Equal("admin"))
+	g.Expect(token.Reason).To(Equal("incident 42"))
+	g.Expect(token.ExpiresAt).To(BeNumerically("<=", time.Now().Add(5*time.Minute).Unix()))
+
+	g.Expect(do(`{}`).Code).To(Equal(http.StatusBadRequest))
+	g.Expect(do(`{"reason":"incident","ttl":"2h"}`).Code).To(Equal(http.StatusBadRequest))
+}
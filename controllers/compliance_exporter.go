@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ComplianceSnapshotAlgorithm is the algorithm of the signatures of the compliance snapshots.
+const ComplianceSnapshotAlgorithm = "ed25519"
+
+// ComplianceSnapshot is the record of a Terraform object written to the bucket of the compliance exports.
+type ComplianceSnapshot struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+	Generation int64  `json:"generation"`
+	// SpecHash is the SHA-256 of the JSON of the spec of the object.
+	SpecHash            string       `json:"specHash"`
+	LastAppliedRevision string       `json:"lastAppliedRevision,omitempty"`
+	LastAppliedPlan     string       `json:"lastAppliedPlan,omitempty"`
+	LastApplyAt         *metav1.Time `json:"lastApplyAt,omitempty"`
+	// ApprovePlan is the spec.approvePlan of the object, and ApprovedBy the approvers of its plan outside of it.
+	ApprovePlan string `json:"approvePlan,omitempty"`
+	ApprovedBy  string `json:"approvedBy,omitempty"`
+	Ready       string `json:"ready"`
+	// Inventory counts the resources of the inventory by type.
+	Inventory  map[string]int `json:"inventory,omitempty"`
+	ExportedAt metav1.Time    `json:"exportedAt"`
+}
+
+// SignedComplianceSnapshot is a snapshot with the signature of its payload, the JSON of the snapshot encoded in base64.
+type SignedComplianceSnapshot struct {
+	Payload   string `json:"payload"`
+	Algorithm string `json:"algorithm"`
+	Signature string `json:"signature"`
+}
+
+// ComplianceStore stores the compliance snapshots.
+type ComplianceStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// ComplianceExporter periodically writes a signed snapshot of each Terraform object to a ComplianceStore,
+// under <prefix>/<date>/<namespace>/<name>/<time>.json, so that the audits can rely on records kept
+// outside of the cluster, and verify them with the public key of the exporter.
+type ComplianceExporter struct {
+	client.Client
+	Store      ComplianceStore
+	Prefix     string
+	SigningKey ed25519.PrivateKey
+	Interval   time.Duration
+}
+
+// Start implements manager.Runnable.
+func (e *ComplianceExporter) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("compliance-exporter")
+
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Export(ctx, time.Now()); err != nil {
+			log.Error(err, "unable to export the compliance snapshots")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (e *ComplianceExporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Export writes the snapshots of all the Terraform objects. A snapshot failing to be written does not stop the others.
+func (e *ComplianceExporter) Export(ctx context.Context, now time.Time) error {
+	var tfList infrav1.TerraformList
+	if err := e.List(ctx, &tfList); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, terraform := range tfList.Items {
+		signed, err := e.sign(complianceSnapshot(terraform, now))
+		if err == nil {
+			err = e.Store.Put(ctx, e.snapshotKey(terraform, now), signed)
+		}
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "unable to export the compliance snapshot", "namespace", terraform.Namespace, "name", terraform.Name)
+			failed = append(failed, terraform.Namespace+"/"+terraform.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to export the compliance snapshots of %d objects: %v", len(failed), failed)
+	}
+	return nil
+}
+
+func (e *ComplianceExporter) snapshotKey(terraform infrav1.Terraform, now time.Time) string {
+	now = now.UTC()
+	return path.Join(e.Prefix, now.Format("2006-01-02"), terraform.Namespace, terraform.Name, now.Format("20060102T150405Z")+".json")
+}
+
+func (e *ComplianceExporter) sign(snapshot ComplianceSnapshot) ([]byte, error) {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(SignedComplianceSnapshot{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Algorithm: ComplianceSnapshotAlgorithm,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(e.SigningKey, payload)),
+	})
+}
+
+// VerifyComplianceSnapshot returns the snapshot of a signed snapshot, if its signature is valid for the public key.
+func VerifyComplianceSnapshot(publicKey ed25519.PublicKey, data []byte) (*ComplianceSnapshot, error) {
+	var signed SignedComplianceSnapshot
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("malformed snapshot: %w", err)
+	}
+	if signed.Algorithm != ComplianceSnapshotAlgorithm {
+		return nil, fmt.Errorf("unsupported algorithm %q", signed.Algorithm)
+	}
+	payload, err := base64.StdEncoding.DecodeString(signed.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed payload: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	if !ed25519.Verify(publicKey, payload, signature) {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	var snapshot ComplianceSnapshot
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		return nil, fmt.Errorf("malformed payload: %w", err)
+	}
+	return &snapshot, nil
+}
+
+func complianceSnapshot(terraform infrav1.Terraform, now time.Time) ComplianceSnapshot {
+	spec, _ := json.Marshal(terraform.Spec)
+	sum := sha256.Sum256(spec)
+
+	ready := string(metav1.ConditionUnknown)
+	if condition := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); condition != nil {
+		ready = string(condition.Status) + "/" + condition.Reason
+	}
+
+	snapshot := ComplianceSnapshot{
+		Namespace:           terraform.Namespace,
+		Name:                terraform.Name,
+		UID:                 string(terraform.UID),
+		Generation:          terraform.Generation,
+		SpecHash:            hex.EncodeToString(sum[:]),
+		LastAppliedRevision: terraform.Status.LastAppliedRevision,
+		LastAppliedPlan:     terraform.Status.Plan.LastApplied,
+		LastApplyAt:         terraform.Status.LastApplyAt,
+		ApprovePlan:         terraform.Spec.ApprovePlan,
+		ApprovedBy:          terraform.Status.Plan.ApprovedBy,
+		Ready:               ready,
+		ExportedAt:          metav1.NewTime(now.UTC().Truncate(time.Second)),
+	}
+	if inventory := terraform.Status.Inventory; inventory != nil && len(inventory.Entries) > 0 {
+		snapshot.Inventory = map[string]int{}
+		for _, entry := range inventory.Entries {
+			snapshot.Inventory[entry.Type]++
+		}
+	}
+	return snapshot
+}
+
+// ParseComplianceSigningKey parses the PEM encoded PKCS #8 ed25519 private key signing the snapshots,
+// e.g. generated with openssl genpkey -algorithm ed25519.
+func ParseComplianceSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ed25519 key, got %T", key)
+	}
+	return signingKey, nil
+}
+
+// S3ComplianceStore stores the compliance snapshots in a bucket of Amazon S3, or of a storage compatible with S3.
+type S3ComplianceStore struct {
+	Client *s3.Client
+	Bucket string
+}
+
+// NewS3ComplianceStore returns the store of a bucket, with the credentials and the region of the environment
+// of the controller, e.g. AWS_REGION and the web identity of its service account. An endpoint replaces Amazon S3.
+func NewS3ComplianceStore(ctx context.Context, bucket, endpoint string) (*S3ComplianceStore, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.EndpointResolver = s3.EndpointResolverFromURL(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &S3ComplianceStore{Client: s3Client, Bucket: bucket}, nil
+}
+
+func (s *S3ComplianceStore) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.Bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
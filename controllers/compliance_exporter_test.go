@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type memoryComplianceStore struct {
+	objects map[string][]byte
+	fail    string
+}
+
+func (s *memoryComplianceStore) Put(ctx context.Context, key string, data []byte) error {
+	if key == s.fail {
+		return fmt.Errorf("access denied")
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func TestComplianceExporter_Export(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	publicKey, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	network := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "team-a", Generation: 3},
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan: "plan-main-b8e362c206",
+			Path:        "./network",
+		},
+		Status: infrav1.TerraformStatus{
+			Conditions: []metav1.Condition{{
+				Type:               "Ready",
+				Status:             metav1.ConditionTrue,
+				Reason:             infrav1.TFExecApplySucceedReason,
+				LastTransitionTime: metav1.Now(),
+			}},
+			LastAppliedRevision: "main/b8e362c206",
+			Plan: infrav1.PlanStatus{
+				LastApplied: "plan-main-b8e362c206",
+				ApprovedBy:  "alice",
+			},
+			Inventory: &infrav1.ResourceInventory{Entries: []infrav1.ResourceRef{
+				{Name: "private", Type: "aws_subnet"},
+				{Name: "public", Type: "aws_subnet"},
+				{Name: "main", Type: "aws_vpc"},
+			}},
+		},
+	}
+	db := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b"},
+	}
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(network, db).Build()
+
+	store := &memoryComplianceStore{objects: map[string][]byte{}}
+	exporter := &ComplianceExporter{Client: cli, Store: store, Prefix: "audit", SigningKey: signingKey}
+	now := time.Date(2023, 2, 1, 10, 5, 30, 0, time.UTC)
+	g.Expect(exporter.Export(ctx, now)).To(Succeed())
+	g.Expect(store.objects).To(HaveLen(2))
+	g.Expect(store.objects).To(HaveKey("audit/2023-02-01/team-b/db/20230201T100530Z.json"))
+
+	data := store.objects["audit/2023-02-01/team-a/network/20230201T100530Z.json"]
+	snapshot, err := VerifyComplianceSnapshot(publicKey, data)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	spec, _ := json.Marshal(network.Spec)
+	sum := sha256.Sum256(spec)
+	g.Expect(snapshot.SpecHash).To(Equal(hex.EncodeToString(sum[:])))
+	g.Expect(snapshot.Generation).To(Equal(int64(3)))
+	g.Expect(snapshot.LastAppliedRevision).To(Equal("main/b8e362c206"))
+	g.Expect(snapshot.LastAppliedPlan).To(Equal("plan-main-b8e362c206"))
+	g.Expect(snapshot.ApprovedBy).To(Equal("alice"))
+	g.Expect(snapshot.Ready).To(Equal("True/" + infrav1.TFExecApplySucceedReason))
+	g.Expect(snapshot.Inventory).To(Equal(map[string]int{"aws_subnet": 2, "aws_vpc": 1}))
+	g.Expect(snapshot.ExportedAt.Time).To(BeTemporally("==", now))
+
+	dbSnapshot, err := VerifyComplianceSnapshot(publicKey, store.objects["audit/2023-02-01/team-b/db/20230201T100530Z.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dbSnapshot.Ready).To(Equal("Unknown"))
+	g.Expect(dbSnapshot.Inventory).To(BeNil())
+
+	// the snapshot is rejected once its payload is changed, or with another key
+	var signed SignedComplianceSnapshot
+	g.Expect(json.Unmarshal(data, &signed)).To(Succeed())
+	tampered := *snapshot
+	tampered.ApprovedBy = "mallory"
+	payload, _ := json.Marshal(tampered)
+	signed.Payload = base64.StdEncoding.EncodeToString(payload)
+	tamperedData, _ := json.Marshal(signed)
+	_, err = VerifyComplianceSnapshot(publicKey, tamperedData)
+	g.Expect(err).To(MatchError("invalid signature"))
+
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	_, err = VerifyComplianceSnapshot(otherKey, data)
+	g.Expect(err).To(MatchError("invalid signature"))
+
+	// a failing snapshot does not stop the others
+	store = &memoryComplianceStore{objects: map[string][]byte{}, fail: "audit/2023-02-01/team-b/db/20230201T100530Z.json"}
+	exporter.Store = store
+	err = exporter.Export(ctx, now)
+	g.Expect(err).To(MatchError(ContainSubstring("team-b/db")))
+	g.Expect(store.objects).To(HaveLen(1))
+}
+
+func TestParseComplianceSigningKey(t *testing.T) {
+	g := NewWithT(t)
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	der, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	parsed, err := ParseComplianceSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(parsed.Equal(signingKey)).To(BeTrue())
+
+	_, err = ParseComplianceSigningKey([]byte("not a key"))
+	g.Expect(err).To(MatchError("no PEM block found"))
+}
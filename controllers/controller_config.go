@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// ControllerConfig holds the settings of the controller that operators can tune
+// at runtime, by editing the config file (usually mounted from a ConfigMap).
+type ControllerConfig struct {
+	// RunnerImage is the default image of the runner pods,
+	// overridden by .spec.runnerPodTemplate.spec.image.
+	RunnerImage string `json:"runnerImage,omitempty"`
+
+	// MaxConcurrentRuns limits the number of Terraform objects being reconciled
+	// with a runner at the same time. Zero means no limit other than --concurrent.
+	MaxConcurrentRuns int `json:"maxConcurrentRuns,omitempty"`
+
+	// RequeueJitterPercent spreads the requeues of the objects by adding
+	// up to this percentage of the interval. Zero disables the jitter.
+	RequeueJitterPercent int `json:"requeueJitterPercent,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces of the Terraform objects
+	// to reconcile. Empty means all namespaces. The objects reconciled before
+	// their namespace was disallowed are still deleted.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// DefaultRetryInterval is used for objects without .spec.retryInterval,
+	// instead of their .spec.interval.
+	DefaultRetryInterval *metav1.Duration `json:"defaultRetryInterval,omitempty"`
+
+	// DefaultMaxConsecutiveFailures is used for objects without .spec.maxConsecutiveFailures.
+	// Zero never stalls the objects.
+	DefaultMaxConsecutiveFailures int32 `json:"defaultMaxConsecutiveFailures,omitempty"`
+
+	// LogLevel overrides --log-level while it is set,
+	// e.g. to enable trace logging for a troubleshooting session.
+	// Can be one of 'trace', 'debug', 'info', 'error'.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// NamespaceResourceLimits are resource limits enforced for every Terraform object
+	// of a namespace, keyed by namespace. Their maxManagedResources applies to
+	// the total of the managed resources of all the objects of the namespace.
+	NamespaceResourceLimits map[string]infrav1.ResourceLimits `json:"namespaceResourceLimits,omitempty"`
+
+	// MaxArtifactFiles limits the number of files and symlinks extracted by the runners
+	// from the source artifacts. Zero means no limit.
+	MaxArtifactFiles int64 `json:"maxArtifactFiles,omitempty"`
+
+	// MaxArtifactSize limits the total size of the files extracted by the runners
+	// from the source artifacts, e.g. 512Mi. Unset means no limit.
+	MaxArtifactSize *resource.Quantity `json:"maxArtifactSize,omitempty"`
+
+	// MaxPlanSize limits the size of the plans, compressed, stored in Secrets.
+	// Planning fails beyond it, as a truncated plan can't be applied. Defaults to 1000Ki.
+	MaxPlanSize *resource.Quantity `json:"maxPlanSize,omitempty"`
+
+	// MaxReadablePlanSize limits the size of the readable plans of .spec.storeReadablePlan,
+	// truncated beyond it. Defaults to 1000Ki.
+	MaxReadablePlanSize *resource.Quantity `json:"maxReadablePlanSize,omitempty"`
+
+	// MaxOutputsSize limits the size of the outputs written to the Secret of .spec.writeOutputsToSecret.
+	// The largest outputs are left out of the Secret beyond it. Defaults to 1000Ki.
+	MaxOutputsSize *resource.Quantity `json:"maxOutputsSize,omitempty"`
+
+	// MaxConditionMessageLength is the length of the longest condition message kept whole in the status.
+	// Longer messages are stored in the messages ConfigMap of the object. Defaults to 2048.
+	MaxConditionMessageLength int `json:"maxConditionMessageLength,omitempty"`
+
+	// ConditionMessageTailLines is the number of the last lines of a long message kept in its condition,
+	// where Terraform reports its errors. Defaults to 20.
+	ConditionMessageTailLines int `json:"conditionMessageTailLines,omitempty"`
+}
+
+// defaultMaxStoredSize leaves room for the metadata under the 1MiB limit of Secrets and ConfigMaps.
+const defaultMaxStoredSize = 1000 * 1024
+
+func (c ControllerConfig) validate() error {
+	if c.MaxConcurrentRuns < 0 {
+		return fmt.Errorf("maxConcurrentRuns must not be negative")
+	}
+	if c.RequeueJitterPercent < 0 || c.RequeueJitterPercent > 100 {
+		return fmt.Errorf("requeueJitterPercent must be between 0 and 100")
+	}
+	if c.DefaultMaxConsecutiveFailures < 0 {
+		return fmt.Errorf("defaultMaxConsecutiveFailures must not be negative")
+	}
+	for ns, limits := range c.NamespaceResourceLimits {
+		if limits.MaxManagedResources != nil && *limits.MaxManagedResources < 0 {
+			return fmt.Errorf("namespaceResourceLimits.%s.maxManagedResources must not be negative", ns)
+		}
+	}
+	if c.MaxConditionMessageLength < 0 {
+		return fmt.Errorf("maxConditionMessageLength must not be negative")
+	}
+	if c.ConditionMessageTailLines < 0 {
+		return fmt.Errorf("conditionMessageTailLines must not be negative")
+	}
+	if c.MaxArtifactFiles < 0 {
+		return fmt.Errorf("maxArtifactFiles must not be negative")
+	}
+	for name, size := range map[string]*resource.Quantity{
+		"maxArtifactSize":     c.MaxArtifactSize,
+		"maxPlanSize":         c.MaxPlanSize,
+		"maxReadablePlanSize": c.MaxReadablePlanSize,
+		"maxOutputsSize":      c.MaxOutputsSize,
+	} {
+		if size != nil && size.Sign() < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	switch c.LogLevel {
+	case "", "trace", "debug", "info", "error":
+	default:
+		return fmt.Errorf("logLevel must be one of 'trace', 'debug', 'info', 'error'")
+	}
+	return nil
+}
+
+// IsNamespaceAllowed returns true if objects in the namespace can be reconciled.
+func (c ControllerConfig) IsNamespaceAllowed(namespace string) bool {
+	if len(c.AllowedNamespaces) == 0 {
+		return true
+	}
+	for _, ns := range c.AllowedNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactLimits returns the limits of the extraction of the source artifacts by the runners.
+func (c ControllerConfig) artifactLimits() (maxFiles int64, maxSize int64) {
+	if c.MaxArtifactSize != nil {
+		maxSize = c.MaxArtifactSize.Value()
+	}
+	return c.MaxArtifactFiles, maxSize
+}
+
+// conditionMessageLimits returns the length of the longest condition message kept whole in the status,
+// and the number of lines of the tail of the longer messages.
+func (c ControllerConfig) conditionMessageLimits() (maxLength int, tailLines int) {
+	maxLength, tailLines = defaultMaxConditionMessageLength, defaultConditionMessageTailLines
+	if c.MaxConditionMessageLength > 0 {
+		maxLength = c.MaxConditionMessageLength
+	}
+	if c.ConditionMessageTailLines > 0 {
+		tailLines = c.ConditionMessageTailLines
+	}
+	return maxLength, tailLines
+}
+
+// maxStoredSize returns the size in bytes of q, or defaultMaxStoredSize if it is not set.
+func maxStoredSize(q *resource.Quantity) int64 {
+	if q == nil {
+		return defaultMaxStoredSize
+	}
+	return q.Value()
+}
+
+// LoadControllerConfig reads and validates the ControllerConfig from a YAML file.
+func LoadControllerConfig(path string) (ControllerConfig, []byte, error) {
+	var config ControllerConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, nil, err
+	}
+
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return config, nil, fmt.Errorf("unable to parse controller config %s: %w", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return config, nil, fmt.Errorf("invalid controller config %s: %w", path, err)
+	}
+
+	return config, data, nil
+}
+
+// ControllerConfigWatcher keeps the ControllerConfig in sync with its file.
+// ConfigMap volumes are updated by swapping a symlink, so the file is polled
+// rather than watched with inotify.
+type ControllerConfigWatcher struct {
+	Path     string
+	Interval time.Duration
+	// OnChange, if set, is called with the new config each time the file changes.
+	OnChange func(ControllerConfig)
+
+	mu     sync.RWMutex
+	config ControllerConfig
+	data   []byte
+}
+
+// NewControllerConfigWatcher loads the config file, failing if it can't be used.
+func NewControllerConfigWatcher(path string, interval time.Duration) (*ControllerConfigWatcher, error) {
+	config, data, err := LoadControllerConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerConfigWatcher{Path: path, Interval: interval, config: config, data: data}, nil
+}
+
+// Get returns the current ControllerConfig, it is nil-safe.
+func (w *ControllerConfigWatcher) Get() ControllerConfig {
+	if w == nil {
+		return ControllerConfig{}
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config
+}
+
+// Start implements manager.Runnable.
+func (w *ControllerConfigWatcher) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("controller-config")
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			config, data, err := LoadControllerConfig(w.Path)
+			if err != nil {
+				// keep running with the last good config
+				log.Error(err, "unable to reload controller config")
+				continue
+			}
+
+			w.mu.Lock()
+			changed := !bytes.Equal(data, w.data)
+			w.config, w.data = config, data
+			w.mu.Unlock()
+
+			if changed {
+				log.Info("controller config reloaded", "path", w.Path)
+				if w.OnChange != nil {
+					w.OnChange(config)
+				}
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable,
+// every replica has to follow the config.
+func (w *ControllerConfigWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// retryInterval returns the interval to retry a failed reconciliation of the object.
+func (r *TerraformReconciler) retryInterval(terraform infrav1.Terraform) time.Duration {
+	if terraform.Spec.RetryInterval == nil {
+		if defaultRetryInterval := r.Config.Get().DefaultRetryInterval; defaultRetryInterval != nil {
+			return r.withJitter(defaultRetryInterval.Duration)
+		}
+	}
+	return r.withJitter(terraform.GetRetryInterval())
+}
+
+// retryResult requeues a blocked or failed reconciliation of the object at the retry interval,
+// or not at all when the object is reconciled manually, to wait for the next change or request.
+func (r *TerraformReconciler) retryResult(terraform infrav1.Terraform) ctrl.Result {
+	if terraform.IsManualReconciliation() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: r.retryInterval(terraform)}
+}
+
+// withJitter adds a random duration of up to RequeueJitterPercent of d.
+func (r *TerraformReconciler) withJitter(d time.Duration) time.Duration {
+	percent := r.Config.Get().RequeueJitterPercent
+	if percent <= 0 || d <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(d)*int64(percent)/100+1))
+}
+
+// acquireRun reserves one of the MaxConcurrentRuns slots. It returns false if none is available.
+func (r *TerraformReconciler) acquireRun() bool {
+	limit := r.Config.Get().MaxConcurrentRuns
+
+	r.runsMu.Lock()
+	defer r.runsMu.Unlock()
+	if limit > 0 && r.activeRuns >= limit {
+		return false
+	}
+	r.activeRuns++
+	return true
+}
+
+func (r *TerraformReconciler) releaseRun() {
+	r.runsMu.Lock()
+	defer r.runsMu.Unlock()
+	r.activeRuns--
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadControllerConfig(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	g.Expect(os.WriteFile(path, []byte(`
+runnerImage: ghcr.io/weaveworks/tf-runner:custom
+maxConcurrentRuns: 2
+allowedNamespaces:
+- flux-system
+defaultRetryInterval: 30s
+defaultMaxConsecutiveFailures: 5
+maxArtifactFiles: 1000
+maxArtifactSize: 1Mi
+maxPlanSize: 2Mi
+`), 0600)).To(Succeed())
+
+	config, _, err := LoadControllerConfig(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(config.RunnerImage).To(Equal("ghcr.io/weaveworks/tf-runner:custom"))
+	g.Expect(config.MaxConcurrentRuns).To(Equal(2))
+	g.Expect(config.IsNamespaceAllowed("flux-system")).To(BeTrue())
+	g.Expect(config.IsNamespaceAllowed("default")).To(BeFalse())
+
+	watcher, err := NewControllerConfigWatcher(path, time.Second)
+	g.Expect(err).To(BeNil())
+	r := &TerraformReconciler{Config: watcher}
+	g.Expect(r.retryInterval(infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{Interval: metav1.Duration{Duration: time.Minute}},
+	})).To(Equal(30 * time.Second))
+	g.Expect(getRunnerPodImage("", r.Config.Get().RunnerImage)).To(Equal("ghcr.io/weaveworks/tf-runner:custom"))
+	g.Expect(r.maxConsecutiveFailures(infrav1.Terraform{})).To(Equal(int32(5)))
+	maxFiles, maxSize := r.Config.Get().artifactLimits()
+	g.Expect(maxFiles).To(Equal(int64(1000)))
+	g.Expect(maxSize).To(Equal(int64(1 << 20)))
+	g.Expect(maxStoredSize(r.Config.Get().MaxPlanSize)).To(Equal(int64(2 << 20)))
+	g.Expect(maxStoredSize(r.Config.Get().MaxOutputsSize)).To(Equal(int64(defaultMaxStoredSize)))
+
+	g.Expect(r.acquireRun()).To(BeTrue())
+	g.Expect(r.acquireRun()).To(BeTrue())
+	g.Expect(r.acquireRun()).To(BeFalse())
+	r.releaseRun()
+	g.Expect(r.acquireRun()).To(BeTrue())
+
+	g.Expect(os.WriteFile(path, []byte("maxConcurrentRuns: -1\n"), 0600)).To(Succeed())
+	_, _, err = LoadControllerConfig(path)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.WriteFile(path, []byte("defaultMaxConsecutiveFailures: -1\n"), 0600)).To(Succeed())
+	_, _, err = LoadControllerConfig(path)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.WriteFile(path, []byte("maxArtifactSize: -1Mi\n"), 0600)).To(Succeed())
+	_, _, err = LoadControllerConfig(path)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.WriteFile(path, []byte("logLevel: verbose\n"), 0600)).To(Succeed())
+	_, _, err = LoadControllerConfig(path)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.WriteFile(path, []byte("unknownSetting: true\n"), 0600)).To(Succeed())
+	_, _, err = LoadControllerConfig(path)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestControllerConfigJitter(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{}
+	g.Expect(r.withJitter(time.Minute)).To(Equal(time.Minute))
+
+	r.Config = &ControllerConfigWatcher{config: ControllerConfig{RequeueJitterPercent: 10}}
+	for i := 0; i < 10; i++ {
+		d := r.withJitter(time.Minute)
+		g.Expect(d).To(BeNumerically(">=", time.Minute))
+		g.Expect(d).To(BeNumerically("<=", time.Minute+6*time.Second))
+	}
+}
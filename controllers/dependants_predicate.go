@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+// DependantFinalizerRemovedPredicate triggers when a dependant of an object being deleted
+// removes its finalizer from the object, once the dependant is finalized,
+// so that the object resumes its deletion without waiting for its retry interval.
+type DependantFinalizerRemovedPredicate struct {
+	predicate.Funcs
+}
+
+func (DependantFinalizerRemovedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	if e.ObjectNew.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+
+	return countDependantFinalizers(e.ObjectNew.GetFinalizers()) < countDependantFinalizers(e.ObjectOld.GetFinalizers())
+}
+
+func countDependantFinalizers(finalizers []string) int {
+	count := 0
+	for _, finalizer := range finalizers {
+		if strings.HasPrefix(finalizer, infrav1.TFDependencyOfPrefix) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestDependantFinalizerRemovedPredicate_Update(t *testing.T) {
+	g := NewWithT(t)
+	predicate := DependantFinalizerRemovedPredicate{}
+
+	now := metav1.Now()
+	terraform := func(deleted bool, finalizers ...string) *infrav1.Terraform {
+		tf := &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "flux-system", Finalizers: finalizers},
+		}
+		if deleted {
+			tf.DeletionTimestamp = &now
+		}
+		return tf
+	}
+
+	g.Expect(predicate.Update(event.UpdateEvent{})).To(BeFalse())
+
+	// a dependant is done with its own deletion
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: terraform(true, infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix+"cluster", infrav1.TFDependencyOfPrefix+"apps"),
+		ObjectNew: terraform(true, infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix+"cluster"),
+	})).To(BeTrue())
+
+	// the object is not being deleted, nothing is blocked
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: terraform(false, infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix+"cluster"),
+		ObjectNew: terraform(false, infrav1.TerraformFinalizer),
+	})).To(BeFalse())
+
+	// a new dependant
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: terraform(true, infrav1.TerraformFinalizer),
+		ObjectNew: terraform(true, infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix+"cluster"),
+	})).To(BeFalse())
+
+	// the finalizer of the controller itself
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: terraform(true, infrav1.TerraformFinalizer),
+		ObjectNew: terraform(true),
+	})).To(BeFalse())
+}
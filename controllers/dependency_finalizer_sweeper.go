@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const StaleDependencyFinalizerEventReason = "StaleDependencyFinalizer"
+
+// DependencyFinalizerSweeper removes the tf.dependency.of.* finalizers naming dependants which no longer
+// depend on the object, at startup and then periodically. A dependant removes its finalizers from its
+// dependencies when it is finalized, so they are left behind when it is force-deleted, or when its
+// dependsOn changes, and would block the deletion of the dependencies forever.
+type DependencyFinalizerSweeper struct {
+	client.Client
+	EventRecorder kuberecorder.EventRecorder
+	Interval      time.Duration
+}
+
+// Start implements manager.Runnable.
+func (s *DependencyFinalizerSweeper) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("dependency-finalizer-sweeper")
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sweep(ctx); err != nil {
+			log.Error(err, "unable to sweep the stale dependency finalizers")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *DependencyFinalizerSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep removes the stale dependency finalizers of all the Terraform objects.
+func (s *DependencyFinalizerSweeper) Sweep(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("dependency-finalizer-sweeper")
+
+	var tfList infrav1.TerraformList
+	if err := s.List(ctx, &tfList); err != nil {
+		return err
+	}
+
+	// the finalizers only name the dependants, which may live in any namespace
+	dependants := map[string]map[string]bool{}
+	for _, terraform := range tfList.Items {
+		for _, d := range terraform.Spec.DependsOn {
+			namespace := d.Namespace
+			if namespace == "" {
+				namespace = terraform.Namespace
+			}
+			key := namespace + "/" + d.Name
+			if dependants[key] == nil {
+				dependants[key] = map[string]bool{}
+			}
+			dependants[key][terraform.Name] = true
+		}
+	}
+
+	var errs []string
+	for i := range tfList.Items {
+		terraform := &tfList.Items[i]
+		var stale []string
+		for _, finalizer := range terraform.GetFinalizers() {
+			if strings.HasPrefix(finalizer, infrav1.TFDependencyOfPrefix) &&
+				!dependants[terraform.Namespace+"/"+terraform.Name][strings.TrimPrefix(finalizer, infrav1.TFDependencyOfPrefix)] {
+				stale = append(stale, finalizer)
+			}
+		}
+		if len(stale) == 0 {
+			continue
+		}
+
+		// the optimistic lock keeps the finalizers added meanwhile by new dependants
+		patch := client.MergeFromWithOptions(terraform.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		for _, finalizer := range stale {
+			controllerutil.RemoveFinalizer(terraform, finalizer)
+		}
+		if err := s.Patch(ctx, terraform, patch); err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %s", terraform.Namespace, terraform.Name, err))
+			continue
+		}
+
+		names := make([]string, 0, len(stale))
+		for _, finalizer := range stale {
+			names = append(names, strings.TrimPrefix(finalizer, infrav1.TFDependencyOfPrefix))
+		}
+		msg := fmt.Sprintf("Removed the finalizers of the dependants which no longer depend on the object: %s", strings.Join(names, ", "))
+		log.Info(msg, "namespace", terraform.Namespace, "name", terraform.Name)
+		if s.EventRecorder != nil {
+			s.EventRecorder.Event(terraform, corev1.EventTypeNormal, StaleDependencyFinalizerEventReason, msg)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to remove the stale dependency finalizers of %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDependencyFinalizerSweeper_Sweep(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "bucket", Namespace: "flux-system", Finalizers: []string{
+				infrav1.TerraformFinalizer,
+				infrav1.TFDependencyOfPrefix + "acl",
+				infrav1.TFDependencyOfPrefix + "force-deleted",
+				infrav1.TFDependencyOfPrefix + "no-longer-dependant",
+			}},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "acl", Namespace: "flux-system"},
+			Spec:       infrav1.TerraformSpec{DependsOn: []infrav1.DependsOnReference{{Name: "bucket"}}},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-longer-dependant", Namespace: "flux-system"},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "infra", Finalizers: []string{
+				infrav1.TFDependencyOfPrefix + "app",
+			}},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+			Spec:       infrav1.TerraformSpec{DependsOn: []infrav1.DependsOnReference{{Name: "network", Namespace: "infra"}}},
+		},
+	).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	sweeper := &DependencyFinalizerSweeper{Client: cli, EventRecorder: recorder}
+	g.Expect(sweeper.Sweep(ctx)).To(Succeed())
+
+	var bucket infrav1.Terraform
+	g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "bucket"}, &bucket)).To(Succeed())
+	g.Expect(bucket.Finalizers).To(Equal([]string{infrav1.TerraformFinalizer, infrav1.TFDependencyOfPrefix + "acl"}))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("force-deleted, no-longer-dependant")))
+
+	// the dependants of other namespaces are kept
+	var network infrav1.Terraform
+	g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "infra", Name: "network"}, &network)).To(Succeed())
+	g.Expect(network.Finalizers).To(Equal([]string{infrav1.TFDependencyOfPrefix + "app"}))
+	g.Expect(recorder.Events).ToNot(Receive())
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftReportName is the name of the TerraformDriftReport generated by the controller.
+const DriftReportName = "tf-controller"
+
+// DriftReportGenerator periodically summarizes the Terraform objects of the cluster with a drift in the
+// TerraformDriftReport named tf-controller, so that the dashboards and the compliance exports read one object
+// instead of the conditions of every Terraform object.
+type DriftReportGenerator struct {
+	client.Client
+	Interval time.Duration
+}
+
+// Start implements manager.Runnable.
+func (g *DriftReportGenerator) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("drift-report-generator")
+
+	ticker := time.NewTicker(g.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := g.Generate(ctx); err != nil {
+			log.Error(err, "unable to generate the drift report")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (g *DriftReportGenerator) NeedLeaderElection() bool {
+	return true
+}
+
+// Generate updates the drift report with the Terraform objects whose drift has not been applied yet,
+// creating the report if it does not exist.
+func (g *DriftReportGenerator) Generate(ctx context.Context) (*infrav1.TerraformDriftReport, error) {
+	var tfList infrav1.TerraformList
+	if err := g.List(ctx, &tfList); err != nil {
+		return nil, err
+	}
+
+	report := &infrav1.TerraformDriftReport{}
+	if err := g.Get(ctx, client.ObjectKey{Name: DriftReportName}, report); apierrors.IsNotFound(err) {
+		report.Name = DriftReportName
+		if err := g.Create(ctx, report); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// a drift keeps the time it was first reported until it gets applied
+	previous := map[string]infrav1.ObjectDrift{}
+	for _, drift := range report.Status.Drifts {
+		previous[drift.Namespace+"/"+drift.Name] = drift
+	}
+
+	status := infrav1.TerraformDriftReportStatus{
+		GeneratedAt:  &metav1.Time{Time: time.Now()},
+		TotalObjects: len(tfList.Items),
+	}
+	for _, terraform := range tfList.Items {
+		if !terraform.HasDrift() {
+			continue
+		}
+		drift := infrav1.ObjectDrift{
+			Namespace:           terraform.Namespace,
+			Name:                terraform.Name,
+			LastAppliedRevision: terraform.Status.LastAppliedRevision,
+			FirstDetectedAt:     *terraform.Status.LastDriftDetectedAt,
+			LastDetectedAt:      *terraform.Status.LastDriftDetectedAt,
+			Resources:           terraform.Status.DriftedResources,
+		}
+		if before, ok := previous[drift.Namespace+"/"+drift.Name]; ok && before.LastAppliedRevision == drift.LastAppliedRevision {
+			drift.FirstDetectedAt = before.FirstDetectedAt
+		}
+		status.Drifts = append(status.Drifts, drift)
+	}
+	sort.Slice(status.Drifts, func(i, j int) bool {
+		if status.Drifts[i].Namespace != status.Drifts[j].Namespace {
+			return status.Drifts[i].Namespace < status.Drifts[j].Namespace
+		}
+		return status.Drifts[i].Name < status.Drifts[j].Name
+	})
+	status.DriftedObjects = len(status.Drifts)
+
+	report.Status = status
+	if err := g.Status().Update(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
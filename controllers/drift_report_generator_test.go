@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDriftReportGenerator_Generate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	appliedAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	detectedAt := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	newTerraform := func(namespace, name string, driftDetectedAt *metav1.Time, resources ...string) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: infrav1.TerraformStatus{
+				Conditions: []metav1.Condition{{
+					Type:               infrav1.ConditionTypeApply,
+					Status:             metav1.ConditionTrue,
+					Reason:             infrav1.TFExecApplySucceedReason,
+					LastTransitionTime: appliedAt,
+				}},
+				LastAppliedRevision: "main/b8e362c206",
+				LastDriftDetectedAt: driftDetectedAt,
+				DriftedResources:    resources,
+			},
+		}
+	}
+	// a drift detected before the last apply has been corrected
+	corrected := metav1.NewTime(appliedAt.Add(-time.Minute))
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		newTerraform("team-b", "bucket", &detectedAt, "aws_s3_bucket.this"),
+		newTerraform("team-a", "network", &detectedAt, "aws_route_table.private", "aws_vpc.main"),
+		newTerraform("team-a", "app", &corrected),
+		newTerraform("team-a", "db", nil),
+	).Build()
+
+	generator := &DriftReportGenerator{Client: cli}
+	report, err := generator.Generate(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Status.TotalObjects).To(Equal(4))
+	g.Expect(report.Status.DriftedObjects).To(Equal(2))
+	g.Expect(report.Status.Drifts).To(HaveLen(2))
+	g.Expect(report.Status.Drifts[0].Namespace + "/" + report.Status.Drifts[0].Name).To(Equal("team-a/network"))
+	g.Expect(report.Status.Drifts[0].Resources).To(Equal([]string{"aws_route_table.private", "aws_vpc.main"}))
+	g.Expect(report.Status.Drifts[0].FirstDetectedAt.Time).To(BeTemporally("==", detectedAt.Time))
+	g.Expect(report.Status.Drifts[1].Name).To(Equal("bucket"))
+
+	// a drift detected again keeps the time it was first reported
+	var bucket infrav1.Terraform
+	g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "bucket"}, &bucket)).To(Succeed())
+	bucket.Status.LastDriftDetectedAt = &metav1.Time{Time: detectedAt.Add(30 * time.Second)}
+	g.Expect(cli.Status().Update(ctx, &bucket)).To(Succeed())
+
+	_, err = generator.Generate(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	var stored infrav1.TerraformDriftReport
+	g.Expect(cli.Get(ctx, types.NamespacedName{Name: DriftReportName}, &stored)).To(Succeed())
+	g.Expect(stored.Status.Drifts[1].FirstDetectedAt.Time).To(BeTemporally("==", detectedAt.Time))
+	g.Expect(stored.Status.Drifts[1].LastDetectedAt.Time).To(BeTemporally("==", detectedAt.Add(30*time.Second)))
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IndexByInventory indexes the Terraform objects by the identifiers, e.g. ARNs,
+// of the cloud resources recorded in their inventory.
+func IndexByInventory(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	return terraform.Status.Inventory.Identifiers()
+}
+
+// listTerraformsManaging returns the Terraform objects whose inventory contains the resource,
+// in the namespace, or in all namespaces when it is empty. It requires the inventory index.
+func listTerraformsManaging(ctx context.Context, reader client.Reader, namespace string, id string) ([]infrav1.Terraform, error) {
+	var list infrav1.TerraformList
+	opts := []client.ListOption{client.MatchingFields{infrav1.InventoryIndexKey: id}}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	if err := reader.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
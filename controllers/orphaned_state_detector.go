@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kuberecorder "k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ManagedByLabel is added to the state Secrets written by the kubernetes backend
+	// that the controller configures, so that they can be told apart from other Terraform states.
+	ManagedByLabel = "infra.contrib.fluxcd.io/managed-by"
+	ManagedByValue = "tf-controller"
+
+	// labels set by the kubernetes backend of Terraform itself
+	tfstateLabel             = "tfstate"
+	tfstateWorkspaceLabel    = "tfstateWorkspace"
+	tfstateSecretSuffixLabel = "tfstateSecretSuffix"
+
+	OrphanedStateEventReason = "OrphanedState"
+)
+
+var (
+	orphanedStateSecrets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gotk_orphaned_state_secrets",
+			Help: "State Secrets of the kubernetes backend which are not claimed by any Terraform object.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	secretSuffixRegexp = regexp.MustCompile(`secret_suffix\s*=\s*"([^"]+)"`)
+)
+
+func init() {
+	crtlmetrics.Registry.MustRegister(orphanedStateSecrets)
+}
+
+// OrphanedStateDetector periodically looks for Terraform states stored by the
+// kubernetes backend that no Terraform object refers to anymore. This happens
+// when an object gets renamed (deleted and re-created) or its secretSuffix or workspace changes.
+// Orphaned states are never deleted, they are reported via the
+// gotk_orphaned_state_secrets metric and a Warning event on the Secret for an operator to clean up.
+type OrphanedStateDetector struct {
+	client.Client
+	EventRecorder kuberecorder.EventRecorder
+	Interval      time.Duration
+
+	reported map[string]bool
+}
+
+// Start implements manager.Runnable.
+func (d *OrphanedStateDetector) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("orphaned-state-detector")
+	d.reported = map[string]bool{}
+
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			orphans, err := d.Detect(ctx)
+			if err != nil {
+				log.Error(err, "unable to detect orphaned states")
+				continue
+			}
+			d.report(orphans)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (d *OrphanedStateDetector) NeedLeaderElection() bool {
+	return true
+}
+
+// Detect returns the state Secrets written for the controller which are not claimed by any Terraform object.
+func (d *OrphanedStateDetector) Detect(ctx context.Context) ([]corev1.Secret, error) {
+	var tfList infrav1.TerraformList
+	if err := d.List(ctx, &tfList); err != nil {
+		return nil, err
+	}
+
+	claimed := map[string]bool{}
+	for _, terraform := range tfList.Items {
+		if suffix, ok := stateSecretSuffix(terraform); ok {
+			for _, workspace := range terraform.WorkspaceNames() {
+				claimed[stateKey(terraform.Namespace, workspace, suffix)] = true
+			}
+		}
+	}
+
+	var secretList corev1.SecretList
+	if err := d.List(ctx, &secretList, client.MatchingLabels{
+		tfstateLabel:   "true",
+		ManagedByLabel: ManagedByValue,
+	}); err != nil {
+		return nil, err
+	}
+
+	orphans := []corev1.Secret{}
+	for _, secret := range secretList.Items {
+		key := stateKey(secret.Namespace, secret.Labels[tfstateWorkspaceLabel], secret.Labels[tfstateSecretSuffixLabel])
+		if !claimed[key] {
+			orphans = append(orphans, secret)
+		}
+	}
+
+	return orphans, nil
+}
+
+func (d *OrphanedStateDetector) report(orphans []corev1.Secret) {
+	orphanedStateSecrets.Reset()
+
+	current := map[string]bool{}
+	for i := range orphans {
+		secret := &orphans[i]
+		key := secret.Namespace + "/" + secret.Name
+		current[key] = true
+		orphanedStateSecrets.WithLabelValues(secret.Namespace, secret.Name).Set(1)
+
+		// only emit an event when the state becomes orphaned
+		if !d.reported[key] && d.EventRecorder != nil {
+			d.EventRecorder.Event(secret, corev1.EventTypeWarning, OrphanedStateEventReason,
+				fmt.Sprintf("Terraform state %s is not claimed by any Terraform object in namespace %s", secret.Name, secret.Namespace))
+		}
+	}
+	d.reported = current
+}
+
+// stateSecretSuffix returns the secret_suffix of the kubernetes backend used by the Terraform object.
+// The bool is false when the object does not store its state with the kubernetes backend.
+func stateSecretSuffix(terraform infrav1.Terraform) (string, bool) {
+	backendConfig := terraform.Spec.BackendConfig
+	if backendConfig == nil {
+		if os.Getenv("DISABLE_TF_K8S_BACKEND") == "1" {
+			return "", false
+		}
+		return terraform.Name, true
+	}
+
+	// the states of the disabled, the remote and the typed backends are not stored in the cluster
+	if backendConfig.Disable || terraform.IsRemote() || terraform.HasTypedBackend() {
+		return "", false
+	}
+
+	if backendConfig.CustomConfiguration != "" {
+		customConfiguration, err := renderCustomConfiguration(terraform, placeholderSecret(terraform))
+		if err != nil {
+			return "", false
+		}
+		if matches := secretSuffixRegexp.FindStringSubmatch(customConfiguration); len(matches) == 2 {
+			return matches[1], true
+		}
+		return "", false
+	}
+
+	return backendConfig.SecretSuffix, true
+}
+
+func stateKey(namespace, workspace, suffix string) string {
+	return namespace + "/" + workspace + "/" + suffix
+}
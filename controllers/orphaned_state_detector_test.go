@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrphanedStateDetector_Detect(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	stateSecret := func(name, workspace, suffix string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "flux-system",
+				Labels: map[string]string{
+					tfstateLabel:             "true",
+					tfstateWorkspaceLabel:    workspace,
+					tfstateSecretSuffixLabel: suffix,
+					ManagedByLabel:           ManagedByValue,
+				},
+			},
+		}
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "renamed", Namespace: "flux-system"},
+			Spec: infrav1.TerraformSpec{
+				Workspace:     "dev",
+				BackendConfig: &infrav1.BackendConfigSpec{SecretSuffix: "custom"},
+			},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "bluegreen", Namespace: "flux-system"},
+			Spec:       infrav1.TerraformSpec{ActiveWorkspace: "green"},
+		},
+		stateSecret("tfstate-default-helloworld", "default", "helloworld"),
+		stateSecret("tfstate-default-blue-bluegreen", "default-blue", "bluegreen"),
+		stateSecret("tfstate-default-green-bluegreen", "default-green", "bluegreen"),
+		stateSecret("tfstate-dev-custom", "dev", "custom"),
+		stateSecret("tfstate-default-old-name", "default", "old-name"),
+	).Build()
+
+	detector := &OrphanedStateDetector{Client: cli}
+	orphans, err := detector.Detect(context.Background())
+	g.Expect(err).To(BeNil())
+	g.Expect(orphans).To(HaveLen(1))
+	g.Expect(orphans[0].Name).To(Equal("tfstate-default-old-name"))
+}
+
+func TestStateSecretSuffix(t *testing.T) {
+	g := NewWithT(t)
+
+	suffix, ok := stateSecretSuffix(infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld"},
+	})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(suffix).To(Equal("helloworld"))
+
+	suffix, ok = stateSecretSuffix(infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{
+				CustomConfiguration: `backend "kubernetes" {
+    secret_suffix    = "tfstate-custom"
+    namespace        = "flux-system"
+  }`,
+			},
+		},
+	})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(suffix).To(Equal("tfstate-custom"))
+
+	_, ok = stateSecretSuffix(infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{
+				CustomConfiguration: `backend "s3" {}`,
+			},
+		},
+	})
+	g.Expect(ok).To(BeFalse())
+}
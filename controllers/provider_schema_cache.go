@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ProviderSchemaCache keeps the schemas of the provider versions used by the Terraform objects,
+// so that the plans are enriched with the descriptions of their resource types without
+// each runner computing the schemas again. The least recently used schemas are evicted past its size.
+type ProviderSchemaCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type providerSchemaEntry struct {
+	key    string
+	schema *tfjson.ProviderSchema
+}
+
+// ResourceTypeInfo describes a resource type of a plan, from the schema of its provider.
+type ResourceTypeInfo struct {
+	Provider    string `json:"provider"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	DocsURL     string `json:"docsURL,omitempty"`
+}
+
+// NewProviderSchemaCache returns a cache of size provider versions.
+func NewProviderSchemaCache(size int) *ProviderSchemaCache {
+	return &ProviderSchemaCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Keys returns the <source>@<version> keys of the cached schemas.
+func (c *ProviderSchemaCache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the schema of a provider version.
+func (c *ProviderSchemaCache) Get(source string, version string) (*tfjson.ProviderSchema, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[runner.ProviderKey(source, version)]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*providerSchemaEntry).schema, true
+}
+
+// Add caches the schema of a provider version.
+func (c *ProviderSchemaCache) Add(source string, version string, schema *tfjson.ProviderSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := runner.ProviderKey(source, version)
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*providerSchemaEntry).schema = schema
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&providerSchemaEntry{key: key, schema: schema})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*providerSchemaEntry).key)
+	}
+}
+
+// ResourceTypes describes the resource types of the "<action> <address>" changes of a plan,
+// with the schemas of the providers. The types of the providers not cached are left out.
+func (c *ProviderSchemaCache) ResourceTypes(providers []infrav1.ProviderVersion, changes []string) map[string]ResourceTypeInfo {
+	types := map[string]ResourceTypeInfo{}
+	for _, change := range changes {
+		_, address, _ := strings.Cut(change, " ")
+		resourceType := resourceAddressType(address)
+		if resourceType == "" {
+			continue
+		}
+		if _, ok := types[resourceType]; ok {
+			continue
+		}
+		for _, p := range providers {
+			schema, ok := c.Get(p.Source, p.Version)
+			if !ok {
+				continue
+			}
+			if resource, ok := schema.ResourceSchemas[resourceType]; ok {
+				info := ResourceTypeInfo{Provider: p.Source, Version: p.Version, DocsURL: resourceDocsURL(p, resourceType)}
+				if resource.Block != nil {
+					info.Description = resource.Block.Description
+				}
+				types[resourceType] = info
+				break
+			}
+		}
+	}
+	return types
+}
+
+// resourceAddressType returns the type of a managed resource address, e.g. aws_s3_bucket of
+// module.logs["eu"].aws_s3_bucket.this[0]. It returns an empty string for the data sources.
+func resourceAddressType(address string) string {
+	var segments []string
+	depth, quoted, start := 0, false, 0
+	for i, c := range address {
+		switch {
+		case c == '"' && (i == 0 || address[i-1] != '\\'):
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			segments = append(segments, address[start:i])
+			start = i + 1
+		}
+	}
+	segments = append(segments, address[start:])
+
+	for len(segments) >= 2 && segments[0] == "module" {
+		segments = segments[2:]
+	}
+	if len(segments) < 2 || segments[0] == "data" {
+		return ""
+	}
+	return segments[0]
+}
+
+// resourceDocsURL returns the page of a resource type in the documentation of the provider, for the providers of the public registry.
+func resourceDocsURL(provider infrav1.ProviderVersion, resourceType string) string {
+	parts := strings.Split(provider.Source, "/")
+	if len(parts) != 3 || parts[0] != "registry.terraform.io" {
+		return ""
+	}
+	name := strings.TrimPrefix(resourceType, parts[2]+"_")
+	return fmt.Sprintf("https://registry.terraform.io/providers/%s/%s/%s/docs/resources/%s", parts[1], parts[2], provider.Version, name)
+}
+
+// cacheProviderSchemas records the provider versions of the plan in the status, and caches the schemas of the new ones.
+// The schemas only enrich the plans, so failing to get them does not fail the plan.
+func (r *TerraformReconciler) cacheProviderSchemas(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, tfInstance string) infrav1.Terraform {
+	log := ctrl.LoggerFrom(ctx)
+	reply, err := runnerClient.GetProviderSchemas(ctx, &runner.GetProviderSchemasRequest{
+		TfInstance: tfInstance,
+		Cached:     r.ProviderSchemas.Keys(),
+	})
+	if err != nil {
+		log.Error(err, "unable to get the provider schemas")
+		return terraform
+	}
+
+	if len(reply.Schemas) > 0 {
+		var schemas tfjson.ProviderSchemas
+		if err := json.Unmarshal(reply.Schemas, &schemas); err != nil {
+			log.Error(err, "unable to parse the provider schemas")
+			return terraform
+		}
+		for source, schema := range schemas.Schemas {
+			if version, ok := reply.Providers[source]; ok {
+				r.ProviderSchemas.Add(source, version, schema)
+			}
+		}
+	}
+
+	providers := make([]infrav1.ProviderVersion, 0, len(reply.Providers))
+	for source, version := range reply.Providers {
+		providers = append(providers, infrav1.ProviderVersion{Source: source, Version: version})
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Source < providers[j].Source })
+	terraform.Status.Providers = providers
+	return terraform
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceAddressType(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(resourceAddressType("aws_s3_bucket.logs")).To(Equal("aws_s3_bucket"))
+	g.Expect(resourceAddressType(`module.logs["eu.west"].aws_s3_bucket.this[0]`)).To(Equal("aws_s3_bucket"))
+	g.Expect(resourceAddressType("module.a.module.b[1].aws_iam_role.deployer")).To(Equal("aws_iam_role"))
+	g.Expect(resourceAddressType("data.aws_caller_identity.current")).To(BeEmpty())
+	g.Expect(resourceAddressType("module.a")).To(BeEmpty())
+}
+
+func TestProviderSchemaCache(t *testing.T) {
+	g := NewWithT(t)
+
+	const aws = "registry.terraform.io/hashicorp/aws"
+	cache := NewProviderSchemaCache(2)
+	cache.Add(aws, "4.0.0", &tfjson.ProviderSchema{})
+	cache.Add(aws, "5.0.0", &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"aws_s3_bucket": {Block: &tfjson.SchemaBlock{Description: "Provides a S3 bucket resource."}},
+		},
+	})
+	_, ok := cache.Get(aws, "4.0.0")
+	g.Expect(ok).To(BeTrue())
+
+	// the least recently used schema is evicted
+	cache.Add("registry.terraform.io/hashicorp/null", "3.2.1", &tfjson.ProviderSchema{})
+	g.Expect(cache.Keys()).To(Equal([]string{aws + "@4.0.0", "registry.terraform.io/hashicorp/null@3.2.1"}))
+
+	cache.Add(aws, "5.0.0", &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"aws_s3_bucket": {Block: &tfjson.SchemaBlock{Description: "Provides a S3 bucket resource."}},
+		},
+	})
+	types := cache.ResourceTypes(
+		[]infrav1.ProviderVersion{{Source: aws, Version: "5.0.0"}},
+		[]string{"create aws_s3_bucket.logs", "delete module.eu.aws_s3_bucket.this[0]", "create aws_iam_role.deployer"},
+	)
+	g.Expect(types).To(Equal(map[string]ResourceTypeInfo{
+		"aws_s3_bucket": {
+			Provider:    aws,
+			Version:     "5.0.0",
+			Description: "Provides a S3 bucket resource.",
+			DocsURL:     "https://registry.terraform.io/providers/hashicorp/aws/5.0.0/docs/resources/s3_bucket",
+		},
+	}))
+}
+
+func TestApproverAPIPlanResourceTypes(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+			Status: infrav1.TerraformStatus{
+				Plan:      infrav1.PlanStatus{Pending: "plan-main-b8e362c206"},
+				Providers: []infrav1.ProviderVersion{{Source: "registry.terraform.io/hashicorp/null", Version: "3.2.1"}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tfplan-default-helloworld", Namespace: "flux-system"},
+			Data:       map[string][]byte{"tfplan.changes": []byte(`["create null_resource.a"]`)},
+		},
+	).Build()
+
+	cache := NewProviderSchemaCache(10)
+	cache.Add("registry.terraform.io/hashicorp/null", "3.2.1", &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{"null_resource": {Block: &tfjson.SchemaBlock{}}},
+	})
+	server := &ApproverAPIServer{
+		Client:          cli,
+		ProviderSchemas: cache,
+		authorize: func(_ context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+			return "viewer", nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/terraforms/flux-system/helloworld/plan", nil)
+	req.Header.Set("Authorization", "Bearer viewer")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	var plan PlanResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &plan)).To(Succeed())
+	g.Expect(plan.ResourceTypes).To(HaveKeyWithValue("null_resource", ResourceTypeInfo{
+		Provider: "registry.terraform.io/hashicorp/null",
+		Version:  "3.2.1",
+		DocsURL:  "https://registry.terraform.io/providers/hashicorp/null/3.2.1/docs/resources/resource",
+	}))
+}
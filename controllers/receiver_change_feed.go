@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+// eventBridgeEvent is the envelope of the events delivered by AWS EventBridge.
+// AWS Config events carry the changed resource in their configuration item.
+type eventBridgeEvent struct {
+	DetailType string   `json:"detail-type"`
+	Resources  []string `json:"resources"`
+	Detail     struct {
+		ConfigurationItem struct {
+			ARN        string `json:"ARN"`
+			ResourceID string `json:"resourceId"`
+		} `json:"configurationItem"`
+		ResourceID string `json:"resourceId"`
+	} `json:"detail"`
+}
+
+// assetFeedAsset is the asset of a GCP Cloud Asset Inventory feed notification.
+type assetFeedAsset struct {
+	Name      string `json:"name"`
+	AssetType string `json:"assetType"`
+}
+
+func isChangeFeedReceiver(receiver infrav1.TerraformReceiver) bool {
+	return receiver.Spec.Type == infrav1.AWSEventBridgeReceiver || receiver.Spec.Type == infrav1.GCPAssetFeedReceiver
+}
+
+// parseAssetFeedMessage reads the asset of a feed notification, pushed by a Pub/Sub push subscription.
+func parseAssetFeedMessage(payload []byte) (assetFeedAsset, error) {
+	var push struct {
+		Message struct {
+			Data string `json:"data"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(payload, &push); err != nil {
+		return assetFeedAsset{}, fmt.Errorf("unable to parse the payload: %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(push.Message.Data)
+	if err != nil {
+		return assetFeedAsset{}, fmt.Errorf("unable to decode the message: %w", err)
+	}
+
+	var notification struct {
+		Asset           assetFeedAsset `json:"asset"`
+		PriorAsset      assetFeedAsset `json:"priorAsset"`
+		PriorAssetState string         `json:"priorAssetState"`
+	}
+	if err := json.Unmarshal(data, &notification); err != nil {
+		return assetFeedAsset{}, fmt.Errorf("unable to parse the message: %w", err)
+	}
+
+	// a deleted asset only comes as the prior asset
+	if notification.Asset.Name == "" {
+		return notification.PriorAsset, nil
+	}
+	return notification.Asset, nil
+}
+
+// changedResources returns the identifiers of the cloud resources changed according to a change feed event.
+func changedResources(receiver infrav1.TerraformReceiver, payload []byte) ([]string, error) {
+	var ids []string
+	switch receiver.Spec.Type {
+	case infrav1.AWSEventBridgeReceiver:
+		var event eventBridgeEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("unable to parse the payload: %w", err)
+		}
+		ids = append(ids, event.Resources...)
+		ids = append(ids, event.Detail.ConfigurationItem.ARN, event.Detail.ConfigurationItem.ResourceID, event.Detail.ResourceID)
+
+	case infrav1.GCPAssetFeedReceiver:
+		asset, err := parseAssetFeedMessage(payload)
+		if err != nil {
+			return nil, err
+		}
+		// asset names are prefixed by the service, e.g. //compute.googleapis.com/projects/p/zones/z/instances/i,
+		// while the identifiers in the state are not
+		name := asset.Name
+		if strings.HasPrefix(name, "//") {
+			if i := strings.Index(name[2:], "/"); i >= 0 {
+				name = name[2+i+1:]
+			}
+		}
+		ids = append(ids, name)
+	}
+
+	var result []string
+	for _, id := range ids {
+		if id != "" {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// terraformsManaging returns the objects whose inventory contains one of the resources.
+func terraformsManaging(terraforms []infrav1.Terraform, ids []string) []infrav1.Terraform {
+	var result []infrav1.Terraform
+	for _, terraform := range terraforms {
+		for _, id := range ids {
+			if _, ok := terraform.Status.Inventory.Find(id); ok {
+				result = append(result, terraform)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChangedResources(t *testing.T) {
+	g := NewWithT(t)
+
+	eventBridge := infrav1.TerraformReceiver{Spec: infrav1.TerraformReceiverSpec{Type: infrav1.AWSEventBridgeReceiver}}
+	payload := []byte(`{
+  "detail-type": "Config Configuration Item Change",
+  "resources": ["arn:aws:s3:::my-bucket"],
+  "detail": {"configurationItem": {"ARN": "arn:aws:ec2:eu-west-1:123456789012:instance/i-0abc", "resourceId": "i-0abc"}}
+}`)
+
+	event, err := receiverEvent(eventBridge, "token", http.Header{"Authorization": []string{"token"}}, payload)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(event).To(Equal("Config Configuration Item Change"))
+
+	_, err = receiverEvent(eventBridge, "token", http.Header{}, payload)
+	g.Expect(err).To(HaveOccurred())
+
+	ids, err := changedResources(eventBridge, payload)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ids).To(Equal([]string{
+		"arn:aws:s3:::my-bucket",
+		"arn:aws:ec2:eu-west-1:123456789012:instance/i-0abc",
+		"i-0abc",
+	}))
+
+	assetFeed := infrav1.TerraformReceiver{Spec: infrav1.TerraformReceiverSpec{Type: infrav1.GCPAssetFeedReceiver}}
+	message := base64.StdEncoding.EncodeToString([]byte(`{"asset": {
+  "name": "//compute.googleapis.com/projects/p/zones/z/instances/vm",
+  "assetType": "compute.googleapis.com/Instance"
+}}`))
+	payload = []byte(`{"message": {"data": "` + message + `"}, "subscription": "projects/p/subscriptions/s"}`)
+
+	event, err = receiverEvent(assetFeed, "token", http.Header{}, payload)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(event).To(Equal("compute.googleapis.com/Instance"))
+
+	ids, err = changedResources(assetFeed, payload)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ids).To(Equal([]string{"projects/p/zones/z/instances/vm"}))
+
+	// deleted assets only come as the prior asset
+	message = base64.StdEncoding.EncodeToString([]byte(`{"priorAssetState": "PRESENT", "priorAsset": {
+  "name": "//storage.googleapis.com/my-bucket"
+}}`))
+	ids, err = changedResources(assetFeed, []byte(`{"message": {"data": "`+message+`"}}`))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ids).To(Equal([]string{"my-bucket"}))
+}
+
+func TestTerraformsManaging(t *testing.T) {
+	g := NewWithT(t)
+
+	withInventory := func(name string, ids ...string) infrav1.Terraform {
+		terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if ids != nil {
+			terraform.Status.Inventory = &infrav1.ResourceInventory{}
+			for _, id := range ids {
+				terraform.Status.Inventory.Entries = append(terraform.Status.Inventory.Entries, infrav1.ResourceRef{Identifier: id})
+			}
+		}
+		return terraform
+	}
+
+	terraforms := []infrav1.Terraform{
+		withInventory("bucket", "arn:aws:s3:::my-bucket", ""),
+		withInventory("vm", "i-0abc"),
+		withInventory("no-inventory"),
+	}
+
+	managing := terraformsManaging(terraforms, []string{"arn:aws:s3:::my-bucket"})
+	g.Expect(managing).To(HaveLen(1))
+	g.Expect(managing[0].Name).To(Equal("bucket"))
+
+	g.Expect(terraformsManaging(terraforms, []string{"unknown"})).To(BeEmpty())
+	g.Expect(terraformsManaging(terraforms, nil)).To(BeEmpty())
+}
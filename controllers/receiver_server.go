@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	receiverPathPrefix = "/hook/"
+
+	// maxReceiverPayloadSize caps the bodies read from the senders.
+	maxReceiverPayloadSize = 1 << 20
+)
+
+// ReceiverServer serves the webhook paths of the TerraformReceiver objects.
+// An accepted request requests the reconciliation of the Terraform objects selected by the receiver.
+type ReceiverServer struct {
+	client.Client
+	Addr string
+}
+
+// Start implements manager.Runnable.
+func (s *ReceiverServer) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("receiver-server")
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("starting receiver server", "addr", s.Addr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *ReceiverServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the http.Handler serving the webhook paths.
+func (s *ReceiverServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(receiverPathPrefix, s.serveHook)
+	return mux
+}
+
+func (s *ReceiverServer) serveHook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := ctrl.LoggerFrom(ctx).WithName("receiver-server")
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	receiver, err := s.findReceiver(ctx, r.URL.Path)
+	if err != nil {
+		log.Error(err, "unable to look up the receiver")
+		http.Error(w, "unable to look up the receiver", http.StatusInternalServerError)
+		return
+	}
+	if receiver == nil || receiver.Spec.Suspend {
+		http.NotFound(w, r)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxReceiverPayloadSize))
+	if err != nil {
+		http.Error(w, "unable to read the payload", http.StatusBadRequest)
+		return
+	}
+
+	token, err := (&TerraformReceiverReconciler{Client: s.Client}).getToken(ctx, *receiver)
+	if err != nil {
+		log.Error(err, "unable to read the token of the receiver", "receiver", client.ObjectKeyFromObject(receiver))
+		http.Error(w, "unable to read the token of the receiver", http.StatusInternalServerError)
+		return
+	}
+
+	event, err := receiverEvent(*receiver, token, r.Header, payload)
+	if err != nil {
+		log.Info("rejected request", "receiver", client.ObjectKeyFromObject(receiver), "reason", err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if !receiverAccepts(*receiver, event) {
+		log.Info("ignored event", "receiver", client.ObjectKeyFromObject(receiver), "event", event)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var terraforms []infrav1.Terraform
+	if isChangeFeedReceiver(*receiver) {
+		changed, err := changedResources(*receiver, payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		terraforms, err = s.selectTerraformsManaging(ctx, *receiver, changed)
+	} else {
+		terraforms, err = s.selectTerraforms(ctx, *receiver)
+	}
+	if err != nil {
+		log.Error(err, "unable to select the Terraform objects", "receiver", client.ObjectKeyFromObject(receiver))
+		http.Error(w, "unable to select the Terraform objects", http.StatusInternalServerError)
+		return
+	}
+
+	requested, err := s.requestReconciliation(ctx, terraforms)
+	if err != nil {
+		log.Error(err, "unable to request the reconciliation", "receiver", client.ObjectKeyFromObject(receiver))
+		http.Error(w, "unable to request the reconciliation", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("requested reconciliation", "receiver", client.ObjectKeyFromObject(receiver), "event", event, "terraforms", requested)
+	w.WriteHeader(http.StatusOK)
+}
+
+// findReceiver returns the receiver published at the path, or nil if there is none.
+func (s *ReceiverServer) findReceiver(ctx context.Context, path string) (*infrav1.TerraformReceiver, error) {
+	var list infrav1.TerraformReceiverList
+	if err := s.List(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		if list.Items[i].Status.WebhookPath == path {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// receiverEvent verifies the request according to the type of the receiver, and returns the name of its event.
+func receiverEvent(receiver infrav1.TerraformReceiver, token string, header http.Header, payload []byte) (string, error) {
+	switch receiver.Spec.Type {
+	case infrav1.GitHubReceiver:
+		signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+		expected, err := hex.DecodeString(signature)
+		if err != nil || signature == "" {
+			return "", errors.New("missing or malformed X-Hub-Signature-256 header")
+		}
+		mac := hmac.New(sha256.New, []byte(token))
+		mac.Write(payload)
+		if !hmac.Equal(mac.Sum(nil), expected) {
+			return "", errors.New("invalid signature")
+		}
+		return header.Get("X-GitHub-Event"), nil
+
+	case infrav1.GitLabReceiver:
+		if !hmac.Equal([]byte(header.Get("X-Gitlab-Token")), []byte(token)) {
+			return "", errors.New("invalid X-Gitlab-Token header")
+		}
+		return header.Get("X-Gitlab-Event"), nil
+
+	case infrav1.HarborReceiver:
+		if !hmac.Equal([]byte(header.Get("Authorization")), []byte(token)) {
+			return "", errors.New("invalid Authorization header")
+		}
+		var body struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return "", fmt.Errorf("unable to parse the payload: %w", err)
+		}
+		return body.Type, nil
+
+	case infrav1.AWSEventBridgeReceiver:
+		// set by the connection of the EventBridge API destination
+		if !hmac.Equal([]byte(header.Get("Authorization")), []byte(token)) {
+			return "", errors.New("invalid Authorization header")
+		}
+		var event eventBridgeEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return "", fmt.Errorf("unable to parse the payload: %w", err)
+		}
+		return event.DetailType, nil
+
+	case infrav1.GCPAssetFeedReceiver:
+		asset, err := parseAssetFeedMessage(payload)
+		if err != nil {
+			return "", err
+		}
+		return asset.AssetType, nil
+	}
+
+	// the generic receiver is only protected by its path
+	return "", nil
+}
+
+func receiverAccepts(receiver infrav1.TerraformReceiver, event string) bool {
+	if len(receiver.Spec.Events) == 0 {
+		return true
+	}
+
+	for _, e := range receiver.Spec.Events {
+		if strings.EqualFold(e, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectTerraforms returns the Terraform objects selected by the resources of the receiver.
+func (s *ReceiverServer) selectTerraforms(ctx context.Context, receiver infrav1.TerraformReceiver) ([]infrav1.Terraform, error) {
+	var terraforms []infrav1.Terraform
+	for _, resource := range receiver.Spec.Resources {
+		if resource.Name == "*" {
+			var list infrav1.TerraformList
+			if err := s.List(ctx, &list, client.InNamespace(receiver.Namespace), client.MatchingLabels(resource.MatchLabels)); err != nil {
+				return nil, err
+			}
+			terraforms = append(terraforms, list.Items...)
+			continue
+		}
+
+		var terraform infrav1.Terraform
+		if err := s.Get(ctx, types.NamespacedName{Namespace: receiver.Namespace, Name: resource.Name}, &terraform); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, err
+		}
+		terraforms = append(terraforms, terraform)
+	}
+
+	return terraforms, nil
+}
+
+// selectTerraformsManaging returns the Terraform objects managing the changed resources, among those selected
+// by the receiver. Without resources, they are looked up in the whole namespace of the receiver with the inventory index.
+func (s *ReceiverServer) selectTerraformsManaging(ctx context.Context, receiver infrav1.TerraformReceiver, changed []string) ([]infrav1.Terraform, error) {
+	if len(receiver.Spec.Resources) > 0 {
+		terraforms, err := s.selectTerraforms(ctx, receiver)
+		if err != nil {
+			return nil, err
+		}
+		return terraformsManaging(terraforms, changed), nil
+	}
+
+	var terraforms []infrav1.Terraform
+	seen := map[string]bool{}
+	for _, id := range changed {
+		managing, err := listTerraformsManaging(ctx, s.Client, receiver.Namespace, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, terraform := range managing {
+			if !seen[terraform.Name] {
+				seen[terraform.Name] = true
+				terraforms = append(terraforms, terraform)
+			}
+		}
+	}
+	return terraforms, nil
+}
+
+// requestReconciliation annotates the Terraform objects, and returns their names.
+func (s *ReceiverServer) requestReconciliation(ctx context.Context, terraforms []infrav1.Terraform) ([]string, error) {
+	var names []string
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+
+	for i := range terraforms {
+		terraform := &terraforms[i]
+		patch := client.MergeFrom(terraform.DeepCopy())
+		if terraform.Annotations == nil {
+			terraform.Annotations = map[string]string{}
+		}
+		terraform.Annotations[meta.ReconcileRequestAnnotation] = requestedAt
+		if err := s.Patch(ctx, terraform, patch); err != nil {
+			return names, err
+		}
+		names = append(names, terraform.Name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReceiverServer(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	const token = "s3cr3t"
+	terraform := func(name string, labels map[string]string) *infrav1.Terraform {
+		return &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Labels: labels}}
+	}
+	receiver := func(name, receiverType string, events []string, resources ...infrav1.ReceiverResource) *infrav1.TerraformReceiver {
+		return &infrav1.TerraformReceiver{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Spec: infrav1.TerraformReceiverSpec{
+				Type:      receiverType,
+				Events:    events,
+				SecretRef: meta.LocalObjectReference{Name: "receiver-token"},
+				Resources: resources,
+			},
+		}
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "receiver-token", Namespace: "flux-system"},
+			Data:       map[string][]byte{"token": []byte(token)},
+		},
+		terraform("app", nil),
+		terraform("network", map[string]string{"team": "infra"}),
+		terraform("database", map[string]string{"team": "infra"}),
+		receiver("github", infrav1.GitHubReceiver, []string{"push"}, infrav1.ReceiverResource{Name: "app"}),
+		receiver("gitlab", infrav1.GitLabReceiver, nil, infrav1.ReceiverResource{Name: "*", MatchLabels: map[string]string{"team": "infra"}}),
+		receiver("generic", infrav1.GenericReceiver, nil, infrav1.ReceiverResource{Name: "missing"}, infrav1.ReceiverResource{Name: "app"}),
+	).Build()
+
+	reconciler := &TerraformReceiverReconciler{Client: cli}
+	paths := map[string]string{}
+	for _, name := range []string{"github", "gitlab", "generic"} {
+		key := types.NamespacedName{Namespace: "flux-system", Name: name}
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var r infrav1.TerraformReceiver
+		g.Expect(cli.Get(ctx, key, &r)).To(Succeed())
+		g.Expect(r.Status.WebhookPath).To(HavePrefix(receiverPathPrefix))
+		paths[name] = r.Status.WebhookPath
+	}
+
+	handler := (&ReceiverServer{Client: cli}).Handler()
+	post := func(path, body string, header map[string]string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	requested := func(name string) bool {
+		var tf infrav1.Terraform
+		g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: name}, &tf)).To(Succeed())
+		_, ok := tf.Annotations[meta.ReconcileRequestAnnotation]
+		return ok
+	}
+	reset := func(names ...string) {
+		for _, name := range names {
+			var tf infrav1.Terraform
+			g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: name}, &tf)).To(Succeed())
+			patch := client.MergeFrom(tf.DeepCopy())
+			delete(tf.Annotations, meta.ReconcileRequestAnnotation)
+			g.Expect(cli.Patch(ctx, &tf, patch)).To(Succeed())
+		}
+	}
+
+	g.Expect(post(receiverPathPrefix+"unknown", "{}", nil)).To(Equal(http.StatusNotFound))
+
+	// github verifies the signature of the payload
+	payload := `{"ref": "refs/heads/main"}`
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(payload))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	g.Expect(post(paths["github"], payload, map[string]string{"X-Hub-Signature-256": "sha256=00", "X-GitHub-Event": "push"})).To(Equal(http.StatusUnauthorized))
+	g.Expect(post(paths["github"], payload, map[string]string{"X-Hub-Signature-256": signature, "X-GitHub-Event": "ping"})).To(Equal(http.StatusOK))
+	g.Expect(requested("app")).To(BeFalse())
+	g.Expect(post(paths["github"], payload, map[string]string{"X-Hub-Signature-256": signature, "X-GitHub-Event": "push"})).To(Equal(http.StatusOK))
+	g.Expect(requested("app")).To(BeTrue())
+	reset("app")
+
+	// gitlab verifies the token header, and selects the objects by labels
+	g.Expect(post(paths["gitlab"], "{}", map[string]string{"X-Gitlab-Token": "wrong"})).To(Equal(http.StatusUnauthorized))
+	g.Expect(post(paths["gitlab"], "{}", map[string]string{"X-Gitlab-Token": token, "X-Gitlab-Event": "Push Hook"})).To(Equal(http.StatusOK))
+	g.Expect(requested("network")).To(BeTrue())
+	g.Expect(requested("database")).To(BeTrue())
+	g.Expect(requested("app")).To(BeFalse())
+
+	// generic skips missing objects
+	g.Expect(post(paths["generic"], "", nil)).To(Equal(http.StatusOK))
+	g.Expect(requested("app")).To(BeTrue())
+}
+
+func TestReceiverEvent(t *testing.T) {
+	g := NewWithT(t)
+
+	harbor := infrav1.TerraformReceiver{Spec: infrav1.TerraformReceiverSpec{Type: infrav1.HarborReceiver}}
+	event, err := receiverEvent(harbor, "token", http.Header{"Authorization": []string{"token"}}, []byte(`{"type": "PUSH_ARTIFACT"}`))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(event).To(Equal("PUSH_ARTIFACT"))
+
+	_, err = receiverEvent(harbor, "token", http.Header{}, []byte(`{"type": "PUSH_ARTIFACT"}`))
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(receiverAccepts(infrav1.TerraformReceiver{}, "anything")).To(BeTrue())
+	g.Expect(receiverAccepts(infrav1.TerraformReceiver{Spec: infrav1.TerraformReceiverSpec{Events: []string{"push_artifact"}}}, event)).To(BeTrue())
+}
@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
 )
 
 type SourceRevisionChangePredicate struct {
@@ -52,11 +55,22 @@ func (SecretDeletePredicate) Delete(e event.DeleteEvent) bool {
 }
 
 // Update implements Predicate.
+// Besides deletion, it also triggers when the content of a generated Secret
+// no longer matches the hash recorded by the runner, i.e. it was modified out-of-band.
 func (SecretDeletePredicate) Update(e event.UpdateEvent) bool {
 	if e.ObjectOld != nil && e.ObjectNew == nil {
 		return true
 	}
 
+	newSecret, ok := e.ObjectNew.(*corev1.Secret)
+	if !ok {
+		return false
+	}
+
+	if hash, ok := newSecret.GetAnnotations()[runner.ContentHashAnnotation]; ok && hash != utils.ContentHash(newSecret.Data) {
+		return true
+	}
+
 	return false
 }
 
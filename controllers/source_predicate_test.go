@@ -3,6 +3,9 @@ package controllers
 import (
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
 	. "github.com/onsi/gomega"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -103,3 +106,42 @@ func TestSourceRevisionChangePredicate_Update(t *testing.T) {
 	g.Expect(result).To(BeFalse())
 
 }
+
+func TestSecretDeletePredicate_Update(t *testing.T) {
+	g := NewWithT(t)
+	predicate := SecretDeletePredicate{}
+
+	data := map[string][]byte{"hello": []byte("world")}
+	fixtureSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tf-output",
+			Namespace: "flux-system",
+			Annotations: map[string]string{
+				runner.ContentHashAnnotation: utils.ContentHash(data),
+			},
+		},
+		Data: data,
+	}
+
+	// untouched secret
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: fixtureSecret.DeepCopy(),
+		ObjectNew: fixtureSecret.DeepCopy(),
+	})).To(BeFalse())
+
+	// secret modified out-of-band
+	tampered := fixtureSecret.DeepCopy()
+	tampered.Data["hello"] = []byte("tampered")
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: fixtureSecret.DeepCopy(),
+		ObjectNew: tampered,
+	})).To(BeTrue())
+
+	// secret without a content hash is not verified
+	noHash := tampered.DeepCopy()
+	noHash.Annotations = nil
+	g.Expect(predicate.Update(event.UpdateEvent{
+		ObjectOld: fixtureSecret.DeepCopy(),
+		ObjectNew: noHash,
+	})).To(BeFalse())
+}
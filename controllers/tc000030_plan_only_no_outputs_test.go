@@ -143,7 +143,7 @@ func Test_000030_plan_only_no_outputs_test(t *testing.T) {
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":    infrav1.ConditionTypePlan,
 		"Reason":  "TerraformPlannedWithChanges",
-		"Pending": "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"Pending": "plan-master-b8e362c206-abbb366287",
 	}))
 
 	time.Sleep(5 * time.Second)
@@ -172,7 +172,7 @@ func Test_000030_plan_only_no_outputs_test(t *testing.T) {
 	}))
 
 	It("should generate the Secret containing the plan named with branch and commit id.")
-	By("checking that the Secret contains plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb in its labels.")
+	By("checking that the Secret contains plan-master-b8e362c206-abbb366287 in its labels.")
 	tfplanKey := types.NamespacedName{Namespace: "flux-system", Name: "tfplan-default-" + terraformName}
 	tfplanSecret := corev1.Secret{}
 	g.Eventually(func() map[string]interface{} {
@@ -186,7 +186,7 @@ func Test_000030_plan_only_no_outputs_test(t *testing.T) {
 			"HasEncodingAnnotation": tfplanSecret.Annotations["encoding"] != "" && tfplanSecret.Annotations["encoding"] == "gzip",
 		}
 	}, timeout, interval).Should(Equal(map[string]interface{}{
-		"SavedPlan":             "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"SavedPlan":             "plan-master-b8e362c206-abbb366287",
 		"TFPlanEmpty":           false,
 		"HasEncodingAnnotation": true,
 	}))
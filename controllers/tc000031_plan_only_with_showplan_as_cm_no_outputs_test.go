@@ -144,11 +144,11 @@ func Test_000031_plan_only_with_showplan_as_cm_no_outputs_test(t *testing.T) {
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":    infrav1.ConditionTypePlan,
 		"Reason":  "TerraformPlannedWithChanges",
-		"Pending": "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"Pending": "plan-master-b8e362c206-abbb366287",
 	}))
 
 	It("should generate the Secret containing the plan named with branch and commit id.")
-	By("checking that the Secret contains plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb in its labels.")
+	By("checking that the Secret contains plan-master-b8e362c206-abbb366287 in its labels.")
 	tfplanKey := types.NamespacedName{Namespace: "flux-system", Name: "tfplan-default-" + terraformName}
 	tfplanSecret := corev1.Secret{}
 	g.Eventually(func() map[string]interface{} {
@@ -162,13 +162,13 @@ func Test_000031_plan_only_with_showplan_as_cm_no_outputs_test(t *testing.T) {
 			"HasEncodingAnnotation": tfplanSecret.Annotations["encoding"] == "gzip",
 		}
 	}, timeout, interval).Should(Equal(map[string]interface{}{
-		"SavedPlan":             "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"SavedPlan":             "plan-master-b8e362c206-abbb366287",
 		"TFPlanEmpty":           false,
 		"HasEncodingAnnotation": true,
 	}))
 
 	It("should generate the ConfigMap containing the plan details named with branch and commit id.")
-	By("checking that the ConfigMap contains plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb in its labels.")
+	By("checking that the ConfigMap contains plan-master-b8e362c206-abbb366287 in its labels.")
 	By("checking that the ConfigMap contains the plan details.")
 	tfplanCM := corev1.ConfigMap{}
 	g.Eventually(func() map[string]interface{} {
@@ -181,7 +181,7 @@ func Test_000031_plan_only_with_showplan_as_cm_no_outputs_test(t *testing.T) {
 			"TFPlan":    tfplanCM.Data["tfplan"],
 		}
 	}, timeout, interval).Should(Equal(map[string]interface{}{
-		"SavedPlan": "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"SavedPlan": "plan-master-b8e362c206-abbb366287",
 		"TFPlan": `
 Changes to Outputs:
   + hello_world = "Hello, World!"
@@ -128,7 +128,7 @@ func Test_000051_plan_and_manual_approve_and_replan_no_outputs_test(t *testing.T
 	}, timeout*3, interval).ShouldNot(BeZero())
 
 	Given("the plan id is the `plan` plus the branch name (master) plus the commit id.")
-	const planId = "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+	const planId = "plan-master-b8e362c206-abbb366287"
 
 	By("checking that the planned status of the TF is created successfully.")
 	By("checking the reason is `TerraformPlannedWithChanges`.")
@@ -266,7 +266,7 @@ func Test_000051_plan_and_manual_approve_and_replan_no_outputs_test(t *testing.T
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":            infrav1.ConditionTypeApply,
 		"Reason":          infrav1.TFExecApplySucceedReason,
-		"LastAppliedPlan": "plan-master-ed22ced771a0056455a2fbb8e362c206e3d0cbb7",
+		"LastAppliedPlan": "plan-master-ed22ced771-b0dadc88cd",
 	}))
 	// TODO check Output condition
 
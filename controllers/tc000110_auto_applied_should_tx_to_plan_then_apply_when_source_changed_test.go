@@ -130,7 +130,7 @@ func Test_000110_auto_applied_resource_should_transit_to_plan_then_apply_when_so
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":    infrav1.ConditionTypePlan,
 		"Reason":  "TerraformPlannedWithChanges",
-		"Pending": "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"Pending": "plan-master-b8e362c206-abbb366287",
 		"Message": "Plan generated",
 	}))
 
@@ -148,7 +148,7 @@ func Test_000110_auto_applied_resource_should_transit_to_plan_then_apply_when_so
 			"HasEncodingAnnotation": tfplanSecret.Annotations["encoding"] != "" && tfplanSecret.Annotations["encoding"] == "gzip",
 		}
 	}, timeout, interval).Should(Equal(map[string]interface{}{
-		"SavedPlan":             "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"SavedPlan":             "plan-master-b8e362c206-abbb366287",
 		"TFPlanEmpty":           false,
 		"HasEncodingAnnotation": true,
 	}))
@@ -176,7 +176,7 @@ func Test_000110_auto_applied_resource_should_transit_to_plan_then_apply_when_so
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":            infrav1.ConditionTypeApply,
 		"Reason":          infrav1.TFExecApplySucceedReason,
-		"LastAppliedPlan": "plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"LastAppliedPlan": "plan-master-b8e362c206-abbb366287",
 	}))
 	// TODO check Output condition
 
@@ -241,7 +241,7 @@ func Test_000110_auto_applied_resource_should_transit_to_plan_then_apply_when_so
 			"TFPlanEmpty": string(tfplanSecret.Data["tfplan"]) == "",
 		}
 	}, timeout, interval).Should(Equal(map[string]interface{}{
-		"SavedPlan":   "plan-master-ed22ced771a0056455a2fbb8e362c206e3d0cbb7",
+		"SavedPlan":   "plan-master-ed22ced771-b0dadc88cd",
 		"TFPlanEmpty": false,
 	}))
 
@@ -266,7 +266,7 @@ func Test_000110_auto_applied_resource_should_transit_to_plan_then_apply_when_so
 	}, timeout, interval).Should(Equal(map[string]interface{}{
 		"Type":            infrav1.ConditionTypeApply,
 		"Reason":          infrav1.TFExecApplySucceedReason,
-		"LastAppliedPlan": "plan-master-ed22ced771a0056455a2fbb8e362c206e3d0cbb7",
+		"LastAppliedPlan": "plan-master-ed22ced771-b0dadc88cd",
 		"Pending":         "",
 		"Message":         "Applied successfully",
 	}))
@@ -101,7 +101,7 @@ func Test_000300_targets(t *testing.T) {
 			Interval:                   metav1.Duration{Duration: 5 * time.Second},
 			EnableInventory:            true,
 			DestroyResourcesOnDeletion: true,
-			Targets:                    []string{resourceOne},
+			Targets:                    []infrav1.ResourceAddress{resourceOne},
 			BackendConfig: &infrav1.BackendConfigSpec{
 				SecretSuffix:    terraformName,
 				InClusterConfig: false,
@@ -256,7 +256,7 @@ func Test_000300_targets(t *testing.T) {
 
 	Given("a Terraform object with targets set to resource two")
 	patch := client.MergeFrom(applyTargetTF.DeepCopy())
-	applyTargetTF.Spec.Targets = []string{resourceTwo}
+	applyTargetTF.Spec.Targets = []infrav1.ResourceAddress{resourceTwo}
 	g.Expect(k8sClient.Patch(ctx, &applyTargetTF, patch)).Should(Succeed())
 
 	By("deleting TF object to trigger the destroy planning")
@@ -315,7 +315,7 @@ spec:
     errorMessageTemplate: "SHOULD FAIL Violation: ${{ (index .violations 0).message }}"
   - stage: post-planning
     enabled: false # it's disabled, so it should not be called
-    url: some-invalid-url
+    url: https://some-invalid-url.example.com
     testExpression: "${{ .passed }}"
     errorMessageTemplate: "SHOULD FAIL Violation: ${{ (index .violations 0).message }}"
 `, terraformName, sourceName, server.URL()+"/terraform/admission/pass", server.URL()+"/terraform/admission/fail")), runnerServer.Scheme)
@@ -0,0 +1,361 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/pkg/oci"
+	"github.com/weaveworks/tf-controller/runner"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:webhook:path=/validate-infra-contrib-fluxcd-io-v1alpha1-terraform,mutating=false,failurePolicy=fail,sideEffects=None,groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=create;update,versions=v1alpha1,name=vterraform.infra.contrib.fluxcd.io,admissionReviewVersions=v1
+
+// TerraformValidator rejects the Terraform objects whose spec would fail their reconciliation,
+// when they are created or updated.
+type TerraformValidator struct {
+	// Client looks up the other objects using the backend of an object with keyPrefixPolicy namespaced.
+	// The backends are not checked when it is nil.
+	Client client.Reader
+}
+
+// SetupWebhookWithManager registers the validating webhook of the Terraform objects with the webhook server of the manager.
+func (v *TerraformValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&infrav1.Terraform{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *TerraformValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj)
+}
+
+func (v *TerraformValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	// an object being deleted must stay updatable, for its finalizers to be removed
+	if terraform, ok := newObj.(*infrav1.Terraform); ok && !terraform.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+func (v *TerraformValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *TerraformValidator) validate(ctx context.Context, obj runtime.Object) error {
+	terraform, ok := obj.(*infrav1.Terraform)
+	if !ok {
+		return fmt.Errorf("expected a Terraform, got %T", obj)
+	}
+	errs := validateTerraformSpec(terraform.Spec, field.NewPath("spec"))
+	if len(errs) == 0 && terraform.HasNamespacedStateKey() {
+		backendErrs, err := v.validateNamespacedStateKey(ctx, *terraform, field.NewPath("spec", "backendConfig"))
+		if err != nil {
+			return err
+		}
+		errs = append(errs, backendErrs...)
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(infrav1.GroupVersion.WithKind(infrav1.TerraformKind).GroupKind(), terraform.Name, errs)
+	}
+	return nil
+}
+
+// validateNamespacedStateKey returns the errors of the backend of an object with keyPrefixPolicy namespaced,
+// whose state key can't be prefixed, or which another object already uses.
+func (v *TerraformValidator) validateNamespacedStateKey(ctx context.Context, terraform infrav1.Terraform, path *field.Path) (field.ErrorList, error) {
+	if terraform.Spec.BackendConfig.CustomConfiguration != "" {
+		customConfiguration, err := renderCustomConfiguration(terraform, placeholderSecret(terraform))
+		if err == nil {
+			_, err = applyKeyPrefixPolicy(terraform, customConfiguration)
+		}
+		if err != nil {
+			return field.ErrorList{field.Invalid(path.Child("customConfiguration"), terraform.Spec.BackendConfig.CustomConfiguration, err.Error())}, nil
+		}
+	}
+
+	identity, ok := backendIdentity(terraform)
+	if v.Client == nil || !ok || terraform.Spec.PlanOnly {
+		return nil, nil
+	}
+	var list infrav1.TerraformList
+	if err := v.Client.List(ctx, &list, client.MatchingFields{infrav1.BackendIndexKey: identity}); err != nil {
+		return nil, err
+	}
+	for _, other := range list.Items {
+		if other.Namespace == terraform.Namespace && other.Name == terraform.Name {
+			continue
+		}
+		return field.ErrorList{field.Duplicate(path, fmt.Sprintf("the backend of %s/%s", other.Namespace, other.Name))}, nil
+	}
+	return nil, nil
+}
+
+// approvePlanRegexp matches the ids of the plans, and their prefixes given by the approve messages.
+var approvePlanRegexp = regexp.MustCompile(`^(plan|` + infrav1.DestroyPlanIdPrefix + `)-[A-Za-z0-9._-]+$`)
+
+var webhookPayloadTypes = []string{"SpecAndPlan", "SpecOnly", "PlanOnly"}
+
+// validateTerraformSpec returns the errors of the spec which the CRD schema can't express.
+func validateTerraformSpec(spec infrav1.TerraformSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	switch spec.ApprovePlan {
+	case "", infrav1.ApprovePlanAutoValue, infrav1.ApprovePlanDisableValue:
+	default:
+		if !approvePlanRegexp.MatchString(spec.ApprovePlan) {
+			errs = append(errs, field.Invalid(path.Child("approvePlan"), spec.ApprovePlan,
+				fmt.Sprintf("must be %q, %q, or the id of a plan, e.g. plan-main-b8e362c206", infrav1.ApprovePlanAutoValue, infrav1.ApprovePlanDisableValue)))
+		}
+	}
+
+	if spec.Destroy && spec.Force {
+		errs = append(errs, field.Forbidden(path.Child("force"),
+			"can't be set with destroy, which must go through the approval of its plan, or approvePlan: auto"))
+	}
+	if spec.PlanOnly && spec.Force {
+		errs = append(errs, field.Forbidden(path.Child("force"), "can't be set with planOnly, whose plans are never applied"))
+	}
+	if spec.ManualReconciliation && spec.Interval.Duration != 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), spec.Interval.Duration.String(),
+			"must be 0s with manualReconciliation, which never reconciles on a timer"))
+	}
+
+	switch spec.SourceRef.Kind {
+	case sourcev1.GitRepositoryKind, sourcev1.BucketKind, sourcev1.OCIRepositoryKind:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("sourceRef", "kind"), spec.SourceRef.Kind,
+			[]string{sourcev1.GitRepositoryKind, sourcev1.BucketKind, sourcev1.OCIRepositoryKind}))
+	}
+	if apiVersion := spec.SourceRef.APIVersion; apiVersion != "" && !strings.HasPrefix(apiVersion, sourcev1.GroupVersion.Group+"/") {
+		errs = append(errs, field.Invalid(path.Child("sourceRef", "apiVersion"), apiVersion,
+			fmt.Sprintf("must be a version of %s", sourcev1.GroupVersion.Group)))
+	}
+
+	if len(spec.Webhooks) > 0 && spec.BackendConfig != nil && spec.BackendConfig.Disable {
+		errs = append(errs, field.Forbidden(path.Child("webhooks"),
+			"the post-planning webhooks need the plan, which is not saved with backendConfig.disable"))
+	}
+	if backend := spec.BackendConfig; backend != nil {
+		backendPath := path.Child("backendConfig")
+		if backend.Type == infrav1.BackendTypeRemote {
+			if backend.Remote == nil {
+				errs = append(errs, field.Required(backendPath.Child("remote"), "the remote backend needs its workspace"))
+			}
+			if backend.Disable || backend.CustomConfiguration != "" || backend.ConfigPath != "" {
+				errs = append(errs, field.Forbidden(backendPath.Child("type"),
+					"the remote backend can't be set with disable, customConfiguration or configPath"))
+			}
+			if len(spec.Webhooks) > 0 {
+				errs = append(errs, field.Forbidden(path.Child("webhooks"),
+					"the post-planning webhooks need the plan, which stays in Terraform Cloud with the remote backend"))
+			}
+		} else if backend.Remote != nil {
+			errs = append(errs, field.Forbidden(backendPath.Child("remote"), "requires type: remote"))
+		}
+		typedBackends := map[string]bool{
+			infrav1.BackendTypeS3:      backend.S3 != nil,
+			infrav1.BackendTypeGCS:     backend.GCS != nil,
+			infrav1.BackendTypeAzureRM: backend.AzureRM != nil,
+		}
+		for _, backendType := range []string{infrav1.BackendTypeS3, infrav1.BackendTypeGCS, infrav1.BackendTypeAzureRM} {
+			if backend.Type == backendType && !typedBackends[backendType] {
+				errs = append(errs, field.Required(backendPath.Child(backendType), fmt.Sprintf("the %s backend needs its bucket", backendType)))
+			} else if backend.Type != backendType && typedBackends[backendType] {
+				errs = append(errs, field.Forbidden(backendPath.Child(backendType), "requires type: "+backendType))
+			}
+		}
+		if _, typed := typedBackends[backend.Type]; typed && (backend.Disable || backend.CustomConfiguration != "" || backend.ConfigPath != "") {
+			errs = append(errs, field.Forbidden(backendPath.Child("type"),
+				fmt.Sprintf("the %s backend can't be set with disable, customConfiguration or configPath", backend.Type)))
+		}
+		if backend.KeyPrefixPolicy == infrav1.KeyPrefixPolicyNamespaced && (backend.Disable || backend.Type != "" && backend.Type != infrav1.BackendTypeKubernetes) {
+			errs = append(errs, field.Forbidden(backendPath.Child("keyPrefixPolicy"),
+				"the state key is only prefixed in the backends of customConfiguration"))
+		}
+	}
+
+	if wots := spec.WriteOutputsToSecret; wots != nil {
+		for i, mapping := range wots.OutputMappings {
+			mappingPath := path.Child("writeOutputsToSecret", "outputMappings").Index(i)
+			for _, msg := range validation.IsConfigMapKey(outputMappingKey(mapping)) {
+				errs = append(errs, field.Invalid(mappingPath.Child("key"), outputMappingKey(mapping), msg))
+			}
+			if mapping.JSONPath != "" {
+				if _, err := parseOutputJSONPath(mapping.JSONPath); err != nil {
+					errs = append(errs, field.Invalid(mappingPath.Child("jsonPath"), mapping.JSONPath, err.Error()))
+				}
+			}
+		}
+	}
+	if configMap := spec.WriteOutputsToConfigMap; configMap != nil {
+		for i, mapping := range configMap.Outputs {
+			key := mapping
+			if parts := strings.SplitN(mapping, ":", 2); len(parts) == 2 {
+				key = parts[1]
+			}
+			for _, msg := range validation.IsConfigMapKey(key) {
+				errs = append(errs, field.Invalid(path.Child("writeOutputsToConfigMap", "outputs").Index(i), mapping, msg))
+			}
+		}
+	}
+
+	for _, stage := range []string{runner.StageInit, runner.StagePlan, runner.StageApply, runner.StageDestroy} {
+		for i, arg := range runner.StageArgs(spec.ExtraArgs, stage) {
+			if _, err := runner.ParseExtraArgs(stage, []string{arg}); err != nil {
+				errs = append(errs, field.Invalid(path.Child("extraArgs", stage).Index(i), arg, err.Error()))
+			}
+		}
+	}
+
+	addresses := map[string]bool{}
+	for i, entry := range spec.Imports {
+		importPath := path.Child("imports").Index(i)
+		if entry.Address == "" {
+			errs = append(errs, field.Required(importPath.Child("address"), "an import needs the address of its resource"))
+		} else if addresses[entry.Address] {
+			errs = append(errs, field.Duplicate(importPath.Child("address"), entry.Address))
+		}
+		if entry.ID == "" {
+			errs = append(errs, field.Required(importPath.Child("id"), "an import needs the identifier of its resource"))
+		}
+		addresses[entry.Address] = true
+	}
+
+	if spec.StateBackup != nil {
+		backupPath := path.Child("stateBackup")
+		errs = append(errs, validateSnapshotStorage(spec.StateBackup.SnapshotStorage, backupPath)...)
+		if backend := spec.BackendConfig; backend != nil && (backend.Disable || backend.Type == infrav1.BackendTypeRemote) {
+			errs = append(errs, field.Forbidden(backupPath,
+				"the state is not kept with backendConfig.disable, and stays in Terraform Cloud with the remote backend"))
+		}
+	} else if spec.RestoreStateFrom != "" {
+		errs = append(errs, field.Forbidden(path.Child("restoreStateFrom"), "requires stateBackup, the storage of the snapshots"))
+	}
+	if spec.WorkingDirSnapshot != nil {
+		errs = append(errs, validateSnapshotStorage(spec.WorkingDirSnapshot.SnapshotStorage, path.Child("workingDirSnapshot"))...)
+	}
+
+	urls := map[string]bool{}
+	for i, webhook := range spec.Webhooks {
+		webhookPath := path.Child("webhooks").Index(i)
+		if webhook.Stage != infrav1.PostPlanningWebhook {
+			errs = append(errs, field.NotSupported(webhookPath.Child("stage"), webhook.Stage, []string{infrav1.PostPlanningWebhook}))
+		}
+		if webhook.PayloadType != "" && !containsString(webhookPayloadTypes, webhook.PayloadType) {
+			errs = append(errs, field.NotSupported(webhookPath.Child("payloadType"), webhook.PayloadType, webhookPayloadTypes))
+		}
+		if webhook.IsEnabled() && webhook.TestExpression == "" {
+			errs = append(errs, field.Required(webhookPath.Child("testExpression"), "an enabled webhook must test its response"))
+		}
+		key := webhook.Stage + " " + webhook.URL
+		if urls[key] {
+			errs = append(errs, field.Duplicate(webhookPath.Child("url"), webhook.URL))
+		}
+		urls[key] = true
+	}
+
+	errs = append(errs, validateChecks(spec, path)...)
+
+	return errs
+}
+
+// validateSnapshotStorage checks that the field of the type of a snapshot storage, and only it, is set.
+func validateSnapshotStorage(storage infrav1.SnapshotStorage, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	typedStorages := map[string]bool{
+		infrav1.SnapshotStorageTypeS3:  storage.S3 != nil,
+		infrav1.SnapshotStorageTypeOCI: storage.OCI != nil,
+	}
+	for _, storageType := range []string{infrav1.SnapshotStorageTypeS3, infrav1.SnapshotStorageTypeOCI} {
+		if storage.Type == storageType && !typedStorages[storageType] {
+			errs = append(errs, field.Required(path.Child(storageType), fmt.Sprintf("the %s storage needs its configuration", storageType)))
+		} else if storage.Type != storageType && typedStorages[storageType] {
+			errs = append(errs, field.Forbidden(path.Child(storageType), "requires type: "+storageType))
+		}
+	}
+	if storage.Type == infrav1.SnapshotStorageTypeOCI && storage.OCI != nil {
+		if _, _, err := oci.ParseRepository(storage.OCI.Repository); err != nil {
+			errs = append(errs, field.Invalid(path.Child("oci", "repository"), storage.OCI.Repository, err.Error()))
+		}
+	}
+	return errs
+}
+
+// checkTypeFields are the fields of infrav1.Check holding the configuration of each type.
+var checkTypeFields = map[string]string{
+	infrav1.CheckTypeWebhook:        "webhook",
+	infrav1.CheckTypeResourceLimits: "resourceLimits",
+	infrav1.CheckTypePolicy:         "policy",
+	infrav1.CheckTypeHealthCheck:    "healthCheck",
+}
+
+func validateChecks(spec infrav1.TerraformSpec, path *field.Path) field.ErrorList {
+	if len(spec.Checks) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for name, set := range map[string]bool{
+		"webhooks":       len(spec.Webhooks) > 0,
+		"resourceLimits": spec.ResourceLimits != nil,
+		"policyChecks":   len(spec.PolicyChecks) > 0,
+		"healthChecks":   len(spec.HealthChecks) > 0,
+	} {
+		if set {
+			errs = append(errs, field.Forbidden(path.Child(name), "can't be set with checks, which replace it"))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, check := range spec.Checks {
+		checkPath := path.Child("checks").Index(i)
+		if names[check.Name] {
+			errs = append(errs, field.Duplicate(checkPath.Child("name"), check.Name))
+		}
+		names[check.Name] = true
+
+		set := map[string]bool{
+			infrav1.CheckTypeWebhook:        check.Webhook != nil,
+			infrav1.CheckTypeResourceLimits: check.ResourceLimits != nil,
+			infrav1.CheckTypePolicy:         check.Policy != nil,
+			infrav1.CheckTypeHealthCheck:    check.HealthCheck != nil,
+		}
+		for checkType, fieldName := range checkTypeFields {
+			if checkType == check.Type && !set[checkType] {
+				errs = append(errs, field.Required(checkPath.Child(fieldName), fmt.Sprintf("a check of type %s needs its %s", check.Type, fieldName)))
+			} else if checkType != check.Type && set[checkType] {
+				errs = append(errs, field.Forbidden(checkPath.Child(fieldName), fmt.Sprintf("can't be set for a check of type %s", check.Type)))
+			}
+		}
+
+		if postApply := check.Stage == infrav1.CheckStagePostApply; postApply != (check.Type == infrav1.CheckTypeHealthCheck) {
+			errs = append(errs, field.Invalid(checkPath.Child("stage"), check.Stage, "the healthCheck checks, and only them, run post-apply"))
+		}
+		if check.Webhook != nil && check.Webhook.IsEnabled() && check.Webhook.TestExpression == "" {
+			errs = append(errs, field.Required(checkPath.Child("webhook", "testExpression"), "an enabled webhook must test its response"))
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
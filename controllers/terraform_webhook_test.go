@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateTerraformSpec(t *testing.T) {
+	valid := func() infrav1.TerraformSpec {
+		return infrav1.TerraformSpec{
+			ApprovePlan: "auto",
+			SourceRef: infrav1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+				Name: "helloworld",
+			},
+		}
+	}
+	webhook := infrav1.Webhook{
+		Stage:          infrav1.PostPlanningWebhook,
+		URL:            "https://opa.example.com/v1/data",
+		TestExpression: "${{ .result }}",
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*infrav1.TerraformSpec)
+		fields []string
+	}{
+		{name: "valid"},
+		{name: "plan id", mutate: func(s *infrav1.TerraformSpec) { s.ApprovePlan = "plan-main-b8e362c206" }},
+		{name: "destroy plan id", mutate: func(s *infrav1.TerraformSpec) { s.ApprovePlan = "destroy-main-b8e362c206" }},
+		{name: "bad approvePlan", mutate: func(s *infrav1.TerraformSpec) { s.ApprovePlan = "yes" }, fields: []string{"spec.approvePlan"}},
+		{name: "destroy with force", mutate: func(s *infrav1.TerraformSpec) { s.Destroy = true; s.Force = true }, fields: []string{"spec.force"}},
+		{name: "planOnly with force", mutate: func(s *infrav1.TerraformSpec) { s.PlanOnly = true; s.Force = true }, fields: []string{"spec.force"}},
+		{name: "manualReconciliation", mutate: func(s *infrav1.TerraformSpec) { s.ManualReconciliation = true }},
+		{name: "manualReconciliation with an interval", mutate: func(s *infrav1.TerraformSpec) {
+			s.ManualReconciliation = true
+			s.Interval = metav1.Duration{Duration: time.Hour}
+		}, fields: []string{"spec.interval"}},
+		{name: "unknown source kind", mutate: func(s *infrav1.TerraformSpec) { s.SourceRef.Kind = "HelmRepository" }, fields: []string{"spec.sourceRef.kind"}},
+		{name: "foreign source group", mutate: func(s *infrav1.TerraformSpec) { s.SourceRef.APIVersion = "example.com/v1" }, fields: []string{"spec.sourceRef.apiVersion"}},
+		{name: "webhook", mutate: func(s *infrav1.TerraformSpec) { s.Webhooks = []infrav1.Webhook{webhook} }},
+		{name: "webhook stage and payload", mutate: func(s *infrav1.TerraformSpec) {
+			w := webhook
+			w.Stage = "pre-apply"
+			w.PayloadType = "Everything"
+			s.Webhooks = []infrav1.Webhook{w}
+		}, fields: []string{"spec.webhooks[0].stage", "spec.webhooks[0].payloadType"}},
+		{name: "webhook without test", mutate: func(s *infrav1.TerraformSpec) {
+			w := webhook
+			w.TestExpression = ""
+			s.Webhooks = []infrav1.Webhook{w}
+		}, fields: []string{"spec.webhooks[0].testExpression"}},
+		{name: "duplicate webhooks", mutate: func(s *infrav1.TerraformSpec) {
+			s.Webhooks = []infrav1.Webhook{webhook, webhook}
+		}, fields: []string{"spec.webhooks[1].url"}},
+		{name: "webhook without backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.Webhooks = []infrav1.Webhook{webhook}
+			s.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+		}, fields: []string{"spec.webhooks"}},
+		{name: "remote backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeRemote, Remote: &infrav1.RemoteBackendSpec{Organization: "weaveworks"}}
+		}},
+		{name: "remote backend without workspace", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeRemote, CustomConfiguration: "backend \"s3\" {}"}
+		}, fields: []string{"spec.backendConfig.remote", "spec.backendConfig.type"}},
+		{name: "remote workspace without remote type", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Remote: &infrav1.RemoteBackendSpec{Organization: "weaveworks"}}
+		}, fields: []string{"spec.backendConfig.remote"}},
+		{name: "s3 backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeS3, S3: &infrav1.S3BackendSpec{Bucket: "tf-states", Region: "eu-west-1"}}
+		}},
+		{name: "gcs backend without bucket", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeGCS, ConfigPath: "/kubeconfig"}
+		}, fields: []string{"spec.backendConfig.gcs", "spec.backendConfig.type"}},
+		{name: "azurerm container without azurerm type", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeS3, AzureRM: &infrav1.AzureRMBackendSpec{ContainerName: "tfstate"}}
+		}, fields: []string{"spec.backendConfig.s3", "spec.backendConfig.azurerm"}},
+		{name: "namespaced key of an s3 backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeS3, S3: &infrav1.S3BackendSpec{Bucket: "tf-states"},
+				KeyPrefixPolicy: infrav1.KeyPrefixPolicyNamespaced}
+		}, fields: []string{"spec.backendConfig.keyPrefixPolicy"}},
+		{name: "namespaced key of a disabled backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.BackendConfig = &infrav1.BackendConfigSpec{Disable: true, KeyPrefixPolicy: infrav1.KeyPrefixPolicyNamespaced}
+		}, fields: []string{"spec.backendConfig.keyPrefixPolicy"}},
+		{name: "output mappings", mutate: func(s *infrav1.TerraformSpec) {
+			s.WriteOutputsToSecret = &infrav1.WriteOutputsToSecretSpec{Name: "outputs", OutputMappings: []infrav1.OutputMapping{
+				{Output: "database", Key: "DB_HOST", JSONPath: "{.endpoint.host}"},
+				{Output: "database", Key: "db/port", JSONPath: "{.endpoint.port"},
+			}}
+		}, fields: []string{"spec.writeOutputsToSecret.outputMappings[1].key", "spec.writeOutputsToSecret.outputMappings[1].jsonPath"}},
+		{name: "outputs ConfigMap keys", mutate: func(s *infrav1.TerraformSpec) {
+			s.WriteOutputsToConfigMap = &infrav1.WriteOutputsToConfigMapSpec{Name: "outputs", Outputs: []string{"endpoint", "url:api.url", "zone:dns/zone"}}
+		}, fields: []string{"spec.writeOutputsToConfigMap.outputs[2]"}},
+		{name: "extra args", mutate: func(s *infrav1.TerraformSpec) {
+			s.ExtraArgs = &infrav1.ExtraArgs{
+				Init:  []string{"-upgrade", "-lock-timeout=5m"},
+				Plan:  []string{"-refresh=false", "-target=aws_vpc.main", "-auto-approve"},
+				Apply: []string{"-parallelism=0", "-target=aws_vpc.main"},
+			}
+		}, fields: []string{"spec.extraArgs.plan[2]", "spec.extraArgs.apply[0]", "spec.extraArgs.apply[1]"}},
+		{name: "checks", mutate: func(s *infrav1.TerraformSpec) {
+			s.Checks = []infrav1.Check{
+				{Name: "opa", Type: infrav1.CheckTypePolicy, Policy: &infrav1.PolicyCheck{Name: "policies"}},
+				{Name: "review", Type: infrav1.CheckTypeWebhook, Webhook: &webhook},
+				{Name: "api", Stage: infrav1.CheckStagePostApply, Type: infrav1.CheckTypeHealthCheck, HealthCheck: &infrav1.HealthCheck{Name: "api", Type: "http", URL: "https://api.example.com"}},
+			}
+		}},
+		{name: "invalid checks", mutate: func(s *infrav1.TerraformSpec) {
+			s.PolicyChecks = []infrav1.PolicyCheck{{Name: "policies"}}
+			s.Checks = []infrav1.Check{
+				{Name: "opa", Type: infrav1.CheckTypePolicy, Webhook: &webhook},
+				{Name: "opa", Type: infrav1.CheckTypeResourceLimits, ResourceLimits: &infrav1.ResourceLimits{}},
+				{Name: "api", Type: infrav1.CheckTypeHealthCheck, HealthCheck: &infrav1.HealthCheck{Name: "api", Type: "tcp"}},
+				{Name: "review", Type: infrav1.CheckTypeWebhook, Webhook: &infrav1.Webhook{URL: webhook.URL}},
+			}
+		}, fields: []string{
+			"spec.checks[0].policy", "spec.checks[0].webhook", "spec.checks[1].name", "spec.checks[2].stage",
+			"spec.checks[3].webhook.testExpression", "spec.policyChecks",
+		}},
+		{name: "invalid imports", mutate: func(s *infrav1.TerraformSpec) {
+			s.Imports = []infrav1.Import{
+				{Address: "aws_s3_bucket.logs", ID: "logs"},
+				{Address: "aws_s3_bucket.logs", ID: "other-logs"},
+				{Address: "aws_vpc.main"},
+			}
+		}, fields: []string{"spec.imports[1].address", "spec.imports[2].id"}},
+		{name: "state backup to s3", mutate: func(s *infrav1.TerraformSpec) {
+			s.StateBackup = &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{
+				Type: infrav1.SnapshotStorageTypeS3,
+				S3:   &infrav1.S3SnapshotStorage{Bucket: "tfstate-snapshots", Region: "eu-west-1"},
+			}}
+			s.RestoreStateFrom = "20230201-100530"
+		}},
+		{name: "invalid state backup", mutate: func(s *infrav1.TerraformSpec) {
+			s.StateBackup = &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{
+				Type: infrav1.SnapshotStorageTypeOCI,
+				S3:   &infrav1.S3SnapshotStorage{Bucket: "tfstate-snapshots", Region: "eu-west-1"},
+			}}
+		}, fields: []string{"spec.stateBackup.s3", "spec.stateBackup.oci"}},
+		{name: "invalid oci repository of the state backup", mutate: func(s *infrav1.TerraformSpec) {
+			s.StateBackup = &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{
+				Type: infrav1.SnapshotStorageTypeOCI,
+				OCI:  &infrav1.OCISnapshotStorage{Repository: "tfstate:latest"},
+			}}
+		}, fields: []string{"spec.stateBackup.oci.repository"}},
+		{name: "state backup without backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.StateBackup = &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{Type: infrav1.SnapshotStorageTypeSecret}}
+			s.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+		}, fields: []string{"spec.stateBackup"}},
+		{name: "invalid working dir snapshot", mutate: func(s *infrav1.TerraformSpec) {
+			s.WorkingDirSnapshot = &infrav1.WorkingDirSnapshot{SnapshotStorage: infrav1.SnapshotStorage{Type: infrav1.SnapshotStorageTypeS3}}
+		}, fields: []string{"spec.workingDirSnapshot.s3"}},
+		{name: "restore without state backup", mutate: func(s *infrav1.TerraformSpec) {
+			s.RestoreStateFrom = "20230201-100530"
+		}, fields: []string{"spec.restoreStateFrom"}},
+		{name: "webhook with remote backend", mutate: func(s *infrav1.TerraformSpec) {
+			s.Webhooks = []infrav1.Webhook{webhook}
+			s.BackendConfig = &infrav1.BackendConfigSpec{Type: infrav1.BackendTypeRemote, Remote: &infrav1.RemoteBackendSpec{Organization: "weaveworks"}}
+		}, fields: []string{"spec.webhooks"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			spec := valid()
+			if tt.mutate != nil {
+				tt.mutate(&spec)
+			}
+			var fields []string
+			for _, err := range validateTerraformSpec(spec, field.NewPath("spec")) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.fields))
+		})
+	}
+}
+
+func TestTerraformValidator(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	v := &TerraformValidator{}
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			Destroy:   true,
+			Force:     true,
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "helloworld"},
+		},
+	}
+	err := v.ValidateCreate(ctx, terraform)
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("spec.force")))
+
+	// the objects being deleted are let through, to remove their finalizers
+	now := metav1.Now()
+	terraform.DeletionTimestamp = &now
+	g.Expect(v.ValidateUpdate(ctx, terraform, terraform)).To(Succeed())
+	g.Expect(v.ValidateDelete(ctx, terraform)).To(Succeed())
+}
+
+// backendIndexReader lists the Terraform objects by the identity of their backend, which the fake client can't index.
+type backendIndexReader struct {
+	client.Client
+}
+
+func (r backendIndexReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	identity, _ := listOpts.FieldSelector.RequiresExactMatch(infrav1.BackendIndexKey)
+
+	var all infrav1.TerraformList
+	if err := r.Client.List(ctx, &all); err != nil {
+		return err
+	}
+	terraforms := list.(*infrav1.TerraformList)
+	for _, item := range all.Items {
+		if id, ok := backendIdentity(item); ok && id == identity {
+			terraforms.Items = append(terraforms.Items, item)
+		}
+	}
+	return nil
+}
+
+func TestTerraformValidatorNamespacedStateKey(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	newTerraform := func(name, key string) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system"},
+			Spec: infrav1.TerraformSpec{
+				SourceRef: infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "helloworld"},
+				BackendConfig: &infrav1.BackendConfigSpec{
+					KeyPrefixPolicy:     infrav1.KeyPrefixPolicyNamespaced,
+					CustomConfiguration: "backend \"s3\" {\n  bucket = \"state\"\n  key = \"" + key + "\"\n}",
+				},
+			},
+		}
+	}
+	// an object without the policy, declaring the key generated for helloworld
+	existing := newTerraform("existing", "flux-system/helloworld/terraform.tfstate")
+	existing.Spec.BackendConfig.KeyPrefixPolicy = ""
+	existing.Spec.BackendConfig.CustomConfiguration = "backend \"s3\" {\n  bucket = \"state\"\n  key    = \"flux-system/helloworld/terraform.tfstate\"\n}"
+	v := &TerraformValidator{Client: backendIndexReader{fake.NewClientBuilder().WithScheme(testScheme).WithObjects(existing).Build()}}
+
+	g.Expect(v.ValidateCreate(ctx, newTerraform("other", "terraform.tfstate"))).To(Succeed())
+
+	err := v.ValidateCreate(ctx, newTerraform("helloworld", "terraform.tfstate"))
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("the backend of flux-system/existing")))
+
+	invalid := newTerraform("helloworld", "")
+	invalid.Spec.BackendConfig.CustomConfiguration = "backend \"pg\" {}"
+	err = v.ValidateCreate(ctx, invalid)
+	g.Expect(err).To(MatchError(ContainSubstring("spec.backendConfig.customConfiguration")))
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// receiverTokenCheckInterval is how often the token of a receiver is read again,
+// as its Secret is not watched.
+const receiverTokenCheckInterval = 10 * time.Minute
+
+// TerraformReceiverReconciler publishes the webhook path of the TerraformReceiver objects.
+type TerraformReceiverReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformreceivers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformreceivers/status,verbs=get;update;patch
+
+func (r *TerraformReceiverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var receiver infrav1.TerraformReceiver
+	if err := r.Get(ctx, req.NamespacedName, &receiver); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	patch := client.MergeFrom(receiver.DeepCopy())
+
+	token, err := r.getToken(ctx, receiver)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		msg := fmt.Sprintf("Secret %s not found", receiver.Spec.SecretRef.Name)
+		log.Info(msg)
+		receiver = infrav1.TerraformReceiverNotReady(receiver, infrav1.ReceiverTokenNotFoundReason, msg)
+	} else if token == "" {
+		msg := fmt.Sprintf("Secret %s has no %s key", receiver.Spec.SecretRef.Name, infrav1.ReceiverTokenKey)
+		log.Info(msg)
+		receiver = infrav1.TerraformReceiverNotReady(receiver, infrav1.ReceiverTokenNotFoundReason, msg)
+	} else {
+		receiver = infrav1.TerraformReceiverReady(receiver, receiverWebhookPath(token, receiver.Name, receiver.Namespace))
+	}
+
+	if err := r.Status().Patch(ctx, &receiver, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: receiverTokenCheckInterval}, nil
+}
+
+func (r *TerraformReceiverReconciler) getToken(ctx context.Context, receiver infrav1.TerraformReceiver) (string, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: receiver.Namespace, Name: receiver.Spec.SecretRef.Name}
+	if err := r.Get(ctx, key, &secret); err != nil {
+		return "", err
+	}
+	return string(secret.Data[infrav1.ReceiverTokenKey]), nil
+}
+
+// receiverWebhookPath derives the path of a receiver from its token, so that it cannot be guessed.
+func receiverWebhookPath(token, name, namespace string) string {
+	return fmt.Sprintf("%s%x", receiverPathPrefix, sha256.Sum256([]byte(token+name+namespace)))
+}
+
+func (r *TerraformReceiverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.TerraformReceiver{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
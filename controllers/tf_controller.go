@@ -17,16 +17,13 @@ limitations under the License.
 package controllers
 
 import (
-	"bytes"
 	"context"
-	"crypto/sha1"
-	"crypto/sha256"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fluxcd/pkg/apis/meta"
@@ -45,6 +42,7 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kuberecorder "k8s.io/client-go/tools/record"
@@ -64,7 +62,9 @@ import (
 // TerraformReconciler reconciles a Terraform object
 type TerraformReconciler struct {
 	client.Client
-	httpClient        *retryablehttp.Client
+	httpClient *retryablehttp.Client
+	// remoteHTTPClient is the client of the API of the remote backend, http.DefaultClient when nil.
+	remoteHTTPClient  *http.Client
 	statusManager     string
 	requeueDependency time.Duration
 
@@ -76,15 +76,69 @@ type TerraformReconciler struct {
 	RunnerGRPCPort           int
 	RunnerCreationTimeout    time.Duration
 	RunnerGRPCMaxMessageSize int
+	RunnerHeartbeatInterval  time.Duration
+	Config                   *ControllerConfigWatcher
+
+	// BreakGlassKey signs the break-glass tokens, which are rejected when it is empty.
+	BreakGlassKey []byte
+
+	// AllowedRunnerRoles are the names of the Roles and ClusterRoles that the ServiceAccounts created with
+	// runner.createServiceAccount may be bound to. Only tf-runner-role is allowed when it is empty.
+	AllowedRunnerRoles []string
+
+	// AllowCrossNamespaceOutputs allows the outputs Secret to be written to another namespace than the object's.
+	AllowCrossNamespaceOutputs bool
+
+	// AllowedOutputsNamespaces are the namespaces the outputs Secrets may be written to, besides the object's,
+	// with AllowCrossNamespaceOutputs.
+	AllowedOutputsNamespaces []string
+
+	// AllowedOutputsKinds are the kinds of the objects the outputs may be written to with writeOutputsTo,
+	// as Kind.group, or Kind for the core group.
+	AllowedOutputsKinds []string
+
+	// ProviderSchemas caches the provider schemas of the plans, which are not fetched when it is nil.
+	ProviderSchemas *ProviderSchemaCache
+
+	// ApprovalWorkers reconcile the objects whose plan gets approved, ahead of the other objects. Disabled when 0.
+	ApprovalWorkers int
+
+	// ArtifactFetchMode is how the runners get the source artifacts, ArtifactFetchModeController when empty.
+	ArtifactFetchMode string
+
+	runsMu     sync.Mutex
+	activeRuns int
+	locks      objectLocks
+
+	controller     controller.Controller
+	watchedKindsMu sync.Mutex
+	watchedKinds   map[schema.GroupKind]bool
 }
 
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraforms/finalizers,verbs=get;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=providerconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformproviders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformnamespaceconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=changefreezes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformdriftreports,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups=infra.contrib.fluxcd.io,resources=terraformdriftreports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=buckets;gitrepositories;ocirepositories,verbs=get;list;watch
 //+kubebuilder:rbac:groups=source.toolkit.fluxcd.io,resources=buckets/status;gitrepositories/status;ocirepositories/status,verbs=get
 //+kubebuilder:rbac:groups="",resources=configmaps;secrets;serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;update;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=create
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=bind,resourceNames=tf-runner-role
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=external-secrets.io,resources=pushsecrets,verbs=get;create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -124,6 +178,29 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	log.Info(fmt.Sprintf(">> Started Generation: %d", terraform.GetGeneration()))
 
+	// with the approval controller, the object may be reconciled by both controllers
+	if !r.locks.tryLock(req.NamespacedName) {
+		log.Info("the object is being reconciled by another controller, requeueing")
+		return ctrl.Result{RequeueAfter: objectBusyRequeue}, nil
+	}
+	defer r.locks.unlock(req.NamespacedName)
+
+	// the objects reconciled before their namespace was disallowed are still deleted, not to hang on their finalizer
+	if !r.Config.Get().IsNamespaceAllowed(terraform.Namespace) {
+		if !isBeingDeleted(terraform) || !controllerutil.ContainsFinalizer(&terraform, infrav1.TerraformFinalizer) {
+			log.Info("namespace is not allowed by the controller config, skipping", "namespace", terraform.Namespace)
+			return ctrl.Result{}, nil
+		}
+		log.Info("namespace is not allowed by the controller config, proceeding with the deletion", "namespace", terraform.Namespace)
+	}
+
+	// Record the reconcile request as handled on every return, for `flux reconcile`
+	// and the other clients waiting for it
+	if requestedAt, ok := meta.ReconcileAnnotationValue(terraform.GetAnnotations()); ok &&
+		requestedAt != terraform.Status.GetLastHandledReconcileRequest() {
+		defer r.recordReconcileRequest(ctx, req.NamespacedName, requestedAt)
+	}
+
 	// Record suspended status metric
 	traceLog.Info("Defer metrics for suspended records")
 	defer r.recordSuspensionMetric(ctx, terraform)
@@ -148,6 +225,37 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// Inherit the defaults of the namespace, on every reconciliation including the deletion
+	defaulted, err := r.applyNamespaceDefaults(ctx, terraform)
+	if err != nil {
+		log.Error(err, "unable to apply the defaults of the namespace")
+		terraform = infrav1.TerraformNotReady(terraform, "", infrav1.NamespaceConfigInvalidReason, err.Error())
+		if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+			log.Error(err, "unable to update status")
+			return ctrl.Result{Requeue: true}, err
+		}
+		return r.retryResult(terraform), nil
+	}
+	terraform = defaulted
+
+	// the outputs of the other objects are read on every reconciliation, for the destroy plans to get them too
+	if len(terraform.Spec.ReadOutputsFromTF) > 0 {
+		withOutputs, err := r.readOutputsFromTF(ctx, terraform)
+		if err != nil && !isBeingDeleted(terraform) {
+			log.Error(err, "unable to read the outputs of the Terraform objects")
+			terraform = infrav1.TerraformNotReady(terraform, "", infrav1.UpstreamOutputsMissingReason, err.Error())
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status")
+				return ctrl.Result{Requeue: true}, err
+			}
+			return r.retryResult(terraform), nil
+		} else if err != nil {
+			// the other objects may already be deleted, the destroy plan then reports the missing variables
+			log.Error(err, "unable to read the outputs of the Terraform objects for the deletion")
+		}
+		terraform = withOutputs
+	}
+
 	// Examine if the object is under deletion
 	if isBeingDeleted(terraform) {
 		dependants := []string{}
@@ -158,14 +266,59 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 
 		if len(dependants) > 0 {
+			order, err := r.dependantsTeardownOrder(ctx, terraform)
+			if err != nil {
+				log.Error(err, "unable to list the dependants")
+				return ctrl.Result{Requeue: true}, err
+			}
+			// the dependants are listed in the order they must be deleted, the finalizers only name the direct ones
+			if len(order) > 0 {
+				dependants = teardownNames(terraform, order)
+			}
+
 			msg := fmt.Sprintf("Deletion in progress, but blocked. Please delete %s to resume ...", strings.Join(dependants, ", "))
+			if terraform.Spec.DeleteDependants {
+				deleted, err := r.deleteDependants(ctx, terraform, order)
+				if len(deleted) > 0 {
+					r.event(ctx, terraform, terraform.Status.LastAttemptedRevision, events.EventSeverityInfo,
+						fmt.Sprintf("Deleting the dependants first: %s", strings.Join(deleted, ", ")), nil)
+				}
+				if err != nil {
+					log.Error(err, "unable to delete the dependants")
+					return ctrl.Result{Requeue: true}, err
+				}
+				msg = fmt.Sprintf("Deletion in progress, waiting for the dependants to be deleted first, in order: %s", strings.Join(dependants, ", "))
+			}
 			terraform = infrav1.TerraformNotReady(terraform, "", infrav1.DeletionBlockedByDependants, msg)
 			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
 				log.Error(err, "unable to update status")
 				return ctrl.Result{Requeue: true}, err
 			}
 
-			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+			return r.retryResult(terraform), nil
+		}
+	}
+
+	// Return early if the object is stalled, until its failures are acknowledged.
+	if !isBeingDeleted(terraform) {
+		stalled, err := r.isStalled(ctx, &terraform)
+		if err != nil {
+			log.Error(err, "unable to update status after the failures have been acknowledged")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if stalled {
+			log.Info("Reconciliation is stalled, waiting for the failures to be acknowledged")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// report the objects of the inventory deleted out-of-band, without waiting for a plan
+	if terraform.Spec.WatchClusterInventory && !isBeingDeleted(terraform) {
+		var err error
+		terraform, err = r.crossCheckClusterInventory(ctx, terraform)
+		if err != nil {
+			log.Error(err, "unable to cross-check the objects of the inventory")
+			return ctrl.Result{Requeue: true}, err
 		}
 	}
 
@@ -177,17 +330,7 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		traceLog.Info("Is the error a NotFound error")
 		if apierrors.IsNotFound(err) {
 			traceLog.Info("The Source was not found")
-			msg := fmt.Sprintf("Source '%s' not found", terraform.Spec.SourceRef.String())
-			terraform = infrav1.TerraformNotReady(terraform, "", infrav1.ArtifactFailedReason, msg)
-			traceLog.Info("Patch the Terraform resource Status with NotReady")
-			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
-				log.Error(err, "unable to update status for source not found")
-				return ctrl.Result{Requeue: true}, err
-			}
-			r.recordReadinessMetric(ctx, terraform)
-			log.Info(msg)
-			// do not requeue immediately, when the source is created the watcher should trigger a reconciliation
-			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+			return r.sourceNotFound(ctx, terraform)
 		} else {
 			// retry on transient errors
 			log.Error(err, "retry")
@@ -195,6 +338,12 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	terraform, err = r.sourceFound(ctx, terraform)
+	if err != nil {
+		log.Error(err, "unable to update status after the source has been found")
+		return ctrl.Result{Requeue: true}, err
+	}
+
 	// sourceObj does not exist, return early
 	traceLog.Info("Check we have a source object")
 	if sourceObj.GetArtifact() == nil {
@@ -208,7 +357,7 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		r.recordReadinessMetric(ctx, terraform)
 		log.Info(msg)
 		// do not requeue immediately, when the artifact is created the watcher should trigger a reconciliation
-		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		return r.retryResult(terraform), nil
 	}
 
 	// check dependencies, if not being deleted
@@ -223,16 +372,84 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 			// we can't rely on exponential backoff because it will prolong the execution too much,
 			// instead we requeue on a fix interval.
-			msg := fmt.Sprintf("Dependencies do not meet ready condition, retrying in %s", terraform.GetRetryInterval().String())
+			result := r.retryResult(terraform)
+			msg := fmt.Sprintf("Dependencies do not meet ready condition, retrying in %s", result.RequeueAfter.String())
+			if result.RequeueAfter == 0 {
+				msg = "Dependencies do not meet ready condition, waiting for a manual reconciliation"
+			}
 			log.Info(msg)
 			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, events.EventSeverityInfo, msg, nil)
 			r.recordReadinessMetric(ctx, terraform)
 
-			return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+			return result, nil
 		}
 		log.Info("All dependencies are ready, proceeding with reconciliation")
 	}
 
+	// make sure this object does not write the state of another object
+	if !isBeingDeleted(terraform) {
+		var blocked bool
+		terraform, blocked, err = r.checkBackendConflict(ctx, terraform, sourceObj.GetArtifact().Revision)
+		if err != nil {
+			log.Error(err, "unable to check for backend conflicts")
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		if blocked {
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status after checking for backend conflicts")
+				return ctrl.Result{Requeue: true}, err
+			}
+
+			msg := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition).Message
+			log.Info(msg)
+			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, events.EventSeverityError, msg, nil)
+			r.recordReadinessMetric(ctx, terraform)
+			return r.retryResult(terraform), nil
+		}
+	}
+
+	// make sure this object does not silently take over a state it does not own,
+	// unless it is about to replace it with one of its snapshots
+	var stateMissing bool
+	if !isBeingDeleted(terraform) && !shouldRestoreState(terraform) {
+		lineage := terraform.StateLineage()
+		var blocked bool
+		terraform, blocked, stateMissing, err = r.checkStateLineage(ctx, terraform, sourceObj.GetArtifact().Revision)
+		if err != nil {
+			log.Error(err, "unable to check the state lineage")
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		if blocked || lineage != terraform.StateLineage() {
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status after checking the state lineage")
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
+
+		if blocked {
+			msg := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition).Message
+			log.Info(msg)
+			r.event(ctx, terraform, sourceObj.GetArtifact().Revision, events.EventSeverityError, msg, nil)
+			r.recordReadinessMetric(ctx, terraform)
+			return r.retryResult(terraform), nil
+		}
+	}
+
+	// The runner pod kept after a failed reconciliation must not be reused, not to overwrite its working directory
+	if !isBeingDeleted(terraform) && !terraform.IsRemote() && os.Getenv("INSECURE_LOCAL_RUNNER") != "1" {
+		keptFor, err := r.waitForKeptRunnerPod(ctx, terraform, time.Now())
+		if err != nil {
+			log.Error(err, "unable to check the kept runner pod")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if keptFor > 0 {
+			log.Info("waiting for the runner pod kept after the failed reconciliation", "wait", keptFor.String())
+			return ctrl.Result{RequeueAfter: keptFor}, nil
+		}
+	}
+
 	// Skip update the status if the ready condition is still unknown
 	// so that the Plan prompt is still shown.
 	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
@@ -254,6 +471,17 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		r.recordReadinessMetric(ctx, terraform)
 	}
 
+	if !r.acquireRun() {
+		log.Info("maximum number of concurrent runs reached, requeueing")
+		return ctrl.Result{RequeueAfter: r.requeueDependency}, nil
+	}
+	defer r.releaseRun()
+
+	// the plans and the applies of the remote backend run in Terraform Cloud, without runner
+	if terraform.IsRemote() {
+		return r.reconcileRemote(ctx, terraform, sourceObj)
+	}
+
 	// Create Runner Pod.
 	// Wait for the Runner Pod to start.
 	traceLog.Info("Fetch/Create Runner pod for this Terraform resource")
@@ -269,6 +497,9 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	log.Info("runner is running")
 
+	// runFailed keeps the runner pod of a failed reconciliation, with keepFailedPodsFor
+	runFailed := false
+
 	traceLog.Info("Defer function to handle clean up")
 	defer func(ctx context.Context, cli client.Client, terraform infrav1.Terraform) {
 		traceLog.Info("Check for closeConn function")
@@ -287,6 +518,14 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return
 		}
 
+		if runFailed && keepFailedRunnerPodFor(terraform) > 0 {
+			err := r.keepFailedRunnerPod(ctx, terraform, sourceObj.GetArtifact().Revision, time.Now())
+			if err == nil {
+				return
+			}
+			log.Error(err, "unable to keep the runner pod of the failed reconciliation")
+		}
+
 		traceLog.Info("Check if we need to clean up the Runner pod")
 		if terraform.Spec.GetAlwaysCleanupRunnerPod() == true {
 			// wait for runner pod complete termination
@@ -330,9 +569,7 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	traceLog.Info("Check for deletion timestamp to finalize")
 	if !terraform.ObjectMeta.DeletionTimestamp.IsZero() {
 		traceLog.Info("Calling finalize function")
-		if result, err := r.finalize(ctx, terraform, runnerClient, sourceObj, reconciliationLoopID); err != nil {
-			return result, err
-		}
+		return r.finalize(ctx, terraform, runnerClient, sourceObj, reconciliationLoopID)
 	}
 
 	// If revision is changed, and there's no intend to apply,
@@ -347,16 +584,71 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// If the resources to replace have changed since the pending plan, we re-plan with them
+	if replaces, _ := pendingReplacements(terraform); !terraform.Spec.Destroy && terraform.Status.Plan.Pending != "" && replaces != terraform.Status.Plan.Replaces && !r.shouldApply(terraform) {
+		traceLog.Info("Clear the pending plan for the resources to replace")
+		terraform.Status.Plan.Pending = ""
+		if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+			log.Error(err, "unable to update status to clear pending plan (resources to replace changed)")
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	// Invalidate the pending plan, and flag the outputs, if the secrets
+	// generated for them have been deleted or modified out-of-band
+	traceLog.Info("Verify the content of the generated secrets")
+	terraform, err = r.verifyGeneratedSecrets(ctx, terraform)
+	if err != nil {
+		log.Error(err, "unable to verify the generated secrets")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	// A deleted outputs Secret is written again from the state, without waiting for the next plan and apply
+	if r.shouldRegenerateOutputs(terraform) {
+		traceLog.Info("Regenerate the outputs")
+		// a failure leaves the outputs to the plan and apply of the reconciliation
+		if terraform, err = r.regenerateOutputs(ctx, runnerClient, terraform, sourceObj, reconciliationLoopID); err != nil {
+			log.Error(err, "unable to regenerate the outputs")
+		}
+	} else if r.shouldRefreshOutputs(terraform, time.Now()) {
+		traceLog.Info("Refresh the outputs")
+		if terraform, err = r.refreshOutputs(ctx, runnerClient, terraform, sourceObj, reconciliationLoopID); err != nil {
+			log.Error(err, "unable to refresh the outputs")
+		}
+	}
+
+	// A pending plan may be approved by the approval of its merge request
+	if terraform.Status.Plan.Pending != "" && pullRequestApprovesPlans(terraform) && !r.forceOrAutoApply(terraform) && !r.shouldApply(terraform) {
+		traceLog.Info("Check the approvals of the merge requests")
+		if terraform, err = r.checkPullRequestApproval(ctx, terraform); err != nil {
+			log.Error(err, "unable to check the approvals of the merge requests")
+		} else if terraform.Status.Plan.ApprovedBy != "" {
+			if err := r.patchStatus(ctx, req.NamespacedName, terraform.Status); err != nil {
+				log.Error(err, "unable to update status with the approval of the plan")
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
+	}
+
 	// Return early if it's manually mode and pending
 	traceLog.Info("Check for pending plan, forceOrAutoApply and shouldApply")
 	if terraform.Status.Plan.Pending != "" && !r.forceOrAutoApply(terraform) && !r.shouldApply(terraform) {
 		log.Info("reconciliation is stopped to wait for a manual approve")
-		return ctrl.Result{}, nil
+		if pullRequestApprovesPlans(terraform) {
+			// the approvals of the merge requests are polled
+			return requeueForOutputsRefresh(terraform, ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}), nil
+		}
+		return requeueForOutputsRefresh(terraform, ctrl.Result{}), nil
 	}
 
 	// reconcile Terraform by applying the latest revision
 	traceLog.Info("Run reconcile for the Terraform resource")
 	reconciledTerraform, reconcileErr := r.reconcile(ctx, runnerClient, *terraform.DeepCopy(), sourceObj, reconciliationLoopID)
+	runFailed = reconcileErr != nil
+	if stateMissing {
+		// the state this object has just created is its own
+		*reconciledTerraform = r.recordCreatedStateLineage(ctx, *reconciledTerraform)
+	}
 	traceLog.Info("Patch the status of the Terraform resource")
 	if err := r.patchStatus(ctx, req.NamespacedName, reconciledTerraform.Status); err != nil {
 		log.Error(err, "unable to update status after the reconciliation is complete")
@@ -367,51 +659,99 @@ func (r *TerraformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	r.recordReadinessMetric(ctx, *reconciledTerraform)
 
 	traceLog.Info("Check for reconciliation errors")
+	if reconcileErr != nil && isApplyInterrupted(*reconciledTerraform) {
+		log.Info("Apply interrupted by the termination of the runner, resuming with a new runner")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	retryInterval := r.retryInterval(terraform)
+	if terraform.IsManualReconciliation() && reconcileErr != nil {
+		log.Error(reconcileErr, fmt.Sprintf("Reconciliation failed after %s, waiting for a manual reconciliation",
+			time.Since(reconcileStart).String()),
+			"revision",
+			sourceObj.GetArtifact().Revision)
+		if reconcileErr.Error() != infrav1.DriftDetectedReason {
+			r.event(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, events.EventSeverityError, reconcileErr.Error(), nil)
+			if _, err := r.recordFailure(ctx, reconciledTerraform, sourceObj.GetArtifact().Revision, reconcileErr); err != nil {
+				log.Error(err, "unable to update status after recording the failure")
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if reconcileErr != nil && reconcileErr.Error() == infrav1.DriftDetectedReason {
 		log.Error(reconcileErr, fmt.Sprintf("Drift detected after %s, next try in %s",
 			time.Since(reconcileStart).String(),
-			terraform.GetRetryInterval().String()),
+			retryInterval.String()),
 			"revision",
 			sourceObj.GetArtifact().Revision)
-		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		return ctrl.Result{RequeueAfter: retryInterval}, nil
 	} else if reconcileErr != nil {
 		// broadcast the reconciliation failure and requeue at the specified retry interval
 		log.Error(reconcileErr, fmt.Sprintf("Reconciliation failed after %s, next try in %s",
 			time.Since(reconcileStart).String(),
-			terraform.GetRetryInterval().String()),
+			retryInterval.String()),
 			"revision",
 			sourceObj.GetArtifact().Revision)
 		traceLog.Info("Record an event for the failure")
-		r.event(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, events.EventSeverityError, reconcileErr.Error(), nil)
-		return ctrl.Result{RequeueAfter: terraform.GetRetryInterval()}, nil
+		r.event(ctx, *reconciledTerraform, sourceObj.GetArtifact().Revision, events.EventSeverityError, reconcileErr.Error(), map[string]string{
+			infrav1.EventMetadataDurationKey: durationSeconds(time.Since(reconcileStart)),
+		})
+
+		traceLog.Info("Count the failure, and stop retrying if it failed too many times")
+		stalled, err := r.recordFailure(ctx, reconciledTerraform, sourceObj.GetArtifact().Revision, reconcileErr)
+		if err != nil {
+			log.Error(err, "unable to update status after recording the failure")
+			return ctrl.Result{Requeue: true}, err
+		}
+		if stalled {
+			log.Info("Reconciliation is stalled, waiting for the failures to be acknowledged")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: retryInterval}, nil
 	}
 
 	log.Info(fmt.Sprintf("Reconciliation completed. Generation: %d", reconciledTerraform.GetGeneration()))
 
+	if err := r.resetFailures(ctx, reconciledTerraform); err != nil {
+		log.Error(err, "unable to update status after resetting the failures")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	return r.completedResult(ctx, terraform, *reconciledTerraform), nil
+}
+
+// completedResult returns when to reconcile the object again after a successful reconciliation.
+func (r *TerraformReconciler) completedResult(ctx context.Context, terraform, reconciledTerraform infrav1.Terraform) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.completedResult")
+
 	traceLog.Info("Check for pending plan and forceOrAutoApply")
-	if reconciledTerraform.Status.Plan.Pending != "" && !r.forceOrAutoApply(*reconciledTerraform) {
+	if reconciledTerraform.Status.Plan.Pending != "" && !r.forceOrAutoApply(reconciledTerraform) {
 		log.Info("Reconciliation is stopped to wait for a manual approve")
-		return ctrl.Result{}, nil
+		return requeueForOutputsRefresh(terraform, ctrl.Result{})
+	}
+
+	if terraform.IsManualReconciliation() {
+		log.Info("Reconciliation is manual, waiting for a change or a reconciliation request")
+		return requeueForOutputsRefresh(terraform, ctrl.Result{})
 	}
 
 	// next reconcile is .Spec.Interval in the future
 	log.Info("requeue after interval", "interval", terraform.Spec.Interval.Duration.String())
-	return ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
+	return ctrl.Result{RequeueAfter: r.withJitter(terraform.Spec.Interval.Duration)}
 }
 
 func isBeingDeleted(terraform infrav1.Terraform) bool {
 	return !terraform.ObjectMeta.DeletionTimestamp.IsZero()
 }
 
-// Revision is in main/abcdefabcdefabcdefabcdefabcdefabcdefabcdef format
-// We want to return main/abcdefa
-func shortRev(revision string) string {
-	const maxLength = 8
-	if strings.Contains(revision, "/") {
-		return revision[:strings.Index(revision, "/")+maxLength]
-	} else {
-		return revision[:maxLength]
-	}
+// reconcileRequested reports whether a reconciliation is requested with the reconcile.fluxcd.io/requestedAt
+// annotation, and not handled yet.
+func reconcileRequested(terraform infrav1.Terraform) bool {
+	requestedAt, ok := meta.ReconcileAnnotationValue(terraform.GetAnnotations())
+	return ok && requestedAt != terraform.Status.GetLastHandledReconcileRequest()
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -434,6 +774,42 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 		return fmt.Errorf("failed setting index fields: %w", err)
 	}
 
+	// Index the Terraforms by the ProviderConfig references they (may) point at.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.ProviderConfigIndexKey,
+		r.IndexByProviderConfig); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the TerraformProvider references they (may) point at.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.TerraformProviderIndexKey,
+		r.IndexByTerraformProvider); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the identity of their backend, to detect objects sharing a state.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.BackendIndexKey,
+		r.IndexByBackend); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the Terraform objects whose outputs they read.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.OutputsSourceIndexKey,
+		r.IndexByOutputsSource); err != nil {
+		return err
+	}
+
+	// Index the Terraforms by the identifiers of the cloud resources in their inventory.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.InventoryIndexKey,
+		IndexByInventory); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
+	// Index the Terraforms by the Kubernetes objects in their inventory.
+	if err := mgr.GetCache().IndexField(context.TODO(), &infrav1.Terraform{}, infrav1.ClusterInventoryIndexKey,
+		IndexByClusterInventory); err != nil {
+		return fmt.Errorf("failed setting index fields: %w", err)
+	}
+
 	// Configure the retryable http client used for fetching artifacts.
 	// By default it retries 10 times within a 3.5 minutes window.
 	httpClient := retryablehttp.NewClient()
@@ -445,10 +821,15 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 	r.statusManager = "tf-controller"
 	r.requeueDependency = 30 * time.Second
 
-	return ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.Terraform{}, builder.WithPredicates(
-			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}, DependantFinalizerRemovedPredicate{}, FailuresAcknowledgedPredicate{}, BreakGlassTokenPredicate{}, OutputsRegenerationRequestedPredicate{}, ReplaceResourcesRequestedPredicate{}),
 		)).
+		Watches(
+			&source.Kind{Type: &infrav1.Terraform{}},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForOutputsChange),
+			builder.WithPredicates(OutputsChangedPredicate{}),
+		).
 		Watches(
 			&source.Kind{Type: &sourcev1.GitRepository{}},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForRevisionChangeOf(infrav1.GitRepositoryIndexKey)),
@@ -464,11 +845,27 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 			handler.EnqueueRequestsFromMapFunc(r.requestsForRevisionChangeOf(infrav1.OCIRepositoryIndexKey)),
 			builder.WithPredicates(SourceRevisionChangePredicate{}),
 		).
+		Watches(
+			&source.Kind{Type: &infrav1.ProviderConfig{}},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForProviderConfigChange),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &infrav1.TerraformProvider{}},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForTerraformProviderChange),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &infrav1.TerraformNamespaceConfig{}},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForNamespaceConfigChange),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Watches(
 			&source.Kind{Type: &corev1.Secret{}},
+			// plan secrets are owned by, but not controlled by, the Terraform object
 			&handler.EnqueueRequestForOwner{
 				OwnerType:    &infrav1.Terraform{},
-				IsController: true,
+				IsController: false,
 			},
 			builder.WithPredicates(SecretDeletePredicate{}),
 		).
@@ -476,7 +873,17 @@ func (r *TerraformReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentRe
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 			RecoverPanic:            true,
 		}).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+	// the kinds of the objects of the inventories are watched as they appear
+	r.controller = c
+
+	if r.ApprovalWorkers > 0 {
+		return r.setupApprovalController(mgr)
+	}
+	return nil
 }
 
 func (r *TerraformReconciler) checkDependencies(source sourcev1.Source, terraform infrav1.Terraform) error {
@@ -524,12 +931,19 @@ func (r *TerraformReconciler) checkDependencies(source sourcev1.Source, terrafor
 		if tf.Spec.WriteOutputsToSecret != nil {
 			outputSecret := tf.Spec.WriteOutputsToSecret.Name
 			outputSecretName := types.NamespacedName{
-				Namespace: tf.GetNamespace(),
+				Namespace: tf.OutputsSecretNamespace(),
 				Name:      outputSecret,
 			}
-			if err := r.Get(context.Background(), outputSecretName, &corev1.Secret{}); err != nil {
+			var secret corev1.Secret
+			if err := r.Get(context.Background(), outputSecretName, &secret); err != nil {
 				return fmt.Errorf("dependency output secret: '%s' of '%s' is not ready yet", outputSecret, dName)
 			}
+
+			if err := checkRequiredOutputs(secret, d.RequiredOutputs); err != nil {
+				return fmt.Errorf("dependency output secret: '%s' of '%s' %w", outputSecret, dName, err)
+			}
+		} else if len(d.RequiredOutputs) > 0 {
+			return fmt.Errorf("dependency '%s' does not write outputs, but outputs %s are required", dName, strings.Join(d.RequiredOutputs, ", "))
 		}
 
 	}
@@ -537,6 +951,29 @@ func (r *TerraformReconciler) checkDependencies(source sourcev1.Source, terrafor
 	return nil
 }
 
+// checkRequiredOutputs verifies that the required keys are present in the output Secret of a dependency, with a non-empty value.
+func checkRequiredOutputs(secret corev1.Secret, requiredOutputs []string) error {
+	var missing, empty []string
+	for _, key := range requiredOutputs {
+		value, exists := secret.Data[key]
+		if !exists {
+			missing = append(missing, key)
+		} else if len(value) == 0 {
+			empty = append(empty, key)
+		}
+	}
+
+	switch {
+	case len(missing) > 0 && len(empty) > 0:
+		return fmt.Errorf("is missing required outputs: %s, and has empty required outputs: %s", strings.Join(missing, ", "), strings.Join(empty, ", "))
+	case len(missing) > 0:
+		return fmt.Errorf("is missing required outputs: %s", strings.Join(missing, ", "))
+	case len(empty) > 0:
+		return fmt.Errorf("has empty required outputs: %s", strings.Join(empty, ", "))
+	}
+	return nil
+}
+
 func (r *TerraformReconciler) requestsForRevisionChangeOf(indexKey string) func(obj client.Object) []reconcile.Request {
 	return func(obj client.Object) []reconcile.Request {
 		repo, ok := obj.(interface {
@@ -628,43 +1065,6 @@ func (r *TerraformReconciler) getSource(ctx context.Context, terraform infrav1.T
 	return sourceObj, nil
 }
 
-func (r *TerraformReconciler) downloadAsBytes(artifact *sourcev1.Artifact) (*bytes.Buffer, error) {
-	artifactURL := artifact.URL
-	if hostname := os.Getenv("SOURCE_CONTROLLER_LOCALHOST"); hostname != "" {
-		u, err := url.Parse(artifactURL)
-		if err != nil {
-			return nil, err
-		}
-		u.Host = hostname
-		artifactURL = u.String()
-	}
-
-	req, err := retryablehttp.NewRequest(http.MethodGet, artifactURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create a new request: %w", err)
-	}
-
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to download artifact, error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// check response
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to download artifact from %s, status: %s", artifactURL, resp.Status)
-	}
-
-	var buf bytes.Buffer
-
-	// verify checksum matches origin
-	if err := r.verifyArtifact(artifact, &buf, resp.Body); err != nil {
-		return nil, err
-	}
-
-	return &buf, nil
-}
-
 func (r *TerraformReconciler) recordReadinessMetric(ctx context.Context, terraform infrav1.Terraform) {
 	if r.MetricsRecorder == nil {
 		return
@@ -722,31 +1122,14 @@ func (r *TerraformReconciler) patchStatus(ctx context.Context, objectKey types.N
 
 	traceLog.Info("Update data and send Patch request")
 	patch := client.MergeFrom(terraform.DeepCopy())
-	terraform.Status = newStatus
-
-	return r.Status().Patch(ctx, &terraform, patch, client.FieldOwner(r.statusManager))
-}
-
-func (r *TerraformReconciler) verifyArtifact(artifact *sourcev1.Artifact, buf *bytes.Buffer, reader io.Reader) error {
-	hasher := sha256.New()
-
-	// for backwards compatibility with source-controller v0.17.2 and older
-	if len(artifact.Checksum) == 40 {
-		hasher = sha1.New()
-	}
-
-	// compute checksum
-	mw := io.MultiWriter(hasher, buf)
-	if _, err := io.Copy(mw, reader); err != nil {
-		return err
-	}
-
-	if checksum := fmt.Sprintf("%x", hasher.Sum(nil)); checksum != artifact.Checksum {
-		return fmt.Errorf("failed to verify artifact: computed checksum '%s' doesn't match advertised '%s'",
-			checksum, artifact.Checksum)
+	status, err := r.compressConditionMessages(ctx, terraform, sanitizeStatus(terraform, newStatus))
+	if err != nil {
+		log.Error(err, "unable to store the long condition messages, keeping them in the status")
 	}
+	terraform.Status = status
+	terraform.Status.NormalizeRevisions()
 
-	return nil
+	return r.Status().Patch(ctx, &terraform, patch, client.FieldOwner(r.statusManager))
 }
 
 func (r *TerraformReconciler) IndexBy(kind string) func(o client.Object) []string {
@@ -768,19 +1151,34 @@ func (r *TerraformReconciler) IndexBy(kind string) func(o client.Object) []strin
 	}
 }
 
-func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terraform, revision, severity, msg string, metadata map[string]string) {
-	log := ctrl.LoggerFrom(ctx)
-	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.event")
-	traceLog.Info("If metadata is nil set to an empty map")
-	if metadata == nil {
-		traceLog.Info("Is nil, set to an empty map")
-		metadata = map[string]string{}
+// eventMetadata returns the metadata of an event, with the keys of the schema set on every event added to metadata.
+func eventMetadata(terraform infrav1.Terraform, revision string, metadata map[string]string) map[string]string {
+	result := map[string]string{
+		infrav1.EventMetadataSchemaKey:    infrav1.EventMetadataSchemaVersion,
+		infrav1.EventMetadataWorkspaceKey: terraform.WorkspaceName(),
 	}
-	traceLog.Info("Check if the revision is empty")
 	if revision != "" {
-		traceLog.Info("Not empty set the metadata revision key")
-		metadata[infrav1.GroupVersion.Group+"/revision"] = revision
+		result[infrav1.EventMetadataRevisionKey] = revision
+	}
+	if terraform.Status.Plan.Pending != "" {
+		result[infrav1.EventMetadataPlanIDKey] = terraform.Status.Plan.Pending
+	}
+	for k, v := range metadata {
+		result[k] = v
 	}
+	return result
+}
+
+// durationSeconds formats a duration of the metadata of the events.
+func durationSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 1, 64)
+}
+
+func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terraform, revision, severity, msg string, metadata map[string]string) {
+	log := ctrl.LoggerFrom(ctx)
+	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.event")
+	traceLog.Info("Set the metadata of the schema")
+	metadata = eventMetadata(terraform, revision, metadata)
 
 	traceLog.Info("Set reason to severity")
 	reason := severity
@@ -799,5 +1197,5 @@ func (r *TerraformReconciler) event(ctx context.Context, terraform infrav1.Terra
 	}
 
 	traceLog.Info("Add new annotated event")
-	r.EventRecorder.AnnotatedEventf(&terraform, metadata, eventType, reason, msg)
+	r.EventRecorder.AnnotatedEventf(&terraform, metadata, eventType, reason, sanitizeMessage(terraform, msg))
 }
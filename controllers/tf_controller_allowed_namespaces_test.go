@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/mtls"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceNotAllowed(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	// the object was reconciled before its namespace was disallowed
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "helloworld", Finalizers: []string{infrav1.TerraformFinalizer}},
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan: "auto",
+			SourceRef:   infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "helloworld"},
+		},
+	}
+	// the other object was never reconciled, it is kept by another controller
+	other := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "other", Finalizers: []string{"example.com/finalizer"}},
+		Spec:       terraform.Spec,
+	}
+	repository := &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "helloworld"}}
+	ready := make(chan struct{})
+	close(ready)
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform, other, repository).Build()
+	r := &TerraformReconciler{
+		Client:        k8sClient,
+		Scheme:        testScheme,
+		EventRecorder: record.NewFakeRecorder(10),
+		CertRotator:   &mtls.CertRotator{Ready: ready},
+		Config:        &ControllerConfigWatcher{config: ControllerConfig{AllowedNamespaces: []string{"flux-system"}}},
+	}
+	key := types.NamespacedName{Namespace: "team-a", Name: "helloworld"}
+
+	// the object is not reconciled
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	var skipped infrav1.Terraform
+	g.Expect(k8sClient.Get(ctx, key, &skipped)).To(Succeed())
+	g.Expect(skipped.Status.Conditions).To(BeEmpty())
+
+	// its deletion goes on, up to the artifact of the source not ready yet
+	g.Expect(k8sClient.Delete(ctx, &skipped)).To(Succeed())
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	var deleting infrav1.Terraform
+	g.Expect(k8sClient.Get(ctx, key, &deleting)).To(Succeed())
+	g.Expect(deleting.DeletionTimestamp.IsZero()).To(BeFalse())
+	g.Expect(apimeta.FindStatusCondition(deleting.Status.Conditions, meta.ReadyCondition).Reason).To(Equal(infrav1.ArtifactFailedReason))
+
+	// the deletion of the objects never reconciled is not handled
+	otherKey := types.NamespacedName{Namespace: "team-a", Name: "other"}
+	g.Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: otherKey})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(k8sClient.Get(ctx, otherKey, other)).To(Succeed())
+	g.Expect(other.Status.Conditions).To(BeEmpty())
+}
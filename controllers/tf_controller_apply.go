@@ -3,7 +3,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fluxcd/pkg/runtime/events"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
@@ -14,15 +16,30 @@ import (
 )
 
 func (r *TerraformReconciler) forceOrAutoApply(terraform infrav1.Terraform) bool {
+	if terraform.Spec.PlanOnly {
+		return false
+	}
 	return terraform.Spec.Force || terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue
 }
 
 func (r *TerraformReconciler) shouldApply(terraform infrav1.Terraform) bool {
 	// Please do not optimize this logic, as we'd like others to easily understand the logics behind this behaviour.
+	if terraform.Spec.PlanOnly {
+		return false
+	}
+
 	if terraform.Spec.Force {
 		return true
 	}
 
+	if r.breakGlassApproves(terraform) {
+		return true
+	}
+
+	if pullRequestApprovesPlans(terraform) && terraform.Status.Plan.Pending != "" && terraform.Status.Plan.ApprovedBy != "" {
+		return true
+	}
+
 	if terraform.Spec.ApprovePlan == "" {
 		return false
 	} else if terraform.Spec.ApprovePlan == infrav1.ApprovePlanAutoValue && terraform.Status.Plan.Pending != "" {
@@ -31,6 +48,9 @@ func (r *TerraformReconciler) shouldApply(terraform infrav1.Terraform) bool {
 		return true
 	} else if strings.HasPrefix(terraform.Status.Plan.Pending, terraform.Spec.ApprovePlan) {
 		return true
+	} else if infrav1.PlanIdMatches(terraform.Spec.ApprovePlan, terraform.Status.Plan.Pending, terraform.Status.LastPlannedRevision) {
+		// the plan is approved with an id given to it by the previous versions
+		return true
 	}
 	return false
 }
@@ -44,6 +64,9 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 	log := ctrl.LoggerFrom(ctx)
 	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
 
+	// the token is recorded with the status of the apply, so that it is used only once
+	r.useBreakGlassToken(ctx, &terraform, revision)
+
 	terraform = infrav1.TerraformProgressing(terraform, "Applying")
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "unable to update status before Terraform applying")
@@ -68,6 +91,12 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 
 	log.Info(fmt.Sprintf("load tf plan: %s", loadTFPlanReply.Message))
 
+	if r.shouldBackupState(terraform) {
+		if terraform, err = r.backupState(ctx, terraform, tfInstance, runnerClient, revision, time.Now()); err != nil {
+			return terraform, err
+		}
+	}
+
 	terraform = infrav1.TerraformApplying(terraform, revision, "Apply started")
 	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 		log.Error(err, "error recording apply status: %s", err)
@@ -77,7 +106,7 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 	applyRequest := &runner.ApplyRequest{
 		TfInstance:         tfInstance,
 		RefreshBeforeApply: terraform.Spec.RefreshBeforeApply,
-		Targets:            terraform.Spec.Targets,
+		Targets:            terraform.GetTargets(),
 	}
 	if r.backendCompletelyDisable(terraform) {
 		// do nothing
@@ -89,17 +118,22 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 
 	var inventoryEntries []infrav1.ResourceRef
 
+	applyStart := time.Now()
+
 	// this a special case, when backend is completely disabled.
 	// we need to use "destroy" command instead of apply
 	if r.backendCompletelyDisable(terraform) && terraform.Spec.Destroy == true {
 		destroyReply, err := runnerClient.Destroy(ctx, &runner.DestroyRequest{
 			TfInstance: tfInstance,
-			Targets:    terraform.Spec.Targets,
+			Targets:    terraform.GetTargets(),
 		})
 		log.Info(fmt.Sprintf("destroy: %s", destroyReply.Message))
 
 		eventSent := false
 		if err != nil {
+			if runnerInterrupted(err) {
+				return r.applyInterrupted(ctx, terraform, revision, fmt.Errorf("error running Destroy: %s", err))
+			}
 			if st, ok := status.FromError(err); ok {
 				for _, detail := range st.Details() {
 					if reply, ok := detail.(*runner.DestroyReply); ok {
@@ -127,8 +161,26 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 		isDestroyApplied = true
 	} else {
 		eventSent := false
-		applyReply, err := runnerClient.Apply(ctx, applyRequest)
+		applyCtx, cancelApply := context.WithCancel(ctx)
+		stopWatchingRunner := r.watchRunner(ctx, terraform, cancelApply)
+		stopWatchingProgress := r.watchApplyProgress(applyCtx, terraform, tfInstance, runnerClient)
+		applyReply, err := runnerClient.Apply(applyCtx, applyRequest)
+		stopWatchingProgress()
+		runnerLost := stopWatchingRunner()
+		cancelApply()
+		// the runner is gone with the state lock, if it held it, and there is nothing to get from it anymore
+		if runnerLost != "" {
+			err = fmt.Errorf("error running Apply: %s", runnerLost)
+			r.event(ctx, terraform, revision, events.EventSeverityError, fmt.Sprintf("Apply error: %s", runnerLost), nil)
+			return infrav1.TerraformRunnerLost(terraform, revision, err.Error()), err
+		}
+		if progressed, progressErr := r.recordApplyProgress(ctx, terraform, tfInstance, runnerClient); progressErr == nil {
+			terraform = progressed
+		}
 		if err != nil {
+			if runnerInterrupted(err) {
+				return r.applyInterrupted(ctx, terraform, revision, fmt.Errorf("error running Apply: %s", err))
+			}
 			if st, ok := status.FromError(err); ok {
 				for _, detail := range st.Details() {
 					if reply, ok := detail.(*runner.ApplyReply); ok {
@@ -146,6 +198,11 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 			}
 
 			err = fmt.Errorf("error running Apply: %s", err)
+			if r.shouldRetryApply(terraform) {
+				if retried, ok := r.retryApply(ctx, terraform, tfInstance, runnerClient, revision, err); ok {
+					return retried, err
+				}
+			}
 			return infrav1.TerraformAppliedFailResetPlanAndNotReady(
 				terraform,
 				revision,
@@ -171,11 +228,20 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 				), err
 			}
 			for _, iv := range getInventoryReply.Inventories {
-				inventoryEntries = append(inventoryEntries, infrav1.ResourceRef{
+				entry := infrav1.ResourceRef{
 					Name:       iv.GetName(),
 					Type:       iv.GetType(),
 					Identifier: iv.GetIdentifier(),
-				})
+				}
+				if object := iv.GetObject(); object != nil {
+					entry.Object = &infrav1.ObjectRef{
+						APIVersion: object.GetApiVersion(),
+						Kind:       object.GetKind(),
+						Namespace:  object.GetNamespace(),
+						Name:       object.GetName(),
+					}
+				}
+				inventoryEntries = append(inventoryEntries, entry)
 			}
 			log.Info(fmt.Sprintf("got inventory - entries count: %d", len(inventoryEntries)))
 		} else {
@@ -183,15 +249,29 @@ func (r *TerraformReconciler) apply(ctx context.Context, terraform infrav1.Terra
 		}
 	}
 
+	planCreatedAt := terraform.Status.Plan.CreatedAt
+	metadata := map[string]string{
+		infrav1.EventMetadataDurationKey: durationSeconds(time.Since(applyStart)),
+	}
 	if isDestroyApplied {
 		msg := fmt.Sprintf("Destroy applied successfully")
-		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, metadata)
 		terraform = infrav1.TerraformApplied(terraform, revision, "Destroy applied successfully", isDestroyApplied, inventoryEntries)
 	} else {
 		msg := fmt.Sprintf("Applied successfully")
-		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+		if len(inventoryEntries) > 0 {
+			// summarized for the release notes, e.g. "3 aws_s3_bucket added"
+			added, removed := infrav1.DiffInventory(terraform.Status.Inventory, inventoryEntries)
+			if summary := (infrav1.InventoryDiff{Added: added, Removed: removed}).Summary(); summary != "" {
+				msg += "\nInventory: " + summary
+			}
+			metadata[infrav1.EventMetadataInventoryAddedKey] = strconv.Itoa(len(added))
+			metadata[infrav1.EventMetadataInventoryRemovedKey] = strconv.Itoa(len(removed))
+		}
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, metadata)
 		terraform = infrav1.TerraformApplied(terraform, revision, "Applied successfully", isDestroyApplied, inventoryEntries)
 	}
+	observeLeadTime(terraform, planCreatedAt)
 
 	return terraform, nil
 }
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// applyProgressInterval is how often the progress of a running apply is recorded in the status.
+var applyProgressInterval = 15 * time.Second
+
+// watchApplyProgress records the progress of the running apply in the status of the object every applyProgressInterval,
+// until the returned function is called.
+func (r *TerraformReconciler) watchApplyProgress(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient) func() {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	// the status is patched concurrently with the apply, from a copy
+	terraform = *terraform.DeepCopy()
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(applyProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var err error
+			terraform, err = r.recordApplyProgress(ctx, terraform, tfInstance, runnerClient)
+			if ctx.Err() != nil {
+				return
+			}
+			if status.Code(err) == codes.Unimplemented {
+				log.Info("the runner does not report the progress of the apply")
+				return
+			}
+			if err != nil {
+				log.Error(err, "unable to get the progress of the apply")
+				continue
+			}
+			if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+				log.Error(err, "unable to update status with the progress of the apply")
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// recordApplyProgress gets the progress of the running, or the last, apply from the runner.
+func (r *TerraformReconciler) recordApplyProgress(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient) (infrav1.Terraform, error) {
+	reply, err := runnerClient.GetApplyProgress(ctx, &runner.GetApplyProgressRequest{TfInstance: tfInstance})
+	if err != nil {
+		return terraform, err
+	}
+
+	return infrav1.TerraformApplyProgressed(terraform, reply.Completed, reply.Total), nil
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockRunnerClientForApplyProgress struct {
+	runner.RunnerClient
+	completed int32
+}
+
+func (m *mockRunnerClientForApplyProgress) GetApplyProgress(ctx context.Context, req *runner.GetApplyProgressRequest, opts ...grpc.CallOption) (*runner.GetApplyProgressReply, error) {
+	return &runner.GetApplyProgressReply{Completed: atomic.AddInt32(&m.completed, 1), Total: 100}, nil
+}
+
+func TestWatchApplyProgress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	defer func(interval time.Duration) { applyProgressInterval = interval }(applyProgressInterval)
+	applyProgressInterval = 10 * time.Millisecond
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build()}
+	runnerClient := &mockRunnerClientForApplyProgress{}
+
+	stop := r.watchApplyProgress(ctx, terraform, "instance", runnerClient)
+	g.Eventually(func() int32 {
+		var stored infrav1.Terraform
+		g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &stored)).To(Succeed())
+		if stored.Status.LastRun == nil || stored.Status.LastRun.Progress == nil {
+			return 0
+		}
+		g.Expect(stored.Status.LastRun.Progress.Total).To(Equal(int32(100)))
+		return stored.Status.LastRun.Progress.Completed
+	}, time.Second, 10*time.Millisecond).Should(BeNumerically(">=", 2))
+	stop()
+
+	// the object given to the watch is left untouched
+	g.Expect(terraform.Status.LastRun).To(BeNil())
+	terraform, err := r.recordApplyProgress(ctx, terraform, "instance", runnerClient)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.LastRun.Progress.Completed).To(Equal(atomic.LoadInt32(&runnerClient.completed)))
+}
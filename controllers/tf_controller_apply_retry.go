@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// shouldRetryApply reports whether the pending plan may be applied again after a failed apply.
+// Without a backend, a new runner starts from an empty state, so the plan cannot be reused.
+func (r *TerraformReconciler) shouldRetryApply(terraform infrav1.Terraform) bool {
+	if terraform.Spec.ApplyRetry == nil || r.backendCompletelyDisable(terraform) {
+		return false
+	}
+
+	return terraform.Status.Plan.ApplyRetries < terraform.Spec.ApplyRetry.GetMaxRetries()
+}
+
+// retryApply keeps the pending plan after a failed apply, when the state has not been written since planning,
+// so that the next reconciliation applies the same plan instead of creating a new one.
+// A failure after some of the changes have been applied advances the serial of the state,
+// which Terraform would refuse the plan for, so it is re-planned as before.
+// The returned bool is true when the plan is kept.
+func (r *TerraformReconciler) retryApply(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string, applyErr error) (infrav1.Terraform, bool) {
+	log := ctrl.LoggerFrom(ctx)
+
+	reply, err := runnerClient.CheckPlanStaleness(ctx, &runner.CheckPlanStalenessRequest{
+		TfInstance: tfInstance,
+	})
+	if err != nil {
+		log.Error(err, "unable to compare the state with the plan after the failed apply, re-planning")
+		return terraform, false
+	}
+
+	if reply.Stale {
+		log.Info(fmt.Sprintf("plan %s cannot be applied again, %s", terraform.Status.Plan.Pending, reply.Message))
+		return terraform, false
+	}
+
+	msg := fmt.Sprintf("%s. Plan %s will be applied again (retry %d of %d), %s",
+		applyErr.Error(), terraform.Status.Plan.Pending,
+		terraform.Status.Plan.ApplyRetries+1, terraform.Spec.ApplyRetry.GetMaxRetries(), reply.Message)
+	log.Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo,
+		fmt.Sprintf("Retrying apply of plan %s, %s", terraform.Status.Plan.Pending, reply.Message), nil)
+
+	return infrav1.TerraformApplyRetryScheduled(terraform, revision, msg), true
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type mockRunnerClientForApplyRetry struct {
+	runner.RunnerClient
+	stale bool
+}
+
+func (m *mockRunnerClientForApplyRetry) CheckPlanStaleness(ctx context.Context, req *runner.CheckPlanStalenessRequest, opts ...grpc.CallOption) (*runner.CheckPlanStalenessReply, error) {
+	if m.stale {
+		return &runner.CheckPlanStalenessReply{Stale: true, Message: "state serial advanced from 4 to 5 since planning"}, nil
+	}
+	return &runner.CheckPlanStalenessReply{Message: "state serial 4 unchanged since planning"}, nil
+}
+
+func TestRetryApply(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan: "auto",
+			ApplyRetry:  &infrav1.ApplyRetry{MaxRetries: 2},
+		},
+		Status: infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-abc"}},
+	}
+	applyErr := errors.New("error running Apply: exit status 1")
+
+	// the plan is kept while the state is unchanged, up to the maximum number of retries
+	for i := 0; i < 2; i++ {
+		g.Expect(r.shouldRetryApply(terraform)).To(BeTrue())
+		var retried bool
+		terraform, retried = r.retryApply(ctx, terraform, "instance", &mockRunnerClientForApplyRetry{}, "main/abc", applyErr)
+		g.Expect(retried).To(BeTrue())
+		g.Expect(terraform.Status.Plan.Pending).To(Equal("plan-main-abc"))
+	}
+	g.Expect(terraform.Status.Plan.ApplyRetries).To(Equal(int32(2)))
+	g.Expect(r.shouldRetryApply(terraform)).To(BeFalse())
+
+	// a state written by the failed apply requires a new plan
+	terraform.Status.Plan.ApplyRetries = 0
+	_, retried := r.retryApply(ctx, terraform, "instance", &mockRunnerClientForApplyRetry{stale: true}, "main/abc", applyErr)
+	g.Expect(retried).To(BeFalse())
+
+	// retries are disabled without spec.applyRetry, or without a backend
+	terraform.Spec.ApplyRetry = nil
+	g.Expect(r.shouldRetryApply(terraform)).To(BeFalse())
+	terraform.Spec.ApplyRetry = &infrav1.ApplyRetry{}
+	g.Expect(r.shouldRetryApply(terraform)).To(BeTrue())
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+	g.Expect(r.shouldRetryApply(terraform)).To(BeFalse())
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// approvalControllerName names the controller reconciling the approved objects, ahead of the queue of the others.
+const approvalControllerName = "terraform-approval"
+
+// objectBusyRequeue is the delay after which an object reconciled by the other controller is retried.
+const objectBusyRequeue = 5 * time.Second
+
+// ApprovalPredicate triggers when approvePlan is changed while a plan is pending, so that the approved plan
+// is applied right away.
+type ApprovalPredicate struct {
+	predicate.Funcs
+}
+
+func (ApprovalPredicate) Update(e event.UpdateEvent) bool {
+	oldTF, ok := e.ObjectOld.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	newTF, ok := e.ObjectNew.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+
+	return newTF.Spec.ApprovePlan != oldTF.Spec.ApprovePlan &&
+		newTF.Spec.ApprovePlan != "" &&
+		newTF.Spec.ApprovePlan != infrav1.ApprovePlanDisableValue &&
+		newTF.Status.Plan.Pending != ""
+}
+
+// setupApprovalController reconciles the approved objects with workers of their own, so that an approval
+// does not wait behind the plans of the other objects in the queue of the main controller.
+func (r *TerraformReconciler) setupApprovalController(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(approvalControllerName).
+		For(&infrav1.Terraform{}, builder.WithPredicates(
+			predicate.Or(ApprovalPredicate{}, BreakGlassTokenPredicate{}),
+		)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.ApprovalWorkers,
+			RecoverPanic:            true,
+		}).
+		Complete(r)
+}
+
+// objectLocks keeps an object from being reconciled by the main and the approval controllers at once.
+type objectLocks struct {
+	mu     sync.Mutex
+	locked map[types.NamespacedName]bool
+}
+
+// tryLock locks the object, it returns false if the object is already locked.
+func (l *objectLocks) tryLock(key types.NamespacedName) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locked == nil {
+		l.locked = map[types.NamespacedName]bool{}
+	}
+	if l.locked[key] {
+		return false
+	}
+	l.locked[key] = true
+	return true
+}
+
+func (l *objectLocks) unlock(key types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.locked, key)
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestApprovalPredicate(t *testing.T) {
+	g := NewWithT(t)
+
+	old := &infrav1.Terraform{Status: infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"}}}
+	approved := old.DeepCopy()
+	approved.Spec.ApprovePlan = "plan-main-b8e362c206"
+	g.Expect(ApprovalPredicate{}.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: approved})).To(BeTrue())
+
+	// the approval is unchanged
+	g.Expect(ApprovalPredicate{}.Update(event.UpdateEvent{ObjectOld: approved, ObjectNew: approved.DeepCopy()})).To(BeFalse())
+
+	disabled := old.DeepCopy()
+	disabled.Spec.ApprovePlan = infrav1.ApprovePlanDisableValue
+	g.Expect(ApprovalPredicate{}.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: disabled})).To(BeFalse())
+
+	// nothing to apply without a pending plan
+	noPlan := approved.DeepCopy()
+	noPlan.Status.Plan.Pending = ""
+	g.Expect(ApprovalPredicate{}.Update(event.UpdateEvent{ObjectOld: &infrav1.Terraform{}, ObjectNew: noPlan})).To(BeFalse())
+}
+
+func TestObjectLocks(t *testing.T) {
+	g := NewWithT(t)
+
+	var locks objectLocks
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	g.Expect(locks.tryLock(key)).To(BeTrue())
+	g.Expect(locks.tryLock(key)).To(BeFalse())
+	g.Expect(locks.tryLock(types.NamespacedName{Namespace: "flux-system", Name: "other"})).To(BeTrue())
+
+	locks.unlock(key)
+	g.Expect(locks.tryLock(key)).To(BeTrue())
+}
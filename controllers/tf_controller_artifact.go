@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// artifactChunkSize is the size of the chunks of the artifacts streamed to the runners,
+// well below the maximum size of the gRPC messages.
+const artifactChunkSize = 512 * 1024
+
+const (
+	// ArtifactFetchModeController streams the artifacts from the source-controller to the runners through the controller.
+	ArtifactFetchModeController = "controller"
+	// ArtifactFetchModeRunner has the runners download the artifacts from the source-controller,
+	// which requires the runner pods to reach it.
+	ArtifactFetchModeRunner = "runner"
+)
+
+// uploadArtifact has the runner extract the artifact, downloaded by the runner itself in ArtifactFetchModeRunner.
+// The runners not streaming the artifacts yet are sent the whole artifact in the request.
+func (r *TerraformReconciler) uploadArtifact(ctx context.Context, runnerClient runner.RunnerClient, artifact *sourcev1.Artifact, req *runner.UploadAndExtractRequest) (*runner.UploadAndExtractReply, error) {
+	if r.ArtifactFetchMode != ArtifactFetchModeRunner {
+		reply, err := r.streamArtifact(ctx, runnerClient, artifact, req)
+		if status.Code(err) == codes.Unimplemented {
+			ctrl.LoggerFrom(ctx).Info("the runner does not stream the artifacts, sending the whole artifact")
+			return r.sendArtifact(ctx, runnerClient, artifact, req)
+		}
+		return reply, err
+	}
+	req.Url = artifact.URL
+	return runnerClient.UploadAndExtract(ctx, req)
+}
+
+// openArtifact starts the download of the artifact from the source-controller.
+func (r *TerraformReconciler) openArtifact(artifact *sourcev1.Artifact) (io.ReadCloser, error) {
+	artifactURL := artifact.URL
+	if hostname := os.Getenv("SOURCE_CONTROLLER_LOCALHOST"); hostname != "" {
+		u, err := url.Parse(artifactURL)
+		if err != nil {
+			return nil, err
+		}
+		u.Host = hostname
+		artifactURL = u.String()
+	}
+
+	req, err := retryablehttp.NewRequest(http.MethodGet, artifactURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new request: %w", err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact, error: %w", err)
+	}
+
+	// check response
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download artifact from %s, status: %s", artifactURL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// sendArtifact downloads the artifact from the source-controller, verifies its checksum, and sends it
+// to the runner in the request.
+func (r *TerraformReconciler) sendArtifact(ctx context.Context, runnerClient runner.RunnerClient, artifact *sourcev1.Artifact, req *runner.UploadAndExtractRequest) (*runner.UploadAndExtractReply, error) {
+	body, err := r.openArtifact(artifact)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	if _, err := verifyArtifact(artifact, &buf, body, runner.ArtifactSpoolLimit(req.MaxSize)); err != nil {
+		return nil, err
+	}
+	req.TarGz = buf.Bytes()
+	return runnerClient.UploadAndExtract(ctx, req)
+}
+
+// downloadArtifact downloads the artifact from the source-controller to a temporary file, and verifies its checksum.
+// It returns the file, to be closed and removed by the caller, and the size of the artifact.
+func (r *TerraformReconciler) downloadArtifact(artifact *sourcev1.Artifact) (*os.File, int64, error) {
+	body, err := r.openArtifact(artifact)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer body.Close()
+
+	f, err := os.CreateTemp("", "artifact-*.tar.gz")
+	if err != nil {
+		return nil, 0, err
+	}
+	_, maxSize := r.Config.Get().artifactLimits()
+	size, err := verifyArtifact(artifact, f, body, runner.ArtifactSpoolLimit(maxSize))
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, 0, err
+	}
+	return f, size, nil
+}
+
+// verifyArtifact copies the artifact read from reader to w, and verifies its checksum. The artifact is rejected
+// beyond limit bytes. It returns the size of the artifact.
+func verifyArtifact(artifact *sourcev1.Artifact, w io.Writer, reader io.Reader, limit int64) (int64, error) {
+	if artifact.Checksum == "" {
+		return 0, fmt.Errorf("failed to verify artifact: no checksum advertised")
+	}
+
+	hasher := sha256.New()
+
+	// for backwards compatibility with source-controller v0.17.2 and older
+	if len(artifact.Checksum) == 40 {
+		hasher = sha1.New()
+	}
+
+	// compute checksum
+	mw := io.MultiWriter(hasher, w)
+	n, err := io.Copy(mw, io.LimitReader(reader, limit+1))
+	if err != nil {
+		return n, err
+	}
+	if n > limit {
+		return n, fmt.Errorf("artifact exceeds the maximum size of %d bytes", limit)
+	}
+
+	if checksum := fmt.Sprintf("%x", hasher.Sum(nil)); checksum != artifact.Checksum {
+		return n, fmt.Errorf("failed to verify artifact: computed checksum '%s' doesn't match advertised '%s'",
+			checksum, artifact.Checksum)
+	}
+
+	return n, nil
+}
+
+// streamArtifact streams the artifact from the source-controller to the runner, which verifies its checksum
+// and extracts it, so that the memory of the controller is bounded by the size of a chunk whatever the size
+// of the artifact.
+func (r *TerraformReconciler) streamArtifact(ctx context.Context, runnerClient runner.RunnerClient, artifact *sourcev1.Artifact, req *runner.UploadAndExtractRequest) (*runner.UploadAndExtractReply, error) {
+	body, err := r.openArtifact(artifact)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	stream, err := runnerClient.UploadAndExtractStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&runner.UploadAndExtractChunk{Request: req}); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	buf := make([]byte, artifactChunkSize)
+	for {
+		n, readErr := io.ReadFull(body, buf)
+		if n > 0 {
+			// the runner stops receiving when the artifact is already extracted, or on error,
+			// which is then returned by CloseAndRecv
+			if err := stream.Send(&runner.UploadAndExtractChunk{Data: buf[:n]}); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			stream.CloseSend()
+			return nil, fmt.Errorf("failed to download artifact, error: %w", readErr)
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
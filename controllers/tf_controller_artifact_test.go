@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/hashicorp/go-retryablehttp"
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testArtifact(g *WithT, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		g.Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tw.Write(content)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	g.Expect(tw.Close()).To(Succeed())
+	g.Expect(zw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func TestStreamArtifact(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Setenv("TMPDIR", t.TempDir())
+
+	// larger than a few chunks, and not compressible
+	large := make([]byte, 3*artifactChunkSize)
+	rand.New(rand.NewSource(1)).Read(large)
+	artifact := testArtifact(g, map[string][]byte{
+		"main.tf":       []byte(`output "hello" { value = "world" }`),
+		"modules/large": large,
+	})
+	artifactServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(artifact)
+	}))
+	defer artifactServer.Close()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	runner.RegisterRunnerServer(server, &runner.TerraformRunnerServer{InstanceID: "1"})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	r := &TerraformReconciler{httpClient: retryablehttp.NewClient()}
+	r.httpClient.RetryMax = 0
+	source := &sourcev1.Artifact{URL: artifactServer.URL, Checksum: fmt.Sprintf("%x", sha256.Sum256(artifact))}
+
+	reply, err := r.streamArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "stream",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	main, err := os.ReadFile(filepath.Join(reply.WorkingDir, "main.tf"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(main)).To(ContainSubstring("hello"))
+	extracted, err := os.ReadFile(filepath.Join(reply.WorkingDir, "modules", "large"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(extracted).To(Equal(large))
+
+	// the runner rejects an artifact not matching its checksum
+	source.Checksum = fmt.Sprintf("%x", sha256.Sum256([]byte("tampered")))
+	_, err = r.streamArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "tampered",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to verify artifact")))
+}
+
+// legacyRunnerServer is a runner not streaming the artifacts yet.
+type legacyRunnerServer struct {
+	*runner.TerraformRunnerServer
+}
+
+func (legacyRunnerServer) UploadAndExtractStream(runner.Runner_UploadAndExtractStreamServer) error {
+	return status.Error(codes.Unimplemented, "method UploadAndExtractStream not implemented")
+}
+
+func TestStreamArtifactToLegacyRunner(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Setenv("TMPDIR", t.TempDir())
+
+	artifact := testArtifact(g, map[string][]byte{"main.tf": []byte(`output "hello" { value = "world" }`)})
+	artifactServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(artifact)
+	}))
+	defer artifactServer.Close()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	runner.RegisterRunnerServer(server, legacyRunnerServer{&runner.TerraformRunnerServer{InstanceID: "1"}})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	// the whole artifact is sent in the request instead
+	r := &TerraformReconciler{httpClient: retryablehttp.NewClient()}
+	r.httpClient.RetryMax = 0
+	source := &sourcev1.Artifact{URL: artifactServer.URL, Checksum: fmt.Sprintf("%x", sha256.Sum256(artifact))}
+
+	reply, err := r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "legacy",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	main, err := os.ReadFile(filepath.Join(reply.WorkingDir, "main.tf"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(main)).To(ContainSubstring("hello"))
+
+	// the controller verifies the artifact it sends
+	source.Checksum = fmt.Sprintf("%x", sha256.Sum256([]byte("tampered")))
+	_, err = r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "legacy-tampered",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to verify artifact")))
+}
+
+func TestRunnerFetchesArtifact(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Setenv("TMPDIR", t.TempDir())
+
+	artifact := testArtifact(g, map[string][]byte{"main.tf": []byte(`output "hello" { value = "world" }`)})
+	artifactServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(artifact)
+	}))
+	defer artifactServer.Close()
+
+	// the runner trusts the CA of the source-controller from its TLS directory
+	tlsDir := t.TempDir()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: artifactServer.Certificate().Raw})
+	g.Expect(os.WriteFile(filepath.Join(tlsDir, "ca.crt"), ca, 0600)).To(Succeed())
+	t.Setenv(runner.ArtifactTLSDirEnvName, tlsDir)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	runner.RegisterRunnerServer(server, &runner.TerraformRunnerServer{InstanceID: "1"})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	// the controller does not download the artifact
+	r := &TerraformReconciler{ArtifactFetchMode: ArtifactFetchModeRunner}
+	source := &sourcev1.Artifact{URL: artifactServer.URL, Checksum: fmt.Sprintf("%x", sha256.Sum256(artifact))}
+
+	reply, err := r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "fetch",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	main, err := os.ReadFile(filepath.Join(reply.WorkingDir, "main.tf"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(main)).To(ContainSubstring("hello"))
+
+	source.Checksum = fmt.Sprintf("%x", sha256.Sum256([]byte("tampered")))
+	_, err = r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "tampered-fetch",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to verify artifact")))
+
+	// the runner does not download an artifact it can't verify
+	_, err = r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "no-checksum",
+		Path:      "./",
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("no checksum advertised")))
+
+	// without the CA, the runner does not trust the source-controller
+	g.Expect(os.Remove(filepath.Join(tlsDir, "ca.crt"))).To(Succeed())
+	_, err = r.uploadArtifact(ctx, runner.NewRunnerClient(conn), source, &runner.UploadAndExtractRequest{
+		Namespace: "flux-system",
+		Name:      "untrusted",
+		Path:      "./",
+		Checksum:  source.Checksum,
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("certificate")))
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -31,25 +33,16 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, runnerClient r
 		return terraform, tfInstance, tmpDir, err
 	}
 
-	// download artifact and extract files
-	buf, err := r.downloadAsBytes(sourceObj.GetArtifact())
-	if err != nil {
-		return infrav1.TerraformNotReady(
-			terraform,
-			revision,
-			infrav1.ArtifactFailedReason,
-			err.Error(),
-		), tfInstance, tmpDir, err
-	}
-
-	// we fix timeout of UploadAndExtract to be 30s
-	// ctx30s, cancelCtx30s := context.WithTimeout(ctx, 30*time.Second)
-	// defer cancelCtx30s()
-	uploadAndExtractReply, err := runnerClient.UploadAndExtract(ctx, &runner.UploadAndExtractRequest{
-		Namespace: terraform.Namespace,
-		Name:      terraform.Name,
-		TarGz:     buf.Bytes(),
-		Path:      terraform.Spec.Path,
+	// stream the artifact to the runner, or have the runner download it, which extracts its files
+	maxFiles, maxSize := r.Config.Get().artifactLimits()
+	uploadAndExtractReply, err := r.uploadArtifact(ctx, runnerClient, sourceObj.GetArtifact(), &runner.UploadAndExtractRequest{
+		Namespace:  terraform.Namespace,
+		Name:       terraform.Name,
+		Path:       terraform.Spec.Path,
+		Persistent: terraform.Spec.HasPersistentWorkingDir(),
+		Checksum:   sourceObj.GetArtifact().Checksum,
+		MaxFiles:   maxFiles,
+		MaxSize:    maxSize,
 	})
 	if err != nil {
 		return infrav1.TerraformNotReady(
@@ -62,16 +55,62 @@ func (r *TerraformReconciler) setupTerraform(ctx context.Context, runnerClient r
 	workingDir := uploadAndExtractReply.WorkingDir
 	tmpDir = uploadAndExtractReply.TmpDir
 
+	if cdktf := terraform.Spec.CDKTF; cdktf != nil {
+		log.Info("synthesize the CDK for Terraform application", "language", cdktf.Language, "stack", cdktf.Stack)
+		synthReply, err := runnerClient.SynthCDKTF(ctx, &runner.SynthCDKTFRequest{
+			WorkingDir: workingDir,
+			Language:   cdktf.Language,
+			Stack:      cdktf.Stack,
+			// the backend configuration of the controller replaces the one of the stack
+			RemoveBackend: !r.backendCompletelyDisable(terraform),
+		})
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.CDKTFSynthFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		// Terraform runs in the directory of the synthesized stack
+		workingDir = synthReply.WorkingDir
+	}
+
 	var backendConfig string
+	var typedBackend []byte
 	DisableTFK8SBackend := os.Getenv("DISABLE_TF_K8S_BACKEND") == "1"
 
-	if terraform.Spec.BackendConfig != nil && terraform.Spec.BackendConfig.CustomConfiguration != "" {
+	if terraform.HasTypedBackend() {
+		// the runner generates the backend block, reading the credentials the controller does not need
+		typedBackend, err = json.Marshal(typedBackendConfig(terraform))
+		if err != nil {
+			return terraform, tfInstance, tmpDir, err
+		}
+	} else if terraform.Spec.BackendConfig != nil && terraform.Spec.BackendConfig.CustomConfiguration != "" {
+		customConfiguration, err := renderCustomConfiguration(terraform, r.backendTemplateSecret(ctx, terraform))
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TemplateGenerationFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		customConfiguration, err = applyKeyPrefixPolicy(terraform, customConfiguration)
+		if err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.StateKeyInvalidReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
 		backendConfig = fmt.Sprintf(`
 terraform {
   %v
 }
 `,
-			terraform.Spec.BackendConfig.CustomConfiguration)
+			customConfiguration)
 	} else if terraform.Spec.BackendConfig != nil {
 		backendConfig = fmt.Sprintf(`
 terraform {
@@ -90,7 +129,7 @@ terraform {
 			terraform.Spec.BackendConfig.InClusterConfig,
 			terraform.Spec.BackendConfig.ConfigPath,
 			terraform.Namespace,
-			getLabelsAsHCL(terraform.Labels, 6))
+			getLabelsAsHCL(backendLabels(terraform.Labels), 6))
 	} else if DisableTFK8SBackend && terraform.Spec.BackendConfig == nil {
 		backendConfig = `
 terraform {
@@ -112,7 +151,7 @@ terraform {
 `,
 			terraform.Name,
 			terraform.Namespace,
-			getLabelsAsHCL(terraform.Labels, 6))
+			getLabelsAsHCL(backendLabels(terraform.Labels), 6))
 	}
 
 	if r.backendCompletelyDisable(terraform) {
@@ -122,9 +161,19 @@ terraform {
 			&runner.WriteBackendConfigRequest{
 				DirPath:       workingDir,
 				BackendConfig: []byte(backendConfig),
+				TypedBackend:  typedBackend,
+				Namespace:     terraform.Namespace,
 			})
 		if err != nil {
 			log.Error(err, "write backend config error")
+			if typedBackend != nil {
+				return infrav1.TerraformNotReady(
+					terraform,
+					revision,
+					infrav1.BackendCredentialsFailedReason,
+					err.Error(),
+				), tfInstance, tmpDir, err
+			}
 			return terraform, tfInstance, tmpDir, err
 		}
 		log.Info(fmt.Sprintf("write backend config: %s", writeBackendConfigReply.Message))
@@ -154,12 +203,8 @@ terraform {
 		tfrcFilepath = processCliConfigReply.FilePath
 	}
 
-	lookPathReply, err := runnerClient.LookPath(ctx,
-		&runner.LookPathRequest{
-			File: "terraform",
-		})
+	execPath, err := r.lookupEngine(ctx, runnerClient, terraform)
 	if err != nil {
-		err = fmt.Errorf("cannot find Terraform binary: %s in %s", err, os.Getenv("PATH"))
 		return infrav1.TerraformNotReady(
 			terraform,
 			revision,
@@ -167,9 +212,8 @@ terraform {
 			err.Error(),
 		), tfInstance, tmpDir, err
 	}
-	execPath := lookPathReply.ExecPath
 
-	log.Info("new terraform", "workingDir", workingDir)
+	log.Info("new terraform", "workingDir", workingDir, "engine", terraform.EngineBinary())
 
 	terraformBytes, err := terraform.ToBytes(r.Scheme)
 	if err != nil {
@@ -231,6 +275,54 @@ terraform {
 		}
 	}
 
+	providerConfigs, err := r.getProviderConfigs(ctx, terraform)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
+	terraformProviders, err := r.getTerraformProviders(ctx, terraform)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
+	terraformProviderEnvs, err := r.terraformProviderEnvs(ctx, terraformProviders)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
+	providerEnvs, err := r.providerConfigEnvs(ctx, providerConfigs)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecInitFailedReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+	// credentials of the provider configs take precedence over those of the cluster-wide terraform providers,
+	// and both over the env of the runner pod template
+	for k, v := range terraformProviderEnvs {
+		envs[k] = v
+	}
+	for k, v := range providerEnvs {
+		envs[k] = v
+	}
+
 	disableTestLogging := os.Getenv("DISABLE_TF_LOGS") == "1"
 	if !disableTestLogging {
 		envs["DISABLE_TF_LOGS"] = "1"
@@ -256,7 +348,7 @@ terraform {
 		), tfInstance, tmpDir, err
 	}
 
-	if len(terraform.Spec.FileMappings) > 0 {
+	if len(terraform.Spec.FileMappings) > 0 || len(providerConfigs) > 0 || len(terraformProviders) > 0 {
 		log.Info("generate runner mapping files")
 		runnerFileMappingList, err := r.createRunnerFileMapping(ctx, terraform)
 		if err != nil {
@@ -269,6 +361,30 @@ terraform {
 			), tfInstance, tmpDir, err
 		}
 
+		providerFileMappingList, err := r.providerConfigFileMappings(ctx, providerConfigs)
+		if err != nil {
+			err = fmt.Errorf("error creating provider config file mappings: %w", err)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TFExecInitFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		runnerFileMappingList = append(runnerFileMappingList, providerFileMappingList...)
+
+		terraformProviderFileMappingList, err := r.terraformProviderFileMappings(ctx, terraformProviders)
+		if err != nil {
+			err = fmt.Errorf("error creating terraform provider file mappings: %w", err)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.TFExecInitFailedReason,
+				err.Error(),
+			), tfInstance, tmpDir, err
+		}
+		runnerFileMappingList = append(runnerFileMappingList, terraformProviderFileMappingList...)
+
 		log.Info("create mapping files")
 		if _, err := runnerClient.CreateFileMappings(ctx, &runner.CreateFileMappingsRequest{
 			WorkingDir:   workingDir,
@@ -349,6 +465,44 @@ terraform {
 	}
 	log.Info(fmt.Sprintf("init reply: %s", initReply.Message))
 
+	if initReply.ModuleInterface != nil {
+		terraform.Status.ModuleInterface = moduleInterfaceFromReply(initReply.ModuleInterface)
+	}
+
+	// fail before planning, as Terraform would only prompt for them, and fail deep in the plan logs
+	if len(initReply.MissingVariables) > 0 {
+		err = fmt.Errorf("missing values for the required variables: %s", strings.Join(initReply.MissingVariables, ", "))
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.MissingVariablesReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
+	if len(initReply.UndeclaredVariables) > 0 {
+		msg := fmt.Sprintf("variables not declared by the module: %s", strings.Join(initReply.UndeclaredVariables, ", "))
+		if terraform.Spec.VarsPolicy == infrav1.VarsPolicyStrict {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.UndeclaredVariablesReason,
+				msg,
+			), tfInstance, tmpDir, errors.New(msg)
+		}
+		log.Info(msg)
+	}
+
+	if initReply.InvalidValues != "" {
+		err = fmt.Errorf("values do not match the type of the values variable: %s", initReply.InvalidValues)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.InvalidValuesReason,
+			err.Error(),
+		), tfInstance, tmpDir, err
+	}
+
 	log.Info("tfexec initialized terraform")
 
 	workspaceRequest := &runner.WorkspaceRequest{
@@ -400,6 +554,37 @@ terraform {
 	return terraform, tfInstance, tmpDir, nil
 }
 
+func moduleInterfaceFromReply(reply *runner.ModuleInterface) *infrav1.ModuleInterface {
+	result := &infrav1.ModuleInterface{}
+	for _, v := range reply.Variables {
+		result.Variables = append(result.Variables, infrav1.ModuleVariable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Required:    v.Required,
+			Sensitive:   v.Sensitive,
+		})
+	}
+	for _, o := range reply.Outputs {
+		result.Outputs = append(result.Outputs, infrav1.ModuleOutput{
+			Name:        o.Name,
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+	return result
+}
+
+// backendLabels returns the labels to put on the state Secret of the kubernetes backend,
+// marking it as managed by the controller.
+func backendLabels(labels map[string]string) map[string]string {
+	result := map[string]string{ManagedByLabel: ManagedByValue}
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
+
 func getLabelsAsHCL(labels map[string]string, indent int) string {
 	var result string
 	for k, v := range labels {
@@ -412,3 +597,29 @@ func getLabelsAsHCL(labels map[string]string, indent int) string {
 
 	return strings.TrimSpace(result)
 }
+
+// lookupEngine returns the path of the binary running the object in the runner: the engine found in the PATH,
+// or the terraform binary of spec.terraformVersion, installed by the runner.
+func (r *TerraformReconciler) lookupEngine(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform) (string, error) {
+	if terraform.Spec.TerraformVersion != "" {
+		if terraform.EngineBinary() != infrav1.EngineTerraform {
+			return "", fmt.Errorf("terraformVersion can't be set with the %s engine", terraform.EngineBinary())
+		}
+		installReply, err := runnerClient.InstallTerraform(ctx, &runner.InstallTerraformRequest{
+			Version: terraform.Spec.TerraformVersion,
+		})
+		if err != nil {
+			return "", fmt.Errorf("cannot install terraform %s: %s", terraform.Spec.TerraformVersion, err)
+		}
+		return installReply.ExecPath, nil
+	}
+
+	lookPathReply, err := runnerClient.LookPath(ctx,
+		&runner.LookPathRequest{
+			File: terraform.EngineBinary(),
+		})
+	if err != nil {
+		return "", fmt.Errorf("cannot find the %s binary: %s in %s", terraform.EngineBinary(), err, os.Getenv("PATH"))
+	}
+	return lookPathReply.ExecPath, nil
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/logger"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// backendIdentity returns a key identifying the state the object stores with its backend,
+// so that objects rendering the same backend configuration for the same workspace get the same key.
+// The bool is false when the state is not stored outside the runner.
+func backendIdentity(terraform infrav1.Terraform) (string, bool) {
+	backendConfig := terraform.Spec.BackendConfig
+	if backendConfig != nil && backendConfig.Disable {
+		return "", false
+	}
+
+	if terraform.IsRemote() {
+		hostname := backendConfig.Remote.Hostname
+		if hostname == "" {
+			hostname = defaultRemoteHostname
+		}
+		return "remote/" + hostname + "/" + backendConfig.Remote.Organization + "/" + terraform.RemoteWorkspaceName(), true
+	}
+
+	if terraform.HasTypedBackend() {
+		return typedBackendIdentity(terraform), true
+	}
+
+	if backendConfig != nil && backendConfig.CustomConfiguration != "" {
+		// the objects sharing a template get the same key only if it renders the same backend for both
+		customConfiguration, err := renderCustomConfiguration(terraform, placeholderSecret(terraform))
+		if err != nil {
+			customConfiguration = backendConfig.CustomConfiguration
+		}
+		if prefixed, err := applyKeyPrefixPolicy(terraform, customConfiguration); err == nil {
+			customConfiguration = prefixed
+		}
+		// formatting differences do not make two configurations different backends
+		normalized := strings.Join(strings.Fields(customConfiguration), " ")
+		sum := sha256.Sum256([]byte(terraform.WorkspaceName() + "\n" + normalized))
+		return "custom/" + hex.EncodeToString(sum[:]), true
+	}
+
+	if backendConfig == nil && os.Getenv("DISABLE_TF_K8S_BACKEND") == "1" {
+		return "", false
+	}
+
+	suffix, configPath := terraform.Name, ""
+	if backendConfig != nil {
+		suffix, configPath = backendConfig.SecretSuffix, backendConfig.ConfigPath
+	}
+
+	return "kubernetes/" + configPath + "/" + terraform.Namespace + "/" + terraform.WorkspaceName() + "/" + suffix, true
+}
+
+// IndexByBackend indexes the Terraform objects by the identity of their backend.
+func (r *TerraformReconciler) IndexByBackend(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	// a plan-only object reads the state of another object, without claiming it
+	if terraform.Spec.PlanOnly {
+		return nil
+	}
+	if identity, ok := backendIdentity(*terraform); ok {
+		return []string{identity}
+	}
+	return nil
+}
+
+// backendOwner returns the object, among those sharing the backend of terraform, that claimed it first,
+// or nil if terraform itself is the first one. The oldest object wins, the name breaks ties.
+func backendOwner(terraform infrav1.Terraform, sharing []infrav1.Terraform) *infrav1.Terraform {
+	var owner *infrav1.Terraform
+	for i := range sharing {
+		other := &sharing[i]
+		if other.Namespace == terraform.Namespace && other.Name == terraform.Name {
+			continue
+		}
+
+		if !claimedBefore(*other, terraform) {
+			continue
+		}
+
+		if owner == nil || claimedBefore(*other, *owner) {
+			owner = other
+		}
+	}
+	return owner
+}
+
+func claimedBefore(a, b infrav1.Terraform) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
+
+// getBackendOwner looks up the object that claimed the backend of terraform before it, if any.
+func (r *TerraformReconciler) getBackendOwner(ctx context.Context, terraform infrav1.Terraform) (*infrav1.Terraform, error) {
+	identity, ok := backendIdentity(terraform)
+	if !ok {
+		return nil, nil
+	}
+
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{infrav1.BackendIndexKey: identity}); err != nil {
+		return nil, err
+	}
+
+	return backendOwner(terraform, list.Items), nil
+}
+
+// checkBackendConflict blocks the object when another object already uses an identical backend configuration,
+// as both of them would write the same state. The returned bool is true when the reconciliation must not proceed.
+func (r *TerraformReconciler) checkBackendConflict(ctx context.Context, terraform infrav1.Terraform, revision string) (infrav1.Terraform, bool, error) {
+	traceLog := ctrl.LoggerFrom(ctx).V(logger.TraceLevel).WithValues("function", "TerraformReconciler.checkBackendConflict")
+
+	if terraform.Spec.PlanOnly {
+		traceLog.Info("Plan-only objects do not write their state")
+		return terraform, false, nil
+	}
+
+	owner, err := r.getBackendOwner(ctx, terraform)
+	if err != nil {
+		return terraform, false, err
+	}
+
+	if owner == nil {
+		traceLog.Info("No other object uses the same backend")
+		return terraform, false, nil
+	}
+
+	msg := fmt.Sprintf("The backend configuration is identical to the one of %s/%s, "+
+		"which already stores its state there. Change the secretSuffix, the workspace or the backend configuration",
+		owner.Namespace, owner.Name)
+	return infrav1.TerraformNotReady(terraform, revision, infrav1.BackendConflictReason, msg), true, nil
+}
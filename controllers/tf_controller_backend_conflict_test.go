@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackendIdentity(t *testing.T) {
+	g := NewWithT(t)
+
+	newTerraform := func(namespace, name string, backendConfig *infrav1.BackendConfigSpec) infrav1.Terraform {
+		return infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       infrav1.TerraformSpec{BackendConfig: backendConfig},
+		}
+	}
+
+	defaultBackend, ok := backendIdentity(newTerraform("flux-system", "hello", nil))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(defaultBackend).To(Equal("kubernetes//flux-system/default/hello"))
+
+	sameSuffix, _ := backendIdentity(newTerraform("flux-system", "other", &infrav1.BackendConfigSpec{SecretSuffix: "hello"}))
+	g.Expect(sameSuffix).To(Equal(defaultBackend))
+
+	otherNamespace, _ := backendIdentity(newTerraform("dev", "hello", nil))
+	g.Expect(otherNamespace).ToNot(Equal(defaultBackend))
+
+	_, ok = backendIdentity(newTerraform("flux-system", "hello", &infrav1.BackendConfigSpec{Disable: true}))
+	g.Expect(ok).To(BeFalse())
+
+	s3 := newTerraform("flux-system", "a", &infrav1.BackendConfigSpec{
+		CustomConfiguration: "backend \"s3\" {\n  bucket = \"state\"\n  key    = \"app\"\n}",
+	})
+	s3Reformatted := newTerraform("dev", "b", &infrav1.BackendConfigSpec{
+		CustomConfiguration: "backend \"s3\" {\n\tbucket = \"state\"\n\tkey = \"app\"\n}\n",
+	})
+	s3OtherWorkspace := *s3.DeepCopy()
+	s3OtherWorkspace.Spec.Workspace = "prod"
+
+	a, _ := backendIdentity(s3)
+	b, _ := backendIdentity(s3Reformatted)
+	c, _ := backendIdentity(s3OtherWorkspace)
+	g.Expect(a).To(Equal(b))
+	g.Expect(a).ToNot(Equal(c))
+
+	// a template shared by the objects renders a backend for each of them
+	templated := &infrav1.BackendConfigSpec{
+		CustomConfiguration: "backend \"s3\" {\n  bucket = \"state\"\n  key    = \"${{ .Namespace }}/${{ .Name }}.tfstate\"\n}",
+	}
+	d, _ := backendIdentity(newTerraform("flux-system", "a", templated))
+	e, _ := backendIdentity(newTerraform("flux-system", "b", templated))
+	f, _ := backendIdentity(newTerraform("dev", "b", &infrav1.BackendConfigSpec{
+		CustomConfiguration: "backend \"s3\" {\n  bucket = \"state\"\n  key    = \"flux-system/a.tfstate\"\n}",
+	}))
+	g.Expect(d).ToNot(Equal(e))
+	g.Expect(d).To(Equal(f))
+
+	// the Secrets of the same name in two namespaces are two different Secrets
+	fromSecret := &infrav1.BackendConfigSpec{
+		CustomConfiguration: "backend \"s3\" {\n  bucket = \"${{ secret \"state-bucket\" \"bucket\" }}\"\n  key    = \"app.tfstate\"\n}",
+	}
+	h, _ := backendIdentity(newTerraform("flux-system", "a", fromSecret))
+	i, _ := backendIdentity(newTerraform("flux-system", "b", fromSecret))
+	j, _ := backendIdentity(newTerraform("dev", "a", fromSecret))
+	g.Expect(h).To(Equal(i))
+	g.Expect(h).ToNot(Equal(j))
+
+	// the remote backend is identified by its workspace
+	remote := &infrav1.BackendConfigSpec{
+		Type:   infrav1.BackendTypeRemote,
+		Remote: &infrav1.RemoteBackendSpec{Organization: "weaveworks", Workspace: "shared"},
+	}
+	remoteBackend, ok := backendIdentity(newTerraform("flux-system", "a", remote))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(remoteBackend).To(Equal("remote/app.terraform.io/weaveworks/shared"))
+	sameWorkspace, _ := backendIdentity(newTerraform("dev", "b", remote))
+	g.Expect(sameWorkspace).To(Equal(remoteBackend))
+
+	// the typed backends are identified by the location of their state, defaulted under the name of the object
+	typed := &infrav1.BackendConfigSpec{
+		Type: infrav1.BackendTypeS3,
+		S3:   &infrav1.S3BackendSpec{Bucket: "state", Region: "eu-west-1"},
+	}
+	typedBackend, ok := backendIdentity(newTerraform("flux-system", "a", typed))
+	g.Expect(ok).To(BeTrue())
+	g.Expect(typedBackend).To(Equal("s3//state/flux-system/a/terraform.tfstate/default"))
+	sameKey, _ := backendIdentity(newTerraform("dev", "b", &infrav1.BackendConfigSpec{
+		Type: infrav1.BackendTypeS3,
+		S3:   &infrav1.S3BackendSpec{Bucket: "state", Key: "flux-system/a/terraform.tfstate", Region: "us-east-1"},
+	}))
+	g.Expect(sameKey).To(Equal(typedBackend))
+}
+
+func TestBackendOwner(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	newTerraform := func(name string, created time.Time) infrav1.Terraform {
+		return infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "flux-system",
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+	}
+
+	first := newTerraform("first", now.Add(-2*time.Hour))
+	second := newTerraform("second", now.Add(-time.Hour))
+	third := newTerraform("third", now)
+	sharing := []infrav1.Terraform{third, second, first}
+
+	g.Expect(backendOwner(first, sharing)).To(BeNil())
+	g.Expect(backendOwner(second, sharing).Name).To(Equal("first"))
+	g.Expect(backendOwner(third, sharing).Name).To(Equal("first"))
+
+	// the name breaks ties between objects created at the same time
+	twin := newTerraform("a-twin", second.CreationTimestamp.Time)
+	g.Expect(backendOwner(second, []infrav1.Terraform{second, twin}).Name).To(Equal("a-twin"))
+	g.Expect(backendOwner(twin, []infrav1.Terraform{second, twin})).To(BeNil())
+}
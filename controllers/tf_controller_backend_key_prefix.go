@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// backendKeyAttributes are the attributes naming the state in the backends storing the states of many configurations
+// in a bucket, with the key generated when the backend does not set it. The prefix of the object is appended to.
+var backendKeyAttributes = map[string]struct {
+	attribute string
+	generated string
+}{
+	"s3":      {"key", "/terraform.tfstate"},
+	"azurerm": {"key", "/terraform.tfstate"},
+	"oss":     {"key", "/terraform.tfstate"},
+	"gcs":     {"prefix", ""},
+	"cos":     {"prefix", ""},
+	"consul":  {"path", ""},
+}
+
+// applyKeyPrefixPolicy returns the custom backend configuration, with the state key of the object under its
+// <namespace>/<name> prefix with keyPrefixPolicy namespaced. The key is generated when the backend does not set it,
+// and prefixed otherwise.
+func applyKeyPrefixPolicy(terraform infrav1.Terraform, customConfiguration string) (string, error) {
+	if !terraform.HasNamespacedStateKey() {
+		return customConfiguration, nil
+	}
+
+	file, diags := hclwrite.ParseConfig([]byte(customConfiguration), "backend.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("unable to parse the backend configuration: %s", diags.Error())
+	}
+
+	var backend *hclwrite.Block
+	for _, block := range file.Body().Blocks() {
+		if block.Type() == "backend" && len(block.Labels()) == 1 {
+			if backend != nil {
+				return "", fmt.Errorf("the backend configuration has more than one backend block")
+			}
+			backend = block
+		}
+	}
+	if backend == nil {
+		return "", fmt.Errorf("the backend configuration has no backend block")
+	}
+
+	backendType := backend.Labels()[0]
+	key, ok := backendKeyAttributes[backendType]
+	if !ok {
+		return "", fmt.Errorf("keyPrefixPolicy %s does not support the %s backend", infrav1.KeyPrefixPolicyNamespaced, backendType)
+	}
+
+	prefix := terraform.StateKeyPrefix()
+	value := prefix + key.generated
+	if attribute := backend.Body().GetAttribute(key.attribute); attribute != nil {
+		declared, err := literalString(attribute.Expr().BuildTokens(nil).Bytes())
+		if err != nil {
+			return "", fmt.Errorf("the %s of the %s backend %s", key.attribute, backendType, err)
+		}
+		declared = strings.TrimPrefix(declared, "/")
+		if declared == prefix || strings.HasPrefix(declared, prefix+"/") {
+			return customConfiguration, nil
+		}
+		value = prefix + "/" + declared
+	}
+
+	backend.Body().SetAttributeValue(key.attribute, cty.StringVal(value))
+	return string(file.Bytes()), nil
+}
+
+// literalString returns the value of an expression which must be a literal string.
+func literalString(expression []byte) (string, error) {
+	expr, diags := hclsyntax.ParseExpression(expression, "backend.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("can't be parsed: %s", diags.Error())
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", fmt.Errorf("must be a literal string")
+	}
+	return value.AsString(), nil
+}
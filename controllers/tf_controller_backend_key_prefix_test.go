@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyKeyPrefixPolicy(t *testing.T) {
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{KeyPrefixPolicy: infrav1.KeyPrefixPolicyNamespaced},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		backend  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "generated key",
+			backend:  "backend \"s3\" {\n  bucket = \"state\"\n}\n",
+			expected: "backend \"s3\" {\n  bucket = \"state\"\n  key    = \"flux-system/helloworld/terraform.tfstate\"\n}\n",
+		},
+		{
+			name:     "prefixed key",
+			backend:  "backend \"azurerm\" {\n  key = \"app.tfstate\"\n}\n",
+			expected: "backend \"azurerm\" {\n  key = \"flux-system/helloworld/app.tfstate\"\n}\n",
+		},
+		{
+			name:     "already prefixed key",
+			backend:  "backend \"s3\" {\n  key = \"flux-system/helloworld/app.tfstate\"\n}\n",
+			expected: "backend \"s3\" {\n  key = \"flux-system/helloworld/app.tfstate\"\n}\n",
+		},
+		{
+			name:     "generated prefix",
+			backend:  "backend \"gcs\" {\n  bucket = \"state\"\n}\n",
+			expected: "backend \"gcs\" {\n  bucket = \"state\"\n  prefix = \"flux-system/helloworld\"\n}\n",
+		},
+		{name: "unsupported backend", backend: "backend \"pg\" {}\n", err: "does not support the pg backend"},
+		{name: "key of a variable", backend: "backend \"s3\" {\n  key = var.key\n}\n", err: "must be a literal string"},
+		{name: "no backend", backend: "required_version = \">= 1.3\"\n", err: "no backend block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			result, err := applyKeyPrefixPolicy(terraform, tt.backend)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tt.expected))
+		})
+	}
+
+	// without the policy, the configuration is kept as it is
+	terraform.Spec.BackendConfig.KeyPrefixPolicy = ""
+	g := NewWithT(t)
+	result, err := applyKeyPrefixPolicy(terraform, "backend \"pg\" {}\n")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal("backend \"pg\" {}\n"))
+}
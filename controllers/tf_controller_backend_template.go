@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// backendTemplateData is the data of the template of the customConfiguration of the backend.
+type backendTemplateData struct {
+	Name      string
+	Namespace string
+	Workspace string
+	Labels    map[string]string
+}
+
+// secretLookup returns the value of a key of a Secret, for the secret function of the templates.
+type secretLookup func(name, key string) (string, error)
+
+// renderCustomConfiguration renders the customConfiguration of the backend of the object.
+func renderCustomConfiguration(terraform infrav1.Terraform, lookup secretLookup) (string, error) {
+	text := terraform.Spec.BackendConfig.CustomConfiguration
+	if !strings.Contains(text, "${{") {
+		return text, nil
+	}
+
+	tmpl, err := template.
+		New("customConfiguration").
+		Delims("${{", "}}").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"secret": lookup}).
+		Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the customConfiguration of the backend: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, backendTemplateData{
+		Name:      terraform.Name,
+		Namespace: terraform.Namespace,
+		Workspace: terraform.WorkspaceName(),
+		Labels:    terraform.Labels,
+	}); err != nil {
+		return "", fmt.Errorf("unable to render the customConfiguration of the backend: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// backendTemplateSecret reads the Secrets of the namespace of the object, only when they are labelled for the backend templates,
+// so that a template can't read the states, the plans or the credentials of the runners.
+func (r *TerraformReconciler) backendTemplateSecret(ctx context.Context, terraform infrav1.Terraform) secretLookup {
+	return func(name, key string) (string, error) {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: name}, &secret); err != nil {
+			return "", err
+		}
+		if secret.Labels[infrav1.BackendTemplateSecretLabel] != "true" {
+			return "", fmt.Errorf("secret %s must be labelled %s=true to be read by the backend configuration", name, infrav1.BackendTemplateSecretLabel)
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("secret %s has no key %s", name, key)
+		}
+		return string(value), nil
+	}
+}
+
+// placeholderSecret stands for the values of the Secrets of the namespace of the object where they are not read,
+// e.g. in the indexes. Two configurations reading the same keys of the same namespace get the same placeholders.
+func placeholderSecret(terraform infrav1.Terraform) secretLookup {
+	return func(name, key string) (string, error) {
+		return fmt.Sprintf("secret:%s/%s/%s", terraform.Namespace, name, key), nil
+	}
+}
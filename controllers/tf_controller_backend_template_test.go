@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRenderCustomConfiguration(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "state-bucket", Namespace: "flux-system", Labels: map[string]string{infrav1.BackendTemplateSecretLabel: "true"}},
+			Data:       map[string][]byte{"bucket": []byte("tf-states-prod")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "runner-credentials", Namespace: "flux-system"},
+			Data:       map[string][]byte{"token": []byte("secret")},
+		},
+	).Build()}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", Labels: map[string]string{"team": "platform"}},
+		Spec: infrav1.TerraformSpec{
+			Workspace: "prod",
+			BackendConfig: &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" {
+  bucket = "${{ secret "state-bucket" "bucket" }}"
+  key    = "env:/${{ .Workspace }}/${{ .Labels.team }}/${{ .Name }}.tfstate"
+}`},
+		},
+	}
+	rendered, err := renderCustomConfiguration(terraform, r.backendTemplateSecret(ctx, terraform))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rendered).To(Equal(`backend "s3" {
+  bucket = "tf-states-prod"
+  key    = "env:/prod/platform/helloworld.tfstate"
+}`))
+
+	rendered, err = renderCustomConfiguration(terraform, placeholderSecret(terraform))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rendered).To(ContainSubstring(`bucket = "secret:flux-system/state-bucket/bucket"`))
+
+	// the Secrets not labelled for the backend templates can't be read
+	terraform.Spec.BackendConfig.CustomConfiguration = `backend "http" { password = "${{ secret "runner-credentials" "token" }}" }`
+	_, err = renderCustomConfiguration(terraform, r.backendTemplateSecret(ctx, terraform))
+	g.Expect(err).To(MatchError(ContainSubstring("must be labelled " + infrav1.BackendTemplateSecretLabel)))
+
+	terraform.Spec.BackendConfig.CustomConfiguration = `backend "s3" { key = "${{ secret "state-bucket" "key" }}" }`
+	_, err = renderCustomConfiguration(terraform, r.backendTemplateSecret(ctx, terraform))
+	g.Expect(err).To(MatchError(ContainSubstring("has no key key")))
+
+	// a configuration without template delimiters is kept as it is
+	terraform.Spec.BackendConfig.CustomConfiguration = `backend "s3" { key = "{{ not a template }}" }`
+	rendered, err = renderCustomConfiguration(terraform, placeholderSecret(terraform))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rendered).To(Equal(`backend "s3" { key = "{{ not a template }}" }`))
+}
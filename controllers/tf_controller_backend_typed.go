@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+// typedBackendConfig returns the backendConfig of an object with an s3, gcs or azurerm backend, sent to the runner
+// generating its backend block, with the state under the <namespace>/<name> prefix when the key is not set.
+func typedBackendConfig(terraform infrav1.Terraform) infrav1.BackendConfigSpec {
+	backendConfig := *terraform.Spec.BackendConfig.DeepCopy()
+	prefix := terraform.StateKeyPrefix()
+	switch backendConfig.Type {
+	case infrav1.BackendTypeS3:
+		if backendConfig.S3.Key == "" {
+			backendConfig.S3.Key = prefix + "/terraform.tfstate"
+		}
+	case infrav1.BackendTypeGCS:
+		if backendConfig.GCS.Prefix == "" {
+			backendConfig.GCS.Prefix = prefix
+		}
+	case infrav1.BackendTypeAzureRM:
+		if backendConfig.AzureRM.Key == "" {
+			backendConfig.AzureRM.Key = prefix + "/terraform.tfstate"
+		}
+	}
+	return backendConfig
+}
+
+// typedBackendIdentity returns the location of the state of an object with an s3, gcs or azurerm backend.
+func typedBackendIdentity(terraform infrav1.Terraform) string {
+	backendConfig := typedBackendConfig(terraform)
+	var location string
+	switch backendConfig.Type {
+	case infrav1.BackendTypeS3:
+		location = backendConfig.S3.Endpoint + "/" + backendConfig.S3.Bucket + "/" + backendConfig.S3.Key
+	case infrav1.BackendTypeGCS:
+		location = backendConfig.GCS.Bucket + "/" + backendConfig.GCS.Prefix
+	case infrav1.BackendTypeAzureRM:
+		location = backendConfig.AzureRM.StorageAccountName + "/" + backendConfig.AzureRM.ContainerName + "/" + backendConfig.AzureRM.Key
+	}
+	return backendConfig.Type + "/" + location + "/" + terraform.WorkspaceName()
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWriteTypedBackendConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "flux-system"},
+		Data: map[string][]byte{
+			"access_key": []byte("AKIA"),
+			"secret_key": []byte("s3cr3t"),
+			"region":     []byte("ignored"),
+		},
+	}
+	server := &runner.TerraformRunnerServer{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(credentials).Build(),
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			BackendConfig: &infrav1.BackendConfigSpec{
+				Type: infrav1.BackendTypeS3,
+				S3: &infrav1.S3BackendSpec{
+					Bucket:               "tf-states",
+					Region:               "eu-west-1",
+					Encrypt:              true,
+					CredentialsSecretRef: &meta.LocalObjectReference{Name: "aws-credentials"},
+				},
+			},
+		},
+	}
+	g.Expect(terraform.HasTypedBackend()).To(BeTrue())
+
+	writeBackend := func(terraform infrav1.Terraform) (string, error) {
+		dir := t.TempDir()
+		typedBackend, err := json.Marshal(typedBackendConfig(terraform))
+		g.Expect(err).ToNot(HaveOccurred())
+		if _, err := server.WriteBackendConfig(ctx, &runner.WriteBackendConfigRequest{
+			DirPath:      dir,
+			TypedBackend: typedBackend,
+			Namespace:    terraform.Namespace,
+		}); err != nil {
+			return "", err
+		}
+		backend, err := os.ReadFile(filepath.Join(dir, "backend_override.tf"))
+		g.Expect(err).ToNot(HaveOccurred())
+		return string(backend), nil
+	}
+
+	backend, err := writeBackend(terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backend).To(Equal(`terraform {
+  backend "s3" {
+    bucket     = "tf-states"
+    key        = "flux-system/helloworld/terraform.tfstate"
+    region     = "eu-west-1"
+    encrypt    = true
+    access_key = "AKIA"
+    secret_key = "s3cr3t"
+  }
+}
+`))
+
+	gcs := *terraform.DeepCopy()
+	gcs.Spec.BackendConfig.Type = infrav1.BackendTypeGCS
+	gcs.Spec.BackendConfig.GCS = &infrav1.GCSBackendSpec{Bucket: "tf-states"}
+	backend, err = writeBackend(gcs)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(backend).To(ContainSubstring(`prefix = "flux-system/helloworld"`))
+
+	// the credentials of a gcs backend are a key of a service account
+	gcs.Spec.BackendConfig.GCS.CredentialsSecretRef = &meta.LocalObjectReference{Name: "aws-credentials"}
+	_, err = writeBackend(gcs)
+	g.Expect(err).To(MatchError(ContainSubstring("has none of the keys credentials")))
+
+	missing := *terraform.DeepCopy()
+	missing.Spec.BackendConfig.S3.CredentialsSecretRef.Name = "missing"
+	_, err = writeBackend(missing)
+	g.Expect(err).To(MatchError(ContainSubstring("unable to get the credentials of the s3 backend")))
+}
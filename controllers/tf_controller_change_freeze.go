@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// applyBlocked returns the reason, and the message, why the pending plan of the object must not be applied now,
+// or an empty reason when it may be.
+func (r *TerraformReconciler) applyBlocked(ctx context.Context, terraform infrav1.Terraform) (string, string, error) {
+	if r.breakGlassApproves(terraform) {
+		return "", "", nil
+	}
+
+	if terraform.Spec.SuspendApply {
+		return infrav1.ApplySuspendedReason, fmt.Sprintf("Apply is suspended, plan %s is pending", terraform.Status.Plan.Pending), nil
+	}
+
+	freeze, window, err := r.activeChangeFreeze(ctx, terraform, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	if freeze != nil {
+		msg := fmt.Sprintf("Change freeze %s is active until %s, plan %s is pending", freeze.Name, window.End.UTC().Format(time.RFC3339), terraform.Status.Plan.Pending)
+		if freeze.Spec.Reason != "" {
+			msg += ": " + freeze.Spec.Reason
+		}
+		return infrav1.ChangeFreezeReason, msg, nil
+	}
+
+	return "", "", nil
+}
+
+// activeChangeFreeze returns the first ChangeFreeze, by name, with a window active at now covering the namespace of the object.
+func (r *TerraformReconciler) activeChangeFreeze(ctx context.Context, terraform infrav1.Terraform, now time.Time) (*infrav1.ChangeFreeze, infrav1.FreezeWindow, error) {
+	var list infrav1.ChangeFreezeList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, infrav1.FreezeWindow{}, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	// read the namespace lazily, as most freezes are not active
+	var namespaceLabels labels.Set
+	for i := range list.Items {
+		freeze := &list.Items[i]
+		window, active := freeze.ActiveWindow(now)
+		if !active {
+			continue
+		}
+
+		if freeze.Spec.NamespaceSelector != nil && namespaceLabels == nil {
+			var ns corev1.Namespace
+			if err := r.Get(ctx, types.NamespacedName{Name: terraform.Namespace}, &ns); err != nil {
+				return nil, infrav1.FreezeWindow{}, err
+			}
+			namespaceLabels = labels.Set(ns.Labels)
+			if namespaceLabels == nil {
+				namespaceLabels = labels.Set{}
+			}
+		}
+
+		covered, err := changeFreezeCovers(*freeze, namespaceLabels)
+		if err != nil {
+			return nil, infrav1.FreezeWindow{}, fmt.Errorf("invalid namespace selector of change freeze %s: %w", freeze.Name, err)
+		}
+		if covered {
+			return freeze, window, nil
+		}
+	}
+
+	return nil, infrav1.FreezeWindow{}, nil
+}
+
+func changeFreezeCovers(freeze infrav1.ChangeFreeze, namespaceLabels labels.Set) (bool, error) {
+	if freeze.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(freeze.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(namespaceLabels), nil
+}
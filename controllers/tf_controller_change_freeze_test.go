@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyBlocked(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	now := time.Now()
+	window := func(from, to time.Duration) infrav1.FreezeWindow {
+		return infrav1.FreezeWindow{Start: metav1.NewTime(now.Add(from)), End: metav1.NewTime(now.Add(to))}
+	}
+
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		&infrav1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "past"},
+			Spec:       infrav1.ChangeFreezeSpec{Windows: []infrav1.FreezeWindow{window(-2*time.Hour, -time.Hour)}},
+		},
+		&infrav1.ChangeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "year-end"},
+			Spec: infrav1.ChangeFreezeSpec{
+				Windows:           []infrav1.FreezeWindow{window(24*time.Hour, 48*time.Hour), window(-time.Hour, time.Hour)},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Reason:            "End of year release freeze",
+			},
+		},
+	).Build()}
+
+	terraform := func(namespace string) infrav1.Terraform {
+		return infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: namespace},
+			Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-abc"}},
+		}
+	}
+
+	reason, msg, err := r.applyBlocked(ctx, terraform("prod"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(Equal(infrav1.ChangeFreezeReason))
+	g.Expect(msg).To(ContainSubstring("Change freeze year-end is active"))
+	g.Expect(msg).To(ContainSubstring("End of year release freeze"))
+
+	reason, _, err = r.applyBlocked(ctx, terraform("dev"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(BeEmpty())
+
+	suspended := terraform("dev")
+	suspended.Spec.SuspendApply = true
+	reason, msg, err = r.applyBlocked(ctx, suspended)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(Equal(infrav1.ApplySuspendedReason))
+	g.Expect(msg).To(ContainSubstring("plan-main-abc"))
+}
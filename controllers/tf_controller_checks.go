@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// namespaceResourceLimitsCheck is the name of the check of the resource limits of the namespace,
+// run first after planning when none of the checks of the object is of the resourceLimits type.
+const namespaceResourceLimitsCheck = "namespace-resource-limits"
+
+// checkEnv is what the checks of a stage share: the runner of the object, and what they read from it,
+// read once for all of them.
+type checkEnv struct {
+	runnerClient runner.RunnerClient
+	tfInstance   string
+	revision     string
+
+	planJSON []byte
+	outputs  map[string]string
+}
+
+// plan returns the JSON of the plan of the runner.
+func (e *checkEnv) plan(ctx context.Context) ([]byte, error) {
+	if e.planJSON == nil {
+		reply, err := e.runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{
+			TfInstance: e.tfInstance,
+			Filename:   runner.TFPlanName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get plan file: %w", err)
+		}
+		e.planJSON = reply.JsonOutput
+	}
+	return e.planJSON, nil
+}
+
+// checkFunc runs a check of a type. A failed check returns the object with the conditions of its failure, and an error.
+type checkFunc func(r *TerraformReconciler, ctx context.Context, terraform infrav1.Terraform, check infrav1.Check, env *checkEnv) (infrav1.Terraform, error)
+
+// checkFuncs are the checks by type. A new type of check is added here, with its field in infrav1.Check.
+var checkFuncs = map[string]checkFunc{
+	infrav1.CheckTypeWebhook:        runWebhookCheck,
+	infrav1.CheckTypeResourceLimits: runResourceLimitsCheck,
+	infrav1.CheckTypePolicy:         runPolicyCheck,
+	infrav1.CheckTypeHealthCheck:    runHealthCheck,
+}
+
+// planFileCheckTypes are the types of the checks reading the plan file, which are skipped without one.
+var planFileCheckTypes = map[string]bool{
+	infrav1.CheckTypeResourceLimits: true,
+	infrav1.CheckTypePolicy:         true,
+}
+
+func checkStage(check infrav1.Check) string {
+	if check.Stage == "" {
+		return infrav1.CheckStagePostPlanning
+	}
+	return check.Stage
+}
+
+// stageChecks returns the checks of a stage, in order: those of spec.checks, or else the checks made of
+// the webhooks, the resource limits and the policy checks after planning, and of the health checks after applying.
+// The resource limits of the namespace of the object are checked in any case.
+func (r *TerraformReconciler) stageChecks(terraform infrav1.Terraform, stage string) []infrav1.Check {
+	var checks []infrav1.Check
+	if len(terraform.Spec.Checks) > 0 {
+		hasResourceLimits := false
+		for _, check := range terraform.Spec.Checks {
+			if checkStage(check) == stage {
+				checks = append(checks, check)
+				hasResourceLimits = hasResourceLimits || check.Type == infrav1.CheckTypeResourceLimits
+			}
+		}
+		if stage == infrav1.CheckStagePostPlanning && !hasResourceLimits && r.namespaceResourceLimits(terraform) != nil {
+			checks = append([]infrav1.Check{{Name: namespaceResourceLimitsCheck, Type: infrav1.CheckTypeResourceLimits}}, checks...)
+		}
+	} else {
+		checks = r.implicitChecks(terraform, stage)
+	}
+
+	var enabled []infrav1.Check
+	for _, check := range checks {
+		if check.Type == infrav1.CheckTypeWebhook && check.Webhook != nil && !check.Webhook.IsEnabled() {
+			continue
+		}
+		if planFileCheckTypes[check.Type] && r.backendCompletelyDisable(terraform) {
+			continue
+		}
+		enabled = append(enabled, check)
+	}
+	return enabled
+}
+
+// implicitChecks returns the checks of a stage made of the webhooks, the resource limits, the policy checks,
+// and the health checks of an object without spec.checks.
+func (r *TerraformReconciler) implicitChecks(terraform infrav1.Terraform, stage string) []infrav1.Check {
+	var checks []infrav1.Check
+	switch stage {
+	case infrav1.CheckStagePostPlanning:
+		for i := range terraform.Spec.Webhooks {
+			if webhook := terraform.Spec.Webhooks[i]; webhook.Stage == infrav1.PostPlanningWebhook {
+				checks = append(checks, infrav1.Check{Name: webhook.URL, Stage: stage, Type: infrav1.CheckTypeWebhook, Webhook: &webhook})
+			}
+		}
+		if terraform.Spec.ResourceLimits != nil || r.namespaceResourceLimits(terraform) != nil {
+			checks = append(checks, infrav1.Check{Name: "resource-limits", Stage: stage, Type: infrav1.CheckTypeResourceLimits, ResourceLimits: terraform.Spec.ResourceLimits})
+		}
+		for i := range terraform.Spec.PolicyChecks {
+			policy := terraform.Spec.PolicyChecks[i]
+			checks = append(checks, infrav1.Check{Name: policy.Name, Stage: stage, Type: infrav1.CheckTypePolicy, Policy: &policy})
+		}
+	case infrav1.CheckStagePostApply:
+		for i := range terraform.Spec.HealthChecks {
+			hc := terraform.Spec.HealthChecks[i]
+			checks = append(checks, infrav1.Check{Name: hc.Name, Stage: stage, Type: infrav1.CheckTypeHealthCheck, HealthCheck: &hc})
+		}
+	}
+	return checks
+}
+
+// runChecks runs the checks of a stage in order, stopping at the first failing one,
+// and records their results in the status, in place of those of the last run of the stage.
+func (r *TerraformReconciler) runChecks(ctx context.Context, terraform infrav1.Terraform, stage string, env *checkEnv) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	checks := r.stageChecks(terraform, stage)
+
+	var statuses []infrav1.CheckStatus
+	for _, status := range terraform.Status.Checks {
+		if status.Stage != stage {
+			statuses = append(statuses, status)
+		}
+	}
+
+	policies := 0
+	for _, check := range checks {
+		log.Info("running check", "name", check.Name, "stage", stage, "type", check.Type)
+		status := infrav1.CheckStatus{Name: check.Name, Stage: stage, Type: check.Type, Result: infrav1.CheckResultPassed}
+
+		var err error
+		if run, ok := checkFuncs[check.Type]; ok {
+			terraform, err = run(r, ctx, terraform, check, env)
+		} else {
+			err = fmt.Errorf("unsupported type %s of check %s", check.Type, check.Name)
+			terraform = infrav1.TerraformNotReady(terraform, env.revision, infrav1.TFExecPlanFailedReason, err.Error())
+		}
+		if err != nil {
+			status.Result = infrav1.CheckResultFailed
+			status.Message = err.Error()
+			terraform.Status.Checks = append(statuses, status)
+			return terraform, err
+		}
+
+		statuses = append(statuses, status)
+		if check.Type == infrav1.CheckTypePolicy {
+			policies++
+		}
+	}
+	terraform.Status.Checks = statuses
+
+	if policies > 0 {
+		terraform = infrav1.TerraformPolicyPassed(terraform, fmt.Sprintf("Plan allowed by %d policy check(s)", policies))
+	}
+	if stage == infrav1.CheckStagePostApply && len(checks) > 0 {
+		terraform = infrav1.TerraformHealthCheckSucceeded(terraform, "Health checks succeeded")
+	}
+	return terraform, nil
+}
+
+func runWebhookCheck(r *TerraformReconciler, ctx context.Context, terraform infrav1.Terraform, check infrav1.Check, env *checkEnv) (infrav1.Terraform, error) {
+	var err error
+	if check.Webhook == nil {
+		err = fmt.Errorf("check %s has no webhook", check.Name)
+	} else {
+		terraform, err = r.processWebhook(ctx, terraform, *check.Webhook, env.runnerClient, env.revision, env.tfInstance)
+	}
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed during the process of post planning webhooks")
+		return infrav1.TerraformNotReady(
+			terraform,
+			env.revision,
+			infrav1.PostPlanningWebhookFailedReason,
+			err.Error(),
+		), err
+	}
+	return terraform, nil
+}
+
+func runResourceLimitsCheck(r *TerraformReconciler, ctx context.Context, terraform infrav1.Terraform, check infrav1.Check, env *checkEnv) (infrav1.Terraform, error) {
+	planJSON, err := env.plan(ctx)
+	var violations []string
+	if err == nil {
+		terraform, violations, err = r.checkResourceLimits(ctx, terraform, check.ResourceLimits, planJSON)
+	}
+	if err != nil {
+		err = fmt.Errorf("error checking resource limits: %s", err)
+		return infrav1.TerraformNotReady(
+			terraform,
+			env.revision,
+			infrav1.TFExecPlanFailedReason,
+			err.Error(),
+		), err
+	}
+
+	if len(violations) > 0 {
+		msg := fmt.Sprintf("Plan exceeds resource limits: %s", strings.Join(violations, "; "))
+		r.event(ctx, terraform, env.revision, events.EventSeverityError, msg, nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			env.revision,
+			infrav1.ResourceLimitsExceededReason,
+			msg,
+		), errors.New(msg)
+	}
+	return terraform, nil
+}
+
+func runPolicyCheck(r *TerraformReconciler, ctx context.Context, terraform infrav1.Terraform, check infrav1.Check, env *checkEnv) (infrav1.Terraform, error) {
+	planJSON, err := env.plan(ctx)
+	var denials []string
+	if err == nil && check.Policy == nil {
+		err = fmt.Errorf("check %s has no policy", check.Name)
+	} else if err == nil {
+		denials, err = r.checkPolicy(ctx, terraform, *check.Policy, planJSON)
+	}
+	if err != nil {
+		err = fmt.Errorf("error checking policies: %s", err)
+		return infrav1.TerraformNotReady(
+			terraform,
+			env.revision,
+			infrav1.TFExecPlanFailedReason,
+			err.Error(),
+		), err
+	}
+
+	if len(denials) > 0 {
+		msg := fmt.Sprintf("Plan denied by policies: %s", strings.Join(denials, "; "))
+		r.event(ctx, terraform, env.revision, events.EventSeverityError, msg, nil)
+		return infrav1.TerraformPolicyFailed(terraform, env.revision, msg), errors.New(msg)
+	}
+	return terraform, nil
+}
+
+func runHealthCheck(r *TerraformReconciler, ctx context.Context, terraform infrav1.Terraform, check infrav1.Check, env *checkEnv) (infrav1.Terraform, error) {
+	if check.HealthCheck == nil {
+		err := fmt.Errorf("check %s has no health check", check.Name)
+		return infrav1.TerraformHealthCheckFailed(terraform, err.Error()), err
+	}
+
+	if env.outputs == nil {
+		outputs, err := r.healthCheckOutputs(ctx, terraform, env.runnerClient)
+		if err != nil {
+			return infrav1.TerraformHealthCheckFailed(terraform, err.Error()), err
+		}
+		env.outputs = outputs
+	}
+	return r.doHealthCheck(ctx, terraform, *check.HealthCheck, env.outputs, env.revision)
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type mockRunnerClientForChecks struct {
+	runner.RunnerClient
+	plansShown int
+}
+
+func (m *mockRunnerClientForChecks) ShowPlanFile(ctx context.Context, req *runner.ShowPlanFileRequest, opts ...grpc.CallOption) (*runner.ShowPlanFileReply, error) {
+	m.plansShown++
+	return &runner.ShowPlanFileReply{JsonOutput: []byte(resourceLimitsPlanJSON)}, nil
+}
+
+func checkNames(checks []infrav1.Check) []string {
+	var names []string
+	for _, check := range checks {
+		names = append(names, check.Type+"/"+check.Name)
+	}
+	return names
+}
+
+func TestStageChecks(t *testing.T) {
+	g := NewWithT(t)
+	disabled := false
+	r := &TerraformReconciler{Config: &ControllerConfigWatcher{config: ControllerConfig{
+		NamespaceResourceLimits: map[string]infrav1.ResourceLimits{"team-a": {ForbiddenResourceTypes: []string{"aws_iam_*"}}},
+	}}}
+
+	// without spec.checks, the webhooks, the resource limits and the policies are checked in this order after planning
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+		Spec: infrav1.TerraformSpec{
+			PolicyChecks: []infrav1.PolicyCheck{{Name: "policies"}},
+			Webhooks: []infrav1.Webhook{
+				{Stage: infrav1.PostPlanningWebhook, URL: "https://review.example.com"},
+				{Stage: infrav1.PostPlanningWebhook, URL: "https://disabled.example.com", Enabled: &disabled},
+			},
+			HealthChecks: []infrav1.HealthCheck{{Name: "api", Type: "http", URL: "https://api.example.com"}},
+		},
+	}
+	g.Expect(checkNames(r.stageChecks(terraform, infrav1.CheckStagePostPlanning))).To(Equal([]string{
+		"webhook/https://review.example.com", "resourceLimits/resource-limits", "policy/policies",
+	}))
+	g.Expect(checkNames(r.stageChecks(terraform, infrav1.CheckStagePostApply))).To(Equal([]string{"healthCheck/api"}))
+
+	// spec.checks replace them, after the resource limits of the namespace
+	terraform.Spec = infrav1.TerraformSpec{Checks: []infrav1.Check{
+		{Name: "opa", Type: infrav1.CheckTypePolicy, Policy: &infrav1.PolicyCheck{Name: "policies"}},
+		{Name: "review", Type: infrav1.CheckTypeWebhook, Webhook: &infrav1.Webhook{URL: "https://review.example.com"}},
+		{Name: "api", Stage: infrav1.CheckStagePostApply, Type: infrav1.CheckTypeHealthCheck, HealthCheck: &infrav1.HealthCheck{Name: "api"}},
+	}}
+	g.Expect(checkNames(r.stageChecks(terraform, infrav1.CheckStagePostPlanning))).To(Equal([]string{
+		"resourceLimits/namespace-resource-limits", "policy/opa", "webhook/review",
+	}))
+	g.Expect(checkNames(r.stageChecks(terraform, infrav1.CheckStagePostApply))).To(Equal([]string{"healthCheck/api"}))
+
+	// the checks of the plan file are skipped without a backend
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+	g.Expect(checkNames(r.stageChecks(terraform, infrav1.CheckStagePostPlanning))).To(Equal([]string{"webhook/review"}))
+}
+
+func TestRunChecks(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	policies := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies", Namespace: "team-a"},
+		Data:       map[string]string{"delete.rego": noDeletePolicy},
+	}
+	noPolicies := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-policies", Namespace: "team-a"},
+		Data:       map[string]string{"allow.rego": "package terraform\n"},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(policies, noPolicies).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"},
+		Spec: infrav1.TerraformSpec{Checks: []infrav1.Check{
+			{Name: "allow-all", Type: infrav1.CheckTypePolicy, Policy: &infrav1.PolicyCheck{Name: "no-policies"}},
+			{Name: "no-iam", Type: infrav1.CheckTypeResourceLimits, ResourceLimits: &infrav1.ResourceLimits{ForbiddenResourceTypes: []string{"aws_iam_*"}}},
+			{Name: "missing", Type: infrav1.CheckTypePolicy, Policy: &infrav1.PolicyCheck{Name: "missing"}},
+		}},
+		Status: infrav1.TerraformStatus{Checks: []infrav1.CheckStatus{
+			{Name: "api", Stage: infrav1.CheckStagePostApply, Type: infrav1.CheckTypeHealthCheck, Result: infrav1.CheckResultPassed},
+			{Name: "allow-all", Stage: infrav1.CheckStagePostPlanning, Type: infrav1.CheckTypePolicy, Result: infrav1.CheckResultFailed},
+		}},
+	}
+
+	// the first failing check stops the stage, the results of the other stages are kept
+	runnerClient := &mockRunnerClientForChecks{}
+	env := &checkEnv{runnerClient: runnerClient, tfInstance: "instance", revision: "main/b8e362c206"}
+	checked, err := r.runChecks(ctx, terraform, infrav1.CheckStagePostPlanning, env)
+	g.Expect(err).To(MatchError("Plan exceeds resource limits: resource types forbidden in this object: module.iam.aws_iam_role.r"))
+	g.Expect(checked.Status.Checks).To(Equal([]infrav1.CheckStatus{
+		{Name: "api", Stage: infrav1.CheckStagePostApply, Type: infrav1.CheckTypeHealthCheck, Result: infrav1.CheckResultPassed},
+		{Name: "allow-all", Stage: infrav1.CheckStagePostPlanning, Type: infrav1.CheckTypePolicy, Result: infrav1.CheckResultPassed},
+		{Name: "no-iam", Stage: infrav1.CheckStagePostPlanning, Type: infrav1.CheckTypeResourceLimits, Result: infrav1.CheckResultFailed, Message: err.Error()},
+	}))
+	g.Expect(apimeta.FindStatusCondition(checked.Status.Conditions, "Ready").Reason).To(Equal(infrav1.ResourceLimitsExceededReason))
+	g.Expect(runnerClient.plansShown).To(Equal(1))
+
+	// the policies deny the plan once the resource limits pass
+	terraform.Spec.Checks[1].ResourceLimits = &infrav1.ResourceLimits{}
+	terraform.Spec.Checks[2].Policy.Name = "policies"
+	checked, err = r.runChecks(ctx, terraform, infrav1.CheckStagePostPlanning, &checkEnv{runnerClient: runnerClient, tfInstance: "instance", revision: "main/b8e362c206"})
+	g.Expect(err).To(MatchError("Plan denied by policies: policies: aws_iam_user.old is deleted"))
+	g.Expect(checked.Status.Checks).To(HaveLen(4))
+	g.Expect(apimeta.FindStatusCondition(checked.Status.Conditions, infrav1.ConditionTypePolicyCheck).Reason).To(Equal(infrav1.PolicyFailedReason))
+
+	terraform.Spec.Checks = terraform.Spec.Checks[:2]
+	checked, err = r.runChecks(ctx, terraform, infrav1.CheckStagePostPlanning, &checkEnv{runnerClient: runnerClient, tfInstance: "instance", revision: "main/b8e362c206"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(apimeta.FindStatusCondition(checked.Status.Conditions, infrav1.ConditionTypePolicyCheck).Message).To(Equal("Plan allowed by 1 policy check(s)"))
+}
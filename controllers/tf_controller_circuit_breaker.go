@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// maxConsecutiveFailures returns the number of failures in a row, with the same reason,
+// after which the object is stalled. Zero never stalls it.
+func (r *TerraformReconciler) maxConsecutiveFailures(terraform infrav1.Terraform) int32 {
+	if terraform.Spec.MaxConsecutiveFailures != nil {
+		return *terraform.Spec.MaxConsecutiveFailures
+	}
+	return r.Config.Get().DefaultMaxConsecutiveFailures
+}
+
+// recordFailure classifies and counts the failed reconciliation, with its diagnostics, and stalls the object once it failed too many times in a row
+// with the same reason. It returns true if the object is stalled.
+func (r *TerraformReconciler) recordFailure(ctx context.Context, terraform *infrav1.Terraform, revision string, reconcileErr error) (bool, error) {
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	class := classifyError(reconcileErr.Error())
+	*terraform = infrav1.TerraformFailureClassified(*terraform, class, reconcileErr.Error())
+	reconcileFailures.WithLabelValues(terraform.Namespace, terraform.Name, class).Inc()
+	if diagnostics := terraformDiagnostics(reconcileErr.Error()); len(diagnostics) > 0 {
+		*terraform = infrav1.TerraformRunFailed(*terraform, diagnostics)
+	} else if terraform.Status.LastRun != nil {
+		terraform.Status.LastRun.Diagnostics = nil
+	}
+
+	var count int32
+	*terraform, count = infrav1.TerraformFailed(*terraform)
+	max := r.maxConsecutiveFailures(*terraform)
+	stalled := max > 0 && count >= max
+	if stalled {
+		msg := fmt.Sprintf("Reconciliation failed %d times in a row with reason %s, it is not retried until the failures are acknowledged with the %s annotation",
+			count, terraform.Status.Failures.Reason, infrav1.AcknowledgeFailuresAnnotation)
+		*terraform = infrav1.TerraformStalled(*terraform, msg)
+		r.event(ctx, *terraform, revision, events.EventSeverityError, msg, nil)
+	}
+
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		return stalled, err
+	}
+	return stalled, nil
+}
+
+// resetFailures clears the failures of an object which has been reconciled successfully.
+func (r *TerraformReconciler) resetFailures(ctx context.Context, terraform *infrav1.Terraform) error {
+	if terraform.Status.Failures.Count == 0 {
+		return nil
+	}
+
+	*terraform = infrav1.TerraformFailuresReset(*terraform)
+	return r.patchStatus(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, terraform.Status)
+}
+
+// isStalled returns true if the object is stalled, and its failures have not been acknowledged since.
+// Acknowledging the failures resets them, and resumes the object.
+func (r *TerraformReconciler) isStalled(ctx context.Context, terraform *infrav1.Terraform) (bool, error) {
+	if !apimeta.IsStatusConditionTrue(terraform.Status.Conditions, meta.StalledCondition) {
+		return false, nil
+	}
+
+	if terraform.Status.Failures.Reason == infrav1.SourceNotFoundReason &&
+		terraform.Spec.SourceNotFound != nil && terraform.Spec.SourceNotFound.ResumeWhenFound {
+		if _, err := r.getSource(ctx, *terraform); err == nil {
+			*terraform = infrav1.TerraformFailuresReset(*terraform)
+			if err := r.patchStatus(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, terraform.Status); err != nil {
+				return false, err
+			}
+			sourceNotFoundStalled.DeleteLabelValues(terraform.Namespace, terraform.Name)
+			r.event(ctx, *terraform, terraform.Status.LastAttemptedRevision, events.EventSeverityInfo, "Source found, the reconciliation is resumed", nil)
+			return false, nil
+		}
+	}
+
+	acknowledged := terraform.GetAnnotations()[infrav1.AcknowledgeFailuresAnnotation]
+	if acknowledged == "" || acknowledged == terraform.Status.Failures.LastAcknowledged {
+		return true, nil
+	}
+
+	*terraform = infrav1.TerraformFailuresReset(*terraform)
+	terraform.Status.Failures.LastAcknowledged = acknowledged
+	if err := r.patchStatus(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, terraform.Status); err != nil {
+		return false, err
+	}
+	sourceNotFoundStalled.DeleteLabelValues(terraform.Namespace, terraform.Name)
+	r.event(ctx, *terraform, terraform.Status.LastAttemptedRevision, events.EventSeverityInfo, "Failures acknowledged, the reconciliation is resumed", nil)
+	return false, nil
+}
+
+// FailuresAcknowledgedPredicate triggers when the acknowledge-failures annotation changes,
+// so that a stalled object resumes right away.
+type FailuresAcknowledgedPredicate struct {
+	predicate.Funcs
+}
+
+func (FailuresAcknowledgedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[infrav1.AcknowledgeFailuresAnnotation] != e.ObjectOld.GetAnnotations()[infrav1.AcknowledgeFailuresAnnotation]
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	maxConsecutiveFailures := int32(2)
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec:       infrav1.TerraformSpec{MaxConsecutiveFailures: &maxConsecutiveFailures},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	fail := func(reason string) bool {
+		terraform = infrav1.TerraformNotReady(terraform, "", reason, "failed")
+		stalled, err := r.recordFailure(ctx, &terraform, "main/abc", errors.New("failed"))
+		g.Expect(err).ToNot(HaveOccurred())
+		return stalled
+	}
+
+	// the failures are only counted in a row with the same reason
+	g.Expect(fail(infrav1.TFExecInitFailedReason)).To(BeFalse())
+	g.Expect(fail(infrav1.TFExecPlanFailedReason)).To(BeFalse())
+	g.Expect(fail(infrav1.TFExecPlanFailedReason)).To(BeTrue())
+	g.Expect(terraform.Status.Failures.Count).To(Equal(int32(2)))
+
+	var stored infrav1.Terraform
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &stored)).To(Succeed())
+	stalledCondition := apimeta.FindStatusCondition(stored.Status.Conditions, meta.StalledCondition)
+	g.Expect(stalledCondition).ToNot(BeNil())
+	g.Expect(stalledCondition.Reason).To(Equal(infrav1.TFExecPlanFailedReason))
+
+	stalled, err := r.isStalled(ctx, &stored)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stalled).To(BeTrue())
+
+	// acknowledging the failures resumes the object, once per value of the annotation
+	stored.SetAnnotations(map[string]string{infrav1.AcknowledgeFailuresAnnotation: "2022-10-14T10:00:00Z"})
+	stalled, err = r.isStalled(ctx, &stored)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stalled).To(BeFalse())
+	g.Expect(stored.Status.Failures.Count).To(BeZero())
+	g.Expect(stored.Status.Failures.LastAcknowledged).To(Equal("2022-10-14T10:00:00Z"))
+
+	terraform = stored
+	g.Expect(fail(infrav1.TFExecPlanFailedReason)).To(BeFalse())
+	g.Expect(fail(infrav1.TFExecPlanFailedReason)).To(BeTrue())
+	stalled, err = r.isStalled(ctx, &terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stalled).To(BeTrue())
+
+	// the diagnostics of the last failed run are kept until a successful reconciliation
+	_, err = r.recordFailure(ctx, &terraform, "main/abc", errors.New("error running Plan: exit status 1\n\nError: Missing required argument\n\n  on main.tf line 7:\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.LastRun.Diagnostics).To(HaveLen(1))
+	g.Expect(terraform.Status.LastRun.Diagnostics[0].Range).To(Equal(&infrav1.DiagnosticRange{Filename: "main.tf", Line: 7}))
+
+	// a successful reconciliation resets the failures
+	g.Expect(r.resetFailures(ctx, &terraform)).To(Succeed())
+	g.Expect(terraform.Status.Failures.Count).To(BeZero())
+	g.Expect(terraform.Status.LastRun).To(BeNil())
+	g.Expect(apimeta.FindStatusCondition(terraform.Status.Conditions, meta.StalledCondition)).To(BeNil())
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	for i := 0; i < 5; i++ {
+		terraform = infrav1.TerraformNotReady(terraform, "", infrav1.TFExecPlanFailedReason, "failed")
+		stalled, err := r.recordFailure(ctx, &terraform, "main/abc", errors.New("failed"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(stalled).To(BeFalse())
+	}
+	g.Expect(terraform.Status.Failures.Count).To(Equal(int32(5)))
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// clusterObjectKey returns the key of an object in the cluster inventory index. It leaves the version out,
+// as the same object may be read with any version of its group.
+func clusterObjectKey(gk schema.GroupKind, namespace string, name string) string {
+	return gk.String() + "/" + namespace + "/" + name
+}
+
+func objectGroupKind(object infrav1.ObjectRef) schema.GroupKind {
+	return schema.FromAPIVersionAndKind(object.APIVersion, object.Kind).GroupKind()
+}
+
+// IndexByClusterInventory indexes the Terraform objects by the Kubernetes objects of their inventory.
+func IndexByClusterInventory(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, object := range terraform.Status.Inventory.Objects() {
+		keys = append(keys, clusterObjectKey(objectGroupKind(object), object.Namespace, object.Name))
+	}
+	return keys
+}
+
+// crossCheckClusterInventory reports the Kubernetes objects of the inventory which no longer exist as a drift,
+// and makes sure that the deletions of such objects trigger a reconciliation.
+func (r *TerraformReconciler) crossCheckClusterInventory(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// the inventory is not updated by destroying the resources
+	if !terraform.Spec.EnableInventory || terraform.Spec.Destroy || terraform.Status.Plan.IsDestroyPlan {
+		return terraform, nil
+	}
+
+	objects := terraform.Status.Inventory.Objects()
+	for _, object := range objects {
+		if err := r.watchClusterObjects(schema.FromAPIVersionAndKind(object.APIVersion, object.Kind)); err != nil {
+			// the objects are still checked at each reconciliation
+			log.Error(err, "unable to watch the objects of the inventory", "apiVersion", object.APIVersion, "kind", object.Kind)
+		}
+	}
+
+	var missing []string
+	for _, object := range objects {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(object.APIVersion, object.Kind))
+		err := r.Get(ctx, types.NamespacedName{Namespace: object.Namespace, Name: object.Name}, obj)
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, object.String())
+		} else if err != nil {
+			return terraform, fmt.Errorf("unable to get %s of the inventory: %w", object, err)
+		}
+	}
+	if len(missing) == 0 {
+		return terraform, nil
+	}
+
+	msg := fmt.Sprintf("Drift detected, objects of the inventory deleted out-of-band: %s", strings.Join(missing, ", "))
+	log.Info(msg)
+	r.event(ctx, terraform, terraform.Status.LastAppliedRevision, events.EventSeverityError, msg, nil)
+	terraform = infrav1.TerraformDriftDetected(terraform, terraform.Status.LastAppliedRevision, infrav1.DriftDetectedReason, msg, missing)
+	if err := r.patchStatus(ctx, client.ObjectKeyFromObject(&terraform), terraform.Status); err != nil {
+		return terraform, err
+	}
+	return terraform, nil
+}
+
+// watchClusterObjects watches the deletions of the objects of a kind, once, to reconcile the Terraform objects
+// having them in their inventory. Only the metadata of the objects is cached.
+func (r *TerraformReconciler) watchClusterObjects(gvk schema.GroupVersionKind) error {
+	if r.controller == nil {
+		return nil
+	}
+
+	r.watchedKindsMu.Lock()
+	defer r.watchedKindsMu.Unlock()
+	if r.watchedKinds[gvk.GroupKind()] {
+		return nil
+	}
+
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	deleted := predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+	if err := r.controller.Watch(&source.Kind{Type: obj}, handler.EnqueueRequestsFromMapFunc(r.requestsForClusterObjectDeletion(gvk.GroupKind())), deleted); err != nil {
+		return err
+	}
+
+	if r.watchedKinds == nil {
+		r.watchedKinds = map[schema.GroupKind]bool{}
+	}
+	r.watchedKinds[gvk.GroupKind()] = true
+	return nil
+}
+
+func (r *TerraformReconciler) requestsForClusterObjectDeletion(gk schema.GroupKind) func(obj client.Object) []reconcile.Request {
+	return func(obj client.Object) []reconcile.Request {
+		var list infrav1.TerraformList
+		if err := r.List(context.Background(), &list, client.MatchingFields{
+			infrav1.ClusterInventoryIndexKey: clusterObjectKey(gk, obj.GetNamespace(), obj.GetName()),
+		}); err != nil {
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, terraform := range list.Items {
+			if !terraform.Spec.WatchClusterInventory {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&terraform)})
+		}
+		return reqs
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCrossCheckClusterInventory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Spec:       infrav1.TerraformSpec{EnableInventory: true, WatchClusterInventory: true},
+		Status: infrav1.TerraformStatus{
+			LastAppliedRevision: "main/abc",
+			Inventory: &infrav1.ResourceInventory{Entries: []infrav1.ResourceRef{
+				{Name: "web", Type: "kubernetes_deployment_v1", Identifier: "default/web",
+					Object: &infrav1.ObjectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}},
+				{Name: "api", Type: "kubernetes_deployment_v1", Identifier: "default/api",
+					Object: &infrav1.ObjectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "api"}},
+				{Name: "logs", Type: "aws_s3_bucket", Identifier: "arn:aws:s3:::logs"},
+			}},
+		},
+	}
+	web := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+
+	g.Expect(IndexByClusterInventory(&terraform)).To(Equal([]string{"Deployment.apps/default/web", "Deployment.apps/default/api"}))
+
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy(), web).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	// the deleted object is reported as a drift
+	checked, err := r.crossCheckClusterInventory(ctx, terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checked.Status.LastDriftDetectedAt).ToNot(BeNil())
+	ready := apimeta.FindStatusCondition(checked.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready).ToNot(BeNil())
+	g.Expect(ready.Reason).To(Equal(infrav1.DriftDetectedReason))
+	g.Expect(ready.Message).To(Equal("Drift detected, objects of the inventory deleted out-of-band: apps/v1 Deployment default/api"))
+
+	var stored infrav1.Terraform
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "apps"}, &stored)).To(Succeed())
+	g.Expect(stored.Status.LastDriftDetectedAt).ToNot(BeNil())
+
+	// the inventory of a destroyed object is not checked
+	destroyed := terraform.DeepCopy()
+	destroyed.Status.Plan.IsDestroyPlan = true
+	checked, err = r.crossCheckClusterInventory(ctx, *destroyed)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(checked.Status.LastDriftDetectedAt).To(BeNil())
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	defaultMaxConditionMessageLength = 2048
+	defaultConditionMessageTailLines = 20
+)
+
+// compressConditionMessages stores the messages of the conditions too long to be kept in the status
+// in the messages ConfigMap of the object, and replaces them with their last lines and a reference to the ConfigMap.
+// The ConfigMap is deleted once no message is too long. Failing to store the messages keeps them in the status.
+func (r *TerraformReconciler) compressConditionMessages(ctx context.Context, terraform infrav1.Terraform, status infrav1.TerraformStatus) (infrav1.TerraformStatus, error) {
+	maxLength, tailLines := r.Config.Get().conditionMessageLimits()
+	messages := map[string]string{}
+	for _, condition := range status.Conditions {
+		if len(condition.Message) > maxLength {
+			messages[condition.Type] = condition.Message
+		}
+	}
+
+	name := terraform.MessagesConfigMapName()
+	if len(messages) == 0 {
+		if terraform.Status.MessagesConfigMap != "" {
+			configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: terraform.Namespace, Name: name}}
+			if err := r.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+				return status, err
+			}
+		}
+		status.MessagesConfigMap = ""
+		return status, nil
+	}
+
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: terraform.Namespace, Name: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Labels = map[string]string{
+			"app.kubernetes.io/created-by": "tf-controller",
+			"app.kubernetes.io/instance":   terraform.Name,
+		}
+		configMap.Data = messages
+		return controllerutil.SetControllerReference(&terraform, configMap, r.Scheme)
+	}); err != nil {
+		return status, err
+	}
+
+	status = *status.DeepCopy()
+	status.MessagesConfigMap = name
+	for i, condition := range status.Conditions {
+		if _, ok := messages[condition.Type]; ok {
+			header := fmt.Sprintf("The message of %d bytes is in the %s key of ConfigMap %s, ending with:\n", len(condition.Message), condition.Type, name)
+			status.Conditions[i].Message = header + messageTail(condition.Message, tailLines, maxLength-len(header))
+		}
+	}
+	return status, nil
+}
+
+// messageTail returns the last lines of the message fitting in max bytes, from the start of a line when possible.
+func messageTail(message string, lines int, max int) string {
+	message = strings.TrimRight(message, "\n")
+	tail := message
+	for i, n := len(message), 0; n < lines; n++ {
+		i = strings.LastIndexByte(message[:i], '\n')
+		if i < 0 {
+			break
+		}
+		tail = message[i+1:]
+	}
+	if max < 0 {
+		max = 0
+	}
+	if len(tail) <= max {
+		return tail
+	}
+	tail = tail[len(tail)-max:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		return tail[i+1:]
+	}
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return tail
+}
+
+// conditionMessage returns the full message of the condition of the type, read from the messages ConfigMap
+// when it is not kept whole in the status.
+func conditionMessage(ctx context.Context, c client.Reader, terraform infrav1.Terraform, conditionType string) (string, error) {
+	for _, condition := range terraform.Status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		if terraform.Status.MessagesConfigMap == "" {
+			return condition.Message, nil
+		}
+		var configMap v1.ConfigMap
+		if err := c.Get(ctx, client.ObjectKey{Namespace: terraform.Namespace, Name: terraform.Status.MessagesConfigMap}, &configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				return condition.Message, nil
+			}
+			return "", err
+		}
+		if message, ok := configMap.Data[conditionType]; ok {
+			return message, nil
+		}
+		return condition.Message, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMessageTail(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(messageTail("short", 20, 10)).To(Equal("short"))
+	g.Expect(messageTail("first line\nsecond\nError: failed\n", 2, 100)).To(Equal("second\nError: failed"))
+	g.Expect(messageTail("first line\nsecond\nError: failed", 20, 19)).To(Equal("Error: failed"))
+	g.Expect(messageTail("no newline at all", 20, 7)).To(Equal(" at all"))
+	g.Expect(messageTail("ééé", 20, 3)).To(Equal("é"))
+}
+
+func TestCompressConditionMessages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "helloworld", UID: "1234"},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform).Build(),
+		Scheme:        testScheme,
+		statusManager: "tf-controller",
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+
+	output := strings.Repeat("module.network: Refreshing state...\n", 200) + "Error: creating VPC: UnauthorizedOperation"
+	failed := infrav1.TerraformNotReady(*terraform, "main/1234", infrav1.TFExecApplyFailedReason, output)
+	g.Expect(r.patchStatus(ctx, key, failed.Status)).To(Succeed())
+
+	g.Expect(r.Get(ctx, key, terraform)).To(Succeed())
+	g.Expect(terraform.Status.MessagesConfigMap).To(Equal("helloworld-tf-messages"))
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	g.Expect(len(ready.Message)).To(BeNumerically("<=", defaultMaxConditionMessageLength))
+	g.Expect(strings.Count(ready.Message, "\n")).To(Equal(defaultConditionMessageTailLines))
+	g.Expect(ready.Message).To(ContainSubstring("Ready key of ConfigMap helloworld-tf-messages"))
+	g.Expect(ready.Message).To(HaveSuffix("Error: creating VPC: UnauthorizedOperation"))
+
+	var configMap v1.ConfigMap
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-messages"}, &configMap)).To(Succeed())
+	g.Expect(configMap.Data[meta.ReadyCondition]).To(Equal(output))
+	g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+
+	message, err := conditionMessage(ctx, r.Client, *terraform, meta.ReadyCondition)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(message).To(Equal(output))
+
+	// the ConfigMap is deleted once the messages are short again
+	ok := infrav1.TerraformNotReady(*terraform, "main/1234", infrav1.TFExecApplyFailedReason, "Error: quota exceeded")
+	g.Expect(r.patchStatus(ctx, key, ok.Status)).To(Succeed())
+	g.Expect(r.Get(ctx, key, terraform)).To(Succeed())
+	g.Expect(terraform.Status.MessagesConfigMap).To(BeEmpty())
+	err = r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-messages"}, &configMap)
+	g.Expect(err).To(HaveOccurred())
+
+	message, err = conditionMessage(ctx, r.Client, *terraform, meta.ReadyCondition)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(message).To(Equal("Error: quota exceeded"))
+}
+
+func TestConditionMessageLimits(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "helloworld", UID: "1234"},
+	}
+	config := &ControllerConfigWatcher{config: ControllerConfig{MaxConditionMessageLength: 200, ConditionMessageTailLines: 2}}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform).Build(),
+		Scheme:        testScheme,
+		Config:        config,
+		statusManager: "tf-controller",
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+
+	// a failure longer than the maximum of the API keeps its first line and the error at its end
+	output := "error running Apply:\n" + strings.Repeat("module.network: Still creating...\n", 1000) + "Error: creating VPC: UnauthorizedOperation"
+	failed := infrav1.TerraformNotReady(*terraform, "main/1234", infrav1.TFExecApplyFailedReason, output)
+	ready := apimeta.FindStatusCondition(failed.Status.Conditions, meta.ReadyCondition)
+	g.Expect(len(ready.Message)).To(BeNumerically("<=", infrav1.MaxConditionMessageLength))
+	g.Expect(ready.Message).To(HavePrefix("error running Apply:\n..."))
+	g.Expect(ready.Message).To(HaveSuffix("Error: creating VPC: UnauthorizedOperation"))
+
+	g.Expect(r.patchStatus(ctx, key, failed.Status)).To(Succeed())
+	g.Expect(r.Get(ctx, key, terraform)).To(Succeed())
+	ready = apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	g.Expect(len(ready.Message)).To(BeNumerically("<=", 200))
+	g.Expect(ready.Message).To(HaveSuffix("\nmodule.network: Still creating...\nError: creating VPC: UnauthorizedOperation"))
+}
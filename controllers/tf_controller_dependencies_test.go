@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckRequiredOutputs(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := corev1.Secret{
+		Data: map[string][]byte{
+			"vpc_id":     []byte("vpc-0123"),
+			"subnet_ids": []byte(""),
+		},
+	}
+
+	g.Expect(checkRequiredOutputs(secret, nil)).To(Succeed())
+	g.Expect(checkRequiredOutputs(secret, []string{"vpc_id"})).To(Succeed())
+
+	err := checkRequiredOutputs(secret, []string{"vpc_id", "subnet_ids"})
+	g.Expect(err).To(MatchError("has empty required outputs: subnet_ids"))
+
+	err = checkRequiredOutputs(secret, []string{"vpc_id", "zone", "region"})
+	g.Expect(err).To(MatchError("is missing required outputs: zone, region"))
+
+	err = checkRequiredOutputs(secret, []string{"zone", "subnet_ids"})
+	g.Expect(err).To(MatchError("is missing required outputs: zone, and has empty required outputs: subnet_ids"))
+}
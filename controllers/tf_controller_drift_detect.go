@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/fluxcd/pkg/runtime/events"
+	tfjson "github.com/hashicorp/terraform-json"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc/status"
@@ -66,7 +69,7 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 		TfInstance: tfInstance,
 		Out:        driftFilename,
 		Refresh:    true,
-		Targets:    terraform.Spec.Targets,
+		Targets:    terraform.GetTargets(),
 	}
 	if r.backendCompletelyDisable(terraform) {
 		planRequest.Out = ""
@@ -105,9 +108,15 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 
 	if drifted {
 		var rawOutput string
+		var resources []string
 		if r.backendCompletelyDisable(terraform) {
 			rawOutput = "not available"
 		} else {
+			resources, err = driftedResources(ctx, runnerClient, tfInstance, driftFilename)
+			if err != nil {
+				// the drifted resources are only reported, the drift is handled without them
+				log.Error(err, "unable to get the drifted resources")
+			}
 			showPlanFileRawReply, err := runnerClient.ShowPlanFileRaw(ctx, &runner.ShowPlanFileRawRequest{
 				TfInstance: tfInstance,
 				Filename:   driftFilename,
@@ -131,10 +140,36 @@ func (r *TerraformReconciler) detectDrift(ctx context.Context, terraform infrav1
 		r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
 
 		// If drift detected & we use the auto mode, then we continue
-		terraform = infrav1.TerraformDriftDetected(terraform, revision, infrav1.DriftDetectedReason, rawOutput)
+		terraform = infrav1.TerraformDriftDetected(terraform, revision, infrav1.DriftDetectedReason, rawOutput, resources)
 		return terraform, fmt.Errorf(infrav1.DriftDetectedReason)
 	}
 
 	terraform = infrav1.TerraformNoDrift(terraform, revision, infrav1.NoDriftReason, "No drift")
 	return terraform, nil
 }
+
+// driftedResources returns the addresses of the managed resources the drift plan would change.
+func driftedResources(ctx context.Context, runnerClient runner.RunnerClient, tfInstance, filename string) ([]string, error) {
+	reply, err := runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{
+		TfInstance: tfInstance,
+		Filename:   filename,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(reply.JsonOutput, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse the drift plan: %w", err)
+	}
+
+	var addresses []string
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode != tfjson.ManagedResourceMode || rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+			continue
+		}
+		addresses = append(addresses, rc.Address)
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
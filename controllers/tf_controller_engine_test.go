@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+)
+
+func TestEngineBinary(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{}
+	g.Expect(terraform.EngineBinary()).To(Equal("terraform"))
+
+	terraform.Spec.Engine = infrav1.EngineOpenTofu
+	g.Expect(terraform.EngineBinary()).To(Equal("tofu"))
+}
+
+func TestInstallTerraformCache(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cacheDir := t.TempDir()
+	t.Setenv(runner.BinaryCacheDirEnvName, cacheDir)
+	cached := filepath.Join(cacheDir, "1.3.9", "terraform")
+	g.Expect(os.MkdirAll(filepath.Dir(cached), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(cached, []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+
+	// a cached release is not downloaded again
+	server := &runner.TerraformRunnerServer{}
+	reply, err := server.InstallTerraform(ctx, &runner.InstallTerraformRequest{Version: "v1.3.9"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reply.ExecPath).To(Equal(cached))
+
+	_, err = server.InstallTerraform(ctx, &runner.InstallTerraformRequest{Version: "latest"})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid terraform version")))
+
+	// the version is only pinned with the terraform engine
+	r := &TerraformReconciler{}
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{Engine: infrav1.EngineOpenTofu, TerraformVersion: "1.3.9"}}
+	_, err = r.lookupEngine(ctx, nil, terraform)
+	g.Expect(err).To(MatchError("terraformVersion can't be set with the tofu engine"))
+}
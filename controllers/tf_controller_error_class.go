@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/utils"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	reconcileFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gotk_terraform_reconcile_failures_total",
+			Help: "Failed reconciliations of the Terraform objects, by class of error.",
+		},
+		[]string{"namespace", "name", "class"},
+	)
+
+	// the first matching class wins, so that e.g. a crashed provider is not reported as a network error
+	errorClasses = []struct {
+		class   string
+		pattern *regexp.Regexp
+	}{
+		{infrav1.StateLockedReason, regexp.MustCompile(`(?i)error acquiring the state lock|state locked|lock identifier`)},
+		{infrav1.ProviderCrashReason, regexp.MustCompile(`(?i)plugin did not respond|plugin encountered an error|plugin crashed|failed to instantiate provider|provider produced (inconsistent|an invalid)|panic: `)},
+		{infrav1.AuthErrorReason, regexp.MustCompile(`(?i)unauthori[sz]ed|authentication failed|access ?denied|forbidden|invalid ?credentials|no valid credential|expired ?token|token (is )?expired|InvalidClientTokenId|AuthFailure|status code:? 40[13]\b`)},
+		{infrav1.QuotaExceededReason, regexp.MustCompile(`(?i)quota|limit ?exceeded|rate ?exceeded|throttl|too many requests|status code:? 429\b|insufficient capacity`)},
+		{infrav1.SyntaxErrorReason, regexp.MustCompile(`(?i)unsupported (argument|block type|attribute)|missing required argument|invalid (expression|block definition|character|reference)|argument or block definition required|reference to undeclared|unclosed configuration block|missing newline after argument|syntax error`)},
+		{infrav1.NetworkErrorReason, regexp.MustCompile(`(?i)dial tcp|i/o timeout|connection (refused|reset)|no such host|tls handshake timeout|context deadline exceeded|network is unreachable|code = Unavailable`)},
+	}
+)
+
+func init() {
+	crtlmetrics.Registry.MustRegister(reconcileFailures)
+}
+
+// classifyError returns the class of an error of Terraform, or of the runner, from its diagnostics.
+func classifyError(message string) string {
+	for _, c := range errorClasses {
+		if c.pattern.MatchString(message) {
+			return c.class
+		}
+	}
+	return infrav1.UnclassifiedErrorReason
+}
+
+// terraformDiagnostics returns the diagnostics of Terraform carried by the error of a failed run.
+func terraformDiagnostics(message string) []infrav1.Diagnostic {
+	var diagnostics []infrav1.Diagnostic
+	for _, d := range utils.ParseDiagnostics(message) {
+		diagnostic := infrav1.Diagnostic{Severity: d.Severity, Summary: d.Summary, Detail: d.Detail}
+		if d.Filename != "" {
+			diagnostic.Range = &infrav1.DiagnosticRange{Filename: d.Filename, Line: int32(d.Line)}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+func TestClassifyError(t *testing.T) {
+	g := NewWithT(t)
+
+	for message, class := range map[string]string{
+		"error running Plan: rpc error: code = Unknown desc = exit status 1\n\nError: error configuring Terraform AWS Provider: error validating provider credentials: " +
+			"error calling sts:GetCallerIdentity: operation error STS: GetCallerIdentity, https response error StatusCode: 403, RequestID: 1, api error InvalidClientTokenId": infrav1.AuthErrorReason,
+		"Error: creating EC2 Instance: VcpuLimitExceeded: You have requested more vCPU capacity than your current vCPU limit of 32 allows":               infrav1.QuotaExceededReason,
+		"Error: Error creating instance: googleapi: Error 403: Quota 'CPUS' exceeded. Limit: 24.0 in region europe-west1":                                infrav1.QuotaExceededReason,
+		"Error: Unsupported argument\n\n  on main.tf line 3, in resource \"aws_s3_bucket\" \"b\":\n   3:   bucket_name = \"b\"":                          infrav1.SyntaxErrorReason,
+		"Error: Plugin did not respond\n\nThe plugin encountered an error, and failed to respond to the plugin.(*GRPCProvider).ApplyResourceChange call": infrav1.ProviderCrashReason,
+		"Plan error: State locked with Lock Identifier f2a9c4b8-3d85-4e5a-9c59-4f1f5b1ea7d2":                                                             infrav1.StateLockedReason,
+		"Error: Error acquiring the state lock\n\nError message: the state is already locked by another terraform client":                                infrav1.StateLockedReason,
+		"Error: Get \"https://10.0.0.1:6443/api/v1/namespaces/default\": dial tcp 10.0.0.1:6443: i/o timeout":                                            infrav1.NetworkErrorReason,
+		"error running Init: rpc error: code = Unavailable desc = connection error":                                                                      infrav1.NetworkErrorReason,
+		"error running Apply: exit status 1": infrav1.UnclassifiedErrorReason,
+	} {
+		g.Expect(classifyError(message)).To(Equal(class), message)
+	}
+}
+
+func TestTerraformDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	message := "error running Plan: rpc error: code = Unknown desc = exit status 1\n\n" +
+		"Error: Unsupported argument\n\n  on main.tf line 3, in resource \"aws_s3_bucket\" \"b\":\n   3:   bucket_name = \"b\"\n\n" +
+		"An argument named \"bucket_name\" is not expected here.\n"
+	g.Expect(terraformDiagnostics(message)).To(Equal([]infrav1.Diagnostic{{
+		Severity: "error",
+		Summary:  "Unsupported argument",
+		Detail:   `An argument named "bucket_name" is not expected here.`,
+		Range:    &infrav1.DiagnosticRange{Filename: "main.tf", Line: 3},
+	}}))
+
+	var many []infrav1.Diagnostic
+	for i := 0; i < infrav1.MaxDiagnostics+5; i++ {
+		many = append(many, infrav1.Diagnostic{Severity: "error", Summary: "Invalid reference", Detail: strings.Repeat("x", 2*infrav1.MaxDiagnosticDetailLength)})
+	}
+	terraform := infrav1.TerraformRunFailed(infrav1.Terraform{}, many)
+	g.Expect(terraform.Status.LastRun.Diagnostics).To(HaveLen(infrav1.MaxDiagnostics))
+	g.Expect(len(terraform.Status.LastRun.Diagnostics[0].Detail)).To(BeNumerically("<=", infrav1.MaxDiagnosticDetailLength+3))
+}
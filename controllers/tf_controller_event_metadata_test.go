@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// annotationsRecorder records the annotations of the events, which the fake recorder of client-go drops.
+type annotationsRecorder struct {
+	annotations []map[string]string
+}
+
+func (r *annotationsRecorder) Event(object runtime.Object, eventtype, reason, message string) {}
+
+func (r *annotationsRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+}
+
+func (r *annotationsRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.annotations = append(r.annotations, annotations)
+}
+
+func TestEventMetadata(t *testing.T) {
+	g := NewWithT(t)
+
+	recorder := &annotationsRecorder{}
+	r := &TerraformReconciler{EventRecorder: recorder}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec:       infrav1.TerraformSpec{Workspace: "staging"},
+		Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"}},
+	}
+
+	r.event(context.Background(), terraform, "main/b8e362c206", events.EventSeverityInfo, "Applied successfully", map[string]string{
+		infrav1.EventMetadataDurationKey: durationSeconds(12500 * time.Millisecond),
+	})
+	g.Expect(recorder.annotations).To(HaveLen(1))
+	g.Expect(recorder.annotations[0]).To(Equal(map[string]string{
+		"infra.contrib.fluxcd.io/metadata-schema":  "v1",
+		"infra.contrib.fluxcd.io/workspace":        "staging",
+		"infra.contrib.fluxcd.io/revision":         "main/b8e362c206",
+		"infra.contrib.fluxcd.io/plan-id":          "plan-main-b8e362c206",
+		"infra.contrib.fluxcd.io/duration-seconds": "12.5",
+	}))
+
+	// the keys set on every event are kept without revision nor plan
+	terraform.Spec.Workspace = ""
+	terraform.Status.Plan.Pending = ""
+	g.Expect(eventMetadata(terraform, "", nil)).To(Equal(map[string]string{
+		infrav1.EventMetadataSchemaKey:    infrav1.EventMetadataSchemaVersion,
+		infrav1.EventMetadataWorkspaceKey: infrav1.DefaultWorkspaceName,
+	}))
+}
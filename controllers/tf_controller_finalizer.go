@@ -26,7 +26,23 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 
 	// TODO how to completely delete without planning?
 	traceLog.Info("Check if we need to Destroy on Delete")
-	if terraform.Spec.DestroyResourcesOnDeletion {
+	// a plan-only object never changes the resources of its state
+	destroy := terraform.Spec.DestroyResourcesOnDeletion && !terraform.Spec.PlanOnly
+	if destroy {
+		// the resources of a state shared with another object belong to that object
+		owner, err := r.getBackendOwner(ctx, terraform)
+		if err != nil {
+			log.Error(err, "unable to check for backend conflicts")
+			return controllerruntime.Result{Requeue: true}, err
+		}
+
+		if owner != nil {
+			log.Info("skip destroying the resources of a state owned by another object", "owner", owner.Namespace+"/"+owner.Name)
+			destroy = false
+		}
+	}
+
+	if destroy {
 
 		for _, finalizer := range terraform.GetFinalizers() {
 			if strings.HasPrefix(finalizer, infrav1.TFDependencyOfPrefix) {
@@ -42,6 +58,19 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 			}
 		}
 
+		// unless plans are applied automatically, the destroy plan waits to be approved like any other plan
+		previewDestroy := !r.forceOrAutoApply(terraform)
+		destroyPlanApproved := infrav1.IsDestroyPlanId(terraform.Status.Plan.Pending) && r.shouldApply(terraform)
+		if previewDestroy && infrav1.IsDestroyPlanId(terraform.Status.Plan.Pending) && !destroyPlanApproved {
+			log.Info("waiting for the destroy plan to be approved", "pending", terraform.Status.Plan.Pending)
+			return controllerruntime.Result{}, nil
+		}
+
+		// store the destroy plan in a readable format, for it to be reviewed
+		if previewDestroy && (terraform.Spec.StoreReadablePlan == "" || terraform.Spec.StoreReadablePlan == "none") {
+			terraform.Spec.StoreReadablePlan = "human"
+		}
+
 		// TODO There's a case of sourceObj got deleted before finalize is called.
 		revision := sourceObj.GetArtifact().Revision
 		traceLog.Info("Setup the terraform instance")
@@ -67,39 +96,46 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 			return controllerruntime.Result{Requeue: true}, err
 		}
 
-		// This will create the "destroy" plan because deletion timestamp is set.
-		traceLog.Info("Create a new plan to destroy")
-		terraform, err = r.plan(ctx, terraform, tfInstance, runnerClient, revision)
-		traceLog.Info("Check for error")
-		if err != nil {
-			traceLog.Error(err, "Error, requeue job")
-			return controllerruntime.Result{Requeue: true}, err
-		}
+		if !destroyPlanApproved {
+			// This will create the "destroy" plan because deletion timestamp is set.
+			traceLog.Info("Create a new plan to destroy")
+			terraform, err = r.plan(ctx, terraform, tfInstance, runnerClient, revision)
+			traceLog.Info("Check for error")
+			if err != nil {
+				traceLog.Error(err, "Error, requeue job")
+				return controllerruntime.Result{Requeue: true}, err
+			}
 
-		traceLog.Info("Patch status of the Terraform resource")
-		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
-			log.Error(err, "unable to update status after planing")
-			return controllerruntime.Result{Requeue: true}, err
-		}
+			traceLog.Info("Patch status of the Terraform resource")
+			if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+				log.Error(err, "unable to update status after planing")
+				return controllerruntime.Result{Requeue: true}, err
+			}
 
-		traceLog.Info("Apply the destroy plan")
-		terraform, err = r.apply(ctx, terraform, tfInstance, runnerClient, revision)
-		traceLog.Info("Check for error")
-		if err != nil {
-			traceLog.Error(err, "Error, requeue job")
-			return controllerruntime.Result{Requeue: true}, err
+			if previewDestroy && terraform.Status.Plan.Pending != "" {
+				log.Info("waiting for the destroy plan to be approved", "pending", terraform.Status.Plan.Pending)
+				return controllerruntime.Result{}, nil
+			}
 		}
 
-		traceLog.Info("Patch status of the Terraform resource")
-		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
-			log.Error(err, "unable to update status after applying")
-			return controllerruntime.Result{Requeue: true}, err
-		}
+		// a destroy plan without changes leaves nothing to apply
+		if !previewDestroy || terraform.Status.Plan.Pending != "" {
+			traceLog.Info("Apply the destroy plan")
+			terraform, err = r.apply(ctx, terraform, tfInstance, runnerClient, revision)
+			traceLog.Info("Check for error")
+			if err != nil {
+				traceLog.Error(err, "Error, requeue job")
+				return controllerruntime.Result{Requeue: true}, err
+			}
 
-		traceLog.Info("Check for a nil error")
-		if err == nil {
-			log.Info("finalizing destroyResourcesOnDeletion: ok")
+			traceLog.Info("Patch status of the Terraform resource")
+			if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+				log.Error(err, "unable to update status after applying")
+				return controllerruntime.Result{Requeue: true}, err
+			}
 		}
+
+		log.Info("finalizing destroyResourcesOnDeletion: ok")
 	}
 
 	traceLog.Info("Check if we are writing output to secrets")
@@ -117,6 +153,9 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 		Workspace:                terraform.WorkspaceName(),
 		HasSpecifiedOutputSecret: hasSpecifiedOutputSecret,
 		OutputSecretName:         outputSecretName,
+		OutputSecretNamespace:    terraform.OutputsSecretNamespace(),
+		RetainOutputSecret:       terraform.RetainsOutputsSecret(),
+		Uuid:                     string(terraform.UID),
 	})
 	traceLog.Info("Check for an error")
 	if err != nil {
@@ -139,6 +178,22 @@ func (r *TerraformReconciler) finalize(ctx context.Context, terraform infrav1.Te
 		log.Info(fmt.Sprintf("finalizing secrets: %s", finalizeSecretsReply.Message))
 	}
 
+	if hasSpecifiedOutputSecret {
+		traceLog.Info("Delete the RBAC of the outputs secret")
+		if err := r.deleteOutputsRBAC(ctx, terraform); err != nil {
+			traceLog.Error(err, "Hit an error")
+			return controllerruntime.Result{}, err
+		}
+	}
+
+	return r.removeFinalizers(ctx, terraform)
+}
+
+// removeFinalizers removes the finalizer of the object being deleted, and its finalizers from its dependencies.
+func (r *TerraformReconciler) removeFinalizers(ctx context.Context, terraform infrav1.Terraform) (controllerruntime.Result, error) {
+	traceLog := controllerruntime.LoggerFrom(ctx).V(logger.TraceLevel).WithValues("function", "TerraformReconciler.removeFinalizers")
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
 	// Record deleted status
 	traceLog.Info("Record the deleted status")
 	r.recordReadinessMetric(ctx, terraform)
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFinalizeWaitsForTheDestroyPlanApproval(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).Build()}
+
+	now := metav1.Now()
+	destroyPlanId, _ := infrav1.GetDestroyPlanIdAndApproveMessage("master/b8e362c206e3d0cbb7ed22ced771a0056455a2fb", "")
+	g.Expect(destroyPlanId).To(Equal("destroy-master-b8e362c206-abbb366287"))
+	g.Expect(infrav1.IsDestroyPlanId(destroyPlanId)).To(BeTrue())
+	g.Expect(infrav1.IsDestroyPlanId("plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb")).To(BeFalse())
+
+	for _, approvePlan := range []string{"", "plan-master-b8e362c206"} {
+		terraform := infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "helloworld",
+				Namespace:         "flux-system",
+				DeletionTimestamp: &now,
+				Finalizers:        []string{infrav1.TerraformFinalizer},
+			},
+			Spec: infrav1.TerraformSpec{
+				ApprovePlan:                approvePlan,
+				DestroyResourcesOnDeletion: true,
+				BackendConfig:              &infrav1.BackendConfigSpec{Disable: true},
+			},
+			Status: infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: destroyPlanId, IsDestroyPlan: true}},
+		}
+
+		// no runner is needed to wait for the approval
+		result, err := r.finalize(ctx, terraform, nil, nil, "")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(controllerruntime.Result{}))
+	}
+}
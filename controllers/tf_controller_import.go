@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// importResources imports the resources of spec.imports not in the state yet, before planning,
+// and records the results in the status. The object is not ready while an import fails.
+func (r *TerraformReconciler) importResources(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	importRequest := &runner.ImportRequest{TfInstance: tfInstance}
+	for _, entry := range terraform.Spec.Imports {
+		importRequest.Imports = append(importRequest.Imports, &runner.ImportEntry{Address: entry.Address, Id: entry.ID})
+	}
+
+	log.Info("calling import ...")
+	importReply, err := runnerClient.Import(ctx, importRequest)
+	if err != nil {
+		err = fmt.Errorf("error running Import: %s", err)
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecImportFailedReason,
+			err.Error(),
+		), err
+	}
+
+	terraform.Status.Imports = importStatuses(terraform.Status.Imports, importReply.Results, metav1.Now())
+
+	var imported, failed []string
+	for _, result := range importReply.Results {
+		switch result.Result {
+		case infrav1.ImportResultImported:
+			imported = append(imported, result.Address)
+		case infrav1.ImportResultFailed:
+			failed = append(failed, fmt.Sprintf("%s: %s", result.Address, result.Message))
+		}
+	}
+	if len(imported) > 0 {
+		msg := fmt.Sprintf("Imported %s", strings.Join(imported, ", "))
+		log.Info(msg)
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("error importing %s", strings.Join(failed, "; "))
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecImportFailedReason,
+			err.Error(),
+		), err
+	}
+	return terraform, nil
+}
+
+// importStatuses returns the statuses of the results of the imports, keeping the time of the imports
+// of the resources which have stayed in the state since.
+func importStatuses(previous []infrav1.ImportStatus, results []*runner.ImportResult, now metav1.Time) []infrav1.ImportStatus {
+	importedAt := map[string]*metav1.Time{}
+	for _, status := range previous {
+		importedAt[status.Address+" "+status.ID] = status.ImportedAt
+	}
+
+	statuses := make([]infrav1.ImportStatus, 0, len(results))
+	for _, result := range results {
+		status := infrav1.ImportStatus{
+			Address: result.Address,
+			ID:      result.Id,
+			Result:  result.Result,
+			Message: result.Message,
+		}
+		switch result.Result {
+		case infrav1.ImportResultImported:
+			status.ImportedAt = &now
+		case infrav1.ImportResultInState:
+			status.ImportedAt = importedAt[result.Address+" "+result.Id]
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImportStatuses(t *testing.T) {
+	g := NewWithT(t)
+	firstImport := metav1.NewTime(time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(firstImport.Add(time.Hour))
+
+	statuses := importStatuses(nil, []*runner.ImportResult{
+		{Address: "aws_s3_bucket.logs", Id: "logs", Result: infrav1.ImportResultImported},
+		{Address: "aws_vpc.main", Id: "vpc-0a1b2c", Result: infrav1.ImportResultFailed, Message: "Cannot import non-existent remote object"},
+	}, firstImport)
+	g.Expect(statuses).To(Equal([]infrav1.ImportStatus{
+		{Address: "aws_s3_bucket.logs", ID: "logs", Result: infrav1.ImportResultImported, ImportedAt: &firstImport},
+		{Address: "aws_vpc.main", ID: "vpc-0a1b2c", Result: infrav1.ImportResultFailed, Message: "Cannot import non-existent remote object"},
+	}))
+
+	// the resources already imported keep the time of their import, the others get the time of theirs
+	statuses = importStatuses(statuses, []*runner.ImportResult{
+		{Address: "aws_s3_bucket.logs", Id: "logs", Result: infrav1.ImportResultInState},
+		{Address: "aws_vpc.main", Id: "vpc-0a1b2c", Result: infrav1.ImportResultImported},
+		{Address: "aws_instance.web", Id: "i-0f1e2d", Result: infrav1.ImportResultInState},
+	}, now)
+	g.Expect(statuses).To(Equal([]infrav1.ImportStatus{
+		{Address: "aws_s3_bucket.logs", ID: "logs", Result: infrav1.ImportResultInState, ImportedAt: &firstImport},
+		{Address: "aws_vpc.main", ID: "vpc-0a1b2c", Result: infrav1.ImportResultImported, ImportedAt: &now},
+		{Address: "aws_instance.web", ID: "i-0f1e2d", Result: infrav1.ImportResultInState},
+	}))
+}
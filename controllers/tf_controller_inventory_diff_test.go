@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInventoryDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	bucketA := infrav1.ResourceRef{Name: "a", Type: "aws_s3_bucket", Identifier: "bucket-a"}
+	bucketB := infrav1.ResourceRef{Name: "b", Type: "aws_s3_bucket", Identifier: "bucket-b"}
+	bucketC := infrav1.ResourceRef{Name: "c", Type: "aws_s3_bucket", Identifier: "bucket-c"}
+	role := infrav1.ResourceRef{Name: "deployer", Type: "aws_iam_role", Identifier: "arn:aws:iam::123456789012:role/deployer"}
+	replacedRole := role
+	replacedRole.Identifier = "arn:aws:iam::123456789012:role/deployer-2"
+
+	terraform := infrav1.Terraform{
+		Status: infrav1.TerraformStatus{
+			LastAppliedRevision: "main/b8e362c206",
+			Inventory:           &infrav1.ResourceInventory{Entries: []infrav1.ResourceRef{bucketA, role}},
+		},
+	}
+	terraform = infrav1.TerraformApplied(terraform, "main/ed22ced771", "Applied successfully", false, []infrav1.ResourceRef{bucketA, bucketB, bucketC, replacedRole})
+
+	diff := terraform.Status.InventoryDiff
+	g.Expect(diff).ToNot(BeNil())
+	g.Expect(diff.FromRevision).To(Equal("main/b8e362c206"))
+	g.Expect(diff.Revision).To(Equal("main/ed22ced771"))
+	g.Expect(diff.Added).To(Equal([]infrav1.ResourceRef{bucketB, bucketC, replacedRole}))
+	g.Expect(diff.Removed).To(Equal([]infrav1.ResourceRef{role}))
+	g.Expect(diff.Summary()).To(Equal("1 aws_iam_role added, 2 aws_s3_bucket added, 1 aws_iam_role removed"))
+
+	// an unchanged inventory has an empty diff
+	terraform = infrav1.TerraformApplied(terraform, "main/ed22ced771", "Applied successfully", false, []infrav1.ResourceRef{bucketA, bucketB, bucketC, replacedRole})
+	g.Expect(terraform.Status.InventoryDiff.Added).To(BeEmpty())
+	g.Expect(terraform.Status.InventoryDiff.Summary()).To(BeEmpty())
+}
+
+func TestApproverAPIInventoryDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+			Spec:       infrav1.TerraformSpec{EnableInventory: true},
+			Status: infrav1.TerraformStatus{
+				InventoryDiff: &infrav1.InventoryDiff{
+					Revision: "main/ed22ced771",
+					Added:    []infrav1.ResourceRef{{Name: "a", Type: "aws_s3_bucket", Identifier: "bucket-a"}},
+				},
+			},
+		},
+		&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "no-inventory", Namespace: "flux-system"}},
+	).Build()
+
+	server := &ApproverAPIServer{
+		Client: cli,
+		authorize: func(_ context.Context, token string, attributes authorizationv1.ResourceAttributes) (string, error) {
+			return "viewer", nil
+		},
+	}
+	handler := server.Handler()
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer viewer")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/api/v1/terraforms/flux-system/helloworld/inventory-diff")
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	var reply InventoryDiffResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &reply)).To(Succeed())
+	g.Expect(reply.Revision).To(Equal("main/ed22ced771"))
+	g.Expect(reply.Added).To(HaveLen(1))
+	g.Expect(reply.Summary).To(Equal("1 aws_s3_bucket added"))
+
+	g.Expect(get("/api/v1/terraforms/flux-system/no-inventory/inventory-diff").Code).To(Equal(http.StatusNotFound))
+}
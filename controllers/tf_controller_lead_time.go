@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// leadTime observes the time from the creation of a plan to its apply, which is the wait for
+// the approval of the manual plans. The buckets go from a minute to about 11 days.
+var leadTime = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "gotk_terraform_lead_time_seconds",
+		Help:    "The time from the creation of the plans of the Terraform objects to their apply.",
+		Buckets: prometheus.ExponentialBuckets(60, 4, 8),
+	},
+	[]string{"namespace", "name"},
+)
+
+func init() {
+	crtlmetrics.Registry.MustRegister(leadTime)
+}
+
+// observeLeadTime records the lead time of the applied plan created at planCreatedAt.
+// The plans created before the controller recorded their creation are not observed.
+func observeLeadTime(terraform infrav1.Terraform, planCreatedAt *metav1.Time) {
+	if planCreatedAt == nil || terraform.Status.LastApplyAt == nil {
+		return
+	}
+	leadTime.WithLabelValues(terraform.Namespace, terraform.Name).
+		Observe(terraform.Status.LastApplyAt.Sub(planCreatedAt.Time).Seconds())
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLeadTime(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "lead-time"}}
+	terraform = infrav1.TerraformPlannedWithChanges(terraform, "main/b8e362c206", false, "Plan generated")
+	g.Expect(terraform.Status.Plan.CreatedAt).NotTo(BeNil())
+
+	// approved an hour later
+	planCreatedAt := &metav1.Time{Time: terraform.Status.Plan.CreatedAt.Add(-time.Hour)}
+	terraform = infrav1.TerraformApplied(terraform, "main/b8e362c206", "Applied successfully", false, nil)
+	g.Expect(terraform.Status.LastApplyAt).NotTo(BeNil())
+	g.Expect(terraform.Status.Plan.CreatedAt).To(BeNil())
+
+	observeLeadTime(terraform, planCreatedAt)
+	g.Expect(testutil.CollectAndCount(leadTime)).To(Equal(1))
+
+	var metric dto.Metric
+	g.Expect(leadTime.WithLabelValues("flux-system", "lead-time").(prometheus.Histogram).Write(&metric)).To(Succeed())
+	g.Expect(metric.Histogram.GetSampleCount()).To(BeEquivalentTo(1))
+	g.Expect(metric.Histogram.GetSampleSum()).To(BeNumerically("~", 3600, 1))
+
+	// a plan created before its creation time was recorded
+	observeLeadTime(terraform, nil)
+	g.Expect(testutil.CollectAndCount(leadTime)).To(Equal(1))
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"regexp"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+const redactedValue = "***"
+
+// sanitizeMessage applies .spec.logging to a message before it is recorded
+// in an event or a condition of the object.
+func sanitizeMessage(terraform infrav1.Terraform, msg string) string {
+	logging := terraform.Spec.Logging
+	if logging == nil {
+		return msg
+	}
+
+	if logging.Level == infrav1.LoggingLevelSummary {
+		msg = strings.TrimSpace(msg)
+		if i := strings.Index(msg, "\n"); i >= 0 {
+			msg = strings.TrimSpace(msg[:i])
+		}
+	}
+
+	for _, pattern := range logging.RedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			// an invalid pattern could hide nothing; mask the whole message rather than leak it
+			return redactedValue
+		}
+		msg = re.ReplaceAllString(msg, redactedValue)
+	}
+
+	return msg
+}
+
+// sanitizeStatus returns a copy of the status with the condition messages sanitized.
+func sanitizeStatus(terraform infrav1.Terraform, status infrav1.TerraformStatus) infrav1.TerraformStatus {
+	if terraform.Spec.Logging == nil {
+		return status
+	}
+
+	result := *status.DeepCopy()
+	for i := range result.Conditions {
+		result.Conditions[i].Message = sanitizeMessage(terraform, result.Conditions[i].Message)
+	}
+	return result
+}
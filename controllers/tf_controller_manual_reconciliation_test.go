@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/mtls"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestManualReconciliationBlocked(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	// the dependency is not ready
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "helloworld"},
+		Spec: infrav1.TerraformSpec{
+			ManualReconciliation: true,
+			RetryInterval:        &metav1.Duration{Duration: time.Minute},
+			ApprovePlan:          "auto",
+			SourceRef:            infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "helloworld"},
+			DependsOn:            []infrav1.DependsOnReference{{Name: "database"}},
+		},
+	}
+	database := &infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "database"}}
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "helloworld"},
+		Status: sourcev1.GitRepositoryStatus{
+			Artifact: &sourcev1.Artifact{Revision: "main/b8e362c206", URL: "http://source-controller/helloworld.tar.gz"},
+		},
+	}
+	ready := make(chan struct{})
+	close(ready)
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform, database, repository).Build()
+	r := &TerraformReconciler{
+		Client:        k8sClient,
+		Scheme:        testScheme,
+		EventRecorder: record.NewFakeRecorder(10),
+		CertRotator:   &mtls.CertRotator{Ready: ready},
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+
+	var blocked infrav1.Terraform
+	g.Expect(k8sClient.Get(ctx, key, &blocked)).To(Succeed())
+	g.Expect(apimeta.FindStatusCondition(blocked.Status.Conditions, meta.ReadyCondition).Reason).To(Equal(infrav1.DependencyNotReadyReason))
+
+	// the periodic object retries
+	blocked.Spec.ManualReconciliation = false
+	blocked.Spec.Interval = metav1.Duration{Duration: time.Hour}
+	g.Expect(k8sClient.Update(ctx, &blocked)).To(Succeed())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+	// the source is not found
+	g.Expect(k8sClient.Delete(ctx, repository)).To(Succeed())
+	blocked = infrav1.Terraform{}
+	g.Expect(k8sClient.Get(ctx, key, &blocked)).To(Succeed())
+	blocked.Spec.ManualReconciliation = true
+	blocked.Spec.Interval = metav1.Duration{}
+	g.Expect(k8sClient.Update(ctx, &blocked)).To(Succeed())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
+
+func TestManualReconciliationCompleted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	r := &TerraformReconciler{}
+
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{ManualReconciliation: true, ApprovePlan: "auto"}}
+	g.Expect(r.completedResult(ctx, terraform, terraform)).To(Equal(ctrl.Result{}))
+
+	terraform = infrav1.Terraform{Spec: infrav1.TerraformSpec{Interval: metav1.Duration{Duration: 10 * time.Minute}, ApprovePlan: "auto"}}
+	g.Expect(r.completedResult(ctx, terraform, terraform)).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Minute}))
+
+	// a plan waiting for its approval is not planned again
+	terraform.Spec.ApprovePlan = ""
+	reconciled := *terraform.DeepCopy()
+	reconciled.Status.Plan.Pending = "plan-main-b8e362c206"
+	g.Expect(r.completedResult(ctx, terraform, reconciled)).To(Equal(ctrl.Result{}))
+}
+
+func TestRetryResult(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{Interval: metav1.Duration{Duration: time.Hour}, RetryInterval: &metav1.Duration{Duration: time.Minute}}}
+	g.Expect(r.retryResult(terraform)).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+
+	terraform.Spec.Interval = metav1.Duration{}
+	g.Expect(r.retryResult(terraform)).To(Equal(ctrl.Result{}))
+}
+
+func TestManualReconciliationRemoteRunWait(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+
+	finishedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+	terraform := infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{RetryInterval: &metav1.Duration{Duration: time.Minute}},
+		Status: infrav1.TerraformStatus{
+			RemoteRun: &infrav1.RemoteRunStatus{ID: "run-1", Status: remoteRunErrored, Revision: "main/1", FinishedAt: &finishedAt},
+		},
+	}
+
+	// a new revision is planned at once
+	_, start := r.remoteRunWait(terraform, "main/2")
+	g.Expect(start).To(BeTrue())
+
+	// the failed run is not retried
+	wait, start := r.remoteRunWait(terraform, "main/1")
+	g.Expect(start).To(BeFalse())
+	g.Expect(wait).To(BeZero())
+
+	// until a reconciliation is requested
+	terraform.SetAnnotations(map[string]string{meta.ReconcileRequestAnnotation: "2023-02-01T10:00:00Z"})
+	_, start = r.remoteRunWait(terraform, "main/1")
+	g.Expect(start).To(BeTrue())
+
+	terraform.Status.LastHandledReconcileAt = "2023-02-01T10:00:00Z"
+	_, start = r.remoteRunWait(terraform, "main/1")
+	g.Expect(start).To(BeFalse())
+}
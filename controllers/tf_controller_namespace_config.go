@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// applyNamespaceDefaults returns the object with the fields it leaves empty set to the defaults
+// of the TerraformNamespaceConfig of its namespace. The defaults are never written back to the object.
+func (r *TerraformReconciler) applyNamespaceDefaults(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	var list infrav1.TerraformNamespaceConfigList
+	if err := r.List(ctx, &list, client.InNamespace(terraform.Namespace)); err != nil {
+		return terraform, fmt.Errorf("unable to list the namespace configs: %w", err)
+	}
+
+	switch len(list.Items) {
+	case 0:
+		return terraform, nil
+	case 1:
+	default:
+		return terraform, fmt.Errorf("namespace %s has %d TerraformNamespaceConfig objects, expected at most one", terraform.Namespace, len(list.Items))
+	}
+
+	defaults := list.Items[0].Spec
+	if terraform.Spec.ServiceAccountName == "" {
+		terraform.Spec.ServiceAccountName = defaults.ServiceAccountName
+	}
+	if terraform.Spec.RunnerPodTemplate.Spec.Image == "" {
+		terraform.Spec.RunnerPodTemplate.Spec.Image = defaults.RunnerImage
+	}
+	if terraform.Spec.ApprovePlan == "" {
+		terraform.Spec.ApprovePlan = defaults.ApprovePlan
+	}
+	if defaults.DisableDriftDetection {
+		terraform.Spec.DisableDriftDetection = true
+	}
+	return terraform, nil
+}
+
+func (r *TerraformReconciler) requestsForNamespaceConfigChange(obj client.Object) []reconcile.Request {
+	ctx := context.Background()
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, len(list.Items))
+	for i, t := range list.Items {
+		reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)}
+	}
+	return reqs
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyNamespaceDefaults(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	config := &infrav1.TerraformNamespaceConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "team-a"},
+		Spec: infrav1.TerraformNamespaceConfigSpec{
+			ServiceAccountName:    "team-a-runner",
+			RunnerImage:           "ghcr.io/team-a/tf-runner:v1",
+			ApprovePlan:           infrav1.ApprovePlanAutoValue,
+			DisableDriftDetection: true,
+		},
+	}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(config).Build()}
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"}}
+	defaulted, err := r.applyNamespaceDefaults(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(defaulted.Spec.ServiceAccountName).To(Equal("team-a-runner"))
+	g.Expect(defaulted.Spec.RunnerPodTemplate.Spec.Image).To(Equal("ghcr.io/team-a/tf-runner:v1"))
+	g.Expect(defaulted.Spec.ApprovePlan).To(Equal(infrav1.ApprovePlanAutoValue))
+	g.Expect(defaulted.Spec.DisableDriftDetection).To(BeTrue())
+
+	// the fields set by the object override the defaults
+	terraform.Spec.ServiceAccountName = "helloworld-runner"
+	terraform.Spec.RunnerPodTemplate.Spec.Image = "ghcr.io/weaveworks/tf-runner:v0.14.0"
+	terraform.Spec.ApprovePlan = "plan-main-b8e362c206"
+	defaulted, err = r.applyNamespaceDefaults(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(defaulted.Spec.ServiceAccountName).To(Equal("helloworld-runner"))
+	g.Expect(defaulted.Spec.RunnerPodTemplate.Spec.Image).To(Equal("ghcr.io/weaveworks/tf-runner:v0.14.0"))
+	g.Expect(defaulted.Spec.ApprovePlan).To(Equal("plan-main-b8e362c206"))
+
+	// the other namespaces have no defaults
+	terraform = infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-b"}}
+	defaulted, err = r.applyNamespaceDefaults(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(defaulted).To(Equal(terraform))
+
+	// more than one config is ambiguous
+	second := config.DeepCopy()
+	second.Name = "more-defaults"
+	second.ResourceVersion = ""
+	g.Expect(r.Create(ctx, second)).To(Succeed())
+	_, err = r.applyNamespaceDefaults(ctx, infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "team-a"}})
+	g.Expect(err).To(MatchError(ContainSubstring("expected at most one")))
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/hashicorp/terraform-exec/tfexec"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
 	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
 	"github.com/zclconf/go-cty/cty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -33,20 +36,37 @@ func convertOutputs(outputs map[string]*runner.OutputMeta) map[string]tfexec.Out
 
 func (r *TerraformReconciler) outputsMayBeDrifted(ctx context.Context, terraform infrav1.Terraform) (bool, error) {
 	if terraform.Spec.WriteOutputsToSecret != nil {
-		outputsSecretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.WriteOutputsToSecret.Name}
+		outputsSecretKey := types.NamespacedName{Namespace: terraform.OutputsSecretNamespace(), Name: terraform.Spec.WriteOutputsToSecret.Name}
 		var outputsSecret corev1.Secret
 		err := r.Client.Get(ctx, outputsSecretKey, &outputsSecret)
 		if err != nil && apierrors.IsNotFound(err) {
 			return true, nil
 		}
 
+		if apimeta.IsStatusConditionTrue(terraform.Status.Conditions, infrav1.ConditionTypeOutputsOutOfSync) {
+			return true, nil
+		}
+
+		if hash, ok := outputsSecret.Annotations[runner.ContentHashAnnotation]; ok && hash != utils.ContentHash(outputsSecret.Data) {
+			return true, nil
+		}
+
 		keysInSecret := []string{}
 		for k, _ := range outputsSecret.Data {
 			keysInSecret = append(keysInSecret, k)
 		}
 		sort.Strings(keysInSecret)
 
-		keysInSpec := terraform.Spec.WriteOutputsToSecret.Outputs
+		keysInSpec := outputsSecretKeys(terraform.Spec.WriteOutputsToSecret)
+		if len(keysInSpec) == 0 && terraform.Spec.WriteOutputsToSecret.ExcludeSensitive {
+			// the sensitive outputs are not known here, the content hash covers the keys removed from the Secret
+			for _, k := range keysInSecret {
+				if !containsString(terraform.Status.AvailableOutputs, k) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
 		if len(keysInSpec) == 0 {
 			keysInSpec = terraform.Status.AvailableOutputs
 		}
@@ -75,6 +95,12 @@ func (r *TerraformReconciler) processOutputs(ctx context.Context, runnerClient r
 	log := ctrl.LoggerFrom(ctx)
 	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
 
+	// the outputs of the previously active workspace are kept until the plan of the newly active one is applied
+	if terraform.IsSwitchingWorkspace() && terraform.Status.Plan.Pending != "" {
+		log.Info("keep the outputs of the previously active workspace", "workspace", terraform.Status.ActiveWorkspace, "pending", terraform.Status.Plan.Pending)
+		return terraform, nil
+	}
+
 	outputs := map[string]tfexec.OutputMeta{}
 	var err error
 	terraform, err = r.obtainOutputs(ctx, terraform, tfInstance, runnerClient, revision, &outputs)
@@ -82,17 +108,44 @@ func (r *TerraformReconciler) processOutputs(ctx context.Context, runnerClient r
 		return terraform, err
 	}
 
+	changed := false
 	if r.shouldWriteOutputs(terraform, outputs) {
 		terraform, err = r.writeOutput(ctx, terraform, runnerClient, outputs, revision)
 		if err != nil {
 			return terraform, err
 		}
+		changed = true
+	}
 
+	if terraform.Spec.WriteOutputsToConfigMap != nil && len(outputs) > 0 {
+		terraform, err = r.writeOutputsToConfigMap(ctx, terraform, outputs, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+
+	if terraform.Spec.WriteOutputsTo != nil && len(outputs) > 0 {
+		terraform, err = r.writeOutputsToObject(ctx, terraform, outputs, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+
+	if terraform.Status.ActiveWorkspace != terraform.Spec.ActiveWorkspace {
+		if terraform.IsSwitchingWorkspace() {
+			msg := fmt.Sprintf("Switched the active workspace from %s to %s", terraform.Status.ActiveWorkspace, terraform.Spec.ActiveWorkspace)
+			log.Info(msg)
+			r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+		}
+		terraform.Status.ActiveWorkspace = terraform.Spec.ActiveWorkspace
+		changed = true
+	}
+
+	if changed {
 		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
 			log.Error(err, "unable to update status after writing outputs")
 			return terraform, err
 		}
-
 	}
 
 	return terraform, nil
@@ -127,20 +180,34 @@ func (r *TerraformReconciler) obtainOutputs(ctx context.Context, terraform infra
 	return terraform, nil
 }
 
-func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, outputs map[string]tfexec.OutputMeta, revision string) (infrav1.Terraform, error) {
+// outputsWriter writes the outputs into their Secret. It is the runner, or the controller itself for the remote backend.
+type outputsWriter interface {
+	WriteOutputs(ctx context.Context, in *runner.WriteOutputsRequest, opts ...grpc.CallOption) (*runner.WriteOutputsReply, error)
+}
+
+func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1.Terraform, writer outputsWriter, outputs map[string]tfexec.OutputMeta, revision string) (infrav1.Terraform, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	wots := terraform.Spec.WriteOutputsToSecret
 	data := map[string][]byte{}
+	values := map[string]interface{}{}
 
 	// if not specified .spec.writeOutputsToSecret.outputs,
 	// then it means export all outputs
-	if len(wots.Outputs) == 0 {
+	if len(wots.Outputs) == 0 && len(wots.OutputMappings) == 0 {
 		for output, v := range outputs {
+			if wots.ExcludeSensitive && v.Sensitive {
+				continue
+			}
 			ct, err := ctyjson.UnmarshalType(v.Type)
 			if err != nil {
 				return terraform, err
 			}
+			var value interface{}
+			if err := json.Unmarshal(v.Value, &value); err != nil {
+				return terraform, err
+			}
+			values[output] = value
 			// if it's a string, we can embed it directly into Secret's data
 			switch ct {
 			case cty.String:
@@ -185,11 +252,24 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1
 				log.Error(fmt.Errorf("output not found"), output)
 				continue
 			}
+			if err := checkSensitiveOutput(wots, output, v); err != nil {
+				return infrav1.TerraformNotReady(
+					terraform,
+					revision,
+					infrav1.OutputsWritingFailedReason,
+					err.Error(),
+				), err
+			}
 
 			ct, err := ctyjson.UnmarshalType(v.Type)
 			if err != nil {
 				return terraform, err
 			}
+			var value interface{}
+			if err := json.Unmarshal(v.Value, &value); err != nil {
+				return terraform, err
+			}
+			values[mappedTo] = value
 			switch ct {
 			case cty.String:
 				cv, err := ctyjson.Unmarshal(v.Value, ct)
@@ -211,16 +291,77 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1
 		}
 	}
 
+	for _, mapping := range wots.OutputMappings {
+		v, exist := outputs[mapping.Output]
+		if !exist {
+			log.Error(fmt.Errorf("output not found"), mapping.Output)
+			continue
+		}
+		if err := checkSensitiveOutput(wots, mapping.Output, v); err != nil {
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+		value, valueBytes, err := mappedOutputValue(mapping, v)
+		if err != nil {
+			err = fmt.Errorf("error mapping the output %s: %s", mapping.Output, err)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+		key := outputMappingKey(mapping)
+		values[key] = value
+		data[key] = valueBytes
+	}
+
 	if len(data) == 0 || terraform.Spec.Destroy == true {
 		return infrav1.TerraformOutputsWritten(terraform, revision, "No Outputs written"), nil
 	}
 
-	writeOutputsReply, err := runnerClient.WriteOutputs(ctx, &runner.WriteOutputsRequest{
-		Namespace:  terraform.Namespace,
-		Name:       terraform.Name,
-		SecretName: terraform.Spec.WriteOutputsToSecret.Name,
-		Uuid:       string(terraform.UID),
-		Data:       data,
+	schema, err := r.getOutputsSchema(ctx, terraform)
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.OutputsWritingFailedReason,
+			err.Error(),
+		), err
+	}
+
+	if schema != nil {
+		if err := validateOutputs(schema, values); err != nil {
+			msg := fmt.Sprintf("Outputs do not match the schema: %s", err.Error())
+			r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+			return infrav1.TerraformOutputsInvalid(terraform, revision, msg), err
+		}
+	}
+
+	if err := r.reconcileOutputsRBAC(ctx, terraform); err != nil {
+		msg := fmt.Sprintf("Outputs can't be written: %s", err.Error())
+		r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.OutputsWritingForbiddenReason,
+			msg,
+		), err
+	}
+
+	writeOutputsReply, err := writer.WriteOutputs(ctx, &runner.WriteOutputsRequest{
+		Namespace:       terraform.Namespace,
+		Name:            terraform.Name,
+		SecretName:      terraform.Spec.WriteOutputsToSecret.Name,
+		SecretNamespace: terraform.OutputsSecretNamespace(),
+		Uuid:            string(terraform.UID),
+		Data:            data,
+		MaxSize:         maxStoredSize(r.Config.Get().MaxOutputsSize),
+		Retain:          terraform.RetainsOutputsSecret(),
 	})
 	if err != nil {
 		return infrav1.TerraformNotReady(
@@ -232,14 +373,41 @@ func (r *TerraformReconciler) writeOutput(ctx context.Context, terraform infrav1
 	}
 	log.Info(fmt.Sprintf("write outputs: %s, changed: %v", writeOutputsReply.Message, writeOutputsReply.Changed))
 
-	if writeOutputsReply.Changed {
-		keysWritten := []string{}
-		for k, _ := range data {
+	truncated := map[string]bool{}
+	for _, k := range writeOutputsReply.TruncatedOutputs {
+		truncated[k] = true
+	}
+
+	keysWritten := []string{}
+	for k, _ := range data {
+		if !truncated[k] {
 			keysWritten = append(keysWritten, k)
 		}
+	}
+
+	if writeOutputsReply.Changed {
 		msg := fmt.Sprintf("Outputs written.\n%d output(s): %s", len(keysWritten), strings.Join(keysWritten, ", "))
+		if len(truncated) > 0 {
+			msg += fmt.Sprintf("\nLeft out, as the outputs exceed the maximum size of %d bytes: %s", maxStoredSize(r.Config.Get().MaxOutputsSize), strings.Join(writeOutputsReply.TruncatedOutputs, ", "))
+		}
 		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
 	}
 
+	if wots.PushTo != nil {
+		if err := r.pushOutputs(ctx, terraform, keysWritten); err != nil {
+			err = fmt.Errorf("error pushing the outputs to the secret stores: %s", err)
+			return infrav1.TerraformNotReady(
+				terraform,
+				revision,
+				infrav1.OutputsWritingFailedReason,
+				err.Error(),
+			), err
+		}
+	}
+
+	if len(truncated) > 0 {
+		msg := fmt.Sprintf("Outputs written, without %s exceeding the maximum size", strings.Join(writeOutputsReply.TruncatedOutputs, ", "))
+		return infrav1.TerraformOutputsWritten(terraform, revision, msg), nil
+	}
 	return infrav1.TerraformOutputsWritten(terraform, revision, "Outputs written"), nil
 }
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// writeOutputsToConfigMap writes the non-sensitive outputs to the ConfigMap of .spec.writeOutputsToConfigMap,
+// replacing its data, so that the outputs removed from the spec or from the module are removed from it too.
+func (r *TerraformReconciler) writeOutputsToConfigMap(ctx context.Context, terraform infrav1.Terraform, outputs map[string]tfexec.OutputMeta, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := terraform.Spec.WriteOutputsToConfigMap
+
+	data, err := outputsConfigMapData(spec, outputs)
+	if err == nil {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: terraform.Namespace, Name: spec.Name}}
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+			if configMap.Labels == nil {
+				configMap.Labels = map[string]string{}
+			}
+			configMap.Labels["app.kubernetes.io/created-by"] = "tf-controller"
+			configMap.Labels["app.kubernetes.io/instance"] = terraform.Name
+			configMap.Data = data
+			return controllerutil.SetControllerReference(&terraform, configMap, r.Scheme)
+		})
+	}
+	if err != nil {
+		err = fmt.Errorf("error writing the outputs to ConfigMap %s: %s", spec.Name, err)
+		log.Error(err, "unable to write the outputs to the ConfigMap")
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.OutputsWritingFailedReason,
+			err.Error(),
+		), err
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	log.Info(fmt.Sprintf("outputs written to ConfigMap %s: %s", spec.Name, strings.Join(keys, ", ")))
+	return terraform, nil
+}
+
+// outputsConfigMapData returns the data of the outputs ConfigMap. The outputs are written as the values of the Secret
+// of .spec.writeOutputsToSecret: strings as they are, numbers and booleans as their JSON, and the other types as JSON.
+func outputsConfigMapData(spec *infrav1.WriteOutputsToConfigMapSpec, outputs map[string]tfexec.OutputMeta) (map[string]string, error) {
+	data := map[string]string{}
+	if len(spec.Outputs) == 0 {
+		for name, output := range outputs {
+			if output.Sensitive {
+				continue
+			}
+			value, err := outputString(output)
+			if err != nil {
+				return nil, fmt.Errorf("output %s: %s", name, err)
+			}
+			data[name] = value
+		}
+		return data, nil
+	}
+
+	for _, mapping := range spec.Outputs {
+		name, key := mapping, mapping
+		if parts := strings.SplitN(mapping, ":", 2); len(parts) == 2 {
+			name, key = parts[0], parts[1]
+		}
+		output, ok := outputs[name]
+		if !ok {
+			return nil, fmt.Errorf("output %s not found", name)
+		}
+		if output.Sensitive {
+			return nil, fmt.Errorf("output %s is sensitive, and can only be written to a Secret", name)
+		}
+		value, err := outputString(output)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %s", name, err)
+		}
+		data[key] = value
+	}
+	return data, nil
+}
+
+func outputString(output tfexec.OutputMeta) (string, error) {
+	ct, err := ctyjson.UnmarshalType(output.Type)
+	if err != nil {
+		return "", err
+	}
+	if ct == cty.String {
+		var value string
+		if err := json.Unmarshal(output.Value, &value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+	outputBytes, err := json.Marshal(output.Value)
+	if err != nil {
+		return "", err
+	}
+	return string(outputBytes), nil
+}
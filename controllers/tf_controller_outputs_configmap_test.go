@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWriteOutputsToConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "load-balancer", UID: "1234"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToConfigMap: &infrav1.WriteOutputsToConfigMapSpec{Name: "load-balancer-outputs"},
+		},
+	}
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform).Build(),
+		Scheme: testScheme,
+	}
+	outputs := map[string]tfexec.OutputMeta{
+		"hostname": {Type: []byte(`"string"`), Value: []byte(`"lb.example.com"`)},
+		"port":     {Type: []byte(`"number"`), Value: []byte(`443`)},
+		"zones":    {Type: []byte(`["list","string"]`), Value: []byte(`["a", "b"]`)},
+		"password": {Type: []byte(`"string"`), Value: []byte(`"secret"`), Sensitive: true},
+	}
+
+	// all the non-sensitive outputs
+	_, err := r.writeOutputsToConfigMap(ctx, *terraform, outputs, "main/1234")
+	g.Expect(err).ToNot(HaveOccurred())
+	var configMap corev1.ConfigMap
+	key := types.NamespacedName{Namespace: "flux-system", Name: "load-balancer-outputs"}
+	g.Expect(r.Get(ctx, key, &configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(Equal(map[string]string{
+		"hostname": "lb.example.com",
+		"port":     "443",
+		"zones":    `["a","b"]`,
+	}))
+	g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+	g.Expect(configMap.OwnerReferences[0].UID).To(Equal(terraform.UID))
+
+	// the selected outputs replace the data, with their keys renamed
+	terraform.Spec.WriteOutputsToConfigMap.Outputs = []string{"hostname:LB_HOSTNAME", "port"}
+	_, err = r.writeOutputsToConfigMap(ctx, *terraform, outputs, "main/1234")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Get(ctx, key, &configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(Equal(map[string]string{
+		"LB_HOSTNAME": "lb.example.com",
+		"port":        "443",
+	}))
+
+	for _, selected := range []string{"password", "missing"} {
+		terraform.Spec.WriteOutputsToConfigMap.Outputs = []string{selected}
+		failed, err := r.writeOutputsToConfigMap(ctx, *terraform, outputs, "main/1234")
+		g.Expect(err).To(HaveOccurred(), selected)
+		g.Expect(failed.Status.Conditions[0].Reason).To(Equal(infrav1.OutputsWritingFailedReason))
+	}
+}
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOutputsSecretDeletionPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	NewWithT(t).Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	NewWithT(t).Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}
+	for _, tt := range []struct {
+		policy   string
+		retained bool
+	}{
+		{policy: "", retained: false},
+		{policy: infrav1.OutputsDeletionPolicyDelete, retained: false},
+		{policy: infrav1.OutputsDeletionPolicyRetain, retained: true},
+	} {
+		t.Run("policy "+tt.policy, func(t *testing.T) {
+			g := NewWithT(t)
+			terraform := infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+				Spec: infrav1.TerraformSpec{
+					WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: key.Name, DeletionPolicy: tt.policy},
+				},
+			}
+			g.Expect(terraform.RetainsOutputsSecret()).To(Equal(tt.retained))
+
+			cli := fake.NewClientBuilder().WithScheme(testScheme).Build()
+			server := &runner.TerraformRunnerServer{Client: cli}
+			_, err := server.WriteOutputs(ctx, &runner.WriteOutputsRequest{
+				Namespace:  terraform.Namespace,
+				Name:       terraform.Name,
+				SecretName: key.Name,
+				Uuid:       string(terraform.UID),
+				Data:       map[string][]byte{"hostname": []byte("lb.example.com")},
+				Retain:     terraform.RetainsOutputsSecret(),
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var secret corev1.Secret
+			g.Expect(cli.Get(ctx, key, &secret)).To(Succeed())
+			if tt.retained {
+				g.Expect(secret.OwnerReferences).To(BeEmpty())
+			} else {
+				g.Expect(secret.OwnerReferences).To(HaveLen(1))
+			}
+
+			// the plan secret is missing, which must not keep the output secret from being finalized
+			_, err = server.FinalizeSecrets(ctx, &runner.FinalizeSecretsRequest{
+				Namespace:                terraform.Namespace,
+				Name:                     terraform.Name,
+				Workspace:                terraform.WorkspaceName(),
+				HasSpecifiedOutputSecret: true,
+				OutputSecretName:         key.Name,
+				Uuid:                     string(terraform.UID),
+				RetainOutputSecret:       terraform.RetainsOutputsSecret(),
+			})
+			g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			err = cli.Get(ctx, key, &secret)
+			if tt.retained {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(secret.Data).To(HaveKey("hostname"))
+			} else {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestFinalizeSecretsReleasesARetainedSecret(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	// written before the policy was set to retain, the secret is still owned by the object
+	owners := []metav1.OwnerReference{
+		{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind, Name: "helloworld", UID: "1234"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "helloworld", UID: "5678"},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "flux-system",
+		Name:            "helloworld-outputs",
+		OwnerReferences: owners,
+		Annotations:     map[string]string{runner.OwnerUIDAnnotation: "1234"},
+	}}
+	plan := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "tfplan-default-helloworld"}}
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(secret, plan).Build()
+	server := &runner.TerraformRunnerServer{Client: cli}
+
+	_, err := server.FinalizeSecrets(ctx, &runner.FinalizeSecretsRequest{
+		Namespace:                "flux-system",
+		Name:                     "helloworld",
+		Workspace:                "default",
+		HasSpecifiedOutputSecret: true,
+		OutputSecretName:         "helloworld-outputs",
+		RetainOutputSecret:       true,
+		Uuid:                     "1234",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}, secret)).To(Succeed())
+	g.Expect(secret.OwnerReferences).To(Equal(owners[1:]))
+	g.Expect(apierrors.IsNotFound(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: plan.Name}, plan))).To(BeTrue())
+
+	// the remote backend releases the secret from the controller
+	secret.OwnerReferences = owners
+	g.Expect(cli.Update(ctx, secret)).To(Succeed())
+	r := &TerraformReconciler{Client: cli}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs", DeletionPolicy: infrav1.OutputsDeletionPolicyRetain},
+		},
+	}
+	g.Expect(r.deleteRemoteOutputs(ctx, terraform)).To(Succeed())
+	g.Expect(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}, secret)).To(Succeed())
+	g.Expect(secret.OwnerReferences).To(Equal(owners[1:]))
+
+	terraform.Spec.WriteOutputsToSecret.DeletionPolicy = infrav1.OutputsDeletionPolicyDelete
+	g.Expect(r.deleteRemoteOutputs(ctx, terraform)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(cli.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}, secret))).To(BeTrue())
+}
+
+func TestOutputsSecretNotWrittenForTheObject(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "kube-system", Name: "bootstrap-token"}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}, Data: map[string][]byte{"token": []byte("abc")}}
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(secret).Build()
+	server := &runner.TerraformRunnerServer{Client: cli}
+
+	// a secret of the same name is neither overwritten
+	_, err := server.WriteOutputs(ctx, &runner.WriteOutputsRequest{
+		Namespace:       "flux-system",
+		Name:            "helloworld",
+		SecretName:      key.Name,
+		SecretNamespace: key.Namespace,
+		Uuid:            "1234",
+		Data:            map[string][]byte{"token": []byte("xyz")},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("was not written for this object")))
+
+	// nor deleted
+	_, err = server.FinalizeSecrets(ctx, &runner.FinalizeSecretsRequest{
+		Namespace:                "flux-system",
+		Name:                     "helloworld",
+		Workspace:                "default",
+		HasSpecifiedOutputSecret: true,
+		OutputSecretName:         key.Name,
+		OutputSecretNamespace:    key.Namespace,
+		Uuid:                     "1234",
+	})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+	r := &TerraformReconciler{Client: cli}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: key.Name, Namespace: key.Namespace},
+		},
+	}
+	g.Expect(r.deleteRemoteOutputs(ctx, terraform)).To(Succeed())
+
+	g.Expect(cli.Get(ctx, key, secret)).To(Succeed())
+	g.Expect(string(secret.Data["token"])).To(Equal("abc"))
+}
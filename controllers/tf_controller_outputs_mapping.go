@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// outputMappingKey returns the key of the outputs Secret an output mapping is written to.
+func outputMappingKey(mapping infrav1.OutputMapping) string {
+	if mapping.Key != "" {
+		return mapping.Key
+	}
+	return mapping.Output
+}
+
+// outputsSecretKeys returns the keys of the outputs Secret selected by the spec, or nil when all the outputs are written.
+func outputsSecretKeys(wots *infrav1.WriteOutputsToSecretSpec) []string {
+	var keys []string
+	for _, output := range wots.Outputs {
+		parts := strings.SplitN(output, ":", 2)
+		keys = append(keys, parts[len(parts)-1])
+	}
+	for _, mapping := range wots.OutputMappings {
+		keys = append(keys, outputMappingKey(mapping))
+	}
+	return keys
+}
+
+// checkSensitiveOutput returns an error if the output selected for the outputs Secret is sensitive,
+// and the spec excludes the sensitive outputs.
+func checkSensitiveOutput(wots *infrav1.WriteOutputsToSecretSpec, name string, output tfexec.OutputMeta) error {
+	if wots.ExcludeSensitive && output.Sensitive {
+		return fmt.Errorf("output %s is sensitive, and excluded from Secret %s by excludeSensitive", name, wots.Name)
+	}
+	return nil
+}
+
+func parseOutputJSONPath(expr string) (*jsonpath.JSONPath, error) {
+	path := jsonpath.New("output")
+	if err := path.Parse(expr); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// mappedOutputValue returns the value of an output reduced by the JSONPath of its mapping, and its bytes in the Secret:
+// strings as they are, with their newlines, and the values of the other types as JSON. The value is then decoded,
+// and ended with a newline, if the mapping asks for it.
+func mappedOutputValue(mapping infrav1.OutputMapping, output tfexec.OutputMeta) (interface{}, []byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(output.Value, &value); err != nil {
+		return nil, nil, err
+	}
+
+	if mapping.JSONPath != "" {
+		path, err := parseOutputJSONPath(mapping.JSONPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JSONPath %s: %w", mapping.JSONPath, err)
+		}
+		results, err := path.FindResults(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("JSONPath %s: %w", mapping.JSONPath, err)
+		}
+		var found []interface{}
+		for _, result := range results {
+			for _, v := range result {
+				found = append(found, v.Interface())
+			}
+		}
+		switch len(found) {
+		case 0:
+			return nil, nil, fmt.Errorf("JSONPath %s selects no value", mapping.JSONPath)
+		case 1:
+			value = found[0]
+		default:
+			value = found
+		}
+	}
+
+	var valueBytes []byte
+	if s, ok := value.(string); ok {
+		valueBytes = []byte(s)
+	} else if mapping.Decode != "" {
+		return nil, nil, fmt.Errorf("only a string can be decoded from %s, got %T", mapping.Decode, value)
+	} else {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return nil, nil, err
+		}
+		valueBytes = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	}
+
+	if mapping.Decode == infrav1.OutputDecodeBase64 {
+		// the encoded value may be wrapped, e.g. by base64 -w 76
+		encoded := strings.Join(strings.Fields(string(valueBytes)), "")
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid base64 value: %w", err)
+		}
+		valueBytes = decoded
+		value = string(decoded)
+	}
+
+	if mapping.TrailingNewline && !bytes.HasSuffix(valueBytes, []byte("\n")) {
+		valueBytes = append(valueBytes, '\n')
+		if s, ok := value.(string); ok {
+			value = s + "\n"
+		}
+	}
+	return value, valueBytes, nil
+}
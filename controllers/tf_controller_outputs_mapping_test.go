@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+func TestMappedOutputValue(t *testing.T) {
+	g := NewWithT(t)
+
+	database := tfexec.OutputMeta{
+		Type:  []byte(`["object",{"endpoint":["object",{"host":"string","port":"number"}],"replicas":["list","string"]}]`),
+		Value: []byte(`{"endpoint":{"host":"db.example.com","port":5432},"replicas":["db-1.example.com","db-2.example.com"]}`),
+	}
+	tests := []struct {
+		jsonPath string
+		value    interface{}
+		data     string
+	}{
+		{jsonPath: "{.endpoint.host}", value: "db.example.com", data: "db.example.com"},
+		{jsonPath: "{.endpoint.port}", value: float64(5432), data: "5432"},
+		{jsonPath: "{.endpoint}", value: map[string]interface{}{"host": "db.example.com", "port": float64(5432)}, data: `{"host":"db.example.com","port":5432}`},
+		{jsonPath: "{.replicas[*]}", value: []interface{}{"db-1.example.com", "db-2.example.com"}, data: `["db-1.example.com","db-2.example.com"]`},
+		{jsonPath: "{.replicas[0]}", value: "db-1.example.com", data: "db-1.example.com"},
+		{jsonPath: "", value: map[string]interface{}{
+			"endpoint": map[string]interface{}{"host": "db.example.com", "port": float64(5432)},
+			"replicas": []interface{}{"db-1.example.com", "db-2.example.com"},
+		}, data: `{"endpoint":{"host":"db.example.com","port":5432},"replicas":["db-1.example.com","db-2.example.com"]}`},
+	}
+	for _, tt := range tests {
+		value, data, err := mappedOutputValue(infrav1.OutputMapping{Output: "database", JSONPath: tt.jsonPath}, database)
+		g.Expect(err).ToNot(HaveOccurred(), tt.jsonPath)
+		g.Expect(value).To(Equal(tt.value), tt.jsonPath)
+		g.Expect(string(data)).To(Equal(tt.data), tt.jsonPath)
+	}
+
+	for _, jsonPath := range []string{"{.endpoint.user}", "{.replicas[5]}", "{.endpoint"} {
+		_, _, err := mappedOutputValue(infrav1.OutputMapping{Output: "database", JSONPath: jsonPath}, database)
+		g.Expect(err).To(HaveOccurred(), jsonPath)
+	}
+}
+
+func TestMappedOutputValue_Documents(t *testing.T) {
+	g := NewWithT(t)
+
+	pem := "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIU\n-----END CERTIFICATE-----"
+	encoded, _ := json.Marshal(pem)
+	cert := tfexec.OutputMeta{Type: []byte(`"string"`), Value: encoded}
+
+	// the newlines of a string are written as they are
+	_, data, err := mappedOutputValue(infrav1.OutputMapping{Output: "cert"}, cert)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(pem))
+
+	_, data, err = mappedOutputValue(infrav1.OutputMapping{Output: "cert", TrailingNewline: true}, cert)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(pem + "\n"))
+
+	// a wrapped base64 value is decoded, and a trailing newline is not doubled
+	b64 := base64.StdEncoding.EncodeToString([]byte(pem + "\n"))
+	wrapped, _ := json.Marshal(map[string]string{"ca": b64[:20] + "\n" + b64[20:]})
+	cluster := tfexec.OutputMeta{Type: []byte(`["object",{"ca":"string"}]`), Value: wrapped}
+	value, data, err := mappedOutputValue(infrav1.OutputMapping{Output: "cluster", JSONPath: "{.ca}", Decode: infrav1.OutputDecodeBase64, TrailingNewline: true}, cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(pem + "\n"))
+	g.Expect(value).To(Equal(pem + "\n"))
+
+	_, _, err = mappedOutputValue(infrav1.OutputMapping{Output: "cluster", Decode: infrav1.OutputDecodeBase64}, cluster)
+	g.Expect(err).To(MatchError("only a string can be decoded from base64, got map[string]interface {}"))
+	_, _, err = mappedOutputValue(infrav1.OutputMapping{Output: "cert", Decode: infrav1.OutputDecodeBase64}, cert)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid base64 value")))
+}
+
+func TestOutputsSecretKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(outputsSecretKeys(&infrav1.WriteOutputsToSecretSpec{})).To(BeEmpty())
+	g.Expect(outputsSecretKeys(&infrav1.WriteOutputsToSecretSpec{
+		Outputs: []string{"hostname", "port:PORT"},
+		OutputMappings: []infrav1.OutputMapping{
+			{Output: "database", Key: "DB_HOST", JSONPath: "{.endpoint.host}"},
+			{Output: "region"},
+		},
+	})).To(Equal([]string{"hostname", "PORT", "DB_HOST", "region"}))
+}
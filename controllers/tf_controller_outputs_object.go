@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// writeOutputsToObject writes the outputs to the fields of the object of .spec.writeOutputsTo with a server-side apply,
+// so that the fields not mapped, e.g. written by the users or by the controller of the object, are kept.
+func (r *TerraformReconciler) writeOutputsToObject(ctx context.Context, terraform infrav1.Terraform, outputs map[string]tfexec.OutputMeta, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	spec := terraform.Spec.WriteOutputsTo
+
+	var gvk schema.GroupVersionKind
+	gv, err := schema.ParseGroupVersion(spec.APIVersion)
+	if err == nil {
+		gvk = gv.WithKind(spec.Kind)
+	}
+	if err == nil && !r.outputsKindAllowed(gvk) {
+		err = fmt.Errorf("writing the outputs to %s is not allowed, it is not in --allowed-outputs-kinds", outputsKind(gvk))
+		log.Error(err, "unable to write the outputs to the object")
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.OutputsWritingForbiddenReason,
+			err.Error(),
+		), err
+	}
+
+	var obj *unstructured.Unstructured
+	if err == nil {
+		obj, err = outputsObject(terraform, outputs)
+	}
+	if err == nil {
+		err = r.applyOutputsObject(ctx, terraform, obj)
+	}
+	if apierrors.IsConflict(err) {
+		// the fields managed by another field manager are not taken over
+		err = fmt.Errorf("error writing the outputs to %s %s, the fields are managed by another field manager: %s", spec.Kind, spec.Name, err)
+		log.Error(err, "unable to write the outputs to the object")
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.OutputsConflictReason,
+			err.Error(),
+		), err
+	}
+	if err != nil {
+		err = fmt.Errorf("error writing the outputs to %s %s: %s", spec.Kind, spec.Name, err)
+		log.Error(err, "unable to write the outputs to the object")
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecOutputFailedReason,
+			err.Error(),
+		), err
+	}
+
+	msg := fmt.Sprintf("Outputs written to %s %s", spec.Kind, spec.Name)
+	log.Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	return terraform, nil
+}
+
+// outputsKindAllowed tells whether the outputs may be written to the objects of the kind, listed as Kind.group
+// in --allowed-outputs-kinds, or as Kind for the core group.
+func (r *TerraformReconciler) outputsKindAllowed(gvk schema.GroupVersionKind) bool {
+	for _, allowed := range r.AllowedOutputsKinds {
+		if allowed == outputsKind(gvk) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputsKind returns the kind as listed in --allowed-outputs-kinds.
+func outputsKind(gvk schema.GroupVersionKind) string {
+	return gvk.GroupKind().String()
+}
+
+// outputsObject returns the object of .spec.writeOutputsTo with only the mapped fields set.
+func outputsObject(terraform infrav1.Terraform, outputs map[string]tfexec.OutputMeta) (*unstructured.Unstructured, error) {
+	spec := terraform.Spec.WriteOutputsTo
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(spec.APIVersion)
+	obj.SetKind(spec.Kind)
+	obj.SetNamespace(terraform.Namespace)
+	obj.SetName(spec.Name)
+
+	for _, mapping := range spec.FieldMappings {
+		fields := strings.Split(mapping.Field, ".")
+		if err := validateOutputField(fields); err != nil {
+			return nil, fmt.Errorf("field %s: %s", mapping.Field, err)
+		}
+
+		output, ok := outputs[mapping.Output]
+		if !ok {
+			return nil, fmt.Errorf("output %s not found", mapping.Output)
+		}
+		if output.Sensitive {
+			return nil, fmt.Errorf("output %s is sensitive, and can only be written to a Secret", mapping.Output)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(output.Value, &value); err != nil {
+			return nil, fmt.Errorf("output %s: %s", mapping.Output, err)
+		}
+		if err := unstructured.SetNestedField(obj.Object, value, fields...); err != nil {
+			return nil, fmt.Errorf("field %s: %s", mapping.Field, err)
+		}
+	}
+	return obj, nil
+}
+
+// validateOutputField rejects the fields identifying the object, and the metadata other than its labels and annotations.
+func validateOutputField(fields []string) error {
+	switch fields[0] {
+	case "apiVersion", "kind":
+		return fmt.Errorf("the %s of the object can't be written", fields[0])
+	case "metadata":
+		if len(fields) != 3 || (fields[1] != "labels" && fields[1] != "annotations") {
+			return fmt.Errorf("only the labels and the annotations of the metadata can be written")
+		}
+	}
+	return nil
+}
+
+func (r *TerraformReconciler) applyOutputsObject(ctx context.Context, terraform infrav1.Terraform, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := r.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// an object created for the outputs is deleted with the Terraform object, an existing one is left as it is
+	owned := apierrors.IsNotFound(err)
+	for _, ref := range existing.GetOwnerReferences() {
+		if ref.UID == terraform.UID {
+			owned = true
+		}
+	}
+	if owned {
+		obj.SetOwnerReferences([]metav1.OwnerReference{
+			{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       infrav1.TerraformKind,
+				Name:       terraform.Name,
+				UID:        terraform.UID,
+			},
+		})
+	}
+
+	return r.Patch(ctx, obj, client.Apply, client.FieldOwner(r.statusManager))
+}
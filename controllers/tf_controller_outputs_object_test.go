@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOutputsObject(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsTo: &infrav1.WriteOutputsToObjectSpec{
+				APIVersion: "externaldns.k8s.io/v1alpha1",
+				Kind:       "DNSEndpoint",
+				Name:       "api",
+				FieldMappings: []infrav1.OutputFieldMapping{
+					{Output: "endpoints", Field: "spec.endpoints"},
+					{Output: "zone", Field: "metadata.labels.zone"},
+				},
+			},
+		},
+	}
+	outputs := map[string]tfexec.OutputMeta{
+		"endpoints": {Type: []byte(`["list",["object",{"dnsName":"string"}]]`), Value: []byte(`[{"dnsName":"api.example.com"}]`)},
+		"zone":      {Type: []byte(`"string"`), Value: []byte(`"example.com"`)},
+		"password":  {Type: []byte(`"string"`), Value: []byte(`"secret"`), Sensitive: true},
+	}
+
+	obj, err := outputsObject(terraform, outputs)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.Object).To(Equal(map[string]interface{}{
+		"apiVersion": "externaldns.k8s.io/v1alpha1",
+		"kind":       "DNSEndpoint",
+		"metadata": map[string]interface{}{
+			"name":      "api",
+			"namespace": "flux-system",
+			"labels":    map[string]interface{}{"zone": "example.com"},
+		},
+		"spec": map[string]interface{}{
+			"endpoints": []interface{}{map[string]interface{}{"dnsName": "api.example.com"}},
+		},
+	}))
+
+	for _, mapping := range []infrav1.OutputFieldMapping{
+		{Output: "password", Field: "spec.password"},
+		{Output: "missing", Field: "spec.missing"},
+		{Output: "zone", Field: "metadata.namespace"},
+		{Output: "zone", Field: "metadata.ownerReferences"},
+		{Output: "zone", Field: "kind"},
+	} {
+		terraform.Spec.WriteOutputsTo.FieldMappings = []infrav1.OutputFieldMapping{mapping}
+		_, err := outputsObject(terraform, outputs)
+		g.Expect(err).To(HaveOccurred(), mapping.Field)
+	}
+}
+
+func TestOutputsKindAllowed(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &TerraformReconciler{AllowedOutputsKinds: []string{"ConfigMap", "DNSEndpoint.externaldns.k8s.io"}}
+	g.Expect(r.outputsKindAllowed(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})).To(BeTrue())
+	g.Expect(r.outputsKindAllowed(schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"})).To(BeTrue())
+	g.Expect(r.outputsKindAllowed(schema.GroupVersionKind{Version: "v1", Kind: "Secret"})).To(BeFalse())
+	g.Expect(r.outputsKindAllowed(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "ConfigMap"})).To(BeFalse())
+	g.Expect((&TerraformReconciler{}).outputsKindAllowed(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})).To(BeFalse())
+}
+
+// conflictingClient fails the server-side applies as the fields are managed by another field manager.
+type conflictingClient struct {
+	client.Client
+}
+
+func (c conflictingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New(`conflict with "kubectl": .data.endpoint`))
+}
+
+func TestWriteOutputsToObjectNotApplied(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	NewWithT(t).Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+
+	outputs := map[string]tfexec.OutputMeta{
+		"endpoint": {Type: []byte(`"string"`), Value: []byte(`"api.example.com"`)},
+	}
+	tests := []struct {
+		name   string
+		kind   string
+		reason string
+		err    string
+	}{
+		{name: "kind not allowed", kind: "Secret", reason: infrav1.OutputsWritingForbiddenReason, err: "writing the outputs to Secret is not allowed"},
+		{name: "fields managed by another field manager", kind: "ConfigMap", reason: infrav1.OutputsConflictReason, err: "the fields are managed by another field manager"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &TerraformReconciler{
+				Client:              conflictingClient{fake.NewClientBuilder().WithScheme(testScheme).Build()},
+				EventRecorder:       record.NewFakeRecorder(10),
+				AllowedOutputsKinds: []string{"ConfigMap"},
+			}
+			terraform := infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "flux-system"},
+				Spec: infrav1.TerraformSpec{
+					WriteOutputsTo: &infrav1.WriteOutputsToObjectSpec{
+						APIVersion:    "v1",
+						Kind:          tt.kind,
+						Name:          "api",
+						FieldMappings: []infrav1.OutputFieldMapping{{Output: "endpoint", Field: "data.endpoint"}},
+					},
+				},
+			}
+
+			terraform, err := r.writeOutputsToObject(context.TODO(), terraform, outputs, "main/1234")
+			g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+			ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+			g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(ready.Reason).To(Equal(tt.reason))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const pushSecretAPIVersion = "external-secrets.io/v1alpha1"
+
+// pushOutputs pushes the outputs Secret to the stores of .spec.writeOutputsToSecret.pushTo, by applying
+// a PushSecret of the External Secrets Operator named after the Secret.
+func (r *TerraformReconciler) pushOutputs(ctx context.Context, terraform infrav1.Terraform, keys []string) error {
+	if terraform.OutputsSecretNamespace() != terraform.Namespace {
+		return fmt.Errorf("the Secret must be in the namespace of the object")
+	}
+	return r.Patch(ctx, pushSecretObject(terraform, keys), client.Apply, client.FieldOwner(r.statusManager), client.ForceOwnership)
+}
+
+// pushSecretObject returns the PushSecret of the outputs Secret, which pushes each key of the Secret
+// as a property of the remote secret.
+func pushSecretObject(terraform infrav1.Terraform, keys []string) *unstructured.Unstructured {
+	wots := terraform.Spec.WriteOutputsToSecret
+	push := wots.PushTo
+
+	vTrue := true
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(pushSecretAPIVersion)
+	obj.SetKind("PushSecret")
+	obj.SetNamespace(terraform.Namespace)
+	obj.SetName(wots.Name)
+	obj.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion: infrav1.GroupVersion.String(),
+			Kind:       infrav1.TerraformKind,
+			Name:       terraform.Name,
+			UID:        terraform.UID,
+			Controller: &vTrue,
+		},
+	})
+
+	var stores []interface{}
+	for _, ref := range push.SecretStoreRefs {
+		kind := ref.Kind
+		if kind == "" {
+			kind = "SecretStore"
+		}
+		stores = append(stores, map[string]interface{}{"name": ref.Name, "kind": kind})
+	}
+
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	var data []interface{}
+	for _, key := range sorted {
+		data = append(data, map[string]interface{}{
+			"match": map[string]interface{}{
+				"secretKey": key,
+				"remoteRef": map[string]interface{}{
+					"remoteKey": push.RemoteKey,
+					"property":  key,
+				},
+			},
+		})
+	}
+
+	spec := map[string]interface{}{
+		"secretStoreRefs": stores,
+		"selector": map[string]interface{}{
+			"secret": map[string]interface{}{"name": wots.Name},
+		},
+		"data": data,
+	}
+	if push.RefreshInterval != nil {
+		spec["refreshInterval"] = push.RefreshInterval.Duration.String()
+	}
+	obj.Object["spec"] = spec
+	return obj
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPushSecretObject(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "flux-system", UID: "uid"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{
+				Name: "db-outputs",
+				PushTo: &infrav1.PushOutputsSpec{
+					SecretStoreRefs: []infrav1.SecretStoreRef{
+						{Name: "aws"},
+						{Name: "vault", Kind: "ClusterSecretStore"},
+					},
+					RemoteKey:       "prod/db",
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+	}
+
+	obj := pushSecretObject(terraform, []string{"password", "endpoint"})
+	g.Expect(obj.GetAPIVersion()).To(Equal("external-secrets.io/v1alpha1"))
+	g.Expect(obj.GetKind()).To(Equal("PushSecret"))
+	g.Expect(obj.GetNamespace()).To(Equal("flux-system"))
+	g.Expect(obj.GetName()).To(Equal("db-outputs"))
+	g.Expect(obj.GetOwnerReferences()).To(HaveLen(1))
+	g.Expect(obj.GetOwnerReferences()[0].UID).To(BeEquivalentTo("uid"))
+	g.Expect(obj.Object["spec"]).To(Equal(map[string]interface{}{
+		"refreshInterval": "1h0m0s",
+		"secretStoreRefs": []interface{}{
+			map[string]interface{}{"name": "aws", "kind": "SecretStore"},
+			map[string]interface{}{"name": "vault", "kind": "ClusterSecretStore"},
+		},
+		"selector": map[string]interface{}{
+			"secret": map[string]interface{}{"name": "db-outputs"},
+		},
+		"data": []interface{}{
+			map[string]interface{}{"match": map[string]interface{}{
+				"secretKey": "endpoint",
+				"remoteRef": map[string]interface{}{"remoteKey": "prod/db", "property": "endpoint"},
+			}},
+			map[string]interface{}{"match": map[string]interface{}{
+				"secretKey": "password",
+				"remoteRef": map[string]interface{}{"remoteKey": "prod/db", "property": "password"},
+			}},
+		},
+	}))
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// outputsRBACName is the name of the Role and the RoleBinding allowing the runner of the object
+// to write its outputs Secret in another namespace. A namespace has no dots, so that the name of
+// each object is different.
+func outputsRBACName(terraform infrav1.Terraform) string {
+	return fmt.Sprintf("tf-runner-outputs-%s.%s", terraform.Namespace, terraform.Name)
+}
+
+// legacyOutputsRBACName is the name of the Role and the RoleBinding of the earlier versions,
+// shared by the objects whose namespace and name join the same with a dash, e.g. a-b/c and a/b-c.
+func legacyOutputsRBACName(terraform infrav1.Terraform) string {
+	return fmt.Sprintf("tf-runner-outputs-%s-%s", terraform.Namespace, terraform.Name)
+}
+
+// reconcileOutputsRBAC makes sure that the runner can write the outputs Secret of another namespace.
+// With writeOutputsToSecret.createRBAC, the Role and the RoleBinding of the runner are created in that namespace,
+// otherwise the permissions of the runner are verified, so that the missing ones are reported rather than
+// the forbidden error of the runner.
+func (r *TerraformReconciler) reconcileOutputsRBAC(ctx context.Context, terraform infrav1.Terraform) error {
+	namespace := terraform.OutputsSecretNamespace()
+	if namespace == terraform.Namespace {
+		return nil
+	}
+	if !r.AllowCrossNamespaceOutputs {
+		return fmt.Errorf("writing the outputs to the namespace %s is not allowed, the controller must be started with --allow-cross-namespace-outputs", namespace)
+	}
+	if !r.outputsNamespaceAllowed(namespace) {
+		return fmt.Errorf("writing the outputs to the namespace %s is not allowed, it is not in --allowed-outputs-namespaces", namespace)
+	}
+
+	if terraform.Spec.WriteOutputsToSecret.CreateRBAC {
+		return r.createOutputsRBAC(ctx, terraform)
+	}
+
+	serviceAccount := terraform.RunnerServiceAccountName()
+	var missing []string
+	for _, verb := range []string{"get", "create", "update"} {
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   fmt.Sprintf("system:serviceaccount:%s:%s", terraform.Namespace, serviceAccount),
+				Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + terraform.Namespace, "system:authenticated"},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Resource:  "secrets",
+				},
+			},
+		}
+		if verb != "create" {
+			review.Spec.ResourceAttributes.Name = terraform.Spec.WriteOutputsToSecret.Name
+		}
+		if err := r.Create(ctx, review); err != nil {
+			return fmt.Errorf("unable to verify the permissions of the runner in the namespace %s: %w", namespace, err)
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, verb)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the runner service account %s/%s is not allowed to %s the secret %s in the namespace %s, set writeOutputsToSecret.createRBAC or grant the permissions",
+			terraform.Namespace, serviceAccount, strings.Join(missing, ", "), terraform.Spec.WriteOutputsToSecret.Name, namespace)
+	}
+	return nil
+}
+
+// outputsNamespaceAllowed tells whether the outputs Secrets may be written to the namespace, another namespace than the object's.
+func (r *TerraformReconciler) outputsNamespaceAllowed(namespace string) bool {
+	for _, allowed := range r.AllowedOutputsNamespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TerraformReconciler) createOutputsRBAC(ctx context.Context, terraform infrav1.Terraform) error {
+	namespace := terraform.OutputsSecretNamespace()
+	name := outputsRBACName(terraform)
+	labels := map[string]string{
+		"app.kubernetes.io/created-by": "tf-controller",
+		"app.kubernetes.io/name":       "tf-runner",
+	}
+	secretName := terraform.Spec.WriteOutputsToSecret.Name
+
+	// the name of a created object is unknown to the authorizer, so creating can't be restricted to the Secret
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"create"}},
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{secretName}, Verbs: []string{"get", "update", "delete"}},
+	}
+	var role rbacv1.Role
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &role)
+	switch {
+	case apierrors.IsNotFound(err):
+		role = rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}, Rules: rules}
+		err = r.Create(ctx, &role)
+	case err == nil:
+		role.Rules = rules
+		err = r.Update(ctx, &role)
+	}
+	if err != nil {
+		return fmt.Errorf("error reconciling the role %s/%s of the runner: %w", namespace, name, err)
+	}
+
+	subjects := []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: terraform.RunnerServiceAccountName(), Namespace: terraform.Namespace},
+	}
+	var rb rbacv1.RoleBinding
+	err = r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &rb)
+	switch {
+	case apierrors.IsNotFound(err):
+		rb = rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+			Subjects:   subjects,
+		}
+		err = r.Create(ctx, &rb)
+	case err == nil:
+		rb.Subjects = subjects
+		err = r.Update(ctx, &rb)
+	}
+	if err != nil {
+		return fmt.Errorf("error reconciling the role binding %s/%s of the runner: %w", namespace, name, err)
+	}
+	return r.deleteLegacyOutputsRBAC(ctx, terraform)
+}
+
+// deleteLegacyOutputsRBAC deletes the Role and the RoleBinding of the legacy name, when they are the object's:
+// the RoleBinding binds the runner of the object only.
+func (r *TerraformReconciler) deleteLegacyOutputsRBAC(ctx context.Context, terraform infrav1.Terraform) error {
+	key := types.NamespacedName{Namespace: terraform.OutputsSecretNamespace(), Name: legacyOutputsRBACName(terraform)}
+	var rb rbacv1.RoleBinding
+	if err := r.Get(ctx, key, &rb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: terraform.RunnerServiceAccountName(), Namespace: terraform.Namespace}
+	if rb.Labels["app.kubernetes.io/created-by"] != "tf-controller" || len(rb.Subjects) != 1 || rb.Subjects[0] != subject {
+		return nil
+	}
+	meta := metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{ObjectMeta: meta}, &rbacv1.Role{ObjectMeta: meta}} {
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOutputsRBAC deletes the Role and the RoleBinding created for the outputs Secret of another namespace.
+func (r *TerraformReconciler) deleteOutputsRBAC(ctx context.Context, terraform infrav1.Terraform) error {
+	namespace := terraform.OutputsSecretNamespace()
+	if namespace == terraform.Namespace || !terraform.Spec.WriteOutputsToSecret.CreateRBAC {
+		return nil
+	}
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: outputsRBACName(terraform)}
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{ObjectMeta: meta}, &rbacv1.Role{ObjectMeta: meta}} {
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return r.deleteLegacyOutputsRBAC(ctx, terraform)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileOutputsRBAC(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ServiceAccountName: "tf-runner",
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{
+				Name:       "helloworld-outputs",
+				Namespace:  "apps",
+				CreateRBAC: true,
+			},
+		},
+	}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+
+	// cross-namespace outputs must be allowed by the controller
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(MatchError(ContainSubstring("--allow-cross-namespace-outputs")))
+
+	// and to the namespace of the Secret
+	r.AllowCrossNamespaceOutputs = true
+	r.AllowedOutputsNamespaces = []string{"monitoring"}
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(MatchError(ContainSubstring("--allowed-outputs-namespaces")))
+	terraform.Spec.WriteOutputsToSecret.Namespace = "kube-system"
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(MatchError(ContainSubstring("--allowed-outputs-namespaces")))
+
+	terraform.Spec.WriteOutputsToSecret.Namespace = "apps"
+	r.AllowedOutputsNamespaces = []string{"monitoring", "apps"}
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(Succeed())
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "apps", Name: "tf-runner-outputs-flux-system.helloworld"}
+	var role rbacv1.Role
+	g.Expect(r.Get(ctx, key, &role)).To(Succeed())
+	g.Expect(role.Rules[1].ResourceNames).To(Equal([]string{"helloworld-outputs"}))
+	var rb rbacv1.RoleBinding
+	g.Expect(r.Get(ctx, key, &rb)).To(Succeed())
+	g.Expect(rb.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: "tf-runner", Namespace: "flux-system"}}))
+
+	g.Expect(r.deleteOutputsRBAC(ctx, terraform)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(r.Get(ctx, key, &role))).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(r.Get(ctx, key, &rb))).To(BeTrue())
+
+	// the outputs of the namespace of the object need nothing
+	r.AllowCrossNamespaceOutputs = false
+	terraform.Spec.WriteOutputsToSecret.Namespace = ""
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(Succeed())
+}
+
+func TestOutputsRBACNames(t *testing.T) {
+	g := NewWithT(t)
+
+	// the objects whose namespace and name join the same with a dash have different names
+	a := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "a-b"}}
+	b := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "b-c", Namespace: "a"}}
+	g.Expect(legacyOutputsRBACName(a)).To(Equal(legacyOutputsRBACName(b)))
+	g.Expect(outputsRBACName(a)).ToNot(Equal(outputsRBACName(b)))
+}
+
+func TestDeleteLegacyOutputsRBAC(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "b-c", Namespace: "a"},
+		Spec: infrav1.TerraformSpec{
+			ServiceAccountName:   "tf-runner",
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "outputs", Namespace: "apps", CreateRBAC: true},
+		},
+	}
+	legacy := metav1.ObjectMeta{Namespace: "apps", Name: "tf-runner-outputs-a-b-c", Labels: map[string]string{"app.kubernetes.io/created-by": "tf-controller"}}
+	// the legacy RoleBinding of a-b/c is not the object's
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: legacy,
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: legacy.Name},
+		Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "tf-runner", Namespace: "a-b"}},
+	}
+	r := &TerraformReconciler{
+		Client:                     fake.NewClientBuilder().WithScheme(testScheme).WithObjects(rb, &rbacv1.Role{ObjectMeta: legacy}).Build(),
+		Scheme:                     testScheme,
+		AllowCrossNamespaceOutputs: true,
+		AllowedOutputsNamespaces:   []string{"apps"},
+	}
+	key := types.NamespacedName{Namespace: "apps", Name: legacy.Name}
+
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(Succeed())
+	g.Expect(r.Get(ctx, key, &rbacv1.RoleBinding{})).To(Succeed())
+	g.Expect(r.Get(ctx, key, &rbacv1.Role{})).To(Succeed())
+
+	// the legacy Role and RoleBinding of the object are replaced
+	terraform.Namespace = "a-b"
+	terraform.Name = "c"
+	g.Expect(r.reconcileOutputsRBAC(ctx, terraform)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(r.Get(ctx, key, &rbacv1.RoleBinding{}))).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(r.Get(ctx, key, &rbacv1.Role{}))).To(BeTrue())
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "apps", Name: "tf-runner-outputs-a-b.c"}, &rbacv1.RoleBinding{})).To(Succeed())
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// readOutputsFromTF returns the object with the outputs of the Terraform objects of spec.readOutputsFromTF
+// prepended to its variables, so that spec.vars and spec.varsFrom override them. The variables are never written back to the object.
+func (r *TerraformReconciler) readOutputsFromTF(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	var vars []infrav1.Variable
+	for _, ref := range terraform.Spec.ReadOutputsFromTF {
+		var upstream infrav1.Terraform
+		if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: ref.Name}, &upstream); err != nil {
+			return terraform, fmt.Errorf("unable to get the Terraform object %s: %w", ref.Name, err)
+		}
+		outputs, err := r.upstreamOutputs(ctx, upstream)
+		if err != nil {
+			return terraform, fmt.Errorf("unable to read the outputs of %s: %w", ref.Name, err)
+		}
+
+		if len(ref.Outputs) == 0 {
+			names := make([]string, 0, len(outputs))
+			for name := range outputs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				vars = append(vars, infrav1.Variable{Name: name, Value: outputs[name]})
+			}
+			continue
+		}
+
+		// an output may be read as another variable, with output:variable
+		for _, mapping := range ref.Outputs {
+			output, variable := mapping, mapping
+			if parts := strings.SplitN(mapping, ":", 2); len(parts) == 2 {
+				output, variable = parts[0], parts[1]
+			}
+			value, ok := outputs[output]
+			if !ok {
+				return terraform, fmt.Errorf("output %s of %s is not available", output, ref.Name)
+			}
+			vars = append(vars, infrav1.Variable{Name: variable, Value: value})
+		}
+	}
+
+	terraform.Spec.Vars = append(vars, terraform.Spec.Vars...)
+	return terraform, nil
+}
+
+// upstreamOutputs returns the outputs of an object, typed when they are read from its state stored in the cluster,
+// or as strings when they are read from its outputs Secret.
+func (r *TerraformReconciler) upstreamOutputs(ctx context.Context, upstream infrav1.Terraform) (map[string]*apiextensionsv1.JSON, error) {
+	if key, ok := inClusterStateSecretKey(upstream); ok {
+		var stateSecret corev1.Secret
+		err := r.Get(ctx, key, &stateSecret)
+		if err == nil {
+			return stateOutputs(stateSecret.Data[tfstateSecretKey])
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if wots := upstream.Spec.WriteOutputsToSecret; wots != nil {
+		var outputsSecret corev1.Secret
+		key := types.NamespacedName{Namespace: upstream.OutputsSecretNamespace(), Name: wots.Name}
+		if err := r.Get(ctx, key, &outputsSecret); err != nil {
+			return nil, err
+		}
+		outputs := map[string]*apiextensionsv1.JSON{}
+		for name, value := range outputsSecret.Data {
+			encoded, err := utils.JSONEncodeBytes(value)
+			if err != nil {
+				return nil, err
+			}
+			outputs[name] = encoded
+		}
+		return outputs, nil
+	}
+
+	return nil, fmt.Errorf("it has neither a state in the cluster nor an outputs Secret")
+}
+
+// stateOutputs returns the values of the outputs of a state stored by the kubernetes backend.
+func stateOutputs(data []byte) (map[string]*apiextensionsv1.JSON, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("the state is empty")
+	}
+
+	stateBytes, err := utils.GzipDecode(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode state: %w", err)
+	}
+
+	var state struct {
+		Outputs map[string]struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"outputs"`
+	}
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		return nil, fmt.Errorf("unable to parse state: %w", err)
+	}
+
+	outputs := make(map[string]*apiextensionsv1.JSON, len(state.Outputs))
+	for name, output := range state.Outputs {
+		outputs[name] = &apiextensionsv1.JSON{Raw: output.Value}
+	}
+	return outputs, nil
+}
+
+// IndexByOutputsSource indexes the Terraform objects by the names of the objects whose outputs they read.
+func (r *TerraformReconciler) IndexByOutputsSource(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, ref := range terraform.Spec.ReadOutputsFromTF {
+		keys = append(keys, ref.Name)
+	}
+	return keys
+}
+
+func (r *TerraformReconciler) requestsForOutputsChange(obj client.Object) []reconcile.Request {
+	ctx := context.Background()
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{
+		infrav1.OutputsSourceIndexKey: obj.GetName(),
+	}); err != nil {
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, len(list.Items))
+	for i, t := range list.Items {
+		reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)}
+	}
+	return reqs
+}
+
+// OutputsChangedPredicate triggers when the outputs of a Terraform object may have changed,
+// once it has applied a plan or its available outputs changed.
+type OutputsChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (OutputsChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldObj, ok := e.ObjectOld.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+	newObj, ok := e.ObjectNew.(*infrav1.Terraform)
+	if !ok {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldObj.Status.LastApplyAt, newObj.Status.LastApplyAt) ||
+		!equality.Semantic.DeepEqual(oldObj.Status.AvailableOutputs, newObj.Status.AvailableOutputs)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestReadOutputsFromTF(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	state, err := utils.GzipEncode([]byte(`{"version":4,"outputs":{
+		"vpc_id":{"value":"vpc-123","type":"string"},
+		"subnet_ids":{"value":["subnet-a","subnet-b"],"type":["list","string"]}}}`))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		// the state of network is stored in the cluster
+		&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: "flux-system"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tfstate-default-network", Namespace: "flux-system"},
+			Data:       map[string][]byte{tfstateSecretKey: state},
+		},
+		// the state of dns is stored in a bucket, its outputs are only in its outputs Secret
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "flux-system"},
+			Spec: infrav1.TerraformSpec{
+				BackendConfig:        &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" {}`},
+				WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "dns-outputs"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns-outputs", Namespace: "flux-system"},
+			Data:       map[string][]byte{"zone_id": []byte("Z123")},
+		},
+		&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-applied", Namespace: "flux-system"},
+			Spec: infrav1.TerraformSpec{
+				BackendConfig: &infrav1.BackendConfigSpec{CustomConfiguration: `backend "s3" {}`},
+			},
+		},
+	).Build()
+	r := &TerraformReconciler{Client: cli}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ReadOutputsFromTF: []infrav1.ReadOutputsFromTFSpec{
+				{Name: "network"},
+				{Name: "dns", Outputs: []string{"zone_id:dns_zone_id"}},
+			},
+			Vars: []infrav1.Variable{{Name: "vpc_id", Value: &apiextensionsv1.JSON{Raw: []byte(`"vpc-override"`)}}},
+		},
+	}
+	withOutputs, err := r.readOutputsFromTF(ctx, terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	var vars []string
+	for _, v := range withOutputs.Spec.Vars {
+		vars = append(vars, v.Name+"="+string(v.Value.Raw))
+	}
+	// the variables of the object come last, overriding the outputs
+	g.Expect(vars).To(Equal([]string{
+		`subnet_ids=["subnet-a","subnet-b"]`,
+		`vpc_id="vpc-123"`,
+		`dns_zone_id="Z123"`,
+		`vpc_id="vpc-override"`,
+	}))
+	g.Expect(terraform.Spec.Vars).To(HaveLen(1))
+
+	terraform.Spec.ReadOutputsFromTF = []infrav1.ReadOutputsFromTFSpec{{Name: "network", Outputs: []string{"missing"}}}
+	_, err = r.readOutputsFromTF(ctx, terraform)
+	g.Expect(err).To(MatchError("output missing of network is not available"))
+
+	terraform.Spec.ReadOutputsFromTF = []infrav1.ReadOutputsFromTFSpec{{Name: "not-applied"}}
+	_, err = r.readOutputsFromTF(ctx, terraform)
+	g.Expect(err).To(MatchError(ContainSubstring("neither a state in the cluster nor an outputs Secret")))
+}
+
+func TestOutputsChangedPredicate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldObj := &infrav1.Terraform{Status: infrav1.TerraformStatus{AvailableOutputs: []string{"vpc_id"}}}
+	newObj := oldObj.DeepCopy()
+	newObj.Status.LastAttemptedRevision = "main/b8e362c206"
+	g.Expect(OutputsChangedPredicate{}.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeFalse())
+
+	newObj.Status.LastApplyAt = &metav1.Time{Time: time.Now()}
+	g.Expect(OutputsChangedPredicate{}.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeTrue())
+
+	newObj = oldObj.DeepCopy()
+	newObj.Status.AvailableOutputs = append(newObj.Status.AvailableOutputs, "subnet_ids")
+	g.Expect(OutputsChangedPredicate{}.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeTrue())
+}
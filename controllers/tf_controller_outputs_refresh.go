@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// outputsRefreshInterval returns the spec.outputs.refreshInterval of an object writing its outputs, or zero.
+func outputsRefreshInterval(terraform infrav1.Terraform) time.Duration {
+	if terraform.Spec.Outputs == nil || terraform.Spec.Outputs.RefreshInterval == nil {
+		return 0
+	}
+	if terraform.Spec.WriteOutputsToSecret == nil && terraform.Spec.WriteOutputsToConfigMap == nil && terraform.Spec.WriteOutputsTo == nil {
+		return 0
+	}
+	return terraform.Spec.Outputs.RefreshInterval.Duration
+}
+
+// shouldRefreshOutputs returns true if the outputs of an applied object were last written, by an apply
+// or by a refresh, longer than its spec.outputs.refreshInterval ago.
+func (r *TerraformReconciler) shouldRefreshOutputs(terraform infrav1.Terraform, now time.Time) bool {
+	interval := outputsRefreshInterval(terraform)
+	if interval <= 0 || terraform.Spec.Destroy || terraform.Status.LastAppliedRevision == "" {
+		return false
+	}
+
+	var last time.Time
+	for _, at := range []*metav1.Time{terraform.Status.LastApplyAt, terraform.Status.LastOutputsRefreshAt} {
+		if at != nil && at.After(last) {
+			last = at.Time
+		}
+	}
+	return now.Sub(last) >= interval
+}
+
+// refreshOutputs writes the outputs again from the state, and records the time of the refresh.
+func (r *TerraformReconciler) refreshOutputs(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, sourceObj sourcev1.Source, reconciliationLoopID string) (infrav1.Terraform, error) {
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	terraform, err := r.writeOutputsFromState(ctx, runnerClient, terraform, sourceObj, reconciliationLoopID)
+	if err != nil {
+		return terraform, err
+	}
+
+	terraform.Status.LastOutputsRefreshAt = &metav1.Time{Time: time.Now()}
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		return terraform, err
+	}
+	ctrl.LoggerFrom(ctx).Info("outputs refreshed from the state", "interval", outputsRefreshInterval(terraform).String())
+	return terraform, nil
+}
+
+// requeueForOutputsRefresh shortens the requeue of a result to the spec.outputs.refreshInterval of the object,
+// so that its outputs are refreshed even when it waits for the approval of a plan, or for a manual reconciliation.
+// The objects reconciled at their interval refresh their outputs in the first reconciliation after the refresh interval,
+// as requeueing them earlier would plan them earlier too.
+func requeueForOutputsRefresh(terraform infrav1.Terraform, result ctrl.Result) ctrl.Result {
+	interval := outputsRefreshInterval(terraform)
+	if interval > 0 && (result.RequeueAfter == 0 || result.RequeueAfter > interval) {
+		result.RequeueAfter = interval
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestShouldRefreshOutputs(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+	now := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	terraform := infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			Interval:             metav1.Duration{Duration: 24 * time.Hour},
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"},
+			Outputs:              &infrav1.OutputsSpec{RefreshInterval: &metav1.Duration{Duration: time.Hour}},
+		},
+		Status: infrav1.TerraformStatus{
+			LastAppliedRevision: "main/b8e362c206",
+			LastApplyAt:         &metav1.Time{Time: now.Add(-30 * time.Minute)},
+		},
+	}
+	g.Expect(r.shouldRefreshOutputs(terraform, now)).To(BeFalse())
+	g.Expect(r.shouldRefreshOutputs(terraform, now.Add(30*time.Minute))).To(BeTrue())
+
+	// the last refresh counts as the last write of the outputs
+	terraform.Status.LastOutputsRefreshAt = &metav1.Time{Time: now.Add(10 * time.Minute)}
+	g.Expect(r.shouldRefreshOutputs(terraform, now.Add(30*time.Minute))).To(BeFalse())
+	g.Expect(r.shouldRefreshOutputs(terraform, now.Add(70*time.Minute))).To(BeTrue())
+
+	// nothing to refresh before the first apply, while destroying, or without outputs to write
+	notApplied := terraform.DeepCopy()
+	notApplied.Status.LastAppliedRevision = ""
+	g.Expect(r.shouldRefreshOutputs(*notApplied, now.Add(2*time.Hour))).To(BeFalse())
+	destroying := terraform.DeepCopy()
+	destroying.Spec.Destroy = true
+	g.Expect(r.shouldRefreshOutputs(*destroying, now.Add(2*time.Hour))).To(BeFalse())
+	noOutputs := terraform.DeepCopy()
+	noOutputs.Spec.WriteOutputsToSecret = nil
+	g.Expect(r.shouldRefreshOutputs(*noOutputs, now.Add(2*time.Hour))).To(BeFalse())
+
+	g.Expect(requeueForOutputsRefresh(terraform, ctrl.Result{})).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+	g.Expect(requeueForOutputsRefresh(terraform, ctrl.Result{RequeueAfter: 5 * time.Minute})).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Minute}))
+	g.Expect(requeueForOutputsRefresh(*noOutputs, ctrl.Result{})).To(Equal(ctrl.Result{}))
+}
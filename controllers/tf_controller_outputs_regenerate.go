@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// shouldRegenerateOutputs returns true if the outputs Secret of an applied object has been deleted,
+// or if the regenerate-outputs annotation has been set to a new value.
+func (r *TerraformReconciler) shouldRegenerateOutputs(terraform infrav1.Terraform) bool {
+	if terraform.Spec.WriteOutputsToSecret == nil || terraform.Spec.Destroy || terraform.Status.LastAppliedRevision == "" {
+		return false
+	}
+	if requested := terraform.GetAnnotations()[infrav1.RegenerateOutputsAnnotation]; requested != "" && requested != terraform.Status.LastRegeneratedOutputs {
+		return true
+	}
+	outOfSync := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeOutputsOutOfSync)
+	return outOfSync != nil && outOfSync.Status == metav1.ConditionTrue && outOfSync.Reason == infrav1.OutputsMissingReason
+}
+
+// regenerateOutputs writes the outputs Secret again from the outputs of the state, with terraform output only,
+// instead of waiting for the next plan and apply. It runs even while a plan waits for its approval.
+func (r *TerraformReconciler) regenerateOutputs(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, sourceObj sourcev1.Source, reconciliationLoopID string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	revision := terraform.Status.LastAppliedRevision
+
+	terraform, err := r.writeOutputsFromState(ctx, runnerClient, terraform, sourceObj, reconciliationLoopID)
+	if err != nil {
+		return terraform, err
+	}
+
+	if requested := terraform.GetAnnotations()[infrav1.RegenerateOutputsAnnotation]; requested != "" {
+		terraform.Status.LastRegeneratedOutputs = requested
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			return terraform, err
+		}
+	}
+
+	msg := fmt.Sprintf("Outputs Secret %s regenerated from the state", terraform.Spec.WriteOutputsToSecret.Name)
+	log.Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	return terraform, nil
+}
+
+// writeOutputsFromState sets up the runner with the last applied revision, and writes the outputs of its state.
+func (r *TerraformReconciler) writeOutputsFromState(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, sourceObj sourcev1.Source, reconciliationLoopID string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	terraform, tfInstance, tmpDir, err := r.setupTerraform(ctx, runnerClient, terraform, sourceObj, sourceObj.GetArtifact().Revision, objectKey, reconciliationLoopID)
+	if !terraform.Spec.HasPersistentWorkingDir() {
+		defer func() {
+			if _, err := runnerClient.CleanupDir(ctx, &runner.CleanupDirRequest{TmpDir: tmpDir}); err != nil {
+				log.Error(err, "clean up error")
+			}
+		}()
+	}
+	if err != nil {
+		return terraform, err
+	}
+
+	return r.processOutputs(ctx, runnerClient, terraform, tfInstance, terraform.Status.LastAppliedRevision)
+}
+
+// OutputsRegenerationRequestedPredicate triggers when the regenerate-outputs annotation changes.
+type OutputsRegenerationRequestedPredicate struct {
+	predicate.Funcs
+}
+
+func (OutputsRegenerationRequestedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[infrav1.RegenerateOutputsAnnotation] != e.ObjectOld.GetAnnotations()[infrav1.RegenerateOutputsAnnotation]
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestShouldRegenerateOutputs(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+
+	applied := func() infrav1.Terraform {
+		return infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+			Spec: infrav1.TerraformSpec{
+				WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"},
+			},
+			Status: infrav1.TerraformStatus{LastAppliedRevision: "main/b8e362c206"},
+		}
+	}
+
+	terraform := applied()
+	g.Expect(r.shouldRegenerateOutputs(terraform)).To(BeFalse())
+
+	missing := infrav1.TerraformOutputsOutOfSync(terraform, infrav1.OutputsMissingReason, "Secret flux-system/helloworld-outputs not found")
+	g.Expect(r.shouldRegenerateOutputs(missing)).To(BeTrue())
+
+	// a modified Secret is left to the drift detection, unless requested
+	modified := infrav1.TerraformOutputsOutOfSync(applied(), infrav1.OutputsModifiedReason, "content hash mismatch")
+	g.Expect(r.shouldRegenerateOutputs(modified)).To(BeFalse())
+	modified.Annotations = map[string]string{infrav1.RegenerateOutputsAnnotation: "2023-02-01T10:00:00Z"}
+	g.Expect(r.shouldRegenerateOutputs(modified)).To(BeTrue())
+	modified.Status.LastRegeneratedOutputs = "2023-02-01T10:00:00Z"
+	g.Expect(r.shouldRegenerateOutputs(modified)).To(BeFalse())
+
+	// nothing to regenerate before the first apply, or while destroying
+	notApplied := missing.DeepCopy()
+	notApplied.Status.LastAppliedRevision = ""
+	g.Expect(r.shouldRegenerateOutputs(*notApplied)).To(BeFalse())
+	destroying := missing.DeepCopy()
+	destroying.Spec.Destroy = true
+	g.Expect(r.shouldRegenerateOutputs(*destroying)).To(BeFalse())
+
+	oldObj := applied()
+	newObj := applied()
+	newObj.Annotations = map[string]string{infrav1.RegenerateOutputsAnnotation: "2023-02-01T10:00:00Z"}
+	g.Expect(OutputsRegenerationRequestedPredicate{}.Update(event.UpdateEvent{ObjectOld: &oldObj, ObjectNew: &newObj})).To(BeTrue())
+	g.Expect(OutputsRegenerationRequestedPredicate{}.Update(event.UpdateEvent{ObjectOld: &newObj, ObjectNew: &newObj})).To(BeFalse())
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// getOutputsSchema returns the schema of .spec.writeOutputsToSecret, either inline
+// or read from a ConfigMap, or nil if no schema is set.
+func (r *TerraformReconciler) getOutputsSchema(ctx context.Context, terraform infrav1.Terraform) ([]byte, error) {
+	wots := terraform.Spec.WriteOutputsToSecret
+	if wots == nil {
+		return nil, nil
+	}
+
+	if wots.Schema != nil {
+		return wots.Schema.Raw, nil
+	}
+
+	if wots.SchemaFrom == nil {
+		return nil, nil
+	}
+
+	var cm corev1.ConfigMap
+	key := types.NamespacedName{Namespace: terraform.Namespace, Name: wots.SchemaFrom.Name}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		return nil, fmt.Errorf("unable to get the outputs schema ConfigMap %s: %w", key, err)
+	}
+
+	data, ok := cm.Data[wots.SchemaFrom.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in the outputs schema ConfigMap %s", wots.SchemaFrom.Key, key)
+	}
+
+	return []byte(data), nil
+}
+
+// validateOutputs checks the outputs, keyed by their names in the output Secret,
+// against an OpenAPI v3 schema given in JSON or YAML.
+func validateOutputs(schema []byte, outputs map[string]interface{}) error {
+	schemaJSON, err := yaml.YAMLToJSON(schema)
+	if err != nil {
+		return fmt.Errorf("unable to parse the outputs schema: %w", err)
+	}
+
+	var props apiextensionsv1.JSONSchemaProps
+	if err := json.Unmarshal(schemaJSON, &props); err != nil {
+		return fmt.Errorf("unable to parse the outputs schema: %w", err)
+	}
+
+	var internal apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&props, &internal, nil); err != nil {
+		return fmt.Errorf("unable to convert the outputs schema: %w", err)
+	}
+
+	validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: &internal})
+	if err != nil {
+		return fmt.Errorf("unable to build the outputs schema validator: %w", err)
+	}
+
+	return validation.ValidateCustomResource(field.NewPath("outputs"), outputs, validator).ToAggregate()
+}
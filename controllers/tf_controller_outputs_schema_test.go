@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateOutputs(t *testing.T) {
+	g := NewWithT(t)
+
+	schema := []byte(`
+type: object
+required: [vpc_id, subnet_ids]
+properties:
+  vpc_id:
+    type: string
+    pattern: "^vpc-"
+  subnet_ids:
+    type: array
+    minItems: 1
+    items:
+      type: string
+`)
+
+	g.Expect(validateOutputs(schema, map[string]interface{}{
+		"vpc_id":     "vpc-0123",
+		"subnet_ids": []interface{}{"subnet-a", "subnet-b"},
+	})).To(Succeed())
+
+	err := validateOutputs(schema, map[string]interface{}{
+		"vpc_id":     "0123",
+		"subnet_ids": []interface{}{},
+	})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(ContainSubstring("outputs.vpc_id"))
+	g.Expect(err.Error()).To(ContainSubstring("outputs.subnet_ids"))
+
+	err = validateOutputs(schema, map[string]interface{}{"vpc_id": "vpc-0123"})
+	g.Expect(err).ToNot(BeNil())
+	g.Expect(err.Error()).To(ContainSubstring("outputs.subnet_ids: Required value"))
+
+	g.Expect(validateOutputs([]byte(`type: [`), map[string]interface{}{})).ToNot(Succeed())
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type recordingOutputsWriter struct {
+	data map[string][]byte
+}
+
+func (w *recordingOutputsWriter) WriteOutputs(ctx context.Context, in *runner.WriteOutputsRequest, opts ...grpc.CallOption) (*runner.WriteOutputsReply, error) {
+	w.data = in.Data
+	return &runner.WriteOutputsReply{Message: "ok", Changed: true}, nil
+}
+
+func TestWriteOutput_ExcludeSensitive(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+
+	outputs := map[string]tfexec.OutputMeta{
+		"endpoint": {Type: []byte(`"string"`), Value: []byte(`"db.example.com"`)},
+		"password": {Type: []byte(`"string"`), Value: []byte(`"s3cr3t"`), Sensitive: true},
+	}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs", ExcludeSensitive: true},
+		},
+	}
+
+	writer := &recordingOutputsWriter{}
+	_, err := r.writeOutput(ctx, terraform, writer, outputs, "main/b8e362c206")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(writer.data).To(Equal(map[string][]byte{"endpoint": []byte("db.example.com")}))
+
+	// selecting a sensitive output is an error, instead of leaving it out silently
+	terraform.Spec.WriteOutputsToSecret.Outputs = []string{"endpoint", "password:DB_PASSWORD"}
+	writer = &recordingOutputsWriter{}
+	_, err = r.writeOutput(ctx, terraform, writer, outputs, "main/b8e362c206")
+	g.Expect(err).To(MatchError("output password is sensitive, and excluded from Secret helloworld-outputs by excludeSensitive"))
+	g.Expect(writer.data).To(BeNil())
+
+	terraform.Spec.WriteOutputsToSecret.Outputs = nil
+	terraform.Spec.WriteOutputsToSecret.OutputMappings = []infrav1.OutputMapping{{Output: "password"}}
+	_, err = r.writeOutput(ctx, terraform, writer, outputs, "main/b8e362c206")
+	g.Expect(err).To(MatchError(ContainSubstring("output password is sensitive")))
+
+	// the sensitive outputs are written by default
+	terraform.Spec.WriteOutputsToSecret = &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"}
+	_, err = r.writeOutput(ctx, terraform, writer, outputs, "main/b8e362c206")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(writer.data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+}
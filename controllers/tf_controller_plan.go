@@ -2,11 +2,15 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/fluxcd/pkg/runtime/events"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
 	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,13 +43,21 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 		return terraform, err
 	}
 
+	// the existing resources of spec.imports are imported into the state, for the plan to adopt them
+	if len(terraform.Spec.Imports) > 0 && !terraform.Spec.Destroy && terraform.ObjectMeta.DeletionTimestamp.IsZero() {
+		var err error
+		if terraform, err = r.importResources(ctx, terraform, tfInstance, runnerClient, revision); err != nil {
+			return terraform, err
+		}
+	}
+
 	const tfplanFilename = "tfplan"
 
 	planRequest := &runner.PlanRequest{
 		TfInstance: tfInstance,
 		Out:        tfplanFilename,
 		Refresh:    true, // be careful, refresh requires to be true by default
-		Targets:    terraform.Spec.Targets,
+		Targets:    terraform.GetTargets(),
 	}
 
 	if r.backendCompletelyDisable(terraform) {
@@ -54,11 +66,25 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 
 	// check if destroy is set to true or
 	// the object is being deleted and DestroyResourcesOnDeletion is set to true
-	if terraform.Spec.Destroy || (!terraform.ObjectMeta.DeletionTimestamp.IsZero() && terraform.Spec.DestroyResourcesOnDeletion) {
+	destroyOnDeletion := !terraform.ObjectMeta.DeletionTimestamp.IsZero() && terraform.Spec.DestroyResourcesOnDeletion
+	if terraform.Spec.Destroy || destroyOnDeletion {
 		log.Info("plan to destroy")
 		planRequest.Destroy = true
 	}
 
+	// the resources of the replace-resources annotation are replaced once, by the plan applying them
+	var replaces string
+	if !planRequest.Destroy {
+		replaces, planRequest.Replace = pendingReplacements(terraform)
+	}
+
+	// the destroy plan of an object being deleted goes through the approval, unless plans are applied automatically
+	planId := ""
+	previewDestroy := destroyOnDeletion && !r.forceOrAutoApply(terraform)
+	if previewDestroy {
+		planId, _ = infrav1.GetDestroyPlanIdAndApproveMessage(revision, "")
+	}
+
 	planReply, err := runnerClient.Plan(ctx, planRequest)
 	if err != nil {
 
@@ -90,17 +116,21 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 	drifted := planReply.Drifted
 	log.Info(fmt.Sprintf("plan: %s, found drift: %v", planReply.Message, drifted))
 
-	if shouldProcessPostPlanningWebhooks(terraform) {
-		log.Info("calling post planning webhooks ...")
-		terraform, err = r.processPostPlanningWebhooks(ctx, terraform, runnerClient, revision, tfInstance)
+	// a break-glass token bypasses the gates of the plan, its use is audited when the plan is applied
+	breakGlass := r.breakGlassActive(ctx, terraform)
+	if breakGlass {
+		log.Info("break-glass token found, skipping the post-planning checks")
+	}
+
+	if !breakGlass {
+		terraform, err = r.runChecks(ctx, terraform, infrav1.CheckStagePostPlanning, &checkEnv{
+			runnerClient: runnerClient,
+			tfInstance:   tfInstance,
+			revision:     revision,
+		})
 		if err != nil {
-			log.Error(err, "failed during the process of post planning webhooks")
-			return infrav1.TerraformNotReady(
-				terraform,
-				revision,
-				infrav1.PostPlanningWebhookFailedReason,
-				err.Error(),
-			), err
+			log.Error(err, "failed during the post-planning checks")
+			return terraform, err
 		}
 	}
 
@@ -111,7 +141,21 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 		Namespace:                terraform.Namespace,
 		Uuid:                     string(terraform.GetUID()),
 		Revision:                 revision,
+		LastAppliedPlan:          terraform.Status.Plan.LastApplied,
+		PlanId:                   planId,
+		MaxPlanSize:              maxStoredSize(r.Config.Get().MaxPlanSize),
+		MaxReadablePlanSize:      maxStoredSize(r.Config.Get().MaxReadablePlanSize),
 	})
+	if status.Code(err) == codes.ResourceExhausted {
+		msg := fmt.Sprintf("Plan too large to be stored: %s", status.Convert(err).Message())
+		r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.PlanTooLargeReason,
+			msg,
+		), errors.New(msg)
+	}
 	if err != nil {
 		err = fmt.Errorf("error saving plan secret: %s", err)
 		return infrav1.TerraformNotReady(
@@ -123,19 +167,91 @@ func (r *TerraformReconciler) plan(ctx context.Context, terraform infrav1.Terraf
 	}
 	log.Info(fmt.Sprintf("save tfplan: %s", saveTFPlanReply.Message))
 
-	if drifted {
+	if saveTFPlanReply.ReadablePlanTruncated {
+		msg := fmt.Sprintf("Readable plan truncated, it exceeds the maximum size of %d bytes", maxStoredSize(r.Config.Get().MaxReadablePlanSize))
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	}
+
+	if terraform.Spec.StoreGraph {
+		// the graph is informational only, failing to store it must not block the plan
+		if _, err := runnerClient.Graph(ctx, &runner.GraphRequest{
+			TfInstance: tfInstance,
+			Name:       terraform.Name,
+			Namespace:  terraform.Namespace,
+			Uuid:       string(terraform.GetUID()),
+			Revision:   revision,
+		}); err != nil {
+			log.Error(err, "unable to store the graph")
+		}
+	}
+
+	if r.ProviderSchemas != nil {
+		terraform = r.cacheProviderSchemas(ctx, terraform, runnerClient, tfInstance)
+	}
+
+	if drifted && previewDestroy {
+		msg := fmt.Sprintf("Destroy plan generated, %d resource(s) to destroy", saveTFPlanReply.ChangeCount)
+		_, approveMessage := infrav1.GetDestroyPlanIdAndApproveMessage(revision, msg)
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, approveMessage, planMetadata(planId, saveTFPlanReply))
+		terraform = infrav1.TerraformDestroyPlanned(terraform, revision, msg)
+	} else if drifted {
 		forceOrAutoApply := r.forceOrAutoApply(terraform)
 
 		// this is the manual mode, we fire the event to show how to apply the plan
 		if forceOrAutoApply == false {
-			_, approveMessage := infrav1.GetPlanIdAndApproveMessage(revision, "Plan generated")
+			planId, approveMessage := infrav1.GetPlanIdAndApproveMessage(revision, "Plan generated")
 			msg := fmt.Sprintf("Planned.\n%s", approveMessage)
-			r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+			r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, planMetadata(planId, saveTFPlanReply))
 		}
 		terraform = infrav1.TerraformPlannedWithChanges(terraform, revision, forceOrAutoApply, "Plan generated")
+		terraform.Status.Plan.Replaces = replaces
+
+		if saveTFPlanReply.SupersededPlan != "" {
+			terraform.Status.Plan.Diff = &infrav1.PlanDiff{
+				Superseded: saveTFPlanReply.SupersededPlan,
+				Added:      truncateChanges(saveTFPlanReply.AddedChanges),
+				Removed:    truncateChanges(saveTFPlanReply.RemovedChanges),
+			}
+			r.event(ctx, terraform, revision, events.EventSeverityInfo, planDiffMessage(terraform.Status.Plan.Pending, saveTFPlanReply), nil)
+		}
+
+		if terraform.Spec.PullRequestComments != nil && !r.backendCompletelyDisable(terraform) {
+			r.commentPlanOnPullRequests(ctx, terraform, runnerClient, tfInstance, revision)
+		}
 	} else {
 		terraform = infrav1.TerraformPlannedNoChanges(terraform, revision, "Plan no changes")
 	}
 
 	return terraform, nil
 }
+
+// planMetadata returns the metadata of the events of a new plan.
+func planMetadata(planId string, reply *runner.SaveTFPlanReply) map[string]string {
+	return map[string]string{
+		infrav1.EventMetadataPlanIDKey:      planId,
+		infrav1.EventMetadataChangeCountKey: strconv.Itoa(int(reply.ChangeCount)),
+	}
+}
+
+// maxPlanDiffChanges bounds the changes kept in .status.plan.diff, to keep the object small.
+const maxPlanDiffChanges = 50
+
+func truncateChanges(changes []string) []string {
+	if len(changes) <= maxPlanDiffChanges {
+		return changes
+	}
+	truncated := append([]string{}, changes[:maxPlanDiffChanges]...)
+	return append(truncated, fmt.Sprintf("... and %d more", len(changes)-maxPlanDiffChanges))
+}
+
+func planDiffMessage(planId string, reply *runner.SaveTFPlanReply) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Plan %s supersedes %s: %d change(s) added, %d removed", planId, reply.SupersededPlan, len(reply.AddedChanges), len(reply.RemovedChanges))
+	for _, c := range truncateChanges(reply.AddedChanges) {
+		fmt.Fprintf(&sb, "\n+ %s", c)
+	}
+	for _, c := range truncateChanges(reply.RemovedChanges) {
+		fmt.Fprintf(&sb, "\n- %s", c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/weaveworks/tf-controller/runner"
+)
+
+func TestPlanDiffMessage(t *testing.T) {
+	g := NewWithT(t)
+
+	msg := planDiffMessage("plan-main-b8e362c2", &runner.SaveTFPlanReply{
+		SupersededPlan: "plan-main-a1b2c3d4",
+		AddedChanges:   []string{"create aws_s3_bucket.logs"},
+		RemovedChanges: []string{"update aws_s3_bucket.data", "delete aws_iam_role.old"},
+	})
+	g.Expect(msg).To(Equal("Plan plan-main-b8e362c2 supersedes plan-main-a1b2c3d4: 1 change(s) added, 2 removed\n" +
+		"+ create aws_s3_bucket.logs\n" +
+		"- update aws_s3_bucket.data\n" +
+		"- delete aws_iam_role.old"))
+}
+
+func TestTruncateChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	var changes []string
+	for i := 0; i < maxPlanDiffChanges+3; i++ {
+		changes = append(changes, fmt.Sprintf("create null_resource.r[%d]", i))
+	}
+	truncated := truncateChanges(changes)
+	g.Expect(truncated).To(HaveLen(maxPlanDiffChanges + 1))
+	g.Expect(truncated[maxPlanDiffChanges]).To(Equal("... and 3 more"))
+	g.Expect(changes).To(HaveLen(maxPlanDiffChanges + 3))
+	g.Expect(truncateChanges(changes[:2])).To(Equal(changes[:2]))
+}
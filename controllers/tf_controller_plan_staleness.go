@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// shouldCheckPlanStaleness reports whether the pending plan is about to be applied
+// and must be checked against the live state first.
+// Without a backend, the state does not outlive the runner, so there is nothing to compare with.
+func (r *TerraformReconciler) shouldCheckPlanStaleness(terraform infrav1.Terraform) bool {
+	if terraform.Spec.ApplyStrictness != infrav1.ApplyStrictnessState {
+		return false
+	}
+
+	if terraform.Status.Plan.Pending == "" || r.backendCompletelyDisable(terraform) {
+		return false
+	}
+
+	return r.shouldApply(terraform)
+}
+
+// checkPlanStaleness loads the pending plan into the runner and compares the state it was created against
+// with the current state. The returned bool is true when the plan is stale and has been dropped.
+func (r *TerraformReconciler) checkPlanStaleness(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	_, err := runnerClient.LoadTFPlan(ctx, &runner.LoadTFPlanRequest{
+		TfInstance:               tfInstance,
+		Name:                     terraform.Name,
+		Namespace:                terraform.Namespace,
+		BackendCompletelyDisable: r.backendCompletelyDisable(terraform),
+		PendingPlan:              terraform.Status.Plan.Pending,
+	})
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecApplyFailedReason,
+			err.Error(),
+		), false, err
+	}
+
+	reply, err := runnerClient.CheckPlanStaleness(ctx, &runner.CheckPlanStalenessRequest{
+		TfInstance: tfInstance,
+	})
+	if err != nil {
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.TFExecApplyFailedReason,
+			err.Error(),
+		), false, err
+	}
+
+	if !reply.Stale {
+		log.Info(fmt.Sprintf("plan staleness: %s", reply.Message))
+		return terraform, false, nil
+	}
+
+	msg := fmt.Sprintf("Plan %s is stale, %s. Re-planning", terraform.Status.Plan.Pending, reply.Message)
+	log.Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+
+	return infrav1.TerraformPlanStale(terraform, revision, msg), true, nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+func TestShouldCheckPlanStaleness(t *testing.T) {
+	g := NewWithT(t)
+	r := &TerraformReconciler{}
+
+	terraform := infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			ApprovePlan:     "plan-main-abc",
+			ApplyStrictness: infrav1.ApplyStrictnessState,
+		},
+		Status: infrav1.TerraformStatus{
+			Plan: infrav1.PlanStatus{Pending: "plan-main-abc"},
+		},
+	}
+	g.Expect(r.shouldCheckPlanStaleness(terraform)).To(BeTrue())
+
+	notApproved := *terraform.DeepCopy()
+	notApproved.Spec.ApprovePlan = ""
+	g.Expect(r.shouldCheckPlanStaleness(notApproved)).To(BeFalse())
+
+	noPlan := *terraform.DeepCopy()
+	noPlan.Status.Plan.Pending = ""
+	g.Expect(r.shouldCheckPlanStaleness(noPlan)).To(BeFalse())
+
+	lenient := *terraform.DeepCopy()
+	lenient.Spec.ApplyStrictness = infrav1.ApplyStrictnessNone
+	g.Expect(r.shouldCheckPlanStaleness(lenient)).To(BeFalse())
+
+	noBackend := *terraform.DeepCopy()
+	noBackend.Spec.BackendConfig = &infrav1.BackendConfigSpec{Disable: true}
+	g.Expect(r.shouldCheckPlanStaleness(noBackend)).To(BeFalse())
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/rego"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultPolicyQuery is the query of the policy checks without one, returning the messages of the deny rules.
+const defaultPolicyQuery = "data.terraform.deny"
+
+// checkPolicy evaluates a policy check against the JSON of the plan.
+// It returns the messages of the policies denying the plan, prefixed with the name of their ConfigMap.
+func (r *TerraformReconciler) checkPolicy(ctx context.Context, terraform infrav1.Terraform, check infrav1.PolicyCheck, planJSON []byte) ([]string, error) {
+	var input interface{}
+	if err := json.Unmarshal(planJSON, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse plan file: %w", err)
+	}
+
+	var configMap v1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: check.Name}, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get the policies of ConfigMap %s: %w", check.Name, err)
+	}
+	messages, err := evaluatePolicies(ctx, configMap, check.Query, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate the policies of ConfigMap %s: %w", check.Name, err)
+	}
+
+	var denials []string
+	for _, message := range messages {
+		denials = append(denials, fmt.Sprintf("%s: %s", check.Name, message))
+	}
+	return denials, nil
+}
+
+// evaluatePolicies evaluates the query against the input, with the Rego modules of the ConfigMap.
+// An undefined query allows the input.
+func evaluatePolicies(ctx context.Context, configMap v1.ConfigMap, query string, input interface{}) ([]string, error) {
+	if query == "" {
+		query = defaultPolicyQuery
+	}
+
+	var keys []string
+	for key := range configMap.Data {
+		if strings.HasSuffix(key, ".rego") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key ending with .rego")
+	}
+	sort.Strings(keys)
+
+	options := []func(*rego.Rego){rego.Query(query), rego.Input(input)}
+	for _, key := range keys {
+		options = append(options, rego.Module(configMap.Name+"/"+key, configMap.Data[key]))
+	}
+	results, err := rego.New(options...).Eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, result := range results {
+		for _, expression := range result.Expressions {
+			messages = append(messages, policyMessages(expression.Value, query)...)
+		}
+	}
+	sort.Strings(messages)
+	return messages, nil
+}
+
+// policyMessages returns the messages of the value of a query, a set of messages for the deny rules,
+// or true for a boolean rule.
+func policyMessages(value interface{}, query string) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, policyMessages(item, query)...)
+		}
+		return messages
+	case string:
+		return []string{v}
+	case bool:
+		if v {
+			return []string{fmt.Sprintf("denied by %s", query)}
+		}
+		return nil
+	case nil:
+		return nil
+	default:
+		data, _ := json.Marshal(v)
+		return []string{string(data)}
+	}
+}
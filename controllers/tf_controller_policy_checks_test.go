@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const noIAMPolicy = `package terraform
+
+deny[msg] {
+	change := input.resource_changes[_]
+	startswith(change.type, "aws_iam_")
+	change.change.actions[_] != "no-op"
+	msg := sprintf("%s changes IAM", [change.address])
+}
+`
+
+const noDeletePolicy = `package terraform
+
+deny[msg] {
+	change := input.resource_changes[_]
+	change.change.actions[_] == "delete"
+	msg := sprintf("%s is deleted", [change.address])
+}
+`
+
+func TestEvaluatePolicies(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	var input interface{}
+	g.Expect(json.Unmarshal([]byte(resourceLimitsPlanJSON), &input)).To(Succeed())
+
+	configMap := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+		Data: map[string]string{
+			"iam.rego":    noIAMPolicy,
+			"delete.rego": noDeletePolicy,
+			"README.md":   "not a policy",
+		},
+	}
+	messages, err := evaluatePolicies(ctx, configMap, "", input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(messages).To(Equal([]string{
+		"aws_iam_user.old changes IAM",
+		"aws_iam_user.old is deleted",
+		"module.iam.aws_iam_role.r changes IAM",
+	}))
+
+	// a boolean rule denies the plan when it is true, and an undefined query allows it
+	configMap.Data = map[string]string{"size.rego": `package limits
+
+too_many { count(input.resource_changes) > 3 }
+`}
+	messages, err = evaluatePolicies(ctx, configMap, "data.limits.too_many", input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(messages).To(Equal([]string{"denied by data.limits.too_many"}))
+
+	messages, err = evaluatePolicies(ctx, configMap, "", input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(messages).To(BeEmpty())
+
+	configMap.Data = map[string]string{"broken.rego": "package terraform\n\ndeny[msg] {"}
+	_, err = evaluatePolicies(ctx, configMap, "", input)
+	g.Expect(err).To(HaveOccurred())
+
+	configMap.Data = map[string]string{"policy.txt": noIAMPolicy}
+	_, err = evaluatePolicies(ctx, configMap, "", input)
+	g.Expect(err).To(MatchError(ContainSubstring("no key ending with .rego")))
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// getProviderConfigs returns the ProviderConfig objects referred by the Terraform object, in order.
+func (r *TerraformReconciler) getProviderConfigs(ctx context.Context, terraform infrav1.Terraform) ([]infrav1.ProviderConfig, error) {
+	var configs []infrav1.ProviderConfig
+	for _, ref := range terraform.Spec.ProviderConfigRefs {
+		var pc infrav1.ProviderConfig
+		key := types.NamespacedName{Namespace: terraform.Namespace, Name: ref.Name}
+		if err := r.Get(ctx, key, &pc); err != nil {
+			return nil, fmt.Errorf("unable to get provider config '%s': %w", key, err)
+		}
+		configs = append(configs, pc)
+	}
+	return configs, nil
+}
+
+// providerConfigEnvs returns the environment variables holding the credentials of the provider configs.
+func (r *TerraformReconciler) providerConfigEnvs(ctx context.Context, configs []infrav1.ProviderConfig) (map[string]string, error) {
+	envs := map[string]string{}
+	for _, pc := range configs {
+		if pc.Spec.WorkloadIdentity != nil {
+			wiEnvs, _, err := workloadIdentityEnvs(pc)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range wiEnvs {
+				envs[k] = v
+			}
+			continue
+		}
+
+		if pc.Spec.CredentialsSecretRef == nil {
+			continue
+		}
+
+		var secret corev1.Secret
+		key := types.NamespacedName{Namespace: pc.Namespace, Name: pc.Spec.CredentialsSecretRef.Name}
+		if err := r.Get(ctx, key, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get credentials of provider config '%s/%s': %w", pc.Namespace, pc.Name, err)
+		}
+
+		for k, v := range secret.Data {
+			envs[k] = string(v)
+		}
+	}
+	return envs, nil
+}
+
+// providerConfigFileMappings returns the provider override files, and the credential files, of the provider configs.
+func (r *TerraformReconciler) providerConfigFileMappings(ctx context.Context, configs []infrav1.ProviderConfig) ([]*runner.FileMapping, error) {
+	var fileMappings []*runner.FileMapping
+	for _, pc := range configs {
+		override, err := providerOverride(pc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render provider config '%s/%s': %w", pc.Namespace, pc.Name, err)
+		}
+
+		fileMappings = append(fileMappings, &runner.FileMapping{
+			Content:  override,
+			Location: "workspace",
+			Path:     providerOverrideFilename(pc),
+		})
+
+		if pc.Spec.WorkloadIdentity != nil {
+			_, wiFileMappings, err := workloadIdentityEnvs(pc)
+			if err != nil {
+				return nil, err
+			}
+			fileMappings = append(fileMappings, wiFileMappings...)
+		}
+
+		for _, fileMapping := range pc.Spec.CredentialFiles {
+			var secret corev1.Secret
+			key := types.NamespacedName{Namespace: pc.Namespace, Name: fileMapping.SecretRef.Name}
+			if err := r.Get(ctx, key, &secret); err != nil {
+				return nil, fmt.Errorf("unable to get credential files of provider config '%s/%s': %w", pc.Namespace, pc.Name, err)
+			}
+
+			fileMappings = append(fileMappings, &runner.FileMapping{
+				Content:  secret.Data[fileMapping.SecretRef.Key],
+				Location: fileMapping.Location,
+				Path:     fileMapping.Path,
+			})
+		}
+	}
+	return fileMappings, nil
+}
+
+func providerOverrideFilename(pc infrav1.ProviderConfig) string {
+	if pc.Spec.Alias != "" {
+		return fmt.Sprintf("provider_%s_%s_override.tf.json", pc.Spec.Provider, pc.Spec.Alias)
+	}
+	return fmt.Sprintf("provider_%s_override.tf.json", pc.Spec.Provider)
+}
+
+// providerOverride renders the provider config into a provider block, in the JSON syntax
+// of Terraform, to be merged into the provider block declared by the Terraform program.
+func providerOverride(pc infrav1.ProviderConfig) ([]byte, error) {
+	return renderProviderBlock(infrav1.ProviderBlock{
+		Provider: pc.Spec.Provider,
+		Alias:    pc.Spec.Alias,
+		Settings: pc.Spec.Settings,
+	})
+}
+
+func renderProviderBlock(block infrav1.ProviderBlock) ([]byte, error) {
+	settings := map[string]interface{}{}
+	if block.Settings != nil && len(block.Settings.Raw) > 0 {
+		if err := json.Unmarshal(block.Settings.Raw, &settings); err != nil {
+			return nil, fmt.Errorf("settings must be an object: %w", err)
+		}
+	}
+
+	if block.Alias != "" {
+		settings["alias"] = block.Alias
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"provider": map[string]interface{}{
+			block.Provider: settings,
+		},
+	}, "", "  ")
+}
+
+// IndexByProviderConfig indexes the Terraform objects by the ProviderConfig objects they refer to.
+func (r *TerraformReconciler) IndexByProviderConfig(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, ref := range terraform.Spec.ProviderConfigRefs {
+		keys = append(keys, fmt.Sprintf("%s/%s", terraform.GetNamespace(), ref.Name))
+	}
+	return keys
+}
+
+func (r *TerraformReconciler) requestsForProviderConfigChange(obj client.Object) []reconcile.Request {
+	ctx := context.Background()
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.ProviderConfigIndexKey: client.ObjectKeyFromObject(obj).String(),
+	}); err != nil {
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, len(list.Items))
+	for i, t := range list.Items {
+		reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)}
+	}
+	return reqs
+}
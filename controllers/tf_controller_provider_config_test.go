@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestProviderOverride(t *testing.T) {
+	g := NewWithT(t)
+
+	pc := infrav1.ProviderConfig{
+		Spec: infrav1.ProviderConfigSpec{
+			Provider: "aws",
+			Settings: &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu-west-1"}`)},
+		},
+	}
+	override, err := providerOverride(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(override).To(MatchJSON(`{"provider":{"aws":{"region":"eu-west-1"}}}`))
+	g.Expect(providerOverrideFilename(pc)).To(Equal("provider_aws_override.tf.json"))
+
+	pc.Spec.Alias = "us"
+	override, err = providerOverride(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(override).To(MatchJSON(`{"provider":{"aws":{"region":"eu-west-1","alias":"us"}}}`))
+	g.Expect(providerOverrideFilename(pc)).To(Equal("provider_aws_us_override.tf.json"))
+
+	pc.Spec.Settings = &apiextensionsv1.JSON{Raw: []byte(`["not", "an", "object"]`)}
+	_, err = providerOverride(pc)
+	g.Expect(err).ToNot(BeNil())
+
+	pc = infrav1.ProviderConfig{Spec: infrav1.ProviderConfigSpec{Provider: "kubernetes"}}
+	override, err = providerOverride(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(override).To(MatchJSON(`{"provider":{"kubernetes":{}}}`))
+}
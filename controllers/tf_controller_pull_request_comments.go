@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// commentPlanOnPullRequests comments the plan with changes on the open pull requests of the revision.
+// The comments only inform the reviews, so failing to post them does not fail the plan.
+func (r *TerraformReconciler) commentPlanOnPullRequests(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, tfInstance string, revision string) {
+	log := ctrl.LoggerFrom(ctx)
+
+	body, err := r.pullRequestPlanComment(ctx, terraform, runnerClient, tfInstance, revision)
+	if err == nil {
+		err = r.postPullRequestComments(ctx, terraform, revision, body)
+	}
+	if err != nil {
+		log.Error(err, "unable to comment the plan on the pull requests")
+		msg := fmt.Sprintf("Unable to comment the plan on the pull requests: %s", err)
+		r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+	}
+}
+
+// pullRequestPlanComment renders the plan of the runner in Markdown, with the summary of its changes and the plan as a diff.
+func (r *TerraformReconciler) pullRequestPlanComment(ctx context.Context, terraform infrav1.Terraform, runnerClient runner.RunnerClient, tfInstance string, revision string) (string, error) {
+	jsonReply, err := runnerClient.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{TfInstance: tfInstance, Filename: runner.TFPlanName})
+	if err != nil {
+		return "", fmt.Errorf("failed to get plan file: %w", err)
+	}
+	var plan tfjson.Plan
+	if err := json.Unmarshal(jsonReply.JsonOutput, &plan); err != nil {
+		return "", fmt.Errorf("failed to parse plan file: %w", err)
+	}
+
+	rawReply, err := runnerClient.ShowPlanFileRaw(ctx, &runner.ShowPlanFileRawRequest{TfInstance: tfInstance, Filename: runner.TFPlanName})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the readable plan: %w", err)
+	}
+	return planComment(terraform, revision, &plan, rawReply.RawOutput), nil
+}
+
+// planComment returns the comment of a plan, with the human readable plan truncated to fit in a comment.
+func planComment(terraform infrav1.Terraform, revision string, plan *tfjson.Plan, human string) string {
+	header := fmt.Sprintf("Plan of `%s/%s` at `%s`", terraform.Namespace, terraform.Name, revision)
+	if terraform.Status.Plan.Pending != "" && !terraform.Spec.Force && terraform.Spec.ApprovePlan != infrav1.ApprovePlanAutoValue {
+		header += fmt.Sprintf(", approve it with `approvePlan: %s`", terraform.Status.Plan.Pending)
+	}
+	header += ".\n\n"
+
+	budget := int64(maxPullRequestCommentLength - len(header) - len(utils.PlanMarkdown(plan, "")))
+	if budget < 1 {
+		budget = 1
+	}
+	human, _ = utils.TruncateText(human, budget)
+	return header + utils.PlanMarkdown(plan, human)
+}
+
+// postPullRequestComments posts the comment on the open pull requests whose head is the commit of the revision.
+func (r *TerraformReconciler) postPullRequestComments(ctx context.Context, terraform infrav1.Terraform, revision string, body string) error {
+	provider, numbers, err := r.pullRequestsOfRevision(ctx, terraform, revision)
+	if err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		if err := provider.Comment(ctx, number, body); err != nil {
+			return fmt.Errorf("unable to comment pull request %d: %w", number, err)
+		}
+		ctrl.LoggerFrom(ctx).Info("commented the plan on the pull request", "pullRequest", number)
+	}
+	return nil
+}
+
+// pullRequestsOfRevision returns the provider of the pull requests of the object, and the open pull requests whose head
+// is the commit of the revision.
+func (r *TerraformReconciler) pullRequestsOfRevision(ctx context.Context, terraform infrav1.Terraform, revision string) (pullRequestProvider, []int, error) {
+	provider, err := r.sourcePullRequestProvider(ctx, terraform)
+	if err != nil {
+		return nil, nil, err
+	}
+	numbers, err := pullRequestsOf(ctx, provider, revision)
+	return provider, numbers, err
+}
+
+func pullRequestsOf(ctx context.Context, provider pullRequestProvider, revision string) ([]int, error) {
+	sha := infrav1.ParseRevision(revision).Digest
+	numbers, err := provider.PullRequestsOf(ctx, sha)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the pull requests of %s: %w", sha, err)
+	}
+	return numbers, nil
+}
+
+// sourcePullRequestProvider returns the provider of the pull requests of the GitRepository of the object.
+func (r *TerraformReconciler) sourcePullRequestProvider(ctx context.Context, terraform infrav1.Terraform) (pullRequestProvider, error) {
+	spec := terraform.Spec.PullRequestComments
+	if terraform.Spec.SourceRef.Kind != sourcev1.GitRepositoryKind {
+		return nil, fmt.Errorf("the plans can only be commented for a GitRepository source, not a %s", terraform.Spec.SourceRef.Kind)
+	}
+
+	sourceRef := types.NamespacedName{Namespace: terraform.Spec.SourceRef.Namespace, Name: terraform.Spec.SourceRef.Name}
+	if sourceRef.Namespace == "" {
+		sourceRef.Namespace = terraform.Namespace
+	}
+	var repository sourcev1.GitRepository
+	if err := r.Get(ctx, sourceRef, &repository); err != nil {
+		return nil, err
+	}
+	return newPullRequestProvider(ctx, r.Client, nil, terraform.Namespace, spec.Provider, spec.SecretRef, spec.APIURL, repository.Spec.URL)
+}
+
+// pullRequestApprovesPlans reports whether the approvals of the merge requests approve the plans of the object.
+func pullRequestApprovesPlans(terraform infrav1.Terraform) bool {
+	return terraform.Spec.PullRequestComments != nil && terraform.Spec.PullRequestComments.ApprovePlanOnApproval
+}
+
+// checkPullRequestApproval records in the status the approval of the pending plan by the approvers
+// of an open merge request of its revision. The plan stays pending while none is approved.
+func (r *TerraformReconciler) checkPullRequestApproval(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	if !pullRequestApprovesPlans(terraform) || terraform.Status.Plan.Pending == "" || terraform.Status.Plan.ApprovedBy != "" {
+		return terraform, nil
+	}
+
+	provider, err := r.sourcePullRequestProvider(ctx, terraform)
+	if err != nil {
+		return terraform, err
+	}
+	approvals, ok := provider.(pullRequestApprovals)
+	if !ok {
+		return terraform, fmt.Errorf("the approvals of the %s pull requests can't approve the plans", terraform.Spec.PullRequestComments.Provider)
+	}
+	revision := terraform.Status.LastPlannedRevision
+	numbers, err := pullRequestsOf(ctx, provider, revision)
+	if err != nil {
+		return terraform, err
+	}
+
+	for _, number := range numbers {
+		users, err := approvals.ApprovedBy(ctx, number)
+		if err != nil {
+			return terraform, fmt.Errorf("unable to get the approvals of merge request !%d: %w", number, err)
+		}
+		if len(users) == 0 {
+			continue
+		}
+		terraform.Status.Plan.ApprovedBy = fmt.Sprintf("merge request !%d, approved by %s", number, strings.Join(users, ", "))
+		msg := fmt.Sprintf("Plan %s approved by the approval of %s", terraform.Status.Plan.Pending, terraform.Status.Plan.ApprovedBy)
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+		return terraform, nil
+	}
+	return terraform, nil
+}
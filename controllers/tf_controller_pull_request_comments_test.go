@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlanComment(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "infra"}}
+	terraform.Status.Plan.Pending = "plan-main-b8e362c206"
+	plan := &tfjson.Plan{ResourceChanges: []*tfjson.ResourceChange{
+		{Address: "aws_s3_bucket.logs", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}}},
+	}}
+
+	comment := planComment(terraform, "main@sha1:b8e362c206", plan, "  # aws_s3_bucket.logs will be created\n  + resource \"aws_s3_bucket\" \"logs\" {}\n")
+	g.Expect(comment).To(HavePrefix("Plan of `flux-system/infra` at `main@sha1:b8e362c206`, approve it with `approvePlan: plan-main-b8e362c206`."))
+	g.Expect(comment).To(ContainSubstring("Plan: **1** to add, **0** to change, **0** to destroy."))
+	g.Expect(comment).To(ContainSubstring(`+   resource "aws_s3_bucket" "logs" {}`))
+
+	// no approval needed for the plans applied automatically
+	terraform.Spec.ApprovePlan = infrav1.ApprovePlanAutoValue
+	g.Expect(planComment(terraform, "main@sha1:b8e362c206", plan, "")).NotTo(ContainSubstring("approve it"))
+
+	// a large plan is truncated, keeping the diff block closed
+	comment = planComment(terraform, "main@sha1:b8e362c206", plan, strings.Repeat("  + tags = {}\n", 10000))
+	g.Expect(len(comment)).To(BeNumerically("<=", maxPullRequestCommentLength))
+	g.Expect(comment).To(ContainSubstring("... Truncated"))
+	g.Expect(comment).To(HaveSuffix("```\n\n</details>\n"))
+}
+
+func TestPostPullRequestComments(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	gitHub := &fakeGitHub{
+		pullRequests: []map[string]interface{}{gitHubPullRequestJSON(7, "feature", "weaveworks/infra")},
+		comments:     map[string][]string{},
+	}
+	server := httptest.NewServer(gitHub)
+	defer server.Close()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "infra"},
+		Spec:       sourcev1.GitRepositorySpec{URL: "ssh://git@github.com/weaveworks/infra.git"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "github-token"},
+		Data:       map[string][]byte{infrav1.BranchPlannerTokenKey: []byte("s3cr3t")},
+	}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(repository, secret).Build()}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "infra"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "infra"},
+			PullRequestComments: &infrav1.PullRequestCommentsSpec{
+				SecretRef: meta.LocalObjectReference{Name: "github-token"},
+				APIURL:    server.URL,
+			},
+		},
+	}
+
+	g.Expect(r.postPullRequestComments(ctx, terraform, "feature@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb", "the plan")).To(Succeed())
+	g.Expect(gitHub.comments["/repos/weaveworks/infra/issues/7/comments"]).To(Equal([]string{"the plan"}))
+
+	// the commit is no longer the head of the pull request
+	g.Expect(r.postPullRequestComments(ctx, terraform, "feature@sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738", "stale plan")).To(Succeed())
+	g.Expect(gitHub.comments["/repos/weaveworks/infra/issues/7/comments"]).To(HaveLen(1))
+
+	terraform.Spec.SourceRef.Kind = sourcev1.BucketKind
+	g.Expect(r.postPullRequestComments(ctx, terraform, "sha256:b8e362c206", "the plan")).To(MatchError(ContainSubstring("GitRepository")))
+}
+
+type fakeGitLab struct {
+	approved bool
+	comments map[string][]string
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("PRIVATE-TOKEN") != "s3cr3t" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	const project = "/api/v4/projects/infra%2Fnetwork"
+	switch path := r.URL.EscapedPath(); {
+	case r.Method == http.MethodGet && path == project+"/repository/commits/b8e362c206e3d0cbb7ed22ced771a0056455a2fb/merge_requests":
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"iid": 12, "state": "opened", "sha": "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"},
+			{"iid": 3, "state": "merged", "sha": "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"},
+		})
+	case r.Method == http.MethodGet && path == project+"/merge_requests/12/approvals":
+		approvals := map[string]interface{}{"approved": f.approved, "approved_by": []interface{}{}}
+		if f.approved {
+			approvals["approved_by"] = []map[string]interface{}{{"user": map[string]string{"username": "alice"}}}
+		}
+		json.NewEncoder(w).Encode(approvals)
+	case r.Method == http.MethodPost && path == project+"/merge_requests/12/notes":
+		var body struct {
+			Body string `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.comments[path] = append(f.comments[path], body.Body)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGitLabMergeRequestApproval(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	gitLab := &fakeGitLab{comments: map[string][]string{}}
+	server := httptest.NewServer(gitLab)
+	defer server.Close()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	repository := &sourcev1.GitRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "network"},
+		Spec:       sourcev1.GitRepositorySpec{URL: "https://gitlab.example.com/infra/network.git"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "gitlab-token"},
+		Data:       map[string][]byte{infrav1.BranchPlannerTokenKey: []byte("s3cr3t")},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(repository, secret).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	revision := "feature@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "network"},
+		Spec: infrav1.TerraformSpec{
+			SourceRef: infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "network"},
+			PullRequestComments: &infrav1.PullRequestCommentsSpec{
+				Provider:              "gitlab",
+				SecretRef:             meta.LocalObjectReference{Name: "gitlab-token"},
+				APIURL:                server.URL + "/api/v4/",
+				ApprovePlanOnApproval: true,
+			},
+		},
+	}
+	terraform = infrav1.TerraformPlannedWithChanges(terraform, revision, false, "Plan generated")
+
+	// only the open merge request is commented
+	g.Expect(r.postPullRequestComments(ctx, terraform, revision, "the plan")).To(Succeed())
+	g.Expect(gitLab.comments).To(HaveKeyWithValue("/api/v4/projects/infra%2Fnetwork/merge_requests/12/notes", []string{"the plan"}))
+
+	terraform, err := r.checkPullRequestApproval(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(terraform.Status.Plan.ApprovedBy).To(BeEmpty())
+	g.Expect(r.shouldApply(terraform)).To(BeFalse())
+
+	gitLab.approved = true
+	terraform, err = r.checkPullRequestApproval(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(terraform.Status.Plan.ApprovedBy).To(Equal("merge request !12, approved by alice"))
+	g.Expect(r.shouldApply(terraform)).To(BeTrue())
+
+	// the approval is of the pending plan only
+	terraform = infrav1.TerraformPlannedWithChanges(terraform, "feature@sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738", false, "Plan generated")
+	g.Expect(r.shouldApply(terraform)).To(BeFalse())
+
+	// GitHub pull request approvals don't approve the plans
+	terraform.Spec.PullRequestComments.Provider = "github"
+	terraform.Status.LastPlannedRevision = revision
+	_, err = r.checkPullRequestApproval(ctx, terraform)
+	g.Expect(err).To(MatchError(ContainSubstring("can't approve the plans")))
+}
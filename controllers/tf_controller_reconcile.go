@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
@@ -29,7 +30,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, sourceObj sourcev1.Source, reconciliationLoopID string) (*infrav1.Terraform, error) {
+func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner.RunnerClient, terraform infrav1.Terraform, sourceObj sourcev1.Source, reconciliationLoopID string) (_ *infrav1.Terraform, retErr error) {
 	log := ctrl.LoggerFrom(ctx)
 	revision := sourceObj.GetArtifact().Revision
 	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
@@ -47,6 +48,16 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 	lastKnownAction = "Setup"
 
 	defer func() {
+		// a persistent working directory is reused by the next reconciliation
+		if terraform.Spec.HasPersistentWorkingDir() {
+			return
+		}
+		// the working directory of a failed reconciliation is kept with its runner pod
+		if retErr != nil && keepFailedRunnerPodFor(terraform) > 0 {
+			log.Info("keeping the working directory of the failed reconciliation", "dir", tmpDir)
+			return
+		}
+
 		cleanupDirReply, err := runnerClient.CleanupDir(ctx, &runner.CleanupDirRequest{TmpDir: tmpDir})
 		if err != nil {
 			log.Error(err, "clean up error")
@@ -62,6 +73,17 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 		return &terraform, err
 	}
 
+	// a snapshot of the state is restored before anything is planned against the state
+	if shouldRestoreState(terraform) {
+		terraform, err = r.restoreState(ctx, terraform, tfInstance, runnerClient, revision)
+		if err != nil {
+			log.Error(err, "error restoring the state")
+			return &terraform, err
+		}
+
+		lastKnownAction = "State Restored"
+	}
+
 	if r.shouldDetectDrift(terraform, revision) {
 		var driftDetectionErr error // declared here to avoid shadowing on terraform variable
 		terraform, driftDetectionErr = r.detectDrift(ctx, terraform, tfInstance, runnerClient, revision)
@@ -110,38 +132,51 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 		return &terraform, nil
 	}
 
-	// if we should plan this Terraform CR, do so
-	if r.shouldPlan(terraform) {
-		terraform, err = r.plan(ctx, terraform, tfInstance, runnerClient, revision)
+	// drop an approved plan that no longer matches the live state, so that it gets re-planned
+	if r.shouldCheckPlanStaleness(terraform) {
+		var stale bool
+		terraform, stale, err = r.checkPlanStaleness(ctx, terraform, tfInstance, runnerClient, revision)
 		if err != nil {
-			log.Error(err, "error planning")
+			log.Error(err, "error checking plan staleness")
 			return &terraform, err
 		}
 
-		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
-			log.Error(err, "unable to update status after planing")
-			return &terraform, err
+		if stale {
+			if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+				log.Error(err, "unable to update status after checking plan staleness")
+				return &terraform, err
+			}
 		}
 
-		lastKnownAction = "Planned"
+		lastKnownAction = "Plan Staleness Checked"
 	}
 
-	// if we should apply the generated plan, do so
-	if r.shouldApply(terraform) {
-		terraform, err = r.apply(ctx, terraform, tfInstance, runnerClient, revision)
+	// if we should plan this Terraform CR, do so
+	if r.shouldPlan(terraform) {
+		terraform, err = r.plan(ctx, terraform, tfInstance, runnerClient, revision)
 		if err != nil {
-			log.Error(err, "error applying")
+			log.Error(err, "error planning")
+			if r.shouldSnapshotWorkingDir(terraform) {
+				terraform = r.snapshotWorkingDir(ctx, terraform, tfInstance, tmpDir, runnerClient, revision, runner.StagePlan, err, time.Now())
+			}
 			return &terraform, err
 		}
 
 		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
-			log.Error(err, "unable to update status after applying")
+			log.Error(err, "unable to update status after planing")
 			return &terraform, err
 		}
 
+		lastKnownAction = "Planned"
+	}
+
+	var applied bool
+	terraform, applied, err = r.applyOrBlock(ctx, terraform, tfInstance, tmpDir, runnerClient, revision)
+	if err != nil {
+		return &terraform, err
+	}
+	if applied {
 		lastKnownAction = "Applied"
-	} else {
-		log.Info("should apply == false")
 	}
 
 	terraform, err = r.processOutputs(ctx, runnerClient, terraform, tfInstance, revision)
@@ -153,7 +188,11 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 
 	if r.shouldDoHealthChecks(terraform) {
 
-		terraform, err = r.doHealthChecks(ctx, terraform, revision, runnerClient)
+		terraform, err = r.runChecks(ctx, terraform, infrav1.CheckStagePostApply, &checkEnv{
+			runnerClient: runnerClient,
+			tfInstance:   tfInstance,
+			revision:     revision,
+		})
 		if err != nil {
 			log.Error(err, "error with health check")
 			return &terraform, err
@@ -186,3 +225,55 @@ func (r *TerraformReconciler) reconcile(ctx context.Context, runnerClient runner
 
 	return &terraform, nil
 }
+
+// applyOrBlock applies the pending plan when it should be, unless the apply is blocked, e.g. suspended
+// or during a change freeze, in which case the plan is kept pending and the Apply condition tells why.
+// The returned bool is true when the plan has been applied.
+func (r *TerraformReconciler) applyOrBlock(ctx context.Context, terraform infrav1.Terraform, tfInstance string, tmpDir string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	// keep the plan pending while the apply is suspended, or during a change freeze
+	var (
+		blockedReason, blockedMessage string
+		err                           error
+	)
+	if r.shouldApply(terraform) {
+		blockedReason, blockedMessage, err = r.applyBlocked(ctx, terraform)
+		if err != nil {
+			log.Error(err, "error checking whether the apply is blocked")
+			return terraform, false, err
+		}
+	}
+
+	// if we should apply the generated plan, do so
+	if blockedReason != "" {
+		log.Info(blockedMessage)
+		terraform = infrav1.TerraformApplyBlocked(terraform, revision, blockedReason, blockedMessage)
+
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			log.Error(err, "unable to update status after blocking the apply")
+			return terraform, false, err
+		}
+	} else if r.shouldApply(terraform) {
+		terraform, err = r.apply(ctx, terraform, tfInstance, runnerClient, revision)
+		if err != nil {
+			log.Error(err, "error applying")
+			if r.shouldSnapshotWorkingDir(terraform) {
+				terraform = r.snapshotWorkingDir(ctx, terraform, tfInstance, tmpDir, runnerClient, revision, runner.StageApply, err, time.Now())
+			}
+			return terraform, false, err
+		}
+
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			log.Error(err, "unable to update status after applying")
+			return terraform, false, err
+		}
+
+		return terraform, true, nil
+	} else {
+		log.Info("should apply == false")
+	}
+
+	return terraform, false, nil
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"context"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordReconcileRequest sets .status.lastHandledReconcileAt, without touching the rest of the status
+// which may have been patched by the reconciliation.
+func (r *TerraformReconciler) recordReconcileRequest(ctx context.Context, objectKey types.NamespacedName, requestedAt string) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var terraform infrav1.Terraform
+	if err := r.Get(ctx, objectKey, &terraform); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to record the handled reconcile request")
+		}
+		return
+	}
+	if terraform.Status.GetLastHandledReconcileRequest() == requestedAt {
+		return
+	}
+
+	patch := client.MergeFrom(terraform.DeepCopy())
+	terraform.Status.SetLastHandledReconcileRequest(requestedAt)
+	if err := r.Status().Patch(ctx, &terraform, patch, client.FieldOwner(r.statusManager)); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "unable to record the handled reconcile request")
+	}
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRecordReconcileRequest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Annotations: map[string]string{
+			meta.ReconcileRequestAnnotation: "2022-10-01T10:00:00Z",
+		}},
+		Status: infrav1.TerraformStatus{
+			ReconcileRequestStatus: meta.ReconcileRequestStatus{LastHandledReconcileAt: "2022-09-30T10:00:00Z"},
+			LastAppliedRevision:    "main/abc",
+		},
+	}
+	r := &TerraformReconciler{
+		Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform).Build(),
+	}
+
+	r.recordReconcileRequest(ctx, key, "2022-10-01T10:00:00Z")
+
+	var stored infrav1.Terraform
+	g.Expect(r.Get(ctx, key, &stored)).To(Succeed())
+	g.Expect(stored.Status.LastHandledReconcileAt).To(Equal("2022-10-01T10:00:00Z"))
+	g.Expect(stored.Status.LastAppliedRevision).To(Equal("main/abc"))
+
+	// the objects deleted by the reconciliation are left alone
+	r.recordReconcileRequest(ctx, types.NamespacedName{Namespace: "flux-system", Name: "deleted"}, "2022-10-01T10:00:00Z")
+}
@@ -0,0 +1,491 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// remoteRunPollInterval is the interval of the polls of a run in progress in Terraform Cloud.
+	remoteRunPollInterval = 15 * time.Second
+	// remoteLogTailLines and remoteLogTailLength bound the tail of the logs of the runs reported in the events.
+	remoteLogTailLines  = 20
+	remoteLogTailLength = 2048
+)
+
+var ansiEscapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+// reconcileRemote reconciles an object of the remote backend, whose plans and applies run in a workspace
+// of Terraform Cloud or Terraform Enterprise instead of a runner pod. Every reconciliation moves the run
+// of the workspace one step further, and is requeued while the run is in progress.
+func (r *TerraformReconciler) reconcileRemote(ctx context.Context, terraform infrav1.Terraform, sourceObj sourcev1.Source) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	revision := sourceObj.GetArtifact().Revision
+	spec := terraform.Spec.BackendConfig.Remote
+
+	c, workspaceID, err := r.remoteWorkspace(ctx, terraform)
+	if err != nil {
+		return r.remoteFailed(ctx, terraform, revision, err)
+	}
+
+	if run := terraform.Status.RemoteRun; run != nil && run.FinishedAt == nil {
+		var waiting, blocked bool
+		terraform, waiting, blocked, err = r.pollRemoteRun(ctx, c, terraform, workspaceID, revision)
+		if err != nil {
+			return r.remoteFailed(ctx, terraform, revision, err)
+		}
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			log.Error(err, "unable to update status after polling the remote run")
+			return ctrl.Result{Requeue: true}, err
+		}
+		r.recordReadinessMetric(ctx, terraform)
+
+		if blocked {
+			// the run stays confirmable, it is confirmed once the apply is no longer blocked
+			return r.retryResult(terraform), nil
+		}
+		if waiting {
+			// the approval of the plan triggers a reconciliation, the run is only checked at the interval meanwhile
+			log.Info("reconciliation is stopped to wait for a manual approve", "run", terraform.Status.RemoteRun.ID)
+			return ctrl.Result{RequeueAfter: terraform.Spec.Interval.Duration}, nil
+		}
+		if terraform.Status.RemoteRun.FinishedAt == nil {
+			return ctrl.Result{RequeueAfter: remoteRunPollInterval}, nil
+		}
+	}
+
+	run := terraform.Status.RemoteRun
+	if isBeingDeleted(terraform) {
+		destroyed := run != nil && run.IsDestroy && (run.Status == remoteRunApplied || run.Status == remoteRunPlannedNoApply)
+		if terraform.Spec.DestroyResourcesOnDeletion && !terraform.Spec.PlanOnly && !destroyed {
+			if run != nil && run.IsDestroy {
+				// the destroy run failed, it is retried
+				if wait := time.Until(run.FinishedAt.Add(r.retryInterval(terraform))); wait > 0 {
+					return ctrl.Result{RequeueAfter: wait}, nil
+				}
+			}
+			return r.startRemoteRun(ctx, c, terraform, workspaceID, sourceObj, true)
+		}
+		log.Info("finalizing the remote backend", "organization", spec.Organization, "workspace", terraform.RemoteWorkspaceName())
+		if err := r.deleteRemoteOutputs(ctx, terraform); err != nil {
+			log.Error(err, "unable to delete the outputs")
+			return ctrl.Result{Requeue: true}, err
+		}
+		return r.removeFinalizers(ctx, terraform)
+	}
+
+	if wait, start := r.remoteRunWait(terraform, revision); !start {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	return r.startRemoteRun(ctx, c, terraform, workspaceID, sourceObj, terraform.Spec.Destroy)
+}
+
+// remoteRunWait returns whether to start a new run, or how long to wait before starting it, 0 for a change.
+// A new revision is planned at once, a failed run is retried at the retry interval, and the last revision
+// is planned again at the interval for its drift to be detected. An object reconciled manually starts a new run
+// of the last revision on request only.
+func (r *TerraformReconciler) remoteRunWait(terraform infrav1.Terraform, revision string) (time.Duration, bool) {
+	run := terraform.Status.RemoteRun
+	if run == nil || run.FinishedAt == nil || run.Revision != revision {
+		return 0, true
+	}
+	if terraform.IsManualReconciliation() {
+		return 0, reconcileRequested(terraform)
+	}
+	interval := terraform.Spec.Interval.Duration
+	if run.Status != remoteRunApplied && run.Status != remoteRunPlannedNoApply {
+		interval = r.retryInterval(terraform)
+	} else if terraform.Spec.DisableDriftDetection {
+		return 0, false
+	}
+	if wait := time.Until(run.FinishedAt.Add(interval)); wait > 0 {
+		return wait, false
+	}
+	return 0, true
+}
+
+// remoteWorkspace returns the client of the API of the remote backend, and the id of the workspace of the object,
+// created when it does not exist.
+func (r *TerraformReconciler) remoteWorkspace(ctx context.Context, terraform infrav1.Terraform) (*remoteClient, string, error) {
+	spec := terraform.Spec.BackendConfig.Remote
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: spec.TokenSecretRef.Name}, &secret); err != nil {
+		return nil, "", fmt.Errorf("unable to get the secret of the token of the remote backend: %w", err)
+	}
+	token := strings.TrimSpace(string(secret.Data[spec.TokenSecretRef.Key]))
+	if token == "" {
+		return nil, "", fmt.Errorf("secret %s has no %s key", spec.TokenSecretRef.Name, spec.TokenSecretRef.Key)
+	}
+
+	c := newRemoteClient(r.remoteHTTPClient, spec.Hostname, token)
+	name := terraform.RemoteWorkspaceName()
+	workspace, err := c.getWorkspace(ctx, spec.Organization, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if workspace == nil {
+		workspace, err = c.createWorkspace(ctx, spec.Organization, name, remoteWorkingDirectory(terraform.Spec.Path))
+		if err != nil {
+			return nil, "", err
+		}
+		r.event(ctx, terraform, "", events.EventSeverityInfo,
+			fmt.Sprintf("Created the workspace %s of organization %s", name, spec.Organization), nil)
+	}
+	return c, workspace.ID, nil
+}
+
+// remoteWorkingDirectory returns the working directory of the workspace, the path of the configuration in the source.
+func remoteWorkingDirectory(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+// startRemoteRun uploads the artifact of the source as a new configuration version of the workspace, and queues its run.
+func (r *TerraformReconciler) startRemoteRun(ctx context.Context, c *remoteClient, terraform infrav1.Terraform, workspaceID string, sourceObj sourcev1.Source, isDestroy bool) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	revision := sourceObj.GetArtifact().Revision
+
+	variables, err := r.remoteVariables(ctx, c, terraform, workspaceID)
+	if err != nil {
+		return r.remoteFailed(ctx, terraform, revision, err)
+	}
+
+	// the artifact is verified before it is uploaded, without holding it in memory
+	tarball, size, err := r.downloadArtifact(sourceObj.GetArtifact())
+	if err != nil {
+		return r.remoteFailed(ctx, terraform, revision, err)
+	}
+	defer func() {
+		tarball.Close()
+		os.Remove(tarball.Name())
+	}()
+
+	configurationVersionID, err := c.uploadConfiguration(ctx, workspaceID, tarball, size)
+	if err != nil {
+		return r.remoteFailed(ctx, terraform, revision, err)
+	}
+
+	message := fmt.Sprintf("Triggered by %s/%s at %s", terraform.Namespace, terraform.Name, revision)
+	run, err := c.createRun(ctx, workspaceID, configurationVersionID, message, isDestroy, variables)
+	if err != nil {
+		return r.remoteFailed(ctx, terraform, revision, err)
+	}
+	log.Info("started a remote run", "run", run.ID, "destroy", isDestroy)
+
+	terraform.Status.RemoteRun = &infrav1.RemoteRunStatus{
+		ID:        run.ID,
+		Status:    run.Status,
+		Revision:  revision,
+		URL:       c.runURL(terraform.Spec.BackendConfig.Remote.Organization, terraform.RemoteWorkspaceName(), run.ID),
+		IsDestroy: isDestroy,
+	}
+	terraform.Status.LastAttemptedRevision = revision
+	terraform = infrav1.TerraformProgressing(terraform, fmt.Sprintf("Run %s of the remote backend is %s", run.ID, run.Status))
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		log.Error(err, "unable to update status after starting the remote run")
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{RequeueAfter: remoteRunPollInterval}, nil
+}
+
+// remoteVariables returns the variables of the run, resolved like the runner does. The variables read from Secrets
+// are set as the sensitive variables of the workspace instead, as the variables of the runs can't be sensitive.
+func (r *TerraformReconciler) remoteVariables(ctx context.Context, c *remoteClient, terraform infrav1.Terraform, workspaceID string) ([]remoteVariable, error) {
+	server := &runner.TerraformRunnerServer{Client: r.Client, InstanceID: "controller"}
+	vars, sensitive, err := server.InputVariables(ctx, terraform)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the variables: %w", err)
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var variables, sensitiveVariables []remoteVariable
+	for _, name := range names {
+		// the values of the variables are HCL expressions, of which JSON is a subset
+		value := "null"
+		if vars[name] != nil {
+			value = string(vars[name].Raw)
+		}
+		if sensitive[name] {
+			sensitiveVariables = append(sensitiveVariables, remoteVariable{Key: name, Value: value})
+		} else {
+			variables = append(variables, remoteVariable{Key: name, Value: value})
+		}
+	}
+
+	description := fmt.Sprintf("Set by tf-controller from the Secrets of %s/%s", terraform.Namespace, terraform.Name)
+	if err := c.setSensitiveVariables(ctx, workspaceID, description, sensitiveVariables); err != nil {
+		return nil, fmt.Errorf("unable to set the sensitive variables of the workspace: %w", err)
+	}
+	return variables, nil
+}
+
+// pollRemoteRun records the status of the run in progress. It reports the plan of a run waiting for its confirmation,
+// which is confirmed once the plan is approved, unless the apply is blocked, and the result of a finished run, writing
+// the outputs of an apply. It returns true while the plan waits to be approved, and true when its apply is blocked.
+func (r *TerraformReconciler) pollRemoteRun(ctx context.Context, c *remoteClient, terraform infrav1.Terraform, workspaceID string, currentRevision string) (infrav1.Terraform, bool, bool, error) {
+	status := terraform.Status.RemoteRun.DeepCopy()
+	terraform.Status.RemoteRun = status
+	revision := status.Revision
+
+	run, err := c.getRun(ctx, status.ID)
+	if err != nil {
+		return terraform, false, false, err
+	}
+	previousStatus := status.Status
+	status.Status = run.Status
+
+	switch {
+	case run.IsConfirmable:
+		// a plan of a previous revision is never applied, nor a plan other than the destroy one after the deletion
+		if isBeingDeleted(terraform) && !run.IsDestroy || !isBeingDeleted(terraform) && revision != currentRevision {
+			if err := c.discardRun(ctx, run.ID, fmt.Sprintf("Superseded by revision %s", currentRevision)); err != nil {
+				return terraform, false, false, err
+			}
+			status.Status = remoteRunDiscarded
+			status.FinishedAt = &metav1.Time{Time: time.Now()}
+			terraform.Status.Plan.Pending = ""
+			return terraform, false, false, nil
+		}
+
+		if previousStatus != run.Status {
+			plan, err := c.getPlan(ctx, run.PlanID)
+			if err != nil {
+				return terraform, false, false, err
+			}
+			terraform = r.remotePlanned(ctx, c, terraform, run, plan, revision)
+		}
+
+		approved := r.shouldApply(terraform)
+		if run.IsDestroy && isBeingDeleted(terraform) {
+			approved = r.forceOrAutoApply(terraform) || (infrav1.IsDestroyPlanId(terraform.Status.Plan.Pending) && r.shouldApply(terraform))
+		}
+		if !approved {
+			return terraform, true, false, nil
+		}
+
+		// keep the plan pending while the apply is suspended, or during a change freeze
+		blockedReason, blockedMessage, err := r.applyBlocked(ctx, terraform)
+		if err != nil {
+			return terraform, false, false, err
+		}
+		if blockedReason != "" {
+			ctrl.LoggerFrom(ctx).Info(blockedMessage)
+			return infrav1.TerraformApplyBlocked(terraform, revision, blockedReason, blockedMessage), false, true, nil
+		}
+
+		// the token is recorded with the status of the apply, so that it is used only once
+		r.useBreakGlassToken(ctx, &terraform, revision)
+		if err := c.applyRun(ctx, run.ID, fmt.Sprintf("Plan %s approved", terraform.Status.Plan.Pending)); err != nil {
+			return terraform, false, false, err
+		}
+		return infrav1.TerraformProgressing(terraform, "Applying"), false, false, nil
+
+	case !run.IsFinal():
+		return infrav1.TerraformProgressing(terraform, fmt.Sprintf("Run %s of the remote backend is %s", run.ID, run.Status)), false, false, nil
+	}
+
+	status.FinishedAt = &metav1.Time{Time: time.Now()}
+	switch run.Status {
+	case remoteRunApplied:
+		apply, err := c.getApply(ctx, run.ApplyID)
+		if err != nil {
+			return terraform, false, false, err
+		}
+		msg := "Applied successfully"
+		if run.IsDestroy {
+			msg = "Destroy applied successfully"
+		}
+		terraform = infrav1.TerraformApplied(terraform, revision, msg, run.IsDestroy, nil)
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, fmt.Sprintf("%s, %d added, %d changed, %d destroyed.\n%s",
+			msg, apply.Additions, apply.Changes, apply.Destructions, r.remoteLogTail(ctx, c, apply.LogReadURL)), nil)
+		if !run.IsDestroy {
+			terraform, err = r.syncRemoteOutputs(ctx, c, terraform, workspaceID, revision)
+		}
+		return terraform, false, false, err
+
+	case remoteRunPlannedNoApply:
+		terraform = infrav1.TerraformPlannedNoChanges(terraform, revision, "Plan no changes")
+		if !run.IsDestroy {
+			// the outputs of the state are written when the object adopts an existing workspace
+			terraform, err = r.syncRemoteOutputs(ctx, c, terraform, workspaceID, revision)
+		}
+		return terraform, false, false, err
+	}
+
+	logReadURL := ""
+	if run.ApplyID != "" {
+		if apply, err := c.getApply(ctx, run.ApplyID); err == nil && apply.LogReadURL != "" {
+			logReadURL = apply.LogReadURL
+		}
+	}
+	if logReadURL == "" {
+		if plan, err := c.getPlan(ctx, run.PlanID); err == nil {
+			logReadURL = plan.LogReadURL
+		}
+	}
+	msg := fmt.Sprintf("Run %s of the remote backend %s: %s\n%s", run.ID, run.Status, status.URL, r.remoteLogTail(ctx, c, logReadURL))
+	terraform.Status.Plan.Pending = ""
+	terraform = infrav1.TerraformNotReady(terraform, revision, infrav1.RemoteRunFailedReason, msg)
+	r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+	return terraform, false, false, nil
+}
+
+// remotePlanned records the plan of a run waiting for its confirmation as the pending plan of the object.
+func (r *TerraformReconciler) remotePlanned(ctx context.Context, c *remoteClient, terraform infrav1.Terraform, run remoteRun, plan remoteChanges, revision string) infrav1.Terraform {
+	tail := r.remoteLogTail(ctx, c, plan.LogReadURL)
+	metadata := map[string]string{
+		infrav1.EventMetadataChangeCountKey: fmt.Sprint(plan.Additions + plan.Changes + plan.Destructions),
+	}
+
+	if run.IsDestroy && isBeingDeleted(terraform) && !r.forceOrAutoApply(terraform) {
+		msg := fmt.Sprintf("Destroy plan generated, %d resource(s) to destroy", plan.Destructions)
+		planId, approveMessage := infrav1.GetDestroyPlanIdAndApproveMessage(revision, msg)
+		metadata[infrav1.EventMetadataPlanIDKey] = planId
+		r.event(ctx, terraform, revision, events.EventSeverityInfo, approveMessage+"\n"+tail, metadata)
+		return infrav1.TerraformDestroyPlanned(terraform, revision, msg)
+	}
+
+	forceOrAutoApply := r.forceOrAutoApply(terraform)
+	planId, approveMessage := infrav1.GetPlanIdAndApproveMessage(revision, "Plan generated")
+	metadata[infrav1.EventMetadataPlanIDKey] = planId
+	msg := fmt.Sprintf("Planned, %s: %s", plan, terraform.Status.RemoteRun.URL)
+	if !forceOrAutoApply {
+		msg += "\n" + approveMessage
+	}
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg+"\n"+tail, metadata)
+	return infrav1.TerraformPlannedWithChanges(terraform, revision, forceOrAutoApply, "Plan generated")
+}
+
+// remoteLogTail returns the last lines of a log of a run, without the colors. The log only informs the events,
+// so failing to read it is logged and does not fail the reconciliation.
+func (r *TerraformReconciler) remoteLogTail(ctx context.Context, c *remoteClient, logReadURL string) string {
+	data, err := c.readLog(ctx, logReadURL)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "unable to read the log of the remote run")
+		return ""
+	}
+	return messageTail(ansiEscapeRegexp.ReplaceAllString(data, ""), remoteLogTailLines, remoteLogTailLength)
+}
+
+// syncRemoteOutputs writes the outputs of the current state of the workspace, like the runner does after an apply.
+func (r *TerraformReconciler) syncRemoteOutputs(ctx context.Context, c *remoteClient, terraform infrav1.Terraform, workspaceID string, revision string) (infrav1.Terraform, error) {
+	remoteOutputs, err := c.currentOutputs(ctx, workspaceID)
+	if err != nil {
+		err = fmt.Errorf("error reading the outputs of the workspace: %s", err)
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.TFExecOutputFailedReason, err.Error()), err
+	}
+
+	outputs := map[string]tfexec.OutputMeta{}
+	var availableOutputs []string
+	for _, output := range remoteOutputs {
+		outputs[output.Name] = tfexec.OutputMeta{
+			Sensitive: output.Sensitive,
+			Type:      output.DetailedType,
+			Value:     output.Value,
+		}
+		availableOutputs = append(availableOutputs, output.Name)
+	}
+	if len(availableOutputs) > 0 {
+		sort.Strings(availableOutputs)
+		terraform = infrav1.TerraformOutputsAvailable(terraform, availableOutputs, "Outputs available")
+	}
+
+	if r.shouldWriteOutputs(terraform, outputs) {
+		writer := localOutputsWriter{server: &runner.TerraformRunnerServer{Client: r.Client, InstanceID: "controller"}}
+		terraform, err = r.writeOutput(ctx, terraform, writer, outputs, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+	if terraform.Spec.WriteOutputsToConfigMap != nil && len(outputs) > 0 {
+		terraform, err = r.writeOutputsToConfigMap(ctx, terraform, outputs, revision)
+		if err != nil {
+			return terraform, err
+		}
+	}
+	if terraform.Spec.WriteOutputsTo != nil && len(outputs) > 0 {
+		return r.writeOutputsToObject(ctx, terraform, outputs, revision)
+	}
+	return terraform, nil
+}
+
+// localOutputsWriter writes the outputs from the controller, for the remote backend running without runner.
+type localOutputsWriter struct {
+	server *runner.TerraformRunnerServer
+}
+
+func (w localOutputsWriter) WriteOutputs(ctx context.Context, in *runner.WriteOutputsRequest, _ ...grpc.CallOption) (*runner.WriteOutputsReply, error) {
+	return w.server.WriteOutputs(ctx, in)
+}
+
+// deleteRemoteOutputs deletes the outputs Secret of an object being deleted, or releases it with deletionPolicy retain,
+// and deletes the RBAC giving access to it.
+func (r *TerraformReconciler) deleteRemoteOutputs(ctx context.Context, terraform infrav1.Terraform) error {
+	if terraform.Spec.WriteOutputsToSecret == nil || terraform.Spec.WriteOutputsToSecret.Name == "" {
+		return nil
+	}
+	var secret corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: terraform.OutputsSecretNamespace(), Name: terraform.Spec.WriteOutputsToSecret.Name}, &secret)
+	// a secret of the same name not written for the object is left as it is
+	if err == nil && !runner.OwnsOutputSecret(secret, string(terraform.UID)) {
+		return r.deleteOutputsRBAC(ctx, terraform)
+	}
+	if err == nil && terraform.RetainsOutputsSecret() {
+		var refs []metav1.OwnerReference
+		for _, ref := range secret.OwnerReferences {
+			if ref.UID != terraform.UID {
+				refs = append(refs, ref)
+			}
+		}
+		if len(refs) != len(secret.OwnerReferences) {
+			secret.OwnerReferences = refs
+			err = r.Update(ctx, &secret)
+		}
+	} else if err == nil {
+		err = r.Delete(ctx, &secret)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return r.deleteOutputsRBAC(ctx, terraform)
+}
+
+// remoteFailed records an error of the API of the remote backend, and retries after the retry interval,
+// unless the object is reconciled manually.
+func (r *TerraformReconciler) remoteFailed(ctx context.Context, terraform infrav1.Terraform, revision string, err error) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Error(err, "remote backend error")
+	terraform = infrav1.TerraformNotReady(terraform, revision, infrav1.RemoteRunFailedReason, err.Error())
+	if err := r.patchStatus(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, terraform.Status); err != nil {
+		log.Error(err, "unable to update status after the remote backend error")
+		return ctrl.Result{Requeue: true}, err
+	}
+	r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+	r.recordReadinessMetric(ctx, terraform)
+	return r.retryResult(terraform), nil
+}
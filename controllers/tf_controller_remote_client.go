@@ -0,0 +1,451 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRemoteHostname   = "app.terraform.io"
+	remoteMediaType         = "application/vnd.api+json"
+	remoteMaxLogLength      = 1024 * 1024
+	remoteConfigUploaded    = "uploaded"
+	remoteConfigErrored     = "errored"
+	remoteRunPlanned        = "planned"
+	remoteRunPlannedNoApply = "planned_and_finished"
+	remoteRunApplied        = "applied"
+	remoteRunErrored        = "errored"
+	remoteRunDiscarded      = "discarded"
+	remoteRunCanceled       = "canceled"
+	remoteRunForceCanceled  = "force_canceled"
+)
+
+// remoteUploadPollInterval is the interval of the polls of the status of an uploaded configuration version.
+var remoteUploadPollInterval = 2 * time.Second
+
+// remoteClient drives the workspaces and the runs of Terraform Cloud and Terraform Enterprise
+// with their JSON:API, https://developer.hashicorp.com/terraform/cloud-docs/api-docs.
+type remoteClient struct {
+	httpClient *http.Client
+	hostname   string
+	token      string
+}
+
+func newRemoteClient(httpClient *http.Client, hostname string, token string) *remoteClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if hostname == "" {
+		hostname = defaultRemoteHostname
+	}
+	return &remoteClient{httpClient: httpClient, hostname: hostname, token: token}
+}
+
+func (c *remoteClient) apiURL() string {
+	return "https://" + c.hostname + "/api/v2"
+}
+
+// runURL returns the page of the run in the user interface.
+func (c *remoteClient) runURL(organization, workspace, runID string) string {
+	return fmt.Sprintf("https://%s/app/%s/workspaces/%s/runs/%s", c.hostname, url.PathEscape(organization), url.PathEscape(workspace), runID)
+}
+
+type remoteDocument struct {
+	Data remoteResource `json:"data"`
+}
+
+type remoteListDocument struct {
+	Data []remoteResource `json:"data"`
+}
+
+type remoteResource struct {
+	ID            string                        `json:"id,omitempty"`
+	Type          string                        `json:"type"`
+	Attributes    json.RawMessage               `json:"attributes,omitempty"`
+	Relationships map[string]remoteRelationship `json:"relationships,omitempty"`
+}
+
+type remoteRelationship struct {
+	Data *remoteResource `json:"data"`
+}
+
+func (r remoteResource) related(name string) string {
+	if rel, ok := r.Relationships[name]; ok && rel.Data != nil {
+		return rel.Data.ID
+	}
+	return ""
+}
+
+type remoteWorkspace struct {
+	ID string
+}
+
+type remoteRun struct {
+	ID            string
+	Status        string
+	IsDestroy     bool
+	IsConfirmable bool
+	HasChanges    bool
+	PlanID        string
+	ApplyID       string
+}
+
+// IsFinal reports whether the run reached a status it does not leave.
+func (r remoteRun) IsFinal() bool {
+	switch r.Status {
+	case remoteRunApplied, remoteRunPlannedNoApply, remoteRunErrored, remoteRunDiscarded,
+		remoteRunCanceled, remoteRunForceCanceled:
+		return true
+	}
+	return false
+}
+
+// remoteChanges are the resource counts and the log of a plan or an apply.
+type remoteChanges struct {
+	Additions    int    `json:"resource-additions"`
+	Changes      int    `json:"resource-changes"`
+	Destructions int    `json:"resource-destructions"`
+	LogReadURL   string `json:"log-read-url"`
+}
+
+func (c remoteChanges) String() string {
+	return fmt.Sprintf("%d to add, %d to change, %d to destroy", c.Additions, c.Changes, c.Destructions)
+}
+
+type remoteOutput struct {
+	ID           string
+	Name         string          `json:"name"`
+	Sensitive    bool            `json:"sensitive"`
+	DetailedType json.RawMessage `json:"detailed-type"`
+	Value        json.RawMessage `json:"value"`
+}
+
+// remoteVariable is a variable of a run, whose value is an HCL expression.
+type remoteVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// remoteWorkspaceVariable is a Terraform variable of a workspace, whose value is an HCL expression.
+type remoteWorkspaceVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	HCL         bool   `json:"hcl"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// getWorkspace returns the workspace of the organization, nil if it does not exist.
+func (c *remoteClient) getWorkspace(ctx context.Context, organization, name string) (*remoteWorkspace, error) {
+	var doc remoteDocument
+	status, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/organizations/%s/workspaces/%s", url.PathEscape(organization), url.PathEscape(name)), nil, &doc)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &remoteWorkspace{ID: doc.Data.ID}, nil
+}
+
+// createWorkspace creates a workspace running the Terraform configuration of the directory,
+// whose plans are never applied without a confirmation of the controller.
+func (c *remoteClient) createWorkspace(ctx context.Context, organization, name, workingDirectory string) (*remoteWorkspace, error) {
+	attributes, _ := json.Marshal(map[string]interface{}{
+		"name":              name,
+		"working-directory": workingDirectory,
+		"auto-apply":        false,
+		"execution-mode":    "remote",
+	})
+	var doc remoteDocument
+	if _, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/organizations/%s/workspaces", url.PathEscape(organization)),
+		remoteDocument{Data: remoteResource{Type: "workspaces", Attributes: attributes}}, &doc); err != nil {
+		return nil, err
+	}
+	return &remoteWorkspace{ID: doc.Data.ID}, nil
+}
+
+// uploadConfiguration creates a configuration version of the workspace with the tarball,
+// and waits for it to be processed.
+func (c *remoteClient) uploadConfiguration(ctx context.Context, workspaceID string, tarball io.Reader, size int64) (string, error) {
+	attributes, _ := json.Marshal(map[string]interface{}{"auto-queue-runs": false})
+	var doc remoteDocument
+	if _, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%s/configuration-versions", workspaceID),
+		remoteDocument{Data: remoteResource{Type: "configuration-versions", Attributes: attributes}}, &doc); err != nil {
+		return "", err
+	}
+	var created struct {
+		UploadURL string `json:"upload-url"`
+	}
+	if err := json.Unmarshal(doc.Data.Attributes, &created); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, created.UploadURL, tarball)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to upload the configuration version %s: %s", doc.Data.ID, resp.Status)
+	}
+
+	// the upload is processed asynchronously, in a few seconds
+	for i := 0; i < 30; i++ {
+		var version remoteDocument
+		if _, err := c.do(ctx, http.MethodGet, "/configuration-versions/"+doc.Data.ID, nil, &version); err != nil {
+			return "", err
+		}
+		var attributes struct {
+			Status string `json:"status"`
+			Error  string `json:"error-message"`
+		}
+		if err := json.Unmarshal(version.Data.Attributes, &attributes); err != nil {
+			return "", err
+		}
+		switch attributes.Status {
+		case remoteConfigUploaded:
+			return doc.Data.ID, nil
+		case remoteConfigErrored:
+			return "", fmt.Errorf("configuration version %s errored: %s", doc.Data.ID, attributes.Error)
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(remoteUploadPollInterval):
+		}
+	}
+	return "", fmt.Errorf("configuration version %s is still not uploaded", doc.Data.ID)
+}
+
+// createRun queues a run of the configuration version in the workspace.
+func (c *remoteClient) createRun(ctx context.Context, workspaceID, configurationVersionID, message string, isDestroy bool, variables []remoteVariable) (remoteRun, error) {
+	attributes, _ := json.Marshal(map[string]interface{}{
+		"message":    message,
+		"is-destroy": isDestroy,
+		"variables":  variables,
+	})
+	var doc remoteDocument
+	if _, err := c.do(ctx, http.MethodPost, "/runs", remoteDocument{Data: remoteResource{
+		Type:       "runs",
+		Attributes: attributes,
+		Relationships: map[string]remoteRelationship{
+			"workspace":             {Data: &remoteResource{Type: "workspaces", ID: workspaceID}},
+			"configuration-version": {Data: &remoteResource{Type: "configuration-versions", ID: configurationVersionID}},
+		},
+	}}, &doc); err != nil {
+		return remoteRun{}, err
+	}
+	return parseRemoteRun(doc.Data)
+}
+
+func (c *remoteClient) getRun(ctx context.Context, runID string) (remoteRun, error) {
+	var doc remoteDocument
+	if _, err := c.do(ctx, http.MethodGet, "/runs/"+runID, nil, &doc); err != nil {
+		return remoteRun{}, err
+	}
+	return parseRemoteRun(doc.Data)
+}
+
+func parseRemoteRun(resource remoteResource) (remoteRun, error) {
+	var attributes struct {
+		Status     string `json:"status"`
+		IsDestroy  bool   `json:"is-destroy"`
+		HasChanges bool   `json:"has-changes"`
+		Actions    struct {
+			IsConfirmable bool `json:"is-confirmable"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal(resource.Attributes, &attributes); err != nil {
+		return remoteRun{}, err
+	}
+	return remoteRun{
+		ID:            resource.ID,
+		Status:        attributes.Status,
+		IsDestroy:     attributes.IsDestroy,
+		IsConfirmable: attributes.Actions.IsConfirmable,
+		HasChanges:    attributes.HasChanges,
+		PlanID:        resource.related("plan"),
+		ApplyID:       resource.related("apply"),
+	}, nil
+}
+
+// getPlan returns the changes of a plan, and getApply those of an apply.
+func (c *remoteClient) getPlan(ctx context.Context, planID string) (remoteChanges, error) {
+	return c.getChanges(ctx, "/plans/"+planID)
+}
+
+func (c *remoteClient) getApply(ctx context.Context, applyID string) (remoteChanges, error) {
+	return c.getChanges(ctx, "/applies/"+applyID)
+}
+
+func (c *remoteClient) getChanges(ctx context.Context, path string) (remoteChanges, error) {
+	var doc remoteDocument
+	var changes remoteChanges
+	if _, err := c.do(ctx, http.MethodGet, path, nil, &doc); err != nil {
+		return changes, err
+	}
+	err := json.Unmarshal(doc.Data.Attributes, &changes)
+	return changes, err
+}
+
+func (c *remoteClient) applyRun(ctx context.Context, runID, comment string) error {
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/runs/%s/actions/apply", runID), map[string]string{"comment": comment}, nil)
+	return err
+}
+
+// setSensitiveVariables sets the variables as sensitive Terraform variables of the workspace, as the variables
+// of the runs can't be sensitive. The variables of the workspace with the description, set before and not any longer,
+// are deleted.
+func (c *remoteClient) setSensitiveVariables(ctx context.Context, workspaceID string, description string, variables []remoteVariable) error {
+	var doc remoteListDocument
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%s/vars", workspaceID), nil, &doc); err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	for _, v := range variables {
+		values[v.Key] = v.Value
+	}
+	for _, resource := range doc.Data {
+		var existing remoteWorkspaceVariable
+		if err := json.Unmarshal(resource.Attributes, &existing); err != nil {
+			return err
+		}
+		if existing.Category != "terraform" {
+			continue
+		}
+		path := fmt.Sprintf("/workspaces/%s/vars/%s", workspaceID, resource.ID)
+		value, ok := values[existing.Key]
+		if !ok {
+			if existing.Description == description {
+				if _, err := c.do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		delete(values, existing.Key)
+		attributes, _ := json.Marshal(remoteWorkspaceVariable{Key: existing.Key, Value: value, Description: description, Category: "terraform", HCL: true, Sensitive: true})
+		if _, err := c.do(ctx, http.MethodPatch, path, remoteDocument{Data: remoteResource{ID: resource.ID, Type: "vars", Attributes: attributes}}, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range variables {
+		if _, ok := values[v.Key]; !ok {
+			continue
+		}
+		attributes, _ := json.Marshal(remoteWorkspaceVariable{Key: v.Key, Value: v.Value, Description: description, Category: "terraform", HCL: true, Sensitive: true})
+		if _, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/workspaces/%s/vars", workspaceID), remoteDocument{Data: remoteResource{Type: "vars", Attributes: attributes}}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *remoteClient) discardRun(ctx context.Context, runID, comment string) error {
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/runs/%s/actions/discard", runID), map[string]string{"comment": comment}, nil)
+	return err
+}
+
+// readLog returns the log of a plan or an apply, at most remoteMaxLogLength bytes of it.
+func (c *remoteClient) readLog(ctx context.Context, logReadURL string) (string, error) {
+	if logReadURL == "" {
+		return "", nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logReadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read the log: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, remoteMaxLogLength))
+	return string(data), err
+}
+
+// currentOutputs returns the outputs of the current state of the workspace, with the values of the sensitive ones,
+// which are only returned by their own endpoint.
+func (c *remoteClient) currentOutputs(ctx context.Context, workspaceID string) ([]remoteOutput, error) {
+	var doc remoteListDocument
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/workspaces/%s/current-state-version-outputs", workspaceID), nil, &doc); err != nil {
+		return nil, err
+	}
+
+	var outputs []remoteOutput
+	for _, resource := range doc.Data {
+		output := remoteOutput{ID: resource.ID}
+		if err := json.Unmarshal(resource.Attributes, &output); err != nil {
+			return nil, err
+		}
+		if output.Sensitive {
+			var sensitive remoteDocument
+			if _, err := c.do(ctx, http.MethodGet, "/state-version-outputs/"+resource.ID, nil, &sensitive); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(sensitive.Data.Attributes, &output); err != nil {
+				return nil, err
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// do sends a request to the API, and decodes the document of the response into result.
+// It returns the status code of the response, 0 if none was received.
+func (c *remoteClient) do(ctx context.Context, method string, path string, body interface{}, result interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL()+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", remoteMediaType)
+	if body != nil {
+		req.Header.Set("Content-Type", remoteMediaType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("Terraform Cloud API %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,439 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/hashicorp/go-retryablehttp"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeTerraformCloud serves the API of Terraform Cloud for a single workspace, whose runs only move
+// when the test sets their status.
+type fakeTerraformCloud struct {
+	mu        sync.Mutex
+	server    *httptest.Server
+	workspace string
+	uploaded  string
+	runs      int
+	runStatus string
+	variables []remoteVariable
+	discarded []string
+	// workspaceVariables are the variables of the workspace, by id
+	workspaceVariables map[string]remoteWorkspaceVariable
+}
+
+func newFakeTerraformCloud() *fakeTerraformCloud {
+	f := &fakeTerraformCloud{workspaceVariables: map[string]remoteWorkspaceVariable{}}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+func (f *fakeTerraformCloud) setRunStatus(status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runStatus = status
+}
+
+func (f *fakeTerraformCloud) serve(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(req.URL.Path, "/api/v2/") {
+		switch req.URL.Path {
+		case "/artifact.tar.gz":
+			w.Write([]byte("tarball"))
+		case "/upload":
+			data, _ := io.ReadAll(req.Body)
+			f.uploaded = string(data)
+		case "/logs/plan":
+			w.Write([]byte("\x1b[0m\x1b[1mPlan:\x1b[0m 1 to add, 0 to change, 0 to destroy.\n"))
+		case "/logs/apply":
+			w.Write([]byte("\x1b[0m\x1b[1mApply complete! Resources: 1 added, 0 changed, 0 destroyed.\x1b[0m\n"))
+		default:
+			http.NotFound(w, req)
+		}
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer t0k3n" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resource := func(id string, attributes string, relationships string) {
+		fmt.Fprintf(w, `{"data":{"id":%q,"attributes":%s,"relationships":%s}}`, id, attributes, relationships)
+	}
+	run := func() {
+		resource(fmt.Sprintf("run-%d", f.runs),
+			fmt.Sprintf(`{"status":%q,"actions":{"is-confirmable":%v}}`, f.runStatus, f.runStatus == remoteRunPlanned),
+			`{"plan":{"data":{"id":"plan-1","type":"plans"}},"apply":{"data":{"id":"apply-1","type":"applies"}}}`)
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/api/v2")
+	switch {
+	case path == "/organizations/weaveworks/workspaces/flux-system-helloworld" && req.Method == http.MethodGet:
+		if f.workspace == "" {
+			http.NotFound(w, req)
+			return
+		}
+		resource(f.workspace, `{}`, `{}`)
+	case path == "/organizations/weaveworks/workspaces" && req.Method == http.MethodPost:
+		f.workspace = "ws-1"
+		resource(f.workspace, `{}`, `{}`)
+	case path == "/workspaces/ws-1/configuration-versions":
+		resource("cv-1", fmt.Sprintf(`{"upload-url":%q}`, f.server.URL+"/upload"), `{}`)
+	case path == "/configuration-versions/cv-1":
+		resource("cv-1", `{"status":"uploaded"}`, `{}`)
+	case path == "/runs" && req.Method == http.MethodPost:
+		var doc struct {
+			Data struct {
+				Attributes struct {
+					Variables []remoteVariable `json:"variables"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		json.NewDecoder(req.Body).Decode(&doc)
+		f.variables = doc.Data.Attributes.Variables
+		f.runs++
+		f.runStatus = "pending"
+		run()
+	case path == fmt.Sprintf("/runs/run-%d", f.runs):
+		run()
+	case path == fmt.Sprintf("/runs/run-%d/actions/apply", f.runs):
+		f.runStatus = "apply_queued"
+		w.WriteHeader(http.StatusAccepted)
+	case path == fmt.Sprintf("/runs/run-%d/actions/discard", f.runs):
+		f.discarded = append(f.discarded, fmt.Sprintf("run-%d", f.runs))
+		f.runStatus = remoteRunDiscarded
+		w.WriteHeader(http.StatusAccepted)
+	case path == "/workspaces/ws-1/vars" && req.Method == http.MethodGet:
+		var resources []string
+		for id, v := range f.workspaceVariables {
+			attributes, _ := json.Marshal(v)
+			resources = append(resources, fmt.Sprintf(`{"id":%q,"type":"vars","attributes":%s}`, id, attributes))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(resources, ","))
+	case path == "/workspaces/ws-1/vars" && req.Method == http.MethodPost:
+		var doc struct {
+			Data struct {
+				Attributes remoteWorkspaceVariable `json:"attributes"`
+			} `json:"data"`
+		}
+		json.NewDecoder(req.Body).Decode(&doc)
+		id := fmt.Sprintf("var-%d", len(f.workspaceVariables)+1)
+		f.workspaceVariables[id] = doc.Data.Attributes
+		resource(id, `{}`, `{}`)
+	case strings.HasPrefix(path, "/workspaces/ws-1/vars/") && req.Method == http.MethodPatch:
+		var doc struct {
+			Data struct {
+				Attributes remoteWorkspaceVariable `json:"attributes"`
+			} `json:"data"`
+		}
+		json.NewDecoder(req.Body).Decode(&doc)
+		id := strings.TrimPrefix(path, "/workspaces/ws-1/vars/")
+		f.workspaceVariables[id] = doc.Data.Attributes
+		resource(id, `{}`, `{}`)
+	case strings.HasPrefix(path, "/workspaces/ws-1/vars/") && req.Method == http.MethodDelete:
+		delete(f.workspaceVariables, strings.TrimPrefix(path, "/workspaces/ws-1/vars/"))
+		w.WriteHeader(http.StatusNoContent)
+	case path == "/plans/plan-1":
+		resource("plan-1", fmt.Sprintf(`{"resource-additions":1,"resource-changes":0,"resource-destructions":0,"log-read-url":%q}`, f.server.URL+"/logs/plan"), `{}`)
+	case path == "/applies/apply-1":
+		resource("apply-1", fmt.Sprintf(`{"resource-additions":1,"resource-changes":0,"resource-destructions":0,"log-read-url":%q}`, f.server.URL+"/logs/apply"), `{}`)
+	case path == "/workspaces/ws-1/current-state-version-outputs":
+		w.Write([]byte(`{"data":[
+			{"id":"wsout-1","attributes":{"name":"hostname","sensitive":false,"detailed-type":"string","value":"example.com"}},
+			{"id":"wsout-2","attributes":{"name":"password","sensitive":true,"detailed-type":"string","value":null}}
+		]}`))
+	case path == "/state-version-outputs/wsout-2":
+		resource("wsout-2", `{"name":"password","sensitive":true,"detailed-type":"string","value":"s3cr3t"}`, `{}`)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// remoteReconciler returns a reconciler of the remote object helloworld of the fake Terraform Cloud, with the other objects,
+// the source of the object, and a function reconciling the object and returning it with the requeue interval.
+func remoteReconciler(g *WithT, tfc *fakeTerraformCloud, terraform *infrav1.Terraform, objects ...client.Object) (*TerraformReconciler, *sourcev1.GitRepository, func() (infrav1.Terraform, time.Duration)) {
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tfc-token", Namespace: "flux-system"},
+		Data:       map[string][]byte{"token": []byte("t0k3n\n")},
+	}
+	terraform.ObjectMeta = metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"}
+	terraform.Spec.Interval = metav1.Duration{Duration: time.Hour}
+	terraform.Spec.Path = "./infra"
+	terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{
+		Type: infrav1.BackendTypeRemote,
+		Remote: &infrav1.RemoteBackendSpec{
+			Hostname:       tfc.server.Listener.Addr().String(),
+			Organization:   "weaveworks",
+			TokenSecretRef: meta.SecretKeyReference{Name: "tfc-token", Key: "token"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(append(objects, token, terraform)...).Build()
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.HTTPClient = tfc.server.Client()
+	httpClient.Logger = nil
+	r := &TerraformReconciler{
+		Client:           c,
+		httpClient:       httpClient,
+		remoteHTTPClient: tfc.server.Client(),
+		EventRecorder:    record.NewFakeRecorder(100),
+		Config:           &ControllerConfigWatcher{},
+		statusManager:    "tf-controller",
+	}
+	source := &sourcev1.GitRepository{Status: sourcev1.GitRepositoryStatus{Artifact: &sourcev1.Artifact{
+		URL:      tfc.server.URL + "/artifact.tar.gz",
+		Revision: "main/b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		Checksum: fmt.Sprintf("%x", sha256.Sum256([]byte("tarball"))),
+	}}}
+
+	reconcile := func() (infrav1.Terraform, time.Duration) {
+		var obj infrav1.Terraform
+		g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &obj)).To(Succeed())
+		result, err := r.reconcileRemote(ctx, obj, source)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &obj)).To(Succeed())
+		return obj, result.RequeueAfter
+	}
+	return r, source, reconcile
+}
+
+func TestReconcileRemote(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	tfc := newFakeTerraformCloud()
+	defer tfc.server.Close()
+
+	r, source, reconcile := remoteReconciler(g, tfc, &infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			Vars:                 []infrav1.Variable{{Name: "region", Value: &apiextensionsv1.JSON{Raw: []byte(`"eu-west-1"`)}}},
+			WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"},
+		},
+	})
+	c := r.Client
+
+	// the workspace is created, and a run of the revision is started
+	obj, requeueAfter := reconcile()
+	g.Expect(tfc.workspace).To(Equal("ws-1"))
+	g.Expect(tfc.uploaded).To(Equal("tarball"))
+	g.Expect(tfc.variables).To(Equal([]remoteVariable{{Key: "region", Value: `"eu-west-1"`}}))
+	g.Expect(requeueAfter).To(Equal(remoteRunPollInterval))
+	g.Expect(obj.Status.RemoteRun.ID).To(Equal("run-1"))
+	g.Expect(obj.Status.RemoteRun.URL).To(HaveSuffix("/app/weaveworks/workspaces/flux-system-helloworld/runs/run-1"))
+
+	// the plan waits for its approval
+	tfc.setRunStatus(remoteRunPlanned)
+	obj, requeueAfter = reconcile()
+	g.Expect(requeueAfter).To(Equal(time.Hour))
+	g.Expect(obj.Status.Plan.Pending).To(HavePrefix("plan-main-b8e362c206"))
+	planId := obj.Status.Plan.Pending
+	g.Expect(tfc.runStatus).To(Equal(remoteRunPlanned))
+
+	// the approved plan is confirmed
+	obj.Spec.ApprovePlan = planId
+	g.Expect(c.Update(ctx, &obj)).To(Succeed())
+	obj, requeueAfter = reconcile()
+	g.Expect(requeueAfter).To(Equal(remoteRunPollInterval))
+	g.Expect(tfc.runStatus).To(Equal("apply_queued"))
+
+	// the outputs of the applied run are written
+	tfc.setRunStatus(remoteRunApplied)
+	obj, requeueAfter = reconcile()
+	g.Expect(obj.Status.RemoteRun.FinishedAt).NotTo(BeNil())
+	g.Expect(obj.Status.LastAppliedRevision).To(Equal(source.Status.Artifact.Revision))
+	g.Expect(obj.Status.Plan.LastApplied).To(Equal(planId))
+	g.Expect(obj.Status.AvailableOutputs).To(Equal([]string{"hostname", "password"}))
+	var outputs corev1.Secret
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-outputs"}, &outputs)).To(Succeed())
+	g.Expect(outputs.Data).To(Equal(map[string][]byte{"hostname": []byte("example.com"), "password": []byte("s3cr3t")}))
+
+	// the revision is planned again at the interval
+	g.Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+	obj, _ = reconcile()
+	g.Expect(tfc.runs).To(Equal(1))
+
+	// the plan of a superseded revision is discarded
+	source.Status.Artifact.Revision = "main/d4a8f4c2b1e3d0cbb7ed22ced771a0056455a2fb"
+	obj, _ = reconcile()
+	g.Expect(tfc.runs).To(Equal(2))
+	tfc.setRunStatus(remoteRunPlanned)
+	source.Status.Artifact.Revision = "main/0f1e2d3c4b5a6978ed22ced771a0056455a2fb00"
+	obj, _ = reconcile()
+	g.Expect(tfc.discarded).To(Equal([]string{"run-2"}))
+	g.Expect(obj.Status.Plan.Pending).To(BeEmpty())
+	obj, _ = reconcile()
+	g.Expect(tfc.runs).To(Equal(3))
+	g.Expect(obj.Status.RemoteRun.Revision).To(Equal(source.Status.Artifact.Revision))
+
+	// a failed run is reported with the tail of its log
+	tfc.setRunStatus(remoteRunErrored)
+	obj, _ = reconcile()
+	ready := apimeta.FindStatusCondition(obj.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Reason).To(Equal(infrav1.RemoteRunFailedReason))
+	g.Expect(ready.Message).To(ContainSubstring("Apply complete! Resources: 1 added"))
+	g.Expect(ready.Message).NotTo(ContainSubstring("\x1b"))
+}
+
+func TestReconcileRemoteApplyBlocked(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		spec    infrav1.TerraformSpec
+		objects []client.Object
+		reason  string
+		unblock func(g *WithT, r *TerraformReconciler)
+	}{
+		{
+			name:   "suspended",
+			spec:   infrav1.TerraformSpec{ApprovePlan: infrav1.ApprovePlanAutoValue, SuspendApply: true},
+			reason: infrav1.ApplySuspendedReason,
+			unblock: func(g *WithT, r *TerraformReconciler) {
+				var obj infrav1.Terraform
+				g.Expect(r.Get(context.Background(), types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &obj)).To(Succeed())
+				obj.Spec.SuspendApply = false
+				g.Expect(r.Update(context.Background(), &obj)).To(Succeed())
+			},
+		},
+		{
+			name: "change freeze",
+			spec: infrav1.TerraformSpec{ApprovePlan: infrav1.ApprovePlanAutoValue},
+			objects: []client.Object{&infrav1.ChangeFreeze{
+				ObjectMeta: metav1.ObjectMeta{Name: "year-end"},
+				Spec: infrav1.ChangeFreezeSpec{Windows: []infrav1.FreezeWindow{
+					{Start: metav1.NewTime(now.Add(-time.Hour)), End: metav1.NewTime(now.Add(time.Hour))},
+				}},
+			}},
+			reason: infrav1.ChangeFreezeReason,
+			unblock: func(g *WithT, r *TerraformReconciler) {
+				g.Expect(r.Delete(context.Background(), &infrav1.ChangeFreeze{ObjectMeta: metav1.ObjectMeta{Name: "year-end"}})).To(Succeed())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tfc := newFakeTerraformCloud()
+			defer tfc.server.Close()
+			r, _, reconcile := remoteReconciler(g, tfc, &infrav1.Terraform{Spec: tt.spec}, tt.objects...)
+
+			reconcile()
+			tfc.setRunStatus(remoteRunPlanned)
+
+			// the approved plan is kept pending, and the run confirmable
+			obj, requeueAfter := reconcile()
+			g.Expect(requeueAfter).To(BeNumerically(">", 0))
+			g.Expect(tfc.runStatus).To(Equal(remoteRunPlanned))
+			g.Expect(obj.Status.Plan.Pending).To(HavePrefix("plan-main-b8e362c206"))
+			g.Expect(apimeta.FindStatusCondition(obj.Status.Conditions, infrav1.ConditionTypeApply).Reason).To(Equal(tt.reason))
+
+			reconcile()
+			g.Expect(tfc.runStatus).To(Equal(remoteRunPlanned))
+
+			// the run is confirmed once the apply is no longer blocked
+			tt.unblock(g, r)
+			reconcile()
+			g.Expect(tfc.runStatus).To(Equal("apply_queued"))
+		})
+	}
+}
+
+func TestReconcileRemoteArtifactVerified(t *testing.T) {
+	g := NewWithT(t)
+
+	tfc := newFakeTerraformCloud()
+	defer tfc.server.Close()
+	_, source, reconcile := remoteReconciler(g, tfc, &infrav1.Terraform{})
+
+	// the artifact is not uploaded when it does not match its checksum
+	source.Status.Artifact.Checksum = fmt.Sprintf("%x", sha256.Sum256([]byte("tampered")))
+	obj, _ := reconcile()
+	ready := apimeta.FindStatusCondition(obj.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Reason).To(Equal(infrav1.RemoteRunFailedReason))
+	g.Expect(ready.Message).To(ContainSubstring("failed to verify artifact"))
+	g.Expect(tfc.uploaded).To(BeEmpty())
+	g.Expect(tfc.runs).To(BeZero())
+
+	// nor without a checksum
+	source.Status.Artifact.Checksum = ""
+	obj, _ = reconcile()
+	g.Expect(apimeta.FindStatusCondition(obj.Status.Conditions, meta.ReadyCondition).Message).To(ContainSubstring("no checksum advertised"))
+	g.Expect(tfc.uploaded).To(BeEmpty())
+}
+
+func TestReconcileRemoteVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	tfc := newFakeTerraformCloud()
+	defer tfc.server.Close()
+	description := "Set by tf-controller from the Secrets of flux-system/helloworld"
+	tfc.workspaceVariables["var-a"] = remoteWorkspaceVariable{Key: "token", Description: description, Category: "terraform", HCL: true, Sensitive: true}
+	tfc.workspaceVariables["var-b"] = remoteWorkspaceVariable{Key: "owner", Value: `"platform"`, Category: "terraform", HCL: true}
+
+	password := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "flux-system"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	settings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "flux-system"},
+		Data:       map[string]string{"region": "eu-west-1"},
+	}
+	_, _, reconcile := remoteReconciler(g, tfc, &infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)},
+			Vars: []infrav1.Variable{
+				{Name: "subject", Value: &apiextensionsv1.JSON{Raw: []byte(`"World"`)}},
+				{Name: "password", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "database"}, Key: "password",
+				}}},
+			},
+			VarsFrom: []infrav1.VarsReference{{Kind: "ConfigMap", Name: "settings"}},
+		},
+	}, password, settings)
+
+	// the variables are resolved like on a runner
+	reconcile()
+	g.Expect(tfc.variables).To(Equal([]remoteVariable{
+		{Key: "region", Value: `"eu-west-1"`},
+		{Key: "subject", Value: `"World"`},
+		{Key: "values", Value: `{"replicas":2}`},
+	}))
+
+	// the ones read from Secrets are sensitive variables of the workspace, replacing the ones not set any longer
+	g.Expect(tfc.workspaceVariables).To(HaveLen(2))
+	g.Expect(tfc.workspaceVariables).To(HaveKeyWithValue("var-b", remoteWorkspaceVariable{Key: "owner", Value: `"platform"`, Category: "terraform", HCL: true}))
+	g.Expect(tfc.workspaceVariables).To(ContainElement(remoteWorkspaceVariable{
+		Key: "password", Value: `"s3cr3t"`, Description: description, Category: "terraform", HCL: true, Sensitive: true,
+	}))
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// pendingReplacements returns the value of the replace-resources annotation, and the addresses it lists,
+// unless the resources of this value have already been replaced by an applied plan.
+func pendingReplacements(terraform infrav1.Terraform) (string, []string) {
+	value := strings.TrimSpace(terraform.GetAnnotations()[infrav1.ReplaceResourcesAnnotation])
+	if value == "" || value == terraform.Status.LastReplacedResources {
+		return "", nil
+	}
+
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return "", nil
+	}
+	return value, addresses
+}
+
+// ReplaceResourcesRequestedPredicate triggers when the replace-resources annotation changes.
+type ReplaceResourcesRequestedPredicate struct {
+	predicate.Funcs
+}
+
+func (ReplaceResourcesRequestedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[infrav1.ReplaceResourcesAnnotation] != e.ObjectOld.GetAnnotations()[infrav1.ReplaceResourcesAnnotation]
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPendingReplacements(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				infrav1.ReplaceResourcesAnnotation: "aws_instance.web, module.db.aws_db_instance.main,",
+			},
+		},
+	}
+	value, addresses := pendingReplacements(terraform)
+	g.Expect(value).To(Equal("aws_instance.web, module.db.aws_db_instance.main,"))
+	g.Expect(addresses).To(Equal([]string{"aws_instance.web", "module.db.aws_db_instance.main"}))
+
+	// the plan applying the replacements is recorded, and the same value replaces nothing more
+	terraform.Status.Plan = infrav1.PlanStatus{Pending: "plan-main-b8e362c206", Replaces: value}
+	terraform = infrav1.TerraformApplied(terraform, "main/b8e362c206", "Applied successfully", false, nil)
+	g.Expect(terraform.Status.LastReplacedResources).To(Equal(value))
+	g.Expect(terraform.Status.Plan.Replaces).To(BeEmpty())
+	value, addresses = pendingReplacements(terraform)
+	g.Expect(value).To(BeEmpty())
+	g.Expect(addresses).To(BeNil())
+
+	// a new value replaces the resources again
+	terraform.Annotations[infrav1.ReplaceResourcesAnnotation] = "aws_instance.web"
+	value, addresses = pendingReplacements(terraform)
+	g.Expect(value).To(Equal("aws_instance.web"))
+	g.Expect(addresses).To(Equal([]string{"aws_instance.web"}))
+
+	terraform.Annotations[infrav1.ReplaceResourcesAnnotation] = " , "
+	_, addresses = pendingReplacements(terraform)
+	g.Expect(addresses).To(BeNil())
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceResourceLimits returns the resource limits of the controller config for the namespace of the object, if any.
+func (r *TerraformReconciler) namespaceResourceLimits(terraform infrav1.Terraform) *infrav1.ResourceLimits {
+	limits, ok := r.Config.Get().NamespaceResourceLimits[terraform.Namespace]
+	if !ok {
+		return nil
+	}
+	return &limits
+}
+
+// checkResourceLimits evaluates the JSON of the plan against the resource limits of a check, if any,
+// and of the namespace of the object. It returns a description of every limit exceeded.
+func (r *TerraformReconciler) checkResourceLimits(ctx context.Context, terraform infrav1.Terraform, objectLimits *infrav1.ResourceLimits, planJSON []byte) (infrav1.Terraform, []string, error) {
+	var plan tfjson.Plan
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return terraform, nil, fmt.Errorf("failed to parse plan file: %w", err)
+	}
+
+	managed := countManagedResources(plan.PlannedValues)
+	terraform.Status.ManagedResources = managed
+
+	var violations []string
+	if objectLimits != nil {
+		violations = append(violations, resourceLimitsViolations(*objectLimits, &plan, managed, "object")...)
+	}
+
+	if limits := r.namespaceResourceLimits(terraform); limits != nil {
+		total := managed
+		if limits.MaxManagedResources != nil {
+			var list infrav1.TerraformList
+			if err := r.List(ctx, &list, client.InNamespace(terraform.Namespace)); err != nil {
+				return terraform, nil, fmt.Errorf("failed to list the Terraform objects of namespace %s: %w", terraform.Namespace, err)
+			}
+			for _, other := range list.Items {
+				if other.Name != terraform.Name {
+					total += other.Status.ManagedResources
+				}
+			}
+		}
+		violations = append(violations, resourceLimitsViolations(*limits, &plan, total, "namespace")...)
+	}
+
+	return terraform, violations, nil
+}
+
+func resourceLimitsViolations(limits infrav1.ResourceLimits, plan *tfjson.Plan, managed int, scope string) []string {
+	var violations []string
+	if limits.MaxManagedResources != nil && managed > *limits.MaxManagedResources {
+		violations = append(violations, fmt.Sprintf("%d managed resources exceed the %s limit of %d", managed, scope, *limits.MaxManagedResources))
+	}
+
+	if forbidden := forbiddenResourceChanges(plan, limits.ForbiddenResourceTypes); len(forbidden) > 0 {
+		violations = append(violations, fmt.Sprintf("resource types forbidden in this %s: %s", scope, strings.Join(forbidden, ", ")))
+	}
+	return violations
+}
+
+// countManagedResources counts the managed resources, not the data sources, that exist once the plan is applied.
+func countManagedResources(values *tfjson.StateValues) int {
+	if values == nil {
+		return 0
+	}
+
+	var count func(module *tfjson.StateModule) int
+	count = func(module *tfjson.StateModule) int {
+		if module == nil {
+			return 0
+		}
+		n := 0
+		for _, resource := range module.Resources {
+			if resource.Mode == tfjson.ManagedResourceMode {
+				n++
+			}
+		}
+		for _, child := range module.ChildModules {
+			n += count(child)
+		}
+		return n
+	}
+	return count(values.RootModule)
+}
+
+// forbiddenResourceChanges returns the addresses of the resources of a forbidden type that the plan creates or updates.
+func forbiddenResourceChanges(plan *tfjson.Plan, patterns []string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var addresses []string
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode != tfjson.ManagedResourceMode || rc.Change == nil {
+			continue
+		}
+		if !rc.Change.Actions.Create() && !rc.Change.Actions.Update() && !rc.Change.Actions.Replace() {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, rc.Type); matched {
+				addresses = append(addresses, rc.Address)
+				break
+			}
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+const resourceLimitsPlanJSON = `{
+  "format_version": "1.0",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {"address": "aws_s3_bucket.a", "mode": "managed", "type": "aws_s3_bucket"},
+        {"address": "data.aws_caller_identity.me", "mode": "data", "type": "aws_caller_identity"}
+      ],
+      "child_modules": [{
+        "resources": [
+          {"address": "module.iam.aws_iam_role.r", "mode": "managed", "type": "aws_iam_role"},
+          {"address": "module.iam.aws_iam_policy.p", "mode": "managed", "type": "aws_iam_policy"}
+        ]
+      }]
+    }
+  },
+  "resource_changes": [
+    {"address": "aws_s3_bucket.a", "mode": "managed", "type": "aws_s3_bucket", "change": {"actions": ["create"]}},
+    {"address": "module.iam.aws_iam_role.r", "mode": "managed", "type": "aws_iam_role", "change": {"actions": ["update"]}},
+    {"address": "module.iam.aws_iam_policy.p", "mode": "managed", "type": "aws_iam_policy", "change": {"actions": ["no-op"]}},
+    {"address": "aws_iam_user.old", "mode": "managed", "type": "aws_iam_user", "change": {"actions": ["delete"]}}
+  ]
+}`
+
+func TestResourceLimitsViolations(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(resourceLimitsPlanJSON), &plan)).To(Succeed())
+
+	managed := countManagedResources(plan.PlannedValues)
+	g.Expect(managed).To(Equal(3))
+	g.Expect(forbiddenResourceChanges(&plan, []string{"aws_iam_*"})).To(Equal([]string{"module.iam.aws_iam_role.r"}))
+
+	three, two := 3, 2
+	g.Expect(resourceLimitsViolations(infrav1.ResourceLimits{MaxManagedResources: &three}, &plan, managed, "object")).To(BeEmpty())
+	g.Expect(resourceLimitsViolations(infrav1.ResourceLimits{
+		MaxManagedResources:    &two,
+		ForbiddenResourceTypes: []string{"aws_iam_role", "aws_s3_*"},
+	}, &plan, managed, "namespace")).To(Equal([]string{
+		"3 managed resources exceed the namespace limit of 2",
+		"resource types forbidden in this namespace: aws_s3_bucket.a, module.iam.aws_iam_role.r",
+	}))
+}
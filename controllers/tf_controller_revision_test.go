@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+func TestParseRevision(t *testing.T) {
+	g := NewWithT(t)
+
+	const sha1 = "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+	const sha256 = "d4f8e5c0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c"
+
+	for revision, expected := range map[string]infrav1.Revision{
+		"master/" + sha1:               {Ref: "master", Algorithm: "sha1", Digest: sha1},
+		"release/v1.2.3/" + sha1:       {Ref: "release/v1.2.3", Algorithm: "sha1", Digest: sha1},
+		sha256:                         {Algorithm: "sha256", Digest: sha256},
+		"latest/" + sha256:             {Ref: "latest", Algorithm: "sha256", Digest: sha256},
+		"main@sha1:" + sha1:            {Ref: "main", Algorithm: "sha1", Digest: sha1},
+		"refs/heads/main@sha1:" + sha1: {Ref: "refs/heads/main", Algorithm: "sha1", Digest: sha1},
+		"sha1:" + sha1:                 {Algorithm: "sha1", Digest: sha1},
+		"sha256:" + sha256:             {Algorithm: "sha256", Digest: sha256},
+		"v1.0.0@sha256:" + sha256:      {Ref: "v1.0.0", Algorithm: "sha256", Digest: sha256},
+		"main/1234":                    {Ref: "main", Digest: "1234"},
+	} {
+		g.Expect(infrav1.ParseRevision(revision)).To(Equal(expected), revision)
+	}
+
+	g.Expect(infrav1.ParseRevision("master/" + sha1).String()).To(Equal("master@sha1:" + sha1))
+	g.Expect(infrav1.ParseRevision("master/" + sha1).ShortDigest()).To(Equal("b8e362c206"))
+}
+
+func TestGetPlanIdAndApproveMessage(t *testing.T) {
+	g := NewWithT(t)
+
+	const sha1 = "b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+
+	// the older and the newer formats of a revision have the same plan id
+	for _, revision := range []string{"master/" + sha1, "master@sha1:" + sha1} {
+		planId, message := infrav1.GetPlanIdAndApproveMessage(revision, "Plan generated")
+		g.Expect(planId).To(Equal("plan-master-b8e362c206-abbb366287"))
+		g.Expect(message).To(Equal("Plan generated: set approvePlan: \"plan-master-b8e362c206\" to approve this plan."))
+	}
+
+	// the refs with slashes, and the long ones, are told apart by the hash
+	planId, message := infrav1.GetPlanIdAndApproveMessage("release/v1.2.3/"+sha1, "Plan generated")
+	g.Expect(planId).To(MatchRegexp(`^plan-release-v1\.2\.3-b8e362c206-[0-9a-f]{10}$`))
+	g.Expect(message).To(ContainSubstring(`"plan-release-v1.2.3-b8e362c206"`))
+	long1, _ := infrav1.GetPlanIdAndApproveMessage("feature/a-very-long-branch-name-number-1@sha1:"+sha1, "")
+	long2, _ := infrav1.GetPlanIdAndApproveMessage("feature/a-very-long-branch-name-number-2@sha1:"+sha1, "")
+	g.Expect(long1).ToNot(Equal(long2))
+	g.Expect(len(long1)).To(BeNumerically("<=", 63))
+
+	planId, _ = infrav1.GetPlanIdAndApproveMessage("sha256:"+sha1, "")
+	g.Expect(planId).To(MatchRegexp(`^plan-b8e362c206-[0-9a-f]{10}$`))
+}
+
+func TestPlanIdMatches(t *testing.T) {
+	g := NewWithT(t)
+
+	const revision = "master/b8e362c206e3d0cbb7ed22ced771a0056455a2fb"
+	planId, _ := infrav1.GetPlanIdAndApproveMessage(revision, "")
+
+	for _, id := range []string{
+		planId,
+		"plan-master-b8e362c206",
+		// the ids given by the previous versions
+		"plan-master-b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		"plan-master-b8e362c206e3",
+	} {
+		g.Expect(infrav1.PlanIdMatches(id, planId, revision)).To(BeTrue(), id)
+	}
+
+	for _, id := range []string{"", "plan-master-4d5e6f7a8b", "destroy-master-b8e362c206"} {
+		g.Expect(infrav1.PlanIdMatches(id, planId, revision)).To(BeFalse(), id)
+	}
+	// the revision must be the one of the plan
+	g.Expect(infrav1.PlanIdMatches("plan-master-b8e362c206e3", planId, "master/4d5e6f7a8b")).To(BeFalse())
+	// the pending plans of the previous versions keep their ids
+	g.Expect(infrav1.PlanIdMatches("plan-main-abc", "plan-main-abc", "main/abc")).To(BeTrue())
+}
+
+func TestNormalizeRevisions(t *testing.T) {
+	g := NewWithT(t)
+
+	status := infrav1.TerraformStatus{
+		LastAppliedRevision:   "main/b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		LastAttemptedRevision: "main@sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738",
+	}
+	status.NormalizeRevisions()
+	g.Expect(status.Revisions).To(Equal(&infrav1.RevisionsStatus{
+		LastApplied: &infrav1.RevisionStatus{
+			Revision: "main@sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+			Ref:      "main",
+			Digest:   "sha1:b8e362c206e3d0cbb7ed22ced771a0056455a2fb",
+		},
+		LastAttempted: &infrav1.RevisionStatus{
+			Revision: "main@sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738",
+			Ref:      "main",
+			Digest:   "sha1:5394cb7f48332b2de7c17dd8b8384bbc84b7e738",
+		},
+	}))
+
+	status = infrav1.TerraformStatus{}
+	status.NormalizeRevisions()
+	g.Expect(status.Revisions).To(BeNil())
+}
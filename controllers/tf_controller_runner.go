@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"time"
 
 	"github.com/fluxcd/pkg/runtime/logger"
@@ -12,6 +13,7 @@ import (
 	"github.com/weaveworks/tf-controller/mtls"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,8 +28,11 @@ func getRunnerPodObjectKey(terraform v1alpha1.Terraform) types.NamespacedName {
 	return types.NamespacedName{Namespace: terraform.Namespace, Name: fmt.Sprintf("%s-tf-runner", terraform.Name)}
 }
 
-func getRunnerPodImage(image string) string {
+func getRunnerPodImage(image string, defaultImage string) string {
 	runnerPodImage := image
+	if runnerPodImage == "" {
+		runnerPodImage = defaultImage
+	}
 	if runnerPodImage == "" {
 		runnerPodImage = os.Getenv("RUNNER_POD_IMAGE")
 	}
@@ -82,6 +87,16 @@ func (r *TerraformReconciler) LookupOrCreateRunner(ctx context.Context, terrafor
 		traceLog.Info("Local Runner, set hostname")
 		hostname = "localhost"
 	} else {
+		traceLog.Info("Reconcile the runner service account")
+		if err := r.reconcileRunnerServiceAccount(ctx, terraform); err != nil {
+			traceLog.Error(err, "Hit an error")
+			return nil, nil, err
+		}
+		traceLog.Info("Reconcile the working directory volume")
+		if err := r.reconcileWorkingDirPVC(ctx, terraform); err != nil {
+			traceLog.Error(err, "Hit an error")
+			return nil, nil, err
+		}
 		traceLog.Info("Get Runner pod IP")
 		podIP, err := r.reconcileRunnerPod(ctx, terraform, secret)
 		traceLog.Info("Check for an error")
@@ -140,19 +155,30 @@ func (r *TerraformReconciler) getRunnerConnection(ctx context.Context, tlsSecret
   }
 }]}`
 
-	traceLog.Info("Return dial context")
-	return grpc.DialContext(ctx, addr,
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(credentials),
 		grpc.WithBlock(),
 		grpc.WithDefaultServiceConfig(retryPolicy),
-	)
+	}
+	if r.RunnerHeartbeatInterval > 0 {
+		// ping the runner on long calls, to fail them when the connection is lost instead of waiting forever
+		interval := r.RunnerHeartbeatInterval
+		if interval < mtls.MinKeepaliveInterval {
+			interval = mtls.MinKeepaliveInterval
+		}
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                interval,
+			Timeout:             interval,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	traceLog.Info("Return dial context")
+	return grpc.DialContext(ctx, addr, opts...)
 }
 
 func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSecretName string) v1.PodSpec {
-	serviceAccountName := terraform.Spec.ServiceAccountName
-	if serviceAccountName == "" {
-		serviceAccountName = "tf-runner"
-	}
+	serviceAccountName := terraform.RunnerServiceAccountName()
 
 	gracefulTermPeriod := terraform.Spec.RunnerTerminationGracePeriodSeconds
 	envvars := []v1.EnvVar{}
@@ -184,6 +210,12 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 		}
 	}
 
+	tokenVolumes, tokenVolumeMounts := runnerTokenVolumes(terraform)
+	if len(tokenVolumes) != 0 {
+		envvarsMap["TF_RUNNER_TOKEN_FILE"] = v1.EnvVar{Name: "TF_RUNNER_TOKEN_FILE", Value: path.Join(runnerTokenMountPath, runnerTokenFile)}
+		envvarsMap["TF_RUNNER_TOKEN_AUDIENCE"] = v1.EnvVar{Name: "TF_RUNNER_TOKEN_AUDIENCE", Value: terraform.RunnerTokenAudience()}
+	}
+
 	for _, env := range terraform.Spec.RunnerPodTemplate.Spec.Env {
 		envvarsMap[env.Name] = env
 	}
@@ -196,12 +228,20 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 	vTrue := true
 	vUser := int64(65532)
 
+	tempVolumeSource := v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}
+	var podSecurityContext *v1.PodSecurityContext
+	if terraform.Spec.HasPersistentWorkingDir() {
+		tempVolumeSource = v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: terraform.WorkingDirPVCName()},
+		}
+		// let the runner write to the volume
+		podSecurityContext = &v1.PodSecurityContext{FSGroup: &vUser}
+	}
+
 	podVolumes := []v1.Volume{
 		{
-			Name: "temp",
-			VolumeSource: v1.VolumeSource{
-				EmptyDir: &v1.EmptyDirVolumeSource{},
-			},
+			Name:         "temp",
+			VolumeSource: tempVolumeSource,
 		},
 		{
 			Name: "home",
@@ -210,6 +250,7 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 			},
 		},
 	}
+	podVolumes = append(podVolumes, tokenVolumes...)
 	if len(terraform.Spec.RunnerPodTemplate.Spec.Volumes) != 0 {
 		podVolumes = append(podVolumes, terraform.Spec.RunnerPodTemplate.Spec.Volumes...)
 	}
@@ -223,10 +264,17 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 			MountPath: "/home/runner",
 		},
 	}
+	podVolumeMounts = append(podVolumeMounts, tokenVolumeMounts...)
 	if len(terraform.Spec.RunnerPodTemplate.Spec.VolumeMounts) != 0 {
 		podVolumeMounts = append(podVolumeMounts, terraform.Spec.RunnerPodTemplate.Spec.VolumeMounts...)
 	}
 
+	// the scoped tokens are projected in place of the automounted token
+	var automountToken *bool
+	if len(tokenVolumes) != 0 {
+		automountToken = &vFalse
+	}
+
 	return v1.PodSpec{
 		TerminationGracePeriodSeconds: gracefulTermPeriod,
 		InitContainers:                terraform.Spec.RunnerPodTemplate.Spec.InitContainers,
@@ -238,7 +286,7 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 					"--tls-secret-name", tlsSecretName,
 					"--grpc-max-message-size", fmt.Sprintf("%d", r.RunnerGRPCMaxMessageSize),
 				},
-				Image:           getRunnerPodImage(terraform.Spec.RunnerPodTemplate.Spec.Image),
+				Image:           getRunnerPodImage(terraform.Spec.RunnerPodTemplate.Spec.Image, r.Config.Get().RunnerImage),
 				ImagePullPolicy: v1.PullIfNotPresent,
 				Ports: []v1.ContainerPort{
 					{
@@ -265,11 +313,13 @@ func (r *TerraformReconciler) runnerPodSpec(terraform v1alpha1.Terraform, tlsSec
 				VolumeMounts: podVolumeMounts,
 			},
 		},
-		Volumes:            podVolumes,
-		ServiceAccountName: serviceAccountName,
-		NodeSelector:       terraform.Spec.RunnerPodTemplate.Spec.NodeSelector,
-		Affinity:           terraform.Spec.RunnerPodTemplate.Spec.Affinity,
-		Tolerations:        terraform.Spec.RunnerPodTemplate.Spec.Tolerations,
+		Volumes:                      podVolumes,
+		SecurityContext:              podSecurityContext,
+		ServiceAccountName:           serviceAccountName,
+		AutomountServiceAccountToken: automountToken,
+		NodeSelector:                 terraform.Spec.RunnerPodTemplate.Spec.NodeSelector,
+		Affinity:                     terraform.Spec.RunnerPodTemplate.Spec.Affinity,
+		Tolerations:                  terraform.Spec.RunnerPodTemplate.Spec.Tolerations,
 	}
 }
 
@@ -298,6 +348,15 @@ func (r *TerraformReconciler) reconcileRunnerPod(ctx context.Context, terraform
 		runnerPodTemplate := runnerPodTemplate(terraform, tlsSecretName)
 		newRunnerPod := *runnerPodTemplate.DeepCopy()
 		newRunnerPod.Spec = r.runnerPodSpec(terraform, tlsSecretName)
+
+		providerConfigs, err := r.getProviderConfigs(ctx, terraform)
+		if err != nil {
+			return err
+		}
+		volumes, mounts := workloadIdentityVolumes(providerConfigs)
+		newRunnerPod.Spec.Volumes = append(newRunnerPod.Spec.Volumes, volumes...)
+		newRunnerPod.Spec.Containers[0].VolumeMounts = append(newRunnerPod.Spec.Containers[0].VolumeMounts, mounts...)
+
 		if err := r.Create(ctx, &newRunnerPod); err != nil {
 			return err
 		}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	runnerServiceAccountLabel = "tf.weave.works/runner-service-account"
+	defaultRunnerRoleName     = "tf-runner-role"
+
+	runnerTokenMountPath         = "/var/run/secrets/tf-controller/runner"
+	runnerTokenFile              = "token"
+	runnerTokenExpirationSeconds = int64(3600)
+	kubeAPITokenMountPath        = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// reconcileRunnerServiceAccount creates the ServiceAccount of the runner Pod, and its RoleBindings,
+// if the object has runner.createServiceAccount set. They are owned by the object, so that they are deleted with it.
+// The RoleBindings no longer in the templates are deleted. A ServiceAccount or a RoleBinding of the same name
+// not owned by the object is never used nor changed, and only the roles allowed by the admin are bound.
+func (r *TerraformReconciler) reconcileRunnerServiceAccount(ctx context.Context, terraform infrav1.Terraform) error {
+	if terraform.Spec.Runner == nil || !terraform.Spec.Runner.CreateServiceAccount {
+		return nil
+	}
+	name := terraform.RunnerServiceAccountName()
+
+	bindings := runnerRoleBindings(terraform)
+	for _, rb := range bindings {
+		if !r.runnerRoleAllowed(rb.RoleRef.Name) {
+			return fmt.Errorf("the %s %s is not allowed to be bound to the runner service account, see --allowed-runner-roles",
+				rb.RoleRef.Kind, rb.RoleRef.Name)
+		}
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/created-by": "tf-controller",
+		"app.kubernetes.io/name":       "tf-runner",
+		runnerServiceAccountLabel:      name,
+	}
+
+	var sa v1.ServiceAccount
+	err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: name}, &sa)
+	if apierrors.IsNotFound(err) {
+		sa = v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: terraform.Namespace, Labels: labels}}
+		if err := controllerutil.SetOwnerReference(&terraform, &sa, r.Scheme); err != nil {
+			return err
+		}
+		err = r.Create(ctx, &sa)
+	}
+	if err != nil {
+		return fmt.Errorf("error reconciling the runner service account %s: %w", name, err)
+	}
+	if !isOwnedBy(&sa, terraform) {
+		return fmt.Errorf("the service account %s already exists and is not owned by this object", name)
+	}
+
+	desired := map[string]rbacv1.RoleBinding{}
+	for _, rb := range bindings {
+		desired[rb.Name] = rb
+	}
+
+	var existing rbacv1.RoleBindingList
+	if err := r.List(ctx, &existing, client.InNamespace(terraform.Namespace), client.MatchingLabels{runnerServiceAccountLabel: name}); err != nil {
+		return err
+	}
+	for i := range existing.Items {
+		rb := &existing.Items[i]
+		if !isOwnedBy(rb, terraform) {
+			continue
+		}
+		want, ok := desired[rb.Name]
+		if ok && rb.RoleRef == want.RoleRef {
+			delete(desired, rb.Name)
+			continue
+		}
+		// the role of a RoleBinding can't be changed, it is created again
+		if err := r.Delete(ctx, rb); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting the runner role binding %s: %w", rb.Name, err)
+		}
+	}
+
+	for _, rb := range desired {
+		rb := rb
+		rb.Labels = labels
+		if err := controllerutil.SetOwnerReference(&terraform, &rb, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, &rb); err != nil {
+			return fmt.Errorf("error creating the runner role binding %s: %w", rb.Name, err)
+		}
+	}
+	return nil
+}
+
+// runnerRoleAllowed tells whether the role of name may be bound to the ServiceAccounts created for the runners.
+func (r *TerraformReconciler) runnerRoleAllowed(name string) bool {
+	allowed := r.AllowedRunnerRoles
+	if len(allowed) == 0 {
+		allowed = []string{defaultRunnerRoleName}
+	}
+	for _, role := range allowed {
+		if role == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isOwnedBy tells whether obj has an owner reference to the object.
+func isOwnedBy(obj metav1.Object, terraform infrav1.Terraform) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == terraform.UID && ref.Kind == infrav1.TerraformKind {
+			return true
+		}
+	}
+	return false
+}
+
+// runnerRoleBindings returns the RoleBindings of the ServiceAccount created for the runner Pod, from their templates.
+func runnerRoleBindings(terraform infrav1.Terraform) []rbacv1.RoleBinding {
+	templates := terraform.Spec.Runner.RoleBindings
+	if len(templates) == 0 {
+		templates = []infrav1.RunnerRoleBinding{{Kind: "ClusterRole", Name: defaultRunnerRoleName}}
+	}
+
+	name := terraform.RunnerServiceAccountName()
+	var bindings []rbacv1.RoleBinding
+	for _, t := range templates {
+		kind := t.Kind
+		if kind == "" {
+			kind = "ClusterRole"
+		}
+		bindings = append(bindings, rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%s", name, strings.ToLower(kind), t.Name),
+				Namespace: terraform.Namespace,
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: kind, Name: t.Name},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: terraform.Namespace},
+			},
+		})
+	}
+	return bindings
+}
+
+// runnerTokenVolumes returns the volumes, and their mounts, of the tokens of the runner Pod with runner.scopedToken set.
+// The token of the Kubernetes API is projected in place of the automounted one, so that it is bound to the Pod
+// and expires, and a token with the audience of the object is projected next to it.
+func runnerTokenVolumes(terraform infrav1.Terraform) ([]v1.Volume, []v1.VolumeMount) {
+	if terraform.Spec.Runner == nil || terraform.Spec.Runner.ScopedToken == nil {
+		return nil, nil
+	}
+	expirationSeconds := runnerTokenExpirationSeconds
+	if terraform.Spec.Runner.ScopedToken.ExpirationSeconds != nil {
+		expirationSeconds = *terraform.Spec.Runner.ScopedToken.ExpirationSeconds
+	}
+
+	volumes := []v1.Volume{
+		{
+			Name: "kube-api-token",
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{
+							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+								ExpirationSeconds: &expirationSeconds,
+								Path:              "token",
+							},
+						},
+						{
+							ConfigMap: &v1.ConfigMapProjection{
+								LocalObjectReference: v1.LocalObjectReference{Name: "kube-root-ca.crt"},
+								Items:                []v1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+							},
+						},
+						{
+							DownwardAPI: &v1.DownwardAPIProjection{
+								Items: []v1.DownwardAPIVolumeFile{
+									{Path: "namespace", FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "runner-token",
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{
+							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+								Audience:          terraform.RunnerTokenAudience(),
+								ExpirationSeconds: &expirationSeconds,
+								Path:              runnerTokenFile,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	mounts := []v1.VolumeMount{
+		{Name: "kube-api-token", MountPath: kubeAPITokenMountPath, ReadOnly: true},
+		{Name: "runner-token", MountPath: runnerTokenMountPath, ReadOnly: true},
+	}
+	return volumes, mounts
+}
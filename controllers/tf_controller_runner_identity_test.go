@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileRunnerServiceAccount(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec: infrav1.TerraformSpec{
+			ServiceAccountName: "tf-runner",
+			Runner:             &infrav1.RunnerSpec{CreateServiceAccount: true},
+		},
+	}
+	r := &TerraformReconciler{
+		Client:             fake.NewClientBuilder().WithScheme(testScheme).Build(),
+		Scheme:             testScheme,
+		AllowedRunnerRoles: []string{"tf-runner-role", "terraform"},
+	}
+
+	g.Expect(r.reconcileRunnerServiceAccount(ctx, terraform)).To(Succeed())
+	var sa v1.ServiceAccount
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-runner"}, &sa)).To(Succeed())
+	g.Expect(sa.OwnerReferences).To(HaveLen(1))
+
+	var rbList rbacv1.RoleBindingList
+	g.Expect(r.List(ctx, &rbList, client.InNamespace("flux-system"))).To(Succeed())
+	g.Expect(rbList.Items).To(HaveLen(1))
+	g.Expect(rbList.Items[0].Name).To(Equal("helloworld-tf-runner-clusterrole-tf-runner-role"))
+	g.Expect(rbList.Items[0].Subjects[0].Name).To(Equal("helloworld-tf-runner"))
+
+	// the role bindings follow their templates
+	terraform.Spec.Runner.RoleBindings = []infrav1.RunnerRoleBinding{
+		{Kind: "Role", Name: "terraform"},
+		{Name: "tf-runner-role"},
+	}
+	g.Expect(r.reconcileRunnerServiceAccount(ctx, terraform)).To(Succeed())
+	terraform.Spec.Runner.RoleBindings = terraform.Spec.Runner.RoleBindings[:1]
+	g.Expect(r.reconcileRunnerServiceAccount(ctx, terraform)).To(Succeed())
+	g.Expect(r.List(ctx, &rbList, client.InNamespace("flux-system"))).To(Succeed())
+	g.Expect(rbList.Items).To(HaveLen(1))
+	g.Expect(rbList.Items[0].RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "terraform"}))
+
+	// the roles not allowed by the admin are not bound
+	terraform.Spec.Runner.RoleBindings = []infrav1.RunnerRoleBinding{{Name: "cluster-admin"}}
+	g.Expect(r.reconcileRunnerServiceAccount(ctx, terraform)).To(MatchError(ContainSubstring("ClusterRole cluster-admin is not allowed")))
+	g.Expect(r.List(ctx, &rbList, client.InNamespace("flux-system"))).To(Succeed())
+	g.Expect(rbList.Items).To(HaveLen(1))
+	terraform.Spec.Runner.RoleBindings = []infrav1.RunnerRoleBinding{{Kind: "Role", Name: "terraform"}}
+
+	podSpec := r.runnerPodSpec(terraform, "tls")
+	g.Expect(podSpec.ServiceAccountName).To(Equal("helloworld-tf-runner"))
+	g.Expect(podSpec.AutomountServiceAccountToken).To(BeNil())
+}
+
+func TestReconcileRunnerServiceAccountNotOwned(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec:       infrav1.TerraformSpec{Runner: &infrav1.RunnerSpec{CreateServiceAccount: true}},
+	}
+	// a ServiceAccount of the same name, e.g. created by another tenant, with its own permissions
+	sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "helloworld-tf-runner", Namespace: "flux-system"}}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(sa).Build(), Scheme: testScheme}
+
+	g.Expect(r.reconcileRunnerServiceAccount(ctx, terraform)).To(MatchError(ContainSubstring("is not owned by this object")))
+	var rbList rbacv1.RoleBindingList
+	g.Expect(r.List(ctx, &rbList, client.InNamespace("flux-system"))).To(Succeed())
+	g.Expect(rbList.Items).To(BeEmpty())
+}
+
+func TestRunnerScopedToken(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ServiceAccountName: "shared",
+			Runner:             &infrav1.RunnerSpec{ScopedToken: &infrav1.RunnerScopedToken{}},
+		},
+	}
+	r := &TerraformReconciler{}
+
+	podSpec := r.runnerPodSpec(terraform, "tls")
+	g.Expect(podSpec.ServiceAccountName).To(Equal("shared"))
+	g.Expect(*podSpec.AutomountServiceAccountToken).To(BeFalse())
+
+	audiences := map[string]string{}
+	for _, volume := range podSpec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				audiences[volume.Name] = source.ServiceAccountToken.Audience
+				g.Expect(*source.ServiceAccountToken.ExpirationSeconds).To(Equal(int64(3600)))
+			}
+		}
+	}
+	g.Expect(audiences).To(Equal(map[string]string{
+		"kube-api-token": "",
+		"runner-token":   "tf-runner/flux-system/helloworld",
+	}))
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(v1.VolumeMount{
+		Name: "kube-api-token", MountPath: "/var/run/secrets/kubernetes.io/serviceaccount", ReadOnly: true,
+	}))
+	g.Expect(podSpec.Containers[0].Env).To(ContainElement(v1.EnvVar{
+		Name: "TF_RUNNER_TOKEN_FILE", Value: "/var/run/secrets/tf-controller/runner/token",
+	}))
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/status"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+)
+
+// runnerInterrupted reports whether the runner interrupted the command, because its pod is being terminated,
+// e.g. when its node is drained or preempted.
+func runnerInterrupted(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	for _, detail := range st.Details() {
+		switch reply := detail.(type) {
+		case *runner.ApplyReply:
+			return reply.Interrupted
+		case *runner.DestroyReply:
+			return reply.Interrupted
+		}
+	}
+	return false
+}
+
+// applyInterrupted records the interrupted apply. It is not a failure of the object, which is reconciled again
+// right away, with a new runner pod scheduled away from the terminated one.
+func (r *TerraformReconciler) applyInterrupted(ctx context.Context, terraform infrav1.Terraform, revision string, err error) (infrav1.Terraform, error) {
+	r.event(ctx, terraform, revision, events.EventSeverityInfo,
+		"Apply interrupted by the termination of the runner, the state has been saved and its lock released", nil)
+	return infrav1.TerraformApplyInterrupted(terraform, revision, err.Error()), err
+}
+
+// isApplyInterrupted reports whether the last reconciliation ended with an interrupted apply.
+func isApplyInterrupted(terraform infrav1.Terraform) bool {
+	condition := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	return condition != nil && condition.Reason == infrav1.ApplyInterruptedReason
+}
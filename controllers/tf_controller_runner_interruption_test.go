@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplyInterrupted(t *testing.T) {
+	g := NewWithT(t)
+
+	st, err := status.New(codes.Internal, "exit status 1").WithDetails(&runner.ApplyReply{Message: "interrupted", Interrupted: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(runnerInterrupted(st.Err())).To(BeTrue())
+
+	st, err = status.New(codes.Internal, "exit status 1").WithDetails(&runner.DestroyReply{Message: "not ok", StateLockIdentifier: "f2ab685b"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(runnerInterrupted(st.Err())).To(BeFalse())
+	g.Expect(runnerInterrupted(errors.New("exit status 1"))).To(BeFalse())
+
+	r := &TerraformReconciler{EventRecorder: record.NewFakeRecorder(10)}
+	terraform := infrav1.Terraform{Status: infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-abc"}}}
+	terraform, err = r.applyInterrupted(context.TODO(), terraform, "main/abc", errors.New("error running Apply: interrupted"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(isApplyInterrupted(terraform)).To(BeTrue())
+
+	// a later failure is not mistaken for the interruption
+	terraform = infrav1.TerraformNotReady(terraform, "main/abc", infrav1.TFExecPlanFailedReason, "error running Plan")
+	g.Expect(isApplyInterrupted(terraform)).To(BeFalse())
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// keepFailedRunnerPodFor returns how long the runner Pod of a failed reconciliation is kept, zero when it is not.
+func keepFailedRunnerPodFor(terraform infrav1.Terraform) time.Duration {
+	if !terraform.Spec.GetAlwaysCleanupRunnerPod() || terraform.Spec.Runner == nil || terraform.Spec.Runner.KeepFailedPodsFor == nil {
+		return 0
+	}
+	return terraform.Spec.Runner.KeepFailedPodsFor.Duration
+}
+
+// runnerPodKeptUntil returns the time a runner Pod is kept until, zero when it is not kept.
+func runnerPodKeptUntil(pod corev1.Pod) time.Time {
+	value, ok := pod.Annotations[infrav1.KeepRunnerPodUntilAnnotation]
+	if !ok {
+		return time.Time{}
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// an invalid annotation releases the Pod
+		return time.Time{}
+	}
+	return until
+}
+
+// keepFailedRunnerPod annotates the runner Pod of a failed reconciliation with the end of the period it is kept for.
+func (r *TerraformReconciler) keepFailedRunnerPod(ctx context.Context, terraform infrav1.Terraform, revision string, now time.Time) error {
+	var runnerPod corev1.Pod
+	if err := r.Get(ctx, getRunnerPodObjectKey(terraform), &runnerPod); err != nil {
+		return err
+	}
+
+	until := now.Add(keepFailedRunnerPodFor(terraform)).UTC().Truncate(time.Second)
+	patch := client.MergeFrom(runnerPod.DeepCopy())
+	if runnerPod.Annotations == nil {
+		runnerPod.Annotations = map[string]string{}
+	}
+	runnerPod.Annotations[infrav1.KeepRunnerPodUntilAnnotation] = until.Format(time.RFC3339)
+	if err := r.Patch(ctx, &runnerPod, patch); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Runner pod %s of the failed reconciliation kept until %s, the reconciliations resume once it is deleted",
+		runnerPod.Name, until.Format(time.RFC3339))
+	ctrl.LoggerFrom(ctx).Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	return nil
+}
+
+// waitForKeptRunnerPod returns how long the reconciliation must wait for the kept runner Pod of the object,
+// zero when there is none. The Pod of an elapsed period is deleted.
+func (r *TerraformReconciler) waitForKeptRunnerPod(ctx context.Context, terraform infrav1.Terraform, now time.Time) (time.Duration, error) {
+	var runnerPod corev1.Pod
+	if err := r.Get(ctx, getRunnerPodObjectKey(terraform), &runnerPod); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+
+	until := runnerPodKeptUntil(runnerPod)
+	if until.IsZero() || runnerPod.DeletionTimestamp != nil {
+		return 0, nil
+	}
+	if now.Before(until) {
+		return until.Sub(now), nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("deleting the kept runner pod", "keep-until", until.Format(time.RFC3339))
+	if err := r.Delete(ctx, &runnerPod, client.GracePeriodSeconds(1)); err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+	return 0, nil
+}
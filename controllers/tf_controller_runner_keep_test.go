@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeepFailedRunnerPodFor(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{
+		Runner: &infrav1.RunnerSpec{KeepFailedPodsFor: &metav1.Duration{Duration: time.Hour}},
+	}}
+	g.Expect(keepFailedRunnerPodFor(terraform)).To(Equal(time.Hour))
+
+	// the runner pods that are not cleaned up are not kept either
+	alwaysCleanup := false
+	terraform.Spec.AlwaysCleanupRunnerPod = &alwaysCleanup
+	g.Expect(keepFailedRunnerPodFor(terraform)).To(BeZero())
+
+	alwaysCleanup = true
+	terraform.Spec.Runner = nil
+	g.Expect(keepFailedRunnerPodFor(terraform)).To(BeZero())
+}
+
+func TestKeepFailedRunnerPod(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	alwaysCleanup := true
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			AlwaysCleanupRunnerPod: &alwaysCleanup,
+			Runner:                 &infrav1.RunnerSpec{KeepFailedPodsFor: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	podKey := getRunnerPodObjectKey(terraform)
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podKey.Name, Namespace: podKey.Namespace}}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(pod).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	// a runner pod that is not kept is not waited for
+	now := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+	keptFor, err := r.waitForKeptRunnerPod(ctx, terraform, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keptFor).To(BeZero())
+
+	g.Expect(r.keepFailedRunnerPod(ctx, terraform, "main/b8e362c206", now)).To(Succeed())
+	var keptPod v1.Pod
+	g.Expect(r.Get(ctx, podKey, &keptPod)).To(Succeed())
+	g.Expect(keptPod.Annotations).To(HaveKeyWithValue(infrav1.KeepRunnerPodUntilAnnotation, "2023-02-01T11:00:00Z"))
+	g.Expect(runnerPodKeptUntil(keptPod)).To(BeTemporally("==", now.Add(time.Hour)))
+
+	// a kept runner pod is waited for until the end of its period
+	keptFor, err = r.waitForKeptRunnerPod(ctx, terraform, now.Add(20*time.Minute))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keptFor).To(Equal(40 * time.Minute))
+	g.Expect(r.Get(ctx, podKey, &keptPod)).To(Succeed())
+
+	// and deleted after it
+	keptFor, err = r.waitForKeptRunnerPod(ctx, terraform, now.Add(time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keptFor).To(BeZero())
+	err = r.Get(ctx, podKey, &keptPod)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// there is nothing to wait for without a runner pod
+	keptFor, err = r.waitForKeptRunnerPod(ctx, terraform, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keptFor).To(BeZero())
+
+	// nor with an invalid annotation
+	g.Expect(runnerPodKeptUntil(v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{infrav1.KeepRunnerPodUntilAnnotation: "tomorrow"},
+	}})).To(BeZero())
+}
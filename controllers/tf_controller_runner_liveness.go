@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// runnerNotReadyHeartbeats is the number of heartbeats in a row that a runner pod may be not ready for,
+// e.g. while its node is briefly unreachable, before it is considered lost.
+const runnerNotReadyHeartbeats = 3
+
+// watchRunner checks that the runner pod of the object stays alive every RunnerHeartbeatInterval, and calls lost
+// once it is not, e.g. after its node crashed, so that the call made to the runner is not waited for indefinitely.
+// The returned function stops the watch, and returns why the runner was lost, if it was.
+func (r *TerraformReconciler) watchRunner(ctx context.Context, terraform infrav1.Terraform, lost context.CancelFunc) func() string {
+	log := ctrl.LoggerFrom(ctx)
+	podKey := getRunnerPodObjectKey(terraform)
+
+	if r.RunnerHeartbeatInterval <= 0 || os.Getenv("INSECURE_LOCAL_RUNNER") == "1" {
+		return func() string { return "" }
+	}
+	var pod v1.Pod
+	if err := r.Get(ctx, podKey, &pod); err != nil {
+		log.Error(err, "unable to get the runner pod, it is not watched")
+		return func() string { return "" }
+	}
+	uid := pod.UID
+
+	var (
+		mu     sync.Mutex
+		reason string
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.RunnerHeartbeatInterval)
+		defer ticker.Stop()
+
+		notReady := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			msg, ready, err := r.runnerPodAlive(ctx, podKey, uid)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Error(err, "unable to check the runner pod")
+				continue
+			}
+			if msg == "" && !ready {
+				notReady++
+				if notReady < runnerNotReadyHeartbeats {
+					continue
+				}
+				msg = fmt.Sprintf("runner pod %s has not been ready for %s", podKey.Name, time.Duration(notReady)*r.RunnerHeartbeatInterval)
+			}
+			if msg == "" {
+				notReady = 0
+				continue
+			}
+
+			log.Info("runner lost", "reason", msg)
+			mu.Lock()
+			reason = msg
+			mu.Unlock()
+			lost()
+			return
+		}
+	}()
+
+	return func() string {
+		cancel()
+		<-done
+		mu.Lock()
+		defer mu.Unlock()
+		return reason
+	}
+}
+
+// runnerPodAlive returns why the runner pod with the uid is gone, or whether it is ready while it is there.
+func (r *TerraformReconciler) runnerPodAlive(ctx context.Context, podKey types.NamespacedName, uid types.UID) (string, bool, error) {
+	var pod v1.Pod
+	if err := r.Get(ctx, podKey, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("runner pod %s is gone", podKey.Name), false, nil
+		}
+		return "", false, err
+	}
+
+	if pod.UID != uid {
+		return fmt.Sprintf("runner pod %s has been replaced", podKey.Name), false, nil
+	}
+	// a terminating runner stops gracefully, by interrupting the apply, within the grace period of its pod
+	if pod.DeletionTimestamp != nil {
+		if time.Now().Before(pod.DeletionTimestamp.Time) {
+			return "", true, nil
+		}
+		return fmt.Sprintf("runner pod %s has not terminated within its grace period", podKey.Name), false, nil
+	}
+	if pod.Status.Phase == v1.PodFailed || pod.Status.Phase == v1.PodSucceeded {
+		return fmt.Sprintf("runner pod %s has terminated: %s", podKey.Name, pod.Status.Phase), false, nil
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return "", c.Status == v1.ConditionTrue, nil
+		}
+	}
+	return "", true, nil
+}
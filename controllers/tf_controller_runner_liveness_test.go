@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWatchRunner(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}}
+	podKey := getRunnerPodObjectKey(terraform)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: podKey.Name, Namespace: podKey.Namespace, UID: "runner-1"},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+	r := &TerraformReconciler{
+		Client:                  fake.NewClientBuilder().WithScheme(testScheme).WithObjects(pod).Build(),
+		RunnerHeartbeatInterval: 10 * time.Millisecond,
+	}
+
+	// a live runner is not reported
+	lostCtx, lost := context.WithCancel(ctx)
+	stop := r.watchRunner(ctx, terraform, lost)
+	time.Sleep(50 * time.Millisecond)
+	g.Expect(stop()).To(BeEmpty())
+	g.Expect(lostCtx.Err()).ToNot(HaveOccurred())
+
+	// a deleted runner is, and the call made to it is cancelled
+	stop = r.watchRunner(ctx, terraform, lost)
+	g.Expect(r.Delete(ctx, pod)).To(Succeed())
+	g.Eventually(lostCtx.Done(), time.Second).Should(BeClosed())
+	g.Expect(stop()).To(Equal("runner pod helloworld-tf-runner is gone"))
+
+	// so is a runner that stays not ready, once its node is unreachable
+	pod.ResourceVersion = ""
+	pod.Status.Conditions[0].Status = v1.ConditionFalse
+	g.Expect(r.Create(ctx, pod)).To(Succeed())
+	lostCtx, lost = context.WithCancel(ctx)
+	stop = r.watchRunner(ctx, terraform, lost)
+	g.Eventually(lostCtx.Done(), time.Second).Should(BeClosed())
+	g.Expect(stop()).To(ContainSubstring("has not been ready for"))
+
+	msg, _, err := r.runnerPodAlive(ctx, podKey, "runner-0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(msg).To(Equal("runner pod helloworld-tf-runner has been replaced"))
+
+	// the runner is not watched when the heartbeats are disabled
+	r.RunnerHeartbeatInterval = 0
+	lostCtx, lost = context.WithCancel(ctx)
+	stop = r.watchRunner(ctx, terraform, lost)
+	time.Sleep(30 * time.Millisecond)
+	g.Expect(stop()).To(BeEmpty())
+	g.Expect(lostCtx.Err()).ToNot(HaveOccurred())
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	"github.com/fluxcd/pkg/runtime/logger"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// verifyGeneratedSecrets compares the plan and output secrets against the
+// content hash the runner recorded when writing them.
+// A pending plan whose secret is gone or tampered with is dropped, so that it gets re-planned
+// instead of being applied. Outputs are flagged with the OutputsOutOfSync condition,
+// which makes the next reconciliation re-write them.
+func (r *TerraformReconciler) verifyGeneratedSecrets(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.verifyGeneratedSecrets")
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+	revision := terraform.Status.LastAttemptedRevision
+	changed := false
+
+	if terraform.Status.Plan.Pending != "" {
+		traceLog.Info("Verify the plan secret", "pending", terraform.Status.Plan.Pending)
+		planSecretKey := types.NamespacedName{Namespace: terraform.Namespace, Name: "tfplan-" + terraform.WorkspaceName() + "-" + terraform.Name}
+		msg, err := r.verifySecret(ctx, planSecretKey)
+		if err != nil {
+			return terraform, err
+		}
+
+		if msg != "" {
+			msg = fmt.Sprintf("%s, pending plan %s is invalidated", msg, terraform.Status.Plan.Pending)
+			log.Info(msg)
+			r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+			terraform.Status.Plan.Pending = ""
+			changed = true
+		}
+	}
+
+	outputsWritten := apimeta.FindStatusCondition(terraform.Status.Conditions, infrav1.ConditionTypeOutput)
+	if terraform.Spec.WriteOutputsToSecret != nil && !terraform.Spec.Destroy && len(terraform.Status.AvailableOutputs) > 0 &&
+		outputsWritten != nil && outputsWritten.Reason == "TerraformOutputsWritten" &&
+		!apimeta.IsStatusConditionTrue(terraform.Status.Conditions, infrav1.ConditionTypeOutputsOutOfSync) {
+		traceLog.Info("Verify the output secret")
+		outputsSecretKey := types.NamespacedName{Namespace: terraform.OutputsSecretNamespace(), Name: terraform.Spec.WriteOutputsToSecret.Name}
+		msg, err := r.verifySecret(ctx, outputsSecretKey)
+		if err != nil {
+			return terraform, err
+		}
+
+		if msg != "" {
+			reason := infrav1.OutputsModifiedReason
+			if msg == secretNotFoundMessage(outputsSecretKey) {
+				reason = infrav1.OutputsMissingReason
+			}
+			log.Info(msg)
+			r.event(ctx, terraform, revision, events.EventSeverityError, msg, nil)
+			terraform = infrav1.TerraformOutputsOutOfSync(terraform, reason, msg)
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+			log.Error(err, "unable to update status after verifying the generated secrets")
+			return terraform, err
+		}
+	}
+
+	return terraform, nil
+}
+
+// verifySecret returns a non-empty message if the Secret does not exist,
+// or its data does not match the recorded content hash.
+// Secrets without a content hash, e.g. written by an older runner, are not verified.
+func (r *TerraformReconciler) verifySecret(ctx context.Context, key types.NamespacedName) (string, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, key, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return secretNotFoundMessage(key), nil
+		}
+		return "", err
+	}
+
+	if hash, ok := secret.Annotations[runner.ContentHashAnnotation]; ok && hash != utils.ContentHash(secret.Data) {
+		return fmt.Sprintf("Secret %s has been modified out-of-band", key.Name), nil
+	}
+
+	return "", nil
+}
+
+func secretNotFoundMessage(key types.NamespacedName) string {
+	return fmt.Sprintf("Secret %s not found", key.Name)
+}
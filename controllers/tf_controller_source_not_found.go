@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	"github.com/prometheus/client_golang/prometheus"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var sourceNotFoundStalled = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "gotk_terraform_source_not_found_stalled",
+		Help: "Terraform objects stalled as their source has not been found.",
+	},
+	[]string{"namespace", "name"},
+)
+
+func init() {
+	crtlmetrics.Registry.MustRegister(sourceNotFoundStalled)
+}
+
+// sourceNotFound counts the attempts to reconcile the object without its source, and stalls the object
+// after .spec.sourceNotFound.maxAttempts of them. Otherwise, it returns the interval to retry.
+func (r *TerraformReconciler) sourceNotFound(ctx context.Context, terraform infrav1.Terraform) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	msg := fmt.Sprintf("Source '%s' not found", terraform.Spec.SourceRef.String())
+	terraform, attempts := infrav1.TerraformSourceNotFound(terraform, msg)
+	log.Info(msg, "attempts", attempts)
+
+	spec := terraform.Spec.SourceNotFound
+	stalled := spec != nil && spec.MaxAttempts > 0 && attempts >= spec.MaxAttempts
+	if stalled {
+		stalledMsg := fmt.Sprintf("Source '%s' not found %d times in a row, it is not retried until the failures are acknowledged with the %s annotation",
+			terraform.Spec.SourceRef.String(), attempts, infrav1.AcknowledgeFailuresAnnotation)
+		if spec.ResumeWhenFound {
+			stalledMsg += ", or the source appears"
+		}
+		terraform = infrav1.TerraformStalled(terraform, stalledMsg)
+		r.event(ctx, terraform, "", events.EventSeverityError, stalledMsg, nil)
+	}
+
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		log.Error(err, "unable to update status for source not found")
+		return ctrl.Result{Requeue: true}, err
+	}
+	r.recordReadinessMetric(ctx, terraform)
+
+	if stalled {
+		sourceNotFoundStalled.WithLabelValues(terraform.Namespace, terraform.Name).Set(1)
+		return ctrl.Result{}, nil
+	}
+	// do not requeue immediately, when the source is created the watcher should trigger a reconciliation
+	if terraform.IsManualReconciliation() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.sourceNotFoundRetryInterval(terraform, attempts)}, nil
+}
+
+// sourceNotFoundRetryInterval doubles the retry interval at each attempt, up to .spec.interval,
+// unless the backoff is disabled.
+func (r *TerraformReconciler) sourceNotFoundRetryInterval(terraform infrav1.Terraform, attempts int32) time.Duration {
+	interval := r.retryInterval(terraform)
+	if spec := terraform.Spec.SourceNotFound; spec != nil && spec.DisableBackoff {
+		return interval
+	}
+
+	max := terraform.Spec.Interval.Duration
+	for i := int32(1); i < attempts && interval < max; i++ {
+		interval *= 2
+		if interval > max {
+			interval = max
+		}
+	}
+	return interval
+}
+
+// sourceFound clears the attempts counted while the source was not found.
+func (r *TerraformReconciler) sourceFound(ctx context.Context, terraform infrav1.Terraform) (infrav1.Terraform, error) {
+	sourceNotFoundStalled.DeleteLabelValues(terraform.Namespace, terraform.Name)
+	if terraform.Status.Failures.Reason != infrav1.SourceNotFoundReason {
+		return terraform, nil
+	}
+
+	terraform = infrav1.TerraformFailuresReset(terraform)
+	return terraform, r.patchStatus(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, terraform.Status)
+}
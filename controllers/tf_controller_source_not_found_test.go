@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1beta2"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSourceNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(sourcev1.AddToScheme(testScheme)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec: infrav1.TerraformSpec{
+			Interval:       metav1.Duration{Duration: 10 * time.Minute},
+			RetryInterval:  &metav1.Duration{Duration: time.Minute},
+			SourceRef:      infrav1.CrossNamespaceSourceReference{Kind: sourcev1.GitRepositoryKind, Name: "infra"},
+			SourceNotFound: &infrav1.SourceNotFoundSpec{MaxAttempts: 3, ResumeWhenFound: true},
+		},
+	}
+	r := &TerraformReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build(),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+
+	var stored infrav1.Terraform
+	notFound := func() time.Duration {
+		g.Expect(r.Get(ctx, key, &stored)).To(Succeed())
+		result, err := r.sourceNotFound(ctx, stored)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(r.Get(ctx, key, &stored)).To(Succeed())
+		return result.RequeueAfter
+	}
+
+	// the retries back off, then the object is stalled
+	g.Expect(notFound()).To(Equal(time.Minute))
+	g.Expect(notFound()).To(Equal(2 * time.Minute))
+	g.Expect(apimeta.IsStatusConditionTrue(stored.Status.Conditions, meta.StalledCondition)).To(BeFalse())
+	g.Expect(notFound()).To(BeZero())
+	g.Expect(stored.Status.Failures.Reason).To(Equal(infrav1.SourceNotFoundReason))
+	stalledCondition := apimeta.FindStatusCondition(stored.Status.Conditions, meta.StalledCondition)
+	g.Expect(stalledCondition).ToNot(BeNil())
+	g.Expect(stalledCondition.Reason).To(Equal(infrav1.SourceNotFoundReason))
+	ready := apimeta.FindStatusCondition(stored.Status.Conditions, meta.ReadyCondition)
+	g.Expect(ready.Reason).To(Equal(infrav1.ArtifactFailedReason))
+
+	stalled, err := r.isStalled(ctx, &stored)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stalled).To(BeTrue())
+
+	// the object resumes once the source appears
+	g.Expect(r.Create(ctx, &sourcev1.GitRepository{ObjectMeta: metav1.ObjectMeta{Name: "infra", Namespace: "flux-system"}})).To(Succeed())
+	stalled, err = r.isStalled(ctx, &stored)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stalled).To(BeFalse())
+	g.Expect(r.Get(ctx, key, &stored)).To(Succeed())
+	g.Expect(apimeta.FindStatusCondition(stored.Status.Conditions, meta.StalledCondition)).To(BeNil())
+	g.Expect(stored.Status.Failures.Count).To(BeZero())
+}
+
+func TestSourceNotFoundRetryInterval(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{
+		Spec: infrav1.TerraformSpec{
+			Interval:      metav1.Duration{Duration: 10 * time.Minute},
+			RetryInterval: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	r := &TerraformReconciler{}
+
+	g.Expect(r.sourceNotFoundRetryInterval(terraform, 1)).To(Equal(time.Minute))
+	g.Expect(r.sourceNotFoundRetryInterval(terraform, 3)).To(Equal(4 * time.Minute))
+	g.Expect(r.sourceNotFoundRetryInterval(terraform, 5)).To(Equal(10 * time.Minute))
+	g.Expect(r.sourceNotFoundRetryInterval(terraform, 100)).To(Equal(10 * time.Minute))
+
+	terraform.Spec.SourceNotFound = &infrav1.SourceNotFoundSpec{DisableBackoff: true}
+	g.Expect(r.sourceNotFoundRetryInterval(terraform, 5)).To(Equal(time.Minute))
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/runtime/events"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// stateSnapshotID returns the ID of the snapshot of the state taken at now, which sorts by time.
+func stateSnapshotID(now time.Time) string {
+	return now.UTC().Format("20060102-150405")
+}
+
+// shouldBackupState tells whether a snapshot of the state is taken before the applies.
+func (r *TerraformReconciler) shouldBackupState(terraform infrav1.Terraform) bool {
+	return terraform.Spec.StateBackup != nil && !r.backendCompletelyDisable(terraform)
+}
+
+// backupState takes a snapshot of the state before an apply, and records it in the status. The apply does not
+// go on without its snapshot. The snapshots beyond spec.stateBackup.keep are then deleted, oldest first.
+func (r *TerraformReconciler) backupState(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string, now time.Time) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	id := stateSnapshotID(now)
+	log.Info("calling snapshot state ...", "id", id)
+	snapshotReply, err := runnerClient.SnapshotState(ctx, &runner.SnapshotStateRequest{TfInstance: tfInstance, Id: id})
+	if err != nil {
+		err = fmt.Errorf("error taking a snapshot of the state: %s", err)
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.StateBackupFailedReason,
+			err.Error(),
+		), err
+	}
+	if snapshotReply.Empty {
+		log.Info("no state to take a snapshot of")
+		return terraform, nil
+	}
+
+	log.Info("snapshot of the state taken", "id", id, "location", snapshotReply.Location)
+	status := &terraform.Status
+	status.StateSnapshots = append(status.StateSnapshots, infrav1.StateSnapshot{
+		ID:        id,
+		Location:  snapshotReply.Location,
+		Lineage:   snapshotReply.Lineage,
+		Serial:    snapshotReply.Serial,
+		Revision:  revision,
+		CreatedAt: metav1.NewTime(now),
+	})
+
+	keep := terraform.Spec.StateBackup.GetKeep()
+	if len(status.StateSnapshots) <= keep {
+		return terraform, nil
+	}
+	var ids []string
+	for _, snapshot := range status.StateSnapshots[:len(status.StateSnapshots)-keep] {
+		ids = append(ids, snapshot.ID)
+	}
+	// the snapshots not deleted are kept in the status, to be deleted with the next ones
+	deleteReply, err := runnerClient.DeleteStateSnapshots(ctx, &runner.DeleteStateSnapshotsRequest{TfInstance: tfInstance, Ids: ids})
+	if err != nil {
+		log.Error(err, "unable to delete the old snapshots of the state")
+		return terraform, nil
+	}
+	if deleteReply.Message != "" {
+		log.Info(deleteReply.Message)
+	}
+	deleted := map[string]bool{}
+	for _, id := range deleteReply.Deleted {
+		deleted[id] = true
+	}
+	snapshots := status.StateSnapshots[:0]
+	for _, snapshot := range status.StateSnapshots {
+		if !deleted[snapshot.ID] {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	status.StateSnapshots = snapshots
+	return terraform, nil
+}
+
+// shouldRestoreState tells whether the snapshot of spec.restoreStateFrom is still to be restored.
+// Each value of the field is restored once.
+func shouldRestoreState(terraform infrav1.Terraform) bool {
+	return terraform.Spec.StateBackup != nil &&
+		terraform.Spec.RestoreStateFrom != "" &&
+		terraform.Spec.RestoreStateFrom != terraform.Status.LastRestoredStateFrom
+}
+
+// restoreState replaces the state with the snapshot of spec.restoreStateFrom. The object takes over the lineage
+// of the snapshot, and drops its pending plan, which was planned against the replaced state.
+func (r *TerraformReconciler) restoreState(ctx context.Context, terraform infrav1.Terraform, tfInstance string, runnerClient runner.RunnerClient, revision string) (infrav1.Terraform, error) {
+	log := ctrl.LoggerFrom(ctx)
+	objectKey := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}
+
+	id := terraform.Spec.RestoreStateFrom
+	log.Info("calling restore state ...", "id", id)
+	restoreReply, err := runnerClient.RestoreState(ctx, &runner.RestoreStateRequest{TfInstance: tfInstance, Id: id})
+	if err != nil {
+		err = fmt.Errorf("error restoring the state from the snapshot %s: %s", id, err)
+		r.event(ctx, terraform, revision, events.EventSeverityError, err.Error(), nil)
+		return infrav1.TerraformNotReady(
+			terraform,
+			revision,
+			infrav1.StateRestoreFailedReason,
+			err.Error(),
+		), err
+	}
+
+	terraform.Status.LastRestoredStateFrom = id
+	if terraform.StateLineage() != "" {
+		terraform.SetStateLineage(restoreReply.Lineage)
+	}
+	terraform.Status.Plan.Pending = ""
+	if err := r.patchStatus(ctx, objectKey, terraform.Status); err != nil {
+		log.Error(err, "unable to update status after restoring the state")
+		return terraform, err
+	}
+
+	msg := fmt.Sprintf("State restored from the snapshot %s, serial %d", id, restoreReply.Serial)
+	log.Info(msg)
+	r.event(ctx, terraform, revision, events.EventSeverityInfo, msg, nil)
+	return terraform, nil
+}
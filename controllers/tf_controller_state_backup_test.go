@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	fakerunner "github.com/weaveworks/tf-controller/runner/fake"
+	"github.com/weaveworks/tf-controller/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeRunnerClient returns a client of the runner s, served over an in-memory connection.
+func fakeRunnerClient(t *testing.T, s *fakerunner.Server) runner.RunnerClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	runner.RegisterRunnerServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return runner.NewRunnerClient(conn)
+}
+
+func TestShouldRestoreState(t *testing.T) {
+	g := NewWithT(t)
+
+	terraform := infrav1.Terraform{Spec: infrav1.TerraformSpec{RestoreStateFrom: "20230201-100000"}}
+	g.Expect(shouldRestoreState(terraform)).To(BeFalse())
+
+	terraform.Spec.StateBackup = &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{Type: infrav1.SnapshotStorageTypeSecret}}
+	g.Expect(shouldRestoreState(terraform)).To(BeTrue())
+
+	// each value is restored once
+	terraform.Status.LastRestoredStateFrom = "20230201-100000"
+	g.Expect(shouldRestoreState(terraform)).To(BeFalse())
+}
+
+func TestBackupAndRestoreState(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			StateBackup: &infrav1.StateBackup{SnapshotStorage: infrav1.SnapshotStorage{Type: infrav1.SnapshotStorageTypeSecret}, Keep: 2},
+		},
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build()
+
+	s := fakerunner.NewServer(k8sClient)
+	runnerClient := fakeRunnerClient(t, s)
+
+	terraformBytes, err := terraform.ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = s.NewTerraform(ctx, &runner.NewTerraformRequest{WorkingDir: t.TempDir(), Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &TerraformReconciler{Client: k8sClient, EventRecorder: record.NewFakeRecorder(10)}
+	g.Expect(r.shouldBackupState(terraform)).To(BeTrue())
+	now := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	// there is nothing to take a snapshot of before the first apply
+	terraform, err = r.backupState(ctx, terraform, "1", runnerClient, "main/1", now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.StateSnapshots).To(BeEmpty())
+
+	for i := 1; i <= 3; i++ {
+		s.SetResult(key, fakerunner.Result{State: &utils.StateMeta{Lineage: "lineage-a", Serial: uint64(i)}})
+		terraform, err = r.backupState(ctx, terraform, "1", runnerClient, "main/1", now.Add(time.Duration(i)*time.Hour))
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	// the oldest snapshot is deleted beyond spec.stateBackup.keep
+	g.Expect(terraform.Status.StateSnapshots).To(HaveLen(2))
+	g.Expect(terraform.Status.StateSnapshots[0]).To(Equal(infrav1.StateSnapshot{
+		ID:        "20230201-120000",
+		Location:  "fake/flux-system/helloworld/20230201-120000",
+		Lineage:   "lineage-a",
+		Serial:    2,
+		Revision:  "main/1",
+		CreatedAt: metav1.NewTime(now.Add(2 * time.Hour)),
+	}))
+	g.Expect(s.Snapshots(key)).To(Equal([]string{"20230201-120000", "20230201-130000"}))
+
+	// an apply does not go on without its snapshot
+	s.SetResult(key, fakerunner.Result{StateBackupError: errors.New("access denied")})
+	terraform, err = r.backupState(ctx, terraform, "1", runnerClient, "main/1", now.Add(4*time.Hour))
+	g.Expect(err).To(MatchError(ContainSubstring("error taking a snapshot of the state")))
+	g.Expect(terraform.Status.Conditions[0].Reason).To(Equal(infrav1.StateBackupFailedReason))
+	g.Expect(terraform.Status.StateSnapshots).To(HaveLen(2))
+
+	// the object takes over the lineage of the snapshot, and drops its pending plan
+	s.SetResult(key, fakerunner.Result{})
+	terraform.SetStateLineage("lineage-b")
+	terraform.Status.Plan.Pending = "plan-main-2"
+	terraform.Spec.RestoreStateFrom = "20230201-120000"
+	g.Expect(shouldRestoreState(terraform)).To(BeTrue())
+	terraform, err = r.restoreState(ctx, terraform, "1", runnerClient, "main/1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.LastRestoredStateFrom).To(Equal("20230201-120000"))
+	g.Expect(terraform.StateLineage()).To(Equal("lineage-a"))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(shouldRestoreState(terraform)).To(BeFalse())
+
+	var patched infrav1.Terraform
+	g.Expect(k8sClient.Get(ctx, key, &patched)).To(Succeed())
+	g.Expect(patched.Status.LastRestoredStateFrom).To(Equal("20230201-120000"))
+
+	// the next snapshot is of the restored state
+	terraform, err = r.backupState(ctx, terraform, "1", runnerClient, "main/1", now.Add(5*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(terraform.Status.StateSnapshots[1].Serial).To(Equal(uint64(2)))
+
+	terraform.Spec.RestoreStateFrom = "20230201-110000"
+	terraform, err = r.restoreState(ctx, terraform, "1", runnerClient, "main/1")
+	g.Expect(err).To(MatchError(ContainSubstring("error restoring the state from the snapshot 20230201-110000")))
+	g.Expect(terraform.Status.Conditions[0].Reason).To(Equal(infrav1.StateRestoreFailedReason))
+	g.Expect(terraform.Status.LastRestoredStateFrom).To(Equal("20230201-120000"))
+}
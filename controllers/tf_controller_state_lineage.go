@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fluxcd/pkg/runtime/logger"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// tfstateSecretKey is the data key under which the kubernetes backend stores the gzipped state.
+const tfstateSecretKey = "tfstate"
+
+// checkStateLineage makes taking over an existing state an explicit decision.
+// A newly created object that finds a state under its secretSuffix and workspace is blocked,
+// unless .spec.backendConfig.adoptExistingState is set. An empty state, of serial 0 without resources,
+// is not a take-over, and its lineage is recorded. Once the lineage of the state is recorded,
+// a state with a different lineage blocks the reconciliation too, as it is not the state
+// this object has been managing.
+// The returned bools are true when the reconciliation must not proceed, and when there is no state yet,
+// which the reconciliation creates, see recordCreatedStateLineage.
+func (r *TerraformReconciler) checkStateLineage(ctx context.Context, terraform infrav1.Terraform, revision string) (infrav1.Terraform, bool, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	traceLog := log.V(logger.TraceLevel).WithValues("function", "TerraformReconciler.checkStateLineage")
+
+	if terraform.Spec.PlanOnly {
+		traceLog.Info("Plan-only objects do not write their state, skip")
+		return terraform, false, false, nil
+	}
+
+	stateKey, ok := inClusterStateSecretKey(terraform)
+	if !ok {
+		traceLog.Info("State is not stored with the in-cluster kubernetes backend, skip")
+		return terraform, false, false, nil
+	}
+
+	state, err := r.getState(ctx, stateKey)
+	if err != nil {
+		return terraform, false, false, err
+	}
+
+	if state.Lineage == "" {
+		traceLog.Info("No state found", "secret", stateKey.Name)
+		return terraform, false, true, nil
+	}
+
+	if terraform.StateLineage() == "" {
+		adopt := terraform.Spec.BackendConfig != nil && terraform.Spec.BackendConfig.AdoptExistingState
+		// an object that has already been applied owns the state, e.g. it was upgraded from a version
+		// without lineage tracking
+		if !adopt && terraform.Status.LastAppliedRevision == "" && !state.empty() {
+			msg := fmt.Sprintf("Found an existing state %s with lineage %s. "+
+				"Set .spec.backendConfig.adoptExistingState to true to take it over", stateKey.Name, state.Lineage)
+			return infrav1.TerraformNotReady(terraform, revision, infrav1.StateAdoptionRequiredReason, msg), true, false, nil
+		}
+
+		log.Info("recording state lineage", "secret", stateKey.Name, "lineage", state.Lineage)
+		terraform.SetStateLineage(state.Lineage)
+		return terraform, false, false, nil
+	}
+
+	if terraform.StateLineage() != state.Lineage {
+		msg := fmt.Sprintf("State %s has lineage %s, but this object manages a state with lineage %s",
+			stateKey.Name, state.Lineage, terraform.StateLineage())
+		return infrav1.TerraformNotReady(terraform, revision, infrav1.StateLineageMismatchReason, msg), true, false, nil
+	}
+
+	return terraform, false, false, nil
+}
+
+// recordCreatedStateLineage records the lineage of the state the reconciliation has created,
+// the kubernetes backend writing it from the init on, when checkStateLineage found no state.
+func (r *TerraformReconciler) recordCreatedStateLineage(ctx context.Context, terraform infrav1.Terraform) infrav1.Terraform {
+	log := ctrl.LoggerFrom(ctx)
+
+	stateKey, ok := inClusterStateSecretKey(terraform)
+	if !ok || terraform.StateLineage() != "" {
+		return terraform
+	}
+
+	state, err := r.getState(ctx, stateKey)
+	if err != nil {
+		log.Error(err, "unable to read the state created by the reconciliation")
+		return terraform
+	}
+	if state.Lineage == "" {
+		return terraform
+	}
+
+	log.Info("recording the lineage of the created state", "secret", stateKey.Name, "lineage", state.Lineage)
+	terraform.SetStateLineage(state.Lineage)
+	return terraform
+}
+
+// tfstate holds the fields of a state telling whose it is, and whether it manages anything.
+type tfstate struct {
+	Lineage   string            `json:"lineage"`
+	Serial    uint64            `json:"serial"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+// empty tells whether the state is the one written by the backend before the first apply.
+func (s tfstate) empty() bool {
+	return s.Serial == 0 && len(s.Resources) == 0
+}
+
+// getState returns the state stored in the Secret,
+// or an empty state if there is no such state.
+func (r *TerraformReconciler) getState(ctx context.Context, key types.NamespacedName) (tfstate, error) {
+	var stateSecret corev1.Secret
+	if err := r.Get(ctx, key, &stateSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return tfstate{}, nil
+		}
+		return tfstate{}, err
+	}
+
+	return parseState(stateSecret.Data[tfstateSecretKey])
+}
+
+func parseState(data []byte) (tfstate, error) {
+	var state tfstate
+	if len(data) == 0 {
+		return state, nil
+	}
+
+	stateBytes, err := utils.GzipDecode(data)
+	if err != nil {
+		return state, fmt.Errorf("unable to decode state: %w", err)
+	}
+
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		return state, fmt.Errorf("unable to parse state: %w", err)
+	}
+
+	return state, nil
+}
+
+// inClusterStateSecretKey returns the key of the Secret holding the state, when
+// it is stored by the kubernetes backend in the same cluster as the object.
+func inClusterStateSecretKey(terraform infrav1.Terraform) (types.NamespacedName, bool) {
+	if terraform.Spec.BackendConfig != nil &&
+		(terraform.Spec.BackendConfig.ConfigPath != "" || terraform.Spec.BackendConfig.CustomConfiguration != "") {
+		return types.NamespacedName{}, false
+	}
+
+	suffix, ok := stateSecretSuffix(terraform)
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+
+	return types.NamespacedName{
+		Namespace: terraform.Namespace,
+		Name:      "tfstate-" + terraform.WorkspaceName() + "-" + suffix,
+	}, true
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func stateSecret(t *testing.T, state string) *corev1.Secret {
+	data, err := utils.GzipEncode([]byte(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tfstate-default-helloworld", Namespace: "flux-system"},
+		Data:       map[string][]byte{tfstateSecretKey: data},
+	}
+}
+
+func TestCheckStateLineage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	const (
+		emptyState   = `{"version":4,"serial":0,"lineage":"lineage-a","resources":[]}`
+		appliedState = `{"version":4,"serial":3,"lineage":"lineage-a","resources":[{"type":"null_resource","name":"a"}]}`
+		otherState   = `{"version":4,"serial":1,"lineage":"lineage-b","resources":[{"type":"null_resource","name":"a"}]}`
+	)
+
+	tests := []struct {
+		name         string
+		terraform    func(*infrav1.Terraform)
+		state        string
+		blocked      bool
+		stateMissing bool
+		reason       string
+		lineage      string
+	}{
+		{
+			name:         "a new object finds no state",
+			stateMissing: true,
+		},
+		{
+			name:    "a new object finds the empty state written by its own init",
+			state:   emptyState,
+			lineage: "lineage-a",
+		},
+		{
+			name:    "a new object does not take over an existing state",
+			state:   appliedState,
+			blocked: true,
+			reason:  infrav1.StateAdoptionRequiredReason,
+		},
+		{
+			name: "a new object adopts an existing state",
+			terraform: func(terraform *infrav1.Terraform) {
+				terraform.Spec.BackendConfig = &infrav1.BackendConfigSpec{SecretSuffix: "helloworld", AdoptExistingState: true}
+			},
+			state:   appliedState,
+			lineage: "lineage-a",
+		},
+		{
+			name: "an applied object records the lineage of its state",
+			terraform: func(terraform *infrav1.Terraform) {
+				terraform.Status.LastAppliedRevision = "main/1"
+			},
+			state:   appliedState,
+			lineage: "lineage-a",
+		},
+		{
+			name: "the state of the recorded lineage",
+			terraform: func(terraform *infrav1.Terraform) {
+				terraform.SetStateLineage("lineage-a")
+			},
+			state:   appliedState,
+			lineage: "lineage-a",
+		},
+		{
+			name: "the lineage of the state has changed",
+			terraform: func(terraform *infrav1.Terraform) {
+				terraform.SetStateLineage("lineage-a")
+			},
+			state:   otherState,
+			blocked: true,
+			reason:  infrav1.StateLineageMismatchReason,
+			lineage: "lineage-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}}
+			if tt.terraform != nil {
+				tt.terraform(&terraform)
+			}
+			var objects []client.Object
+			if tt.state != "" {
+				objects = append(objects, stateSecret(t, tt.state))
+			}
+			r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objects...).Build()}
+
+			terraform, blocked, stateMissing, err := r.checkStateLineage(ctx, terraform, "main/1")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(blocked).To(Equal(tt.blocked))
+			g.Expect(stateMissing).To(Equal(tt.stateMissing))
+			g.Expect(terraform.StateLineage()).To(Equal(tt.lineage))
+			if tt.reason != "" {
+				g.Expect(terraform.Status.Conditions[0].Reason).To(Equal(tt.reason))
+			}
+		})
+	}
+}
+
+func TestRecordCreatedStateLineage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	r := &TerraformReconciler{Client: k8sClient}
+	terraform := infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"}}
+
+	// the first reconciliation of a new object plans, or fails to apply, with a state of its own
+	terraform, blocked, stateMissing, err := r.checkStateLineage(ctx, terraform, "main/1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(blocked).To(BeFalse())
+	g.Expect(stateMissing).To(BeTrue())
+	g.Expect(k8sClient.Create(ctx, stateSecret(t, `{"version":4,"serial":1,"lineage":"lineage-a","resources":[{"type":"null_resource","name":"a"}]}`))).To(Succeed())
+	terraform = r.recordCreatedStateLineage(ctx, terraform)
+	g.Expect(terraform.StateLineage()).To(Equal("lineage-a"))
+
+	// so that the next reconciliation, before any successful apply, is not blocked
+	g.Expect(terraform.Status.LastAppliedRevision).To(BeEmpty())
+	terraform, blocked, _, err = r.checkStateLineage(ctx, terraform, "main/1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(blocked).To(BeFalse())
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	fakerunner "github.com/weaveworks/tf-controller/runner/fake"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSuspendApply(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec:       infrav1.TerraformSpec{ApprovePlan: "auto", SuspendApply: true},
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(terraform.DeepCopy()).Build()
+
+	s := fakerunner.NewServer(k8sClient)
+	s.SetResult(key, fakerunner.Result{Changes: []string{"create aws_s3_bucket.logs"}})
+	runnerClient := fakeRunnerClient(t, s)
+	terraformBytes, err := terraform.ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = s.NewTerraform(ctx, &runner.NewTerraformRequest{WorkingDir: t.TempDir(), Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &TerraformReconciler{Client: k8sClient, Scheme: testScheme, EventRecorder: record.NewFakeRecorder(100)}
+
+	terraform, err = r.plan(ctx, terraform, "1", runnerClient, "main/b8e362c206")
+	g.Expect(err).ToNot(HaveOccurred())
+	pending := terraform.Status.Plan.Pending
+	g.Expect(pending).ToNot(BeEmpty())
+
+	// the approved plan is kept pending while the apply is suspended
+	terraform, applied, err := r.applyOrBlock(ctx, terraform, "1", "", runnerClient, "main/b8e362c206")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(BeFalse())
+	g.Expect(s.Calls(key)).ToNot(ContainElement("Apply"))
+	g.Expect(terraform.Status.Plan.Pending).To(Equal(pending))
+
+	var blocked infrav1.Terraform
+	g.Expect(k8sClient.Get(ctx, key, &blocked)).To(Succeed())
+	condition := apimeta.FindStatusCondition(blocked.Status.Conditions, infrav1.ConditionTypeApply)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Reason).To(Equal(infrav1.ApplySuspendedReason))
+	g.Expect(blocked.Status.Plan.Pending).To(Equal(pending))
+
+	// the pending plan is applied once the apply is resumed
+	terraform.Spec.SuspendApply = false
+	terraform, applied, err = r.applyOrBlock(ctx, terraform, "1", "", runnerClient, "main/b8e362c206")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(applied).To(BeTrue())
+	g.Expect(s.Calls(key)).To(ContainElement("Apply"))
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(terraform.Status.LastAppliedRevision).To(Equal("main/b8e362c206"))
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// dependantsTeardownOrder returns the objects depending on terraform, directly or transitively,
+// in the order they must be deleted: each object comes after all the objects depending on it.
+func (r *TerraformReconciler) dependantsTeardownOrder(ctx context.Context, terraform infrav1.Terraform) ([]infrav1.Terraform, error) {
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	dependants := map[types.NamespacedName][]infrav1.Terraform{}
+	for _, tf := range list.Items {
+		for _, d := range tf.Spec.DependsOn {
+			namespace := d.Namespace
+			if namespace == "" {
+				namespace = tf.Namespace
+			}
+			key := types.NamespacedName{Namespace: namespace, Name: d.Name}
+			dependants[key] = append(dependants[key], tf)
+		}
+	}
+
+	return teardownOrder(types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Name}, dependants), nil
+}
+
+// teardownOrder walks the dependants of root depth first, and lists every object once all of its own dependants are listed.
+// An object met again through a dependency cycle is not waited for.
+func teardownOrder(root types.NamespacedName, dependants map[types.NamespacedName][]infrav1.Terraform) []infrav1.Terraform {
+	var order []infrav1.Terraform
+	visited := map[types.NamespacedName]bool{root: true}
+
+	var visit func(key types.NamespacedName)
+	visit = func(key types.NamespacedName) {
+		children := append([]infrav1.Terraform{}, dependants[key]...)
+		sort.Slice(children, func(i, j int) bool {
+			if children[i].Namespace != children[j].Namespace {
+				return children[i].Namespace < children[j].Namespace
+			}
+			return children[i].Name < children[j].Name
+		})
+
+		for _, child := range children {
+			childKey := types.NamespacedName{Namespace: child.Namespace, Name: child.Name}
+			if visited[childKey] {
+				continue
+			}
+			visited[childKey] = true
+			visit(childKey)
+			order = append(order, child)
+		}
+	}
+	visit(root)
+
+	return order
+}
+
+// deleteDependants deletes the dependants of terraform in its namespace, following their teardown order,
+// and returns the names of those it deleted. Each of them waits for its own dependants before being finalized,
+// so that the destroys happen dependants first.
+func (r *TerraformReconciler) deleteDependants(ctx context.Context, terraform infrav1.Terraform, order []infrav1.Terraform) ([]string, error) {
+	var deleted []string
+	for i := range order {
+		dependant := &order[i]
+		// objects of other namespaces are left to their owners
+		if dependant.Namespace != terraform.Namespace || isBeingDeleted(*dependant) {
+			continue
+		}
+
+		if err := r.Delete(ctx, dependant); err != nil && !apierrors.IsNotFound(err) {
+			return deleted, err
+		}
+		deleted = append(deleted, dependant.Name)
+	}
+
+	return deleted, nil
+}
+
+// teardownNames returns the names of the objects, qualified with their namespace when it is not the one of terraform.
+func teardownNames(terraform infrav1.Terraform, order []infrav1.Terraform) []string {
+	names := make([]string, 0, len(order))
+	for _, tf := range order {
+		if tf.Namespace != terraform.Namespace {
+			names = append(names, tf.Namespace+"/"+tf.Name)
+			continue
+		}
+		names = append(names, tf.Name)
+	}
+	return names
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDependantsTeardownOrder(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	tf := func(namespace, name string, dependsOn ...infrav1.DependsOnReference) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       infrav1.TerraformSpec{DependsOn: dependsOn},
+		}
+	}
+
+	// network <- cluster <- apps <- monitoring, apps also depends on network directly
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		tf("flux-system", "network"),
+		tf("flux-system", "cluster", infrav1.DependsOnReference{Name: "network"}),
+		tf("flux-system", "apps", infrav1.DependsOnReference{Name: "cluster"}, infrav1.DependsOnReference{Name: "network"}),
+		tf("flux-system", "monitoring", infrav1.DependsOnReference{Name: "apps"}),
+		tf("team-a", "dns", infrav1.DependsOnReference{Name: "network", Namespace: "flux-system"}),
+		tf("team-a", "network"),
+	).Build()}
+
+	var network infrav1.Terraform
+	g.Expect(r.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "network"}, &network)).To(Succeed())
+
+	order, err := r.dependantsTeardownOrder(ctx, network)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(teardownNames(network, order)).To(Equal([]string{"monitoring", "apps", "cluster", "team-a/dns"}))
+
+	deleted, err := r.deleteDependants(ctx, network, order)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(Equal([]string{"monitoring", "apps", "cluster"}))
+
+	var list infrav1.TerraformList
+	g.Expect(r.List(ctx, &list)).To(Succeed())
+	g.Expect(list.Items).To(HaveLen(3))
+}
+
+func TestTeardownOrderWithACycle(t *testing.T) {
+	g := NewWithT(t)
+
+	a := types.NamespacedName{Namespace: "flux-system", Name: "a"}
+	b := types.NamespacedName{Namespace: "flux-system", Name: "b"}
+	dependants := map[types.NamespacedName][]infrav1.Terraform{
+		a: {{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "b"}}},
+		b: {{ObjectMeta: metav1.ObjectMeta{Namespace: "flux-system", Name: "a"}}},
+	}
+
+	order := teardownOrder(a, dependants)
+	g.Expect(order).To(HaveLen(1))
+	g.Expect(order[0].Name).To(Equal("b"))
+}
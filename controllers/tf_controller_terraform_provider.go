@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// getTerraformProviders returns the TerraformProvider objects referred by the Terraform object, in order.
+// It fails if one of them does not select the namespace of the object.
+func (r *TerraformReconciler) getTerraformProviders(ctx context.Context, terraform infrav1.Terraform) ([]infrav1.TerraformProvider, error) {
+	if len(terraform.Spec.ProviderRefs) == 0 {
+		return nil, nil
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: terraform.Namespace}, &ns); err != nil {
+		return nil, fmt.Errorf("unable to get namespace '%s': %w", terraform.Namespace, err)
+	}
+
+	var providers []infrav1.TerraformProvider
+	for _, ref := range terraform.Spec.ProviderRefs {
+		var tp infrav1.TerraformProvider
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name}, &tp); err != nil {
+			return nil, fmt.Errorf("unable to get terraform provider '%s': %w", ref.Name, err)
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&tp.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector of terraform provider '%s': %w", tp.Name, err)
+		}
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			return nil, fmt.Errorf("terraform provider '%s' does not select namespace '%s'", tp.Name, terraform.Namespace)
+		}
+
+		providers = append(providers, tp)
+	}
+	return providers, nil
+}
+
+// terraformProviderEnvs returns the environment variables holding the credentials of the TerraformProvider objects.
+func (r *TerraformReconciler) terraformProviderEnvs(ctx context.Context, providers []infrav1.TerraformProvider) (map[string]string, error) {
+	envs := map[string]string{}
+	for _, tp := range providers {
+		if tp.Spec.CredentialsSecretRef == nil {
+			continue
+		}
+
+		secret, err := r.terraformProviderSecret(ctx, tp, tp.Spec.CredentialsSecretRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get credentials of terraform provider '%s': %w", tp.Name, err)
+		}
+
+		for k, v := range secret.Data {
+			envs[k] = string(v)
+		}
+	}
+	return envs, nil
+}
+
+// terraformProviderFileMappings returns the provider override files, and the credential files, of the TerraformProvider objects.
+func (r *TerraformReconciler) terraformProviderFileMappings(ctx context.Context, providers []infrav1.TerraformProvider) ([]*runner.FileMapping, error) {
+	var fileMappings []*runner.FileMapping
+	for _, tp := range providers {
+		for _, block := range tp.Spec.Providers {
+			override, err := renderProviderBlock(block)
+			if err != nil {
+				return nil, fmt.Errorf("unable to render provider %s of terraform provider '%s': %w", block.Provider, tp.Name, err)
+			}
+
+			fileMappings = append(fileMappings, &runner.FileMapping{
+				Content:  override,
+				Location: "workspace",
+				Path:     terraformProviderOverrideFilename(tp, block),
+			})
+		}
+
+		for _, fileMapping := range tp.Spec.CredentialFiles {
+			secret, err := r.terraformProviderSecret(ctx, tp, fileMapping.SecretRef.Name)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get credential files of terraform provider '%s': %w", tp.Name, err)
+			}
+
+			fileMappings = append(fileMappings, &runner.FileMapping{
+				Content:  secret.Data[fileMapping.SecretRef.Key],
+				Location: fileMapping.Location,
+				Path:     fileMapping.Path,
+			})
+		}
+	}
+	return fileMappings, nil
+}
+
+func (r *TerraformReconciler) terraformProviderSecret(ctx context.Context, tp infrav1.TerraformProvider, name string) (*corev1.Secret, error) {
+	if tp.Spec.SecretNamespace == "" {
+		return nil, fmt.Errorf("secretNamespace is required to refer to Secrets")
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: tp.Spec.SecretNamespace, Name: name}, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// terraformProviderOverrideFilename is prefixed with the name of the TerraformProvider,
+// so that its files never replace those of a ProviderConfig of the same provider.
+func terraformProviderOverrideFilename(tp infrav1.TerraformProvider, block infrav1.ProviderBlock) string {
+	if block.Alias != "" {
+		return fmt.Sprintf("terraform_provider_%s_%s_%s_override.tf.json", tp.Name, block.Provider, block.Alias)
+	}
+	return fmt.Sprintf("terraform_provider_%s_%s_override.tf.json", tp.Name, block.Provider)
+}
+
+// IndexByTerraformProvider indexes the Terraform objects by the TerraformProvider objects they refer to.
+func (r *TerraformReconciler) IndexByTerraformProvider(o client.Object) []string {
+	terraform, ok := o.(*infrav1.Terraform)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Terraform, got %T", o))
+	}
+
+	var keys []string
+	for _, ref := range terraform.Spec.ProviderRefs {
+		keys = append(keys, ref.Name)
+	}
+	return keys
+}
+
+func (r *TerraformReconciler) requestsForTerraformProviderChange(obj client.Object) []reconcile.Request {
+	ctx := context.Background()
+	var list infrav1.TerraformList
+	if err := r.List(ctx, &list, client.MatchingFields{
+		infrav1.TerraformProviderIndexKey: obj.GetName(),
+	}); err != nil {
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, len(list.Items))
+	for i, t := range list.Items {
+		reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&t)}
+	}
+	return reqs
+}
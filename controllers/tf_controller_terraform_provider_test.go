@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTerraformProviders(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	aws := &infrav1.TerraformProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: infrav1.TerraformProviderSpec{
+			Providers: []infrav1.ProviderBlock{
+				{Provider: "aws", Settings: &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu-west-1"}`)}},
+				{Provider: "aws", Alias: "us", Settings: &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-1"}`)}},
+			},
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+			SecretNamespace:   "tf-system",
+			CredentialsSecretRef: &meta.LocalObjectReference{
+				Name: "aws-credentials",
+			},
+		},
+	}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		aws,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform", Labels: map[string]string{"team": "platform"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-credentials", Namespace: "tf-system"},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("AKIA"), "AWS_SECRET_ACCESS_KEY": []byte("secret")},
+		},
+	).Build()}
+
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "platform"},
+		Spec: infrav1.TerraformSpec{
+			ProviderRefs: []meta.LocalObjectReference{{Name: "aws"}},
+		},
+	}
+	providers, err := r.getTerraformProviders(ctx, terraform)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers).To(HaveLen(1))
+
+	envs, err := r.terraformProviderEnvs(ctx, providers)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(envs).To(Equal(map[string]string{"AWS_ACCESS_KEY_ID": "AKIA", "AWS_SECRET_ACCESS_KEY": "secret"}))
+
+	fileMappings, err := r.terraformProviderFileMappings(ctx, providers)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fileMappings).To(HaveLen(2))
+	g.Expect(fileMappings[0].Path).To(Equal("terraform_provider_aws_aws_override.tf.json"))
+	g.Expect(fileMappings[0].Content).To(MatchJSON(`{"provider":{"aws":{"region":"eu-west-1"}}}`))
+	g.Expect(fileMappings[1].Path).To(Equal("terraform_provider_aws_aws_us_override.tf.json"))
+	g.Expect(fileMappings[1].Content).To(MatchJSON(`{"provider":{"aws":{"region":"us-east-1","alias":"us"}}}`))
+
+	// the namespaces not selected can't use the credentials
+	terraform.Namespace = "apps"
+	_, err = r.getTerraformProviders(ctx, terraform)
+	g.Expect(err).To(MatchError(ContainSubstring("does not select namespace 'apps'")))
+
+	terraform.Spec.ProviderRefs = []meta.LocalObjectReference{{Name: "missing"}}
+	terraform.Namespace = "platform"
+	_, err = r.getTerraformProviders(ctx, terraform)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(r.IndexByTerraformProvider(&infrav1.Terraform{Spec: infrav1.TerraformSpec{
+		ProviderRefs: []meta.LocalObjectReference{{Name: "aws"}, {Name: "google"}},
+	}})).To(Equal([]string{"aws", "google"}))
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// maxWorkingDirSnapshotMessage bounds the error of a failed run recorded with its snapshot.
+const maxWorkingDirSnapshotMessage = 1024
+
+// shouldSnapshotWorkingDir tells whether the working directory of the failed plans and applies is stored.
+func (r *TerraformReconciler) shouldSnapshotWorkingDir(terraform infrav1.Terraform) bool {
+	return terraform.Spec.WorkingDirSnapshot != nil
+}
+
+// snapshotWorkingDir stores the working directory of a failed plan or apply, extracted in tmpDir, and records
+// the snapshot in the status. It is best effort: the run has failed either way, so an error is only logged.
+// The snapshots beyond spec.workingDirSnapshot.keep are then deleted, oldest first.
+func (r *TerraformReconciler) snapshotWorkingDir(ctx context.Context, terraform infrav1.Terraform, tfInstance string, tmpDir string, runnerClient runner.RunnerClient, revision string, stage string, runErr error, now time.Time) infrav1.Terraform {
+	log := ctrl.LoggerFrom(ctx)
+
+	id := stateSnapshotID(now)
+	log.Info("calling snapshot working dir ...", "id", id, "stage", stage)
+	snapshotReply, err := runnerClient.SnapshotWorkingDir(ctx, &runner.SnapshotWorkingDirRequest{TfInstance: tfInstance, TmpDir: tmpDir, Id: id})
+	if err != nil {
+		log.Error(err, "unable to take a snapshot of the working directory")
+		return terraform
+	}
+	log.Info("snapshot of the working directory taken", "id", id, "location", snapshotReply.Location, "size", snapshotReply.Size)
+
+	message, _ := utils.TruncateText(runErr.Error(), maxWorkingDirSnapshotMessage)
+	status := &terraform.Status
+	status.WorkingDirSnapshots = append(status.WorkingDirSnapshots, infrav1.WorkingDirSnapshotStatus{
+		ID:        id,
+		Location:  snapshotReply.Location,
+		Stage:     stage,
+		Path:      terraform.Spec.Path,
+		Revision:  revision,
+		Message:   message,
+		CreatedAt: metav1.NewTime(now),
+	})
+
+	keep := terraform.Spec.WorkingDirSnapshot.GetKeep()
+	if len(status.WorkingDirSnapshots) <= keep {
+		return terraform
+	}
+	var ids []string
+	for _, snapshot := range status.WorkingDirSnapshots[:len(status.WorkingDirSnapshots)-keep] {
+		ids = append(ids, snapshot.ID)
+	}
+	deleteReply, err := runnerClient.DeleteWorkingDirSnapshots(ctx, &runner.DeleteWorkingDirSnapshotsRequest{TfInstance: tfInstance, Ids: ids})
+	if err != nil {
+		log.Error(err, "unable to delete the old snapshots of the working directory")
+		return terraform
+	}
+	if deleteReply.Message != "" {
+		log.Info(deleteReply.Message)
+	}
+	deleted := map[string]bool{}
+	for _, id := range deleteReply.Deleted {
+		deleted[id] = true
+	}
+	snapshots := status.WorkingDirSnapshots[:0]
+	for _, snapshot := range status.WorkingDirSnapshots {
+		if !deleted[snapshot.ID] {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	status.WorkingDirSnapshots = snapshots
+	return terraform
+}
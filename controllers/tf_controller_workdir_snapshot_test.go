@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	fakerunner "github.com/weaveworks/tf-controller/runner/fake"
+	"github.com/weaveworks/tf-controller/utils"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSnapshotWorkingDir(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			Path:               "./envs/prod",
+			WorkingDirSnapshot: &infrav1.WorkingDirSnapshot{SnapshotStorage: infrav1.SnapshotStorage{Type: infrav1.SnapshotStorageTypeSecret}, Keep: 1},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+	tmpDir := t.TempDir()
+	workingDir := filepath.Join(tmpDir, "envs", "prod")
+	g.Expect(os.MkdirAll(filepath.Join(workingDir, ".terraform", "providers"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(workingDir, ".terraform", "providers", "terraform-provider-aws"), []byte("binary"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(workingDir, "generated.auto.tfvars.json"), []byte(`{"region":"eu-west-1"}`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(workingDir, "backend_override.tf"), []byte(`terraform { backend "kubernetes" {} }`), 0644)).To(Succeed())
+
+	s := fakerunner.NewServer(k8sClient)
+	runnerClient := fakeRunnerClient(t, s)
+	terraformBytes, err := terraform.ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = s.NewTerraform(ctx, &runner.NewTerraformRequest{WorkingDir: workingDir, Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &TerraformReconciler{Client: k8sClient, EventRecorder: record.NewFakeRecorder(10)}
+	g.Expect(r.shouldSnapshotWorkingDir(terraform)).To(BeTrue())
+	now := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	terraform = r.snapshotWorkingDir(ctx, terraform, "1", tmpDir, runnerClient, "main/1", runner.StagePlan, errors.New("error running Plan: exit status 1"), now)
+	g.Expect(terraform.Status.WorkingDirSnapshots).To(Equal([]infrav1.WorkingDirSnapshotStatus{{
+		ID:        "20230201-100000",
+		Location:  "secret/workdir-snapshot-helloworld-20230201-100000",
+		Stage:     runner.StagePlan,
+		Path:      "./envs/prod",
+		Revision:  "main/1",
+		Message:   "error running Plan: exit status 1",
+		CreatedAt: metav1.NewTime(now),
+	}}))
+
+	// the snapshot has the generated files, but not the providers
+	var secret v1.Secret
+	g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "flux-system", Name: "workdir-snapshot-helloworld-20230201-100000"}, &secret)).To(Succeed())
+	extracted := t.TempDir()
+	g.Expect(utils.Untar(bytes.NewReader(secret.Data["workdir"]), extracted, utils.UntarLimits{})).To(Succeed())
+	g.Expect(filepath.Join(extracted, "envs", "prod", "generated.auto.tfvars.json")).To(BeARegularFile())
+	g.Expect(filepath.Join(extracted, "envs", "prod", "backend_override.tf")).To(BeARegularFile())
+	g.Expect(filepath.Join(extracted, "envs", "prod", ".terraform", "providers")).ToNot(BeAnExistingFile())
+
+	// the oldest snapshot is deleted beyond spec.workingDirSnapshot.keep
+	terraform = r.snapshotWorkingDir(ctx, terraform, "1", tmpDir, runnerClient, "main/2", runner.StageApply, errors.New("error running Apply: exit status 1"), now.Add(time.Hour))
+	g.Expect(terraform.Status.WorkingDirSnapshots).To(HaveLen(1))
+	g.Expect(terraform.Status.WorkingDirSnapshots[0].ID).To(Equal("20230201-110000"))
+	g.Expect(terraform.Status.WorkingDirSnapshots[0].Stage).To(Equal(runner.StageApply))
+	var secrets v1.SecretList
+	g.Expect(k8sClient.List(ctx, &secrets)).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(1))
+	g.Expect(secrets.Items[0].Name).To(Equal("workdir-snapshot-helloworld-20230201-110000"))
+
+	// a snapshot failing does not fail the reconciliation any further
+	terraform = r.snapshotWorkingDir(ctx, terraform, "1", filepath.Join(tmpDir, "missing"), runnerClient, "main/3", runner.StagePlan, errors.New("error running Plan: exit status 1"), now.Add(2*time.Hour))
+	g.Expect(terraform.Status.WorkingDirSnapshots).To(HaveLen(1))
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileWorkingDirPVC creates the PersistentVolumeClaim of the working directory of the runner, if the object has one.
+// The claim is owned by the object, so that it is deleted with it. A larger size is applied to the existing claim,
+// for storage classes allowing the expansion of their volumes.
+func (r *TerraformReconciler) reconcileWorkingDirPVC(ctx context.Context, terraform infrav1.Terraform) error {
+	if !terraform.Spec.HasPersistentWorkingDir() {
+		return nil
+	}
+	spec := terraform.Spec.WorkingDirStorage.PVC
+
+	var pvc v1.PersistentVolumeClaim
+	err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.WorkingDirPVCName()}, &pvc)
+	if err == nil {
+		if spec.Size.Cmp(pvc.Spec.Resources.Requests[v1.ResourceStorage]) <= 0 {
+			return nil
+		}
+		patch := pvc.DeepCopy()
+		patch.Spec.Resources.Requests[v1.ResourceStorage] = spec.Size
+		return r.Update(ctx, patch)
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	pvc = v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      terraform.WorkingDirPVCName(),
+			Namespace: terraform.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/created-by": "tf-controller",
+				"app.kubernetes.io/name":       "tf-runner",
+			},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			StorageClassName: spec.StorageClassName,
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: spec.Size},
+			},
+		},
+	}
+	if err := controllerutil.SetOwnerReference(&terraform, &pvc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, &pvc)
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileWorkingDirPVC(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	storageClassName := "fast"
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "1234"},
+		Spec: infrav1.TerraformSpec{
+			WorkingDirStorage: &infrav1.WorkingDirStorage{PVC: &infrav1.WorkingDirPVC{
+				Size:             resource.MustParse("10Gi"),
+				StorageClassName: &storageClassName,
+			}},
+		},
+	}
+	r := &TerraformReconciler{Client: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+
+	g.Expect(r.reconcileWorkingDirPVC(ctx, terraform)).To(Succeed())
+	var pvc v1.PersistentVolumeClaim
+	pvcKey := types.NamespacedName{Namespace: "flux-system", Name: "helloworld-tf-runner-workdir"}
+	g.Expect(r.Get(ctx, pvcKey, &pvc)).To(Succeed())
+	g.Expect(pvc.Spec.StorageClassName).To(Equal(&storageClassName))
+	g.Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+	g.Expect(pvc.OwnerReferences).To(HaveLen(1))
+
+	// the claim is only ever grown
+	terraform.Spec.WorkingDirStorage.PVC.Size = resource.MustParse("5Gi")
+	g.Expect(r.reconcileWorkingDirPVC(ctx, terraform)).To(Succeed())
+	terraform.Spec.WorkingDirStorage.PVC.Size = resource.MustParse("20Gi")
+	g.Expect(r.reconcileWorkingDirPVC(ctx, terraform)).To(Succeed())
+	g.Expect(r.Get(ctx, pvcKey, &pvc)).To(Succeed())
+	g.Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+
+	// the runner pod mounts the claim as its temporary directory
+	podSpec := r.runnerPodSpec(terraform, "tls")
+	g.Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("helloworld-tf-runner-workdir"))
+	g.Expect(podSpec.SecurityContext.FSGroup).ToNot(BeNil())
+}
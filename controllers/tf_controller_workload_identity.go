@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	workloadIdentityMountPath         = "/var/run/secrets/tf-controller/workload-identity"
+	workloadIdentityTokenFile         = "token"
+	workloadIdentityExpirationSeconds = int64(3600)
+
+	awsWorkloadIdentityAudience   = "sts.amazonaws.com"
+	azureWorkloadIdentityAudience = "api://AzureADTokenExchange"
+)
+
+// workloadIdentityTokenPath is where the runner finds the projected service account token of a provider config.
+func workloadIdentityTokenPath(pc infrav1.ProviderConfig) string {
+	return path.Join(workloadIdentityMountPath, pc.Name, workloadIdentityTokenFile)
+}
+
+func workloadIdentityAudience(pc infrav1.ProviderConfig) string {
+	wi := pc.Spec.WorkloadIdentity
+	if wi.Audience != "" {
+		return wi.Audience
+	}
+
+	switch pc.Spec.Provider {
+	case "aws":
+		return awsWorkloadIdentityAudience
+	case "azurerm":
+		return azureWorkloadIdentityAudience
+	case "google":
+		return "//iam.googleapis.com/" + wi.WorkloadIdentityProvider
+	}
+	return ""
+}
+
+// validateWorkloadIdentity checks that the workload identity of a provider config has the fields its provider requires.
+func validateWorkloadIdentity(pc infrav1.ProviderConfig) error {
+	wi := pc.Spec.WorkloadIdentity
+	if pc.Spec.CredentialsSecretRef != nil {
+		return fmt.Errorf("provider config '%s/%s' cannot use both workloadIdentity and credentialsSecretRef", pc.Namespace, pc.Name)
+	}
+
+	switch pc.Spec.Provider {
+	case "aws":
+		if wi.RoleARN == "" {
+			return fmt.Errorf("provider config '%s/%s' requires workloadIdentity.roleARN", pc.Namespace, pc.Name)
+		}
+	case "google":
+		if wi.WorkloadIdentityProvider == "" {
+			return fmt.Errorf("provider config '%s/%s' requires workloadIdentity.workloadIdentityProvider", pc.Namespace, pc.Name)
+		}
+	case "azurerm":
+		if wi.ClientID == "" || wi.TenantID == "" {
+			return fmt.Errorf("provider config '%s/%s' requires workloadIdentity.clientID and workloadIdentity.tenantID", pc.Namespace, pc.Name)
+		}
+	default:
+		return fmt.Errorf("provider config '%s/%s': workload identity is not supported for the %s provider", pc.Namespace, pc.Name, pc.Spec.Provider)
+	}
+	return nil
+}
+
+// workloadIdentityVolumes returns the projected service account token volumes, and their mounts,
+// that the runner pod needs for the workload identity of the provider configs.
+func workloadIdentityVolumes(configs []infrav1.ProviderConfig) ([]v1.Volume, []v1.VolumeMount) {
+	var volumes []v1.Volume
+	var mounts []v1.VolumeMount
+	for i, pc := range configs {
+		if pc.Spec.WorkloadIdentity == nil {
+			continue
+		}
+
+		expirationSeconds := workloadIdentityExpirationSeconds
+		if pc.Spec.WorkloadIdentity.ExpirationSeconds != nil {
+			expirationSeconds = *pc.Spec.WorkloadIdentity.ExpirationSeconds
+		}
+
+		name := fmt.Sprintf("workload-identity-%d", i)
+		volumes = append(volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{
+							ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+								Audience:          workloadIdentityAudience(pc),
+								ExpirationSeconds: &expirationSeconds,
+								Path:              workloadIdentityTokenFile,
+							},
+						},
+					},
+				},
+			},
+		})
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: path.Dir(workloadIdentityTokenPath(pc)),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, mounts
+}
+
+// workloadIdentityEnvs returns the environment variables, and the files, with which the provider
+// exchanges the projected service account token for short-lived credentials. The exchange is done
+// by the provider itself, so that the credentials are refreshed during long running operations.
+func workloadIdentityEnvs(pc infrav1.ProviderConfig) (map[string]string, []*runner.FileMapping, error) {
+	if err := validateWorkloadIdentity(pc); err != nil {
+		return nil, nil, err
+	}
+
+	wi := pc.Spec.WorkloadIdentity
+	tokenPath := workloadIdentityTokenPath(pc)
+	switch pc.Spec.Provider {
+	case "aws":
+		return map[string]string{
+			"AWS_ROLE_ARN":                wi.RoleARN,
+			"AWS_WEB_IDENTITY_TOKEN_FILE": tokenPath,
+			"AWS_ROLE_SESSION_NAME":       fmt.Sprintf("tf-controller-%s-%s", pc.Namespace, pc.Name),
+		}, nil, nil
+	case "azurerm":
+		return map[string]string{
+			"ARM_USE_OIDC":             "true",
+			"ARM_OIDC_TOKEN_FILE_PATH": tokenPath,
+			"ARM_CLIENT_ID":            wi.ClientID,
+			"ARM_TENANT_ID":            wi.TenantID,
+		}, nil, nil
+	case "google":
+		credentials := map[string]interface{}{
+			"type":               "external_account",
+			"audience":           workloadIdentityAudience(pc),
+			"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+			"token_url":          "https://sts.googleapis.com/v1/token",
+			"credential_source": map[string]interface{}{
+				"file": tokenPath,
+			},
+		}
+		if wi.ServiceAccountEmail != "" {
+			credentials["service_account_impersonation_url"] = fmt.Sprintf(
+				"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", wi.ServiceAccountEmail)
+		}
+		content, err := json.MarshalIndent(credentials, "", "  ")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		credentialsPath := path.Join(".config", "tf-controller", pc.Name+"-credentials.json")
+		return map[string]string{
+			"GOOGLE_APPLICATION_CREDENTIALS": path.Join(runner.HomePath, credentialsPath),
+		}, []*runner.FileMapping{{
+			Content:  content,
+			Location: "home",
+			Path:     credentialsPath,
+		}}, nil
+	}
+	return nil, nil, nil
+}
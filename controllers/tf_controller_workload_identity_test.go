@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkloadIdentityEnvs(t *testing.T) {
+	g := NewWithT(t)
+
+	pc := infrav1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "flux-system"},
+		Spec: infrav1.ProviderConfigSpec{
+			Provider: "aws",
+			WorkloadIdentity: &infrav1.WorkloadIdentitySpec{
+				RoleARN: "arn:aws:iam::123456789012:role/tf-runner",
+			},
+		},
+	}
+	envs, files, err := workloadIdentityEnvs(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(files).To(BeEmpty())
+	g.Expect(envs).To(Equal(map[string]string{
+		"AWS_ROLE_ARN":                "arn:aws:iam::123456789012:role/tf-runner",
+		"AWS_WEB_IDENTITY_TOKEN_FILE": "/var/run/secrets/tf-controller/workload-identity/aws/token",
+		"AWS_ROLE_SESSION_NAME":       "tf-controller-flux-system-aws",
+	}))
+
+	pc.Spec.CredentialsSecretRef = &meta.LocalObjectReference{Name: "aws-credentials"}
+	_, _, err = workloadIdentityEnvs(pc)
+	g.Expect(err).ToNot(BeNil())
+
+	pc = infrav1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp", Namespace: "flux-system"},
+		Spec: infrav1.ProviderConfigSpec{
+			Provider: "google",
+			WorkloadIdentity: &infrav1.WorkloadIdentitySpec{
+				WorkloadIdentityProvider: "projects/123/locations/global/workloadIdentityPools/pool/providers/k8s",
+				ServiceAccountEmail:      "tf@project.iam.gserviceaccount.com",
+			},
+		},
+	}
+	envs, files, err = workloadIdentityEnvs(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(envs).To(HaveKeyWithValue("GOOGLE_APPLICATION_CREDENTIALS", "/home/runner/.config/tf-controller/gcp-credentials.json"))
+	g.Expect(files).To(HaveLen(1))
+	g.Expect(files[0].Location).To(Equal("home"))
+	g.Expect(files[0].Content).To(MatchJSON(`{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/k8s",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "https://sts.googleapis.com/v1/token",
+  "credential_source": {"file": "/var/run/secrets/tf-controller/workload-identity/gcp/token"},
+  "service_account_impersonation_url": "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/tf@project.iam.gserviceaccount.com:generateAccessToken"
+}`))
+
+	pc = infrav1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "azure", Namespace: "flux-system"},
+		Spec: infrav1.ProviderConfigSpec{
+			Provider:         "azurerm",
+			WorkloadIdentity: &infrav1.WorkloadIdentitySpec{ClientID: "client"},
+		},
+	}
+	_, _, err = workloadIdentityEnvs(pc)
+	g.Expect(err).ToNot(BeNil())
+
+	pc.Spec.WorkloadIdentity.TenantID = "tenant"
+	envs, _, err = workloadIdentityEnvs(pc)
+	g.Expect(err).To(BeNil())
+	g.Expect(envs).To(HaveKeyWithValue("ARM_USE_OIDC", "true"))
+	g.Expect(envs).To(HaveKeyWithValue("ARM_OIDC_TOKEN_FILE_PATH", "/var/run/secrets/tf-controller/workload-identity/azure/token"))
+
+	pc.Spec.Provider = "kubernetes"
+	_, _, err = workloadIdentityEnvs(pc)
+	g.Expect(err).ToNot(BeNil())
+}
+
+func TestWorkloadIdentityVolumes(t *testing.T) {
+	g := NewWithT(t)
+
+	configs := []infrav1.ProviderConfig{
+		{ObjectMeta: metav1.ObjectMeta{Name: "k8s"}, Spec: infrav1.ProviderConfigSpec{Provider: "kubernetes"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+			Spec: infrav1.ProviderConfigSpec{
+				Provider:         "aws",
+				WorkloadIdentity: &infrav1.WorkloadIdentitySpec{RoleARN: "arn"},
+			},
+		},
+	}
+
+	volumes, mounts := workloadIdentityVolumes(configs)
+	g.Expect(volumes).To(HaveLen(1))
+	g.Expect(volumes[0].Name).To(Equal("workload-identity-1"))
+	g.Expect(volumes[0].Projected.Sources[0].ServiceAccountToken.Audience).To(Equal("sts.amazonaws.com"))
+	g.Expect(*volumes[0].Projected.Sources[0].ServiceAccountToken.ExpirationSeconds).To(Equal(int64(3600)))
+	g.Expect(mounts).To(HaveLen(1))
+	g.Expect(mounts[0].MountPath).To(Equal("/var/run/secrets/tf-controller/workload-identity/aws"))
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // StartGRPCServerForTesting should be used only for testing
-func StartGRPCServerForTesting(server *runner.TerraformRunnerServer, namespace string, addr string, mgr controllerruntime.Manager, rotator *CertRotator) error {
+func StartGRPCServerForTesting(server runner.RunnerServer, namespace string, addr string, mgr controllerruntime.Manager, rotator *CertRotator) error {
 	// wait for the certs to be available and the manager to be ready
 	<-rotator.Ready
 	<-mgr.Elected()
@@ -35,7 +35,7 @@ func StartGRPCServerForTesting(server *runner.TerraformRunnerServer, namespace s
 		return err
 	}
 
-	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	grpcServer := grpc.NewServer(grpc.Creds(creds), keepaliveEnforcement())
 
 	// local runner, use the same client as the manager
 	runner.RegisterRunnerServer(grpcServer, server)
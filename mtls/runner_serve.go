@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1beta1"
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
 	"github.com/weaveworks/tf-controller/runner"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -18,6 +20,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// MinKeepaliveInterval is the shortest interval between the keepalive pings accepted by the runner.
+const MinKeepaliveInterval = 10 * time.Second
+
+// keepaliveEnforcement accepts the keepalive pings of the controller, which detect a lost runner during long calls.
+func keepaliveEnforcement() grpc.ServerOption {
+	return grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             MinKeepaliveInterval,
+		PermitWithoutStream: true,
+	})
+}
+
 func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.Signal, maxMessageSizeInMiB int) error {
 	scheme := runtime.NewScheme()
 
@@ -66,7 +79,7 @@ func RunnerServe(namespace, addr string, tlsSecretName string, sigterm chan os.S
 
 	// 30 MB is the maximum allowed payload size for gRPC.
 	maxMsgSize := maxMessageSizeInMiB * 1024 * 1024
-	grpcServer := grpc.NewServer(grpc.Creds(credentials), grpc.MaxRecvMsgSize(maxMsgSize), grpc.MaxSendMsgSize(maxMsgSize))
+	grpcServer := grpc.NewServer(grpc.Creds(credentials), grpc.MaxRecvMsgSize(maxMsgSize), grpc.MaxSendMsgSize(maxMsgSize), keepaliveEnforcement())
 	runner.RegisterRunnerServer(grpcServer, runnerServer)
 
 	if err := grpcServer.Serve(listener); err != nil {
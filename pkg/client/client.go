@@ -0,0 +1,226 @@
+// Package client provides helpers to drive Terraform objects from Go programs,
+// e.g. platform tooling or integration tests, on top of a controller-runtime client.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPollInterval is the interval between the checks of the objects while waiting.
+const DefaultPollInterval = 2 * time.Second
+
+// ErrNoPendingPlan is returned when approving the plan of an object without a pending plan.
+var ErrNoPendingPlan = errors.New("no plan pending")
+
+// Client reads and updates Terraform objects. Its scheme must include the infrav1 and the core types.
+type Client struct {
+	runtimeclient.Client
+
+	// PollInterval is the interval between the checks of the objects while waiting,
+	// DefaultPollInterval when zero.
+	PollInterval time.Duration
+}
+
+// New returns a Client using c.
+func New(c runtimeclient.Client) *Client {
+	return &Client{Client: c}
+}
+
+// RequestReconciliation annotates the object to be reconciled, and returns the value of the annotation.
+func (c *Client) RequestReconciliation(ctx context.Context, key types.NamespacedName) (string, error) {
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+	return requestedAt, retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return err
+		}
+		patch := runtimeclient.MergeFrom(terraform.DeepCopy())
+		annotations := terraform.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[meta.ReconcileRequestAnnotation] = requestedAt
+		terraform.SetAnnotations(annotations)
+		return c.Patch(ctx, terraform, patch)
+	})
+}
+
+// ApprovePlan approves the pending plan of the object, and returns its name.
+// It returns ErrNoPendingPlan if the object has no pending plan.
+func (c *Client) ApprovePlan(ctx context.Context, key types.NamespacedName) (string, error) {
+	var approved string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return err
+		}
+		if terraform.Status.Plan.Pending == "" {
+			return ErrNoPendingPlan
+		}
+		patch := runtimeclient.MergeFrom(terraform.DeepCopy())
+		terraform.Spec.ApprovePlan = terraform.Status.Plan.Pending
+		approved = terraform.Status.Plan.Pending
+		return c.Patch(ctx, terraform, patch)
+	})
+	return approved, err
+}
+
+// Replan discards the pending plan of the object, and requests its reconciliation, which plans it again,
+// e.g. after a change outside of the source. It returns the name of the discarded plan, and the value of
+// the reconciliation request annotation. It returns ErrNoPendingPlan if the object has no pending plan.
+func (c *Client) Replan(ctx context.Context, key types.NamespacedName) (string, string, error) {
+	var discarded string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return err
+		}
+		if terraform.Status.Plan.Pending == "" {
+			return ErrNoPendingPlan
+		}
+		patch := runtimeclient.MergeFrom(terraform.DeepCopy())
+		discarded = terraform.Status.Plan.Pending
+		terraform.Status.Plan.Pending = ""
+		return c.Status().Patch(ctx, terraform, patch)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	requestedAt, err := c.RequestReconciliation(ctx, key)
+	return discarded, requestedAt, err
+}
+
+// WaitForReady waits until the object is ready for its current generation, or ctx is done.
+func (c *Client) WaitForReady(ctx context.Context, key types.NamespacedName) (*infrav1.Terraform, error) {
+	terraform := &infrav1.Terraform{}
+	err := c.poll(ctx, func() (bool, error) {
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return false, err
+		}
+		return terraform.Status.ObservedGeneration == terraform.Generation &&
+			apimeta.IsStatusConditionTrue(terraform.Status.Conditions, meta.ReadyCondition), nil
+	})
+	if err != nil {
+		return nil, c.waitError(key, "to be ready", terraform, err)
+	}
+	return terraform, nil
+}
+
+// WaitForReconciliation waits until the object has handled the reconciliation requested at requestedAt,
+// see RequestReconciliation, and its Ready condition is not in progress anymore. The Ready condition of the
+// returned object is either final, or waiting for the approval of a plan.
+func (c *Client) WaitForReconciliation(ctx context.Context, key types.NamespacedName, requestedAt string) (*infrav1.Terraform, error) {
+	requested, err := time.Parse(time.RFC3339Nano, requestedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	terraform := &infrav1.Terraform{}
+	err = c.poll(ctx, func() (bool, error) {
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return false, err
+		}
+		if terraform.Spec.Suspend {
+			return false, fmt.Errorf("%s is suspended", key)
+		}
+		return ReconciliationCompleted(terraform, requestedAt, requested), nil
+	})
+	if err != nil {
+		return nil, c.waitError(key, "to be reconciled", terraform, err)
+	}
+	return terraform, nil
+}
+
+// ReconciliationCompleted returns true if the Ready condition of the object has been set after the request,
+// to a final status or to waiting for the approval of a plan.
+func ReconciliationCompleted(terraform *infrav1.Terraform, requestedAt string, requested time.Time) bool {
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	if ready == nil || terraform.Status.ObservedGeneration != terraform.Generation {
+		return false
+	}
+
+	// the transition times are at the second, so the condition may have been set a bit before the request
+	handled := terraform.Status.LastHandledReconcileAt == requestedAt ||
+		!ready.LastTransitionTime.Time.Before(requested.Truncate(time.Second))
+	if !handled {
+		return false
+	}
+
+	return ready.Status != metav1.ConditionUnknown ||
+		(ready.Reason == "TerraformPlannedWithChanges" && terraform.Status.Plan.Pending != "")
+}
+
+// GetOutputs returns the outputs written to the Secret of .spec.writeOutputsToSecret of the object.
+func (c *Client) GetOutputs(ctx context.Context, key types.NamespacedName) (map[string][]byte, error) {
+	terraform := &infrav1.Terraform{}
+	if err := c.Get(ctx, key, terraform); err != nil {
+		return nil, err
+	}
+	return c.getOutputs(ctx, terraform)
+}
+
+// WaitForOutputs waits until the outputs of the current generation of the object are written,
+// and returns them.
+func (c *Client) WaitForOutputs(ctx context.Context, key types.NamespacedName) (map[string][]byte, error) {
+	terraform := &infrav1.Terraform{}
+	err := c.poll(ctx, func() (bool, error) {
+		if err := c.Get(ctx, key, terraform); err != nil {
+			return false, err
+		}
+		if terraform.Spec.WriteOutputsToSecret == nil {
+			return false, fmt.Errorf("%s does not write its outputs", key)
+		}
+		return terraform.Status.ObservedGeneration == terraform.Generation &&
+			apimeta.IsStatusConditionTrue(terraform.Status.Conditions, infrav1.ConditionTypeOutput), nil
+	})
+	if err != nil {
+		return nil, c.waitError(key, "to write its outputs", terraform, err)
+	}
+	return c.getOutputs(ctx, terraform)
+}
+
+func (c *Client) getOutputs(ctx context.Context, terraform *infrav1.Terraform) (map[string][]byte, error) {
+	if terraform.Spec.WriteOutputsToSecret == nil {
+		return nil, fmt.Errorf("%s/%s does not write its outputs", terraform.Namespace, terraform.Name)
+	}
+
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: terraform.Namespace, Name: terraform.Spec.WriteOutputsToSecret.Name}
+	if err := c.Get(ctx, key, &secret); err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+func (c *Client) poll(ctx context.Context, condition wait.ConditionFunc) error {
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return wait.PollImmediateUntil(interval, condition, ctx.Done())
+}
+
+// waitError adds the last Ready message of the object to a timeout.
+func (c *Client) waitError(key types.NamespacedName, what string, terraform *infrav1.Terraform, err error) error {
+	if err != wait.ErrWaitTimeout {
+		return err
+	}
+	if ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition); ready != nil {
+		return fmt.Errorf("timed out waiting for %s %s, last status %s: %s", key, what, ready.Status, ready.Message)
+	}
+	return fmt.Errorf("timed out waiting for %s %s", key, what)
+}
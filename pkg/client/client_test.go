@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciliationCompleted(t *testing.T) {
+	g := NewWithT(t)
+
+	requestedAt := "2022-10-14T10:00:00.5Z"
+	requested, _ := time.Parse(time.RFC3339Nano, requestedAt)
+	withReady := func(status metav1.ConditionStatus, reason string, at time.Time) *infrav1.Terraform {
+		return &infrav1.Terraform{Status: infrav1.TerraformStatus{Conditions: []metav1.Condition{{
+			Type: meta.ReadyCondition, Status: status, Reason: reason, LastTransitionTime: metav1.NewTime(at),
+		}}}}
+	}
+
+	g.Expect(ReconciliationCompleted(&infrav1.Terraform{}, requestedAt, requested)).To(BeFalse())
+	g.Expect(ReconciliationCompleted(withReady(metav1.ConditionTrue, "TerraformAppliedSucceed", requested.Add(-time.Minute)), requestedAt, requested)).To(BeFalse())
+	g.Expect(ReconciliationCompleted(withReady(metav1.ConditionUnknown, meta.ProgressingReason, requested.Add(time.Second)), requestedAt, requested)).To(BeFalse())
+	g.Expect(ReconciliationCompleted(withReady(metav1.ConditionTrue, "TerraformAppliedSucceed", requested.Add(time.Second)), requestedAt, requested)).To(BeTrue())
+	g.Expect(ReconciliationCompleted(withReady(metav1.ConditionFalse, infrav1.TFExecPlanFailedReason, requested.Add(time.Second)), requestedAt, requested)).To(BeTrue())
+
+	pending := withReady(metav1.ConditionUnknown, "TerraformPlannedWithChanges", requested.Add(-time.Minute))
+	pending.Status.Plan.Pending = "plan-main-abc"
+	g.Expect(ReconciliationCompleted(pending, requestedAt, requested)).To(BeFalse())
+	pending.Status.LastHandledReconcileAt = requestedAt
+	g.Expect(ReconciliationCompleted(pending, requestedAt, requested)).To(BeTrue())
+}
+
+func TestClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec:       infrav1.TerraformSpec{WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"}},
+		Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-abc"}},
+	}
+	c := &Client{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld-outputs", Namespace: key.Namespace},
+			Data:       map[string][]byte{"hello": []byte("world")},
+		}).Build(),
+		PollInterval: 10 * time.Millisecond,
+	}
+
+	approved, err := c.ApprovePlan(ctx, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(approved).To(Equal("plan-main-abc"))
+	g.Expect(c.Get(ctx, key, terraform)).To(Succeed())
+	g.Expect(terraform.Spec.ApprovePlan).To(Equal("plan-main-abc"))
+
+	// the outputs are not written yet
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.WaitForOutputs(timeoutCtx, key)
+	g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for flux-system/helloworld to write its outputs")))
+
+	requestedAt, err := c.RequestReconciliation(ctx, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(ctx, key, terraform)).To(Succeed())
+	g.Expect(terraform.Annotations[meta.ReconcileRequestAnnotation]).To(Equal(requestedAt))
+
+	*terraform = infrav1.TerraformOutputsWritten(*terraform, "main/abc", "Outputs written")
+	terraform.Status.Plan.Pending = ""
+	terraform.Status.LastHandledReconcileAt = requestedAt
+	g.Expect(c.Status().Update(ctx, terraform)).To(Succeed())
+
+	_, err = c.ApprovePlan(ctx, key)
+	g.Expect(err).To(Equal(ErrNoPendingPlan))
+	_, _, err = c.Replan(ctx, key)
+	g.Expect(err).To(Equal(ErrNoPendingPlan))
+
+	ready, err := c.WaitForReady(ctx, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ready.Status.LastAttemptedRevision).To(Equal("main/abc"))
+
+	reconciled, err := c.WaitForReconciliation(ctx, key, requestedAt)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reconciled.Status.LastHandledReconcileAt).To(Equal(requestedAt))
+
+	outputs, err := c.WaitForOutputs(ctx, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(outputs).To(Equal(map[string][]byte{"hello": []byte("world")}))
+
+	// a replan discards the pending plan, and requests a reconciliation
+	ready.Status.Plan.Pending = "plan-main-def"
+	g.Expect(c.Status().Update(ctx, ready)).To(Succeed())
+	discarded, replanRequestedAt, err := c.Replan(ctx, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(discarded).To(Equal("plan-main-def"))
+	g.Expect(c.Get(ctx, key, terraform)).To(Succeed())
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(terraform.Annotations[meta.ReconcileRequestAnnotation]).To(Equal(replanRequestedAt))
+}
@@ -0,0 +1,398 @@
+// Package oci provides a minimal client of the OCI distribution API, pushing, pulling and deleting
+// artifacts made of a single layer, e.g. the snapshots stored by the runner in an OCI repository.
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ConfigMediaType is the media type of the empty config of the artifacts.
+const ConfigMediaType = "application/vnd.weaveworks.tf-controller.config.v1+json"
+
+// ErrNotFound is returned when pulling an artifact whose tag does not exist.
+var ErrNotFound = errors.New("artifact not found")
+
+// Client pushes and pulls the artifacts of the repositories of a registry, e.g. ghcr.io/org/tfstate.
+type Client struct {
+	// HTTPClient sends the requests, http.DefaultClient when nil.
+	HTTPClient *http.Client
+	// Username and Password authenticate with the registry, or with its token service.
+	// The registry is accessed anonymously without them.
+	Username string
+	Password string
+	// Insecure accesses the registry over plain HTTP.
+	Insecure bool
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// ParseRepository splits a repository into the host of its registry and its name, e.g. ghcr.io and org/tfstate.
+func ParseRepository(repository string) (string, string, error) {
+	host, name, ok := strings.Cut(repository, "/")
+	if !ok || host == "" || name == "" {
+		return "", "", fmt.Errorf("invalid repository %q, expected <registry>/<name>", repository)
+	}
+	if strings.ContainsAny(name, ":@") {
+		return "", "", fmt.Errorf("invalid repository %q, it must not have a tag or a digest", repository)
+	}
+	return host, name, nil
+}
+
+// CredentialsFromDockerConfig returns the username and the password of a registry in the JSON
+// of the .dockerconfigjson key of a Secret of the kubernetes.io/dockerconfigjson type.
+func CredentialsFromDockerConfig(dockerConfig []byte, host string) (string, string, error) {
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(dockerConfig, &config); err != nil {
+		return "", "", fmt.Errorf("invalid docker config: %w", err)
+	}
+
+	for registry, auth := range config.Auths {
+		registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+		if strings.TrimSuffix(registry, "/") != host {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid auth of %s in the docker config: %w", host, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	return "", "", fmt.Errorf("no credentials of %s in the docker config", host)
+}
+
+// Push uploads data as the single layer of mediaType of an artifact tagged tag in repository,
+// and returns the digest of its manifest.
+func (c *Client) Push(ctx context.Context, repository, tag, mediaType string, data []byte, annotations map[string]string) (string, error) {
+	host, name, err := ParseRepository(repository)
+	if err != nil {
+		return "", err
+	}
+
+	config := []byte("{}")
+	configDesc, err := c.pushBlob(ctx, host, name, ConfigMediaType, config)
+	if err != nil {
+		return "", fmt.Errorf("unable to push the config: %w", err)
+	}
+	layerDesc, err := c.pushBlob(ctx, host, name, mediaType, data)
+	if err != nil {
+		return "", fmt.Errorf("unable to push the layer: %w", err)
+	}
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      configDesc,
+		Layers:      []ocispec.Descriptor{layerDesc},
+		Annotations: annotations,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ctx, host, name, http.MethodPut, c.url(host, "/v2/"+name+"/manifests/"+tag), manifest,
+		map[string]string{"Content-Type": ocispec.MediaTypeImageManifest})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", responseError("push the manifest", resp)
+	}
+	return digest.FromBytes(manifest).String(), nil
+}
+
+// Pull downloads the layer of mediaType of the artifact tagged tag in repository.
+func (c *Client) Pull(ctx context.Context, repository, tag, mediaType string) ([]byte, error) {
+	host, name, err := ParseRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := c.manifest(ctx, host, name, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaType {
+			continue
+		}
+		resp, err := c.do(ctx, host, name, http.MethodGet, c.url(host, "/v2/"+name+"/blobs/"+layer.Digest.String()), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, responseError("pull the layer", resp)
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if layer.Digest.Validate() != nil || layer.Digest.Algorithm().FromBytes(data) != layer.Digest {
+			return nil, fmt.Errorf("the layer of %s:%s does not match its digest %s", repository, tag, layer.Digest)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("the artifact %s:%s has no layer of type %s", repository, tag, mediaType)
+}
+
+// Delete deletes the manifest of the artifact tagged tag in repository. An artifact not found is not an error.
+func (c *Client) Delete(ctx context.Context, repository, tag string) error {
+	host, name, err := ParseRepository(repository)
+	if err != nil {
+		return err
+	}
+
+	_, manifestDigest, err := c.manifest(ctx, host, name, tag)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, host, name, http.MethodDelete, c.url(host, "/v2/"+name+"/manifests/"+manifestDigest), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError("delete the manifest", resp)
+	}
+	return nil
+}
+
+// manifest returns the manifest of a tag, and its digest.
+func (c *Client) manifest(ctx context.Context, host, name, tag string) (*ocispec.Manifest, string, error) {
+	resp, err := c.do(ctx, host, name, http.MethodGet, c.url(host, "/v2/"+name+"/manifests/"+tag), nil,
+		map[string]string{"Accept": ocispec.MediaTypeImageManifest})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("%w: %s/%s:%s", ErrNotFound, host, name, tag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", responseError("get the manifest", resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("invalid manifest of %s/%s:%s: %w", host, name, tag, err)
+	}
+	manifestDigest := resp.Header.Get("Docker-Content-Digest")
+	if manifestDigest == "" {
+		manifestDigest = digest.FromBytes(data).String()
+	}
+	return &manifest, manifestDigest, nil
+}
+
+// pushBlob uploads a blob, unless the repository already has it, with a monolithic upload.
+func (c *Client) pushBlob(ctx context.Context, host, name, mediaType string, data []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(data), Size: int64(len(data))}
+
+	resp, err := c.do(ctx, host, name, http.MethodHead, c.url(host, "/v2/"+name+"/blobs/"+desc.Digest.String()), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = c.do(ctx, host, name, http.MethodPost, c.url(host, "/v2/"+name+"/blobs/uploads/"), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return desc, responseError("start the upload", resp)
+	}
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return desc, fmt.Errorf("invalid location of the upload: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", desc.Digest.String())
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, host, name, http.MethodPut, location.String(), data,
+		map[string]string{"Content-Type": "application/octet-stream"})
+	if err != nil {
+		return desc, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return desc, responseError("upload the blob", resp)
+	}
+	return desc, nil
+}
+
+func (c *Client) url(host, path string) string {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	return scheme + "://" + host + path
+}
+
+// do sends a request to the registry, authenticating once it is challenged to: with the credentials
+// for a Basic challenge, or with a token of the pull and push scope of the repository for a Bearer challenge.
+func (c *Client) do(ctx context.Context, host, name, method, rawURL string, body []byte, headers map[string]string) (*http.Response, error) {
+	send := func(authorization string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return c.httpClient().Do(req)
+	}
+
+	scope := "repository:" + name + ":pull,push,delete"
+	resp, err := send(c.token(host, scope))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	authorization, err := c.authorize(ctx, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.tokens == nil {
+		c.tokens = map[string]string{}
+	}
+	c.tokens[host+"/"+scope] = authorization
+	c.mu.Unlock()
+	return send(authorization)
+}
+
+func (c *Client) token(host, scope string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[host+"/"+scope]
+}
+
+// authorize returns the Authorization header answering a challenge of the registry.
+func (c *Client) authorize(ctx context.Context, challenge, scope string) (string, error) {
+	authScheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(authScheme) {
+	case "basic":
+		if c.Username == "" {
+			return "", fmt.Errorf("the registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q of the registry", challenge)
+	}
+
+	attrs := parseChallengeParams(params)
+	realm, err := url.Parse(attrs["realm"])
+	if err != nil || attrs["realm"] == "" {
+		return "", fmt.Errorf("invalid realm of the authentication challenge %q", challenge)
+	}
+	query := realm.Query()
+	if attrs["service"] != "" {
+		query.Set("service", attrs["service"])
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError("get a token", resp)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("invalid token of the registry: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallengeParams parses the comma-separated key="value" parameters of a challenge.
+func parseChallengeParams(params string) map[string]string {
+	attrs := map[string]string{}
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(strings.TrimLeft(params, " ,"), "=")
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			attrs[strings.ToLower(key)] = value
+		}
+	}
+	return attrs
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func responseError(action string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unable to %s: %s %s: %s", action, resp.Request.Method, resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,174 @@
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+)
+
+// registry is an in-memory registry of a single repository, requiring a token of its token service.
+type registry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tags      map[string]string
+}
+
+func newRegistry(t *testing.T) *httptest.Server {
+	reg := &registry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, tags: map[string]string{}}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+
+		if req.URL.Path == "/token" {
+			if user, pass, _ := req.BasicAuth(); user != "alice" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"t0k3n"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry",scope="repository:org/tfstate:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		path := strings.TrimPrefix(req.URL.Path, "/v2/org/tfstate")
+		body, _ := ioutil.ReadAll(req.Body)
+		switch {
+		case req.Method == http.MethodHead && strings.HasPrefix(path, "/blobs/"):
+			if _, ok := reg.blobs[strings.TrimPrefix(path, "/blobs/")]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case req.Method == http.MethodGet && strings.HasPrefix(path, "/blobs/"):
+			data, ok := reg.blobs[strings.TrimPrefix(path, "/blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case req.Method == http.MethodPost && path == "/blobs/uploads/":
+			w.Header().Set("Location", "/v2/org/tfstate/blobs/uploads/1?state=abc")
+			w.WriteHeader(http.StatusAccepted)
+		case req.Method == http.MethodPut && path == "/blobs/uploads/1":
+			if req.URL.Query().Get("state") != "abc" || digest.FromBytes(body).String() != req.URL.Query().Get("digest") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			reg.blobs[req.URL.Query().Get("digest")] = body
+			w.WriteHeader(http.StatusCreated)
+		case req.Method == http.MethodPut && strings.HasPrefix(path, "/manifests/"):
+			d := digest.FromBytes(body).String()
+			reg.manifests[d] = body
+			reg.tags[strings.TrimPrefix(path, "/manifests/")] = d
+			w.WriteHeader(http.StatusCreated)
+		case req.Method == http.MethodGet && strings.HasPrefix(path, "/manifests/"):
+			d, ok := reg.tags[strings.TrimPrefix(path, "/manifests/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", d)
+			_, _ = w.Write(reg.manifests[d])
+		case req.Method == http.MethodDelete && strings.HasPrefix(path, "/manifests/"):
+			d := strings.TrimPrefix(path, "/manifests/")
+			for tag, tagged := range reg.tags {
+				if tagged == d {
+					delete(reg.tags, tag)
+				}
+			}
+			delete(reg.manifests, d)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	server := newRegistry(t)
+	repository := strings.TrimPrefix(server.URL, "http://") + "/org/tfstate"
+	c := &Client{Username: "alice", Password: "secret", Insecure: true}
+
+	manifestDigest, err := c.Push(ctx, repository, "20230201-100530", "application/vnd.test+gzip", []byte("state"), map[string]string{"serial": "3"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(manifestDigest).To(HavePrefix("sha256:"))
+
+	data, err := c.Pull(ctx, repository, "20230201-100530", "application/vnd.test+gzip")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("state"))
+
+	_, err = c.Pull(ctx, repository, "20230201-100530", "application/vnd.other")
+	g.Expect(err).To(MatchError(ContainSubstring("has no layer of type application/vnd.other")))
+
+	// the blobs already in the repository are not uploaded again
+	_, err = c.Push(ctx, repository, "20230201-110000", "application/vnd.test+gzip", []byte("state"), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(c.Delete(ctx, repository, "20230201-100530")).To(Succeed())
+	_, err = c.Pull(ctx, repository, "20230201-100530", "application/vnd.test+gzip")
+	g.Expect(err).To(MatchError(ErrNotFound))
+	g.Expect(c.Delete(ctx, repository, "20230201-100530")).To(Succeed())
+
+	anonymous := &Client{Insecure: true}
+	_, err = anonymous.Pull(ctx, repository, "20230201-110000", "application/vnd.test+gzip")
+	g.Expect(err).To(MatchError(ContainSubstring("unable to get a token")))
+}
+
+func TestParseRepository(t *testing.T) {
+	g := NewWithT(t)
+
+	host, name, err := ParseRepository("ghcr.io/org/tfstate/helloworld")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(host).To(Equal("ghcr.io"))
+	g.Expect(name).To(Equal("org/tfstate/helloworld"))
+
+	_, _, err = ParseRepository("tfstate")
+	g.Expect(err).To(HaveOccurred())
+	_, _, err = ParseRepository("ghcr.io/org/tfstate:latest")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCredentialsFromDockerConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	auth := base64.StdEncoding.EncodeToString([]byte("alice:secret"))
+	config := []byte(`{"auths":{"https://ghcr.io/":{"auth":"` + auth + `"},"registry.example.com":{"username":"bob","password":"pass"}}}`)
+
+	username, password, err := CredentialsFromDockerConfig(config, "ghcr.io")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(username).To(Equal("alice"))
+	g.Expect(password).To(Equal("secret"))
+
+	username, password, err = CredentialsFromDockerConfig(config, "registry.example.com")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(username).To(Equal("bob"))
+	g.Expect(password).To(Equal("pass"))
+
+	_, _, err = CredentialsFromDockerConfig(config, "quay.io")
+	g.Expect(err).To(MatchError("no credentials of quay.io in the docker config"))
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(parseChallengeParams(`realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/a:pull,push"`)).To(Equal(map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:org/a:pull,push",
+	}))
+}
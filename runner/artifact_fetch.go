@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ArtifactTLSDirEnvName is the environment variable of the directory of the TLS files used by the runner
+// to download the artifacts from the source-controller: ca.crt to verify the server, with tls.crt and tls.key
+// to authenticate the runner with mTLS. Each file is optional.
+const ArtifactTLSDirEnvName = "SOURCE_CONTROLLER_TLS_DIR"
+
+// artifactFetchTimeout bounds the download of an artifact by the runner.
+const artifactFetchTimeout = 10 * time.Minute
+
+// artifactTLSConfig returns the TLS configuration of the downloads of the artifacts, nil without TLS directory.
+func artifactTLSConfig() (*tls.Config, error) {
+	dir := os.Getenv(ArtifactTLSDirEnvName)
+	if dir == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	ca, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	if err == nil {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in %s", filepath.Join(dir, "ca.crt"))
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if _, err := os.Stat(certFile); err == nil {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// fetchArtifact downloads the artifact from the source-controller to a temporary file, and verifies its checksum,
+// so that the artifact does not go through the controller. The artifact is rejected beyond limit bytes.
+func fetchArtifact(ctx context.Context, url string, checksum string, limit int64) (io.ReadCloser, error) {
+	// the artifact is not downloaded when it can't be verified
+	if checksum == "" {
+		return nil, fmt.Errorf("failed to verify artifact from %s: no checksum advertised", url)
+	}
+
+	tlsConfig, err := artifactTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: transport, Timeout: artifactFetchTimeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact, error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download artifact from %s, status: %s", url, resp.Status)
+	}
+	return spoolArtifact(resp.Body, checksum, limit)
+}
@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// UploadAndExtractStream extracts an artifact streamed in chunks by the controller, so that
+// neither the controller nor the runner holds the whole artifact in memory. The artifact is
+// spooled to a temporary file and verified against the checksum of the request before being extracted.
+func (r *TerraformRunnerServer) UploadAndExtractStream(stream Runner_UploadAndExtractStreamServer) error {
+	ctx := stream.Context()
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	first, err := stream.Recv()
+	if err != nil {
+		log.Error(err, "unable to receive the request of the artifact")
+		return err
+	}
+	req := first.Request
+	if req == nil {
+		err := fmt.Errorf("the stream of the artifact must start with its request")
+		log.Error(err, "no request")
+		return err
+	}
+
+	reply, err := r.extractArtifact(ctx, req, func() (io.ReadCloser, error) {
+		return spoolArtifact(&chunkReader{stream: stream, data: first.Data}, req.Checksum, spoolLimit(req))
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+// spooledArtifact is an artifact received in a temporary file, removed once closed.
+type spooledArtifact struct {
+	*os.File
+}
+
+func (a spooledArtifact) Close() error {
+	err := a.File.Close()
+	if rmErr := os.Remove(a.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// chunkReader reads the chunks of the stream of an artifact, starting with data.
+type chunkReader struct {
+	stream Runner_UploadAndExtractStreamServer
+	data   []byte
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.data) == 0 {
+		chunk, err := c.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to receive the artifact: %w", err)
+		}
+		c.data = chunk.Data
+	}
+	n := copy(p, c.data)
+	c.data = c.data[n:]
+	return n, nil
+}
+
+const (
+	// defaultMaxSpoolSize is the maximum size of a spooled artifact when the size of its files is not limited.
+	defaultMaxSpoolSize = 1 << 30
+	// maxSpoolOverhead is the size allowed in a spooled artifact on top of the size of its files,
+	// for the headers and the padding of the tar entries, and the framing of gzip.
+	maxSpoolOverhead = 16 << 20
+)
+
+// spoolLimit returns the maximum size of the artifact of req, before it is extracted.
+func spoolLimit(req *UploadAndExtractRequest) int64 {
+	return ArtifactSpoolLimit(req.MaxSize)
+}
+
+// ArtifactSpoolLimit returns the maximum size of an artifact whose files are at most maxSize bytes in total,
+// 0 for no limit on the files.
+func ArtifactSpoolLimit(maxSize int64) int64 {
+	if maxSize <= 0 {
+		return defaultMaxSpoolSize
+	}
+	return maxSize + maxSpoolOverhead
+}
+
+// spoolArtifact writes the artifact read from r to a temporary file, and verifies its checksum.
+// The artifact is rejected beyond limit bytes, before the limits of its extraction apply.
+func spoolArtifact(r io.Reader, checksum string, limit int64) (io.ReadCloser, error) {
+	if checksum == "" {
+		return nil, fmt.Errorf("failed to verify artifact: no checksum advertised")
+	}
+
+	f, err := os.CreateTemp("", "artifact-*.tar.gz")
+	if err != nil {
+		return nil, err
+	}
+	artifact := spooledArtifact{File: f}
+
+	hasher := artifactHasher(checksum)
+	n, err := io.Copy(io.MultiWriter(f, hasher), io.LimitReader(r, limit+1))
+	if err != nil {
+		artifact.Close()
+		return nil, err
+	}
+	if n > limit {
+		artifact.Close()
+		return nil, fmt.Errorf("artifact exceeds the maximum size of %d bytes", limit)
+	}
+
+	if sum := fmt.Sprintf("%x", hasher.Sum(nil)); sum != checksum {
+		artifact.Close()
+		return nil, fmt.Errorf("failed to verify artifact: computed checksum '%s' doesn't match advertised '%s'", sum, checksum)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		artifact.Close()
+		return nil, err
+	}
+	return artifact, nil
+}
+
+// artifactHasher returns the hash of the checksum of an artifact, which is SHA-1 for source-controller v0.17.2 and older.
+func artifactHasher(checksum string) hash.Hash {
+	if len(checksum) == 40 {
+		return sha1.New()
+	}
+	return sha256.New()
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSpoolArtifact(t *testing.T) {
+	artifact := []byte("artifact")
+	sha256sum := fmt.Sprintf("%x", sha256.Sum256(artifact))
+
+	tests := []struct {
+		name     string
+		checksum string
+		limit    int64
+		err      string
+	}{
+		{name: "sha256", checksum: sha256sum, limit: 1024},
+		{name: "sha1 of source-controller v0.17.2 and older", checksum: fmt.Sprintf("%x", sha1.Sum(artifact)), limit: 1024},
+		{name: "at the limit", checksum: sha256sum, limit: int64(len(artifact))},
+		{name: "no checksum", limit: 1024, err: "no checksum advertised"},
+		{name: "checksum mismatch", checksum: fmt.Sprintf("%x", sha256.Sum256([]byte("tampered"))), limit: 1024, err: "doesn't match advertised"},
+		{name: "over the limit", checksum: sha256sum, limit: int64(len(artifact)) - 1, err: "exceeds the maximum size of 7 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv("TMPDIR", t.TempDir())
+
+			spooled, err := spoolArtifact(bytes.NewReader(artifact), tt.checksum, tt.limit)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+				// the rejected artifacts are not left behind
+				entries, err := os.ReadDir(os.Getenv("TMPDIR"))
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(entries).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			content, err := io.ReadAll(spooled)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(content).To(Equal(artifact))
+			g.Expect(spooled.Close()).To(Succeed())
+		})
+	}
+}
+
+func TestSpoolLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(spoolLimit(&UploadAndExtractRequest{})).To(Equal(int64(defaultMaxSpoolSize)))
+	g.Expect(spoolLimit(&UploadAndExtractRequest{MaxSize: 1024})).To(Equal(int64(1024 + maxSpoolOverhead)))
+}
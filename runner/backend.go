@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/zclconf/go-cty/cty"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// typedBackendCredentials are the keys of the credentials Secrets of the typed backends,
+// each of them set as the attribute of the same name of the backend block.
+var typedBackendCredentials = map[string][]string{
+	infrav1.BackendTypeS3:      {"access_key", "secret_key", "token"},
+	infrav1.BackendTypeGCS:     {"credentials"},
+	infrav1.BackendTypeAzureRM: {"access_key", "sas_token", "client_id", "client_secret", "tenant_id", "subscription_id"},
+}
+
+// generateTypedBackend returns the terraform block with the s3, gcs or azurerm backend of backendConfig,
+// whose credentials are read from the Secret of the namespace of the object.
+func (r *TerraformRunnerServer) generateTypedBackend(ctx context.Context, namespace string, backendConfig infrav1.BackendConfigSpec) ([]byte, error) {
+	file := hclwrite.NewEmptyFile()
+	backend := file.Body().AppendNewBlock("terraform", nil).Body().
+		AppendNewBlock("backend", []string{backendConfig.Type}).Body()
+
+	var credentials *meta.LocalObjectReference
+	switch {
+	case backendConfig.Type == infrav1.BackendTypeS3 && backendConfig.S3 != nil:
+		s3 := backendConfig.S3
+		backend.SetAttributeValue("bucket", cty.StringVal(s3.Bucket))
+		backend.SetAttributeValue("key", cty.StringVal(s3.Key))
+		backend.SetAttributeValue("region", cty.StringVal(s3.Region))
+		if s3.Endpoint != "" {
+			backend.SetAttributeValue("endpoint", cty.StringVal(s3.Endpoint))
+		}
+		if s3.DynamoDBTable != "" {
+			backend.SetAttributeValue("dynamodb_table", cty.StringVal(s3.DynamoDBTable))
+		}
+		if s3.Encrypt {
+			backend.SetAttributeValue("encrypt", cty.True)
+		}
+		if s3.ForcePathStyle {
+			backend.SetAttributeValue("force_path_style", cty.True)
+		}
+		credentials = s3.CredentialsSecretRef
+	case backendConfig.Type == infrav1.BackendTypeGCS && backendConfig.GCS != nil:
+		gcs := backendConfig.GCS
+		backend.SetAttributeValue("bucket", cty.StringVal(gcs.Bucket))
+		backend.SetAttributeValue("prefix", cty.StringVal(gcs.Prefix))
+		credentials = gcs.CredentialsSecretRef
+	case backendConfig.Type == infrav1.BackendTypeAzureRM && backendConfig.AzureRM != nil:
+		azurerm := backendConfig.AzureRM
+		backend.SetAttributeValue("resource_group_name", cty.StringVal(azurerm.ResourceGroupName))
+		backend.SetAttributeValue("storage_account_name", cty.StringVal(azurerm.StorageAccountName))
+		backend.SetAttributeValue("container_name", cty.StringVal(azurerm.ContainerName))
+		backend.SetAttributeValue("key", cty.StringVal(azurerm.Key))
+		credentials = azurerm.CredentialsSecretRef
+	default:
+		return nil, fmt.Errorf("the backend of type %q has no configuration", backendConfig.Type)
+	}
+
+	if credentials != nil {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: credentials.Name}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the credentials of the %s backend: %w", backendConfig.Type, err)
+		}
+		keys := typedBackendCredentials[backendConfig.Type]
+		found := false
+		for _, key := range keys {
+			if value, ok := secret.Data[key]; ok {
+				backend.SetAttributeValue(key, cty.StringVal(string(value)))
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("the Secret %s of the credentials of the %s backend has none of the keys %s",
+				credentials.Name, backendConfig.Type, strings.Join(keys, ", "))
+		}
+	}
+
+	return file.Bytes(), nil
+}
@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/weaveworks/tf-controller/utils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const cdktfOutDir = "cdktf.out"
+
+// SynthCDKTF runs `cdktf synth` on the CDK for Terraform application in the working directory,
+// and returns the directory of the synthesized stack, where Terraform runs next.
+func (r *TerraformRunnerServer) SynthCDKTF(ctx context.Context, req *SynthCDKTFRequest) (*SynthCDKTFReply, error) {
+	log := ctrl.LoggerFrom(ctx).WithName(loggerName)
+	log.Info("synthesizing the CDK for Terraform application", "language", req.Language, "stack", req.Stack)
+
+	data, err := os.ReadFile(filepath.Join(req.WorkingDir, "cdktf.json"))
+	if err != nil {
+		err = fmt.Errorf("error reading cdktf.json of the application: %s", err)
+		log.Error(err, "unable to read the application")
+		return nil, err
+	}
+	var app struct {
+		Language string `json:"language"`
+	}
+	if err := json.Unmarshal(data, &app); err != nil {
+		err = fmt.Errorf("error parsing cdktf.json of the application: %s", err)
+		log.Error(err, "unable to read the application")
+		return nil, err
+	}
+	if app.Language != req.Language {
+		err := fmt.Errorf("the language of cdktf.json is %q, not %q", app.Language, req.Language)
+		log.Error(err, "language mismatch")
+		return nil, err
+	}
+
+	if req.Stack != "" && filepath.Base(req.Stack) != req.Stack {
+		return nil, fmt.Errorf("invalid stack name %q", req.Stack)
+	}
+
+	execPath, err := exec.LookPath("cdktf")
+	if err != nil {
+		err = fmt.Errorf("cannot find the cdktf CLI in the runner image: %s", err)
+		log.Error(err, "unable to synthesize")
+		return nil, err
+	}
+
+	// the previous synthesized stacks are removed, not to plan a stack removed from the application
+	if err := os.RemoveAll(filepath.Join(req.WorkingDir, cdktfOutDir)); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, execPath, "synth", "--output", cdktfOutDir)
+	cmd.Dir = req.WorkingDir
+	cmd.Env = append(os.Environ(), "CHECKPOINT_DISABLE=1", "CI=1")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		message, _ := utils.TruncateText(string(output), 4096)
+		err = fmt.Errorf("error running cdktf synth: %s\n%s", err, message)
+		log.Error(err, "unable to synthesize")
+		return nil, err
+	}
+
+	stacksDir := filepath.Join(req.WorkingDir, cdktfOutDir, "stacks")
+	entries, err := os.ReadDir(stacksDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the synthesized stacks: %s", err)
+	}
+	var stacks []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			stacks = append(stacks, entry.Name())
+		}
+	}
+	sort.Strings(stacks)
+
+	stack := req.Stack
+	if stack == "" {
+		if len(stacks) != 1 {
+			return nil, fmt.Errorf("the application synthesizes the stacks %v, spec.cdktf.stack must select one", stacks)
+		}
+		stack = stacks[0]
+	} else if i := sort.SearchStrings(stacks, stack); i == len(stacks) || stacks[i] != stack {
+		return nil, fmt.Errorf("stack %q not found in the synthesized stacks %v", stack, stacks)
+	}
+
+	stackDir := filepath.Join(stacksDir, stack)
+	if req.RemoveBackend {
+		if err := removeStackBackend(filepath.Join(stackDir, "cdk.tf.json")); err != nil {
+			err = fmt.Errorf("error removing the backend of stack %s: %s", stack, err)
+			log.Error(err, "unable to prepare the stack")
+			return nil, err
+		}
+	}
+
+	return &SynthCDKTFReply{WorkingDir: stackDir}, nil
+}
+
+// removeStackBackend removes the backend of a synthesized stack, the local one by default,
+// as Terraform fails with more than one backend.
+func removeStackBackend(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	// the numbers are kept as written
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var config map[string]interface{}
+	if err := decoder.Decode(&config); err != nil {
+		return err
+	}
+
+	block, ok := config["terraform"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if _, ok := block["backend"]; !ok {
+		return nil
+	}
+	delete(block, "backend")
+
+	// the version constraints are kept readable, e.g. ">= 1.0"
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, bytes.TrimSuffix(buf.Bytes(), []byte("\n")), 0644)
+}
@@ -0,0 +1,213 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// stubCDKTF puts a cdktf CLI on PATH, which synthesizes the stacks of $CDKTF_TEST_STACKS with a local backend.
+func stubCDKTF(t *testing.T, stacks string) {
+	bin := t.TempDir()
+	script := `#!/bin/sh
+for stack in $CDKTF_TEST_STACKS; do
+  mkdir -p "$3/stacks/$stack"
+  echo '{"terraform":{"backend":{"local":{"path":"terraform.tfstate"}},"required_version":">= 1.0"},"resource":{"null_resource":{"a":{"count":1}}}}' > "$3/stacks/$stack/cdk.tf.json"
+done
+`
+	if err := os.WriteFile(filepath.Join(bin, "cdktf"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("CDKTF_TEST_STACKS", stacks)
+}
+
+// cdktfApp returns the working directory of an application in the language.
+func cdktfApp(t *testing.T, language string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "cdktf.json"), []byte(`{"language":"`+language+`","app":"npx ts-node main.ts"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSynthCDKTF(t *testing.T) {
+	tests := []struct {
+		name     string
+		stacks   string
+		language string
+		req      *SynthCDKTFRequest
+		stack    string
+		err      string
+	}{
+		{
+			name:     "the only stack",
+			stacks:   "app",
+			language: "typescript",
+			req:      &SynthCDKTFRequest{Language: "typescript"},
+			stack:    "app",
+		},
+		{
+			name:     "the selected stack",
+			stacks:   "dev prod",
+			language: "typescript",
+			req:      &SynthCDKTFRequest{Language: "typescript", Stack: "prod", RemoveBackend: true},
+			stack:    "prod",
+		},
+		{
+			name:     "no stack selected among several",
+			stacks:   "dev prod",
+			language: "typescript",
+			req:      &SynthCDKTFRequest{Language: "typescript"},
+			err:      "the application synthesizes the stacks [dev prod], spec.cdktf.stack must select one",
+		},
+		{
+			name:     "the selected stack is not synthesized",
+			stacks:   "dev prod",
+			language: "typescript",
+			req:      &SynthCDKTFRequest{Language: "typescript", Stack: "staging"},
+			err:      `stack "staging" not found in the synthesized stacks [dev prod]`,
+		},
+		{
+			name:     "the stack name is a path",
+			stacks:   "app",
+			language: "typescript",
+			req:      &SynthCDKTFRequest{Language: "typescript", Stack: "../app"},
+			err:      `invalid stack name "../app"`,
+		},
+		{
+			name:     "the language of the application is another one",
+			stacks:   "app",
+			language: "python",
+			req:      &SynthCDKTFRequest{Language: "typescript"},
+			err:      `the language of cdktf.json is "python", not "typescript"`,
+		},
+		{
+			name:     "the language of the application is not set",
+			stacks:   "app",
+			language: "",
+			req:      &SynthCDKTFRequest{Language: "go"},
+			err:      `the language of cdktf.json is "", not "go"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			stubCDKTF(t, tt.stacks)
+			req := tt.req
+			req.WorkingDir = cdktfApp(t, tt.language)
+
+			r := &TerraformRunnerServer{}
+			reply, err := r.SynthCDKTF(context.TODO(), req)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(reply.WorkingDir).To(Equal(filepath.Join(req.WorkingDir, cdktfOutDir, "stacks", tt.stack)))
+
+			data, err := os.ReadFile(filepath.Join(reply.WorkingDir, "cdk.tf.json"))
+			g.Expect(err).ToNot(HaveOccurred())
+			var config struct {
+				Terraform map[string]interface{} `json:"terraform"`
+			}
+			g.Expect(json.Unmarshal(data, &config)).To(Succeed())
+			if req.RemoveBackend {
+				g.Expect(config.Terraform).ToNot(HaveKey("backend"))
+			} else {
+				g.Expect(config.Terraform).To(HaveKey("backend"))
+			}
+		})
+	}
+}
+
+func TestSynthCDKTFRemovesPreviousStacks(t *testing.T) {
+	g := NewWithT(t)
+	dir := cdktfApp(t, "typescript")
+	r := &TerraformRunnerServer{}
+
+	stubCDKTF(t, "dev prod")
+	_, err := r.SynthCDKTF(context.TODO(), &SynthCDKTFRequest{WorkingDir: dir, Language: "typescript", Stack: "dev"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// a stack removed from the application is not planned any more
+	stubCDKTF(t, "prod")
+	_, err = r.SynthCDKTF(context.TODO(), &SynthCDKTFRequest{WorkingDir: dir, Language: "typescript", Stack: "dev"})
+	g.Expect(err).To(MatchError(`stack "dev" not found in the synthesized stacks [prod]`))
+}
+
+func TestRemoveStackBackend(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{
+			name:   "the local backend is removed",
+			config: `{"terraform":{"backend":{"local":{"path":"terraform.tfstate"}},"required_version":">= 1.0"},"resource":{"null_resource":{"a":{"count":1}}}}`,
+			want: `{
+  "resource": {
+    "null_resource": {
+      "a": {
+        "count": 1
+      }
+    }
+  },
+  "terraform": {
+    "required_version": ">= 1.0"
+  }
+}`,
+		},
+		{
+			name:   "any backend is removed",
+			config: `{"terraform":{"backend":{"s3":{"bucket":"states"}}}}`,
+			want: `{
+  "terraform": {}
+}`,
+		},
+		{
+			name:   "a stack without backend is kept as written",
+			config: `{"terraform":{"required_version":">= 1.0"},"output":{"a":{"value":1.50}}}`,
+			want:   `{"terraform":{"required_version":">= 1.0"},"output":{"a":{"value":1.50}}}`,
+		},
+		{
+			name:   "a stack without terraform block is kept as written",
+			config: `{"resource":{}}`,
+			want:   `{"resource":{}}`,
+		},
+		{
+			name:   "the numbers are kept as written",
+			config: `{"terraform":{"backend":{"local":{}}},"variable":{"a":{"default":12345678901234567890}}}`,
+			want: `{
+  "terraform": {},
+  "variable": {
+    "a": {
+      "default": 12345678901234567890
+    }
+  }
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			filename := filepath.Join(t.TempDir(), "cdk.tf.json")
+			g.Expect(os.WriteFile(filename, []byte(tt.config), 0644)).To(Succeed())
+
+			g.Expect(removeStackBackend(filename)).To(Succeed())
+			data, err := os.ReadFile(filename)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(data)).To(Equal(tt.want))
+		})
+	}
+
+	g := NewWithT(t)
+	filename := filepath.Join(t.TempDir(), "cdk.tf.json")
+	g.Expect(os.WriteFile(filename, []byte(`{"terraform":`), 0644)).To(Succeed())
+	g.Expect(removeStackBackend(filename)).ToNot(Succeed())
+	g.Expect(removeStackBackend(filepath.Join(t.TempDir(), "missing.json"))).ToNot(Succeed())
+}
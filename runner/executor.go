@@ -0,0 +1,43 @@
+package runner
+
+import (
+	"context"
+	"io"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Executor runs the commands of a Terraform workflow in a working directory.
+// *tfexec.Terraform is the default implementation, other tools producing or consuming
+// Terraform configurations and plans can be plugged with TerraformRunnerServer.NewExecutor.
+type Executor interface {
+	Init(ctx context.Context, opts ...tfexec.InitOption) error
+	Plan(ctx context.Context, opts ...tfexec.PlanOption) (bool, error)
+	Apply(ctx context.Context, opts ...tfexec.ApplyOption) error
+	Destroy(ctx context.Context, opts ...tfexec.DestroyOption) error
+	Output(ctx context.Context, opts ...tfexec.OutputOption) (map[string]tfexec.OutputMeta, error)
+	Import(ctx context.Context, address, id string, opts ...tfexec.ImportOption) error
+
+	Show(ctx context.Context, opts ...tfexec.ShowOption) (*tfjson.State, error)
+	ShowPlanFile(ctx context.Context, planPath string, opts ...tfexec.ShowOption) (*tfjson.Plan, error)
+	ShowPlanFileRaw(ctx context.Context, planPath string, opts ...tfexec.ShowOption) (string, error)
+	Graph(ctx context.Context, opts ...tfexec.GraphOption) (string, error)
+	ProvidersSchema(ctx context.Context) (*tfjson.ProviderSchemas, error)
+	WorkspaceNew(ctx context.Context, workspace string, opts ...tfexec.WorkspaceNewCmdOption) error
+	WorkspaceSelect(ctx context.Context, workspace string) error
+	ForceUnlock(ctx context.Context, lockID string, opts ...tfexec.ForceUnlockOption) error
+
+	SetEnv(env map[string]string) error
+	SetStdout(w io.Writer)
+	SetStderr(w io.Writer)
+	WorkingDir() string
+	ExecPath() string
+}
+
+var _ Executor = &tfexec.Terraform{}
+
+// NewTerraformExecutor returns the default Executor, running the terraform binary at execPath.
+func NewTerraformExecutor(workingDir string, execPath string) (Executor, error) {
+	return tfexec.NewTerraform(workingDir, execPath)
+}
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// stubExecutor records the calls of the server, the commands not stubbed panic.
+type stubExecutor struct {
+	Executor
+	workingDir string
+	execPath   string
+	env        map[string]string
+}
+
+func (e *stubExecutor) SetEnv(env map[string]string) error {
+	e.env = env
+	return nil
+}
+
+func (e *stubExecutor) SetStdout(io.Writer) {}
+
+func (e *stubExecutor) SetStderr(io.Writer) {}
+
+func (e *stubExecutor) Output(context.Context, ...tfexec.OutputOption) (map[string]tfexec.OutputMeta, error) {
+	return map[string]tfexec.OutputMeta{
+		"endpoint": {Type: json.RawMessage(`"string"`), Value: json.RawMessage(`"https://example.com"`)},
+	}, nil
+}
+
+func TestNewExecutor(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	t.Setenv("DISABLE_TF_LOGS", "1")
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	terraform := infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+	}
+	terraformBytes, err := terraform.ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	stub := &stubExecutor{}
+	r := &TerraformRunnerServer{
+		Scheme: testScheme,
+		NewExecutor: func(workingDir string, execPath string) (Executor, error) {
+			stub.workingDir = workingDir
+			stub.execPath = execPath
+			return stub, nil
+		},
+	}
+	_, err = r.NewTerraform(ctx, &NewTerraformRequest{WorkingDir: "/tmp/helloworld", ExecPath: "/usr/local/bin/tofu", Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stub.workingDir).To(Equal("/tmp/helloworld"))
+	g.Expect(stub.execPath).To(Equal("/usr/local/bin/tofu"))
+
+	// the commands run with the executor
+	_, err = r.SetEnv(ctx, &SetEnvRequest{TfInstance: "1", Envs: map[string]string{"TF_VAR_region": "eu-west-1"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stub.env).To(HaveKeyWithValue("TF_VAR_region", "eu-west-1"))
+
+	outputs, err := r.Output(ctx, &OutputRequest{TfInstance: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(outputs.Outputs).To(HaveKey("endpoint"))
+	g.Expect(string(outputs.Outputs["endpoint"].Value)).To(Equal(`"https://example.com"`))
+
+	// an executor which can't be created fails the instance
+	r.NewExecutor = func(string, string) (Executor, error) { return nil, errors.New("no such tool") }
+	_, err = r.NewTerraform(ctx, &NewTerraformRequest{WorkingDir: "/tmp/helloworld", Terraform: terraformBytes, InstanceID: "2"})
+	g.Expect(err).To(MatchError("no such tool"))
+}
+
+func TestNewTerraformExecutor(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv("DISABLE_TF_LOGS", "1")
+
+	testScheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+	terraformBytes, err := (&infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+	}).ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the server defaults to the terraform binary
+	dir := t.TempDir()
+	r := &TerraformRunnerServer{Scheme: testScheme}
+	_, err = r.NewTerraform(context.TODO(), &NewTerraformRequest{WorkingDir: dir, ExecPath: "/usr/local/bin/terraform", Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.tf).To(BeAssignableToTypeOf(&tfexec.Terraform{}))
+	g.Expect(r.tf.WorkingDir()).To(Equal(dir))
+	g.Expect(r.tf.ExecPath()).To(Equal("/usr/local/bin/terraform"))
+}
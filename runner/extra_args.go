@@ -0,0 +1,212 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+)
+
+// The stages of spec.extraArgs.
+const (
+	StageInit    = "init"
+	StagePlan    = "plan"
+	StageApply   = "apply"
+	StageDestroy = "destroy"
+)
+
+// extraArgsFlags are the flags of spec.extraArgs supported by each stage.
+var extraArgsFlags = map[string][]string{
+	StageInit:    {"lock", "lock-timeout", "reconfigure", "upgrade"},
+	StagePlan:    {"lock", "lock-timeout", "parallelism", "refresh", "replace", "target"},
+	StageApply:   {"lock", "lock-timeout", "parallelism"},
+	StageDestroy: {"lock", "lock-timeout", "parallelism", "refresh", "target"},
+}
+
+// ExtraArgs are the flags of a stage parsed from spec.extraArgs, unset when nil or empty.
+type ExtraArgs struct {
+	Lock        *bool
+	LockTimeout string
+	Parallelism int
+	Refresh     *bool
+	Reconfigure *bool
+	Upgrade     *bool
+	Replace     []string
+	Targets     []string
+}
+
+// StageArgs returns the flags of spec.extraArgs of a stage.
+func StageArgs(spec *infrav1.ExtraArgs, stage string) []string {
+	if spec == nil {
+		return nil
+	}
+	switch stage {
+	case StageInit:
+		return spec.Init
+	case StagePlan:
+		return spec.Plan
+	case StageApply:
+		return spec.Apply
+	case StageDestroy:
+		return spec.Destroy
+	}
+	return nil
+}
+
+// ParseExtraArgs parses the flags of a stage, written -name=value, or -name for the boolean flags.
+func ParseExtraArgs(stage string, args []string) (*ExtraArgs, error) {
+	supported := extraArgsFlags[stage]
+	parsed := &ExtraArgs{}
+	for _, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || !containsFlag(supported, name) {
+			return nil, fmt.Errorf("unsupported flag %s of %s, the supported flags are -%s", arg, stage, strings.Join(supported, ", -"))
+		}
+
+		var err error
+		switch name {
+		case "lock":
+			parsed.Lock, err = parseBoolFlag(value, hasValue)
+		case "refresh":
+			parsed.Refresh, err = parseBoolFlag(value, hasValue)
+		case "reconfigure":
+			parsed.Reconfigure, err = parseBoolFlag(value, hasValue)
+		case "upgrade":
+			parsed.Upgrade, err = parseBoolFlag(value, hasValue)
+		case "lock-timeout":
+			if _, err = time.ParseDuration(value); err == nil {
+				parsed.LockTimeout = value
+			}
+		case "parallelism":
+			parsed.Parallelism, err = strconv.Atoi(value)
+			if err == nil && parsed.Parallelism < 1 {
+				err = fmt.Errorf("must be at least 1")
+			}
+		case "replace":
+			parsed.Replace = append(parsed.Replace, value)
+		case "target":
+			parsed.Targets = append(parsed.Targets, value)
+		}
+		if err == nil && !hasValue && name != "lock" && name != "refresh" && name != "reconfigure" && name != "upgrade" {
+			err = fmt.Errorf("a value is required")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag %s of %s: %s", arg, stage, err)
+		}
+	}
+	return parsed, nil
+}
+
+func parseBoolFlag(value string, hasValue bool) (*bool, error) {
+	if !hasValue {
+		v := true
+		return &v, nil
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func containsFlag(flags []string, name string) bool {
+	i := sort.SearchStrings(flags, name)
+	return i < len(flags) && flags[i] == name
+}
+
+// parallelism returns the parallelism of the object, overridden by the -parallelism flag of its stage.
+func (a *ExtraArgs) parallelism(terraform *infrav1.Terraform) int {
+	if a.Parallelism > 0 {
+		return a.Parallelism
+	}
+	if terraform != nil {
+		return int(terraform.Spec.Parallelism)
+	}
+	return 0
+}
+
+func (a *ExtraArgs) initOptions() []tfexec.InitOption {
+	var opts []tfexec.InitOption
+	if a.Lock != nil {
+		opts = append(opts, tfexec.Lock(*a.Lock))
+	}
+	if a.LockTimeout != "" {
+		opts = append(opts, tfexec.LockTimeout(a.LockTimeout))
+	}
+	if a.Reconfigure != nil {
+		opts = append(opts, tfexec.Reconfigure(*a.Reconfigure))
+	}
+	if a.Upgrade != nil {
+		opts = append(opts, tfexec.Upgrade(*a.Upgrade))
+	}
+	return opts
+}
+
+func (a *ExtraArgs) planOptions(terraform *infrav1.Terraform) []tfexec.PlanOption {
+	var opts []tfexec.PlanOption
+	if a.Lock != nil {
+		opts = append(opts, tfexec.Lock(*a.Lock))
+	}
+	if a.LockTimeout != "" {
+		opts = append(opts, tfexec.LockTimeout(a.LockTimeout))
+	}
+	if n := a.parallelism(terraform); n > 0 {
+		opts = append(opts, tfexec.Parallelism(n))
+	}
+	if a.Refresh != nil {
+		opts = append(opts, tfexec.Refresh(*a.Refresh))
+	}
+	for _, address := range a.Replace {
+		opts = append(opts, tfexec.Replace(address))
+	}
+	for _, target := range a.Targets {
+		opts = append(opts, tfexec.Target(target))
+	}
+	return opts
+}
+
+func (a *ExtraArgs) applyOptions(terraform *infrav1.Terraform) []tfexec.ApplyOption {
+	var opts []tfexec.ApplyOption
+	if a.Lock != nil {
+		opts = append(opts, tfexec.Lock(*a.Lock))
+	}
+	if a.LockTimeout != "" {
+		opts = append(opts, tfexec.LockTimeout(a.LockTimeout))
+	}
+	if n := a.parallelism(terraform); n > 0 {
+		opts = append(opts, tfexec.Parallelism(n))
+	}
+	return opts
+}
+
+func (a *ExtraArgs) destroyOptions(terraform *infrav1.Terraform) []tfexec.DestroyOption {
+	var opts []tfexec.DestroyOption
+	if a.Lock != nil {
+		opts = append(opts, tfexec.Lock(*a.Lock))
+	}
+	if a.LockTimeout != "" {
+		opts = append(opts, tfexec.LockTimeout(a.LockTimeout))
+	}
+	if n := a.parallelism(terraform); n > 0 {
+		opts = append(opts, tfexec.Parallelism(n))
+	}
+	if a.Refresh != nil {
+		opts = append(opts, tfexec.Refresh(*a.Refresh))
+	}
+	for _, target := range a.Targets {
+		opts = append(opts, tfexec.Target(target))
+	}
+	return opts
+}
+
+// extraArgs returns the flags of spec.extraArgs of a stage of the object of the runner.
+func (r *TerraformRunnerServer) extraArgs(stage string) (*ExtraArgs, error) {
+	if r.terraform == nil {
+		return &ExtraArgs{}, nil
+	}
+	return ParseExtraArgs(stage, StageArgs(r.terraform.Spec.ExtraArgs, stage))
+}
@@ -0,0 +1,611 @@
+// Package fake provides a runner server that implements the runner gRPC API without Terraform.
+// Its plans, applies and outputs are programmed per Terraform object, so that controllers and
+// pipelines built on top of tf-controller can be tested end-to-end without terraform binaries
+// or cloud credentials.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// execPath is reported as the path of the terraform binary, which is never run.
+const execPath = "/fake/terraform"
+
+// Result programs what the runs of a Terraform object produce.
+type Result struct {
+	// Changes are the changes of the plans, as "<action> <address>" entries,
+	// e.g. "create aws_s3_bucket.logs". The actions are create, update, delete and replace.
+	Changes []string
+	// PlanError, ApplyError and DestroyError, when set, fail the corresponding runs.
+	PlanError    error
+	ApplyError   error
+	DestroyError error
+	// Outputs are the values of the outputs once applied, which must marshal to JSON.
+	Outputs map[string]interface{}
+	// SensitiveOutputs are the names of the outputs marked as sensitive.
+	SensitiveOutputs []string
+	// Inventory is the inventory of the resources once applied.
+	Inventory []*runner.Inventory
+	// StalePlan reports the saved plans as stale when they are applied.
+	StalePlan bool
+	// ImportErrors fail the imports of the resources of their addresses. The resources of the inventory
+	// are reported as already in the state, and the others as imported.
+	ImportErrors map[string]error
+	// State is the state of the object until a snapshot is restored, reported by the snapshots of the state.
+	// The snapshots report no state when nil.
+	State *utils.StateMeta
+	// StateBackupError, when set, fails the snapshots and the restores of the state.
+	StateBackupError error
+}
+
+// Server is a runner server whose Terraform runs are programmed with SetResult.
+// The operations not involving Terraform, e.g. uploading the source or writing the outputs,
+// are those of the real runner, and the plans are stored in the same Secrets.
+type Server struct {
+	*runner.TerraformRunnerServer
+
+	mu         sync.Mutex
+	results    map[types.NamespacedName]Result
+	calls      map[types.NamespacedName][]string
+	terraform  *infrav1.Terraform
+	workingDir string
+	// states are the states restored from the snapshots, and snapshots the snapshots taken, by object and ID.
+	states    map[types.NamespacedName]utils.StateMeta
+	snapshots map[types.NamespacedName]map[string]utils.StateMeta
+
+	// Default is the result of the objects without one set with SetResult.
+	Default Result
+}
+
+// NewServer returns a Server using the client and the scheme of c to read and write the objects.
+func NewServer(c client.Client) *Server {
+	return &Server{
+		TerraformRunnerServer: &runner.TerraformRunnerServer{
+			Client: c,
+			Scheme: c.Scheme(),
+		},
+		results:   map[types.NamespacedName]Result{},
+		calls:     map[types.NamespacedName][]string{},
+		states:    map[types.NamespacedName]utils.StateMeta{},
+		snapshots: map[types.NamespacedName]map[string]utils.StateMeta{},
+	}
+}
+
+// SetResult programs the runs of the Terraform object key.
+func (s *Server) SetResult(key types.NamespacedName, result Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[key] = result
+}
+
+// Calls returns the names of the methods called for the Terraform object key, in order,
+// e.g. ["Init", "Plan", "SaveTFPlan"].
+func (s *Server) Calls(key types.NamespacedName) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls[key]...)
+}
+
+// Reset forgets the programmed results and the recorded calls.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = map[types.NamespacedName]Result{}
+	s.calls = map[types.NamespacedName][]string{}
+	s.states = map[types.NamespacedName]utils.StateMeta{}
+	s.snapshots = map[types.NamespacedName]map[string]utils.StateMeta{}
+}
+
+// Snapshots returns the IDs of the snapshots of the state of the Terraform object key, sorted.
+func (s *Server) Snapshots(key types.NamespacedName) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id := range s.snapshots[key] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// begin records the call of method for the current object and returns its result.
+func (s *Server) begin(tfInstance string, method string) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.terraform == nil || tfInstance != s.InstanceID {
+		return Result{}, fmt.Errorf("no TF instance found")
+	}
+
+	key := types.NamespacedName{Namespace: s.terraform.Namespace, Name: s.terraform.Name}
+	s.calls[key] = append(s.calls[key], method)
+	if result, ok := s.results[key]; ok {
+		return result, nil
+	}
+	return s.Default, nil
+}
+
+func (s *Server) LookPath(ctx context.Context, req *runner.LookPathRequest) (*runner.LookPathReply, error) {
+	return &runner.LookPathReply{ExecPath: execPath}, nil
+}
+
+func (s *Server) InstallTerraform(ctx context.Context, req *runner.InstallTerraformRequest) (*runner.InstallTerraformReply, error) {
+	return &runner.InstallTerraformReply{ExecPath: execPath}, nil
+}
+
+func (s *Server) NewTerraform(ctx context.Context, req *runner.NewTerraformRequest) (*runner.NewTerraformReply, error) {
+	// the real runner caches the object and the working directory, the binary is never run
+	req.ExecPath = execPath
+	reply, err := s.TerraformRunnerServer.NewTerraform(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var terraform infrav1.Terraform
+	if err := terraform.FromBytes(req.Terraform, s.Scheme); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.terraform = &terraform
+	s.workingDir = req.WorkingDir
+	s.mu.Unlock()
+	return reply, nil
+}
+
+func (s *Server) Init(ctx context.Context, req *runner.InitRequest) (*runner.InitReply, error) {
+	if _, err := s.begin(req.TfInstance, "Init"); err != nil {
+		return nil, err
+	}
+	return &runner.InitReply{Message: "ok"}, nil
+}
+
+func (s *Server) SelectWorkspace(ctx context.Context, req *runner.WorkspaceRequest) (*runner.WorkspaceReply, error) {
+	if _, err := s.begin(req.TfInstance, "SelectWorkspace"); err != nil {
+		return nil, err
+	}
+	return &runner.WorkspaceReply{Message: "ok"}, nil
+}
+
+// plan is the content of the plan files of the fake runner.
+type plan struct {
+	Changes []string `json:"changes"`
+}
+
+func (s *Server) Plan(ctx context.Context, req *runner.PlanRequest) (*runner.PlanReply, error) {
+	result, err := s.begin(req.TfInstance, "Plan")
+	if err != nil {
+		return nil, err
+	}
+	if result.PlanError != nil {
+		return nil, result.PlanError
+	}
+
+	changes := result.Changes
+	if req.Destroy {
+		changes = nil
+		for _, inventory := range result.Inventory {
+			changes = append(changes, "delete "+inventory.Type+"."+inventory.Name)
+		}
+	}
+	if _, err := resourceChanges(changes); err != nil {
+		return nil, err
+	}
+
+	if req.Out != "" {
+		data, err := json.Marshal(plan{Changes: changes})
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(s.path(req.Out), data, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return &runner.PlanReply{Message: "ok", Drifted: len(changes) > 0}, nil
+}
+
+func (s *Server) Import(ctx context.Context, req *runner.ImportRequest) (*runner.ImportReply, error) {
+	result, err := s.begin(req.TfInstance, "Import")
+	if err != nil {
+		return nil, err
+	}
+
+	inState := map[string]bool{}
+	for _, inventory := range result.Inventory {
+		inState[inventory.Type+"."+inventory.Name] = true
+	}
+	reply := &runner.ImportReply{}
+	for _, entry := range req.Imports {
+		importResult := &runner.ImportResult{Address: entry.Address, Id: entry.Id, Result: infrav1.ImportResultImported}
+		if inState[entry.Address] {
+			importResult.Result = infrav1.ImportResultInState
+		} else if err := result.ImportErrors[entry.Address]; err != nil {
+			importResult.Result = infrav1.ImportResultFailed
+			importResult.Message = err.Error()
+		}
+		reply.Results = append(reply.Results, importResult)
+	}
+	return reply, nil
+}
+
+func (s *Server) SnapshotState(ctx context.Context, req *runner.SnapshotStateRequest) (*runner.SnapshotStateReply, error) {
+	result, err := s.begin(req.TfInstance, "SnapshotState")
+	if err != nil {
+		return nil, err
+	}
+	if result.StateBackupError != nil {
+		return nil, result.StateBackupError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := types.NamespacedName{Namespace: s.terraform.Namespace, Name: s.terraform.Name}
+	state, ok := s.states[key]
+	if !ok && result.State == nil {
+		return &runner.SnapshotStateReply{Empty: true}, nil
+	} else if !ok {
+		state = *result.State
+	}
+	if s.snapshots[key] == nil {
+		s.snapshots[key] = map[string]utils.StateMeta{}
+	}
+	s.snapshots[key][req.Id] = state
+	return &runner.SnapshotStateReply{
+		Location: fmt.Sprintf("fake/%s/%s", key, req.Id),
+		Lineage:  state.Lineage,
+		Serial:   state.Serial,
+	}, nil
+}
+
+func (s *Server) RestoreState(ctx context.Context, req *runner.RestoreStateRequest) (*runner.RestoreStateReply, error) {
+	result, err := s.begin(req.TfInstance, "RestoreState")
+	if err != nil {
+		return nil, err
+	}
+	if result.StateBackupError != nil {
+		return nil, result.StateBackupError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := types.NamespacedName{Namespace: s.terraform.Namespace, Name: s.terraform.Name}
+	state, ok := s.snapshots[key][req.Id]
+	if !ok {
+		return nil, fmt.Errorf("unable to get the snapshot %s: not found", req.Id)
+	}
+	s.states[key] = state
+	return &runner.RestoreStateReply{Lineage: state.Lineage, Serial: state.Serial}, nil
+}
+
+func (s *Server) DeleteStateSnapshots(ctx context.Context, req *runner.DeleteStateSnapshotsRequest) (*runner.DeleteStateSnapshotsReply, error) {
+	if _, err := s.begin(req.TfInstance, "DeleteStateSnapshots"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := types.NamespacedName{Namespace: s.terraform.Namespace, Name: s.terraform.Name}
+	for _, id := range req.Ids {
+		delete(s.snapshots[key], id)
+	}
+	return &runner.DeleteStateSnapshotsReply{Deleted: req.Ids}, nil
+}
+
+func (s *Server) ShowPlanFile(ctx context.Context, req *runner.ShowPlanFileRequest) (*runner.ShowPlanFileReply, error) {
+	if _, err := s.begin(req.TfInstance, "ShowPlanFile"); err != nil {
+		return nil, err
+	}
+
+	planObj, err := s.readPlan(req.Filename)
+	if err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(planObj)
+	if err != nil {
+		return nil, err
+	}
+	return &runner.ShowPlanFileReply{JsonOutput: jsonBytes}, nil
+}
+
+func (s *Server) ShowPlanFileRaw(ctx context.Context, req *runner.ShowPlanFileRawRequest) (*runner.ShowPlanFileRawReply, error) {
+	if _, err := s.begin(req.TfInstance, "ShowPlanFileRaw"); err != nil {
+		return nil, err
+	}
+
+	planObj, err := s.readPlan(req.Filename)
+	if err != nil {
+		return nil, err
+	}
+	return &runner.ShowPlanFileRawReply{RawOutput: humanPlan(planObj)}, nil
+}
+
+func (s *Server) SaveTFPlan(ctx context.Context, req *runner.SaveTFPlanRequest) (*runner.SaveTFPlanReply, error) {
+	if _, err := s.begin(req.TfInstance, "SaveTFPlan"); err != nil {
+		return nil, err
+	}
+
+	planName, _ := infrav1.GetPlanIdAndApproveMessage(req.Revision, "")
+	if req.PlanId != "" {
+		planName = req.PlanId
+	}
+
+	tfplan := []byte("dummy plan")
+	var changes []byte
+	reply := &runner.SaveTFPlanReply{Message: "ok"}
+	if !req.BackendCompletelyDisable {
+		var err error
+		tfplan, err = ioutil.ReadFile(s.path(runner.TFPlanName))
+		if err != nil {
+			return nil, fmt.Errorf("error running Plan: %s", err)
+		}
+		planObj, err := s.readPlan(runner.TFPlanName)
+		if err != nil {
+			return nil, err
+		}
+		planChanges := utils.PlanChanges(planObj)
+		reply.ChangeCount = int32(len(planChanges))
+		if changes, err = json.Marshal(planChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	// the plan is stored as the real runner does, for the controller and the real LoadTFPlan to read it
+	tfplan, err := utils.GzipEncode(tfplan)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string][]byte{runner.TFPlanName: tfplan}
+	if changes != nil {
+		data[runner.TFPlanChangesKey] = changes
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tfplan-" + s.terraform.WorkspaceName() + "-" + req.Name,
+			Namespace: req.Namespace,
+			Annotations: map[string]string{
+				"encoding":                       "gzip",
+				runner.SavedPlanSecretAnnotation: planName,
+				runner.ContentHashAnnotation:     utils.ContentHash(data),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+					Kind:       infrav1.TerraformKind,
+					Name:       req.Name,
+					UID:        types.UID(req.Uuid),
+				},
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	if err := s.Client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error deleting tfplanSecret: %s", err)
+	}
+	if err := s.Client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("error recording plan status: %s", err)
+	}
+
+	return reply, nil
+}
+
+func (s *Server) LoadTFPlan(ctx context.Context, req *runner.LoadTFPlanRequest) (*runner.LoadTFPlanReply, error) {
+	if _, err := s.begin(req.TfInstance, "LoadTFPlan"); err != nil {
+		return nil, err
+	}
+	return s.TerraformRunnerServer.LoadTFPlan(ctx, req)
+}
+
+func (s *Server) CheckPlanStaleness(ctx context.Context, req *runner.CheckPlanStalenessRequest) (*runner.CheckPlanStalenessReply, error) {
+	result, err := s.begin(req.TfInstance, "CheckPlanStaleness")
+	if err != nil {
+		return nil, err
+	}
+	if result.StalePlan {
+		return &runner.CheckPlanStalenessReply{Stale: true, Message: "state serial advanced since planning"}, nil
+	}
+	return &runner.CheckPlanStalenessReply{Message: "state unchanged since planning"}, nil
+}
+
+func (s *Server) Apply(ctx context.Context, req *runner.ApplyRequest) (*runner.ApplyReply, error) {
+	result, err := s.begin(req.TfInstance, "Apply")
+	if err != nil {
+		return nil, err
+	}
+	if result.ApplyError != nil {
+		return nil, result.ApplyError
+	}
+	if req.DirOrPlan != "" {
+		if _, err := s.readPlan(req.DirOrPlan); err != nil {
+			return nil, err
+		}
+	}
+	return &runner.ApplyReply{Message: "ok"}, nil
+}
+
+func (s *Server) Destroy(ctx context.Context, req *runner.DestroyRequest) (*runner.DestroyReply, error) {
+	result, err := s.begin(req.TfInstance, "Destroy")
+	if err != nil {
+		return nil, err
+	}
+	if result.DestroyError != nil {
+		return nil, result.DestroyError
+	}
+	return &runner.DestroyReply{Message: "ok"}, nil
+}
+
+func (s *Server) GetInventory(ctx context.Context, req *runner.GetInventoryRequest) (*runner.GetInventoryReply, error) {
+	result, err := s.begin(req.TfInstance, "GetInventory")
+	if err != nil {
+		return nil, err
+	}
+	inventories := result.Inventory
+	if inventories == nil {
+		inventories = []*runner.Inventory{}
+	}
+	return &runner.GetInventoryReply{Inventories: inventories}, nil
+}
+
+func (s *Server) Output(ctx context.Context, req *runner.OutputRequest) (*runner.OutputReply, error) {
+	result, err := s.begin(req.TfInstance, "Output")
+	if err != nil {
+		return nil, err
+	}
+
+	sensitive := map[string]bool{}
+	for _, name := range result.SensitiveOutputs {
+		sensitive[name] = true
+	}
+
+	reply := &runner.OutputReply{Outputs: map[string]*runner.OutputMeta{}}
+	for name, value := range result.Outputs {
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", name, err)
+		}
+		// the controller reads the types of the outputs as terraform reports them
+		ty, err := ctyjson.ImpliedType(valueJSON)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", name, err)
+		}
+		typeJSON, err := ctyjson.MarshalType(ty)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", name, err)
+		}
+		reply.Outputs[name] = &runner.OutputMeta{Sensitive: sensitive[name], Type: typeJSON, Value: valueJSON}
+	}
+	return reply, nil
+}
+
+func (s *Server) GetProviderSchemas(ctx context.Context, req *runner.GetProviderSchemasRequest) (*runner.GetProviderSchemasReply, error) {
+	// no provider is installed
+	return &runner.GetProviderSchemasReply{Providers: map[string]string{}}, nil
+}
+
+func (s *Server) Graph(ctx context.Context, req *runner.GraphRequest) (*runner.GraphReply, error) {
+	if _, err := s.begin(req.TfInstance, "Graph"); err != nil {
+		return nil, err
+	}
+	return &runner.GraphReply{Dot: "digraph {\n}\n"}, nil
+}
+
+func (s *Server) ForceUnlock(ctx context.Context, req *runner.ForceUnlockRequest) (*runner.ForceUnlockReply, error) {
+	return &runner.ForceUnlockReply{
+		Success: true,
+		Message: fmt.Sprintf("Successfully unlocked state with lock identifier: %s", req.GetLockIdentifier()),
+	}, nil
+}
+
+// path returns the path of filename in the working directory.
+func (s *Server) path(filename string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return filepath.Join(s.workingDir, filename)
+}
+
+// readPlan reads a plan file written by Plan, or loaded by LoadTFPlan.
+func (s *Server) readPlan(filename string) (*tfjson.Plan, error) {
+	data, err := ioutil.ReadFile(s.path(filename))
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan file: %s", err)
+	}
+	var p plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("error reading plan file %s: %s", filename, err)
+	}
+
+	changes, err := resourceChanges(p.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return &tfjson.Plan{FormatVersion: "1.0", ResourceChanges: changes}, nil
+}
+
+// resourceChanges returns the resource changes of "<action> <address>" entries.
+func resourceChanges(changes []string) ([]*tfjson.ResourceChange, error) {
+	var result []*tfjson.ResourceChange
+	for _, change := range changes {
+		action, address, ok := strings.Cut(change, " ")
+		parts := strings.Split(address, ".")
+		if !ok || len(parts) < 2 {
+			return nil, fmt.Errorf("invalid change %q, expected \"<action> <type>.<name>\"", change)
+		}
+
+		var actions tfjson.Actions
+		switch action {
+		case "create":
+			actions = tfjson.Actions{tfjson.ActionCreate}
+		case "update":
+			actions = tfjson.Actions{tfjson.ActionUpdate}
+		case "delete":
+			actions = tfjson.Actions{tfjson.ActionDelete}
+		case "replace":
+			actions = tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}
+		default:
+			return nil, fmt.Errorf("invalid change %q, unknown action %s", change, action)
+		}
+
+		result = append(result, &tfjson.ResourceChange{
+			Address: address,
+			Mode:    tfjson.ManagedResourceMode,
+			Type:    parts[len(parts)-2],
+			Name:    parts[len(parts)-1],
+			Change:  &tfjson.Change{Actions: actions},
+		})
+	}
+	return result, nil
+}
+
+// humanPlan renders the plan like `terraform show` does, without the attributes.
+func humanPlan(planObj *tfjson.Plan) string {
+	if len(planObj.ResourceChanges) == 0 {
+		return "No changes. Your infrastructure matches the configuration.\n"
+	}
+
+	var add, change, destroy int
+	var sb strings.Builder
+	sb.WriteString("Terraform will perform the following actions:\n\n")
+	changes := append([]*tfjson.ResourceChange(nil), planObj.ResourceChanges...)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Address < changes[j].Address })
+	for _, rc := range changes {
+		var marker, verb string
+		switch {
+		case rc.Change.Actions.Replace():
+			marker, verb = "-/+", "replaced"
+			add++
+			destroy++
+		case rc.Change.Actions.Create():
+			marker, verb = "+", "created"
+			add++
+		case rc.Change.Actions.Update():
+			marker, verb = "~", "updated in-place"
+			change++
+		case rc.Change.Actions.Delete():
+			marker, verb = "-", "destroyed"
+			destroy++
+		}
+		fmt.Fprintf(&sb, "  # %s will be %s\n  %s resource %q %q {\n    }\n\n", rc.Address, verb, marker, rc.Type, rc.Name)
+	}
+	fmt.Fprintf(&sb, "Plan: %d to add, %d to change, %d to destroy.\n", add, change, destroy)
+	return sb.String()
+}
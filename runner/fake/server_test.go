@@ -0,0 +1,99 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServerPlanAndApply(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(testScheme)).To(Succeed())
+
+	terraform := infrav1.Terraform{
+		TypeMeta:   metav1.TypeMeta{APIVersion: infrav1.GroupVersion.String(), Kind: infrav1.TerraformKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system", UID: "uid"},
+	}
+	key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+
+	s := NewServer(fake.NewClientBuilder().WithScheme(testScheme).Build())
+	s.SetResult(key, Result{
+		Changes: []string{"create aws_s3_bucket.logs", "replace module.vpc.aws_vpc.main"},
+		Outputs: map[string]interface{}{"bucket": "logs", "ports": []int{80, 443}},
+	})
+
+	terraformBytes, err := terraform.ToBytes(testScheme)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = s.NewTerraform(ctx, &runner.NewTerraformRequest{WorkingDir: t.TempDir(), Terraform: terraformBytes, InstanceID: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	planReply, err := s.Plan(ctx, &runner.PlanRequest{TfInstance: "1", Out: runner.TFPlanName, Refresh: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(planReply.Drifted).To(BeTrue())
+
+	showReply, err := s.ShowPlanFile(ctx, &runner.ShowPlanFileRequest{TfInstance: "1", Filename: runner.TFPlanName})
+	g.Expect(err).ToNot(HaveOccurred())
+	var planObj tfjson.Plan
+	g.Expect(json.Unmarshal(showReply.JsonOutput, &planObj)).To(Succeed())
+	g.Expect(planObj.ResourceChanges).To(HaveLen(2))
+	g.Expect(planObj.ResourceChanges[1].Type).To(Equal("aws_vpc"))
+	g.Expect(planObj.ResourceChanges[1].Change.Actions.Replace()).To(BeTrue())
+
+	rawReply, err := s.ShowPlanFileRaw(ctx, &runner.ShowPlanFileRawRequest{TfInstance: "1", Filename: runner.TFPlanName})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rawReply.RawOutput).To(ContainSubstring("Plan: 2 to add, 0 to change, 1 to destroy."))
+
+	// the plan goes through its Secret, as with the real runner
+	saveReply, err := s.SaveTFPlan(ctx, &runner.SaveTFPlanRequest{TfInstance: "1", Name: "helloworld", Namespace: "flux-system", Uuid: "uid", Revision: "main/abc"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(saveReply.ChangeCount).To(Equal(int32(2)))
+	planId, _ := infrav1.GetPlanIdAndApproveMessage("main/abc", "")
+	_, err = s.LoadTFPlan(ctx, &runner.LoadTFPlanRequest{TfInstance: "1", Name: "helloworld", Namespace: "flux-system", PendingPlan: planId})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = s.Apply(ctx, &runner.ApplyRequest{TfInstance: "1", DirOrPlan: runner.TFPlanName})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	outputReply, err := s.Output(ctx, &runner.OutputRequest{TfInstance: "1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(outputReply.Outputs["bucket"].Type)).To(Equal(`"string"`))
+	g.Expect(string(outputReply.Outputs["ports"].Value)).To(Equal(`[80,443]`))
+
+	g.Expect(s.Calls(key)).To(Equal([]string{"Plan", "ShowPlanFile", "ShowPlanFileRaw", "SaveTFPlan", "LoadTFPlan", "Apply", "Output"}))
+
+	// a programmed failure fails the run
+	s.SetResult(key, Result{ApplyError: errors.New("error running Apply: exit status 1")})
+	_, err = s.Apply(ctx, &runner.ApplyRequest{TfInstance: "1"})
+	g.Expect(err).To(MatchError("error running Apply: exit status 1"))
+
+	_, err = s.Plan(ctx, &runner.PlanRequest{TfInstance: "2"})
+	g.Expect(err).To(MatchError("no TF instance found"))
+}
+
+func TestResourceChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	changes, err := resourceChanges([]string{"delete aws_s3_bucket.logs"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(humanPlan(&tfjson.Plan{ResourceChanges: changes})).To(ContainSubstring("# aws_s3_bucket.logs will be destroyed"))
+
+	_, err = resourceChanges([]string{"recreate aws_s3_bucket.logs"})
+	g.Expect(err).To(HaveOccurred())
+	_, err = resourceChanges([]string{"create logs"})
+	g.Expect(err).To(HaveOccurred())
+}
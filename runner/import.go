@@ -0,0 +1,76 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Import imports the resources of the request which are not in the state yet. A failed import
+// does not stop the next ones, and is reported in its result.
+func (r *TerraformRunnerServer) Import(ctx context.Context, req *ImportRequest) (*ImportReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("importing resources")
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	state, err := r.tf.Show(ctx)
+	if err != nil {
+		log.Error(err, "unable to get state via show command")
+		return nil, err
+	}
+	inState := map[string]bool{}
+	if state.Values != nil {
+		stateAddresses(state.Values.RootModule, inState)
+	}
+
+	extraArgs, err := r.extraArgs(StagePlan)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	var importOpt []tfexec.ImportOption
+	if extraArgs.Lock != nil {
+		importOpt = append(importOpt, tfexec.Lock(*extraArgs.Lock))
+	}
+	if extraArgs.LockTimeout != "" {
+		importOpt = append(importOpt, tfexec.LockTimeout(extraArgs.LockTimeout))
+	}
+
+	reply := &ImportReply{}
+	for _, entry := range req.Imports {
+		result := &ImportResult{Address: entry.Address, Id: entry.Id}
+		if inState[entry.Address] {
+			result.Result = infrav1.ImportResultInState
+		} else if err := r.tf.Import(ctx, entry.Address, entry.Id, importOpt...); err != nil {
+			log.Error(err, "unable to import the resource", "address", entry.Address)
+			result.Result = infrav1.ImportResultFailed
+			result.Message = err.Error()
+		} else {
+			log.Info("resource imported", "address", entry.Address)
+			result.Result = infrav1.ImportResultImported
+		}
+		reply.Results = append(reply.Results, result)
+	}
+	return reply, nil
+}
+
+// stateAddresses adds the addresses of the resources of a module of the state, and of its child modules.
+func stateAddresses(module *tfjson.StateModule, addresses map[string]bool) {
+	if module == nil {
+		return
+	}
+	for _, resource := range module.Resources {
+		addresses[resource.Address] = true
+	}
+	for _, child := range module.ChildModules {
+		stateAddresses(child, addresses)
+	}
+}
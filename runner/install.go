@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/releases"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// BinaryCacheDirEnvName is the environment variable of the directory caching the terraform binaries
+// installed by the runner. A volume mounted there shares them between the runs.
+const BinaryCacheDirEnvName = "TF_BINARY_CACHE_DIR"
+
+// binaryCacheDir returns the directory caching the terraform binaries, $HOME/.cache/terraform by default.
+func binaryCacheDir() string {
+	if dir := os.Getenv(BinaryCacheDirEnvName); dir != "" {
+		return dir
+	}
+	return filepath.Join(HomePath, ".cache", "terraform")
+}
+
+// InstallTerraform returns the path of the terraform binary of the requested version.
+// A release missing from the cache is downloaded from releases.hashicorp.com, and its checksum and the signature
+// of the checksums are verified before it is cached at <cache dir>/<version>/terraform.
+func (r *TerraformRunnerServer) InstallTerraform(ctx context.Context, req *InstallTerraformRequest) (*InstallTerraformReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	v, err := version.NewVersion(req.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid terraform version %q: %s", req.Version, err)
+	}
+	// the cache is keyed by the canonical version, so that 1.3 and 1.3.0 share a binary
+	versionDir := filepath.Join(binaryCacheDir(), v.String())
+	execPath := filepath.Join(versionDir, product.Terraform.BinaryName())
+	if info, err := os.Stat(execPath); err == nil && !info.IsDir() {
+		log.Info("using the cached terraform binary", "version", v.String(), "execPath", execPath)
+		return &InstallTerraformReply{ExecPath: execPath}, nil
+	}
+
+	log.Info("installing terraform", "version", v.String())
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating the terraform binary cache: %s", err)
+	}
+	// the release is installed in a directory of its own, then moved in place, so that the runners sharing
+	// the cache never see a partial binary
+	installDir, err := os.MkdirTemp(versionDir, ".install-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating the terraform binary cache: %s", err)
+	}
+	defer os.RemoveAll(installDir)
+
+	installer := &releases.ExactVersion{
+		Product:    product.Terraform,
+		Version:    v,
+		InstallDir: installDir,
+	}
+	installedPath, err := installer.Install(ctx)
+	if err != nil {
+		err = fmt.Errorf("error installing terraform %s: %s", v.String(), err)
+		log.Error(err, "unable to install terraform")
+		return nil, err
+	}
+	if err := os.Rename(installedPath, execPath); err != nil {
+		return nil, fmt.Errorf("error caching terraform %s: %s", v.String(), err)
+	}
+
+	return &InstallTerraformReply{ExecPath: execPath}, nil
+}
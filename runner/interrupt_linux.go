@@ -0,0 +1,60 @@
+//go:build linux
+
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+// interruptTerraform sends SIGINT to the terraform processes started by the runner. tfexec starts each of them
+// in its own process group, so their provider plugins are interrupted too.
+// It returns the number of processes interrupted.
+func interruptTerraform() (int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, err
+	}
+
+	self := os.Getpid()
+	interrupted := 0
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		stat, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			// the process has exited in the meantime
+			continue
+		}
+		if ppid, err := parentPid(stat); err != nil || ppid != self {
+			continue
+		}
+
+		if err := syscall.Kill(-pid, syscall.SIGINT); err != nil {
+			return interrupted, fmt.Errorf("unable to interrupt process %d: %w", pid, err)
+		}
+		interrupted++
+	}
+	return interrupted, nil
+}
+
+// parentPid parses the parent pid from the content of /proc/<pid>/stat, "<pid> (<comm>) <state> <ppid> ...".
+// The command name may contain spaces and parentheses, so the fields are read after the last one.
+func parentPid(stat []byte) (int, error) {
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 {
+		return 0, fmt.Errorf("invalid process stat")
+	}
+	fields := bytes.Fields(stat[i+1:])
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("invalid process stat")
+	}
+	return strconv.Atoi(string(fields[1]))
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package runner
+
+import "fmt"
+
+// interruptTerraform is only supported on Linux, where the runner pods run.
+func interruptTerraform() (int, error) {
+	return 0, fmt.Errorf("interrupting terraform is not supported on this platform")
+}
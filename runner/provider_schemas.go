@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const providerLockFile = ".terraform.lock.hcl"
+
+type providerLocks struct {
+	Providers []struct {
+		Source  string   `hcl:"source,label"`
+		Version string   `hcl:"version"`
+		Remain  hcl.Body `hcl:",remain"`
+	} `hcl:"provider,block"`
+	Remain hcl.Body `hcl:",remain"`
+}
+
+// ProviderKey is the key of the schema of a provider version, as <source>@<version>.
+func ProviderKey(source string, version string) string {
+	return source + "@" + version
+}
+
+// readProviderLocks returns the version of each provider of the dependency lock file of the working directory.
+func readProviderLocks(workingDir string) (map[string]string, error) {
+	filename := filepath.Join(workingDir, providerLockFile)
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+
+	file, diags := hclparse.NewParser().ParseHCLFile(filename)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("error parsing %s: %s", providerLockFile, diags.Error())
+	}
+	var locks providerLocks
+	if diags := gohcl.DecodeBody(file.Body, nil, &locks); diags.HasErrors() {
+		return nil, fmt.Errorf("error decoding %s: %s", providerLockFile, diags.Error())
+	}
+
+	providers := make(map[string]string, len(locks.Providers))
+	for _, p := range locks.Providers {
+		providers[p.Source] = p.Version
+	}
+	return providers, nil
+}
+
+// GetProviderSchemas returns the providers locked in the working directory, and the schemas of the ones
+// that the caller has not cached yet. Terraform only computes the schemas when one is missing.
+func (r *TerraformRunnerServer) GetProviderSchemas(ctx context.Context, req *GetProviderSchemasRequest) (*GetProviderSchemasReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("getting the provider schemas")
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	providers, err := readProviderLocks(r.tf.WorkingDir())
+	if err != nil {
+		log.Error(err, "unable to read the provider locks")
+		return nil, err
+	}
+
+	cached := make(map[string]bool, len(req.Cached))
+	for _, key := range req.Cached {
+		cached[key] = true
+	}
+	missing := false
+	for source, version := range providers {
+		if !cached[ProviderKey(source, version)] {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return &GetProviderSchemasReply{Providers: providers}, nil
+	}
+
+	schemas, err := r.tf.ProvidersSchema(ctx)
+	if err != nil {
+		log.Error(err, "unable to get the provider schemas")
+		return nil, err
+	}
+	for source := range schemas.Schemas {
+		if version, ok := providers[source]; ok && cached[ProviderKey(source, version)] {
+			delete(schemas.Schemas, source)
+		}
+	}
+
+	data, err := json.Marshal(schemas)
+	if err != nil {
+		return nil, err
+	}
+	return &GetProviderSchemasReply{Providers: providers, Schemas: data}, nil
+}
@@ -114,6 +114,100 @@ func (x *LookPathReply) GetExecPath() string {
 	return ""
 }
 
+type InstallTerraformRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *InstallTerraformRequest) Reset() {
+	*x = InstallTerraformRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstallTerraformRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallTerraformRequest) ProtoMessage() {}
+
+func (x *InstallTerraformRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallTerraformRequest.ProtoReflect.Descriptor instead.
+func (*InstallTerraformRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InstallTerraformRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type InstallTerraformReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExecPath string `protobuf:"bytes,1,opt,name=execPath,proto3" json:"execPath,omitempty"`
+}
+
+func (x *InstallTerraformReply) Reset() {
+	*x = InstallTerraformReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InstallTerraformReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallTerraformReply) ProtoMessage() {}
+
+func (x *InstallTerraformReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallTerraformReply.ProtoReflect.Descriptor instead.
+func (*InstallTerraformReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InstallTerraformReply) GetExecPath() string {
+	if x != nil {
+		return x.ExecPath
+	}
+	return ""
+}
+
 type NewTerraformRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -128,7 +222,7 @@ type NewTerraformRequest struct {
 func (x *NewTerraformRequest) Reset() {
 	*x = NewTerraformRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[2]
+		mi := &file_runner_runner_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -141,7 +235,7 @@ func (x *NewTerraformRequest) String() string {
 func (*NewTerraformRequest) ProtoMessage() {}
 
 func (x *NewTerraformRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[2]
+	mi := &file_runner_runner_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +248,7 @@ func (x *NewTerraformRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewTerraformRequest.ProtoReflect.Descriptor instead.
 func (*NewTerraformRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{2}
+	return file_runner_runner_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *NewTerraformRequest) GetWorkingDir() string {
@@ -196,7 +290,7 @@ type NewTerraformReply struct {
 func (x *NewTerraformReply) Reset() {
 	*x = NewTerraformReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[3]
+		mi := &file_runner_runner_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -209,7 +303,7 @@ func (x *NewTerraformReply) String() string {
 func (*NewTerraformReply) ProtoMessage() {}
 
 func (x *NewTerraformReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[3]
+	mi := &file_runner_runner_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +316,7 @@ func (x *NewTerraformReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewTerraformReply.ProtoReflect.Descriptor instead.
 func (*NewTerraformReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{3}
+	return file_runner_runner_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NewTerraformReply) GetId() string {
@@ -244,7 +338,7 @@ type SetEnvRequest struct {
 func (x *SetEnvRequest) Reset() {
 	*x = SetEnvRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[4]
+		mi := &file_runner_runner_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -257,7 +351,7 @@ func (x *SetEnvRequest) String() string {
 func (*SetEnvRequest) ProtoMessage() {}
 
 func (x *SetEnvRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[4]
+	mi := &file_runner_runner_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,7 +364,7 @@ func (x *SetEnvRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEnvRequest.ProtoReflect.Descriptor instead.
 func (*SetEnvRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{4}
+	return file_runner_runner_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SetEnvRequest) GetTfInstance() string {
@@ -298,7 +392,7 @@ type SetEnvReply struct {
 func (x *SetEnvReply) Reset() {
 	*x = SetEnvReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[5]
+		mi := &file_runner_runner_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -311,7 +405,7 @@ func (x *SetEnvReply) String() string {
 func (*SetEnvReply) ProtoMessage() {}
 
 func (x *SetEnvReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[5]
+	mi := &file_runner_runner_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +418,7 @@ func (x *SetEnvReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetEnvReply.ProtoReflect.Descriptor instead.
 func (*SetEnvReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{5}
+	return file_runner_runner_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SetEnvReply) GetMessage() string {
@@ -347,7 +441,7 @@ type FileMapping struct {
 func (x *FileMapping) Reset() {
 	*x = FileMapping{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[6]
+		mi := &file_runner_runner_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +454,7 @@ func (x *FileMapping) String() string {
 func (*FileMapping) ProtoMessage() {}
 
 func (x *FileMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[6]
+	mi := &file_runner_runner_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +467,7 @@ func (x *FileMapping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileMapping.ProtoReflect.Descriptor instead.
 func (*FileMapping) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{6}
+	return file_runner_runner_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FileMapping) GetContent() []byte {
@@ -409,7 +503,7 @@ type CreateFileMappingsRequest struct {
 func (x *CreateFileMappingsRequest) Reset() {
 	*x = CreateFileMappingsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[7]
+		mi := &file_runner_runner_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +516,7 @@ func (x *CreateFileMappingsRequest) String() string {
 func (*CreateFileMappingsRequest) ProtoMessage() {}
 
 func (x *CreateFileMappingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[7]
+	mi := &file_runner_runner_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +529,7 @@ func (x *CreateFileMappingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFileMappingsRequest.ProtoReflect.Descriptor instead.
 func (*CreateFileMappingsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{7}
+	return file_runner_runner_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateFileMappingsRequest) GetWorkingDir() string {
@@ -463,7 +557,7 @@ type CreateFileMappingsReply struct {
 func (x *CreateFileMappingsReply) Reset() {
 	*x = CreateFileMappingsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[8]
+		mi := &file_runner_runner_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -476,7 +570,7 @@ func (x *CreateFileMappingsReply) String() string {
 func (*CreateFileMappingsReply) ProtoMessage() {}
 
 func (x *CreateFileMappingsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[8]
+	mi := &file_runner_runner_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +583,7 @@ func (x *CreateFileMappingsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateFileMappingsReply.ProtoReflect.Descriptor instead.
 func (*CreateFileMappingsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{8}
+	return file_runner_runner_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateFileMappingsReply) GetMessage() string {
@@ -504,16 +598,22 @@ type UploadAndExtractRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	TarGz     []byte `protobuf:"bytes,3,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
-	Path      string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TarGz      []byte `protobuf:"bytes,3,opt,name=tarGz,proto3" json:"tarGz,omitempty"`
+	Path       string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Persistent bool   `protobuf:"varint,5,opt,name=persistent,proto3" json:"persistent,omitempty"`
+	Checksum   string `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	MaxFiles   int64  `protobuf:"varint,7,opt,name=maxFiles,proto3" json:"maxFiles,omitempty"`
+	MaxSize    int64  `protobuf:"varint,8,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	// url of the artifact, downloaded by the runner instead of being uploaded in tarGz when set.
+	Url string `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
 }
 
 func (x *UploadAndExtractRequest) Reset() {
 	*x = UploadAndExtractRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[9]
+		mi := &file_runner_runner_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -526,7 +626,7 @@ func (x *UploadAndExtractRequest) String() string {
 func (*UploadAndExtractRequest) ProtoMessage() {}
 
 func (x *UploadAndExtractRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[9]
+	mi := &file_runner_runner_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +639,7 @@ func (x *UploadAndExtractRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadAndExtractRequest.ProtoReflect.Descriptor instead.
 func (*UploadAndExtractRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{9}
+	return file_runner_runner_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UploadAndExtractRequest) GetNamespace() string {
@@ -570,6 +670,98 @@ func (x *UploadAndExtractRequest) GetPath() string {
 	return ""
 }
 
+func (x *UploadAndExtractRequest) GetPersistent() bool {
+	if x != nil {
+		return x.Persistent
+	}
+	return false
+}
+
+func (x *UploadAndExtractRequest) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *UploadAndExtractRequest) GetMaxFiles() int64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *UploadAndExtractRequest) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *UploadAndExtractRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// UploadAndExtractChunk is a message of the stream of an artifact. The first message
+// has the request, with an empty tarGz, and the next ones the bytes of the artifact.
+type UploadAndExtractChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *UploadAndExtractRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Data    []byte                   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UploadAndExtractChunk) Reset() {
+	*x = UploadAndExtractChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAndExtractChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAndExtractChunk) ProtoMessage() {}
+
+func (x *UploadAndExtractChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAndExtractChunk.ProtoReflect.Descriptor instead.
+func (*UploadAndExtractChunk) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadAndExtractChunk) GetRequest() *UploadAndExtractRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *UploadAndExtractChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 type UploadAndExtractReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -582,7 +774,7 @@ type UploadAndExtractReply struct {
 func (x *UploadAndExtractReply) Reset() {
 	*x = UploadAndExtractReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[10]
+		mi := &file_runner_runner_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +787,7 @@ func (x *UploadAndExtractReply) String() string {
 func (*UploadAndExtractReply) ProtoMessage() {}
 
 func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[10]
+	mi := &file_runner_runner_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +800,7 @@ func (x *UploadAndExtractReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadAndExtractReply.ProtoReflect.Descriptor instead.
 func (*UploadAndExtractReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{10}
+	return file_runner_runner_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UploadAndExtractReply) GetWorkingDir() string {
@@ -636,7 +828,7 @@ type CleanupDirRequest struct {
 func (x *CleanupDirRequest) Reset() {
 	*x = CleanupDirRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[11]
+		mi := &file_runner_runner_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -649,7 +841,7 @@ func (x *CleanupDirRequest) String() string {
 func (*CleanupDirRequest) ProtoMessage() {}
 
 func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[11]
+	mi := &file_runner_runner_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +854,7 @@ func (x *CleanupDirRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirRequest.ProtoReflect.Descriptor instead.
 func (*CleanupDirRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{11}
+	return file_runner_runner_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CleanupDirRequest) GetTmpDir() string {
@@ -683,7 +875,7 @@ type CleanupDirReply struct {
 func (x *CleanupDirReply) Reset() {
 	*x = CleanupDirReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[12]
+		mi := &file_runner_runner_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -696,7 +888,7 @@ func (x *CleanupDirReply) String() string {
 func (*CleanupDirReply) ProtoMessage() {}
 
 func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[12]
+	mi := &file_runner_runner_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +901,7 @@ func (x *CleanupDirReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupDirReply.ProtoReflect.Descriptor instead.
 func (*CleanupDirReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{12}
+	return file_runner_runner_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CleanupDirReply) GetMessage() string {
@@ -719,32 +911,34 @@ func (x *CleanupDirReply) GetMessage() string {
 	return ""
 }
 
-type WriteBackendConfigRequest struct {
+type SynthCDKTFRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DirPath       string `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
-	BackendConfig []byte `protobuf:"bytes,2,opt,name=backendConfig,proto3" json:"backendConfig,omitempty"`
+	WorkingDir    string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	Language      string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Stack         string `protobuf:"bytes,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	RemoveBackend bool   `protobuf:"varint,4,opt,name=removeBackend,proto3" json:"removeBackend,omitempty"`
 }
 
-func (x *WriteBackendConfigRequest) Reset() {
-	*x = WriteBackendConfigRequest{}
+func (x *SynthCDKTFRequest) Reset() {
+	*x = SynthCDKTFRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[13]
+		mi := &file_runner_runner_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteBackendConfigRequest) String() string {
+func (x *SynthCDKTFRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteBackendConfigRequest) ProtoMessage() {}
+func (*SynthCDKTFRequest) ProtoMessage() {}
 
-func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[13]
+func (x *SynthCDKTFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -755,50 +949,64 @@ func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteBackendConfigRequest.ProtoReflect.Descriptor instead.
-func (*WriteBackendConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SynthCDKTFRequest.ProtoReflect.Descriptor instead.
+func (*SynthCDKTFRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *WriteBackendConfigRequest) GetDirPath() string {
+func (x *SynthCDKTFRequest) GetWorkingDir() string {
 	if x != nil {
-		return x.DirPath
+		return x.WorkingDir
 	}
 	return ""
 }
 
-func (x *WriteBackendConfigRequest) GetBackendConfig() []byte {
+func (x *SynthCDKTFRequest) GetLanguage() string {
 	if x != nil {
-		return x.BackendConfig
+		return x.Language
 	}
-	return nil
+	return ""
 }
 
-type WriteBackendConfigReply struct {
+func (x *SynthCDKTFRequest) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+func (x *SynthCDKTFRequest) GetRemoveBackend() bool {
+	if x != nil {
+		return x.RemoveBackend
+	}
+	return false
+}
+
+type SynthCDKTFReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	WorkingDir string `protobuf:"bytes,1,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
 }
 
-func (x *WriteBackendConfigReply) Reset() {
-	*x = WriteBackendConfigReply{}
+func (x *SynthCDKTFReply) Reset() {
+	*x = SynthCDKTFReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[14]
+		mi := &file_runner_runner_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteBackendConfigReply) String() string {
+func (x *SynthCDKTFReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteBackendConfigReply) ProtoMessage() {}
+func (*SynthCDKTFReply) ProtoMessage() {}
 
-func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[14]
+func (x *SynthCDKTFReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,12 +1017,133 @@ func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteBackendConfigReply.ProtoReflect.Descriptor instead.
-func (*WriteBackendConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SynthCDKTFReply.ProtoReflect.Descriptor instead.
+func (*SynthCDKTFReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *WriteBackendConfigReply) GetMessage() string {
+func (x *SynthCDKTFReply) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+type WriteBackendConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DirPath       string `protobuf:"bytes,1,opt,name=dirPath,proto3" json:"dirPath,omitempty"`
+	BackendConfig []byte `protobuf:"bytes,2,opt,name=backendConfig,proto3" json:"backendConfig,omitempty"`
+	// typedBackend is the JSON of the backendConfig of an object with an s3, gcs or azurerm backend,
+	// whose backend block is generated by the runner instead of backendConfig, with the credentials
+	// of the Secrets of the namespace.
+	TypedBackend []byte `protobuf:"bytes,3,opt,name=typedBackend,proto3" json:"typedBackend,omitempty"`
+	Namespace    string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (x *WriteBackendConfigRequest) Reset() {
+	*x = WriteBackendConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteBackendConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBackendConfigRequest) ProtoMessage() {}
+
+func (x *WriteBackendConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBackendConfigRequest.ProtoReflect.Descriptor instead.
+func (*WriteBackendConfigRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WriteBackendConfigRequest) GetDirPath() string {
+	if x != nil {
+		return x.DirPath
+	}
+	return ""
+}
+
+func (x *WriteBackendConfigRequest) GetBackendConfig() []byte {
+	if x != nil {
+		return x.BackendConfig
+	}
+	return nil
+}
+
+func (x *WriteBackendConfigRequest) GetTypedBackend() []byte {
+	if x != nil {
+		return x.TypedBackend
+	}
+	return nil
+}
+
+func (x *WriteBackendConfigRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type WriteBackendConfigReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WriteBackendConfigReply) Reset() {
+	*x = WriteBackendConfigReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteBackendConfigReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBackendConfigReply) ProtoMessage() {}
+
+func (x *WriteBackendConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBackendConfigReply.ProtoReflect.Descriptor instead.
+func (*WriteBackendConfigReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WriteBackendConfigReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
@@ -834,7 +1163,7 @@ type ProcessCliConfigRequest struct {
 func (x *ProcessCliConfigRequest) Reset() {
 	*x = ProcessCliConfigRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[15]
+		mi := &file_runner_runner_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -847,7 +1176,7 @@ func (x *ProcessCliConfigRequest) String() string {
 func (*ProcessCliConfigRequest) ProtoMessage() {}
 
 func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[15]
+	mi := &file_runner_runner_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1189,7 @@ func (x *ProcessCliConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessCliConfigRequest.ProtoReflect.Descriptor instead.
 func (*ProcessCliConfigRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{15}
+	return file_runner_runner_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ProcessCliConfigRequest) GetDirPath() string {
@@ -895,7 +1224,7 @@ type ProcessCliConfigReply struct {
 func (x *ProcessCliConfigReply) Reset() {
 	*x = ProcessCliConfigReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[16]
+		mi := &file_runner_runner_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -908,7 +1237,7 @@ func (x *ProcessCliConfigReply) String() string {
 func (*ProcessCliConfigReply) ProtoMessage() {}
 
 func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[16]
+	mi := &file_runner_runner_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,7 +1250,7 @@ func (x *ProcessCliConfigReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessCliConfigReply.ProtoReflect.Descriptor instead.
 func (*ProcessCliConfigReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{16}
+	return file_runner_runner_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ProcessCliConfigReply) GetFilePath() string {
@@ -942,7 +1271,7 @@ type GenerateVarsForTFRequest struct {
 func (x *GenerateVarsForTFRequest) Reset() {
 	*x = GenerateVarsForTFRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[17]
+		mi := &file_runner_runner_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -955,7 +1284,7 @@ func (x *GenerateVarsForTFRequest) String() string {
 func (*GenerateVarsForTFRequest) ProtoMessage() {}
 
 func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[17]
+	mi := &file_runner_runner_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -968,7 +1297,7 @@ func (x *GenerateVarsForTFRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateVarsForTFRequest.ProtoReflect.Descriptor instead.
 func (*GenerateVarsForTFRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{17}
+	return file_runner_runner_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GenerateVarsForTFRequest) GetWorkingDir() string {
@@ -989,7 +1318,7 @@ type GenerateVarsForTFReply struct {
 func (x *GenerateVarsForTFReply) Reset() {
 	*x = GenerateVarsForTFReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[18]
+		mi := &file_runner_runner_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1002,7 +1331,7 @@ func (x *GenerateVarsForTFReply) String() string {
 func (*GenerateVarsForTFReply) ProtoMessage() {}
 
 func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[18]
+	mi := &file_runner_runner_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1344,7 @@ func (x *GenerateVarsForTFReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateVarsForTFReply.ProtoReflect.Descriptor instead.
 func (*GenerateVarsForTFReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{18}
+	return file_runner_runner_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GenerateVarsForTFReply) GetMessage() string {
@@ -1036,7 +1365,7 @@ type GenerateTemplateRequest struct {
 func (x *GenerateTemplateRequest) Reset() {
 	*x = GenerateTemplateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[19]
+		mi := &file_runner_runner_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1049,7 +1378,7 @@ func (x *GenerateTemplateRequest) String() string {
 func (*GenerateTemplateRequest) ProtoMessage() {}
 
 func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[19]
+	mi := &file_runner_runner_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1062,7 +1391,7 @@ func (x *GenerateTemplateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateTemplateRequest.ProtoReflect.Descriptor instead.
 func (*GenerateTemplateRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{19}
+	return file_runner_runner_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GenerateTemplateRequest) GetWorkingDir() string {
@@ -1083,7 +1412,7 @@ type GenerateTemplateReply struct {
 func (x *GenerateTemplateReply) Reset() {
 	*x = GenerateTemplateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[20]
+		mi := &file_runner_runner_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1096,7 +1425,7 @@ func (x *GenerateTemplateReply) String() string {
 func (*GenerateTemplateReply) ProtoMessage() {}
 
 func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[20]
+	mi := &file_runner_runner_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1109,7 +1438,7 @@ func (x *GenerateTemplateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateTemplateReply.ProtoReflect.Descriptor instead.
 func (*GenerateTemplateReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{20}
+	return file_runner_runner_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GenerateTemplateReply) GetMessage() string {
@@ -1129,12 +1458,13 @@ type PlanRequest struct {
 	Refresh    bool     `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
 	Destroy    bool     `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
 	Targets    []string `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	Replace    []string `protobuf:"bytes,6,rep,name=replace,proto3" json:"replace,omitempty"`
 }
 
 func (x *PlanRequest) Reset() {
 	*x = PlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[21]
+		mi := &file_runner_runner_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1147,7 +1477,7 @@ func (x *PlanRequest) String() string {
 func (*PlanRequest) ProtoMessage() {}
 
 func (x *PlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[21]
+	mi := &file_runner_runner_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1160,7 +1490,7 @@ func (x *PlanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
 func (*PlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{21}
+	return file_runner_runner_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PlanRequest) GetTfInstance() string {
@@ -1198,33 +1528,39 @@ func (x *PlanRequest) GetTargets() []string {
 	return nil
 }
 
-type PlanReply struct {
+func (x *PlanRequest) GetReplace() []string {
+	if x != nil {
+		return x.Replace
+	}
+	return nil
+}
+
+type ImportEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Drifted             bool   `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
-	Message             string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Id      string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *PlanReply) Reset() {
-	*x = PlanReply{}
+func (x *ImportEntry) Reset() {
+	*x = ImportEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[22]
+		mi := &file_runner_runner_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlanReply) String() string {
+func (x *ImportEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanReply) ProtoMessage() {}
+func (*ImportEntry) ProtoMessage() {}
 
-func (x *PlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[22]
+func (x *ImportEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1235,58 +1571,51 @@ func (x *PlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
-func (*PlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *PlanReply) GetDrifted() bool {
-	if x != nil {
-		return x.Drifted
-	}
-	return false
+// Deprecated: Use ImportEntry.ProtoReflect.Descriptor instead.
+func (*ImportEntry) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *PlanReply) GetMessage() string {
+func (x *ImportEntry) GetAddress() string {
 	if x != nil {
-		return x.Message
+		return x.Address
 	}
 	return ""
 }
 
-func (x *PlanReply) GetStateLockIdentifier() string {
+func (x *ImportEntry) GetId() string {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.Id
 	}
 	return ""
 }
 
-type ShowPlanFileRequest struct {
+type ImportRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	TfInstance string         `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Imports    []*ImportEntry `protobuf:"bytes,2,rep,name=imports,proto3" json:"imports,omitempty"`
 }
 
-func (x *ShowPlanFileRequest) Reset() {
-	*x = ShowPlanFileRequest{}
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[23]
+		mi := &file_runner_runner_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRequest) String() string {
+func (x *ImportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRequest) ProtoMessage() {}
+func (*ImportRequest) ProtoMessage() {}
 
-func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[23]
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1297,50 +1626,53 @@ func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ShowPlanFileRequest) GetTfInstance() string {
+func (x *ImportRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ShowPlanFileRequest) GetFilename() string {
+func (x *ImportRequest) GetImports() []*ImportEntry {
 	if x != nil {
-		return x.Filename
+		return x.Imports
 	}
-	return ""
+	return nil
 }
 
-type ShowPlanFileReply struct {
+type ImportResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JsonOutput []byte `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Id      string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Result  string `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *ShowPlanFileReply) Reset() {
-	*x = ShowPlanFileReply{}
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[24]
+		mi := &file_runner_runner_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileReply) String() string {
+func (x *ImportResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileReply) ProtoMessage() {}
+func (*ImportResult) ProtoMessage() {}
 
-func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[24]
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,44 +1683,64 @@ func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ShowPlanFileReply) GetJsonOutput() []byte {
+func (x *ImportResult) GetAddress() string {
 	if x != nil {
-		return x.JsonOutput
+		return x.Address
 	}
-	return nil
+	return ""
 }
 
-type ShowPlanFileRawRequest struct {
+func (x *ImportResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ImportResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Results []*ImportResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *ShowPlanFileRawRequest) Reset() {
-	*x = ShowPlanFileRawRequest{}
+func (x *ImportReply) Reset() {
+	*x = ImportReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[25]
+		mi := &file_runner_runner_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRawRequest) String() string {
+func (x *ImportReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawRequest) ProtoMessage() {}
+func (*ImportReply) ProtoMessage() {}
 
-func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[25]
+func (x *ImportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,50 +1751,45 @@ func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *ShowPlanFileRawRequest) GetTfInstance() string {
-	if x != nil {
-		return x.TfInstance
-	}
-	return ""
+// Deprecated: Use ImportReply.ProtoReflect.Descriptor instead.
+func (*ImportReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ShowPlanFileRawRequest) GetFilename() string {
+func (x *ImportReply) GetResults() []*ImportResult {
 	if x != nil {
-		return x.Filename
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-type ShowPlanFileRawReply struct {
+type PlanReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RawOutput string `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
+	Drifted             bool   `protobuf:"varint,1,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	Message             string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string `protobuf:"bytes,3,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
 }
 
-func (x *ShowPlanFileRawReply) Reset() {
-	*x = ShowPlanFileRawReply{}
+func (x *PlanReply) Reset() {
+	*x = PlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[26]
+		mi := &file_runner_runner_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ShowPlanFileRawReply) String() string {
+func (x *PlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShowPlanFileRawReply) ProtoMessage() {}
+func (*PlanReply) ProtoMessage() {}
 
-func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[26]
+func (x *PlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1453,48 +1800,58 @@ func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
-func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use PlanReply.ProtoReflect.Descriptor instead.
+func (*PlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ShowPlanFileRawReply) GetRawOutput() string {
+func (x *PlanReply) GetDrifted() bool {
 	if x != nil {
-		return x.RawOutput
+		return x.Drifted
+	}
+	return false
+}
+
+func (x *PlanReply) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type SaveTFPlanRequest struct {
+func (x *PlanReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+type ShowPlanFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Uuid                     string `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Revision                 string `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *SaveTFPlanRequest) Reset() {
-	*x = SaveTFPlanRequest{}
+func (x *ShowPlanFileRequest) Reset() {
+	*x = ShowPlanFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[27]
+		mi := &file_runner_runner_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveTFPlanRequest) String() string {
+func (x *ShowPlanFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanRequest) ProtoMessage() {}
+func (*ShowPlanFileRequest) ProtoMessage() {}
 
-func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[27]
+func (x *ShowPlanFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1505,78 +1862,50 @@ func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ShowPlanFileRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *SaveTFPlanRequest) GetTfInstance() string {
+func (x *ShowPlanFileRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
-	if x != nil {
-		return x.BackendCompletelyDisable
-	}
-	return false
-}
-
-func (x *SaveTFPlanRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *SaveTFPlanRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
-}
-
-func (x *SaveTFPlanRequest) GetUuid() string {
-	if x != nil {
-		return x.Uuid
-	}
-	return ""
-}
-
-func (x *SaveTFPlanRequest) GetRevision() string {
+func (x *ShowPlanFileRequest) GetFilename() string {
 	if x != nil {
-		return x.Revision
+		return x.Filename
 	}
 	return ""
 }
 
-type SaveTFPlanReply struct {
+type ShowPlanFileReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	JsonOutput []byte `protobuf:"bytes,1,opt,name=jsonOutput,proto3" json:"jsonOutput,omitempty"`
 }
 
-func (x *SaveTFPlanReply) Reset() {
-	*x = SaveTFPlanReply{}
+func (x *ShowPlanFileReply) Reset() {
+	*x = ShowPlanFileReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[28]
+		mi := &file_runner_runner_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SaveTFPlanReply) String() string {
+func (x *ShowPlanFileReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveTFPlanReply) ProtoMessage() {}
+func (*ShowPlanFileReply) ProtoMessage() {}
 
-func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[28]
+func (x *ShowPlanFileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1587,47 +1916,44 @@ func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
-func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ShowPlanFileReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *SaveTFPlanReply) GetMessage() string {
+func (x *ShowPlanFileReply) GetJsonOutput() []byte {
 	if x != nil {
-		return x.Message
+		return x.JsonOutput
 	}
-	return ""
+	return nil
 }
 
-type LoadTFPlanRequest struct {
+type ShowPlanFileRawRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
-	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	PendingPlan              string `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Filename   string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 }
 
-func (x *LoadTFPlanRequest) Reset() {
-	*x = LoadTFPlanRequest{}
+func (x *ShowPlanFileRawRequest) Reset() {
+	*x = ShowPlanFileRawRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[29]
+		mi := &file_runner_runner_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoadTFPlanRequest) String() string {
+func (x *ShowPlanFileRawRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanRequest) ProtoMessage() {}
+func (*ShowPlanFileRawRequest) ProtoMessage() {}
 
-func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[29]
+func (x *ShowPlanFileRawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1638,71 +1964,50 @@ func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ShowPlanFileRawRequest.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *LoadTFPlanRequest) GetTfInstance() string {
+func (x *ShowPlanFileRawRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
-	if x != nil {
-		return x.BackendCompletelyDisable
-	}
-	return false
-}
-
-func (x *LoadTFPlanRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *LoadTFPlanRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
-}
-
-func (x *LoadTFPlanRequest) GetPendingPlan() string {
+func (x *ShowPlanFileRawRequest) GetFilename() string {
 	if x != nil {
-		return x.PendingPlan
+		return x.Filename
 	}
 	return ""
 }
 
-type LoadTFPlanReply struct {
+type ShowPlanFileRawReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	RawOutput string `protobuf:"bytes,1,opt,name=rawOutput,proto3" json:"rawOutput,omitempty"`
 }
 
-func (x *LoadTFPlanReply) Reset() {
-	*x = LoadTFPlanReply{}
+func (x *ShowPlanFileRawReply) Reset() {
+	*x = ShowPlanFileRawReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[30]
+		mi := &file_runner_runner_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoadTFPlanReply) String() string {
+func (x *ShowPlanFileRawReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadTFPlanReply) ProtoMessage() {}
+func (*ShowPlanFileRawReply) ProtoMessage() {}
 
-func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[30]
+func (x *ShowPlanFileRawReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1713,46 +2018,47 @@ func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
-func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ShowPlanFileRawReply.ProtoReflect.Descriptor instead.
+func (*ShowPlanFileRawReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *LoadTFPlanReply) GetMessage() string {
+func (x *ShowPlanFileRawReply) GetRawOutput() string {
 	if x != nil {
-		return x.Message
+		return x.RawOutput
 	}
 	return ""
 }
 
-type ApplyRequest struct {
+type GraphRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance         string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	DirOrPlan          string   `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
-	RefreshBeforeApply bool     `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
-	Targets            []string `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Uuid       string `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Revision   string `protobuf:"bytes,5,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
-func (x *ApplyRequest) Reset() {
-	*x = ApplyRequest{}
+func (x *GraphRequest) Reset() {
+	*x = GraphRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[31]
+		mi := &file_runner_runner_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ApplyRequest) String() string {
+func (x *GraphRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyRequest) ProtoMessage() {}
+func (*GraphRequest) ProtoMessage() {}
 
-func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[31]
+func (x *GraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1763,65 +2069,71 @@ func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
-func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use GraphRequest.ProtoReflect.Descriptor instead.
+func (*GraphRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ApplyRequest) GetTfInstance() string {
+func (x *GraphRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetDirOrPlan() string {
+func (x *GraphRequest) GetName() string {
 	if x != nil {
-		return x.DirOrPlan
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetRefreshBeforeApply() bool {
+func (x *GraphRequest) GetNamespace() string {
 	if x != nil {
-		return x.RefreshBeforeApply
+		return x.Namespace
 	}
-	return false
+	return ""
 }
 
-func (x *ApplyRequest) GetTargets() []string {
+func (x *GraphRequest) GetUuid() string {
 	if x != nil {
-		return x.Targets
+		return x.Uuid
 	}
-	return nil
+	return ""
 }
 
-type ApplyReply struct {
+func (x *GraphRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type GraphReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Dot string `protobuf:"bytes,1,opt,name=dot,proto3" json:"dot,omitempty"`
 }
 
-func (x *ApplyReply) Reset() {
-	*x = ApplyReply{}
+func (x *GraphReply) Reset() {
+	*x = GraphReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[32]
+		mi := &file_runner_runner_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ApplyReply) String() string {
+func (x *GraphReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyReply) ProtoMessage() {}
+func (*GraphReply) ProtoMessage() {}
 
-func (x *ApplyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[32]
+func (x *GraphReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1832,50 +2144,45 @@ func (x *ApplyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
-func (*ApplyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *ApplyReply) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use GraphReply.ProtoReflect.Descriptor instead.
+func (*GraphReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ApplyReply) GetStateLockIdentifier() string {
+func (x *GraphReply) GetDot() string {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.Dot
 	}
 	return ""
 }
 
-type GetInventoryRequest struct {
+type GetProviderSchemasRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	// cached are the provider schemas the caller already has, as <source>@<version> entries.
+	Cached []string `protobuf:"bytes,2,rep,name=cached,proto3" json:"cached,omitempty"`
 }
 
-func (x *GetInventoryRequest) Reset() {
-	*x = GetInventoryRequest{}
+func (x *GetProviderSchemasRequest) Reset() {
+	*x = GetProviderSchemasRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[33]
+		mi := &file_runner_runner_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetInventoryRequest) String() string {
+func (x *GetProviderSchemasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryRequest) ProtoMessage() {}
+func (*GetProviderSchemasRequest) ProtoMessage() {}
 
-func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[33]
+func (x *GetProviderSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1886,43 +2193,53 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GetProviderSchemasRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetInventoryRequest) GetTfInstance() string {
+func (x *GetProviderSchemasRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-type GetInventoryReply struct {
+func (x *GetProviderSchemasRequest) GetCached() []string {
+	if x != nil {
+		return x.Cached
+	}
+	return nil
+}
+
+type GetProviderSchemasReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Inventories []*Inventory `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	// providers maps the source of each provider of the working directory to its version.
+	Providers map[string]string `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// schemas is the JSON of the provider schemas, without the cached ones.
+	Schemas []byte `protobuf:"bytes,2,opt,name=schemas,proto3" json:"schemas,omitempty"`
 }
 
-func (x *GetInventoryReply) Reset() {
-	*x = GetInventoryReply{}
+func (x *GetProviderSchemasReply) Reset() {
+	*x = GetProviderSchemasReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[34]
+		mi := &file_runner_runner_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetInventoryReply) String() string {
+func (x *GetProviderSchemasReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryReply) ProtoMessage() {}
+func (*GetProviderSchemasReply) ProtoMessage() {}
 
-func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[34]
+func (x *GetProviderSchemasReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1933,45 +2250,62 @@ func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
-func (*GetInventoryReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use GetProviderSchemasReply.ProtoReflect.Descriptor instead.
+func (*GetProviderSchemasReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetInventoryReply) GetInventories() []*Inventory {
+func (x *GetProviderSchemasReply) GetProviders() map[string]string {
 	if x != nil {
-		return x.Inventories
+		return x.Providers
 	}
 	return nil
 }
 
-type Inventory struct {
+func (x *GetProviderSchemasReply) GetSchemas() []byte {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+type SaveTFPlanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Identifier string `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Uuid                     string `protobuf:"bytes,5,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Revision                 string `protobuf:"bytes,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	LastAppliedPlan          string `protobuf:"bytes,7,opt,name=lastAppliedPlan,proto3" json:"lastAppliedPlan,omitempty"`
+	// planId names the plan, plan-<revision> when empty
+	PlanId string `protobuf:"bytes,8,opt,name=planId,proto3" json:"planId,omitempty"`
+	// maxPlanSize bounds the compressed plan, no limit when zero
+	MaxPlanSize int64 `protobuf:"varint,9,opt,name=maxPlanSize,proto3" json:"maxPlanSize,omitempty"`
+	// maxReadablePlanSize bounds the readable plan, truncated beyond it, no limit when zero
+	MaxReadablePlanSize int64 `protobuf:"varint,10,opt,name=maxReadablePlanSize,proto3" json:"maxReadablePlanSize,omitempty"`
 }
 
-func (x *Inventory) Reset() {
-	*x = Inventory{}
+func (x *SaveTFPlanRequest) Reset() {
+	*x = SaveTFPlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[35]
+		mi := &file_runner_runner_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Inventory) String() string {
+func (x *SaveTFPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Inventory) ProtoMessage() {}
+func (*SaveTFPlanRequest) ProtoMessage() {}
 
-func (x *Inventory) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[35]
+func (x *SaveTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1982,113 +2316,111 @@ func (x *Inventory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
-func (*Inventory) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use SaveTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *Inventory) GetName() string {
+func (x *SaveTFPlanRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Name
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *Inventory) GetType() string {
+func (x *SaveTFPlanRequest) GetBackendCompletelyDisable() bool {
 	if x != nil {
-		return x.Type
+		return x.BackendCompletelyDisable
 	}
-	return ""
+	return false
 }
 
-func (x *Inventory) GetIdentifier() string {
+func (x *SaveTFPlanRequest) GetName() string {
 	if x != nil {
-		return x.Identifier
+		return x.Name
 	}
 	return ""
 }
 
-type DestroyRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Targets    []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+func (x *SaveTFPlanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
 }
 
-func (x *DestroyRequest) Reset() {
-	*x = DestroyRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[36]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SaveTFPlanRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
 	}
+	return ""
 }
 
-func (x *DestroyRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SaveTFPlanRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
 }
 
-func (*DestroyRequest) ProtoMessage() {}
-
-func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[36]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *SaveTFPlanRequest) GetLastAppliedPlan() string {
+	if x != nil {
+		return x.LastAppliedPlan
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
-func (*DestroyRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{36}
+func (x *SaveTFPlanRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
 }
 
-func (x *DestroyRequest) GetTfInstance() string {
+func (x *SaveTFPlanRequest) GetMaxPlanSize() int64 {
 	if x != nil {
-		return x.TfInstance
+		return x.MaxPlanSize
 	}
-	return ""
+	return 0
 }
 
-func (x *DestroyRequest) GetTargets() []string {
+func (x *SaveTFPlanRequest) GetMaxReadablePlanSize() int64 {
 	if x != nil {
-		return x.Targets
+		return x.MaxReadablePlanSize
 	}
-	return nil
+	return 0
 }
 
-type DestroyReply struct {
+type SaveTFPlanReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Message               string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	SupersededPlan        string   `protobuf:"bytes,2,opt,name=supersededPlan,proto3" json:"supersededPlan,omitempty"`
+	AddedChanges          []string `protobuf:"bytes,3,rep,name=addedChanges,proto3" json:"addedChanges,omitempty"`
+	RemovedChanges        []string `protobuf:"bytes,4,rep,name=removedChanges,proto3" json:"removedChanges,omitempty"`
+	ChangeCount           int32    `protobuf:"varint,5,opt,name=changeCount,proto3" json:"changeCount,omitempty"`
+	ReadablePlanTruncated bool     `protobuf:"varint,6,opt,name=readablePlanTruncated,proto3" json:"readablePlanTruncated,omitempty"`
 }
 
-func (x *DestroyReply) Reset() {
-	*x = DestroyReply{}
+func (x *SaveTFPlanReply) Reset() {
+	*x = SaveTFPlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[37]
+		mi := &file_runner_runner_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DestroyReply) String() string {
+func (x *SaveTFPlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestroyReply) ProtoMessage() {}
+func (*SaveTFPlanReply) ProtoMessage() {}
 
-func (x *DestroyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[37]
+func (x *SaveTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2099,50 +2431,82 @@ func (x *DestroyReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
-func (*DestroyReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use SaveTFPlanReply.ProtoReflect.Descriptor instead.
+func (*SaveTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *DestroyReply) GetMessage() string {
+func (x *SaveTFPlanReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *DestroyReply) GetStateLockIdentifier() string {
+func (x *SaveTFPlanReply) GetSupersededPlan() string {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.SupersededPlan
 	}
 	return ""
 }
 
-type OutputRequest struct {
+func (x *SaveTFPlanReply) GetAddedChanges() []string {
+	if x != nil {
+		return x.AddedChanges
+	}
+	return nil
+}
+
+func (x *SaveTFPlanReply) GetRemovedChanges() []string {
+	if x != nil {
+		return x.RemovedChanges
+	}
+	return nil
+}
+
+func (x *SaveTFPlanReply) GetChangeCount() int32 {
+	if x != nil {
+		return x.ChangeCount
+	}
+	return 0
+}
+
+func (x *SaveTFPlanReply) GetReadablePlanTruncated() bool {
+	if x != nil {
+		return x.ReadablePlanTruncated
+	}
+	return false
+}
+
+type LoadTFPlanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	TfInstance               string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	BackendCompletelyDisable bool   `protobuf:"varint,2,opt,name=backendCompletelyDisable,proto3" json:"backendCompletelyDisable,omitempty"`
+	Name                     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace                string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PendingPlan              string `protobuf:"bytes,5,opt,name=pendingPlan,proto3" json:"pendingPlan,omitempty"`
 }
 
-func (x *OutputRequest) Reset() {
-	*x = OutputRequest{}
+func (x *LoadTFPlanRequest) Reset() {
+	*x = LoadTFPlanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[38]
+		mi := &file_runner_runner_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutputRequest) String() string {
+func (x *LoadTFPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputRequest) ProtoMessage() {}
+func (*LoadTFPlanRequest) ProtoMessage() {}
 
-func (x *OutputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[38]
+func (x *LoadTFPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2153,43 +2517,71 @@ func (x *OutputRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
-func (*OutputRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use LoadTFPlanRequest.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *OutputRequest) GetTfInstance() string {
+func (x *LoadTFPlanRequest) GetTfInstance() string {
 	if x != nil {
 		return x.TfInstance
 	}
 	return ""
 }
 
-type OutputReply struct {
+func (x *LoadTFPlanRequest) GetBackendCompletelyDisable() bool {
+	if x != nil {
+		return x.BackendCompletelyDisable
+	}
+	return false
+}
+
+func (x *LoadTFPlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LoadTFPlanRequest) GetPendingPlan() string {
+	if x != nil {
+		return x.PendingPlan
+	}
+	return ""
+}
+
+type LoadTFPlanReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Outputs map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *OutputReply) Reset() {
-	*x = OutputReply{}
+func (x *LoadTFPlanReply) Reset() {
+	*x = LoadTFPlanReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[39]
+		mi := &file_runner_runner_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutputReply) String() string {
+func (x *LoadTFPlanReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputReply) ProtoMessage() {}
+func (*LoadTFPlanReply) ProtoMessage() {}
 
-func (x *OutputReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[39]
+func (x *LoadTFPlanReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2200,45 +2592,46 @@ func (x *OutputReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
-func (*OutputReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use LoadTFPlanReply.ProtoReflect.Descriptor instead.
+func (*LoadTFPlanReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
+func (x *LoadTFPlanReply) GetMessage() string {
 	if x != nil {
-		return x.Outputs
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type OutputMeta struct {
+type ApplyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sensitive bool   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
-	Type      []byte `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	TfInstance         string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	DirOrPlan          string   `protobuf:"bytes,2,opt,name=dirOrPlan,proto3" json:"dirOrPlan,omitempty"`
+	RefreshBeforeApply bool     `protobuf:"varint,3,opt,name=refreshBeforeApply,proto3" json:"refreshBeforeApply,omitempty"`
+	Targets            []string `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
 }
 
-func (x *OutputMeta) Reset() {
-	*x = OutputMeta{}
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[40]
+		mi := &file_runner_runner_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OutputMeta) String() string {
+func (x *ApplyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OutputMeta) ProtoMessage() {}
+func (*ApplyRequest) ProtoMessage() {}
 
-func (x *OutputMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[40]
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2249,61 +2642,66 @@ func (x *OutputMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
-func (*OutputMeta) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *OutputMeta) GetSensitive() bool {
+func (x *ApplyRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Sensitive
+		return x.TfInstance
 	}
-	return false
+	return ""
 }
 
-func (x *OutputMeta) GetType() []byte {
+func (x *ApplyRequest) GetDirOrPlan() string {
 	if x != nil {
-		return x.Type
+		return x.DirOrPlan
 	}
-	return nil
+	return ""
 }
 
-func (x *OutputMeta) GetValue() []byte {
+func (x *ApplyRequest) GetRefreshBeforeApply() bool {
 	if x != nil {
-		return x.Value
+		return x.RefreshBeforeApply
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
 	}
 	return nil
 }
 
-type WriteOutputsRequest struct {
+type ApplyReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace  string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name       string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	SecretName string            `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
-	Uuid       string            `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Data       map[string][]byte `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Interrupted         bool   `protobuf:"varint,3,opt,name=interrupted,proto3" json:"interrupted,omitempty"`
 }
 
-func (x *WriteOutputsRequest) Reset() {
-	*x = WriteOutputsRequest{}
+func (x *ApplyReply) Reset() {
+	*x = ApplyReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[41]
+		mi := &file_runner_runner_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteOutputsRequest) String() string {
+func (x *ApplyReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsRequest) ProtoMessage() {}
+func (*ApplyReply) ProtoMessage() {}
 
-func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[41]
+func (x *ApplyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2314,72 +2712,57 @@ func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
-func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{41}
-}
-
-func (x *WriteOutputsRequest) GetNamespace() string {
-	if x != nil {
-		return x.Namespace
-	}
-	return ""
-}
-
-func (x *WriteOutputsRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use ApplyReply.ProtoReflect.Descriptor instead.
+func (*ApplyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *WriteOutputsRequest) GetSecretName() string {
+func (x *ApplyReply) GetMessage() string {
 	if x != nil {
-		return x.SecretName
+		return x.Message
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetUuid() string {
+func (x *ApplyReply) GetStateLockIdentifier() string {
 	if x != nil {
-		return x.Uuid
+		return x.StateLockIdentifier
 	}
 	return ""
 }
 
-func (x *WriteOutputsRequest) GetData() map[string][]byte {
+func (x *ApplyReply) GetInterrupted() bool {
 	if x != nil {
-		return x.Data
+		return x.Interrupted
 	}
-	return nil
+	return false
 }
 
-type WriteOutputsReply struct {
+type GetApplyProgressRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Changed bool   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *WriteOutputsReply) Reset() {
-	*x = WriteOutputsReply{}
+func (x *GetApplyProgressRequest) Reset() {
+	*x = GetApplyProgressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[42]
+		mi := &file_runner_runner_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WriteOutputsReply) String() string {
+func (x *GetApplyProgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteOutputsReply) ProtoMessage() {}
+func (*GetApplyProgressRequest) ProtoMessage() {}
 
-func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[42]
+func (x *GetApplyProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2390,51 +2773,44 @@ func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
-func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use GetApplyProgressRequest.ProtoReflect.Descriptor instead.
+func (*GetApplyProgressRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *WriteOutputsReply) GetMessage() string {
+func (x *GetApplyProgressRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-func (x *WriteOutputsReply) GetChanged() bool {
-	if x != nil {
-		return x.Changed
-	}
-	return false
-}
-
-type GetOutputsRequest struct {
+type GetApplyProgressReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	SecretName string `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	Completed int32 `protobuf:"varint,1,opt,name=completed,proto3" json:"completed,omitempty"`
+	Total     int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 }
 
-func (x *GetOutputsRequest) Reset() {
-	*x = GetOutputsRequest{}
+func (x *GetApplyProgressReply) Reset() {
+	*x = GetApplyProgressReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[43]
+		mi := &file_runner_runner_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetOutputsRequest) String() string {
+func (x *GetApplyProgressReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsRequest) ProtoMessage() {}
+func (*GetApplyProgressReply) ProtoMessage() {}
 
-func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[43]
+func (x *GetApplyProgressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2445,50 +2821,50 @@ func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
-func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use GetApplyProgressReply.ProtoReflect.Descriptor instead.
+func (*GetApplyProgressReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetOutputsRequest) GetNamespace() string {
+func (x *GetApplyProgressReply) GetCompleted() int32 {
 	if x != nil {
-		return x.Namespace
+		return x.Completed
 	}
-	return ""
+	return 0
 }
 
-func (x *GetOutputsRequest) GetSecretName() string {
+func (x *GetApplyProgressReply) GetTotal() int32 {
 	if x != nil {
-		return x.SecretName
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-type GetOutputsReply struct {
+type GetInventoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Outputs map[string]string `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *GetOutputsReply) Reset() {
-	*x = GetOutputsReply{}
+func (x *GetInventoryRequest) Reset() {
+	*x = GetInventoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[44]
+		mi := &file_runner_runner_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetOutputsReply) String() string {
+func (x *GetInventoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputsReply) ProtoMessage() {}
+func (*GetInventoryRequest) ProtoMessage() {}
 
-func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[44]
+func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2499,45 +2875,43 @@ func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
-func (*GetOutputsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetOutputsReply) GetOutputs() map[string]string {
+func (x *GetInventoryRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Outputs
+		return x.TfInstance
 	}
-	return nil
+	return ""
 }
 
-type InitRequest struct {
+type GetInventoryReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
-	Upgrade    bool   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
-	ForceCopy  bool   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+	Inventories []*Inventory `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
 }
 
-func (x *InitRequest) Reset() {
-	*x = InitRequest{}
+func (x *GetInventoryReply) Reset() {
+	*x = GetInventoryReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[45]
+		mi := &file_runner_runner_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InitRequest) String() string {
+func (x *GetInventoryReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitRequest) ProtoMessage() {}
+func (*GetInventoryReply) ProtoMessage() {}
 
-func (x *InitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[45]
+func (x *GetInventoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2548,58 +2922,46 @@ func (x *InitRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
-func (*InitRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{45}
-}
-
-func (x *InitRequest) GetTfInstance() string {
-	if x != nil {
-		return x.TfInstance
-	}
-	return ""
-}
-
-func (x *InitRequest) GetUpgrade() bool {
-	if x != nil {
-		return x.Upgrade
-	}
-	return false
+// Deprecated: Use GetInventoryReply.ProtoReflect.Descriptor instead.
+func (*GetInventoryReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *InitRequest) GetForceCopy() bool {
+func (x *GetInventoryReply) GetInventories() []*Inventory {
 	if x != nil {
-		return x.ForceCopy
+		return x.Inventories
 	}
-	return false
+	return nil
 }
 
-type InitReply struct {
+type Inventory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Name       string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type       string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Identifier string            `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Object     *KubernetesObject `protobuf:"bytes,4,opt,name=object,proto3" json:"object,omitempty"`
 }
 
-func (x *InitReply) Reset() {
-	*x = InitReply{}
+func (x *Inventory) Reset() {
+	*x = Inventory{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[46]
+		mi := &file_runner_runner_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InitReply) String() string {
+func (x *Inventory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitReply) ProtoMessage() {}
+func (*Inventory) ProtoMessage() {}
 
-func (x *InitReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[46]
+func (x *Inventory) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2610,50 +2972,67 @@ func (x *InitReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
-func (*InitReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
+func (*Inventory) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *InitReply) GetMessage() string {
+func (x *Inventory) GetName() string {
 	if x != nil {
-		return x.Message
+		return x.Name
 	}
 	return ""
 }
 
-func (x *InitReply) GetStateLockIdentifier() string {
+func (x *Inventory) GetType() string {
 	if x != nil {
-		return x.StateLockIdentifier
+		return x.Type
 	}
 	return ""
 }
 
-type WorkspaceRequest struct {
+func (x *Inventory) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *Inventory) GetObject() *KubernetesObject {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+type KubernetesObject struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *WorkspaceRequest) Reset() {
-	*x = WorkspaceRequest{}
+func (x *KubernetesObject) Reset() {
+	*x = KubernetesObject{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[47]
+		mi := &file_runner_runner_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WorkspaceRequest) String() string {
+func (x *KubernetesObject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceRequest) ProtoMessage() {}
+func (*KubernetesObject) ProtoMessage() {}
 
-func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[47]
+func (x *KubernetesObject) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2664,43 +3043,65 @@ func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceRequest.ProtoReflect.Descriptor instead.
-func (*WorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use KubernetesObject.ProtoReflect.Descriptor instead.
+func (*KubernetesObject) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *WorkspaceRequest) GetTfInstance() string {
+func (x *KubernetesObject) GetApiVersion() string {
 	if x != nil {
-		return x.TfInstance
+		return x.ApiVersion
 	}
 	return ""
 }
 
-type WorkspaceReply struct {
+func (x *KubernetesObject) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *KubernetesObject) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *KubernetesObject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DestroyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Targets    []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
 }
 
-func (x *WorkspaceReply) Reset() {
-	*x = WorkspaceReply{}
+func (x *DestroyRequest) Reset() {
+	*x = DestroyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[48]
+		mi := &file_runner_runner_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WorkspaceReply) String() string {
+func (x *DestroyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkspaceReply) ProtoMessage() {}
+func (*DestroyRequest) ProtoMessage() {}
 
-func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[48]
+func (x *DestroyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2711,43 +3112,52 @@ func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkspaceReply.ProtoReflect.Descriptor instead.
-func (*WorkspaceReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use DestroyRequest.ProtoReflect.Descriptor instead.
+func (*DestroyRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *WorkspaceReply) GetMessage() string {
+func (x *DestroyRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-type UploadRequest struct {
+func (x *DestroyRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type DestroyReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Blob []byte `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
+	Message             string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	Interrupted         bool   `protobuf:"varint,3,opt,name=interrupted,proto3" json:"interrupted,omitempty"`
 }
 
-func (x *UploadRequest) Reset() {
-	*x = UploadRequest{}
+func (x *DestroyReply) Reset() {
+	*x = DestroyReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[49]
+		mi := &file_runner_runner_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadRequest) String() string {
+func (x *DestroyReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadRequest) ProtoMessage() {}
+func (*DestroyReply) ProtoMessage() {}
 
-func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[49]
+func (x *DestroyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2758,43 +3168,57 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
-func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use DestroyReply.ProtoReflect.Descriptor instead.
+func (*DestroyReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *UploadRequest) GetBlob() []byte {
+func (x *DestroyReply) GetMessage() string {
 	if x != nil {
-		return x.Blob
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type UploadReply struct {
+func (x *DestroyReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *DestroyReply) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
+type OutputRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
 }
 
-func (x *UploadReply) Reset() {
-	*x = UploadReply{}
+func (x *OutputRequest) Reset() {
+	*x = OutputRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[50]
+		mi := &file_runner_runner_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadReply) String() string {
+func (x *OutputRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadReply) ProtoMessage() {}
+func (*OutputRequest) ProtoMessage() {}
 
-func (x *UploadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[50]
+func (x *OutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2805,47 +3229,43 @@ func (x *UploadReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
-func (*UploadReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use OutputRequest.ProtoReflect.Descriptor instead.
+func (*OutputRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *UploadReply) GetMessage() string {
+func (x *OutputRequest) GetTfInstance() string {
 	if x != nil {
-		return x.Message
+		return x.TfInstance
 	}
 	return ""
 }
 
-type FinalizeSecretsRequest struct {
+type OutputReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace                string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name                     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Workspace                string `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	HasSpecifiedOutputSecret bool   `protobuf:"varint,4,opt,name=hasSpecifiedOutputSecret,proto3" json:"hasSpecifiedOutputSecret,omitempty"`
-	OutputSecretName         string `protobuf:"bytes,5,opt,name=outputSecretName,proto3" json:"outputSecretName,omitempty"`
+	Outputs map[string]*OutputMeta `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *FinalizeSecretsRequest) Reset() {
-	*x = FinalizeSecretsRequest{}
+func (x *OutputReply) Reset() {
+	*x = OutputReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[51]
+		mi := &file_runner_runner_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FinalizeSecretsRequest) String() string {
+func (x *OutputReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeSecretsRequest) ProtoMessage() {}
+func (*OutputReply) ProtoMessage() {}
 
-func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[51]
+func (x *OutputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2856,72 +3276,214 @@ func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeSecretsRequest.ProtoReflect.Descriptor instead.
-func (*FinalizeSecretsRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use OutputReply.ProtoReflect.Descriptor instead.
+func (*OutputReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *FinalizeSecretsRequest) GetNamespace() string {
+func (x *OutputReply) GetOutputs() map[string]*OutputMeta {
 	if x != nil {
-		return x.Namespace
+		return x.Outputs
 	}
-	return ""
+	return nil
 }
 
-func (x *FinalizeSecretsRequest) GetName() string {
+type OutputMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sensitive bool   `protobuf:"varint,1,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	Type      []byte `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *OutputMeta) Reset() {
+	*x = OutputMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputMeta) ProtoMessage() {}
+
+func (x *OutputMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputMeta.ProtoReflect.Descriptor instead.
+func (*OutputMeta) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *OutputMeta) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+func (x *OutputMeta) GetType() []byte {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *OutputMeta) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type WriteOutputsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace  string            `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SecretName string            `protobuf:"bytes,3,opt,name=secretName,proto3" json:"secretName,omitempty"`
+	Uuid       string            `protobuf:"bytes,4,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Data       map[string][]byte `protobuf:"bytes,5,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// maxSize bounds the data of the secret, the largest outputs are left out beyond it, no limit when zero
+	MaxSize int64 `protobuf:"varint,6,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	// secretNamespace is the namespace of the secret, the namespace of the object when empty
+	SecretNamespace string `protobuf:"bytes,7,opt,name=secretNamespace,proto3" json:"secretNamespace,omitempty"`
+	// retain creates the secret without the owner reference to the object, to be kept when the object is deleted
+	Retain bool `protobuf:"varint,8,opt,name=retain,proto3" json:"retain,omitempty"`
+}
+
+func (x *WriteOutputsRequest) Reset() {
+	*x = WriteOutputsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOutputsRequest) ProtoMessage() {}
+
+func (x *WriteOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOutputsRequest.ProtoReflect.Descriptor instead.
+func (*WriteOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WriteOutputsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WriteOutputsRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetWorkspace() string {
+func (x *WriteOutputsRequest) GetSecretName() string {
 	if x != nil {
-		return x.Workspace
+		return x.SecretName
 	}
 	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetHasSpecifiedOutputSecret() bool {
+func (x *WriteOutputsRequest) GetUuid() string {
 	if x != nil {
-		return x.HasSpecifiedOutputSecret
+		return x.Uuid
 	}
-	return false
+	return ""
 }
 
-func (x *FinalizeSecretsRequest) GetOutputSecretName() string {
+func (x *WriteOutputsRequest) GetData() map[string][]byte {
 	if x != nil {
-		return x.OutputSecretName
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteOutputsRequest) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *WriteOutputsRequest) GetSecretNamespace() string {
+	if x != nil {
+		return x.SecretNamespace
 	}
 	return ""
 }
 
-type FinalizeSecretsReply struct {
+func (x *WriteOutputsRequest) GetRetain() bool {
+	if x != nil {
+		return x.Retain
+	}
+	return false
+}
+
+type WriteOutputsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message  string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	NotFound bool   `protobuf:"varint,2,opt,name=notFound,proto3" json:"notFound,omitempty"`
+	Message          string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Changed          bool     `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	TruncatedOutputs []string `protobuf:"bytes,3,rep,name=truncatedOutputs,proto3" json:"truncatedOutputs,omitempty"`
 }
 
-func (x *FinalizeSecretsReply) Reset() {
-	*x = FinalizeSecretsReply{}
+func (x *WriteOutputsReply) Reset() {
+	*x = WriteOutputsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[52]
+		mi := &file_runner_runner_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FinalizeSecretsReply) String() string {
+func (x *WriteOutputsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeSecretsReply) ProtoMessage() {}
+func (*WriteOutputsReply) ProtoMessage() {}
 
-func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[52]
+func (x *WriteOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2932,50 +3494,58 @@ func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeSecretsReply.ProtoReflect.Descriptor instead.
-func (*FinalizeSecretsReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use WriteOutputsReply.ProtoReflect.Descriptor instead.
+func (*WriteOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *FinalizeSecretsReply) GetMessage() string {
+func (x *WriteOutputsReply) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *FinalizeSecretsReply) GetNotFound() bool {
+func (x *WriteOutputsReply) GetChanged() bool {
 	if x != nil {
-		return x.NotFound
+		return x.Changed
 	}
 	return false
 }
 
-type ForceUnlockRequest struct {
+func (x *WriteOutputsReply) GetTruncatedOutputs() []string {
+	if x != nil {
+		return x.TruncatedOutputs
+	}
+	return nil
+}
+
+type GetOutputsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LockIdentifier string `protobuf:"bytes,1,opt,name=lockIdentifier,proto3" json:"lockIdentifier,omitempty"`
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SecretName string `protobuf:"bytes,2,opt,name=secretName,proto3" json:"secretName,omitempty"`
 }
 
-func (x *ForceUnlockRequest) Reset() {
-	*x = ForceUnlockRequest{}
+func (x *GetOutputsRequest) Reset() {
+	*x = GetOutputsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[53]
+		mi := &file_runner_runner_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForceUnlockRequest) String() string {
+func (x *GetOutputsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForceUnlockRequest) ProtoMessage() {}
+func (*GetOutputsRequest) ProtoMessage() {}
 
-func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[53]
+func (x *GetOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2986,44 +3556,50 @@ func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForceUnlockRequest.ProtoReflect.Descriptor instead.
-func (*ForceUnlockRequest) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use GetOutputsRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ForceUnlockRequest) GetLockIdentifier() string {
+func (x *GetOutputsRequest) GetNamespace() string {
 	if x != nil {
-		return x.LockIdentifier
+		return x.Namespace
 	}
 	return ""
 }
 
-type ForceUnlockReply struct {
+func (x *GetOutputsRequest) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+type GetOutputsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Outputs map[string]string `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ForceUnlockReply) Reset() {
-	*x = ForceUnlockReply{}
+func (x *GetOutputsReply) Reset() {
+	*x = GetOutputsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runner_runner_proto_msgTypes[54]
+		mi := &file_runner_runner_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForceUnlockReply) String() string {
+func (x *GetOutputsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForceUnlockReply) ProtoMessage() {}
+func (*GetOutputsReply) ProtoMessage() {}
 
-func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runner_runner_proto_msgTypes[54]
+func (x *GetOutputsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3034,600 +3610,2725 @@ func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForceUnlockReply.ProtoReflect.Descriptor instead.
-func (*ForceUnlockReply) Descriptor() ([]byte, []int) {
-	return file_runner_runner_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use GetOutputsReply.ProtoReflect.Descriptor instead.
+func (*GetOutputsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ForceUnlockReply) GetMessage() string {
+func (x *GetOutputsReply) GetOutputs() map[string]string {
 	if x != nil {
-		return x.Message
+		return x.Outputs
 	}
-	return ""
+	return nil
 }
 
-func (x *ForceUnlockReply) GetSuccess() bool {
-	if x != nil {
-		return x.Success
+type InitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Upgrade    bool   `protobuf:"varint,2,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	ForceCopy  bool   `protobuf:"varint,3,opt,name=forceCopy,proto3" json:"forceCopy,omitempty"`
+}
+
+func (x *InitRequest) Reset() {
+	*x = InitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-var File_runner_runner_proto protoreflect.FileDescriptor
+func (x *InitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var file_runner_runner_proto_rawDesc = []byte{
-	0x0a, 0x13, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2f, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x25, 0x0a,
-	0x0f, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x66, 0x69, 0x6c, 0x65, 0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
-	0x68, 0x22, 0x8f, 0x01, 0x0a, 0x13, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f,
-	0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72,
-	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77,
-	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65,
-	0x63, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65,
-	0x63, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f,
-	0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66,
-	0x6f, 0x72, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
-	0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x49, 0x44, 0x22, 0x23, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66,
-	0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x74,
-	0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x6e,
-	0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x1a,
-	0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x27, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x57, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
-	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x74, 0x0a, 0x19, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69,
-	0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72,
-	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x37, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d,
-	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69,
-	0x6e, 0x67, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73,
-	0x22, 0x33, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x75, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
-	0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x4f, 0x0a, 0x15,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
-	0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69,
-	0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a,
-	0x11, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x6c,
-	0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5b, 0x0a, 0x19, 0x57, 0x72, 0x69, 0x74, 0x65,
-	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24,
-	0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x22, 0x33, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63,
-	0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x17, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c,
-	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x22, 0x33, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x50, 0x61, 0x74, 0x68, 0x22, 0x3a, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
-	0x72, 0x22, 0x32, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72,
-	0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72,
-	0x22, 0x31, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12,
-	0x18, 0x0a, 0x07, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x22, 0x71, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63,
-	0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c,
-	0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
-	0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x68, 0x6f,
-	0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e,
-	0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x54,
-	0x0a, 0x16, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61,
-	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x34, 0x0a, 0x14, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e,
-	0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09,
-	0x72, 0x61, 0x77, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x72, 0x61, 0x77, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x11, 0x53,
-	0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2b,
-	0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc3, 0x01, 0x0a, 0x11,
-	0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70,
-	0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70,
-	0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
-	0x20, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61,
-	0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x96,
-	0x01, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x2e, 0x0a,
-	0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x41, 0x70,
-	0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65,
-	0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x58, 0x0a, 0x0a, 0x41, 0x70, 0x70, 0x6c, 0x79,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x22, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x48, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49,
-	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a,
-	0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65,
-	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69,
-	0x65, 0x73, 0x22, 0x53, 0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x4a, 0x0a, 0x0e, 0x44, 0x65, 0x73, 0x74, 0x72,
-	0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
-	0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x22, 0x5a, 0x0a, 0x0c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a,
-	0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22,
-	0x2f, 0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x22, 0x99, 0x01, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x3a, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0c,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74,
-	0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x54, 0x0a, 0x0a,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
-	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73,
-	0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x22, 0xef, 0x01, 0x0a, 0x13, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
-	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x75, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
-	0x12, 0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09, 0x44,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x47, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x51, 0x0a,
-	0x11, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47,
-	0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x65, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x22, 0x57, 0x0a, 0x09, 0x49, 0x6e, 0x69, 0x74, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30,
-	0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
-	0x22, 0x32, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x23, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0x27, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xd0,
-	0x01, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x77,
-	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x68, 0x61, 0x73,
-	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x68, 0x61, 0x73,
-	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x22, 0x4c, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22,
-	0x3c, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c,
-	0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x46, 0x0a,
-	0x10, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0xc1, 0x0e, 0x0a, 0x06, 0x52, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x12, 0x3c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x17, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c,
-	0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48,
-	0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1b,
-	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61,
-	0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72,
-	0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x5a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e,
-	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70,
-	0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72,
-	0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75,
-	0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42,
-	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65,
-	0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61,
-	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x12, 0x20, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56,
-	0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x04, 0x50, 0x6c, 0x61, 0x6e, 0x12,
-	0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x6c,
-	0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x53, 0x68, 0x6f,
-	0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x12, 0x1e, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c,
-	0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x72,
-	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46,
-	0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x61,
-	0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50,
-	0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x4c, 0x6f,
-	0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61,
-	0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x33,
-	0x0a, 0x05, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
-	0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74,
-	0x6f, 0x72, 0x79, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
-	0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76,
-	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a,
-	0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f,
-	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
-	0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x48, 0x0a, 0x0c, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x47, 0x65,
-	0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30,
-	0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
-	0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75,
-	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x45, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
-	0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
-	0x51, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65,
-	0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f,
-	0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x72, 0x75, 0x6e,
-	0x6e, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (*InitRequest) ProtoMessage() {}
+
+func (x *InitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var (
-	file_runner_runner_proto_rawDescOnce sync.Once
-	file_runner_runner_proto_rawDescData = file_runner_runner_proto_rawDesc
-)
+// Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
+func (*InitRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{61}
+}
 
-func file_runner_runner_proto_rawDescGZIP() []byte {
-	file_runner_runner_proto_rawDescOnce.Do(func() {
-		file_runner_runner_proto_rawDescData = protoimpl.X.CompressGZIP(file_runner_runner_proto_rawDescData)
-	})
-	return file_runner_runner_proto_rawDescData
+func (x *InitRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
 }
 
-var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
-var file_runner_runner_proto_goTypes = []interface{}{
-	(*LookPathRequest)(nil),           // 0: runner.LookPathRequest
-	(*LookPathReply)(nil),             // 1: runner.LookPathReply
-	(*NewTerraformRequest)(nil),       // 2: runner.NewTerraformRequest
-	(*NewTerraformReply)(nil),         // 3: runner.NewTerraformReply
-	(*SetEnvRequest)(nil),             // 4: runner.SetEnvRequest
-	(*SetEnvReply)(nil),               // 5: runner.SetEnvReply
-	(*FileMapping)(nil),               // 6: runner.fileMapping
-	(*CreateFileMappingsRequest)(nil), // 7: runner.CreateFileMappingsRequest
-	(*CreateFileMappingsReply)(nil),   // 8: runner.CreateFileMappingsReply
-	(*UploadAndExtractRequest)(nil),   // 9: runner.UploadAndExtractRequest
-	(*UploadAndExtractReply)(nil),     // 10: runner.UploadAndExtractReply
-	(*CleanupDirRequest)(nil),         // 11: runner.CleanupDirRequest
-	(*CleanupDirReply)(nil),           // 12: runner.CleanupDirReply
-	(*WriteBackendConfigRequest)(nil), // 13: runner.WriteBackendConfigRequest
-	(*WriteBackendConfigReply)(nil),   // 14: runner.WriteBackendConfigReply
-	(*ProcessCliConfigRequest)(nil),   // 15: runner.ProcessCliConfigRequest
-	(*ProcessCliConfigReply)(nil),     // 16: runner.ProcessCliConfigReply
-	(*GenerateVarsForTFRequest)(nil),  // 17: runner.GenerateVarsForTFRequest
-	(*GenerateVarsForTFReply)(nil),    // 18: runner.GenerateVarsForTFReply
-	(*GenerateTemplateRequest)(nil),   // 19: runner.GenerateTemplateRequest
-	(*GenerateTemplateReply)(nil),     // 20: runner.GenerateTemplateReply
-	(*PlanRequest)(nil),               // 21: runner.PlanRequest
-	(*PlanReply)(nil),                 // 22: runner.PlanReply
-	(*ShowPlanFileRequest)(nil),       // 23: runner.ShowPlanFileRequest
-	(*ShowPlanFileReply)(nil),         // 24: runner.ShowPlanFileReply
-	(*ShowPlanFileRawRequest)(nil),    // 25: runner.ShowPlanFileRawRequest
-	(*ShowPlanFileRawReply)(nil),      // 26: runner.ShowPlanFileRawReply
-	(*SaveTFPlanRequest)(nil),         // 27: runner.SaveTFPlanRequest
-	(*SaveTFPlanReply)(nil),           // 28: runner.SaveTFPlanReply
-	(*LoadTFPlanRequest)(nil),         // 29: runner.LoadTFPlanRequest
-	(*LoadTFPlanReply)(nil),           // 30: runner.LoadTFPlanReply
-	(*ApplyRequest)(nil),              // 31: runner.ApplyRequest
-	(*ApplyReply)(nil),                // 32: runner.ApplyReply
-	(*GetInventoryRequest)(nil),       // 33: runner.GetInventoryRequest
-	(*GetInventoryReply)(nil),         // 34: runner.GetInventoryReply
-	(*Inventory)(nil),                 // 35: runner.Inventory
-	(*DestroyRequest)(nil),            // 36: runner.DestroyRequest
-	(*DestroyReply)(nil),              // 37: runner.DestroyReply
-	(*OutputRequest)(nil),             // 38: runner.OutputRequest
-	(*OutputReply)(nil),               // 39: runner.OutputReply
-	(*OutputMeta)(nil),                // 40: runner.OutputMeta
-	(*WriteOutputsRequest)(nil),       // 41: runner.WriteOutputsRequest
-	(*WriteOutputsReply)(nil),         // 42: runner.WriteOutputsReply
-	(*GetOutputsRequest)(nil),         // 43: runner.GetOutputsRequest
-	(*GetOutputsReply)(nil),           // 44: runner.GetOutputsReply
-	(*InitRequest)(nil),               // 45: runner.InitRequest
-	(*InitReply)(nil),                 // 46: runner.InitReply
-	(*WorkspaceRequest)(nil),          // 47: runner.WorkspaceRequest
-	(*WorkspaceReply)(nil),            // 48: runner.WorkspaceReply
-	(*UploadRequest)(nil),             // 49: runner.UploadRequest
-	(*UploadReply)(nil),               // 50: runner.UploadReply
-	(*FinalizeSecretsRequest)(nil),    // 51: runner.FinalizeSecretsRequest
-	(*FinalizeSecretsReply)(nil),      // 52: runner.FinalizeSecretsReply
-	(*ForceUnlockRequest)(nil),        // 53: runner.ForceUnlockRequest
-	(*ForceUnlockReply)(nil),          // 54: runner.ForceUnlockReply
-	nil,                               // 55: runner.SetEnvRequest.EnvsEntry
-	nil,                               // 56: runner.OutputReply.OutputsEntry
-	nil,                               // 57: runner.WriteOutputsRequest.DataEntry
-	nil,                               // 58: runner.GetOutputsReply.OutputsEntry
+func (x *InitRequest) GetUpgrade() bool {
+	if x != nil {
+		return x.Upgrade
+	}
+	return false
 }
-var file_runner_runner_proto_depIdxs = []int32{
-	55, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
-	6,  // 1: runner.CreateFileMappingsRequest.fileMappings:type_name -> runner.fileMapping
-	35, // 2: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
-	56, // 3: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
-	57, // 4: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
-	58, // 5: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
-	40, // 6: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
-	0,  // 7: runner.Runner.LookPath:input_type -> runner.LookPathRequest
-	2,  // 8: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
-	4,  // 9: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
-	7,  // 10: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
-	9,  // 11: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
-	11, // 12: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
-	13, // 13: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
-	15, // 14: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
-	17, // 15: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
-	19, // 16: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
-	21, // 17: runner.Runner.Plan:input_type -> runner.PlanRequest
-	25, // 18: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
-	23, // 19: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
-	27, // 20: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
-	29, // 21: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
-	31, // 22: runner.Runner.Apply:input_type -> runner.ApplyRequest
-	33, // 23: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
-	36, // 24: runner.Runner.Destroy:input_type -> runner.DestroyRequest
-	38, // 25: runner.Runner.Output:input_type -> runner.OutputRequest
-	41, // 26: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
-	43, // 27: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
-	45, // 28: runner.Runner.Init:input_type -> runner.InitRequest
-	47, // 29: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
-	49, // 30: runner.Runner.Upload:input_type -> runner.UploadRequest
-	51, // 31: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
-	53, // 32: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
-	1,  // 33: runner.Runner.LookPath:output_type -> runner.LookPathReply
-	3,  // 34: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
-	5,  // 35: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
-	8,  // 36: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
-	10, // 37: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
-	12, // 38: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
-	14, // 39: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
-	16, // 40: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
-	18, // 41: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
-	20, // 42: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
-	22, // 43: runner.Runner.Plan:output_type -> runner.PlanReply
-	26, // 44: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
-	24, // 45: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
-	28, // 46: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
-	30, // 47: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
-	32, // 48: runner.Runner.Apply:output_type -> runner.ApplyReply
-	34, // 49: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
-	37, // 50: runner.Runner.Destroy:output_type -> runner.DestroyReply
-	39, // 51: runner.Runner.Output:output_type -> runner.OutputReply
-	42, // 52: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
-	44, // 53: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
-	46, // 54: runner.Runner.Init:output_type -> runner.InitReply
-	48, // 55: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
-	50, // 56: runner.Runner.Upload:output_type -> runner.UploadReply
-	52, // 57: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
-	54, // 58: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
-	33, // [33:59] is the sub-list for method output_type
-	7,  // [7:33] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+
+func (x *InitRequest) GetForceCopy() bool {
+	if x != nil {
+		return x.ForceCopy
+	}
+	return false
 }
 
-func init() { file_runner_runner_proto_init() }
-func file_runner_runner_proto_init() {
-	if File_runner_runner_proto != nil {
-		return
+type InitReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message             string           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	StateLockIdentifier string           `protobuf:"bytes,2,opt,name=stateLockIdentifier,proto3" json:"stateLockIdentifier,omitempty"`
+	ModuleInterface     *ModuleInterface `protobuf:"bytes,3,opt,name=moduleInterface,proto3" json:"moduleInterface,omitempty"`
+	MissingVariables    []string         `protobuf:"bytes,4,rep,name=missingVariables,proto3" json:"missingVariables,omitempty"`
+	UndeclaredVariables []string         `protobuf:"bytes,5,rep,name=undeclaredVariables,proto3" json:"undeclaredVariables,omitempty"`
+	InvalidValues       string           `protobuf:"bytes,6,opt,name=invalidValues,proto3" json:"invalidValues,omitempty"`
+}
+
+func (x *InitReply) Reset() {
+	*x = InitReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_runner_runner_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookPathRequest); i {
+}
+
+func (x *InitReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitReply) ProtoMessage() {}
+
+func (x *InitReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitReply.ProtoReflect.Descriptor instead.
+func (*InitReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *InitReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InitReply) GetStateLockIdentifier() string {
+	if x != nil {
+		return x.StateLockIdentifier
+	}
+	return ""
+}
+
+func (x *InitReply) GetModuleInterface() *ModuleInterface {
+	if x != nil {
+		return x.ModuleInterface
+	}
+	return nil
+}
+
+func (x *InitReply) GetMissingVariables() []string {
+	if x != nil {
+		return x.MissingVariables
+	}
+	return nil
+}
+
+func (x *InitReply) GetUndeclaredVariables() []string {
+	if x != nil {
+		return x.UndeclaredVariables
+	}
+	return nil
+}
+
+func (x *InitReply) GetInvalidValues() string {
+	if x != nil {
+		return x.InvalidValues
+	}
+	return ""
+}
+
+type ModuleInterface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Variables []*ModuleVariable `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+	Outputs   []*ModuleOutput   `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+}
+
+func (x *ModuleInterface) Reset() {
+	*x = ModuleInterface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModuleInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleInterface) ProtoMessage() {}
+
+func (x *ModuleInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleInterface.ProtoReflect.Descriptor instead.
+func (*ModuleInterface) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ModuleInterface) GetVariables() []*ModuleVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *ModuleInterface) GetOutputs() []*ModuleOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type ModuleVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type        string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Required    bool   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Sensitive   bool   `protobuf:"varint,5,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+}
+
+func (x *ModuleVariable) Reset() {
+	*x = ModuleVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModuleVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleVariable) ProtoMessage() {}
+
+func (x *ModuleVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleVariable.ProtoReflect.Descriptor instead.
+func (*ModuleVariable) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ModuleVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModuleVariable) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ModuleVariable) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ModuleVariable) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ModuleVariable) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+type ModuleOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Sensitive   bool   `protobuf:"varint,3,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+}
+
+func (x *ModuleOutput) Reset() {
+	*x = ModuleOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModuleOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleOutput) ProtoMessage() {}
+
+func (x *ModuleOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleOutput.ProtoReflect.Descriptor instead.
+func (*ModuleOutput) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ModuleOutput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModuleOutput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ModuleOutput) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+type WorkspaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+}
+
+func (x *WorkspaceRequest) Reset() {
+	*x = WorkspaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceRequest) ProtoMessage() {}
+
+func (x *WorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*WorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WorkspaceRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+type WorkspaceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *WorkspaceReply) Reset() {
+	*x = WorkspaceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceReply) ProtoMessage() {}
+
+func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceReply.ProtoReflect.Descriptor instead.
+func (*WorkspaceReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WorkspaceReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UploadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blob []byte `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UploadRequest) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+type UploadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *UploadReply) Reset() {
+	*x = UploadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadReply) ProtoMessage() {}
+
+func (x *UploadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadReply.ProtoReflect.Descriptor instead.
+func (*UploadReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UploadReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FinalizeSecretsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace                string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Workspace                string `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	HasSpecifiedOutputSecret bool   `protobuf:"varint,4,opt,name=hasSpecifiedOutputSecret,proto3" json:"hasSpecifiedOutputSecret,omitempty"`
+	OutputSecretName         string `protobuf:"bytes,5,opt,name=outputSecretName,proto3" json:"outputSecretName,omitempty"`
+	// outputSecretNamespace is the namespace of the output secret, the namespace of the object when empty
+	OutputSecretNamespace string `protobuf:"bytes,6,opt,name=outputSecretNamespace,proto3" json:"outputSecretNamespace,omitempty"`
+	// retainOutputSecret keeps the output secret, removing the owner reference to the object instead of deleting it
+	RetainOutputSecret bool `protobuf:"varint,7,opt,name=retainOutputSecret,proto3" json:"retainOutputSecret,omitempty"`
+	// uuid is the UID of the object, the output secret is finalized only when it was written for it
+	Uuid string `protobuf:"bytes,8,opt,name=uuid,proto3" json:"uuid,omitempty"`
+}
+
+func (x *FinalizeSecretsRequest) Reset() {
+	*x = FinalizeSecretsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalizeSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSecretsRequest) ProtoMessage() {}
+
+func (x *FinalizeSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeSecretsRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *FinalizeSecretsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetHasSpecifiedOutputSecret() bool {
+	if x != nil {
+		return x.HasSpecifiedOutputSecret
+	}
+	return false
+}
+
+func (x *FinalizeSecretsRequest) GetOutputSecretName() string {
+	if x != nil {
+		return x.OutputSecretName
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetOutputSecretNamespace() string {
+	if x != nil {
+		return x.OutputSecretNamespace
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsRequest) GetRetainOutputSecret() bool {
+	if x != nil {
+		return x.RetainOutputSecret
+	}
+	return false
+}
+
+func (x *FinalizeSecretsRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type FinalizeSecretsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message  string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	NotFound bool   `protobuf:"varint,2,opt,name=notFound,proto3" json:"notFound,omitempty"`
+}
+
+func (x *FinalizeSecretsReply) Reset() {
+	*x = FinalizeSecretsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalizeSecretsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSecretsReply) ProtoMessage() {}
+
+func (x *FinalizeSecretsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeSecretsReply.ProtoReflect.Descriptor instead.
+func (*FinalizeSecretsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *FinalizeSecretsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FinalizeSecretsReply) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+type CheckPlanStalenessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+}
+
+func (x *CheckPlanStalenessRequest) Reset() {
+	*x = CheckPlanStalenessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckPlanStalenessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPlanStalenessRequest) ProtoMessage() {}
+
+func (x *CheckPlanStalenessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPlanStalenessRequest.ProtoReflect.Descriptor instead.
+func (*CheckPlanStalenessRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CheckPlanStalenessRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+type CheckPlanStalenessReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stale   bool   `protobuf:"varint,1,opt,name=stale,proto3" json:"stale,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *CheckPlanStalenessReply) Reset() {
+	*x = CheckPlanStalenessReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckPlanStalenessReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPlanStalenessReply) ProtoMessage() {}
+
+func (x *CheckPlanStalenessReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPlanStalenessReply.ProtoReflect.Descriptor instead.
+func (*CheckPlanStalenessReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CheckPlanStalenessReply) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *CheckPlanStalenessReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SnapshotStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SnapshotStateRequest) Reset() {
+	*x = SnapshotStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotStateRequest) ProtoMessage() {}
+
+func (x *SnapshotStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotStateRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotStateRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SnapshotStateRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *SnapshotStateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SnapshotStateReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// empty is true when there is no state yet, and no snapshot is taken
+	Empty    bool   `protobuf:"varint,1,opt,name=empty,proto3" json:"empty,omitempty"`
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Lineage  string `protobuf:"bytes,3,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	Serial   uint64 `protobuf:"varint,4,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (x *SnapshotStateReply) Reset() {
+	*x = SnapshotStateReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotStateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotStateReply) ProtoMessage() {}
+
+func (x *SnapshotStateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotStateReply.ProtoReflect.Descriptor instead.
+func (*SnapshotStateReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SnapshotStateReply) GetEmpty() bool {
+	if x != nil {
+		return x.Empty
+	}
+	return false
+}
+
+func (x *SnapshotStateReply) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SnapshotStateReply) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *SnapshotStateReply) GetSerial() uint64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+type RestoreStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RestoreStateRequest) Reset() {
+	*x = RestoreStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStateRequest) ProtoMessage() {}
+
+func (x *RestoreStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStateRequest.ProtoReflect.Descriptor instead.
+func (*RestoreStateRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RestoreStateRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *RestoreStateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreStateReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lineage string `protobuf:"bytes,1,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	Serial  uint64 `protobuf:"varint,2,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (x *RestoreStateReply) Reset() {
+	*x = RestoreStateReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreStateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStateReply) ProtoMessage() {}
+
+func (x *RestoreStateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStateReply.ProtoReflect.Descriptor instead.
+func (*RestoreStateReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RestoreStateReply) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *RestoreStateReply) GetSerial() uint64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+type DeleteStateSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Ids        []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *DeleteStateSnapshotsRequest) Reset() {
+	*x = DeleteStateSnapshotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteStateSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStateSnapshotsRequest) ProtoMessage() {}
+
+func (x *DeleteStateSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStateSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStateSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *DeleteStateSnapshotsRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *DeleteStateSnapshotsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DeleteStateSnapshotsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Message string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteStateSnapshotsReply) Reset() {
+	*x = DeleteStateSnapshotsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteStateSnapshotsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStateSnapshotsReply) ProtoMessage() {}
+
+func (x *DeleteStateSnapshotsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStateSnapshotsReply.ProtoReflect.Descriptor instead.
+func (*DeleteStateSnapshotsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeleteStateSnapshotsReply) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteStateSnapshotsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SnapshotWorkingDirRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	TmpDir     string `protobuf:"bytes,2,opt,name=tmpDir,proto3" json:"tmpDir,omitempty"`
+	Id         string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SnapshotWorkingDirRequest) Reset() {
+	*x = SnapshotWorkingDirRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotWorkingDirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotWorkingDirRequest) ProtoMessage() {}
+
+func (x *SnapshotWorkingDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotWorkingDirRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotWorkingDirRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *SnapshotWorkingDirRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *SnapshotWorkingDirRequest) GetTmpDir() string {
+	if x != nil {
+		return x.TmpDir
+	}
+	return ""
+}
+
+func (x *SnapshotWorkingDirRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SnapshotWorkingDirReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Size     int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *SnapshotWorkingDirReply) Reset() {
+	*x = SnapshotWorkingDirReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotWorkingDirReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotWorkingDirReply) ProtoMessage() {}
+
+func (x *SnapshotWorkingDirReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotWorkingDirReply.ProtoReflect.Descriptor instead.
+func (*SnapshotWorkingDirReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SnapshotWorkingDirReply) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SnapshotWorkingDirReply) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type DeleteWorkingDirSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TfInstance string   `protobuf:"bytes,1,opt,name=tfInstance,proto3" json:"tfInstance,omitempty"`
+	Ids        []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *DeleteWorkingDirSnapshotsRequest) Reset() {
+	*x = DeleteWorkingDirSnapshotsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWorkingDirSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkingDirSnapshotsRequest) ProtoMessage() {}
+
+func (x *DeleteWorkingDirSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkingDirSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWorkingDirSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DeleteWorkingDirSnapshotsRequest) GetTfInstance() string {
+	if x != nil {
+		return x.TfInstance
+	}
+	return ""
+}
+
+func (x *DeleteWorkingDirSnapshotsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DeleteWorkingDirSnapshotsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Message string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteWorkingDirSnapshotsReply) Reset() {
+	*x = DeleteWorkingDirSnapshotsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWorkingDirSnapshotsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWorkingDirSnapshotsReply) ProtoMessage() {}
+
+func (x *DeleteWorkingDirSnapshotsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWorkingDirSnapshotsReply.ProtoReflect.Descriptor instead.
+func (*DeleteWorkingDirSnapshotsReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *DeleteWorkingDirSnapshotsReply) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteWorkingDirSnapshotsReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ForceUnlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LockIdentifier string `protobuf:"bytes,1,opt,name=lockIdentifier,proto3" json:"lockIdentifier,omitempty"`
+}
+
+func (x *ForceUnlockRequest) Reset() {
+	*x = ForceUnlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceUnlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceUnlockRequest) ProtoMessage() {}
+
+func (x *ForceUnlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceUnlockRequest.ProtoReflect.Descriptor instead.
+func (*ForceUnlockRequest) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ForceUnlockRequest) GetLockIdentifier() string {
+	if x != nil {
+		return x.LockIdentifier
+	}
+	return ""
+}
+
+type ForceUnlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *ForceUnlockReply) Reset() {
+	*x = ForceUnlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runner_runner_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceUnlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceUnlockReply) ProtoMessage() {}
+
+func (x *ForceUnlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_runner_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceUnlockReply.ProtoReflect.Descriptor instead.
+func (*ForceUnlockReply) Descriptor() ([]byte, []int) {
+	return file_runner_runner_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ForceUnlockReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ForceUnlockReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_runner_runner_proto protoreflect.FileDescriptor
+
+var file_runner_runner_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2f, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x25, 0x0a,
+	0x0f, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x2b, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74,
+	0x68, 0x22, 0x33, 0x0a, 0x17, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x65, 0x72, 0x72,
+	0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x22, 0x8f, 0x01, 0x0a, 0x13,
+	0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
+	0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x74, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x44, 0x22, 0x23, 0x0a,
+	0x11, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45,
+	0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x45, 0x6e, 0x76,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x27, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x57, 0x0a, 0x0b, 0x66,
+	0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0x74, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x72, 0x12, 0x37, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x66, 0x69,
+	0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x33, 0x0a, 0x17, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0xf9, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x61, 0x72, 0x47, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x61,
+	0x72, 0x47, 0x7a, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x66, 0x0a, 0x15, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x39, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x4f, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64,
+	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d,
+	0x70, 0x44, 0x69, 0x72, 0x22, 0x2b, 0x0a, 0x11, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44,
+	0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70,
+	0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69,
+	0x72, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8b,
+	0x01, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x74, 0x68, 0x43, 0x44, 0x4b, 0x54, 0x46, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44,
+	0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e,
+	0x67, 0x44, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x31, 0x0a, 0x0f,
+	0x53, 0x79, 0x6e, 0x74, 0x68, 0x43, 0x44, 0x4b, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22,
+	0x9d, 0x01, 0x0a, 0x19, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x22, 0x0a,
+	0x0c, 0x74, 0x79, 0x70, 0x65, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x79, 0x70, 0x65, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22,
+	0x33, 0x0a, 0x17, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43,
+	0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x69, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x15, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68,
+	0x22, 0x3a, 0x0a, 0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73,
+	0x46, 0x6f, 0x72, 0x54, 0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x32, 0x0a, 0x16,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54,
+	0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x39, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77,
+	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x31, 0x0a, 0x15, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa7,
+	0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x75, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x73,
+	0x74, 0x72, 0x6f, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x22, 0x37, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x5e, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x22, 0x6a, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3d, 0x0a,
+	0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x71, 0x0a, 0x09,
+	0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x69,
+	0x66, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x72, 0x69, 0x66,
+	0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a,
+	0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22,
+	0x51, 0x0a, 0x13, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6a, 0x73, 0x6f,
+	0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x53, 0x68, 0x6f, 0x77, 0x50,
+	0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x34, 0x0a,
+	0x14, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x77, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x61, 0x77, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x22, 0x90, 0x01, 0x0a, 0x0c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x64, 0x6f, 0x74, 0x22, 0x53, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0xbf, 0x01, 0x0a, 0x17,
+	0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x1a,
+	0x3c, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe7, 0x02,
+	0x0a, 0x11, 0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x50, 0x6c, 0x61, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74,
+	0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x6c, 0x61, 0x6e, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c, 0x61,
+	0x6e, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61,
+	0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x61, 0x64,
+	0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x50,
+	0x6c, 0x61, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xf7, 0x01, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65,
+	0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x75, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x64, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73,
+	0x75, 0x70, 0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x22, 0x0a,
+	0x0c, 0x61, 0x64, 0x64, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x15, 0x72,
+	0x65, 0x61, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x72, 0x65, 0x61, 0x64,
+	0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0xc3, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x6c, 0x79, 0x44, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x50, 0x6c, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x22, 0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x54,
+	0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50, 0x6c,
+	0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x4f, 0x72, 0x50,
+	0x6c, 0x61, 0x6e, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x7a, 0x0a,
+	0x0a, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f,
+	0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x22, 0x39, 0x0a, 0x17, 0x47, 0x65, 0x74,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x4b, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x48, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a,
+	0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x76, 0x65,
+	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x52, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x78, 0x0a, 0x10, 0x4b, 0x75,
+	0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4a, 0x0a, 0x0e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73,
+	0x22, 0x7c, 0x0a, 0x0c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f,
+	0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x22, 0x2f,
+	0x0a, 0x0d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0x99, 0x01, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x3a, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0c, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74, 0x61,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x54, 0x0a, 0x0a, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0xcb, 0x02, 0x0a, 0x13, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x39, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61,
+	0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x73, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x75, 0x6e,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x73, 0x22, 0x51, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x70, 0x79, 0x22, 0x9e,
+	0x02, 0x0a, 0x09, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c,
+	0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0f, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x75, 0x6e, 0x64, 0x65, 0x63,
+	0x6c, 0x61, 0x72, 0x65, 0x64, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x75, 0x6e, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22,
+	0x77, 0x0a, 0x0f, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x0e, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x22,
+	0x62, 0x0a, 0x0c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x69, 0x76, 0x65, 0x22, 0x32, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x2a, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x22, 0x27, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xca, 0x02, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x18,
+	0x68, 0x61, 0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18,
+	0x68, 0x61, 0x73, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x15, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x15, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65,
+	0x74, 0x61, 0x69, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x4c,
+	0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x3b, 0x0a, 0x19,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x49, 0x0a, 0x17, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x46, 0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x78, 0x0a, 0x12,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x45, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x45, 0x0a,
+	0x11, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x22, 0x4f, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x4f, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x19, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6d, 0x70, 0x44, 0x69, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x49, 0x0a, 0x17, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69,
+	0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x54, 0x0a, 0x20, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x66,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x74, 0x66, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x54, 0x0a, 0x1e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x3c, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x22, 0x46, 0x0a, 0x10, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0xf8, 0x16, 0x0a, 0x06, 0x52, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x08, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x54, 0x0a, 0x10, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x65, 0x72, 0x72,
+	0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x54, 0x65,
+	0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x4e, 0x65, 0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x65,
+	0x77, 0x54, 0x65, 0x72, 0x72, 0x61, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x15, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x45,
+	0x6e, 0x76, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
+	0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x16, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x41, 0x6e, 0x64, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x44, 0x69, 0x72, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43,
+	0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75,
+	0x70, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x53,
+	0x79, 0x6e, 0x74, 0x68, 0x43, 0x44, 0x4b, 0x54, 0x46, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x53, 0x79, 0x6e, 0x74, 0x68, 0x43, 0x44, 0x4b, 0x54, 0x46, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x79,
+	0x6e, 0x74, 0x68, 0x43, 0x44, 0x4b, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x5a, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x43, 0x6c, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x57, 0x0a, 0x11, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72,
+	0x73, 0x46, 0x6f, 0x72, 0x54, 0x46, 0x12, 0x20, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f, 0x72, 0x54,
+	0x46, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x56, 0x61, 0x72, 0x73, 0x46, 0x6f,
+	0x72, 0x54, 0x46, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1f,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x36, 0x0a, 0x06, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x04, 0x50, 0x6c, 0x61, 0x6e,
+	0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x50,
+	0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x53, 0x68,
+	0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x12, 0x1e, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a,
+	0x0c, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x6c, 0x61, 0x6e, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x12, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x53, 0x61, 0x76, 0x65,
+	0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54,
+	0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a,
+	0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4c,
+	0x6f, 0x61, 0x64, 0x54, 0x46, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x5a, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61,
+	0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x6c,
+	0x65, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0d,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e, 0x6e,
+	0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x12, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x21, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x6f, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x69,
+	0x6e, 0x67, 0x44, 0x69, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x28,
+	0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44,
+	0x69, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x12, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74,
+	0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x15, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74,
+	0x12, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x49,
+	0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0f, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x2e,
+	0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x36, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72,
+	0x75, 0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x75,
+	0x6e, 0x6e, 0x65, 0x72, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72,
+	0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_runner_runner_proto_rawDescOnce sync.Once
+	file_runner_runner_proto_rawDescData = file_runner_runner_proto_rawDesc
+)
+
+func file_runner_runner_proto_rawDescGZIP() []byte {
+	file_runner_runner_proto_rawDescOnce.Do(func() {
+		file_runner_runner_proto_rawDescData = protoimpl.X.CompressGZIP(file_runner_runner_proto_rawDescData)
+	})
+	return file_runner_runner_proto_rawDescData
+}
+
+var file_runner_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 91)
+var file_runner_runner_proto_goTypes = []interface{}{
+	(*LookPathRequest)(nil),                  // 0: runner.LookPathRequest
+	(*LookPathReply)(nil),                    // 1: runner.LookPathReply
+	(*InstallTerraformRequest)(nil),          // 2: runner.InstallTerraformRequest
+	(*InstallTerraformReply)(nil),            // 3: runner.InstallTerraformReply
+	(*NewTerraformRequest)(nil),              // 4: runner.NewTerraformRequest
+	(*NewTerraformReply)(nil),                // 5: runner.NewTerraformReply
+	(*SetEnvRequest)(nil),                    // 6: runner.SetEnvRequest
+	(*SetEnvReply)(nil),                      // 7: runner.SetEnvReply
+	(*FileMapping)(nil),                      // 8: runner.fileMapping
+	(*CreateFileMappingsRequest)(nil),        // 9: runner.CreateFileMappingsRequest
+	(*CreateFileMappingsReply)(nil),          // 10: runner.CreateFileMappingsReply
+	(*UploadAndExtractRequest)(nil),          // 11: runner.UploadAndExtractRequest
+	(*UploadAndExtractChunk)(nil),            // 12: runner.UploadAndExtractChunk
+	(*UploadAndExtractReply)(nil),            // 13: runner.UploadAndExtractReply
+	(*CleanupDirRequest)(nil),                // 14: runner.CleanupDirRequest
+	(*CleanupDirReply)(nil),                  // 15: runner.CleanupDirReply
+	(*SynthCDKTFRequest)(nil),                // 16: runner.SynthCDKTFRequest
+	(*SynthCDKTFReply)(nil),                  // 17: runner.SynthCDKTFReply
+	(*WriteBackendConfigRequest)(nil),        // 18: runner.WriteBackendConfigRequest
+	(*WriteBackendConfigReply)(nil),          // 19: runner.WriteBackendConfigReply
+	(*ProcessCliConfigRequest)(nil),          // 20: runner.ProcessCliConfigRequest
+	(*ProcessCliConfigReply)(nil),            // 21: runner.ProcessCliConfigReply
+	(*GenerateVarsForTFRequest)(nil),         // 22: runner.GenerateVarsForTFRequest
+	(*GenerateVarsForTFReply)(nil),           // 23: runner.GenerateVarsForTFReply
+	(*GenerateTemplateRequest)(nil),          // 24: runner.GenerateTemplateRequest
+	(*GenerateTemplateReply)(nil),            // 25: runner.GenerateTemplateReply
+	(*PlanRequest)(nil),                      // 26: runner.PlanRequest
+	(*ImportEntry)(nil),                      // 27: runner.ImportEntry
+	(*ImportRequest)(nil),                    // 28: runner.ImportRequest
+	(*ImportResult)(nil),                     // 29: runner.ImportResult
+	(*ImportReply)(nil),                      // 30: runner.ImportReply
+	(*PlanReply)(nil),                        // 31: runner.PlanReply
+	(*ShowPlanFileRequest)(nil),              // 32: runner.ShowPlanFileRequest
+	(*ShowPlanFileReply)(nil),                // 33: runner.ShowPlanFileReply
+	(*ShowPlanFileRawRequest)(nil),           // 34: runner.ShowPlanFileRawRequest
+	(*ShowPlanFileRawReply)(nil),             // 35: runner.ShowPlanFileRawReply
+	(*GraphRequest)(nil),                     // 36: runner.GraphRequest
+	(*GraphReply)(nil),                       // 37: runner.GraphReply
+	(*GetProviderSchemasRequest)(nil),        // 38: runner.GetProviderSchemasRequest
+	(*GetProviderSchemasReply)(nil),          // 39: runner.GetProviderSchemasReply
+	(*SaveTFPlanRequest)(nil),                // 40: runner.SaveTFPlanRequest
+	(*SaveTFPlanReply)(nil),                  // 41: runner.SaveTFPlanReply
+	(*LoadTFPlanRequest)(nil),                // 42: runner.LoadTFPlanRequest
+	(*LoadTFPlanReply)(nil),                  // 43: runner.LoadTFPlanReply
+	(*ApplyRequest)(nil),                     // 44: runner.ApplyRequest
+	(*ApplyReply)(nil),                       // 45: runner.ApplyReply
+	(*GetApplyProgressRequest)(nil),          // 46: runner.GetApplyProgressRequest
+	(*GetApplyProgressReply)(nil),            // 47: runner.GetApplyProgressReply
+	(*GetInventoryRequest)(nil),              // 48: runner.GetInventoryRequest
+	(*GetInventoryReply)(nil),                // 49: runner.GetInventoryReply
+	(*Inventory)(nil),                        // 50: runner.Inventory
+	(*KubernetesObject)(nil),                 // 51: runner.KubernetesObject
+	(*DestroyRequest)(nil),                   // 52: runner.DestroyRequest
+	(*DestroyReply)(nil),                     // 53: runner.DestroyReply
+	(*OutputRequest)(nil),                    // 54: runner.OutputRequest
+	(*OutputReply)(nil),                      // 55: runner.OutputReply
+	(*OutputMeta)(nil),                       // 56: runner.OutputMeta
+	(*WriteOutputsRequest)(nil),              // 57: runner.WriteOutputsRequest
+	(*WriteOutputsReply)(nil),                // 58: runner.WriteOutputsReply
+	(*GetOutputsRequest)(nil),                // 59: runner.GetOutputsRequest
+	(*GetOutputsReply)(nil),                  // 60: runner.GetOutputsReply
+	(*InitRequest)(nil),                      // 61: runner.InitRequest
+	(*InitReply)(nil),                        // 62: runner.InitReply
+	(*ModuleInterface)(nil),                  // 63: runner.ModuleInterface
+	(*ModuleVariable)(nil),                   // 64: runner.ModuleVariable
+	(*ModuleOutput)(nil),                     // 65: runner.ModuleOutput
+	(*WorkspaceRequest)(nil),                 // 66: runner.WorkspaceRequest
+	(*WorkspaceReply)(nil),                   // 67: runner.WorkspaceReply
+	(*UploadRequest)(nil),                    // 68: runner.UploadRequest
+	(*UploadReply)(nil),                      // 69: runner.UploadReply
+	(*FinalizeSecretsRequest)(nil),           // 70: runner.FinalizeSecretsRequest
+	(*FinalizeSecretsReply)(nil),             // 71: runner.FinalizeSecretsReply
+	(*CheckPlanStalenessRequest)(nil),        // 72: runner.CheckPlanStalenessRequest
+	(*CheckPlanStalenessReply)(nil),          // 73: runner.CheckPlanStalenessReply
+	(*SnapshotStateRequest)(nil),             // 74: runner.SnapshotStateRequest
+	(*SnapshotStateReply)(nil),               // 75: runner.SnapshotStateReply
+	(*RestoreStateRequest)(nil),              // 76: runner.RestoreStateRequest
+	(*RestoreStateReply)(nil),                // 77: runner.RestoreStateReply
+	(*DeleteStateSnapshotsRequest)(nil),      // 78: runner.DeleteStateSnapshotsRequest
+	(*DeleteStateSnapshotsReply)(nil),        // 79: runner.DeleteStateSnapshotsReply
+	(*SnapshotWorkingDirRequest)(nil),        // 80: runner.SnapshotWorkingDirRequest
+	(*SnapshotWorkingDirReply)(nil),          // 81: runner.SnapshotWorkingDirReply
+	(*DeleteWorkingDirSnapshotsRequest)(nil), // 82: runner.DeleteWorkingDirSnapshotsRequest
+	(*DeleteWorkingDirSnapshotsReply)(nil),   // 83: runner.DeleteWorkingDirSnapshotsReply
+	(*ForceUnlockRequest)(nil),               // 84: runner.ForceUnlockRequest
+	(*ForceUnlockReply)(nil),                 // 85: runner.ForceUnlockReply
+	nil,                                      // 86: runner.SetEnvRequest.EnvsEntry
+	nil,                                      // 87: runner.GetProviderSchemasReply.ProvidersEntry
+	nil,                                      // 88: runner.OutputReply.OutputsEntry
+	nil,                                      // 89: runner.WriteOutputsRequest.DataEntry
+	nil,                                      // 90: runner.GetOutputsReply.OutputsEntry
+}
+var file_runner_runner_proto_depIdxs = []int32{
+	86, // 0: runner.SetEnvRequest.envs:type_name -> runner.SetEnvRequest.EnvsEntry
+	8,  // 1: runner.CreateFileMappingsRequest.fileMappings:type_name -> runner.fileMapping
+	11, // 2: runner.UploadAndExtractChunk.request:type_name -> runner.UploadAndExtractRequest
+	27, // 3: runner.ImportRequest.imports:type_name -> runner.ImportEntry
+	29, // 4: runner.ImportReply.results:type_name -> runner.ImportResult
+	87, // 5: runner.GetProviderSchemasReply.providers:type_name -> runner.GetProviderSchemasReply.ProvidersEntry
+	50, // 6: runner.GetInventoryReply.inventories:type_name -> runner.Inventory
+	51, // 7: runner.Inventory.object:type_name -> runner.KubernetesObject
+	88, // 8: runner.OutputReply.outputs:type_name -> runner.OutputReply.OutputsEntry
+	89, // 9: runner.WriteOutputsRequest.data:type_name -> runner.WriteOutputsRequest.DataEntry
+	90, // 10: runner.GetOutputsReply.outputs:type_name -> runner.GetOutputsReply.OutputsEntry
+	63, // 11: runner.InitReply.moduleInterface:type_name -> runner.ModuleInterface
+	64, // 12: runner.ModuleInterface.variables:type_name -> runner.ModuleVariable
+	65, // 13: runner.ModuleInterface.outputs:type_name -> runner.ModuleOutput
+	56, // 14: runner.OutputReply.OutputsEntry.value:type_name -> runner.OutputMeta
+	0,  // 15: runner.Runner.LookPath:input_type -> runner.LookPathRequest
+	2,  // 16: runner.Runner.InstallTerraform:input_type -> runner.InstallTerraformRequest
+	4,  // 17: runner.Runner.NewTerraform:input_type -> runner.NewTerraformRequest
+	6,  // 18: runner.Runner.SetEnv:input_type -> runner.SetEnvRequest
+	9,  // 19: runner.Runner.CreateFileMappings:input_type -> runner.CreateFileMappingsRequest
+	11, // 20: runner.Runner.UploadAndExtract:input_type -> runner.UploadAndExtractRequest
+	12, // 21: runner.Runner.UploadAndExtractStream:input_type -> runner.UploadAndExtractChunk
+	14, // 22: runner.Runner.CleanupDir:input_type -> runner.CleanupDirRequest
+	16, // 23: runner.Runner.SynthCDKTF:input_type -> runner.SynthCDKTFRequest
+	18, // 24: runner.Runner.WriteBackendConfig:input_type -> runner.WriteBackendConfigRequest
+	20, // 25: runner.Runner.ProcessCliConfig:input_type -> runner.ProcessCliConfigRequest
+	22, // 26: runner.Runner.GenerateVarsForTF:input_type -> runner.GenerateVarsForTFRequest
+	24, // 27: runner.Runner.GenerateTemplate:input_type -> runner.GenerateTemplateRequest
+	28, // 28: runner.Runner.Import:input_type -> runner.ImportRequest
+	26, // 29: runner.Runner.Plan:input_type -> runner.PlanRequest
+	34, // 30: runner.Runner.ShowPlanFileRaw:input_type -> runner.ShowPlanFileRawRequest
+	32, // 31: runner.Runner.ShowPlanFile:input_type -> runner.ShowPlanFileRequest
+	36, // 32: runner.Runner.Graph:input_type -> runner.GraphRequest
+	38, // 33: runner.Runner.GetProviderSchemas:input_type -> runner.GetProviderSchemasRequest
+	40, // 34: runner.Runner.SaveTFPlan:input_type -> runner.SaveTFPlanRequest
+	42, // 35: runner.Runner.LoadTFPlan:input_type -> runner.LoadTFPlanRequest
+	72, // 36: runner.Runner.CheckPlanStaleness:input_type -> runner.CheckPlanStalenessRequest
+	74, // 37: runner.Runner.SnapshotState:input_type -> runner.SnapshotStateRequest
+	76, // 38: runner.Runner.RestoreState:input_type -> runner.RestoreStateRequest
+	78, // 39: runner.Runner.DeleteStateSnapshots:input_type -> runner.DeleteStateSnapshotsRequest
+	80, // 40: runner.Runner.SnapshotWorkingDir:input_type -> runner.SnapshotWorkingDirRequest
+	82, // 41: runner.Runner.DeleteWorkingDirSnapshots:input_type -> runner.DeleteWorkingDirSnapshotsRequest
+	44, // 42: runner.Runner.Apply:input_type -> runner.ApplyRequest
+	46, // 43: runner.Runner.GetApplyProgress:input_type -> runner.GetApplyProgressRequest
+	48, // 44: runner.Runner.GetInventory:input_type -> runner.GetInventoryRequest
+	52, // 45: runner.Runner.Destroy:input_type -> runner.DestroyRequest
+	54, // 46: runner.Runner.Output:input_type -> runner.OutputRequest
+	57, // 47: runner.Runner.WriteOutputs:input_type -> runner.WriteOutputsRequest
+	59, // 48: runner.Runner.GetOutputs:input_type -> runner.GetOutputsRequest
+	61, // 49: runner.Runner.Init:input_type -> runner.InitRequest
+	66, // 50: runner.Runner.SelectWorkspace:input_type -> runner.WorkspaceRequest
+	68, // 51: runner.Runner.Upload:input_type -> runner.UploadRequest
+	70, // 52: runner.Runner.FinalizeSecrets:input_type -> runner.FinalizeSecretsRequest
+	84, // 53: runner.Runner.ForceUnlock:input_type -> runner.ForceUnlockRequest
+	1,  // 54: runner.Runner.LookPath:output_type -> runner.LookPathReply
+	3,  // 55: runner.Runner.InstallTerraform:output_type -> runner.InstallTerraformReply
+	5,  // 56: runner.Runner.NewTerraform:output_type -> runner.NewTerraformReply
+	7,  // 57: runner.Runner.SetEnv:output_type -> runner.SetEnvReply
+	10, // 58: runner.Runner.CreateFileMappings:output_type -> runner.CreateFileMappingsReply
+	13, // 59: runner.Runner.UploadAndExtract:output_type -> runner.UploadAndExtractReply
+	13, // 60: runner.Runner.UploadAndExtractStream:output_type -> runner.UploadAndExtractReply
+	15, // 61: runner.Runner.CleanupDir:output_type -> runner.CleanupDirReply
+	17, // 62: runner.Runner.SynthCDKTF:output_type -> runner.SynthCDKTFReply
+	19, // 63: runner.Runner.WriteBackendConfig:output_type -> runner.WriteBackendConfigReply
+	21, // 64: runner.Runner.ProcessCliConfig:output_type -> runner.ProcessCliConfigReply
+	23, // 65: runner.Runner.GenerateVarsForTF:output_type -> runner.GenerateVarsForTFReply
+	25, // 66: runner.Runner.GenerateTemplate:output_type -> runner.GenerateTemplateReply
+	30, // 67: runner.Runner.Import:output_type -> runner.ImportReply
+	31, // 68: runner.Runner.Plan:output_type -> runner.PlanReply
+	35, // 69: runner.Runner.ShowPlanFileRaw:output_type -> runner.ShowPlanFileRawReply
+	33, // 70: runner.Runner.ShowPlanFile:output_type -> runner.ShowPlanFileReply
+	37, // 71: runner.Runner.Graph:output_type -> runner.GraphReply
+	39, // 72: runner.Runner.GetProviderSchemas:output_type -> runner.GetProviderSchemasReply
+	41, // 73: runner.Runner.SaveTFPlan:output_type -> runner.SaveTFPlanReply
+	43, // 74: runner.Runner.LoadTFPlan:output_type -> runner.LoadTFPlanReply
+	73, // 75: runner.Runner.CheckPlanStaleness:output_type -> runner.CheckPlanStalenessReply
+	75, // 76: runner.Runner.SnapshotState:output_type -> runner.SnapshotStateReply
+	77, // 77: runner.Runner.RestoreState:output_type -> runner.RestoreStateReply
+	79, // 78: runner.Runner.DeleteStateSnapshots:output_type -> runner.DeleteStateSnapshotsReply
+	81, // 79: runner.Runner.SnapshotWorkingDir:output_type -> runner.SnapshotWorkingDirReply
+	83, // 80: runner.Runner.DeleteWorkingDirSnapshots:output_type -> runner.DeleteWorkingDirSnapshotsReply
+	45, // 81: runner.Runner.Apply:output_type -> runner.ApplyReply
+	47, // 82: runner.Runner.GetApplyProgress:output_type -> runner.GetApplyProgressReply
+	49, // 83: runner.Runner.GetInventory:output_type -> runner.GetInventoryReply
+	53, // 84: runner.Runner.Destroy:output_type -> runner.DestroyReply
+	55, // 85: runner.Runner.Output:output_type -> runner.OutputReply
+	58, // 86: runner.Runner.WriteOutputs:output_type -> runner.WriteOutputsReply
+	60, // 87: runner.Runner.GetOutputs:output_type -> runner.GetOutputsReply
+	62, // 88: runner.Runner.Init:output_type -> runner.InitReply
+	67, // 89: runner.Runner.SelectWorkspace:output_type -> runner.WorkspaceReply
+	69, // 90: runner.Runner.Upload:output_type -> runner.UploadReply
+	71, // 91: runner.Runner.FinalizeSecrets:output_type -> runner.FinalizeSecretsReply
+	85, // 92: runner.Runner.ForceUnlock:output_type -> runner.ForceUnlockReply
+	54, // [54:93] is the sub-list for method output_type
+	15, // [15:54] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_runner_runner_proto_init() }
+func file_runner_runner_proto_init() {
+	if File_runner_runner_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_runner_runner_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookPathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LookPathReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallTerraformRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallTerraformReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewTerraformRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewTerraformReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetEnvRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetEnvReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateFileMappingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateFileMappingsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAndExtractRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAndExtractChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAndExtractReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CleanupDirRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CleanupDirReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynthCDKTFRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynthCDKTFReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteBackendConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteBackendConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessCliConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessCliConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateVarsForTFRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateVarsForTFReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3638,8 +6339,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LookPathReply); i {
+		file_runner_runner_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTemplateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3650,8 +6351,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewTerraformRequest); i {
+		file_runner_runner_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTemplateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3662,8 +6363,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewTerraformReply); i {
+		file_runner_runner_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3674,8 +6375,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetEnvRequest); i {
+		file_runner_runner_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3686,8 +6387,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetEnvReply); i {
+		file_runner_runner_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3698,8 +6399,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileMapping); i {
+		file_runner_runner_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3710,8 +6411,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateFileMappingsRequest); i {
+		file_runner_runner_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3722,8 +6423,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateFileMappingsReply); i {
+		file_runner_runner_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3734,8 +6435,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAndExtractRequest); i {
+		file_runner_runner_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowPlanFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3746,8 +6447,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAndExtractReply); i {
+		file_runner_runner_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowPlanFileReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3758,8 +6459,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupDirRequest); i {
+		file_runner_runner_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowPlanFileRawRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3770,8 +6471,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupDirReply); i {
+		file_runner_runner_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowPlanFileRawReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3782,8 +6483,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBackendConfigRequest); i {
+		file_runner_runner_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3794,8 +6495,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBackendConfigReply); i {
+		file_runner_runner_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3806,8 +6507,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessCliConfigRequest); i {
+		file_runner_runner_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProviderSchemasRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3818,8 +6519,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessCliConfigReply); i {
+		file_runner_runner_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProviderSchemasReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3830,8 +6531,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateVarsForTFRequest); i {
+		file_runner_runner_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveTFPlanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3842,8 +6543,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateVarsForTFReply); i {
+		file_runner_runner_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveTFPlanReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3854,8 +6555,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateTemplateRequest); i {
+		file_runner_runner_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadTFPlanRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3866,8 +6567,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateTemplateReply); i {
+		file_runner_runner_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadTFPlanReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3878,8 +6579,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlanRequest); i {
+		file_runner_runner_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3890,8 +6591,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlanReply); i {
+		file_runner_runner_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3902,8 +6603,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShowPlanFileRequest); i {
+		file_runner_runner_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetApplyProgressRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3914,8 +6615,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShowPlanFileReply); i {
+		file_runner_runner_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetApplyProgressReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3926,8 +6627,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShowPlanFileRawRequest); i {
+		file_runner_runner_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInventoryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3938,8 +6639,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ShowPlanFileRawReply); i {
+		file_runner_runner_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInventoryReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3950,8 +6651,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveTFPlanRequest); i {
+		file_runner_runner_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Inventory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3962,8 +6663,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveTFPlanReply); i {
+		file_runner_runner_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubernetesObject); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3974,8 +6675,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadTFPlanRequest); i {
+		file_runner_runner_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3986,8 +6687,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadTFPlanReply); i {
+		file_runner_runner_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3998,8 +6699,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyRequest); i {
+		file_runner_runner_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4010,8 +6711,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyReply); i {
+		file_runner_runner_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4022,8 +6723,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetInventoryRequest); i {
+		file_runner_runner_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputMeta); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4034,8 +6735,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetInventoryReply); i {
+		file_runner_runner_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteOutputsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4046,8 +6747,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Inventory); i {
+		file_runner_runner_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteOutputsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4058,8 +6759,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyRequest); i {
+		file_runner_runner_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOutputsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4070,8 +6771,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyReply); i {
+		file_runner_runner_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOutputsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4082,8 +6783,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutputRequest); i {
+		file_runner_runner_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4094,8 +6795,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutputReply); i {
+		file_runner_runner_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4106,8 +6807,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OutputMeta); i {
+		file_runner_runner_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModuleInterface); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4118,8 +6819,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteOutputsRequest); i {
+		file_runner_runner_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModuleVariable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4130,8 +6831,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteOutputsReply); i {
+		file_runner_runner_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModuleOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4142,8 +6843,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOutputsRequest); i {
+		file_runner_runner_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4154,8 +6855,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOutputsReply); i {
+		file_runner_runner_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4166,8 +6867,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitRequest); i {
+		file_runner_runner_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4178,8 +6879,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitReply); i {
+		file_runner_runner_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4190,8 +6891,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WorkspaceRequest); i {
+		file_runner_runner_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalizeSecretsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4202,8 +6903,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WorkspaceReply); i {
+		file_runner_runner_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalizeSecretsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4214,8 +6915,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadRequest); i {
+		file_runner_runner_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckPlanStalenessRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4226,8 +6927,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadReply); i {
+		file_runner_runner_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckPlanStalenessReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4238,8 +6939,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinalizeSecretsRequest); i {
+		file_runner_runner_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotStateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4250,8 +6951,8 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FinalizeSecretsReply); i {
+		file_runner_runner_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotStateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4262,7 +6963,103 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreStateReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteStateSnapshotsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteStateSnapshotsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotWorkingDirRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotWorkingDirReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWorkingDirSnapshotsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWorkingDirSnapshotsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runner_runner_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForceUnlockRequest); i {
 			case 0:
 				return &v.state
@@ -4274,7 +7071,7 @@ func file_runner_runner_proto_init() {
 				return nil
 			}
 		}
-		file_runner_runner_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+		file_runner_runner_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForceUnlockReply); i {
 			case 0:
 				return &v.state
@@ -4293,7 +7090,7 @@ func file_runner_runner_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_runner_runner_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   59,
+			NumMessages:   91,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,21 +23,34 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RunnerClient interface {
 	LookPath(ctx context.Context, in *LookPathRequest, opts ...grpc.CallOption) (*LookPathReply, error)
+	InstallTerraform(ctx context.Context, in *InstallTerraformRequest, opts ...grpc.CallOption) (*InstallTerraformReply, error)
 	NewTerraform(ctx context.Context, in *NewTerraformRequest, opts ...grpc.CallOption) (*NewTerraformReply, error)
 	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*SetEnvReply, error)
 	CreateFileMappings(ctx context.Context, in *CreateFileMappingsRequest, opts ...grpc.CallOption) (*CreateFileMappingsReply, error)
 	UploadAndExtract(ctx context.Context, in *UploadAndExtractRequest, opts ...grpc.CallOption) (*UploadAndExtractReply, error)
+	UploadAndExtractStream(ctx context.Context, opts ...grpc.CallOption) (Runner_UploadAndExtractStreamClient, error)
 	CleanupDir(ctx context.Context, in *CleanupDirRequest, opts ...grpc.CallOption) (*CleanupDirReply, error)
+	SynthCDKTF(ctx context.Context, in *SynthCDKTFRequest, opts ...grpc.CallOption) (*SynthCDKTFReply, error)
 	WriteBackendConfig(ctx context.Context, in *WriteBackendConfigRequest, opts ...grpc.CallOption) (*WriteBackendConfigReply, error)
 	ProcessCliConfig(ctx context.Context, in *ProcessCliConfigRequest, opts ...grpc.CallOption) (*ProcessCliConfigReply, error)
 	GenerateVarsForTF(ctx context.Context, in *GenerateVarsForTFRequest, opts ...grpc.CallOption) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(ctx context.Context, in *GenerateTemplateRequest, opts ...grpc.CallOption) (*GenerateTemplateReply, error)
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportReply, error)
 	Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error)
 	ShowPlanFileRaw(ctx context.Context, in *ShowPlanFileRawRequest, opts ...grpc.CallOption) (*ShowPlanFileRawReply, error)
 	ShowPlanFile(ctx context.Context, in *ShowPlanFileRequest, opts ...grpc.CallOption) (*ShowPlanFileReply, error)
+	Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*GraphReply, error)
+	GetProviderSchemas(ctx context.Context, in *GetProviderSchemasRequest, opts ...grpc.CallOption) (*GetProviderSchemasReply, error)
 	SaveTFPlan(ctx context.Context, in *SaveTFPlanRequest, opts ...grpc.CallOption) (*SaveTFPlanReply, error)
 	LoadTFPlan(ctx context.Context, in *LoadTFPlanRequest, opts ...grpc.CallOption) (*LoadTFPlanReply, error)
+	CheckPlanStaleness(ctx context.Context, in *CheckPlanStalenessRequest, opts ...grpc.CallOption) (*CheckPlanStalenessReply, error)
+	SnapshotState(ctx context.Context, in *SnapshotStateRequest, opts ...grpc.CallOption) (*SnapshotStateReply, error)
+	RestoreState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*RestoreStateReply, error)
+	DeleteStateSnapshots(ctx context.Context, in *DeleteStateSnapshotsRequest, opts ...grpc.CallOption) (*DeleteStateSnapshotsReply, error)
+	SnapshotWorkingDir(ctx context.Context, in *SnapshotWorkingDirRequest, opts ...grpc.CallOption) (*SnapshotWorkingDirReply, error)
+	DeleteWorkingDirSnapshots(ctx context.Context, in *DeleteWorkingDirSnapshotsRequest, opts ...grpc.CallOption) (*DeleteWorkingDirSnapshotsReply, error)
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyReply, error)
+	GetApplyProgress(ctx context.Context, in *GetApplyProgressRequest, opts ...grpc.CallOption) (*GetApplyProgressReply, error)
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryReply, error)
 	Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyReply, error)
 	Output(ctx context.Context, in *OutputRequest, opts ...grpc.CallOption) (*OutputReply, error)
@@ -67,6 +80,15 @@ func (c *runnerClient) LookPath(ctx context.Context, in *LookPathRequest, opts .
 	return out, nil
 }
 
+func (c *runnerClient) InstallTerraform(ctx context.Context, in *InstallTerraformRequest, opts ...grpc.CallOption) (*InstallTerraformReply, error) {
+	out := new(InstallTerraformReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/InstallTerraform", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) NewTerraform(ctx context.Context, in *NewTerraformRequest, opts ...grpc.CallOption) (*NewTerraformReply, error) {
 	out := new(NewTerraformReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/NewTerraform", in, out, opts...)
@@ -103,6 +125,40 @@ func (c *runnerClient) UploadAndExtract(ctx context.Context, in *UploadAndExtrac
 	return out, nil
 }
 
+func (c *runnerClient) UploadAndExtractStream(ctx context.Context, opts ...grpc.CallOption) (Runner_UploadAndExtractStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runner_ServiceDesc.Streams[0], "/runner.Runner/UploadAndExtractStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runnerUploadAndExtractStreamClient{stream}
+	return x, nil
+}
+
+type Runner_UploadAndExtractStreamClient interface {
+	Send(*UploadAndExtractChunk) error
+	CloseAndRecv() (*UploadAndExtractReply, error)
+	grpc.ClientStream
+}
+
+type runnerUploadAndExtractStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *runnerUploadAndExtractStreamClient) Send(m *UploadAndExtractChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *runnerUploadAndExtractStreamClient) CloseAndRecv() (*UploadAndExtractReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadAndExtractReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *runnerClient) CleanupDir(ctx context.Context, in *CleanupDirRequest, opts ...grpc.CallOption) (*CleanupDirReply, error) {
 	out := new(CleanupDirReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/CleanupDir", in, out, opts...)
@@ -112,6 +168,15 @@ func (c *runnerClient) CleanupDir(ctx context.Context, in *CleanupDirRequest, op
 	return out, nil
 }
 
+func (c *runnerClient) SynthCDKTF(ctx context.Context, in *SynthCDKTFRequest, opts ...grpc.CallOption) (*SynthCDKTFReply, error) {
+	out := new(SynthCDKTFReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/SynthCDKTF", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) WriteBackendConfig(ctx context.Context, in *WriteBackendConfigRequest, opts ...grpc.CallOption) (*WriteBackendConfigReply, error) {
 	out := new(WriteBackendConfigReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/WriteBackendConfig", in, out, opts...)
@@ -148,6 +213,15 @@ func (c *runnerClient) GenerateTemplate(ctx context.Context, in *GenerateTemplat
 	return out, nil
 }
 
+func (c *runnerClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportReply, error) {
+	out := new(ImportReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) Plan(ctx context.Context, in *PlanRequest, opts ...grpc.CallOption) (*PlanReply, error) {
 	out := new(PlanReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/Plan", in, out, opts...)
@@ -175,6 +249,24 @@ func (c *runnerClient) ShowPlanFile(ctx context.Context, in *ShowPlanFileRequest
 	return out, nil
 }
 
+func (c *runnerClient) Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*GraphReply, error) {
+	out := new(GraphReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/Graph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) GetProviderSchemas(ctx context.Context, in *GetProviderSchemasRequest, opts ...grpc.CallOption) (*GetProviderSchemasReply, error) {
+	out := new(GetProviderSchemasReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/GetProviderSchemas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) SaveTFPlan(ctx context.Context, in *SaveTFPlanRequest, opts ...grpc.CallOption) (*SaveTFPlanReply, error) {
 	out := new(SaveTFPlanReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/SaveTFPlan", in, out, opts...)
@@ -193,6 +285,60 @@ func (c *runnerClient) LoadTFPlan(ctx context.Context, in *LoadTFPlanRequest, op
 	return out, nil
 }
 
+func (c *runnerClient) CheckPlanStaleness(ctx context.Context, in *CheckPlanStalenessRequest, opts ...grpc.CallOption) (*CheckPlanStalenessReply, error) {
+	out := new(CheckPlanStalenessReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/CheckPlanStaleness", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) SnapshotState(ctx context.Context, in *SnapshotStateRequest, opts ...grpc.CallOption) (*SnapshotStateReply, error) {
+	out := new(SnapshotStateReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/SnapshotState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) RestoreState(ctx context.Context, in *RestoreStateRequest, opts ...grpc.CallOption) (*RestoreStateReply, error) {
+	out := new(RestoreStateReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/RestoreState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) DeleteStateSnapshots(ctx context.Context, in *DeleteStateSnapshotsRequest, opts ...grpc.CallOption) (*DeleteStateSnapshotsReply, error) {
+	out := new(DeleteStateSnapshotsReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/DeleteStateSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) SnapshotWorkingDir(ctx context.Context, in *SnapshotWorkingDirRequest, opts ...grpc.CallOption) (*SnapshotWorkingDirReply, error) {
+	out := new(SnapshotWorkingDirReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/SnapshotWorkingDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runnerClient) DeleteWorkingDirSnapshots(ctx context.Context, in *DeleteWorkingDirSnapshotsRequest, opts ...grpc.CallOption) (*DeleteWorkingDirSnapshotsReply, error) {
+	out := new(DeleteWorkingDirSnapshotsReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/DeleteWorkingDirSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyReply, error) {
 	out := new(ApplyReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/Apply", in, out, opts...)
@@ -202,6 +348,15 @@ func (c *runnerClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *runnerClient) GetApplyProgress(ctx context.Context, in *GetApplyProgressRequest, opts ...grpc.CallOption) (*GetApplyProgressReply, error) {
+	out := new(GetApplyProgressReply)
+	err := c.cc.Invoke(ctx, "/runner.Runner/GetApplyProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runnerClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryReply, error) {
 	out := new(GetInventoryReply)
 	err := c.cc.Invoke(ctx, "/runner.Runner/GetInventory", in, out, opts...)
@@ -297,21 +452,34 @@ func (c *runnerClient) ForceUnlock(ctx context.Context, in *ForceUnlockRequest,
 // for forward compatibility
 type RunnerServer interface {
 	LookPath(context.Context, *LookPathRequest) (*LookPathReply, error)
+	InstallTerraform(context.Context, *InstallTerraformRequest) (*InstallTerraformReply, error)
 	NewTerraform(context.Context, *NewTerraformRequest) (*NewTerraformReply, error)
 	SetEnv(context.Context, *SetEnvRequest) (*SetEnvReply, error)
 	CreateFileMappings(context.Context, *CreateFileMappingsRequest) (*CreateFileMappingsReply, error)
 	UploadAndExtract(context.Context, *UploadAndExtractRequest) (*UploadAndExtractReply, error)
+	UploadAndExtractStream(Runner_UploadAndExtractStreamServer) error
 	CleanupDir(context.Context, *CleanupDirRequest) (*CleanupDirReply, error)
+	SynthCDKTF(context.Context, *SynthCDKTFRequest) (*SynthCDKTFReply, error)
 	WriteBackendConfig(context.Context, *WriteBackendConfigRequest) (*WriteBackendConfigReply, error)
 	ProcessCliConfig(context.Context, *ProcessCliConfigRequest) (*ProcessCliConfigReply, error)
 	GenerateVarsForTF(context.Context, *GenerateVarsForTFRequest) (*GenerateVarsForTFReply, error)
 	GenerateTemplate(context.Context, *GenerateTemplateRequest) (*GenerateTemplateReply, error)
+	Import(context.Context, *ImportRequest) (*ImportReply, error)
 	Plan(context.Context, *PlanRequest) (*PlanReply, error)
 	ShowPlanFileRaw(context.Context, *ShowPlanFileRawRequest) (*ShowPlanFileRawReply, error)
 	ShowPlanFile(context.Context, *ShowPlanFileRequest) (*ShowPlanFileReply, error)
+	Graph(context.Context, *GraphRequest) (*GraphReply, error)
+	GetProviderSchemas(context.Context, *GetProviderSchemasRequest) (*GetProviderSchemasReply, error)
 	SaveTFPlan(context.Context, *SaveTFPlanRequest) (*SaveTFPlanReply, error)
 	LoadTFPlan(context.Context, *LoadTFPlanRequest) (*LoadTFPlanReply, error)
+	CheckPlanStaleness(context.Context, *CheckPlanStalenessRequest) (*CheckPlanStalenessReply, error)
+	SnapshotState(context.Context, *SnapshotStateRequest) (*SnapshotStateReply, error)
+	RestoreState(context.Context, *RestoreStateRequest) (*RestoreStateReply, error)
+	DeleteStateSnapshots(context.Context, *DeleteStateSnapshotsRequest) (*DeleteStateSnapshotsReply, error)
+	SnapshotWorkingDir(context.Context, *SnapshotWorkingDirRequest) (*SnapshotWorkingDirReply, error)
+	DeleteWorkingDirSnapshots(context.Context, *DeleteWorkingDirSnapshotsRequest) (*DeleteWorkingDirSnapshotsReply, error)
 	Apply(context.Context, *ApplyRequest) (*ApplyReply, error)
+	GetApplyProgress(context.Context, *GetApplyProgressRequest) (*GetApplyProgressReply, error)
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error)
 	Destroy(context.Context, *DestroyRequest) (*DestroyReply, error)
 	Output(context.Context, *OutputRequest) (*OutputReply, error)
@@ -332,6 +500,9 @@ type UnimplementedRunnerServer struct {
 func (UnimplementedRunnerServer) LookPath(context.Context, *LookPathRequest) (*LookPathReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LookPath not implemented")
 }
+func (UnimplementedRunnerServer) InstallTerraform(context.Context, *InstallTerraformRequest) (*InstallTerraformReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallTerraform not implemented")
+}
 func (UnimplementedRunnerServer) NewTerraform(context.Context, *NewTerraformRequest) (*NewTerraformReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewTerraform not implemented")
 }
@@ -344,9 +515,15 @@ func (UnimplementedRunnerServer) CreateFileMappings(context.Context, *CreateFile
 func (UnimplementedRunnerServer) UploadAndExtract(context.Context, *UploadAndExtractRequest) (*UploadAndExtractReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadAndExtract not implemented")
 }
+func (UnimplementedRunnerServer) UploadAndExtractStream(Runner_UploadAndExtractStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAndExtractStream not implemented")
+}
 func (UnimplementedRunnerServer) CleanupDir(context.Context, *CleanupDirRequest) (*CleanupDirReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanupDir not implemented")
 }
+func (UnimplementedRunnerServer) SynthCDKTF(context.Context, *SynthCDKTFRequest) (*SynthCDKTFReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SynthCDKTF not implemented")
+}
 func (UnimplementedRunnerServer) WriteBackendConfig(context.Context, *WriteBackendConfigRequest) (*WriteBackendConfigReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteBackendConfig not implemented")
 }
@@ -359,6 +536,9 @@ func (UnimplementedRunnerServer) GenerateVarsForTF(context.Context, *GenerateVar
 func (UnimplementedRunnerServer) GenerateTemplate(context.Context, *GenerateTemplateRequest) (*GenerateTemplateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateTemplate not implemented")
 }
+func (UnimplementedRunnerServer) Import(context.Context, *ImportRequest) (*ImportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedRunnerServer) Plan(context.Context, *PlanRequest) (*PlanReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Plan not implemented")
 }
@@ -368,15 +548,42 @@ func (UnimplementedRunnerServer) ShowPlanFileRaw(context.Context, *ShowPlanFileR
 func (UnimplementedRunnerServer) ShowPlanFile(context.Context, *ShowPlanFileRequest) (*ShowPlanFileReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShowPlanFile not implemented")
 }
+func (UnimplementedRunnerServer) Graph(context.Context, *GraphRequest) (*GraphReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Graph not implemented")
+}
+func (UnimplementedRunnerServer) GetProviderSchemas(context.Context, *GetProviderSchemasRequest) (*GetProviderSchemasReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviderSchemas not implemented")
+}
 func (UnimplementedRunnerServer) SaveTFPlan(context.Context, *SaveTFPlanRequest) (*SaveTFPlanReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveTFPlan not implemented")
 }
 func (UnimplementedRunnerServer) LoadTFPlan(context.Context, *LoadTFPlanRequest) (*LoadTFPlanReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadTFPlan not implemented")
 }
+func (UnimplementedRunnerServer) CheckPlanStaleness(context.Context, *CheckPlanStalenessRequest) (*CheckPlanStalenessReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPlanStaleness not implemented")
+}
+func (UnimplementedRunnerServer) SnapshotState(context.Context, *SnapshotStateRequest) (*SnapshotStateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotState not implemented")
+}
+func (UnimplementedRunnerServer) RestoreState(context.Context, *RestoreStateRequest) (*RestoreStateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreState not implemented")
+}
+func (UnimplementedRunnerServer) DeleteStateSnapshots(context.Context, *DeleteStateSnapshotsRequest) (*DeleteStateSnapshotsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStateSnapshots not implemented")
+}
+func (UnimplementedRunnerServer) SnapshotWorkingDir(context.Context, *SnapshotWorkingDirRequest) (*SnapshotWorkingDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotWorkingDir not implemented")
+}
+func (UnimplementedRunnerServer) DeleteWorkingDirSnapshots(context.Context, *DeleteWorkingDirSnapshotsRequest) (*DeleteWorkingDirSnapshotsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkingDirSnapshots not implemented")
+}
 func (UnimplementedRunnerServer) Apply(context.Context, *ApplyRequest) (*ApplyReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
 }
+func (UnimplementedRunnerServer) GetApplyProgress(context.Context, *GetApplyProgressRequest) (*GetApplyProgressReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplyProgress not implemented")
+}
 func (UnimplementedRunnerServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
 }
@@ -438,6 +645,24 @@ func _Runner_LookPath_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_InstallTerraform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallTerraformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).InstallTerraform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/InstallTerraform",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).InstallTerraform(ctx, req.(*InstallTerraformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_NewTerraform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewTerraformRequest)
 	if err := dec(in); err != nil {
@@ -510,6 +735,32 @@ func _Runner_UploadAndExtract_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_UploadAndExtractStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RunnerServer).UploadAndExtractStream(&runnerUploadAndExtractStreamServer{stream})
+}
+
+type Runner_UploadAndExtractStreamServer interface {
+	SendAndClose(*UploadAndExtractReply) error
+	Recv() (*UploadAndExtractChunk, error)
+	grpc.ServerStream
+}
+
+type runnerUploadAndExtractStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *runnerUploadAndExtractStreamServer) SendAndClose(m *UploadAndExtractReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *runnerUploadAndExtractStreamServer) Recv() (*UploadAndExtractChunk, error) {
+	m := new(UploadAndExtractChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Runner_CleanupDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CleanupDirRequest)
 	if err := dec(in); err != nil {
@@ -528,6 +779,24 @@ func _Runner_CleanupDir_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_SynthCDKTF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynthCDKTFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).SynthCDKTF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/SynthCDKTF",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).SynthCDKTF(ctx, req.(*SynthCDKTFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_WriteBackendConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WriteBackendConfigRequest)
 	if err := dec(in); err != nil {
@@ -600,6 +869,24 @@ func _Runner_GenerateTemplate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_Plan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlanRequest)
 	if err := dec(in); err != nil {
@@ -654,6 +941,42 @@ func _Runner_ShowPlanFile_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_Graph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).Graph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/Graph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).Graph(ctx, req.(*GraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_GetProviderSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).GetProviderSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/GetProviderSchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).GetProviderSchemas(ctx, req.(*GetProviderSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_SaveTFPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SaveTFPlanRequest)
 	if err := dec(in); err != nil {
@@ -690,6 +1013,114 @@ func _Runner_LoadTFPlan_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_CheckPlanStaleness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPlanStalenessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).CheckPlanStaleness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/CheckPlanStaleness",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).CheckPlanStaleness(ctx, req.(*CheckPlanStalenessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_SnapshotState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).SnapshotState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/SnapshotState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).SnapshotState(ctx, req.(*SnapshotStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_RestoreState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).RestoreState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/RestoreState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).RestoreState(ctx, req.(*RestoreStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_DeleteStateSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStateSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).DeleteStateSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/DeleteStateSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).DeleteStateSnapshots(ctx, req.(*DeleteStateSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_SnapshotWorkingDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotWorkingDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).SnapshotWorkingDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/SnapshotWorkingDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).SnapshotWorkingDir(ctx, req.(*SnapshotWorkingDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Runner_DeleteWorkingDirSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkingDirSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).DeleteWorkingDirSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/DeleteWorkingDirSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).DeleteWorkingDirSnapshots(ctx, req.(*DeleteWorkingDirSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApplyRequest)
 	if err := dec(in); err != nil {
@@ -708,6 +1139,24 @@ func _Runner_Apply_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Runner_GetApplyProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApplyProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunnerServer).GetApplyProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runner.Runner/GetApplyProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunnerServer).GetApplyProgress(ctx, req.(*GetApplyProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Runner_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInventoryRequest)
 	if err := dec(in); err != nil {
@@ -899,6 +1348,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LookPath",
 			Handler:    _Runner_LookPath_Handler,
 		},
+		{
+			MethodName: "InstallTerraform",
+			Handler:    _Runner_InstallTerraform_Handler,
+		},
 		{
 			MethodName: "NewTerraform",
 			Handler:    _Runner_NewTerraform_Handler,
@@ -919,6 +1372,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanupDir",
 			Handler:    _Runner_CleanupDir_Handler,
 		},
+		{
+			MethodName: "SynthCDKTF",
+			Handler:    _Runner_SynthCDKTF_Handler,
+		},
 		{
 			MethodName: "WriteBackendConfig",
 			Handler:    _Runner_WriteBackendConfig_Handler,
@@ -935,6 +1392,10 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateTemplate",
 			Handler:    _Runner_GenerateTemplate_Handler,
 		},
+		{
+			MethodName: "Import",
+			Handler:    _Runner_Import_Handler,
+		},
 		{
 			MethodName: "Plan",
 			Handler:    _Runner_Plan_Handler,
@@ -947,6 +1408,14 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShowPlanFile",
 			Handler:    _Runner_ShowPlanFile_Handler,
 		},
+		{
+			MethodName: "Graph",
+			Handler:    _Runner_Graph_Handler,
+		},
+		{
+			MethodName: "GetProviderSchemas",
+			Handler:    _Runner_GetProviderSchemas_Handler,
+		},
 		{
 			MethodName: "SaveTFPlan",
 			Handler:    _Runner_SaveTFPlan_Handler,
@@ -955,10 +1424,38 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoadTFPlan",
 			Handler:    _Runner_LoadTFPlan_Handler,
 		},
+		{
+			MethodName: "CheckPlanStaleness",
+			Handler:    _Runner_CheckPlanStaleness_Handler,
+		},
+		{
+			MethodName: "SnapshotState",
+			Handler:    _Runner_SnapshotState_Handler,
+		},
+		{
+			MethodName: "RestoreState",
+			Handler:    _Runner_RestoreState_Handler,
+		},
+		{
+			MethodName: "DeleteStateSnapshots",
+			Handler:    _Runner_DeleteStateSnapshots_Handler,
+		},
+		{
+			MethodName: "SnapshotWorkingDir",
+			Handler:    _Runner_SnapshotWorkingDir_Handler,
+		},
+		{
+			MethodName: "DeleteWorkingDirSnapshots",
+			Handler:    _Runner_DeleteWorkingDirSnapshots_Handler,
+		},
 		{
 			MethodName: "Apply",
 			Handler:    _Runner_Apply_Handler,
 		},
+		{
+			MethodName: "GetApplyProgress",
+			Handler:    _Runner_GetApplyProgress_Handler,
+		},
 		{
 			MethodName: "GetInventory",
 			Handler:    _Runner_GetInventory_Handler,
@@ -1000,6 +1497,12 @@ var Runner_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Runner_ForceUnlock_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAndExtractStream",
+			Handler:       _Runner_UploadAndExtractStream_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "runner/runner.proto",
 }
@@ -6,17 +6,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	securejoin "github.com/cyphar/filepath-securejoin"
-	"github.com/fluxcd/pkg/untar"
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/terraform-exec/tfexec"
 	tfjson "github.com/hashicorp/terraform-json"
@@ -32,11 +34,19 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	TFPlanName                            = "tfplan"
+	TFPlanChangesKey                      = "tfplan.changes"
+	TFGraphName                           = "tfgraph"
+	GraphRevisionAnnotation               = "infra.contrib.fluxcd.io/graph-revision"
 	SavedPlanSecretAnnotation             = "savedPlan"
+	ContentHashAnnotation                 = "infra.contrib.fluxcd.io/content-hash"
+	TruncatedAnnotation                   = "infra.contrib.fluxcd.io/truncated"
+	TruncatedOutputsAnnotation            = "infra.contrib.fluxcd.io/truncated-outputs"
+	OwnerUIDAnnotation                    = "infra.contrib.fluxcd.io/owner-uid"
 	runnerFileMappingLocationHome         = "home"
 	runnerFileMappingLocationWorkspace    = "workspace"
 	runnerFileMappingDirectoryPermissions = 0700
@@ -54,12 +64,19 @@ func (l LocalPrintfer) Printf(format string, v ...interface{}) {
 
 type TerraformRunnerServer struct {
 	UnimplementedRunnerServer
-	tf *tfexec.Terraform
+	tf Executor
 	client.Client
-	Scheme     *runtime.Scheme
-	Done       chan os.Signal
-	terraform  *infrav1.Terraform
-	InstanceID string
+	Scheme *runtime.Scheme
+	// NewExecutor creates the Executor of each Terraform object, NewTerraformExecutor when nil.
+	NewExecutor func(workingDir string, execPath string) (Executor, error)
+	Done        chan os.Signal
+	terraform   *infrav1.Terraform
+	InstanceID  string
+	envs        map[string]string
+	stdout      io.Writer
+
+	progressMu sync.Mutex
+	progress   *utils.ApplyProgress
 }
 
 const loggerName = "runner.terraform"
@@ -76,6 +93,17 @@ func (r *TerraformRunnerServer) LookPath(ctx context.Context, req *LookPathReque
 }
 
 func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *UploadAndExtractRequest) (*UploadAndExtractReply, error) {
+	return r.extractArtifact(ctx, req, func() (io.ReadCloser, error) {
+		if req.Url != "" {
+			return fetchArtifact(ctx, req.Url, req.Checksum, spoolLimit(req))
+		}
+		return io.NopCloser(bytes.NewReader(req.TarGz)), nil
+	})
+}
+
+// extractArtifact extracts the artifact read from open in the working directory of the request.
+// open is not called when a persistent working directory has the artifact extracted already.
+func (r *TerraformRunnerServer) extractArtifact(ctx context.Context, req *UploadAndExtractRequest, open func() (io.ReadCloser, error)) (*UploadAndExtractReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("preparing for Upload and Extraction")
 	tmpDir, err := securejoin.SecureJoin(os.TempDir(), fmt.Sprintf("%s-%s", req.Namespace, req.Name))
@@ -84,18 +112,48 @@ func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *Uploa
 		return nil, err
 	}
 
-	buf := bytes.NewBuffer(req.TarGz)
-	if _, err = untar.Untar(buf, tmpDir); err != nil {
-		log.Error(err, "unable to extract tar file", "namespace", req.Namespace, "name", req.Name)
-		return nil, fmt.Errorf("failed to untar artifact, error: %w", err)
-	}
-
 	dirPath, err := securejoin.SecureJoin(tmpDir, req.Path)
 	if err != nil {
 		log.Error(err, "unable to join securely", "tmpDir", tmpDir, "path", req.Path)
 		return nil, err
 	}
 
+	// a persistent working directory is extracted again only when the artifact changes,
+	// and keeps its .terraform directory with the providers and the modules installed by init
+	extracted := false
+	checksumPath := filepath.Join(tmpDir, extractedChecksumFile)
+	if req.Persistent && req.Checksum != "" {
+		if checksum, err := os.ReadFile(checksumPath); err == nil && string(checksum) == req.Checksum {
+			log.Info("artifact already extracted in the persistent working directory", "checksum", req.Checksum)
+			extracted = true
+		} else if err := removeAllExcept(tmpDir, filepath.Join(dirPath, ".terraform")); err != nil {
+			log.Error(err, "unable to clean up the persistent working directory", "tmpDir", tmpDir)
+			return nil, err
+		}
+	}
+
+	if !extracted {
+		artifact, err := open()
+		if err != nil {
+			log.Error(err, "unable to receive the artifact", "namespace", req.Namespace, "name", req.Name)
+			return nil, err
+		}
+		defer artifact.Close()
+
+		limits := utils.UntarLimits{MaxFiles: req.MaxFiles, MaxSize: req.MaxSize}
+		if err = utils.Untar(artifact, tmpDir, limits); err != nil {
+			log.Error(err, "unable to extract tar file", "namespace", req.Namespace, "name", req.Name)
+			return nil, fmt.Errorf("failed to untar artifact, error: %w", err)
+		}
+
+		if req.Persistent && req.Checksum != "" {
+			if err := os.WriteFile(checksumPath, []byte(req.Checksum), 0644); err != nil {
+				log.Error(err, "unable to record the checksum of the extracted artifact", "tmpDir", tmpDir)
+				return nil, err
+			}
+		}
+	}
+
 	if _, err := os.Stat(dirPath); err != nil {
 		log.Error(err, "path not found", "dirPath", dirPath)
 		err = fmt.Errorf("terraform path not found: %w", err)
@@ -105,6 +163,37 @@ func (r *TerraformRunnerServer) UploadAndExtract(ctx context.Context, req *Uploa
 	return &UploadAndExtractReply{WorkingDir: dirPath, TmpDir: tmpDir}, nil
 }
 
+// extractedChecksumFile records the checksum of the artifact extracted in a persistent working directory.
+const extractedChecksumFile = ".tf-controller-checksum"
+
+// removeAllExcept removes the content of the dir, except the keep path and its parents.
+func removeAllExcept(dir, keep string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if path == keep {
+			continue
+		}
+		if entry.IsDir() && strings.HasPrefix(keep, path+string(filepath.Separator)) {
+			if err := removeAllExcept(path, keep); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *TerraformRunnerServer) CleanupDir(ctx context.Context, req *CleanupDirRequest) (*CleanupDirReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("cleanup TmpDir", "tmpDir", req.TmpDir)
@@ -127,8 +216,23 @@ func (r *TerraformRunnerServer) WriteBackendConfig(ctx context.Context, req *Wri
 		return nil, err
 	}
 
+	backendConfig := req.BackendConfig
+	if len(req.TypedBackend) > 0 {
+		var typedBackend infrav1.BackendConfigSpec
+		if err := json.Unmarshal(req.TypedBackend, &typedBackend); err != nil {
+			log.Error(err, "unable to unmarshal the typed backend")
+			return nil, err
+		}
+		backendConfig, err = r.generateTypedBackend(ctx, req.Namespace, typedBackend)
+		if err != nil {
+			log.Error(err, "unable to generate the typed backend", "type", typedBackend.Type)
+			return nil, err
+		}
+	}
+
 	log.Info("write config to file", "filePath", filePath)
-	err = os.WriteFile(filePath, req.BackendConfig, 0644)
+	// the backend may hold the credentials of the state
+	err = os.WriteFile(filePath, backendConfig, 0600)
 	if err != nil {
 		log.Error(err, "unable to write file", "filePath", filePath)
 		return nil, err
@@ -180,7 +284,11 @@ func (r *TerraformRunnerServer) NewTerraform(ctx context.Context, req *NewTerraf
 	r.InstanceID = req.GetInstanceID()
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("creating new terraform", "workingDir", req.WorkingDir, "execPath", req.ExecPath)
-	tf, err := tfexec.NewTerraform(req.WorkingDir, req.ExecPath)
+	newExecutor := r.NewExecutor
+	if newExecutor == nil {
+		newExecutor = NewTerraformExecutor
+	}
+	tf, err := newExecutor(req.WorkingDir, req.ExecPath)
 	if err != nil {
 		log.Error(err, "unable to create new terraform", "workingDir", req.WorkingDir, "execPath", req.ExecPath)
 		return nil, err
@@ -197,12 +305,15 @@ func (r *TerraformRunnerServer) NewTerraform(ctx context.Context, req *NewTerraf
 	// cache the Terraform resource when initializing
 	r.terraform = &terraform
 
+	r.stdout = ioutil.Discard
 	disableTestLogging := os.Getenv("DISABLE_TF_LOGS") == "1"
 	if !disableTestLogging {
+		r.stdout = os.Stdout
 		r.tf.SetStdout(os.Stdout)
 		r.tf.SetStderr(os.Stderr)
-		if os.Getenv("ENABLE_SENSITIVE_TF_LOGS") == "1" {
-			r.tf.SetLogger(&LocalPrintfer{logger: log})
+		// the logger is specific to tfexec
+		if tfexecTerraform, ok := r.tf.(*tfexec.Terraform); ok && os.Getenv("ENABLE_SENSITIVE_TF_LOGS") == "1" {
+			tfexecTerraform.SetLogger(&LocalPrintfer{logger: log})
 		}
 	}
 
@@ -229,6 +340,7 @@ func (r *TerraformRunnerServer) SetEnv(ctx context.Context, req *SetEnvRequest)
 		log.Error(err, "unable to set envvars", "envvars", envs)
 		return nil, err
 	}
+	r.envs = envs
 
 	return &SetEnvReply{Message: "ok"}, nil
 }
@@ -345,6 +457,14 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 
 	initOpts := []tfexec.InitOption{tfexec.Upgrade(req.Upgrade), tfexec.ForceCopy(req.ForceCopy)}
 	initOpts = append(initOpts, backendConfigsOpts...)
+
+	extraArgs, err := r.extraArgs(StageInit)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	initOpts = append(initOpts, extraArgs.initOptions()...)
+
 	if err := r.tf.Init(ctx, initOpts...); err != nil {
 		st := status.New(codes.Internal, err.Error())
 		var stateErr *tfexec.ErrStateLocked
@@ -361,7 +481,86 @@ func (r *TerraformRunnerServer) Init(ctx context.Context, req *InitRequest) (*In
 		return nil, st.Err()
 	}
 
-	return &InitReply{Message: "ok"}, nil
+	reply := &InitReply{Message: "ok"}
+	// the interface is informational, and a module that cannot be parsed fails the plan anyway
+	if mi, err := utils.LoadModuleInterface(r.tf.WorkingDir()); err != nil {
+		log.Error(err, "unable to parse the module interface")
+	} else {
+		reply.ModuleInterface = getModuleInterfaceReply(mi)
+
+		envs := r.envs
+		if envs == nil {
+			envs = utils.EnvMap(os.Environ())
+		}
+		provided, err := utils.ProvidedVariables(r.tf.WorkingDir(), envs)
+		if err != nil {
+			log.Error(err, "unable to read the provided variables")
+		} else {
+			reply.MissingVariables = mi.MissingVariables(provided)
+		}
+
+		generated, err := generatedVariables(r.tf.WorkingDir())
+		if err != nil {
+			log.Error(err, "unable to read the generated variables")
+		} else {
+			supplied := make([]string, 0, len(generated))
+			for name := range generated {
+				supplied = append(supplied, name)
+			}
+			reply.UndeclaredVariables = mi.UndeclaredVariables(supplied)
+
+			if values, ok := generated["values"]; ok {
+				for _, v := range mi.Variables {
+					if v.Name != "values" {
+						continue
+					}
+					if err := utils.ValidateVariableValue(v.Type, values); err != nil {
+						reply.InvalidValues = err.Error()
+					}
+				}
+			}
+		}
+	}
+
+	return reply, nil
+}
+
+// generatedVariables returns the variables given by the object, as written by GenerateVarsForTF.
+func generatedVariables(workingDir string) (map[string]json.RawMessage, error) {
+	jsonBytes, err := ioutil.ReadFile(filepath.Join(workingDir, "generated.auto.tfvars.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	vars := map[string]json.RawMessage{}
+	if err := json.Unmarshal(jsonBytes, &vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+func getModuleInterfaceReply(mi *utils.ModuleInterface) *ModuleInterface {
+	result := &ModuleInterface{}
+	for _, v := range mi.Variables {
+		result.Variables = append(result.Variables, &ModuleVariable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Required:    v.Required,
+			Sensitive:   v.Sensitive,
+		})
+	}
+	for _, o := range mi.Outputs {
+		result.Outputs = append(result.Outputs, &ModuleOutput{
+			Name:        o.Name,
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+	return result
 }
 
 func (r *TerraformRunnerServer) SelectWorkspace(ctx context.Context, req *WorkspaceRequest) (*WorkspaceReply, error) {
@@ -377,7 +576,7 @@ func (r *TerraformRunnerServer) SelectWorkspace(ctx context.Context, req *Worksp
 
 	if terraform.WorkspaceName() != infrav1.DefaultWorkspaceName {
 		wsOpts := []tfexec.WorkspaceNewCmdOption{}
-		ws := terraform.Spec.Workspace
+		ws := terraform.WorkspaceName()
 		if err := r.tf.WorkspaceNew(ctx, ws, wsOpts...); err != nil {
 			log.Info(fmt.Sprintf("workspace new:, %s", err.Error()))
 		}
@@ -398,9 +597,34 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 	log.Info("setting up the input variables")
 
 	// use from the cached object
-	terraform := *r.terraform
+	vars, _, err := r.InputVariables(ctx, *r.terraform)
+	if err != nil {
+		return nil, err
+	}
 
+	jsonBytes, err := json.Marshal(vars)
+	if err != nil {
+		log.Error(err, "unable to marshal the data")
+		return nil, err
+	}
+
+	varFilePath := filepath.Join(req.WorkingDir, "generated.auto.tfvars.json")
+	if err := os.WriteFile(varFilePath, jsonBytes, 0644); err != nil {
+		err = fmt.Errorf("error generating var file: %s", err)
+		log.Error(err, "unable to write the data to file", "filePath", varFilePath)
+		return nil, err
+	}
+
+	return &GenerateVarsForTFReply{Message: "ok"}, nil
+}
+
+// InputVariables returns the input variables of the object: spec.values rendered with the inputs read from Secrets,
+// spec.vars, and the variables of spec.varsFrom overriding them. The variables whose value is read from a Secret
+// are sensitive.
+func (r *TerraformRunnerServer) InputVariables(ctx context.Context, terraform infrav1.Terraform) (map[string]*apiextensionsv1.JSON, map[string]bool, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	vars := map[string]*apiextensionsv1.JSON{}
+	sensitive := map[string]bool{}
 
 	inputs := map[string]interface{}{}
 	if len(terraform.Spec.ReadInputsFromSecrets) > 0 {
@@ -409,7 +633,7 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 			err := r.Get(ctx, types.NamespacedName{Namespace: terraform.Namespace, Name: readSpec.Name}, &secret)
 			if err != nil {
 				log.Error(err, "unable to get secret", "secret", readSpec.Name)
-				return nil, err
+				return nil, nil, err
 			}
 
 			// outputs are always strings
@@ -430,22 +654,33 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 			Parse(string(terraform.Spec.Values.Raw))
 		if err != nil {
 			log.Error(err, "unable to parse values as template")
-			return nil, err
+			return nil, nil, err
 		}
 
 		var buf bytes.Buffer
 		if err := tmpl.Execute(&buf, inputs); err != nil {
 			log.Error(err, "unable to execute values template")
-			return nil, err
+			return nil, nil, err
 		}
 
 		vars["values"] = &apiextensionsv1.JSON{Raw: buf.Bytes()}
+		// the values are rendered with the inputs read from the Secrets
+		sensitive["values"] = len(terraform.Spec.ReadInputsFromSecrets) > 0
 	}
 
 	log.Info("mapping the Spec.Vars")
 	if len(terraform.Spec.Vars) > 0 {
 		for _, v := range terraform.Spec.Vars {
-			vars[v.Name] = v.Value
+			if v.ValueFrom == nil {
+				vars[v.Name] = v.Value
+				continue
+			}
+			value, fromSecret, err := r.variableValueFrom(ctx, terraform.Namespace, v)
+			if err != nil {
+				log.Error(err, "unable to get the value of the variable", "variable", v.Name)
+				return nil, nil, err
+			}
+			vars[v.Name], sensitive[v.Name] = value, fromSecret
 		}
 	}
 
@@ -461,25 +696,27 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 			err := r.Get(ctx, objectKey, &s)
 			if err != nil && vf.Optional == false {
 				log.Error(err, "unable to get object key", "objectKey", objectKey, "secret", s.ObjectMeta.Name)
-				return nil, err
+				return nil, nil, err
 			}
 			// if VarsKeys is null, use all
 			if vf.VarsKeys == nil {
 				for key, val := range s.Data {
+					sensitive[key] = true
 					vars[key], err = utils.JSONEncodeBytes(val)
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
 						log.Error(err, "encoding failure")
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			} else {
 				for _, key := range vf.VarsKeys {
+					sensitive[key] = true
 					vars[key], err = utils.JSONEncodeBytes(s.Data[key])
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
 						log.Error(err, "encoding failure")
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -488,42 +725,46 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 			err := r.Get(ctx, objectKey, &cm)
 			if err != nil && vf.Optional == false {
 				log.Error(err, "unable to get object key", "objectKey", objectKey, "configmap", cm.ObjectMeta.Name)
-				return nil, err
+				return nil, nil, err
 			}
 
 			// if VarsKeys is null, use all
 			if vf.VarsKeys == nil {
 				for key, val := range cm.Data {
+					sensitive[key] = false
 					vars[key], err = utils.JSONEncodeBytes([]byte(val))
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
 						log.Error(err, "encoding failure")
-						return nil, err
+						return nil, nil, err
 					}
 				}
 				for key, val := range cm.BinaryData {
+					sensitive[key] = false
 					vars[key], err = utils.JSONEncodeBytes(val)
 					if err != nil {
 						err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
 						log.Error(err, "encoding failure")
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			} else {
 				for _, key := range vf.VarsKeys {
 					if val, ok := cm.Data[key]; ok {
+						sensitive[key] = false
 						vars[key], err = utils.JSONEncodeBytes([]byte(val))
 						if err != nil {
 							err := fmt.Errorf("failed to encode key %s with error: %w", key, err)
 							log.Error(err, "encoding failure")
-							return nil, err
+							return nil, nil, err
 						}
 					}
 					if val, ok := cm.BinaryData[key]; ok {
+						sensitive[key] = false
 						vars[key], err = utils.JSONEncodeBytes(val)
 						if err != nil {
 							log.Error(err, "encoding failure")
-							return nil, err
+							return nil, nil, err
 						}
 					}
 				}
@@ -531,20 +772,55 @@ func (r *TerraformRunnerServer) GenerateVarsForTF(ctx context.Context, req *Gene
 		}
 	}
 
-	jsonBytes, err := json.Marshal(vars)
-	if err != nil {
-		log.Error(err, "unable to marshal the data")
-		return nil, err
+	for name, isSensitive := range sensitive {
+		if !isSensitive {
+			delete(sensitive, name)
+		}
 	}
+	return vars, sensitive, nil
+}
 
-	varFilePath := filepath.Join(req.WorkingDir, "generated.auto.tfvars.json")
-	if err := os.WriteFile(varFilePath, jsonBytes, 0644); err != nil {
-		err = fmt.Errorf("error generating var file: %s", err)
-		log.Error(err, "unable to write the data to file", "filePath", varFilePath)
-		return nil, err
+// variableValueFrom returns the value of the key of the Secret or the ConfigMap of the variable, as a string,
+// and whether it is read from a Secret. The value of an optional key not found is null.
+func (r *TerraformRunnerServer) variableValueFrom(ctx context.Context, namespace string, v infrav1.Variable) (*apiextensionsv1.JSON, bool, error) {
+	if ref := v.ValueFrom.SecretKeyRef; ref != nil {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil && !(apierrors.IsNotFound(err) && isOptional(ref.Optional)) {
+			return nil, true, err
+		}
+		val, ok := secret.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return nil, true, fmt.Errorf("key %s of Secret %s not found for the variable %s", ref.Key, ref.Name, v.Name)
+		} else if !ok {
+			return nil, true, nil
+		}
+		value, err := utils.JSONEncodeBytes(val)
+		return value, true, err
 	}
 
-	return &GenerateVarsForTFReply{Message: "ok"}, nil
+	if ref := v.ValueFrom.ConfigMapKeyRef; ref != nil {
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &cm); err != nil && !(apierrors.IsNotFound(err) && isOptional(ref.Optional)) {
+			return nil, false, err
+		}
+		val, ok := cm.BinaryData[ref.Key]
+		if data, found := cm.Data[ref.Key]; found {
+			val, ok = []byte(data), true
+		}
+		if !ok && !isOptional(ref.Optional) {
+			return nil, false, fmt.Errorf("key %s of ConfigMap %s not found for the variable %s", ref.Key, ref.Name, v.Name)
+		} else if !ok {
+			return nil, false, nil
+		}
+		value, err := utils.JSONEncodeBytes(val)
+		return value, false, err
+	}
+
+	return nil, false, fmt.Errorf("the value of the variable %s must be read from a Secret or a ConfigMap", v.Name)
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
 }
 
 func (r *TerraformRunnerServer) GenerateTemplate(ctx context.Context, req *GenerateTemplateRequest) (*GenerateTemplateReply, error) {
@@ -644,6 +920,17 @@ func (r *TerraformRunnerServer) Plan(ctx context.Context, req *PlanRequest) (*Pl
 		planOpt = append(planOpt, tfexec.Target(target))
 	}
 
+	for _, address := range req.Replace {
+		planOpt = append(planOpt, tfexec.Replace(address))
+	}
+
+	extraArgs, err := r.extraArgs(StagePlan)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	planOpt = append(planOpt, extraArgs.planOptions(r.terraform)...)
+
 	drifted, err := r.tf.Plan(ctx, planOpt...)
 	if err != nil {
 		st := status.New(codes.Internal, err.Error())
@@ -679,9 +966,26 @@ func (r *TerraformRunnerServer) ShowPlanFileRaw(ctx context.Context, req *ShowPl
 		return nil, err
 	}
 
+	rawOutput, err = r.scrubRawPlan(ctx, req.Filename, rawOutput)
+	if err != nil {
+		log.Error(err, "unable to scrub sensitive values from the raw plan output")
+		return nil, err
+	}
+
 	return &ShowPlanFileRawReply{RawOutput: rawOutput}, nil
 }
 
+// scrubRawPlan masks, in the human readable rendering of a plan file, the values
+// that the JSON rendering of the same plan marks as sensitive.
+func (r *TerraformRunnerServer) scrubRawPlan(ctx context.Context, filename string, rawOutput string) (string, error) {
+	plan, err := r.tf.ShowPlanFile(ctx, filename)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ScrubSensitiveValues(rawOutput, utils.MaskSensitivePlan(plan)), nil
+}
+
 func (r *TerraformRunnerServer) ShowPlanFile(ctx context.Context, req *ShowPlanFileRequest) (*ShowPlanFileReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("show the raw plan file")
@@ -697,6 +1001,7 @@ func (r *TerraformRunnerServer) ShowPlanFile(ctx context.Context, req *ShowPlanF
 		return nil, err
 	}
 
+	utils.MaskSensitivePlan(plan)
 	jsonBytes, err := json.Marshal(plan)
 	if err != nil {
 		log.Error(err, "unable to marshal the plan to json")
@@ -706,6 +1011,50 @@ func (r *TerraformRunnerServer) ShowPlanFile(ctx context.Context, req *ShowPlanF
 	return &ShowPlanFileReply{JsonOutput: jsonBytes}, nil
 }
 
+// Graph runs terraform graph in the working directory, and stores the resulting DOT graph
+// in the tfgraph-<workspace>-<name> ConfigMap, owned by the Terraform object.
+func (r *TerraformRunnerServer) Graph(ctx context.Context, req *GraphRequest) (*GraphReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("graph the resources")
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	dot, err := r.tf.Graph(ctx)
+	if err != nil {
+		log.Error(err, "unable to graph the resources")
+		return nil, err
+	}
+
+	graphCM := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TFGraphName + "-" + r.terraform.WorkspaceName() + "-" + req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &graphCM, func() error {
+		graphCM.Annotations = map[string]string{GraphRevisionAnnotation: req.Revision}
+		graphCM.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+				Kind:       infrav1.TerraformKind,
+				Name:       req.Name,
+				UID:        types.UID(req.Uuid),
+			},
+		}
+		graphCM.Data = map[string]string{TFGraphName: dot}
+		return nil
+	}); err != nil {
+		err = fmt.Errorf("error storing the graph: %s", err)
+		log.Error(err, "unable to store the graph configmap")
+		return nil, err
+	}
+
+	return &GraphReply{Dot: dot}, nil
+}
+
 func (r *TerraformRunnerServer) SaveTFPlan(ctx context.Context, req *SaveTFPlanRequest) (*SaveTFPlanReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("save the plan")
@@ -728,9 +1077,40 @@ func (r *TerraformRunnerServer) SaveTFPlan(ctx context.Context, req *SaveTFPlanR
 		}
 	}
 
-	planRev := strings.Replace(req.Revision, "/", "-", 1)
-	planName := "plan-" + planRev
-	if err := r.writePlanAsSecret(ctx, req.Name, req.Namespace, log, planName, tfplan, "", req.Uuid); err != nil {
+	planName, _ := infrav1.GetPlanIdAndApproveMessage(req.Revision, "")
+	if req.PlanId != "" {
+		planName = req.PlanId
+	}
+	reply := &SaveTFPlanReply{Message: "ok"}
+
+	var changes []byte
+	if !req.BackendCompletelyDisable {
+		planObj, err := r.tf.ShowPlanFile(ctx, TFPlanName)
+		if err != nil {
+			log.Error(err, "unable to get the plan output for its changes")
+			return nil, err
+		}
+		planChanges := utils.PlanChanges(planObj)
+		reply.ChangeCount = int32(len(planChanges))
+		changes, err = json.Marshal(planChanges)
+		if err != nil {
+			log.Error(err, "unable to marshal the plan changes")
+			return nil, err
+		}
+
+		// a previous plan that has been neither applied, nor re-created for the same revision, is superseded
+		previousPlan, previousChanges, err := r.readPlanChanges(ctx, req.Name, req.Namespace)
+		if err != nil {
+			log.Error(err, "unable to read the changes of the previous plan")
+			return nil, err
+		}
+		if previousPlan != "" && previousPlan != planName && previousPlan != req.LastAppliedPlan {
+			reply.SupersededPlan = previousPlan
+			reply.AddedChanges, reply.RemovedChanges = utils.DiffPlanChanges(previousChanges, planChanges)
+		}
+	}
+
+	if err := r.writePlanAsSecret(ctx, req.Name, req.Namespace, log, planName, tfplan, changes, "", req.Uuid, req.MaxPlanSize, false); err != nil {
 		return nil, err
 	}
 
@@ -740,31 +1120,94 @@ func (r *TerraformRunnerServer) SaveTFPlan(ctx context.Context, req *SaveTFPlanR
 			log.Error(err, "unable to get the plan output for json")
 			return nil, err
 		}
+		utils.MaskSensitivePlan(planObj)
 		jsonBytes, err := json.Marshal(planObj)
 		if err != nil {
 			log.Error(err, "unable to marshal the plan to json")
 			return nil, err
 		}
 
-		if err := r.writePlanAsSecret(ctx, req.Name, req.Namespace, log, planName, jsonBytes, ".json", req.Uuid); err != nil {
+		// a cut JSON document can't be parsed, so a plan too large is replaced by a marker
+		if req.MaxReadablePlanSize > 0 && int64(len(jsonBytes)) > req.MaxReadablePlanSize {
+			log.Info("the readable plan is truncated", "size", len(jsonBytes), "maxSize", req.MaxReadablePlanSize)
+			jsonBytes, err = json.Marshal(map[string]interface{}{
+				"truncated": true,
+				"message":   fmt.Sprintf("%d bytes exceed the maximum of %d bytes", len(jsonBytes), req.MaxReadablePlanSize),
+			})
+			if err != nil {
+				return nil, err
+			}
+			reply.ReadablePlanTruncated = true
+		}
+
+		if err := r.writePlanAsSecret(ctx, req.Name, req.Namespace, log, planName, jsonBytes, nil, ".json", req.Uuid, 0, reply.ReadablePlanTruncated); err != nil {
 			return nil, err
 		}
-	} else if r.terraform.Spec.StoreReadablePlan == "human" {
+	} else if format := r.terraform.Spec.StoreReadablePlan; format == "human" || format == "markdown" || format == "diff" {
 		rawOutput, err := r.tf.ShowPlanFileRaw(ctx, TFPlanName)
 		if err != nil {
 			log.Error(err, "unable to get the plan output for human")
 			return nil, err
 		}
 
-		if err := r.writePlanAsConfigMap(ctx, req.Name, req.Namespace, log, planName, rawOutput, "", req.Uuid); err != nil {
+		rawOutput, err = r.scrubRawPlan(ctx, TFPlanName, rawOutput)
+		if err != nil {
+			log.Error(err, "unable to scrub sensitive values from the plan output for human")
+			return nil, err
+		}
+
+		switch format {
+		case "diff":
+			rawOutput = utils.PlanDiff(rawOutput)
+		case "markdown":
+			planObj, err := r.tf.ShowPlanFile(ctx, TFPlanName)
+			if err != nil {
+				log.Error(err, "unable to get the plan output for markdown")
+				return nil, err
+			}
+			rawOutput = utils.PlanMarkdown(planObj, rawOutput)
+		}
+
+		rawOutput, reply.ReadablePlanTruncated = utils.TruncateText(rawOutput, req.MaxReadablePlanSize)
+		if reply.ReadablePlanTruncated {
+			log.Info("the readable plan is truncated", "maxSize", req.MaxReadablePlanSize)
+		}
+
+		if err := r.writePlanAsConfigMap(ctx, req.Name, req.Namespace, log, planName, rawOutput, "", req.Uuid, reply.ReadablePlanTruncated); err != nil {
 			return nil, err
 		}
 	}
 
-	return &SaveTFPlanReply{Message: "ok"}, nil
+	return reply, nil
 }
 
-func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name string, namespace string, log logr.Logger, planName string, tfplan []byte, suffix string, uuid string) error {
+// readPlanChanges returns the name, and the changes, of the plan currently saved in the plan secret.
+// It returns an empty name if there is no such plan, or if its changes were not recorded.
+func (r *TerraformRunnerServer) readPlanChanges(ctx context.Context, name string, namespace string) (string, []string, error) {
+	secretName := "tfplan-" + r.terraform.WorkspaceName() + "-" + name
+	var tfplanSecret corev1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, &tfplanSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	data, ok := tfplanSecret.Data[TFPlanChangesKey]
+	if !ok {
+		return "", nil, nil
+	}
+
+	var changes []string
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return "", nil, err
+	}
+	return tfplanSecret.Annotations[SavedPlanSecretAnnotation], changes, nil
+}
+
+// writePlanAsSecret stores the plan gzipped. It fails with ResourceExhausted if the plan exceeds maxSize,
+// unless maxSize is zero, as a truncated plan could not be applied.
+func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name string, namespace string, log logr.Logger, planName string, tfplan []byte, changes []byte, suffix string, uuid string, maxSize int64, truncated bool) error {
 	secretName := "tfplan-" + r.terraform.WorkspaceName() + "-" + name + suffix
 	tfplanObjectKey := types.NamespacedName{Name: secretName, Namespace: namespace}
 	var tfplanSecret corev1.Secret
@@ -793,8 +1236,16 @@ func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name stri
 		log.Error(err, "unable to encode the plan revision", "planName", planName)
 		return err
 	}
+	if maxSize > 0 && int64(len(tfplan)+len(changes)) > maxSize {
+		err := fmt.Errorf("plan %s of %d bytes, compressed, exceeds the maximum of %d bytes", planName, len(tfplan)+len(changes), maxSize)
+		log.Error(err, "unable to store the plan")
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
 
 	tfplanData := map[string][]byte{TFPlanName: tfplan}
+	if changes != nil {
+		tfplanData[TFPlanChangesKey] = changes
+	}
 	tfplanSecret = corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -806,6 +1257,7 @@ func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name stri
 			Annotations: map[string]string{
 				"encoding":                "gzip",
 				SavedPlanSecretAnnotation: planName,
+				ContentHashAnnotation:     utils.ContentHash(tfplanData),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -819,6 +1271,9 @@ func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name stri
 		Type: corev1.SecretTypeOpaque,
 		Data: tfplanData,
 	}
+	if truncated {
+		tfplanSecret.Annotations[TruncatedAnnotation] = "true"
+	}
 
 	if err := r.Client.Create(ctx, &tfplanSecret); err != nil {
 		err = fmt.Errorf("error recording plan status: %s", err)
@@ -829,7 +1284,7 @@ func (r *TerraformRunnerServer) writePlanAsSecret(ctx context.Context, name stri
 	return nil
 }
 
-func (r *TerraformRunnerServer) writePlanAsConfigMap(ctx context.Context, name string, namespace string, log logr.Logger, planName string, tfplan string, suffix string, uuid string) error {
+func (r *TerraformRunnerServer) writePlanAsConfigMap(ctx context.Context, name string, namespace string, log logr.Logger, planName string, tfplan string, suffix string, uuid string, truncated bool) error {
 	configMapName := "tfplan-" + r.terraform.WorkspaceName() + "-" + name + suffix
 	tfplanObjectKey := types.NamespacedName{Name: configMapName, Namespace: namespace}
 	var tfplanCM corev1.ConfigMap
@@ -876,6 +1331,9 @@ func (r *TerraformRunnerServer) writePlanAsConfigMap(ctx context.Context, name s
 		},
 		Data: tfplanData,
 	}
+	if truncated {
+		tfplanCM.Annotations[TruncatedAnnotation] = "true"
+	}
 
 	if err := r.Client.Create(ctx, &tfplanCM); err != nil {
 		err = fmt.Errorf("error recording plan status: %s", err)
@@ -912,6 +1370,13 @@ func (r *TerraformRunnerServer) LoadTFPlan(ctx context.Context, req *LoadTFPlanR
 		return nil, err
 	}
 
+	// refuse to apply a plan that has been modified since it was saved
+	if hash, ok := tfplanSecret.Annotations[ContentHashAnnotation]; ok && hash != utils.ContentHash(tfplanSecret.Data) {
+		err = fmt.Errorf("error plan secret %s has been modified out-of-band", tfplanSecretKey.Name)
+		log.Error(err, "plan content hash mismatch")
+		return nil, status.Error(codes.DataLoss, err.Error())
+	}
+
 	if req.BackendCompletelyDisable {
 		// do nothing
 	} else {
@@ -932,18 +1397,123 @@ func (r *TerraformRunnerServer) LoadTFPlan(ctx context.Context, req *LoadTFPlanR
 	return &LoadTFPlanReply{Message: "ok"}, nil
 }
 
-func (r *TerraformRunnerServer) Destroy(ctx context.Context, req *DestroyRequest) (*DestroyReply, error) {
+// CheckPlanStaleness compares the state the loaded plan was created against with the current state in the backend.
+// The plan is stale when the state has been written since planning, or replaced by a state of another lineage.
+func (r *TerraformRunnerServer) CheckPlanStaleness(ctx context.Context, req *CheckPlanStalenessRequest) (*CheckPlanStalenessReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
-	log.Info("running destroy")
-	ctx, cancel := context.WithCancel(ctx)
+	log.Info("checking plan staleness")
+
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	planFile, err := ioutil.ReadFile(filepath.Join(r.tf.WorkingDir(), TFPlanName))
+	if err != nil {
+		err = fmt.Errorf("error reading plan file: %s", err)
+		log.Error(err, "unable to read the plan from disk")
+		return nil, err
+	}
+
+	planned, err := utils.PlanPriorStateMeta(planFile)
+	if err != nil {
+		log.Error(err, "unable to read the state of the plan")
+		return nil, err
+	}
+
+	current, err := r.pullStateMeta(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull the current state")
+		return nil, err
+	}
+
+	if planned.Lineage != current.Lineage {
+		return &CheckPlanStalenessReply{
+			Stale:   true,
+			Message: fmt.Sprintf("state lineage changed from %q to %q since planning", planned.Lineage, current.Lineage),
+		}, nil
+	}
+
+	if planned.Serial != current.Serial {
+		return &CheckPlanStalenessReply{
+			Stale:   true,
+			Message: fmt.Sprintf("state serial advanced from %d to %d since planning", planned.Serial, current.Serial),
+		}, nil
+	}
+
+	return &CheckPlanStalenessReply{Message: fmt.Sprintf("state serial %d unchanged since planning", current.Serial)}, nil
+}
+
+// pullStateMeta reads the lineage and the serial of the state pulled with pullState.
+func (r *TerraformRunnerServer) pullStateMeta(ctx context.Context) (utils.StateMeta, error) {
+	state, err := r.pullState(ctx)
+	if err != nil {
+		return utils.StateMeta{}, err
+	}
+
+	return utils.ParseStateMeta(state)
+}
+
+// pullState runs `terraform state pull`, which is not supported by tfexec,
+// with the same binary, working directory and environment variables as the other commands.
+// It returns an empty state when there is none yet.
+func (r *TerraformRunnerServer) pullState(ctx context.Context) ([]byte, error) {
+	return r.runStateCommand(ctx, nil, "state", "pull")
+}
+
+// runStateCommand runs a terraform command with stdin as its input, and returns its output.
+func (r *TerraformRunnerServer) runStateCommand(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, r.tf.ExecPath(), args...)
+	cmd.Dir = r.tf.WorkingDir()
+	if r.envs != nil {
+		cmd.Env = utils.MapToEnv(r.envs)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("terraform %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// interruptOnTermination interrupts the running terraform command when the runner pod is terminated,
+// e.g. because its node is drained or preempted. On SIGINT, Terraform finishes the changes in progress,
+// writes the state and releases its lock before exiting, which leaves the state consistent for the next runner.
+// The returned function reports whether the command was interrupted.
+func (r *TerraformRunnerServer) interruptOnTermination(ctx context.Context, cancel context.CancelFunc) func() bool {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+
+	var interrupted atomic.Bool
 	go func() {
 		select {
 		case <-r.Done:
+			interrupted.Store(true)
+			n, err := interruptTerraform()
+			if err != nil {
+				log.Error(err, "unable to interrupt terraform")
+			}
+			log.Info("runner is terminating, waiting for the interrupted terraform to exit", "processes", n)
 			cancel()
 		case <-ctx.Done():
 		}
 	}()
 
+	return interrupted.Load
+}
+
+func (r *TerraformRunnerServer) Destroy(ctx context.Context, req *DestroyRequest) (*DestroyReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("running destroy")
+	ctx, cancel := context.WithCancel(ctx)
+	interrupted := r.interruptOnTermination(ctx, cancel)
+
 	if req.TfInstance != r.InstanceID {
 		err := fmt.Errorf("no TF instance found")
 		log.Error(err, "no terraform")
@@ -955,11 +1525,24 @@ func (r *TerraformRunnerServer) Destroy(ctx context.Context, req *DestroyRequest
 		destroyOpt = append(destroyOpt, tfexec.Target(target))
 	}
 
+	extraArgs, err := r.extraArgs(StageDestroy)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	destroyOpt = append(destroyOpt, extraArgs.destroyOptions(r.terraform)...)
+
 	if err := r.tf.Destroy(ctx, destroyOpt...); err != nil {
 		st := status.New(codes.Internal, err.Error())
 		var stateErr *tfexec.ErrStateLocked
 
-		if errors.As(err, &stateErr) {
+		if interrupted() {
+			st, err = st.WithDetails(&DestroyReply{Message: "interrupted", Interrupted: true})
+
+			if err != nil {
+				return nil, err
+			}
+		} else if errors.As(err, &stateErr) {
 			st, err = st.WithDetails(&DestroyReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
 
 			if err != nil {
@@ -978,13 +1561,7 @@ func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("running apply")
 	ctx, cancel := context.WithCancel(ctx)
-	go func() {
-		select {
-		case <-r.Done:
-			cancel()
-		case <-ctx.Done():
-		}
-	}()
+	interrupted := r.interruptOnTermination(ctx, cancel)
 
 	if req.TfInstance != r.InstanceID {
 		err := fmt.Errorf("no TF instance found")
@@ -1005,11 +1582,39 @@ func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*
 		applyOpt = append(applyOpt, tfexec.Target(target))
 	}
 
+	extraArgs, err := r.extraArgs(StageApply)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	applyOpt = append(applyOpt, extraArgs.applyOptions(r.terraform)...)
+
+	// the changes of the plan are the total of the progress, unknown when applying without a plan
+	total := 0
+	if req.DirOrPlan != "" {
+		planObj, err := r.tf.ShowPlanFile(ctx, req.DirOrPlan)
+		if err != nil {
+			log.Error(err, "unable to get the plan output for the progress of the apply")
+		} else {
+			total = len(utils.PlanChanges(planObj))
+		}
+	}
+	progress := utils.NewApplyProgress(total)
+	r.setApplyProgress(progress)
+	r.tf.SetStdout(io.MultiWriter(r.stdout, progress))
+	defer r.tf.SetStdout(r.stdout)
+
 	if err := r.tf.Apply(ctx, applyOpt...); err != nil {
 		st := status.New(codes.Internal, err.Error())
 		var stateErr *tfexec.ErrStateLocked
 
-		if errors.As(err, &stateErr) {
+		if interrupted() {
+			st, err = st.WithDetails(&ApplyReply{Message: "interrupted", Interrupted: true})
+
+			if err != nil {
+				return nil, err
+			}
+		} else if errors.As(err, &stateErr) {
 			st, err = st.WithDetails(&ApplyReply{Message: "not ok", StateLockIdentifier: stateErr.ID})
 
 			if err != nil {
@@ -1024,6 +1629,29 @@ func (r *TerraformRunnerServer) Apply(ctx context.Context, req *ApplyRequest) (*
 	return &ApplyReply{Message: "ok"}, nil
 }
 
+func (r *TerraformRunnerServer) setApplyProgress(progress *utils.ApplyProgress) {
+	r.progressMu.Lock()
+	defer r.progressMu.Unlock()
+	r.progress = progress
+}
+
+// GetApplyProgress returns the progress of the running apply, or of the last one.
+func (r *TerraformRunnerServer) GetApplyProgress(ctx context.Context, req *GetApplyProgressRequest) (*GetApplyProgressReply, error) {
+	if req.TfInstance != r.InstanceID {
+		return nil, fmt.Errorf("no TF instance found")
+	}
+
+	r.progressMu.Lock()
+	progress := r.progress
+	r.progressMu.Unlock()
+	if progress == nil {
+		return &GetApplyProgressReply{}, nil
+	}
+
+	completed, total := progress.Counts()
+	return &GetApplyProgressReply{Completed: int32(completed), Total: int32(total)}, nil
+}
+
 func getInventoryFromTerraformModule(m *tfjson.StateModule) []*Inventory {
 	var result []*Inventory
 	for _, r := range m.Resources {
@@ -1034,11 +1662,20 @@ func getInventoryFromTerraformModule(m *tfjson.StateModule) []*Inventory {
 		} else if val, ok := r.AttributeValues["id"]; ok {
 			id = val.(string)
 		}
-		result = append(result, &Inventory{
+		inventory := &Inventory{
 			Name:       r.Name,
 			Type:       r.Type,
 			Identifier: id,
-		})
+		}
+		if object := utils.KubernetesObjectOf(r.Type, r.AttributeValues); object != nil {
+			inventory.Object = &KubernetesObject{
+				ApiVersion: object.APIVersion,
+				Kind:       object.Kind,
+				Namespace:  object.Namespace,
+				Name:       object.Name,
+			}
+		}
+		result = append(result, inventory)
 	}
 
 	// recursively get all resources from submodules
@@ -1108,14 +1745,32 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("write outputs to secret")
 
-	objectKey := types.NamespacedName{Namespace: req.Namespace, Name: req.SecretName}
+	secretNamespace := req.SecretNamespace
+	if secretNamespace == "" {
+		secretNamespace = req.Namespace
+	}
+	objectKey := types.NamespacedName{Namespace: secretNamespace, Name: req.SecretName}
 	var outputSecret corev1.Secret
 
+	data, truncatedOutputs := utils.FitData(req.Data, req.MaxSize)
+	if len(truncatedOutputs) > 0 {
+		log.Info("outputs left out of the secret", "outputs", truncatedOutputs, "maxSize", req.MaxSize)
+	}
+
 	drift := true
 	create := true
+	contentHash := utils.ContentHash(data)
 	if err := r.Client.Get(ctx, objectKey, &outputSecret); err == nil {
+		// a secret of the same name written by anyone else is never overwritten
+		if !OwnsOutputSecret(outputSecret, req.Uuid) {
+			err := fmt.Errorf("the secret %s/%s already exists and was not written for this object", secretNamespace, req.SecretName)
+			log.Error(err, "unable to write the output secret")
+			return nil, err
+		}
 		// if everything is there, we don't write anything
-		if reflect.DeepEqual(outputSecret.Data, req.Data) {
+		if reflect.DeepEqual(outputSecret.Data, data) && outputSecret.Annotations[ContentHashAnnotation] == contentHash &&
+			outputSecret.Annotations[OwnerUIDAnnotation] == req.Uuid &&
+			outputSecret.Annotations[TruncatedOutputsAnnotation] == strings.Join(truncatedOutputs, ",") {
 			drift = false
 		} else {
 			// found, but need update
@@ -1132,19 +1787,30 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 			outputSecret = corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      req.SecretName,
-					Namespace: req.Namespace,
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
-							Kind:       infrav1.TerraformKind,
-							Name:       req.Name,
-							UID:        types.UID(req.Uuid),
-							Controller: &vTrue,
-						},
+					Namespace: secretNamespace,
+					Annotations: map[string]string{
+						ContentHashAnnotation: contentHash,
+						OwnerUIDAnnotation:    req.Uuid,
 					},
 				},
 				Type: corev1.SecretTypeOpaque,
-				Data: req.Data,
+				Data: data,
+			}
+			// an owner of another namespace would be seen as missing by the garbage collector,
+			// and a retained secret must outlive the object
+			if secretNamespace == req.Namespace && !req.Retain {
+				outputSecret.OwnerReferences = []metav1.OwnerReference{
+					{
+						APIVersion: infrav1.GroupVersion.Group + "/" + infrav1.GroupVersion.Version,
+						Kind:       infrav1.TerraformKind,
+						Name:       req.Name,
+						UID:        types.UID(req.Uuid),
+						Controller: &vTrue,
+					},
+				}
+			}
+			if len(truncatedOutputs) > 0 {
+				outputSecret.Annotations[TruncatedOutputsAnnotation] = strings.Join(truncatedOutputs, ",")
 			}
 
 			err := r.Client.Create(ctx, &outputSecret)
@@ -1153,7 +1819,17 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 				return nil, err
 			}
 		} else {
-			outputSecret.Data = req.Data
+			outputSecret.Data = data
+			if outputSecret.Annotations == nil {
+				outputSecret.Annotations = map[string]string{}
+			}
+			outputSecret.Annotations[ContentHashAnnotation] = contentHash
+			outputSecret.Annotations[OwnerUIDAnnotation] = req.Uuid
+			if len(truncatedOutputs) > 0 {
+				outputSecret.Annotations[TruncatedOutputsAnnotation] = strings.Join(truncatedOutputs, ",")
+			} else {
+				delete(outputSecret.Annotations, TruncatedOutputsAnnotation)
+			}
 			err := r.Client.Update(ctx, &outputSecret)
 			if err != nil {
 				log.Error(err, "unable to update secret")
@@ -1161,10 +1837,10 @@ func (r *TerraformRunnerServer) WriteOutputs(ctx context.Context, req *WriteOutp
 			}
 		}
 
-		return &WriteOutputsReply{Message: "ok", Changed: true}, nil
+		return &WriteOutputsReply{Message: "ok", Changed: true, TruncatedOutputs: truncatedOutputs}, nil
 	}
 
-	return &WriteOutputsReply{Message: "ok", Changed: false}, nil
+	return &WriteOutputsReply{Message: "ok", Changed: false, TruncatedOutputs: truncatedOutputs}, nil
 }
 
 func (r *TerraformRunnerServer) GetOutputs(ctx context.Context, req *GetOutputsRequest) (*GetOutputsReply, error) {
@@ -1193,7 +1869,8 @@ func (r *TerraformRunnerServer) GetOutputs(ctx context.Context, req *GetOutputsR
 func (r *TerraformRunnerServer) FinalizeSecrets(ctx context.Context, req *FinalizeSecretsRequest) (*FinalizeSecretsReply, error) {
 	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
 	log.Info("finalize the output secrets")
-	// nil dereference bug here
+	// a missing plan secret must not keep the output secret from being finalized, its NotFound is returned last
+	var notFound error
 	planObjectKey := types.NamespacedName{Namespace: req.Namespace, Name: "tfplan-" + req.Workspace + "-" + req.Name}
 	var planSecret corev1.Secret
 	if err := r.Client.Get(ctx, planObjectKey, &planSecret); err == nil {
@@ -1204,7 +1881,7 @@ func (r *TerraformRunnerServer) FinalizeSecrets(ctx context.Context, req *Finali
 		}
 	} else if apierrors.IsNotFound(err) {
 		log.Error(err, "plan secret not found")
-		return nil, status.Error(codes.NotFound, err.Error())
+		notFound = err
 	} else {
 		// transient failure
 		log.Error(err, "unable to get the plan secret")
@@ -1212,17 +1889,28 @@ func (r *TerraformRunnerServer) FinalizeSecrets(ctx context.Context, req *Finali
 	}
 
 	if req.HasSpecifiedOutputSecret {
-		outputsObjectKey := types.NamespacedName{Namespace: req.Namespace, Name: req.OutputSecretName}
+		outputsNamespace := req.OutputSecretNamespace
+		if outputsNamespace == "" {
+			outputsNamespace = req.Namespace
+		}
+		outputsObjectKey := types.NamespacedName{Namespace: outputsNamespace, Name: req.OutputSecretName}
 		var outputsSecret corev1.Secret
 		if err := r.Client.Get(ctx, outputsObjectKey, &outputsSecret); err == nil {
-			if err := r.Client.Delete(ctx, &outputsSecret); err != nil {
+			if !OwnsOutputSecret(outputsSecret, req.Uuid) {
+				log.Info("output secret not written for this object, left as it is", "secret", outputsObjectKey)
+			} else if req.RetainOutputSecret {
+				err = r.releaseOutputSecret(ctx, &outputsSecret, req.Name)
+			} else {
+				err = r.Client.Delete(ctx, &outputsSecret)
+			}
+			if err != nil {
 				// transient failure
-				log.Error(err, "unable to delete the output secret")
+				log.Error(err, "unable to finalize the output secret", "retain", req.RetainOutputSecret)
 				return nil, status.Error(codes.Internal, err.Error())
 			}
 		} else if apierrors.IsNotFound(err) {
 			log.Error(err, "output secret not found")
-			return nil, status.Error(codes.NotFound, err.Error())
+			notFound = err
 		} else {
 			// transient failure
 			log.Error(err, "unable to get the output secret")
@@ -1230,9 +1918,46 @@ func (r *TerraformRunnerServer) FinalizeSecrets(ctx context.Context, req *Finali
 		}
 	}
 
+	if notFound != nil {
+		return nil, status.Error(codes.NotFound, notFound.Error())
+	}
 	return &FinalizeSecretsReply{Message: "ok"}, nil
 }
 
+// OwnsOutputSecret tells whether an output secret was written for the object of uid: it is annotated with the UID,
+// or, for the secrets written before the annotation, owned by the object.
+func OwnsOutputSecret(secret corev1.Secret, uid string) bool {
+	if uid == "" {
+		return false
+	}
+	if secret.Annotations[OwnerUIDAnnotation] == uid {
+		return true
+	}
+	for _, ref := range secret.OwnerReferences {
+		if string(ref.UID) == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseOutputSecret removes the owner reference of the object from a retained output secret,
+// so that the garbage collector keeps it once the object is deleted.
+func (r *TerraformRunnerServer) releaseOutputSecret(ctx context.Context, secret *corev1.Secret, name string) error {
+	var refs []metav1.OwnerReference
+	for _, ref := range secret.OwnerReferences {
+		if ref.Kind == infrav1.TerraformKind && ref.Name == name && strings.HasPrefix(ref.APIVersion, infrav1.GroupVersion.Group+"/") {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	if len(refs) == len(secret.OwnerReferences) {
+		return nil
+	}
+	secret.OwnerReferences = refs
+	return r.Client.Update(ctx, secret)
+}
+
 func (r *TerraformRunnerServer) ForceUnlock(ctx context.Context, req *ForceUnlockRequest) (*ForceUnlockReply, error) {
 	reply := &ForceUnlockReply{
 		Success: true,
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInputVariables(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	testScheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+	r := &TerraformRunnerServer{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "database", Namespace: "flux-system"},
+			Data:       map[string][]byte{"password": []byte("s3cr3t"), "region": []byte("us-east-1"), "user": []byte("admin")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "flux-system"},
+			Data:       map[string]string{"region": "eu-west-1"},
+		},
+	).Build()}
+
+	optional := true
+	terraform := infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		Spec: infrav1.TerraformSpec{
+			ReadInputsFromSecrets: []infrav1.ReadInputsFromSecretSpec{{Name: "database", As: "db"}},
+			Values:                &apiextensionsv1.JSON{Raw: []byte(`{"user":"${{ .db.user }}"}`)},
+			Vars: []infrav1.Variable{
+				{Name: "subject", Value: &apiextensionsv1.JSON{Raw: []byte(`"World"`)}},
+				{Name: "password", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "database"}, Key: "password",
+				}}},
+				{Name: "zone", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}, Key: "zone", Optional: &optional,
+				}}},
+			},
+			// the last one wins
+			VarsFrom: []infrav1.VarsReference{
+				{Kind: "Secret", Name: "database", VarsKeys: []string{"region"}},
+				{Kind: "ConfigMap", Name: "settings"},
+			},
+		},
+	}
+
+	vars, sensitive, err := r.InputVariables(ctx, terraform)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(vars).To(Equal(map[string]*apiextensionsv1.JSON{
+		"values":   {Raw: []byte(`{"user":"admin"}`)},
+		"subject":  {Raw: []byte(`"World"`)},
+		"password": {Raw: []byte(`"s3cr3t"`)},
+		"zone":     nil,
+		"region":   {Raw: []byte(`"eu-west-1"`)},
+	}))
+	g.Expect(sensitive).To(Equal(map[string]bool{"values": true, "password": true}))
+
+	// a key not optional must be found
+	terraform.Spec.Vars[2].ValueFrom.ConfigMapKeyRef.Optional = nil
+	_, _, err = r.InputVariables(ctx, terraform)
+	g.Expect(err).To(MatchError("key zone of ConfigMap settings not found for the variable zone"))
+}
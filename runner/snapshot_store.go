@@ -0,0 +1,263 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/pkg/oci"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// snapshotKind is what the snapshots of a store are, e.g. tfstate for the snapshots of the state.
+type snapshotKind struct {
+	// name prefixes the names of the Secrets and of the S3 keys of the snapshots, and is the key of their data in the Secrets.
+	name string
+	// mediaType is the media type of the layers of the snapshots in an OCI repository.
+	mediaType string
+	// extension ends the S3 keys of the snapshots.
+	extension string
+}
+
+// snapshotStore stores the gzipped snapshots of an object under their IDs.
+type snapshotStore interface {
+	// Put stores a snapshot, and returns its location.
+	Put(ctx context.Context, id string, data []byte) (string, error)
+	Get(ctx context.Context, id string) ([]byte, error)
+	// Delete deletes a snapshot, a missing snapshot not being an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// newSnapshotStore returns the store of the snapshots of a kind of the object of the runner.
+// The Secrets of the credentials are read from the namespace of the object.
+func (r *TerraformRunnerServer) newSnapshotStore(ctx context.Context, storage infrav1.SnapshotStorage, kind snapshotKind) (snapshotStore, error) {
+	terraform := r.terraform
+	if terraform == nil {
+		return nil, fmt.Errorf("no terraform object")
+	}
+
+	switch storage.Type {
+	case infrav1.SnapshotStorageTypeSecret:
+		return &secretSnapshotStore{client: r.Client, namespace: terraform.Namespace, name: terraform.Name, kind: kind}, nil
+	case infrav1.SnapshotStorageTypeS3:
+		if storage.S3 == nil {
+			return nil, fmt.Errorf("the snapshot storage of type s3 has no configuration")
+		}
+		return r.newS3SnapshotStore(ctx, *storage.S3, kind)
+	case infrav1.SnapshotStorageTypeOCI:
+		if storage.OCI == nil {
+			return nil, fmt.Errorf("the snapshot storage of type oci has no configuration")
+		}
+		return r.newOCISnapshotStore(ctx, *storage.OCI, kind)
+	}
+	return nil, fmt.Errorf("unsupported snapshot storage type %q", storage.Type)
+}
+
+// deleteSnapshots deletes the snapshots of ids from store. A failed deletion does not stop the next ones:
+// the IDs of the deleted snapshots are returned, with a message of the failures.
+func deleteSnapshots(ctx context.Context, store snapshotStore, ids []string) ([]string, string) {
+	log := ctrl.LoggerFrom(ctx).WithName(loggerName)
+	var deleted, failures []string
+	for _, id := range ids {
+		if err := store.Delete(ctx, id); err != nil {
+			log.Error(err, "unable to delete the snapshot", "id", id)
+			failures = append(failures, fmt.Sprintf("%s: %s", id, err))
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+	if len(failures) > 0 {
+		return deleted, "unable to delete the snapshots " + strings.Join(failures, "; ")
+	}
+	return deleted, ""
+}
+
+// secretSnapshotStore stores each snapshot in a Secret of the namespace of the object, named
+// <kind>-snapshot-<name>-<id>. The Secrets are not owned by the object, so that they are kept when it is deleted.
+type secretSnapshotStore struct {
+	client    client.Client
+	namespace string
+	name      string
+	kind      snapshotKind
+}
+
+func (s *secretSnapshotStore) key(id string) types.NamespacedName {
+	return types.NamespacedName{Namespace: s.namespace, Name: fmt.Sprintf("%s-snapshot-%s-%s", s.kind.name, s.name, id)}
+}
+
+func (s *secretSnapshotStore) Put(ctx context.Context, id string, data []byte) (string, error) {
+	key := s.key(id)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/created-by":      "tf-controller",
+				"infra.contrib.fluxcd.io/terraform": s.name,
+				"infra.contrib.fluxcd.io/snapshot":  s.kind.name,
+			},
+			Annotations: map[string]string{
+				"encoding": "gzip",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{s.kind.name: data},
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return "", err
+	}
+	return "secret/" + key.Name, nil
+}
+
+func (s *secretSnapshotStore) Get(ctx context.Context, id string) ([]byte, error) {
+	var secret corev1.Secret
+	if err := s.client.Get(ctx, s.key(id), &secret); err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[s.kind.name]
+	if !ok {
+		return nil, fmt.Errorf("the snapshot Secret %s has no %s key", secret.Name, s.kind.name)
+	}
+	return data, nil
+}
+
+func (s *secretSnapshotStore) Delete(ctx context.Context, id string) error {
+	key := s.key(id)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	return client.IgnoreNotFound(s.client.Delete(ctx, secret))
+}
+
+// s3SnapshotStore stores each snapshot in an object of a bucket, keyed <prefix>/<kind>-snapshots/<id><extension>.
+type s3SnapshotStore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+	kind   snapshotKind
+}
+
+func (r *TerraformRunnerServer) newS3SnapshotStore(ctx context.Context, spec infrav1.S3SnapshotStorage, kind snapshotKind) (*s3SnapshotStore, error) {
+	opts := []func(*config.LoadOptions) error{config.WithRegion(spec.Region)}
+	if spec.CredentialsSecretRef != nil {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: r.terraform.Namespace, Name: spec.CredentialsSecretRef.Name}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the credentials of the s3 snapshot storage: %w", err)
+		}
+		if len(secret.Data["access_key"]) == 0 || len(secret.Data["secret_key"]) == 0 {
+			return nil, fmt.Errorf("the Secret %s of the credentials of the s3 snapshot storage must have the access_key and secret_key keys", secret.Name)
+		}
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			string(secret.Data["access_key"]), string(secret.Data["secret_key"]), string(secret.Data["token"]))))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if spec.Endpoint != "" {
+			o.EndpointResolver = s3.EndpointResolverFromURL(spec.Endpoint)
+		}
+		o.UsePathStyle = spec.ForcePathStyle
+	})
+
+	prefix := spec.Prefix
+	if prefix == "" {
+		prefix = r.terraform.StateKeyPrefix()
+	}
+	return &s3SnapshotStore{client: s3Client, bucket: spec.Bucket, prefix: strings.Trim(prefix, "/"), kind: kind}, nil
+}
+
+func (s *s3SnapshotStore) key(id string) string {
+	return path.Join(s.prefix, s.kind.name+"-snapshots", id+s.kind.extension)
+}
+
+func (s *s3SnapshotStore) Put(ctx context.Context, id string, data []byte) (string, error) {
+	key := s.key(id)
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/gzip"),
+	}); err != nil {
+		return "", err
+	}
+	return "s3://" + s.bucket + "/" + key, nil
+}
+
+func (s *s3SnapshotStore) Get(ctx context.Context, id string) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.key(id))})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("snapshot s3://%s/%s not found", s.bucket, s.key(id))
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+	return ioutil.ReadAll(out.Body)
+}
+
+func (s *s3SnapshotStore) Delete(ctx context.Context, id string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.key(id))})
+	return err
+}
+
+// ociSnapshotStore stores each snapshot as an artifact of a repository, tagged with its ID.
+type ociSnapshotStore struct {
+	client     *oci.Client
+	repository string
+	kind       snapshotKind
+}
+
+func (r *TerraformRunnerServer) newOCISnapshotStore(ctx context.Context, spec infrav1.OCISnapshotStorage, kind snapshotKind) (*ociSnapshotStore, error) {
+	host, _, err := oci.ParseRepository(spec.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &oci.Client{Insecure: spec.Insecure}
+	if spec.SecretRef != nil {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: r.terraform.Namespace, Name: spec.SecretRef.Name}, &secret); err != nil {
+			return nil, fmt.Errorf("unable to get the credentials of the oci snapshot storage: %w", err)
+		}
+		dockerConfig, ok := secret.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			return nil, fmt.Errorf("the Secret %s of the credentials of the oci snapshot storage has no %s key", secret.Name, corev1.DockerConfigJsonKey)
+		}
+		if c.Username, c.Password, err = oci.CredentialsFromDockerConfig(dockerConfig, host); err != nil {
+			return nil, err
+		}
+	}
+	return &ociSnapshotStore{client: c, repository: spec.Repository, kind: kind}, nil
+}
+
+func (s *ociSnapshotStore) Put(ctx context.Context, id string, data []byte) (string, error) {
+	manifestDigest, err := s.client.Push(ctx, s.repository, id, s.kind.mediaType, data, nil)
+	if err != nil {
+		return "", err
+	}
+	return s.repository + ":" + id + "@" + manifestDigest, nil
+}
+
+func (s *ociSnapshotStore) Get(ctx context.Context, id string) ([]byte, error) {
+	return s.client.Pull(ctx, s.repository, id, s.kind.mediaType)
+}
+
+func (s *ociSnapshotStore) Delete(ctx context.Context, id string) error {
+	return s.client.Delete(ctx, s.repository, id)
+}
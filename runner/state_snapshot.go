@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/weaveworks/tf-controller/utils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// stateSnapshotKind is the kind of the snapshots of spec.stateBackup.
+var stateSnapshotKind = snapshotKind{
+	name:      "tfstate",
+	mediaType: "application/vnd.weaveworks.tf-controller.tfstate.v1+gzip",
+	extension: ".gz",
+}
+
+// stateSnapshotStore returns the store of spec.stateBackup of the object of the runner.
+func (r *TerraformRunnerServer) stateSnapshotStore(ctx context.Context) (snapshotStore, error) {
+	if r.terraform == nil || r.terraform.Spec.StateBackup == nil {
+		return nil, fmt.Errorf("the object has no stateBackup")
+	}
+	return r.newSnapshotStore(ctx, r.terraform.Spec.StateBackup.SnapshotStorage, stateSnapshotKind)
+}
+
+// SnapshotState stores the current state, pulled from the backend, as the snapshot of the ID of the request.
+func (r *TerraformRunnerServer) SnapshotState(ctx context.Context, req *SnapshotStateRequest) (*SnapshotStateReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("taking a snapshot of the state", "id", req.Id)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	state, err := r.pullState(ctx)
+	if err != nil {
+		log.Error(err, "unable to pull the state")
+		return nil, err
+	}
+	if len(bytes.TrimSpace(state)) == 0 {
+		log.Info("no state yet, no snapshot taken")
+		return &SnapshotStateReply{Empty: true}, nil
+	}
+	meta, err := utils.ParseStateMeta(state)
+	if err != nil {
+		log.Error(err, "unable to read the state")
+		return nil, err
+	}
+
+	store, err := r.stateSnapshotStore(ctx)
+	if err != nil {
+		log.Error(err, "unable to set up the snapshot storage")
+		return nil, err
+	}
+	data, err := utils.GzipEncode(state)
+	if err != nil {
+		return nil, err
+	}
+	location, err := store.Put(ctx, req.Id, data)
+	if err != nil {
+		err = fmt.Errorf("unable to store the snapshot %s: %w", req.Id, err)
+		log.Error(err, "unable to store the snapshot")
+		return nil, err
+	}
+
+	log.Info("snapshot of the state taken", "location", location, "serial", meta.Serial)
+	return &SnapshotStateReply{Location: location, Lineage: meta.Lineage, Serial: meta.Serial}, nil
+}
+
+// RestoreState replaces the state of the backend with the snapshot of the ID of the request,
+// with `terraform state push -force`, as the snapshot may be older than the state, or of another lineage.
+func (r *TerraformRunnerServer) RestoreState(ctx context.Context, req *RestoreStateRequest) (*RestoreStateReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("restoring the state", "id", req.Id)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	store, err := r.stateSnapshotStore(ctx)
+	if err != nil {
+		log.Error(err, "unable to set up the snapshot storage")
+		return nil, err
+	}
+	data, err := store.Get(ctx, req.Id)
+	if err != nil {
+		err = fmt.Errorf("unable to get the snapshot %s: %w", req.Id, err)
+		log.Error(err, "unable to get the snapshot")
+		return nil, err
+	}
+	state, err := utils.GzipDecode(data)
+	if err != nil {
+		err = fmt.Errorf("unable to decode the snapshot %s: %w", req.Id, err)
+		log.Error(err, "unable to decode the snapshot")
+		return nil, err
+	}
+	meta, err := utils.ParseStateMeta(state)
+	if err != nil {
+		log.Error(err, "unable to read the snapshot")
+		return nil, err
+	}
+
+	args := []string{"state", "push", "-force"}
+	extraArgs, err := r.extraArgs(StagePlan)
+	if err != nil {
+		log.Error(err, "invalid extra args")
+		return nil, err
+	}
+	if extraArgs.Lock != nil {
+		args = append(args, fmt.Sprintf("-lock=%t", *extraArgs.Lock))
+	}
+	if extraArgs.LockTimeout != "" {
+		args = append(args, "-lock-timeout="+extraArgs.LockTimeout)
+	}
+	if _, err := r.runStateCommand(ctx, state, append(args, "-")...); err != nil {
+		log.Error(err, "unable to push the snapshot")
+		return nil, err
+	}
+
+	log.Info("state restored", "id", req.Id, "lineage", meta.Lineage, "serial", meta.Serial)
+	return &RestoreStateReply{Lineage: meta.Lineage, Serial: meta.Serial}, nil
+}
+
+// DeleteStateSnapshots deletes the snapshots of the IDs of the request. A failed deletion does not stop
+// the next ones, and the IDs of the deleted snapshots are returned.
+func (r *TerraformRunnerServer) DeleteStateSnapshots(ctx context.Context, req *DeleteStateSnapshotsRequest) (*DeleteStateSnapshotsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("deleting state snapshots", "ids", req.Ids)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	store, err := r.stateSnapshotStore(ctx)
+	if err != nil {
+		log.Error(err, "unable to set up the snapshot storage")
+		return nil, err
+	}
+
+	deleted, message := deleteSnapshots(ctx, store, req.Ids)
+	return &DeleteStateSnapshotsReply{Deleted: deleted, Message: message}, nil
+}
@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/weaveworks/tf-controller/utils"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// workingDirSnapshotKind is the kind of the snapshots of spec.workingDirSnapshot.
+var workingDirSnapshotKind = snapshotKind{
+	name:      "workdir",
+	mediaType: "application/vnd.weaveworks.tf-controller.workdir.v1.tar+gzip",
+	extension: ".tar.gz",
+}
+
+// workingDirSnapshotStore returns the store of spec.workingDirSnapshot of the object of the runner.
+func (r *TerraformRunnerServer) workingDirSnapshotStore(ctx context.Context) (snapshotStore, error) {
+	if r.terraform == nil || r.terraform.Spec.WorkingDirSnapshot == nil {
+		return nil, fmt.Errorf("the object has no workingDirSnapshot")
+	}
+	return r.newSnapshotStore(ctx, r.terraform.Spec.WorkingDirSnapshot.SnapshotStorage, workingDirSnapshotKind)
+}
+
+// skipInWorkingDirSnapshot leaves the providers installed by init out of the snapshots, as they are large
+// and installed again by terraform init from the lock file of the snapshot.
+func skipInWorkingDirSnapshot(name string) bool {
+	dir, base := path.Split(name)
+	return (base == "providers" || base == "plugins") && path.Base(dir) == ".terraform"
+}
+
+// SnapshotWorkingDir stores the directory of the request, where the source was extracted, as a gzipped tarball
+// under the ID of the request. It holds the generated files of the run, e.g. the variables and the backend configuration.
+func (r *TerraformRunnerServer) SnapshotWorkingDir(ctx context.Context, req *SnapshotWorkingDirRequest) (*SnapshotWorkingDirReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("taking a snapshot of the working directory", "id", req.Id, "tmpDir", req.TmpDir)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	store, err := r.workingDirSnapshotStore(ctx)
+	if err != nil {
+		log.Error(err, "unable to set up the snapshot storage")
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := utils.Tar(&buf, req.TmpDir, skipInWorkingDirSnapshot); err != nil {
+		err = fmt.Errorf("unable to archive the working directory: %w", err)
+		log.Error(err, "unable to archive the working directory")
+		return nil, err
+	}
+	location, err := store.Put(ctx, req.Id, buf.Bytes())
+	if err != nil {
+		err = fmt.Errorf("unable to store the snapshot %s: %w", req.Id, err)
+		log.Error(err, "unable to store the snapshot")
+		return nil, err
+	}
+
+	log.Info("snapshot of the working directory taken", "location", location, "size", buf.Len())
+	return &SnapshotWorkingDirReply{Location: location, Size: int64(buf.Len())}, nil
+}
+
+// DeleteWorkingDirSnapshots deletes the snapshots of the IDs of the request, as DeleteStateSnapshots does.
+func (r *TerraformRunnerServer) DeleteWorkingDirSnapshots(ctx context.Context, req *DeleteWorkingDirSnapshotsRequest) (*DeleteWorkingDirSnapshotsReply, error) {
+	log := ctrl.LoggerFrom(ctx, "instance-id", r.InstanceID).WithName(loggerName)
+	log.Info("deleting working directory snapshots", "ids", req.Ids)
+	if req.TfInstance != r.InstanceID {
+		err := fmt.Errorf("no TF instance found")
+		log.Error(err, "no terraform")
+		return nil, err
+	}
+
+	store, err := r.workingDirSnapshotStore(ctx)
+	if err != nil {
+		log.Error(err, "unable to set up the snapshot storage")
+		return nil, err
+	}
+	deleted, message := deleteSnapshots(ctx, store, req.Ids)
+	return &DeleteWorkingDirSnapshotsReply{Deleted: deleted, Message: message}, nil
+}
@@ -6,9 +6,8 @@ import (
 	"io"
 
 	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	tfclient "github.com/weaveworks/tf-controller/pkg/client"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ApprovePlan approves the pending plan for a given terraform resource
@@ -27,8 +26,7 @@ func (c *CLI) ApprovePlan(out io.Writer, resource string) error {
 		return nil
 	}
 
-	err := approvePlan(context.TODO(), c.client, key, terraform)
-	if err != nil {
+	if _, err := tfclient.New(c.client).ApprovePlan(context.TODO(), key); err != nil {
 		return err
 	}
 
@@ -36,19 +34,3 @@ func (c *CLI) ApprovePlan(out io.Writer, resource string) error {
 
 	return nil
 }
-
-func approvePlan(ctx context.Context,
-	kubeClient client.Client,
-	namespacedName types.NamespacedName,
-	terraform *infrav1.Terraform,
-) error {
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
-		terraform := &infrav1.Terraform{}
-		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
-			return err
-		}
-		patch := client.MergeFrom(terraform.DeepCopy())
-		terraform.Spec.ApprovePlan = terraform.Status.Plan.Pending
-		return kubeClient.Patch(ctx, terraform, patch)
-	})
-}
@@ -12,15 +12,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Get prints information about terraform resources
-func (c *CLI) Get(out io.Writer) error {
+// Get prints information about terraform resources, of all namespaces with allNamespaces
+func (c *CLI) Get(out io.Writer, allNamespaces bool) error {
+	var opts []client.ListOption
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+
 	terraformList := &infrav1.TerraformList{}
-	if err := c.client.List(context.TODO(), terraformList, client.InNamespace(c.namespace)); err != nil {
+	if err := c.client.List(context.TODO(), terraformList, opts...); err != nil {
 		return err
 	}
 
 	if len(terraformList.Items) == 0 {
-		fmt.Fprintf(out, "No resources found in %s namespace\n", c.namespace)
+		if allNamespaces {
+			fmt.Fprintln(out, "No resources found")
+		} else {
+			fmt.Fprintf(out, "No resources found in %s namespace\n", c.namespace)
+		}
 		return nil
 	}
 
@@ -33,16 +42,25 @@ func (c *CLI) Get(out io.Writer) error {
 				break
 			}
 		}
-		data = append(data, []string{
+		row := []string{
 			terraform.Name,
 			string(readyCondition.Status),
 			readyCondition.Message,
+			terraform.Status.LastAppliedRevision,
+			strconv.FormatBool(terraform.HasDrift()),
 			strconv.FormatBool(terraform.Status.Plan.Pending != ""),
 			strconv.FormatBool(terraform.Spec.Suspend),
-		})
+		}
+		if allNamespaces {
+			row = append([]string{terraform.Namespace}, row...)
+		}
+		data = append(data, row)
 	}
 
-	header := []string{"Name", "Ready", "Message", "Plan Pending", "Suspended"}
+	header := []string{"Name", "Ready", "Message", "Applied Revision", "Drift Detected", "Plan Pending", "Suspended"}
+	if allNamespaces {
+		header = append([]string{"Namespace"}, header...)
+	}
 	table := newTablePrinter(out, header)
 	table.AppendBulk(data)
 	table.Render()
@@ -31,7 +31,7 @@ func (c *CLI) GetTerraform(out io.Writer, resource string) error {
 		}
 	}
 
-	header := []string{"Name", "Ready", "Message", "Drift Detected", "Suspended"}
+	header := []string{"Name", "Ready", "Message", "Applied Revision", "Drift Detected", "Suspended"}
 
 	table := newTablePrinter(out, header)
 
@@ -39,6 +39,7 @@ func (c *CLI) GetTerraform(out io.Writer, resource string) error {
 		terraform.Name,
 		string(readyCondition.Status),
 		readyCondition.Message,
+		terraform.Status.LastAppliedRevision,
 		strconv.FormatBool(terraform.HasDrift()),
 		strconv.FormatBool(terraform.Spec.Suspend),
 	})
@@ -51,6 +51,7 @@ func (c *CLI) Init(k8sConfig *rest.Config, config *viper.Viper) error {
 		return err
 	}
 
+	c.restConfig = k8sConfig
 	c.client = client
 	c.namespace = config.GetString("namespace")
 	c.terraform = config.GetString("terraform")
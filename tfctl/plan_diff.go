@@ -0,0 +1,102 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	"github.com/weaveworks/tf-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// the ANSI colors of the lines of a plan diff, by their first character
+var diffColors = map[byte]string{
+	'+': "\x1b[32m",
+	'-': "\x1b[31m",
+	'!': "\x1b[33m",
+}
+
+const colorReset = "\x1b[0m"
+
+// ShowPlanDiff displays the pending plan of the given Terraform resource as a diff, in color unless noColor is set.
+// The plan is read from the readable plan ConfigMap of storeReadablePlan: human or diff if it matches the pending plan,
+// and rendered from the plan Secret with the terraform binary otherwise.
+func (c *CLI) ShowPlanDiff(out io.Writer, resource string, noColor bool) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+		return fmt.Errorf("resource %s not found", resource)
+	}
+
+	if terraform.Status.Plan.Pending == "" {
+		fmt.Fprintln(out, "There is no plan pending.")
+		return nil
+	}
+
+	diff, err := c.readablePlanDiff(terraform)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		human, err := c.renderPlan(out, terraform)
+		if err != nil {
+			return err
+		}
+		diff = utils.PlanDiff(human)
+	}
+
+	if !noColor {
+		diff = colorPlanDiff(diff)
+	}
+	fmt.Fprintln(out, diff)
+
+	return nil
+}
+
+// readablePlanDiff returns the diff of the readable plan ConfigMap of the pending plan, or an empty string
+// if the ConfigMap is missing, holds another plan, or holds another format.
+func (c *CLI) readablePlanDiff(terraform *infrav1.Terraform) (string, error) {
+	format := terraform.Spec.StoreReadablePlan
+	if format != "human" && format != "diff" {
+		return "", nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMapKey := types.NamespacedName{
+		Name:      fmt.Sprintf("tfplan-%s-%s", terraform.WorkspaceName(), terraform.Name),
+		Namespace: c.namespace,
+	}
+	if err := c.client.Get(context.TODO(), configMapKey, configMap); err != nil {
+		return "", nil
+	}
+	if configMap.Annotations[runner.SavedPlanSecretAnnotation] != terraform.Status.Plan.Pending {
+		return "", nil
+	}
+
+	plan := configMap.Data[runner.TFPlanName]
+	if format == "human" {
+		plan = utils.PlanDiff(plan)
+	}
+	return plan, nil
+}
+
+// colorPlanDiff colors the added, removed and changed lines of a plan diff.
+func colorPlanDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if color, ok := diffColors[line[0]]; ok {
+			lines[i] = color + line + colorReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,60 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"github.com/weaveworks/tf-controller/runner"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestShowPlanDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	terraform := &infrav1.Terraform{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world", Namespace: "default"},
+		Spec:       infrav1.TerraformSpec{StoreReadablePlan: "human"},
+		Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"}},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tfplan-default-hello-world",
+			Namespace:   "default",
+			Annotations: map[string]string{runner.SavedPlanSecretAnnotation: "plan-main-b8e362c206"},
+		},
+		Data: map[string]string{runner.TFPlanName: "  # null_resource.hello will be created\n  + resource \"null_resource\" \"hello\" {\n    }\n"},
+	}
+	c := CLI{
+		namespace: "default",
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform, configMap).Build(),
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.ShowPlanDiff(&out, "hello-world", true)).To(Succeed())
+	g.Expect(out.String()).To(Equal("  # null_resource.hello will be created\n+   resource \"null_resource\" \"hello\" {\n    }\n\n"))
+
+	out.Reset()
+	g.Expect(c.ShowPlanDiff(&out, "hello-world", false)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("\x1b[32m+   resource \"null_resource\" \"hello\" {\x1b[0m\n"))
+
+	// the readable plan of another plan is not shown, the plan Secret is rendered instead
+	configMap.Annotations[runner.SavedPlanSecretAnnotation] = "plan-main-0a1b2c3d4e"
+	c.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(terraform, configMap).Build()
+	g.Expect(c.ShowPlanDiff(&out, "hello-world", true)).To(MatchError("plan for resource hello-world not found"))
+}
+
+func TestColorPlanDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(colorPlanDiff("+ added\n- removed\n! changed\n  kept\n")).To(Equal(
+		"\x1b[32m+ added\x1b[0m\n\x1b[31m- removed\x1b[0m\n\x1b[33m! changed\x1b[0m\n  kept\n"))
+}
@@ -7,45 +7,50 @@ import (
 	"time"
 
 	"github.com/fluxcd/pkg/apis/meta"
-	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	tfclient "github.com/weaveworks/tf-controller/pkg/client"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Reconcile annotates the given object
-func (c *CLI) Reconcile(out io.Writer, resource string) error {
+// reconcilePollInterval is the interval between the checks of the object, while waiting for its reconciliation.
+var reconcilePollInterval = tfclient.DefaultPollInterval
+
+// Reconcile annotates the given object. With wait, it also waits for the reconciliation to complete,
+// failing if the object is not ready at the end of it or after the timeout.
+func (c *CLI) Reconcile(out io.Writer, resource string, wait bool, timeout time.Duration) error {
 	key := types.NamespacedName{
 		Name:      resource,
 		Namespace: c.namespace,
 	}
 
-	err := requestReconciliation(context.TODO(), c.client, key)
+	tfClient := &tfclient.Client{Client: c.client, PollInterval: reconcilePollInterval}
+	requestedAt, err := tfClient.RequestReconciliation(context.TODO(), key)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(out, " Reconcile requested for %s/%s\n", c.namespace, resource)
+	fmt.Fprintf(out, " Reconcile requested for %s/%s\n", c.namespace, resource)
+	if !wait {
+		return nil
+	}
 
-	return nil
-}
+	fmt.Fprintf(out, " Waiting for the reconciliation of %s/%s\n", c.namespace, resource)
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+	terraform, err := tfClient.WaitForReconciliation(ctx, key, requestedAt)
+	if err != nil {
+		return err
+	}
 
-func requestReconciliation(ctx context.Context, kubeClient client.Client,
-	namespacedName types.NamespacedName) error {
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() (err error) {
-		terraform := &infrav1.Terraform{}
-		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
-			return err
-		}
-		patch := client.MergeFrom(terraform.DeepCopy())
-		if ann := terraform.GetAnnotations(); ann == nil {
-			terraform.SetAnnotations(map[string]string{
-				meta.ReconcileRequestAnnotation: time.Now().Format(time.RFC3339Nano),
-			})
-		} else {
-			ann[meta.ReconcileRequestAnnotation] = time.Now().Format(time.RFC3339Nano)
-			terraform.SetAnnotations(ann)
-		}
-		return kubeClient.Patch(ctx, terraform, patch)
-	})
+	ready := apimeta.FindStatusCondition(terraform.Status.Conditions, meta.ReadyCondition)
+	switch {
+	case ready.Status == metav1.ConditionFalse:
+		return fmt.Errorf("reconciliation failed: %s", ready.Message)
+	case terraform.Status.Plan.Pending != "":
+		fmt.Fprintf(out, " Reconciliation completed, plan %s is pending\n", terraform.Status.Plan.Pending)
+	default:
+		fmt.Fprintf(out, " Reconciliation completed, applied revision %s\n", terraform.Status.LastAppliedRevision)
+	}
+	return nil
 }
@@ -0,0 +1,59 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileAndWait(t *testing.T) {
+	g := NewWithT(t)
+	reconcilePollInterval = 10 * time.Millisecond
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	c := CLI{
+		namespace: "flux-system",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+		}).Build(),
+	}
+
+	// the controller fails to reconcile the object once requested
+	go func() {
+		key := types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}
+		for {
+			var terraform infrav1.Terraform
+			if err := c.client.Get(context.TODO(), key, &terraform); err != nil {
+				return
+			}
+			if requestedAt, ok := terraform.Annotations[meta.ReconcileRequestAnnotation]; ok {
+				terraform = infrav1.TerraformNotReady(terraform, "main/abc", infrav1.TFExecPlanFailedReason, "error running Plan")
+				terraform.Status.LastHandledReconcileAt = requestedAt
+				c.client.Status().Update(context.TODO(), &terraform)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var out bytes.Buffer
+	err := c.Reconcile(&out, "helloworld", true, 5*time.Second)
+	g.Expect(err).To(MatchError("reconciliation failed: error running Plan"))
+
+	g.Expect(c.Reconcile(&out, "helloworld", false, 0)).To(Succeed())
+
+	out.Reset()
+	g.Expect(c.Get(&out, true)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("flux-system"))
+	g.Expect(out.String()).To(ContainSubstring("error running Plan"))
+}
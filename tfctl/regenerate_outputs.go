@@ -0,0 +1,54 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegenerateOutputs requests the outputs Secret of the given Terraform resource to be written again from its state,
+// without a plan or an apply.
+func (c *CLI) RegenerateOutputs(out io.Writer, resource string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	secretName, err := requestOutputsRegeneration(context.TODO(), c.client, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " Regeneration of the outputs Secret %s requested for %s/%s\n", secretName, c.namespace, resource)
+
+	return nil
+}
+
+func requestOutputsRegeneration(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName) (string, error) {
+	var secretName string
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		terraform := &infrav1.Terraform{}
+		if err := kubeClient.Get(ctx, namespacedName, terraform); err != nil {
+			return err
+		}
+		if terraform.Spec.WriteOutputsToSecret == nil {
+			return fmt.Errorf("%s/%s does not write its outputs to a Secret", namespacedName.Namespace, namespacedName.Name)
+		}
+		secretName = terraform.Spec.WriteOutputsToSecret.Name
+		patch := client.MergeFrom(terraform.DeepCopy())
+		annotations := terraform.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[infrav1.RegenerateOutputsAnnotation] = time.Now().Format(time.RFC3339Nano)
+		terraform.SetAnnotations(annotations)
+		return kubeClient.Patch(ctx, terraform, patch)
+	})
+	return secretName, err
+}
@@ -0,0 +1,45 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRegenerateOutputs(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	c := CLI{
+		namespace: "flux-system",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+				Spec: infrav1.TerraformSpec{
+					WriteOutputsToSecret: &infrav1.WriteOutputsToSecretSpec{Name: "helloworld-outputs"},
+				},
+			},
+			&infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-outputs", Namespace: "flux-system"},
+			},
+		).Build(),
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.RegenerateOutputs(&out, "helloworld")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("outputs Secret helloworld-outputs requested for flux-system/helloworld"))
+
+	var terraform infrav1.Terraform
+	g.Expect(c.client.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &terraform)).To(Succeed())
+	g.Expect(terraform.Annotations).To(HaveKey(infrav1.RegenerateOutputsAnnotation))
+
+	g.Expect(c.RegenerateOutputs(&out, "no-outputs")).To(MatchError("flux-system/no-outputs does not write its outputs to a Secret"))
+}
@@ -0,0 +1,31 @@
+package tfctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	tfclient "github.com/weaveworks/tf-controller/pkg/client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Replan discards the pending plan of the given terraform resource, and requests a new plan
+func (c *CLI) Replan(out io.Writer, resource string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+
+	discarded, _, err := tfclient.New(c.client).Replan(context.TODO(), key)
+	if errors.Is(err, tfclient.ErrNoPendingPlan) {
+		fmt.Fprintln(out, "no plan pending")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, " Plan %s discarded, replan requested for %s/%s\n", discarded, c.namespace, resource)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReplan(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	c := CLI{
+		namespace: "flux-system",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&infrav1.Terraform{
+				ObjectMeta: metav1.ObjectMeta{Name: "helloworld", Namespace: "flux-system"},
+				Status:     infrav1.TerraformStatus{Plan: infrav1.PlanStatus{Pending: "plan-main-b8e362c206"}},
+			},
+		).Build(),
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.Replan(&out, "helloworld")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("Plan plan-main-b8e362c206 discarded, replan requested for flux-system/helloworld"))
+
+	var terraform infrav1.Terraform
+	g.Expect(c.client.Get(context.TODO(), types.NamespacedName{Namespace: "flux-system", Name: "helloworld"}, &terraform)).To(Succeed())
+	g.Expect(terraform.Status.Plan.Pending).To(BeEmpty())
+	g.Expect(terraform.Annotations).To(HaveKey(meta.ReconcileRequestAnnotation))
+
+	out.Reset()
+	g.Expect(c.Replan(&out, "helloworld")).To(Succeed())
+	g.Expect(out.String()).To(Equal("no plan pending\n"))
+}
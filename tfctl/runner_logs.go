@@ -0,0 +1,33 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// RunnerLogs prints the logs of the runner pod of the given Terraform resource, and follows them with follow.
+func (c *CLI) RunnerLogs(out io.Writer, resource string, follow bool) error {
+	clientset, err := kubernetes.NewForConfig(c.restConfig)
+	if err != nil {
+		return err
+	}
+	return streamRunnerLogs(context.TODO(), clientset.CoreV1(), out, c.namespace, resource, follow)
+}
+
+func streamRunnerLogs(ctx context.Context, pods corev1client.PodsGetter, out io.Writer, namespace, resource string, follow bool) error {
+	// the name of the runner pod, as created by the controller
+	podName := fmt.Sprintf("%s-tf-runner", resource)
+	stream, err := pods.Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Follow: follow}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read the logs of the runner pod %s/%s: %w", namespace, podName, err)
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(out, stream)
+	return err
+}
@@ -0,0 +1,25 @@
+package tfctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStreamRunnerLogs(t *testing.T) {
+	g := NewWithT(t)
+
+	clientset := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-world-tf-runner", Namespace: "default"},
+	})
+
+	var out bytes.Buffer
+	g.Expect(streamRunnerLogs(context.TODO(), clientset.CoreV1(), &out, "default", "hello-world", false)).To(Succeed())
+	// the fake clientset returns the same logs for all pods
+	g.Expect(out.String()).To(Equal("fake logs"))
+}
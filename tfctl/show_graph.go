@@ -0,0 +1,41 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ShowGraph displays the dependency graph, in the DOT format, stored for the given Terraform resource
+func (c *CLI) ShowGraph(out io.Writer, resource string) error {
+	key := types.NamespacedName{
+		Name:      resource,
+		Namespace: c.namespace,
+	}
+	terraform := &infrav1.Terraform{}
+	if err := c.client.Get(context.TODO(), key, terraform); err != nil {
+		return fmt.Errorf("resource %s not found", resource)
+	}
+
+	graphKey := types.NamespacedName{
+		Name:      fmt.Sprintf("tfgraph-%s-%s", terraform.WorkspaceName(), resource),
+		Namespace: c.namespace,
+	}
+
+	graphCM := &corev1.ConfigMap{}
+	if err := c.client.Get(context.TODO(), graphKey, graphCM); err != nil {
+		if apierrors.IsNotFound(err) {
+			fmt.Fprintf(out, "There is no graph stored for resource %s, set .spec.storeGraph to store it.\n", resource)
+			return nil
+		}
+		return fmt.Errorf("failed to get the graph for resource %s: %w", resource, err)
+	}
+
+	fmt.Fprint(out, graphCM.Data["tfgraph"])
+	return nil
+}
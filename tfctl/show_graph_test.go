@@ -0,0 +1,45 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestShowGraph(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	const dot = "digraph {\n\t\"null_resource.a\"\n}\n"
+	c := CLI{
+		namespace: "flux-system",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "with-graph", Namespace: "flux-system"}},
+			&infrav1.Terraform{ObjectMeta: metav1.ObjectMeta{Name: "without-graph", Namespace: "flux-system"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "tfgraph-default-with-graph", Namespace: "flux-system"},
+				Data:       map[string]string{"tfgraph": dot},
+			},
+		).Build(),
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.ShowGraph(&out, "with-graph")).To(Succeed())
+	g.Expect(out.String()).To(Equal(dot))
+
+	out.Reset()
+	g.Expect(c.ShowGraph(&out, "without-graph")).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("There is no graph stored"))
+
+	g.Expect(c.ShowGraph(&out, "missing")).ToNot(Succeed())
+}
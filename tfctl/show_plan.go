@@ -31,24 +31,36 @@ func (c *CLI) ShowPlan(out io.Writer, resource string) error {
 		return nil
 	}
 
+	result, err := c.renderPlan(out, terraform)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, result)
+
+	return nil
+}
+
+// renderPlan returns the human readable plan of the plan Secret of the Terraform resource, with the terraform binary.
+func (c *CLI) renderPlan(out io.Writer, terraform *infrav1.Terraform) (string, error) {
 	planKey := types.NamespacedName{
-		Name:      fmt.Sprintf("tfplan-%s-%s", terraform.WorkspaceName(), resource),
+		Name:      fmt.Sprintf("tfplan-%s-%s", terraform.WorkspaceName(), terraform.Name),
 		Namespace: c.namespace,
 	}
 
 	planSecret := &corev1.Secret{}
 	if err := c.client.Get(context.TODO(), planKey, planSecret); err != nil {
-		return fmt.Errorf("plan for resource %s not found", resource)
+		return "", fmt.Errorf("plan for resource %s not found", terraform.Name)
 	}
 
 	data, err := utils.GzipDecode(planSecret.Data["tfplan"])
 	if err != nil {
-		return fmt.Errorf("failed to decode plan for resources %s: %s", resource, err)
+		return "", fmt.Errorf("failed to decode plan for resources %s: %s", terraform.Name, err)
 	}
 
 	tmpDir, err := ioutil.TempDir("", "tfctl")
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	defer func() {
@@ -59,25 +71,23 @@ func (c *CLI) ShowPlan(out io.Writer, resource string) error {
 
 	planFilepath := filepath.Join(tmpDir, "tfctl-plan")
 	if err := os.WriteFile(planFilepath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write plan: %w", err)
+		return "", fmt.Errorf("failed to write plan: %w", err)
 	}
 
 	wd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to get current working directory: %w", err)
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
 	}
 
 	tf, err := tfexec.NewTerraform(wd, c.terraform)
 	if err != nil {
-		return fmt.Errorf("failed to create Terraform instance: %w", err)
+		return "", fmt.Errorf("failed to create Terraform instance: %w", err)
 	}
 
 	result, err := tf.ShowPlanFileRaw(context.TODO(), planFilepath)
 	if err != nil {
-		return fmt.Errorf("failed to parse Terraform plan: %w", err)
+		return "", fmt.Errorf("failed to parse Terraform plan: %w", err)
 	}
 
-	fmt.Fprintln(out, result)
-
-	return nil
+	return result, nil
 }
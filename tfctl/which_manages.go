@@ -0,0 +1,48 @@
+package tfctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WhichManages prints the Terraform resources whose inventory contains the cloud resource with the given identifier, e.g. an ARN
+func (c *CLI) WhichManages(out io.Writer, id string, allNamespaces bool) error {
+	var opts []client.ListOption
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+
+	terraformList := &infrav1.TerraformList{}
+	if err := c.client.List(context.TODO(), terraformList, opts...); err != nil {
+		return err
+	}
+
+	var data [][]string
+	for _, terraform := range terraformList.Items {
+		entry, ok := terraform.Status.Inventory.Find(id)
+		if !ok {
+			continue
+		}
+		data = append(data, []string{
+			terraform.Namespace,
+			terraform.Name,
+			entry.Type + "." + entry.Name,
+		})
+	}
+
+	if len(data) == 0 {
+		fmt.Fprintf(out, "No Terraform resource manages %s\n", id)
+		return nil
+	}
+
+	header := []string{"Namespace", "Name", "Resource"}
+	table := newTablePrinter(out, header)
+	table.AppendBulk(data)
+	table.Render()
+
+	return nil
+}
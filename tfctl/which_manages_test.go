@@ -0,0 +1,50 @@
+package tfctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "github.com/weaveworks/tf-controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWhichManages(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	withInventory := func(namespace, name string, entries ...infrav1.ResourceRef) *infrav1.Terraform {
+		return &infrav1.Terraform{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     infrav1.TerraformStatus{Inventory: &infrav1.ResourceInventory{Entries: entries}},
+		}
+	}
+
+	const arn = "arn:aws:s3:::my-bucket"
+	c := CLI{
+		namespace: "flux-system",
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			withInventory("flux-system", "storage", infrav1.ResourceRef{Name: "logs", Type: "aws_s3_bucket", Identifier: arn}),
+			withInventory("flux-system", "network", infrav1.ResourceRef{Name: "main", Type: "aws_vpc", Identifier: "vpc-123"}),
+			withInventory("dev", "storage", infrav1.ResourceRef{Name: "logs", Type: "aws_s3_bucket", Identifier: arn}),
+		).Build(),
+	}
+
+	var out bytes.Buffer
+	g.Expect(c.WhichManages(&out, arn, false)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("aws_s3_bucket.logs"))
+	g.Expect(out.String()).To(ContainSubstring("flux-system"))
+	g.Expect(out.String()).ToNot(ContainSubstring("dev"))
+
+	out.Reset()
+	g.Expect(c.WhichManages(&out, arn, true)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("dev"))
+
+	out.Reset()
+	g.Expect(c.WhichManages(&out, "arn:aws:s3:::unknown", true)).To(Succeed())
+	g.Expect(out.String()).To(Equal("No Terraform resource manages arn:aws:s3:::unknown\n"))
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+)
+
+// applyCompleteRegexp matches the lines of `terraform apply -no-color` reporting that the change of a resource is complete,
+// e.g. "aws_s3_bucket.logs: Creation complete after 2s [id=logs]".
+var applyCompleteRegexp = regexp.MustCompile(`^(.+?)(?: \(deposed object \S+\))?: (?:Creation|Destruction|Modifications) complete after `)
+
+// ApplyProgress counts the resources whose changes are complete, from the output of a running `terraform apply`.
+// It is written to by the apply, and read concurrently.
+type ApplyProgress struct {
+	mu        sync.Mutex
+	total     int
+	completed map[string]struct{}
+	line      []byte
+}
+
+// NewApplyProgress returns the progress of an apply of total resource changes, 0 if unknown.
+func NewApplyProgress(total int) *ApplyProgress {
+	return &ApplyProgress{total: total, completed: map[string]struct{}{}}
+}
+
+func (p *ApplyProgress) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.line = append(p.line, b...)
+	for {
+		i := bytes.IndexByte(p.line, '\n')
+		if i < 0 {
+			break
+		}
+		// a replaced resource completes twice, it is counted once
+		if match := applyCompleteRegexp.FindSubmatch(bytes.TrimRight(p.line[:i], "\r")); match != nil {
+			p.completed[string(match[1])] = struct{}{}
+		}
+		p.line = p.line[i+1:]
+	}
+	return len(b), nil
+}
+
+// Counts returns the number of resources completed so far, and the total number of resource changes.
+func (p *ApplyProgress) Counts() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.completed), p.total
+}
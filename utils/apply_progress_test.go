@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestApplyProgress(t *testing.T) {
+	g := NewWithT(t)
+
+	p := NewApplyProgress(3)
+	completed, total := p.Counts()
+	g.Expect(completed).To(BeZero())
+	g.Expect(total).To(Equal(3))
+
+	_, err := p.Write([]byte("aws_s3_bucket.logs: Creating...\naws_s3_bucket.logs: Creation complete after 2s [id=logs]\naws_instance.web: Destr"))
+	g.Expect(err).ToNot(HaveOccurred())
+	completed, _ = p.Counts()
+	g.Expect(completed).To(Equal(1))
+
+	// lines are counted once complete, and a replaced resource is counted once
+	_, err = p.Write([]byte("uction complete after 10s\r\n" +
+		"aws_instance.web: Creation complete after 30s [id=i-123]\n" +
+		"aws_instance.db (deposed object 5d3f2a1c): Destruction complete after 1m0s\n" +
+		"aws_instance.db: Modifications complete after 1s [id=i-456]\n" +
+		"module.vpc.aws_subnet.private[\"a b\"]: Still creating... [10s elapsed]\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	completed, _ = p.Counts()
+	g.Expect(completed).To(Equal(3))
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestContentHash(t *testing.T) {
+	g := NewWithT(t)
+
+	a := ContentHash(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	b := ContentHash(map[string][]byte{"b": []byte("2"), "a": []byte("1")})
+	g.Expect(a).To(Equal(b))
+	g.Expect(a).To(HavePrefix("sha256:"))
+
+	g.Expect(ContentHash(map[string][]byte{"a": []byte("12")})).ToNot(Equal(ContentHash(map[string][]byte{"a": []byte("1"), "2": nil})))
+	g.Expect(ContentHash(map[string][]byte{"a": []byte("1")})).ToNot(Equal(ContentHash(map[string][]byte{"a": []byte("2")})))
+}
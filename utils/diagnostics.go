@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is an error or a warning reported by Terraform.
+type Diagnostic struct {
+	// Severity is either error or warning.
+	Severity string
+	Summary  string
+	Detail   string
+	// Filename and Line locate the configuration the diagnostic is about, if any.
+	Filename string
+	Line     int
+}
+
+var (
+	diagnosticStartRegexp = regexp.MustCompile(`^(Error|Warning): (.+)$`)
+	diagnosticRangeRegexp = regexp.MustCompile(`^\s+on (\S+) line (\d+)`)
+)
+
+// ParseDiagnostics returns the diagnostics in the output of a Terraform command run with -no-color,
+// e.g. in the error of a failed plan. Each of them starts with an "Error: <summary>" or a "Warning: <summary>" line,
+// followed by the source it refers to, if any, then by its detail.
+func ParseDiagnostics(output string) []Diagnostic {
+	var diagnostics []Diagnostic
+	var current *Diagnostic
+	var paragraphs []string
+	var paragraph []string
+
+	endParagraph := func() {
+		if len(paragraph) > 0 {
+			paragraphs = append(paragraphs, strings.Join(paragraph, "\n"))
+			paragraph = nil
+		}
+	}
+	endDiagnostic := func() {
+		endParagraph()
+		if current != nil {
+			for i, p := range paragraphs {
+				// the source of the diagnostic comes first, with its location
+				if match := diagnosticRangeRegexp.FindStringSubmatch(p); i == 0 && match != nil {
+					current.Filename = match[1]
+					current.Line, _ = strconv.Atoi(match[2])
+					continue
+				}
+				if current.Detail != "" {
+					current.Detail += "\n\n"
+				}
+				current.Detail += p
+			}
+			diagnostics = append(diagnostics, *current)
+		}
+		current, paragraphs = nil, nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		// the diagnostics are boxed when colored
+		line = strings.TrimRight(line, " \r")
+		if strings.HasPrefix(line, "╷") || strings.HasPrefix(line, "╵") {
+			continue
+		}
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "│"), " ")
+
+		if match := diagnosticStartRegexp.FindStringSubmatch(line); match != nil {
+			endDiagnostic()
+			current = &Diagnostic{Severity: strings.ToLower(match[1]), Summary: strings.TrimSpace(match[2])}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			endParagraph()
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	endDiagnostic()
+
+	return diagnostics
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	output := `error running Plan: rpc error: code = Unknown desc = exit status 1
+
+Error: Unsupported argument
+
+  on main.tf line 3, in resource "aws_s3_bucket" "b":
+   3:   bucket_name = "b"
+
+An argument named "bucket_name" is not expected here.
+
+Warning: Deprecated attribute
+
+  on outputs.tf line 12, in output "arn":
+  12:   value = aws_s3_bucket.b.acl
+    ├────────────────
+    │ aws_s3_bucket.b is a object
+
+The attribute "acl" is deprecated.
+
+Refer to the provider documentation for details.
+
+Error: No valid credential sources found
+
+Please see https://registry.terraform.io/providers/hashicorp/aws
+for more information about providing credentials.
+`
+
+	g.Expect(ParseDiagnostics(output)).To(Equal([]Diagnostic{
+		{
+			Severity: "error",
+			Summary:  "Unsupported argument",
+			Detail:   `An argument named "bucket_name" is not expected here.`,
+			Filename: "main.tf",
+			Line:     3,
+		},
+		{
+			Severity: "warning",
+			Summary:  "Deprecated attribute",
+			Detail:   "The attribute \"acl\" is deprecated.\n\nRefer to the provider documentation for details.",
+			Filename: "outputs.tf",
+			Line:     12,
+		},
+		{
+			Severity: "error",
+			Summary:  "No valid credential sources found",
+			Detail:   "Please see https://registry.terraform.io/providers/hashicorp/aws\nfor more information about providing credentials.",
+		},
+	}))
+
+	boxed := "╷\n│ Error: Invalid reference\n│ \n│   on main.tf line 7:\n│    7:   name = foo\n│ \n│ A reference to a resource type must be followed by at least one attribute access.\n╵\n"
+	g.Expect(ParseDiagnostics(boxed)).To(Equal([]Diagnostic{{
+		Severity: "error",
+		Summary:  "Invalid reference",
+		Detail:   "A reference to a resource type must be followed by at least one attribute access.",
+		Filename: "main.tf",
+		Line:     7,
+	}}))
+
+	g.Expect(ParseDiagnostics("exit status 1")).To(BeEmpty())
+}
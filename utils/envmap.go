@@ -1,6 +1,9 @@
 package utils
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 func EnvMap(environ []string) map[string]string {
 	env := map[string]string{}
@@ -18,3 +21,13 @@ func EnvMap(environ []string) map[string]string {
 	}
 	return env
 }
+
+// MapToEnv is the inverse of EnvMap, it returns the variables sorted by name.
+func MapToEnv(env map[string]string) []string {
+	environ := make([]string, 0, len(env))
+	for k, v := range env {
+		environ = append(environ, k+"="+v)
+	}
+	sort.Strings(environ)
+	return environ
+}
@@ -10,3 +10,9 @@ func TestEnvMap(t *testing.T) {
 	g := NewWithT(t)
 	g.Expect(EnvMap([]string{"A=a", "B=b", "C"})).To(Equal(map[string]string{"A": "a", "B": "b"}))
 }
+
+func TestMapToEnv(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(MapToEnv(map[string]string{"B": "b", "A": "a=1"})).To(Equal([]string{"A=a=1", "B=b"}))
+	g.Expect(MapToEnv(nil)).To(BeEmpty())
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"regexp"
+)
+
+// KubernetesObjectRef locates an object of a cluster.
+type KubernetesObjectRef struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// kubernetesResourceKinds are the apiVersions and kinds of the typed resources of the kubernetes provider,
+// by resource type without the version suffix.
+var kubernetesResourceKinds = map[string][2]string{
+	"kubernetes_namespace":                 {"v1", "Namespace"},
+	"kubernetes_config_map":                {"v1", "ConfigMap"},
+	"kubernetes_secret":                    {"v1", "Secret"},
+	"kubernetes_service":                   {"v1", "Service"},
+	"kubernetes_service_account":           {"v1", "ServiceAccount"},
+	"kubernetes_persistent_volume":         {"v1", "PersistentVolume"},
+	"kubernetes_persistent_volume_claim":   {"v1", "PersistentVolumeClaim"},
+	"kubernetes_pod":                       {"v1", "Pod"},
+	"kubernetes_limit_range":               {"v1", "LimitRange"},
+	"kubernetes_resource_quota":            {"v1", "ResourceQuota"},
+	"kubernetes_deployment":                {"apps/v1", "Deployment"},
+	"kubernetes_stateful_set":              {"apps/v1", "StatefulSet"},
+	"kubernetes_daemon_set":                {"apps/v1", "DaemonSet"},
+	"kubernetes_daemonset":                 {"apps/v1", "DaemonSet"},
+	"kubernetes_job":                       {"batch/v1", "Job"},
+	"kubernetes_cron_job":                  {"batch/v1", "CronJob"},
+	"kubernetes_horizontal_pod_autoscaler": {"autoscaling/v2", "HorizontalPodAutoscaler"},
+	"kubernetes_ingress":                   {"networking.k8s.io/v1", "Ingress"},
+	"kubernetes_network_policy":            {"networking.k8s.io/v1", "NetworkPolicy"},
+	"kubernetes_role":                      {"rbac.authorization.k8s.io/v1", "Role"},
+	"kubernetes_role_binding":              {"rbac.authorization.k8s.io/v1", "RoleBinding"},
+	"kubernetes_cluster_role":              {"rbac.authorization.k8s.io/v1", "ClusterRole"},
+	"kubernetes_cluster_role_binding":      {"rbac.authorization.k8s.io/v1", "ClusterRoleBinding"},
+	"kubernetes_storage_class":             {"storage.k8s.io/v1", "StorageClass"},
+	"kubernetes_priority_class":            {"scheduling.k8s.io/v1", "PriorityClass"},
+}
+
+var resourceVersionSuffix = regexp.MustCompile(`_v[0-9]+(beta[0-9]+)?$`)
+
+// KubernetesObjectOf returns the object of a cluster managed by a resource, from the attribute values of
+// its state, for the typed resources and kubernetes_manifest of the kubernetes provider, and kubectl_manifest.
+// It returns nil for the other resources.
+func KubernetesObjectOf(resourceType string, values map[string]interface{}) *KubernetesObjectRef {
+	switch resourceType {
+	case "kubernetes_manifest":
+		object, _ := values["object"].(map[string]interface{})
+		if object == nil {
+			object, _ = values["manifest"].(map[string]interface{})
+		}
+		metadata, _ := object["metadata"].(map[string]interface{})
+		return objectRef(stringValue(object, "apiVersion"), stringValue(object, "kind"),
+			stringValue(metadata, "namespace"), stringValue(metadata, "name"))
+	case "kubectl_manifest":
+		return objectRef(stringValue(values, "api_version"), stringValue(values, "kind"),
+			stringValue(values, "namespace"), stringValue(values, "name"))
+	}
+
+	kind, ok := kubernetesResourceKinds[resourceVersionSuffix.ReplaceAllString(resourceType, "")]
+	if !ok {
+		return nil
+	}
+	// the metadata of the typed resources is a block
+	blocks, _ := values["metadata"].([]interface{})
+	if len(blocks) == 0 {
+		return nil
+	}
+	metadata, _ := blocks[0].(map[string]interface{})
+	return objectRef(kind[0], kind[1], stringValue(metadata, "namespace"), stringValue(metadata, "name"))
+}
+
+func objectRef(apiVersion, kind, namespace, name string) *KubernetesObjectRef {
+	if apiVersion == "" || kind == "" || name == "" {
+		return nil
+	}
+	return &KubernetesObjectRef{APIVersion: apiVersion, Kind: kind, Namespace: namespace, Name: name}
+}
+
+func stringValue(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
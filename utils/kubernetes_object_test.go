@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestKubernetesObjectOf(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(KubernetesObjectOf("kubernetes_deployment_v1", map[string]interface{}{
+		"id":       "default/web",
+		"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": "default"}},
+	})).To(Equal(&KubernetesObjectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}))
+
+	g.Expect(KubernetesObjectOf("kubernetes_namespace", map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "team-a"}},
+	})).To(Equal(&KubernetesObjectRef{APIVersion: "v1", Kind: "Namespace", Name: "team-a"}))
+
+	g.Expect(KubernetesObjectOf("kubernetes_manifest", map[string]interface{}{
+		"object": map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Issuer",
+			"metadata":   map[string]interface{}{"name": "letsencrypt", "namespace": "cert-manager"},
+		},
+	})).To(Equal(&KubernetesObjectRef{APIVersion: "cert-manager.io/v1", Kind: "Issuer", Namespace: "cert-manager", Name: "letsencrypt"}))
+
+	g.Expect(KubernetesObjectOf("kubectl_manifest", map[string]interface{}{
+		"api_version": "v1", "kind": "ConfigMap", "name": "settings", "namespace": "default",
+	})).To(Equal(&KubernetesObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "settings"}))
+
+	// the resources of other providers, and the ones without the metadata, are not objects of the cluster
+	g.Expect(KubernetesObjectOf("aws_s3_bucket", map[string]interface{}{"id": "logs"})).To(BeNil())
+	g.Expect(KubernetesObjectOf("kubernetes_secret", map[string]interface{}{})).To(BeNil())
+	g.Expect(KubernetesObjectOf("kubernetes_manifest", map[string]interface{}{"object": nil})).To(BeNil())
+}
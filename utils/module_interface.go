@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ModuleVariable is an input variable declared by a Terraform module.
+type ModuleVariable struct {
+	Name        string
+	Type        string
+	Description string
+	// Required is true when the variable has no default value.
+	Required  bool
+	Sensitive bool
+}
+
+// ModuleOutput is an output value declared by a Terraform module.
+type ModuleOutput struct {
+	Name        string
+	Description string
+	Sensitive   bool
+}
+
+// ModuleInterface holds the variables and the outputs declared by a Terraform module.
+type ModuleInterface struct {
+	Variables []ModuleVariable
+	Outputs   []ModuleOutput
+}
+
+var moduleInterfaceSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "output", LabelNames: []string{"name"}},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"},
+		{Name: "description"},
+		{Name: "default"},
+		{Name: "sensitive"},
+	},
+}
+
+var outputSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "description"},
+		{Name: "sensitive"},
+	},
+}
+
+// LoadModuleInterface parses the .tf and .tf.json files of the module in dir, without evaluating them,
+// and returns the variables and the outputs they declare, sorted by name.
+func LoadModuleInterface(dir string) (*ModuleInterface, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	result := &ModuleInterface{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		switch {
+		case strings.HasSuffix(name, ".tf"):
+			file, diags = parser.ParseHCLFile(filepath.Join(dir, name))
+		case strings.HasSuffix(name, ".tf.json"):
+			file, diags = parser.ParseJSONFile(filepath.Join(dir, name))
+		default:
+			continue
+		}
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse %s: %w", name, diags)
+		}
+
+		content, _, diags := file.Body.PartialContent(moduleInterfaceSchema)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to read %s: %w", name, diags)
+		}
+
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "variable":
+				attrs, _, _ := block.Body.PartialContent(variableSchema)
+				_, hasDefault := attrs.Attributes["default"]
+				result.Variables = append(result.Variables, ModuleVariable{
+					Name:        block.Labels[0],
+					Type:        typeSource(attrs.Attributes["type"], file.Bytes),
+					Description: stringAttribute(attrs.Attributes["description"]),
+					Required:    !hasDefault,
+					Sensitive:   boolAttribute(attrs.Attributes["sensitive"]),
+				})
+			case "output":
+				attrs, _, _ := block.Body.PartialContent(outputSchema)
+				result.Outputs = append(result.Outputs, ModuleOutput{
+					Name:        block.Labels[0],
+					Description: stringAttribute(attrs.Attributes["description"]),
+					Sensitive:   boolAttribute(attrs.Attributes["sensitive"]),
+				})
+			}
+		}
+	}
+
+	sort.Slice(result.Variables, func(i, j int) bool { return result.Variables[i].Name < result.Variables[j].Name })
+	sort.Slice(result.Outputs, func(i, j int) bool { return result.Outputs[i].Name < result.Outputs[j].Name })
+	return result, nil
+}
+
+// typeSource returns the type constraint as written, e.g. list(string), as types are not values.
+func typeSource(attr *hcl.Attribute, src []byte) string {
+	if attr == nil {
+		return ""
+	}
+
+	// in JSON files, the type constraint is given as a string
+	if v, diags := attr.Expr.Value(nil); !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+		return v.AsString()
+	}
+	return string(attr.Expr.Range().SliceBytes(src))
+}
+
+func stringAttribute(attr *hcl.Attribute) string {
+	if attr == nil {
+		return ""
+	}
+
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || v.Type() != cty.String || !v.IsKnown() || v.IsNull() {
+		return ""
+	}
+	return v.AsString()
+}
+
+func boolAttribute(attr *hcl.Attribute) bool {
+	if attr == nil {
+		return false
+	}
+
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || v.Type() != cty.Bool || !v.IsKnown() || v.IsNull() {
+		return false
+	}
+	return v.True()
+}
+
+// MissingVariables returns the names of the required variables that are not provided, sorted by name.
+func (mi *ModuleInterface) MissingVariables(provided map[string]bool) []string {
+	var missing []string
+	for _, v := range mi.Variables {
+		if v.Required && !provided[v.Name] {
+			missing = append(missing, v.Name)
+		}
+	}
+	return missing
+}
+
+// UndeclaredVariables returns the names of the supplied variables that the module does not declare, sorted by name.
+func (mi *ModuleInterface) UndeclaredVariables(supplied []string) []string {
+	declared := map[string]bool{}
+	for _, v := range mi.Variables {
+		declared[v.Name] = true
+	}
+
+	var undeclared []string
+	for _, name := range supplied {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
+
+// ProvidedVariables returns the names of the variables that Terraform loads automatically for the module in dir,
+// from the terraform.tfvars and *.auto.tfvars files, in both HCL and JSON, and from the TF_VAR_ environment variables.
+func ProvidedVariables(dir string, env map[string]string) (map[string]bool, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	provided := map[string]bool{}
+	for k := range env {
+		if name := strings.TrimPrefix(k, "TF_VAR_"); name != k && name != "" {
+			provided[name] = true
+		}
+	}
+
+	parser := hclparse.NewParser()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		switch {
+		case name == "terraform.tfvars" || strings.HasSuffix(name, ".auto.tfvars"):
+			file, diags = parser.ParseHCLFile(filepath.Join(dir, name))
+		case name == "terraform.tfvars.json" || strings.HasSuffix(name, ".auto.tfvars.json"):
+			file, diags = parser.ParseJSONFile(filepath.Join(dir, name))
+		default:
+			continue
+		}
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse %s: %w", name, diags)
+		}
+
+		attrs, diags := file.Body.JustAttributes()
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to read %s: %w", name, diags)
+		}
+		for variable := range attrs {
+			provided[variable] = true
+		}
+	}
+
+	return provided, nil
+}
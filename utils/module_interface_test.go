@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLoadModuleInterface(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(`
+variable "region" {
+  type        = string
+  description = "The region of the bucket"
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+  validation {
+    condition     = length(var.password) > 8
+    error_message = "The password is too short."
+  }
+}
+`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "outputs.tf.json"), []byte(`{
+  "variable": {"subnets": {"type": "list(string)", "default": []}},
+  "output": {"arn": {"value": "${aws_s3_bucket.b.arn}", "description": "The ARN of the bucket"}}
+}`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte(`variable "ignored" {}`), 0644)).To(Succeed())
+
+	mi, err := LoadModuleInterface(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mi.Variables).To(Equal([]ModuleVariable{
+		{Name: "password", Type: "string", Required: true, Sensitive: true},
+		{Name: "region", Type: "string", Description: "The region of the bucket", Required: true},
+		{Name: "subnets", Type: "list(string)"},
+		{Name: "tags", Type: "map(string)"},
+	}))
+	g.Expect(mi.Outputs).To(Equal([]ModuleOutput{
+		{Name: "arn", Description: "The ARN of the bucket"},
+	}))
+
+	g.Expect(os.WriteFile(filepath.Join(dir, "broken.tf"), []byte(`variable "x" {`), 0644)).To(Succeed())
+	_, err = LoadModuleInterface(dir)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestProvidedVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte(`region = "eu-west-1"`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "generated.auto.tfvars.json"), []byte(`{"tags": {"env": "dev"}}`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "dev.tfvars"), []byte(`password = "not loaded"`), 0644)).To(Succeed())
+
+	provided, err := ProvidedVariables(dir, map[string]string{"TF_VAR_subnets": "[]", "HOME": "/home/runner"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(provided).To(Equal(map[string]bool{"region": true, "tags": true, "subnets": true}))
+
+	mi := &ModuleInterface{Variables: []ModuleVariable{
+		{Name: "password", Required: true},
+		{Name: "region", Required: true},
+		{Name: "tags"},
+		{Name: "token", Required: true},
+	}}
+	g.Expect(mi.MissingVariables(provided)).To(Equal([]string{"password", "token"}))
+	g.Expect(mi.UndeclaredVariables([]string{"tags", "enviroment", "region", "accout"})).To(Equal([]string{"accout", "enviroment"}))
+	g.Expect(mi.UndeclaredVariables(nil)).To(BeEmpty())
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// PlanChanges returns the resource changes of a plan, as sorted "<actions> <address>" entries.
+// Resources that are left untouched, or only read, are not included.
+func PlanChanges(plan *tfjson.Plan) []string {
+	if plan == nil {
+		return nil
+	}
+
+	changes := []string{}
+	for _, rc := range plan.ResourceChanges {
+		if rc.Change == nil || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+			continue
+		}
+
+		var action string
+		switch {
+		case rc.Change.Actions.Replace():
+			action = "replace"
+		case rc.Change.Actions.Create():
+			action = "create"
+		case rc.Change.Actions.Update():
+			action = "update"
+		case rc.Change.Actions.Delete():
+			action = "delete"
+		default:
+			actions := make([]string, len(rc.Change.Actions))
+			for i, a := range rc.Change.Actions {
+				actions[i] = string(a)
+			}
+			action = strings.Join(actions, ",")
+		}
+		changes = append(changes, action+" "+rc.Address)
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// DiffPlanChanges returns the changes of the current plan that the previous plan did not have,
+// and the changes of the previous plan that the current plan no longer has.
+func DiffPlanChanges(previous, current []string) (added []string, removed []string) {
+	inPrevious := make(map[string]struct{}, len(previous))
+	for _, c := range previous {
+		inPrevious[c] = struct{}{}
+	}
+	inCurrent := make(map[string]struct{}, len(current))
+	for _, c := range current {
+		inCurrent[c] = struct{}{}
+		if _, ok := inPrevious[c]; !ok {
+			added = append(added, c)
+		}
+	}
+	for _, c := range previous {
+		if _, ok := inCurrent[c]; !ok {
+			removed = append(removed, c)
+		}
+	}
+	return added, removed
+}
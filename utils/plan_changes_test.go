@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+)
+
+func TestPlanChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{Address: "b.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}}},
+			{Address: "a.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}}},
+			{Address: "c.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}}},
+			{Address: "d.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}}},
+			{Address: "e.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}}},
+			{Address: "data.f.x", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionRead}}},
+		},
+	}
+	g.Expect(PlanChanges(plan)).To(Equal([]string{"create a.x", "delete e.x", "replace d.x", "update b.x"}))
+	g.Expect(PlanChanges(&tfjson.Plan{})).To(BeEmpty())
+}
+
+func TestDiffPlanChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	added, removed := DiffPlanChanges(
+		[]string{"create a.x", "update b.x", "delete c.x"},
+		[]string{"create a.x", "replace b.x", "create d.x"},
+	)
+	g.Expect(added).To(Equal([]string{"replace b.x", "create d.x"}))
+	g.Expect(removed).To(Equal([]string{"update b.x", "delete c.x"}))
+
+	added, removed = DiffPlanChanges([]string{"create a.x"}, []string{"create a.x"})
+	g.Expect(added).To(BeEmpty())
+	g.Expect(removed).To(BeEmpty())
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// diffMarkers maps the change markers of the human readable plan, longest first,
+// to the markers highlighted by the diff syntax.
+var diffMarkers = []struct{ plan, diff string }{
+	{"-/+", "!"},
+	{"+/-", "!"},
+	{"<=", " "},
+	{"+", "+"},
+	{"-", "-"},
+	{"~", "!"},
+}
+
+// PlanDiff returns the human readable plan with the change markers moved to the first column,
+// so that the plan is highlighted as a diff, e.g. in a ```diff block.
+func PlanDiff(human string) string {
+	lines := strings.Split(human, "\n")
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		rest := line[indent:]
+		for _, m := range diffMarkers {
+			if !strings.HasPrefix(rest, m.plan+" ") {
+				continue
+			}
+			// the marker is replaced by spaces to keep the alignment of the lines
+			padding := indent + len(m.plan) - len(m.diff)
+			if padding < 0 {
+				padding = 0
+			}
+			lines[i] = m.diff + strings.Repeat(" ", padding) + rest[len(m.plan):]
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanMarkdown returns a Markdown summary of the plan, e.g. for a pull request comment or a chat message,
+// with the table of its resource changes and the human readable plan, as a diff, in a collapsed section.
+func PlanMarkdown(plan *tfjson.Plan, human string) string {
+	changes := PlanChanges(plan)
+
+	var add, change, destroy int
+	for _, c := range changes {
+		switch strings.SplitN(c, " ", 2)[0] {
+		case "create":
+			add++
+		case "update":
+			change++
+		case "delete":
+			destroy++
+		case "replace":
+			add++
+			destroy++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Terraform plan\n\n")
+	if len(changes) == 0 {
+		sb.WriteString("No changes.\n")
+	} else {
+		fmt.Fprintf(&sb, "Plan: **%d** to add, **%d** to change, **%d** to destroy.\n\n", add, change, destroy)
+		sb.WriteString("| Action | Resource |\n|--------|----------|\n")
+		for _, c := range changes {
+			parts := strings.SplitN(c, " ", 2)
+			fmt.Fprintf(&sb, "| %s | `%s` |\n", parts[0], parts[1])
+		}
+	}
+
+	sb.WriteString("\n<details><summary>Show plan</summary>\n\n```diff\n")
+	sb.WriteString(strings.TrimRight(PlanDiff(human), "\n"))
+	sb.WriteString("\n```\n\n</details>\n")
+	return sb.String()
+}
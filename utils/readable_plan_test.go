@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+)
+
+const humanPlan = `Terraform will perform the following actions:
+
+  # null_resource.a will be created
+  + resource "null_resource" "a" {
+      + id = (known after apply)
+    }
+
+  # null_resource.b must be replaced
+-/+ resource "null_resource" "b" {
+      ~ id       = "1234" -> (known after apply)
+      - triggers = {
+          - "x" = "1"
+        } -> null # forces replacement
+    }
+
+Plan: 2 to add, 0 to change, 1 to destroy.
+`
+
+func TestPlanDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(PlanDiff(humanPlan)).To(Equal(`Terraform will perform the following actions:
+
+  # null_resource.a will be created
++   resource "null_resource" "a" {
++       id = (known after apply)
+    }
+
+  # null_resource.b must be replaced
+!   resource "null_resource" "b" {
+!       id       = "1234" -> (known after apply)
+-       triggers = {
+-           "x" = "1"
+        } -> null # forces replacement
+    }
+
+Plan: 2 to add, 0 to change, 1 to destroy.
+`))
+}
+
+func TestPlanMarkdown(t *testing.T) {
+	g := NewWithT(t)
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{Address: "null_resource.a", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}}},
+			{Address: "null_resource.b", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}}},
+		},
+	}
+	markdown := PlanMarkdown(plan, humanPlan)
+	g.Expect(markdown).To(HavePrefix("### Terraform plan\n\nPlan: **2** to add, **0** to change, **1** to destroy.\n\n" +
+		"| Action | Resource |\n|--------|----------|\n| create | `null_resource.a` |\n| replace | `null_resource.b` |\n"))
+	g.Expect(markdown).To(ContainSubstring("```diff\nTerraform will perform the following actions:\n"))
+	g.Expect(markdown).To(HaveSuffix("Plan: 2 to add, 0 to change, 1 to destroy.\n```\n\n</details>\n"))
+
+	g.Expect(PlanMarkdown(&tfjson.Plan{}, "No changes.")).To(HavePrefix("### Terraform plan\n\nNo changes.\n"))
+}
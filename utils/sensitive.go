@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// SensitiveValueMask replaces the values Terraform marked as sensitive.
+// It is the same text Terraform prints for them in a human readable plan.
+const SensitiveValueMask = "(sensitive value)"
+
+// minSensitiveValueLength keeps very short values, which would match almost
+// any text, out of the substring scrubbing done by ScrubSensitiveValues.
+const minSensitiveValueLength = 4
+
+// MaskSensitivePlan replaces, in place, every value of the plan that Terraform
+// marked as sensitive with SensitiveValueMask. It returns the string values it
+// masked, so that they can also be scrubbed from the plain text renderings
+// of the same plan.
+func MaskSensitivePlan(plan *tfjson.Plan) []string {
+	if plan == nil {
+		return nil
+	}
+
+	found := map[string]struct{}{}
+
+	for _, rc := range plan.ResourceChanges {
+		maskChange(rc.Change, found)
+	}
+	for _, change := range plan.OutputChanges {
+		maskChange(change, found)
+	}
+
+	if plan.Config != nil && plan.Config.RootModule != nil {
+		for name, v := range plan.Config.RootModule.Variables {
+			if pv, ok := plan.Variables[name]; ok && v.Sensitive && pv != nil {
+				pv.Value = maskValue(pv.Value, true, found)
+			}
+		}
+	}
+
+	maskStateValues(plan.PlannedValues, found)
+	if plan.PriorState != nil {
+		maskStateValues(plan.PriorState.Values, found)
+	}
+
+	values := make([]string, 0, len(found))
+	for v := range found {
+		values = append(values, v)
+	}
+	// longest first, so that a value containing another one is scrubbed as a whole
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// ScrubSensitiveValues replaces every occurrence of the given values in text with SensitiveValueMask.
+func ScrubSensitiveValues(text string, values []string) string {
+	for _, v := range values {
+		if len(v) < minSensitiveValueLength {
+			continue
+		}
+		text = strings.ReplaceAll(text, v, SensitiveValueMask)
+	}
+	return text
+}
+
+func maskChange(change *tfjson.Change, found map[string]struct{}) {
+	if change == nil {
+		return
+	}
+	change.Before = maskValue(change.Before, change.BeforeSensitive, found)
+	change.After = maskValue(change.After, change.AfterSensitive, found)
+}
+
+func maskStateValues(values *tfjson.StateValues, found map[string]struct{}) {
+	if values == nil {
+		return
+	}
+	for _, output := range values.Outputs {
+		if output != nil && output.Sensitive {
+			output.Value = maskValue(output.Value, true, found)
+		}
+	}
+	maskStateModule(values.RootModule, found)
+}
+
+func maskStateModule(module *tfjson.StateModule, found map[string]struct{}) {
+	if module == nil {
+		return
+	}
+	for _, resource := range module.Resources {
+		if resource == nil || len(resource.SensitiveValues) == 0 {
+			continue
+		}
+		var mask map[string]interface{}
+		if err := json.Unmarshal(resource.SensitiveValues, &mask); err != nil {
+			continue
+		}
+		for k, v := range resource.AttributeValues {
+			resource.AttributeValues[k] = maskValue(v, mask[k], found)
+		}
+	}
+	for _, child := range module.ChildModules {
+		maskStateModule(child, found)
+	}
+}
+
+// maskValue walks value along the sensitivity mask Terraform produces
+// (true for sensitive leaves, nested objects and lists for the rest).
+func maskValue(value interface{}, mask interface{}, found map[string]struct{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch m := mask.(type) {
+	case bool:
+		if m {
+			collectStrings(value, found)
+			return SensitiveValueMask
+		}
+	case map[string]interface{}:
+		if v, ok := value.(map[string]interface{}); ok {
+			for k, sub := range m {
+				if _, exists := v[k]; exists {
+					v[k] = maskValue(v[k], sub, found)
+				}
+			}
+		}
+	case []interface{}:
+		if v, ok := value.([]interface{}); ok {
+			for i := range m {
+				if i < len(v) {
+					v[i] = maskValue(v[i], m[i], found)
+				}
+			}
+		}
+	}
+	return value
+}
+
+func collectStrings(value interface{}, found map[string]struct{}) {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			found[v] = struct{}{}
+		}
+	case map[string]interface{}:
+		for _, sub := range v {
+			collectStrings(sub, found)
+		}
+	case []interface{}:
+		for _, sub := range v {
+			collectStrings(sub, found)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	. "github.com/onsi/gomega"
+)
+
+const sensitivePlanJSON = `{
+  "format_version": "1.0",
+  "variables": {
+    "password": {"value": "hunter2-secret"},
+    "region": {"value": "eu-west-1"}
+  },
+  "planned_values": {
+    "outputs": {
+      "token": {"sensitive": true, "value": "planned-token-value"}
+    },
+    "root_module": {
+      "resources": [{
+        "address": "random_password.db",
+        "values": {"length": 16, "result": "planned-result-value"},
+        "sensitive_values": {"result": true}
+      }]
+    }
+  },
+  "resource_changes": [{
+    "address": "random_password.db",
+    "change": {
+      "actions": ["create"],
+      "before": null,
+      "after": {"length": 16, "result": "planned-result-value", "keepers": {"a": "b"}},
+      "after_sensitive": {"result": true, "keepers": {}}
+    }
+  }],
+  "output_changes": {
+    "token": {
+      "actions": ["create"],
+      "before": null,
+      "after": "planned-token-value",
+      "after_sensitive": true
+    }
+  },
+  "configuration": {
+    "root_module": {
+      "variables": {
+        "password": {"sensitive": true},
+        "region": {}
+      }
+    }
+  }
+}`
+
+func TestMaskSensitivePlan(t *testing.T) {
+	g := NewWithT(t)
+
+	var plan tfjson.Plan
+	g.Expect(json.Unmarshal([]byte(sensitivePlanJSON), &plan)).To(Succeed())
+
+	values := MaskSensitivePlan(&plan)
+	g.Expect(values).To(ConsistOf("hunter2-secret", "planned-token-value", "planned-result-value"))
+
+	g.Expect(plan.Variables["password"].Value).To(Equal(SensitiveValueMask))
+	g.Expect(plan.Variables["region"].Value).To(Equal("eu-west-1"))
+	g.Expect(plan.OutputChanges["token"].After).To(Equal(SensitiveValueMask))
+	g.Expect(plan.PlannedValues.Outputs["token"].Value).To(Equal(SensitiveValueMask))
+
+	after := plan.ResourceChanges[0].Change.After.(map[string]interface{})
+	g.Expect(after["result"]).To(Equal(SensitiveValueMask))
+	g.Expect(after["keepers"]).To(Equal(map[string]interface{}{"a": "b"}))
+	g.Expect(plan.PlannedValues.RootModule.Resources[0].AttributeValues["result"]).To(Equal(SensitiveValueMask))
+
+	masked, err := json.Marshal(&plan)
+	g.Expect(err).To(BeNil())
+	for _, v := range values {
+		g.Expect(string(masked)).ToNot(ContainSubstring(v))
+	}
+}
+
+func TestScrubSensitiveValues(t *testing.T) {
+	g := NewWithT(t)
+
+	text := "  + result = \"planned-result-value\"\n  + length = 16"
+	g.Expect(ScrubSensitiveValues(text, []string{"planned-result-value", "16"})).
+		To(Equal("  + result = \"(sensitive value)\"\n  + length = 16"))
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// planFileStateEntry is the name of the entry in a saved plan file holding
+// the state the plan was created against.
+const planFileStateEntry = "tfstate"
+
+// StateMeta identifies a snapshot of a Terraform state.
+// The serial is incremented by Terraform every time the state is written.
+type StateMeta struct {
+	Lineage string `json:"lineage"`
+	Serial  uint64 `json:"serial"`
+}
+
+// ParseStateMeta reads the lineage and the serial of a state in JSON form.
+// An empty input is an absent state, and yields an empty StateMeta.
+func ParseStateMeta(data []byte) (StateMeta, error) {
+	var meta StateMeta
+	if len(bytes.TrimSpace(data)) == 0 {
+		return meta, nil
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("unable to parse state: %w", err)
+	}
+
+	return meta, nil
+}
+
+// PlanPriorStateMeta reads the lineage and the serial of the state
+// embedded in a saved plan file, i.e. the state the plan was computed against.
+func PlanPriorStateMeta(planFile []byte) (StateMeta, error) {
+	zr, err := zip.NewReader(bytes.NewReader(planFile), int64(len(planFile)))
+	if err != nil {
+		return StateMeta{}, fmt.Errorf("unable to read plan file: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if f.Name != planFileStateEntry {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return StateMeta{}, fmt.Errorf("unable to open the state of the plan file: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return StateMeta{}, fmt.Errorf("unable to read the state of the plan file: %w", err)
+		}
+
+		return ParseStateMeta(data)
+	}
+
+	// a plan created without a prior state
+	return StateMeta{}, nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func planFile(g *WithT, entries map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = w.Write([]byte(content))
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(zw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func TestParseStateMeta(t *testing.T) {
+	g := NewWithT(t)
+
+	meta, err := ParseStateMeta([]byte(`{"version": 4, "serial": 7, "lineage": "abc"}`))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(StateMeta{Lineage: "abc", Serial: 7}))
+
+	meta, err = ParseStateMeta([]byte("\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(StateMeta{}))
+
+	_, err = ParseStateMeta([]byte("not a state"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestPlanPriorStateMeta(t *testing.T) {
+	g := NewWithT(t)
+
+	meta, err := PlanPriorStateMeta(planFile(g, map[string]string{
+		"tfplan":  "plan",
+		"tfstate": `{"version": 4, "serial": 3, "lineage": "abc"}`,
+	}))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(StateMeta{Lineage: "abc", Serial: 3}))
+
+	meta, err = PlanPriorStateMeta(planFile(g, map[string]string{"tfplan": "plan"}))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meta).To(Equal(StateMeta{}))
+
+	_, err = PlanPriorStateMeta([]byte("not a zip"))
+	g.Expect(err).To(HaveOccurred())
+}
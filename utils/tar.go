@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Tar writes the directories, files and symlinks of dir as a gzipped tarball to w, with names relative to dir.
+// The paths for which skip returns true, given their slash-separated relative name, are left out with their content.
+func Tar(w io.Writer, dir string, skip func(name string) bool) error {
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if skip != nil && skip(name) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var linkname string
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			if linkname, err = os.Readlink(p); err != nil {
+				return err
+			}
+		case fi.IsDir(), fi.Mode().IsRegular():
+		default:
+			// sockets, devices and pipes have no content to reproduce a run with
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(fi, linkname)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("tar entry %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
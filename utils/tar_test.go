@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTar(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(dir, "envs", "prod", ".terraform", "providers", "registry.terraform.io"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "envs", "prod", "main.tf"), []byte(`module "vpc" { source = "../../modules/vpc" }`), 0644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "envs", "prod", ".terraform", "providers", "registry.terraform.io", "aws"), []byte("binary"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "envs", "prod", ".terraform", "terraform.tfstate"), []byte(`{"backend":{}}`), 0644)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(dir, "modules", "vpc"), 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "modules", "vpc", "main.tf"), []byte(`resource "aws_vpc" "main" {}`), 0644)).To(Succeed())
+	g.Expect(os.Symlink("../modules", filepath.Join(dir, "envs", "modules"))).To(Succeed())
+
+	var buf bytes.Buffer
+	g.Expect(Tar(&buf, dir, func(name string) bool {
+		return strings.HasSuffix(name, ".terraform/providers")
+	})).To(Succeed())
+
+	extracted := t.TempDir()
+	g.Expect(Untar(&buf, extracted, UntarLimits{})).To(Succeed())
+
+	main, err := os.ReadFile(filepath.Join(extracted, "envs", "prod", "main.tf"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(main)).To(ContainSubstring("../../modules/vpc"))
+	g.Expect(filepath.Join(extracted, "envs", "prod", ".terraform", "terraform.tfstate")).To(BeARegularFile())
+	g.Expect(filepath.Join(extracted, "envs", "modules", "vpc", "main.tf")).To(BeARegularFile())
+	g.Expect(filepath.Join(extracted, "envs", "prod", ".terraform", "providers")).ToNot(BeAnExistingFile())
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TruncateText returns s, cut at a line boundary to fit in max bytes with a marker of the truncation,
+// and true if it had to be truncated. No limit applies when max is not positive.
+func TruncateText(s string, max int64) (string, bool) {
+	if max <= 0 || int64(len(s)) <= max {
+		return s, false
+	}
+
+	marker := fmt.Sprintf("\n... Truncated: %d bytes exceed the maximum of %d bytes\n", len(s), max)
+	keep := max - int64(len(marker))
+	if keep < 0 {
+		keep = 0
+	}
+	cut := s[:keep]
+	if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+		cut = cut[:i]
+	}
+	return cut + marker, true
+}
+
+// FitData returns the entries of data fitting in max bytes, leaving out the largest ones first,
+// and the sorted keys of the entries left out. No limit applies when max is not positive.
+func FitData(data map[string][]byte, max int64) (map[string][]byte, []string) {
+	size := int64(0)
+	keys := make([]string, 0, len(data))
+	for k, v := range data {
+		size += int64(len(k) + len(v))
+		keys = append(keys, k)
+	}
+	if max <= 0 || size <= max {
+		return data, nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(data[keys[i]]) != len(data[keys[j]]) {
+			return len(data[keys[i]]) > len(data[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+
+	fitted := make(map[string][]byte, len(data))
+	for k, v := range data {
+		fitted[k] = v
+	}
+	var left []string
+	for _, k := range keys {
+		if size <= max {
+			break
+		}
+		size -= int64(len(k) + len(data[k]))
+		delete(fitted, k)
+		left = append(left, k)
+	}
+	sort.Strings(left)
+	return fitted, left
+}
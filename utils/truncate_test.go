@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTruncateText(t *testing.T) {
+	g := NewWithT(t)
+
+	s, truncated := TruncateText("short", 100)
+	g.Expect(s).To(Equal("short"))
+	g.Expect(truncated).To(BeFalse())
+
+	plan := strings.Repeat("  + resource \"null_resource\" \"a\" {}\n", 10)
+	s, truncated = TruncateText(plan, 200)
+	g.Expect(truncated).To(BeTrue())
+	g.Expect(len(s)).To(BeNumerically("<=", 200))
+	marker := "\n... Truncated: 360 bytes exceed the maximum of 200 bytes\n"
+	g.Expect(s).To(HaveSuffix(marker))
+	// the plan is cut at a line boundary
+	g.Expect(plan).To(HavePrefix(strings.TrimSuffix(s, marker) + "\n"))
+
+	s, truncated = TruncateText(plan, 0)
+	g.Expect(s).To(Equal(plan))
+	g.Expect(truncated).To(BeFalse())
+}
+
+func TestFitData(t *testing.T) {
+	g := NewWithT(t)
+
+	data := map[string][]byte{
+		"cluster_ca":  []byte(strings.Repeat("x", 50)),
+		"kubeconfig":  []byte(strings.Repeat("x", 100)),
+		"cluster_url": []byte("https://example.com"),
+	}
+
+	fitted, left := FitData(data, 1000)
+	g.Expect(fitted).To(Equal(data))
+	g.Expect(left).To(BeEmpty())
+
+	fitted, left = FitData(data, 100)
+	g.Expect(left).To(Equal([]string{"kubeconfig"}))
+	g.Expect(fitted).To(HaveLen(2))
+	g.Expect(data).To(HaveLen(3))
+
+	fitted, left = FitData(data, 10)
+	g.Expect(left).To(Equal([]string{"cluster_ca", "cluster_url", "kubeconfig"}))
+	g.Expect(fitted).To(BeEmpty())
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// UntarLimits bounds what an artifact may extract. Zero means no limit.
+type UntarLimits struct {
+	// MaxFiles is the maximum number of files and symlinks.
+	MaxFiles int64
+	// MaxSize is the maximum total size of the files, in bytes.
+	MaxSize int64
+}
+
+// Untar extracts the gzipped tarball r into dir. Unlike a plain extraction, it rejects entries
+// with absolute paths or `..` components, entries written through a symlink and symlinks
+// resolving outside of dir, as artifacts may come from repositories of semi-trusted tenants.
+func Untar(r io.Reader, dir string, limits UntarLimits) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("requires gzip-compressed body: %w", err)
+	}
+	tr := tar.NewReader(zr)
+
+	type symlink struct{ name, target, linkname string }
+	var (
+		files    int64
+		size     int64
+		symlinks []symlink
+	)
+	countFile := func(name string) error {
+		files++
+		if limits.MaxFiles > 0 && files > limits.MaxFiles {
+			return fmt.Errorf("tar entry %s exceeds the maximum of %d files", name, limits.MaxFiles)
+		}
+		return nil
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("tar error: %w", err)
+		}
+
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		name, err := untarEntryName(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			continue
+		}
+		target := filepath.Join(root, filepath.FromSlash(name))
+		if err := noSymlinkUnder(root, filepath.Dir(target)); err != nil {
+			return fmt.Errorf("tar entry %s: %w", hdr.Name, err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := noSymlinkUnder(root, target); err != nil {
+				return fmt.Errorf("tar entry %s: %w", hdr.Name, err)
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := countFile(hdr.Name); err != nil {
+				return err
+			}
+			n, err := untarFile(tr, target, hdr.FileInfo().Mode().Perm(), limits.MaxSize-size, limits.MaxSize > 0)
+			size += n
+			if err != nil {
+				return fmt.Errorf("tar entry %s: %w", hdr.Name, err)
+			}
+			if limits.MaxSize > 0 && size > limits.MaxSize {
+				return fmt.Errorf("tar entry %s exceeds the maximum size of %d bytes", hdr.Name, limits.MaxSize)
+			}
+		case tar.TypeSymlink:
+			if err := countFile(hdr.Name); err != nil {
+				return err
+			}
+			linkTarget := filepath.FromSlash(hdr.Linkname)
+			if filepath.IsAbs(linkTarget) || !isUnder(root, filepath.Join(filepath.Dir(target), linkTarget)) {
+				return fmt.Errorf("tar entry %s is a symlink to %s, outside of the artifact", hdr.Name, hdr.Linkname)
+			}
+			// symlinks are created once all the files are extracted, so that no entry is written through them
+			symlinks = append(symlinks, symlink{name: hdr.Name, target: target, linkname: linkTarget})
+		default:
+			return fmt.Errorf("tar entry %s has unsupported type %q", hdr.Name, hdr.Typeflag)
+		}
+	}
+
+	for _, link := range symlinks {
+		if err := noSymlinkUnder(root, filepath.Dir(link.target)); err != nil {
+			return fmt.Errorf("tar entry %s: %w", link.name, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(link.target), 0755); err != nil {
+			return err
+		}
+		// a directory extracted at the path of the symlink is not replaced
+		if err := os.Remove(link.target); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("tar entry %s: %w", link.name, err)
+		}
+		if err := os.Symlink(link.linkname, link.target); err != nil {
+			return err
+		}
+	}
+
+	// the targets of the symlinks are checked with all of them in place,
+	// as a symlink may go through other ones
+	for _, link := range symlinks {
+		resolved, err := filepath.EvalSymlinks(link.target)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("tar entry %s: %w", link.name, err)
+		}
+		if !isUnder(root, resolved) {
+			os.Remove(link.target)
+			return fmt.Errorf("tar entry %s is a symlink to %s, outside of the artifact", link.name, link.linkname)
+		}
+	}
+
+	return nil
+}
+
+// untarEntryName returns the cleaned, slash-separated, name of a tar entry,
+// or an error if it is absolute or has `..` components.
+func untarEntryName(name string) (string, error) {
+	if name == "" || strings.Contains(name, `\`) {
+		return "", fmt.Errorf("tar entry %q has an invalid name", name)
+	}
+	if path.IsAbs(name) {
+		return "", fmt.Errorf("tar entry %s has an absolute path", name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("tar entry %s has a path outside of the artifact", name)
+		}
+	}
+	return path.Clean(name), nil
+}
+
+// noSymlinkUnder returns an error if any existing path from root, excluded, to p is a symlink.
+func noSymlinkUnder(root, p string) error {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	current := root
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		fi, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", current)
+		}
+	}
+	return nil
+}
+
+// isUnder returns true if the path p is root or one of its descendants.
+func isUnder(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// untarFile writes the content of r to the file target and returns the number of bytes written.
+// When limited, it stops after reading one byte more than remaining.
+func untarFile(r io.Reader, target string, mode os.FileMode, remaining int64, limited bool) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return 0, err
+	}
+	// don't follow a symlink left at the path of the file
+	if fi, err := os.Lstat(target); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return 0, err
+		}
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	if limited {
+		r = io.LimitReader(r, remaining+1)
+	}
+	n, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return n, err
+	}
+	return n, f.Close()
+}
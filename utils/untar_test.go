@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type tarEntry struct {
+	name     string
+	content  string
+	linkname string
+	typeflag byte
+}
+
+func tarGz(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Typeflag: e.typeflag, Linkname: e.linkname, Size: int64(len(e.content))}
+		if hdr.Typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content[:hdr.Size])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUntar(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	err := Untar(tarGz(t,
+		tarEntry{name: "./", typeflag: tar.TypeDir},
+		tarEntry{name: "main.tf", content: "terraform {}"},
+		tarEntry{name: "modules/vpc/main.tf", content: "variable \"cidr\" {}"},
+		tarEntry{name: "current", linkname: "./modules/vpc", typeflag: tar.TypeSymlink},
+	), dir, UntarLimits{MaxFiles: 3, MaxSize: 64})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	content, err := os.ReadFile(filepath.Join(dir, "current", "main.tf"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(content)).To(Equal("variable \"cidr\" {}"))
+}
+
+func TestUntarRejectsEscapes(t *testing.T) {
+	for name, entries := range map[string][]tarEntry{
+		"absolute path":                 {{name: "/etc/passwd", content: "x"}},
+		"parent directory":              {{name: "modules/../../main.tf", content: "x"}},
+		"absolute symlink":              {{name: "passwd", linkname: "/etc/passwd", typeflag: tar.TypeSymlink}},
+		"relative symlink":              {{name: "modules/up", linkname: "../..", typeflag: tar.TypeSymlink}},
+		"written through a symlink":     {{name: "up", linkname: ".", typeflag: tar.TypeSymlink}, {name: "up/main.tf", content: "x"}},
+		"symlink through a symlink":     {{name: "a/b", linkname: "..", typeflag: tar.TypeSymlink}, {name: "c", linkname: "a/b/..", typeflag: tar.TypeSymlink}},
+		"hard link":                     {{name: "main.tf", content: "x"}, {name: "link", linkname: "main.tf", typeflag: tar.TypeLink}},
+		"more files than the maximum":   {{name: "a.tf", content: "x"}, {name: "b.tf", content: "x"}, {name: "c.tf", content: "x"}},
+		"larger files than the maximum": {{name: "a.tf", content: "0123456789"}, {name: "b.tf", content: "0123456789"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "artifact")
+
+			err := Untar(tarGz(t, entries...), dir, UntarLimits{MaxFiles: 2, MaxSize: 16})
+			g.Expect(err).To(HaveOccurred())
+
+			// nothing is written outside of the directory of the artifact
+			outside, err := os.ReadDir(parent)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(outside).To(HaveLen(1))
+		})
+	}
+}
+
+func TestUntarDoesNotFollowExistingSymlinks(t *testing.T) {
+	g := NewWithT(t)
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "artifact")
+	g.Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(parent, "secret"), []byte("secret"), 0644)).To(Succeed())
+	g.Expect(os.Symlink(filepath.Join(parent, "secret"), filepath.Join(dir, "main.tf"))).To(Succeed())
+	g.Expect(os.Symlink(parent, filepath.Join(dir, "modules"))).To(Succeed())
+
+	g.Expect(Untar(tarGz(t, tarEntry{name: "main.tf", content: "terraform {}"}), dir, UntarLimits{})).To(Succeed())
+	secret, err := os.ReadFile(filepath.Join(parent, "secret"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(secret)).To(Equal("secret"))
+
+	g.Expect(Untar(tarGz(t, tarEntry{name: "modules/secret", content: "x"}), dir, UntarLimits{})).ToNot(Succeed())
+}
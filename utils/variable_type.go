@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ValidateVariableValue checks that a value in JSON form conforms to the type constraint of a variable,
+// e.g. object({ name = string, replicas = optional(number) }), the way Terraform converts it when planning.
+// An empty type constraint accepts any value.
+func ValidateVariableValue(typeConstraint string, value []byte) error {
+	if strings.TrimSpace(typeConstraint) == "" {
+		return nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(typeConstraint), "type", hcl.InitialPos)
+	if diags.HasErrors() {
+		return fmt.Errorf("invalid type constraint %s: %w", typeConstraint, diags)
+	}
+	ty, defaults, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		return fmt.Errorf("invalid type constraint %s: %w", typeConstraint, diags)
+	}
+
+	implied, err := ctyjson.ImpliedType(value)
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	val, err := ctyjson.Unmarshal(value, implied)
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	if defaults != nil {
+		val = defaults.Apply(val)
+	}
+	// the errors of the conversion locate the offending attribute or element
+	_, err = convert.Convert(val, ty)
+	return err
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateVariableValue(t *testing.T) {
+	g := NewWithT(t)
+
+	const constraint = `object({
+    region = string
+    nodes  = list(object({
+      name     = string
+      replicas = optional(number)
+    }))
+  })`
+
+	g.Expect(ValidateVariableValue(constraint, []byte(`{"region": "eu-west-1", "nodes": [{"name": "a"}, {"name": "b", "replicas": 3}]}`))).To(Succeed())
+	// numbers and booleans convert to strings, as in Terraform
+	g.Expect(ValidateVariableValue(constraint, []byte(`{"region": 1, "nodes": []}`))).To(Succeed())
+
+	err := ValidateVariableValue(constraint, []byte(`{"nodes": []}`))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring(`attribute "region" is required`))
+
+	err = ValidateVariableValue(constraint, []byte(`{"region": "eu-west-1", "nodes": [{"name": "a"}, {"replicas": "three"}]}`))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring(`attribute "nodes": element 1`))
+
+	g.Expect(ValidateVariableValue("", []byte(`{"anything": true}`))).To(Succeed())
+	g.Expect(ValidateVariableValue("any", []byte(`[1, "two"]`))).To(Succeed())
+	g.Expect(ValidateVariableValue("strin", []byte(`"a"`))).ToNot(Succeed())
+}